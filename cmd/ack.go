@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/ack"
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var ackCmd = &cobra.Command{
+	Use:   "ack",
+	Short: "Record that you have read and acknowledged a plan",
+	Long: `Record your acknowledgment of a plan, e.g. a decision the whole team must
+read. Acknowledgments are kept per plan in .logosyncx/acks/<plan>.jsonl
+(committed with the project) and list who acknowledged and when; the plan
+itself is not modified. Acknowledging a plan twice keeps the first record.
+
+You are identified by git user.name unless --as is given. --name is matched
+like logos refer (filename, topic, or ID). List the plans someone has not
+acknowledged yet with logos ls --unacked-by <name> ("me" = git user.name).`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		as, _ := cmd.Flags().GetString("as")
+		return runAck(name, as, time.Now())
+	},
+}
+
+func init() {
+	ackCmd.Flags().StringP("name", "n", "", "Plan to acknowledge (exact or partial match)")
+	ackCmd.Flags().String("as", "", "Acknowledge as this user instead of git user.name")
+	_ = ackCmd.MarkFlagRequired("name")
+	rootCmd.AddCommand(ackCmd)
+}
+
+func runAck(name, as string, now time.Time) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	user, err := ackUser(root, as)
+	if err != nil {
+		return err
+	}
+
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	matches := matchPlans(plans, name)
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("no plan found matching %q", name)
+	case 1:
+	default:
+		return printPlanCandidates(matches, name, nil)
+	}
+	filename := matches[0].Filename
+
+	acks, added, err := ack.Record(root, filename, user, now.Truncate(time.Second))
+	if err != nil {
+		return fmt.Errorf("record acknowledgment: %w", err)
+	}
+	loc := displayLocation(cfg)
+	if added {
+		stageFiles(root, cfg, ack.Path(root, filename))
+		printSuccess("Acknowledged %s as %s", filename, user)
+	} else {
+		prev, _ := ack.Find(acks, user)
+		fmt.Printf("%s already acknowledged %s on %s.\n", user, filename, prev.At.In(loc).Format("2006-01-02 15:04"))
+	}
+
+	users := make([]string, len(acks))
+	for i, a := range acks {
+		users[i] = a.User
+	}
+	fmt.Printf("Acknowledged by (%d): %s\n", len(users), strings.Join(users, ", "))
+	return nil
+}
+
+// ackUser returns the user acknowledgments are recorded or checked for:
+// name, or git user.name when name is empty or "me".
+func ackUser(root, name string) (string, error) {
+	if name = strings.TrimSpace(name); name != "" && name != "me" {
+		return name, nil
+	}
+	user, err := gitutil.UserName(root)
+	if err != nil {
+		return "", fmt.Errorf("cannot tell who you are (%v) — pass a name explicitly", err)
+	}
+	return user, nil
+}
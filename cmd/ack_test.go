@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/ack"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+func TestAck_RecordsOncePerUser(t *testing.T) {
+	dir := setupInitedProject(t)
+	p := makeTestPlan("db-choice", nil, time.Now())
+	writePlanFileWithBody(t, dir, p)
+	filename := plan.FileName(p)
+
+	out := captureOutput(t, func() {
+		if err := runAck("db-choice", "alice", time.Now()); err != nil {
+			t.Fatalf("runAck: %v", err)
+		}
+		if err := runAck("db-choice", "Alice", time.Now()); err != nil {
+			t.Fatalf("runAck again: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Acknowledged "+filename+" as alice") || !strings.Contains(out, "already acknowledged") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+	acks, err := ack.Load(dir, filename)
+	if err != nil || len(acks) != 1 || acks[0].User != "alice" {
+		t.Errorf("acks = %v, %v; want one by alice", acks, err)
+	}
+}
+
+func TestAck_UnknownPlan(t *testing.T) {
+	setupInitedProject(t)
+	if err := runAck("nothing", "alice", time.Now()); err == nil {
+		t.Error("expected an error for an unknown plan")
+	}
+}
+
+func TestLS_UnackedBy(t *testing.T) {
+	dir := setupInitedProject(t)
+	read := makeTestPlan("read-decision", nil, time.Now())
+	unread := makeTestPlan("unread-decision", nil, time.Now())
+	unread.ID = "test02"
+	writePlanFileWithBody(t, dir, read)
+	writePlanFileWithBody(t, dir, unread)
+	if _, _, err := ack.Record(dir, plan.FileName(read), "alice", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{format: "table", unackedBy: "alice"}); err != nil {
+			t.Errorf("runLS: %v", err)
+		}
+	})
+	if !strings.Contains(out, "unread-decision") || strings.Count(out, "read-decision") != 1 {
+		t.Errorf("expected only the unacknowledged plan, got:\n%s", out)
+	}
+}
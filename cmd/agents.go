@@ -0,0 +1,257 @@
+package cmd
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+// briefFileName is the optional hand-written project brief under
+// .logosyncx/, copied verbatim into the agent context file.
+const briefFileName = "BRIEF.md"
+
+var agentsCmd = &cobra.Command{
+	Use:   "agents",
+	Short: "Generate the context file agents load automatically",
+	Long: `Maintain a Markdown file that agents load at the start of every session
+(e.g. .claude/context.md), so the context they need is there without a
+logos ls or refer round-trip.
+
+The file holds the project brief (.logosyncx/BRIEF.md, when present), the
+summary sections of every pinned plan, the plans you refer to most (see
+logos stats --most-used), and all open high-priority tasks.
+Pin the plans that should always be in front of an agent with
+logos agents pin.`,
+}
+
+var agentsRenderContextCmd = &cobra.Command{
+	Use:   "render-context",
+	Short: "Write the brief, pinned plans, and high-priority tasks to a file",
+	Long: `Write the agent context file: the project brief, the summary sections of
+pinned plans, and open high-priority tasks. The file is overwritten.
+
+--out defaults to context_file in config.json (relative to the project
+root). Set context_file to have logos sync, logos agents pin, and
+logos agents unpin regenerate the file so it never goes stale. Use
+--out - to print to stdout instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, _ := cmd.Flags().GetString("out")
+		return runRenderContext(out)
+	},
+}
+
+var agentsPinCmd = &cobra.Command{
+	Use:   "pin",
+	Short: "Pin a plan into the agent context file",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		return runPin(name, true)
+	},
+}
+
+var agentsUnpinCmd = &cobra.Command{
+	Use:   "unpin",
+	Short: "Remove a plan from the agent context file",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		return runPin(name, false)
+	},
+}
+
+func init() {
+	agentsRenderContextCmd.Flags().String("out", "", "File to write (default: context_file in config.json; - for stdout)")
+	for _, c := range []*cobra.Command{agentsPinCmd, agentsUnpinCmd} {
+		c.Flags().StringP("name", "n", "", "Plan name (partial match against the filename)")
+		_ = c.MarkFlagRequired("name")
+	}
+	agentsCmd.AddCommand(agentsRenderContextCmd, agentsPinCmd, agentsUnpinCmd)
+	rootCmd.AddCommand(agentsCmd)
+}
+
+// runRenderContext writes the agent context file to out, which is relative
+// to the working directory; empty uses cfg.ContextFile.
+func runRenderContext(out string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	if out == "-" {
+		_, err := fmt.Print(renderContext(root, cfg, time.Now()))
+		return err
+	}
+	if out == "" {
+		if cfg.ContextFile == "" {
+			return errors.New("--out is required when context_file is not set in config.json")
+		}
+		out = filepath.Join(root, cfg.ContextFile)
+	}
+	if err := writeContextFile(root, cfg, out); err != nil {
+		return err
+	}
+	printSuccess("Agent context written to %s", out)
+	return nil
+}
+
+// refreshContextFile regenerates cfg.ContextFile when it is set. Failures
+// are reported as a warning: the command that triggered the refresh has
+// already succeeded.
+func refreshContextFile(root string, cfg config.Config) {
+	if cfg.ContextFile == "" {
+		return
+	}
+	if err := writeContextFile(root, cfg, filepath.Join(root, cfg.ContextFile)); err != nil {
+		warnf("could not refresh %s: %v", cfg.ContextFile, err)
+	}
+}
+
+// writeContextFile renders the agent context file and writes it to path,
+// creating parent directories as needed.
+func writeContextFile(root string, cfg config.Config, path string) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(path, []byte(renderContext(root, cfg, time.Now())), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return nil
+}
+
+// renderContext returns the agent context file: the project brief, the
+// summary sections of pinned plans (newest first), the other plans you refer
+// to most, and open high-priority tasks that are not snoozed at now.
+// Unreadable plans and tasks are skipped.
+func renderContext(root string, cfg config.Config, now time.Time) string {
+	var b strings.Builder
+	b.WriteString("<!-- Generated by `logos agents render-context`; do not edit. Pin plans with `logos agents pin`. -->\n\n")
+	fmt.Fprintf(&b, "# %s context\n", cfg.Project)
+
+	if brief, err := os.ReadFile(filepath.Join(root, config.DirName, briefFileName)); err == nil {
+		b.WriteString("\n## Brief\n\n")
+		b.WriteString(strings.TrimSpace(string(brief)))
+		b.WriteString("\n")
+	}
+
+	b.WriteString("\n## Pinned plans\n")
+	plans, _ := plan.LoadAllWithOptions(root, planParseOptions(cfg))
+	var pinned []plan.Plan
+	for _, p := range plans {
+		if p.Pinned {
+			pinned = append(pinned, p)
+		}
+	}
+	slices.SortFunc(pinned, func(a, b plan.Plan) int {
+		return cmp.Or(cmp.Compare(planUnix(b), planUnix(a)), cmp.Compare(a.Filename, b.Filename))
+	})
+	if len(pinned) == 0 {
+		b.WriteString("\nNone.\n")
+	}
+	for _, p := range pinned {
+		fmt.Fprintf(&b, "\n### %s\n\n", p.Topic)
+		fmt.Fprintf(&b, "`%s`\n", p.Filename)
+		if summary := strings.TrimSpace(plan.ExtractSections(p.Body, cfg.Plans.SummarySections)); summary != "" {
+			b.WriteString("\n" + summary + "\n")
+		}
+	}
+
+	if frequent := frequentPlans(root, plans, now); len(frequent) > 0 {
+		b.WriteString("\n## Frequently referred plans\n\n")
+		for _, p := range frequent {
+			fmt.Fprintf(&b, "- %s (`%s`)\n", p.Topic, p.Filename)
+		}
+	}
+
+	b.WriteString("\n## Open high-priority tasks\n\n")
+	tasks, _ := task.ReadAllTaskIndex(root)
+	var urgent []task.TaskJSON
+	for _, t := range tasks {
+		if t.Priority == task.PriorityHigh && t.Status != task.StatusDone && !t.IsSnoozed(now) {
+			urgent = append(urgent, t)
+		}
+	}
+	slices.SortFunc(urgent, func(a, b task.TaskJSON) int {
+		return cmp.Or(cmp.Compare(a.Plan, b.Plan), cmp.Compare(a.Seq, b.Seq), cmp.Compare(a.ID, b.ID))
+	})
+	if len(urgent) == 0 {
+		b.WriteString("None.\n")
+	}
+	for _, t := range urgent {
+		fmt.Fprintf(&b, "- %s %s [%s] (plan: %s)\n", t.ID, t.Title, t.Status, t.Plan)
+	}
+	return b.String()
+}
+
+// planUnix returns p's date as a Unix time, or 0 when it has none.
+func planUnix(p plan.Plan) int64 {
+	if p.Date == nil {
+		return 0
+	}
+	return p.Date.Unix()
+}
+
+// runPin sets or clears the pinned flag of the plan matching name, then
+// rebuilds the plan index and refreshes the context file.
+func runPin(name string, pinned bool) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	p, err := findPlan(name, plans)
+	if err != nil {
+		return err
+	}
+	if p.Pinned == pinned {
+		if pinned {
+			fmt.Printf("%s is already pinned.\n", p.Filename)
+		} else {
+			fmt.Printf("%s is not pinned.\n", p.Filename)
+		}
+		return nil
+	}
+
+	p.Pinned = pinned
+	path, err := plan.Write(root, p)
+	if err != nil {
+		return fmt.Errorf("write plan: %w", err)
+	}
+	if _, err := index.RebuildWithOptions(root, planIndexOptions(cfg)); err != nil {
+		warnf("rebuild index: %v", err)
+	}
+	stageFiles(root, cfg, path, index.FilePath(root))
+	refreshContextFile(root, cfg)
+
+	if pinned {
+		printSuccess("Pinned %s", p.Filename)
+	} else {
+		printSuccess("Unpinned %s", p.Filename)
+	}
+	return nil
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// setupAgentsProject creates a project with a brief, one plan, and a high-
+// and a low-priority task under it, and returns the root and plan slug.
+func setupAgentsProject(t *testing.T) (string, string) {
+	t.Helper()
+	dir := setupInitedProject(t)
+	if err := os.WriteFile(filepath.Join(dir, ".logosyncx", "BRIEF.md"), []byte("We build the billing API.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writePlanFileWithBody(t, dir, plan.Plan{
+		ID:    "p1",
+		Topic: "auth refactor",
+		Date:  ptrTime(time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)),
+		Body:  "## Background\nTokens expire too fast.\n\n## Notes\nscratch\n",
+	})
+	plans, _ := plan.LoadAll(dir)
+	slug := strings.TrimSuffix(plans[0].Filename, ".md")
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Rotate keys", priority: "high"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Tidy docs", priority: "low"}); err != nil {
+		t.Fatal(err)
+	}
+	return dir, slug
+}
+
+func ptrTime(t time.Time) *time.Time { return &t }
+
+func TestRenderContext_IncludesBriefPinnedPlansAndHighPriorityTasks(t *testing.T) {
+	dir, slug := setupAgentsProject(t)
+	if err := runPin(slug, true); err != nil {
+		t.Fatalf("runPin: %v", err)
+	}
+	cfg, _ := config.Load(dir)
+	out := renderContext(dir, cfg, time.Now())
+	for _, want := range []string{"We build the billing API.", "### auth refactor", "Tokens expire too fast.", "Rotate keys"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in context, got:\n%s", want, out)
+		}
+	}
+	for _, unwanted := range []string{"scratch", "Tidy docs"} {
+		if strings.Contains(out, unwanted) {
+			t.Errorf("did not expect %q in context, got:\n%s", unwanted, out)
+		}
+	}
+}
+
+func TestRenderContext_UnpinnedPlanOmitted(t *testing.T) {
+	dir, _ := setupAgentsProject(t)
+	cfg, _ := config.Load(dir)
+	out := renderContext(dir, cfg, time.Now())
+	if strings.Contains(out, "auth refactor") {
+		t.Errorf("unpinned plan should not be rendered, got:\n%s", out)
+	}
+}
+
+func TestRunRenderContext_WritesOutFile(t *testing.T) {
+	dir, _ := setupAgentsProject(t)
+	out := filepath.Join(dir, ".claude", "context.md")
+	if err := runRenderContext(out); err != nil {
+		t.Fatalf("runRenderContext: %v", err)
+	}
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "Rotate keys") {
+		t.Errorf("unexpected context file:\n%s", data)
+	}
+}
+
+func TestRunRenderContext_RequiresOutOrConfig(t *testing.T) {
+	setupAgentsProject(t)
+	if err := runRenderContext(""); err == nil {
+		t.Error("expected error without --out or context_file")
+	}
+}
+
+func TestPinAndSync_RefreshContextFile(t *testing.T) {
+	dir, slug := setupAgentsProject(t)
+	cfg, _ := config.Load(dir)
+	cfg.ContextFile = ".claude/context.md"
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, ".claude", "context.md")
+
+	if err := runPin(slug, true); err != nil {
+		t.Fatal(err)
+	}
+	data, _ := os.ReadFile(path)
+	if !strings.Contains(string(data), "auth refactor") {
+		t.Fatalf("expected pin to refresh the context file, got:\n%s", data)
+	}
+
+	if err := os.Remove(path); err != nil {
+		t.Fatal(err)
+	}
+	captureOutput(t, func() {
+		if err := runSync("", false, false, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("expected sync to regenerate the context file: %v", err)
+	}
+}
+
+func TestPin_SetsFrontmatter(t *testing.T) {
+	dir, slug := setupAgentsProject(t)
+	if err := runPin(slug, true); err != nil {
+		t.Fatal(err)
+	}
+	plans, _ := plan.LoadAll(dir)
+	if !plans[0].Pinned {
+		t.Fatal("expected plan to be pinned")
+	}
+	if err := runPin(slug, false); err != nil {
+		t.Fatal(err)
+	}
+	plans, _ = plan.LoadAll(dir)
+	if plans[0].Pinned {
+		t.Error("expected plan to be unpinned")
+	}
+}
@@ -0,0 +1,332 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Move a plan out of the active index, keeping it restorable",
+	Long: `Move a plan from .logosyncx/plans/ to .logosyncx/plans/archive/, so it no
+longer appears in logos ls or search, and rebuild the plan index. Nothing is
+deleted: list archived plans and tasks with logos archive ls and bring them
+back with logos archive restore. logos gc archives stale plans the same way,
+and logos gc purge deletes the archive permanently.
+
+--name is matched like logos refer (filename, topic, or ID). The plan's
+tasks stay in .logosyncx/tasks/ unless --with-tasks is given, which moves
+them to .logosyncx/tasks-archive/ as logos task archive does.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		withTasks, _ := cmd.Flags().GetBool("with-tasks")
+		return runArchive(name, withTasks)
+	},
+}
+
+var archiveLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List archived plans and tasks",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		return runArchiveLs(asJSON)
+	},
+}
+
+var archiveRestoreCmd = &cobra.Command{
+	Use:   "restore",
+	Short: "Move an archived plan or task back into use",
+	Long: `Move an archived plan back to .logosyncx/plans/, or an archived task back to
+.logosyncx/tasks/<plan>/, and rebuild the index it belongs to.
+
+--name is matched against archived plans (filename, topic, or ID) and
+archived tasks (their directory name, or their "<plan>/<NNN-title>" path as
+shown by logos archive ls). It must select exactly one plan or task. Restoring fails when an
+active plan or task already has the same name.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		return runArchiveRestore(name)
+	},
+}
+
+func init() {
+	archiveCmd.Flags().StringP("name", "n", "", "Plan to archive (exact or partial match)")
+	archiveCmd.Flags().Bool("with-tasks", false, "Also archive the plan's tasks")
+	archiveLsCmd.Flags().Bool("json", false, "Output JSON (for agent consumption)")
+	archiveRestoreCmd.Flags().StringP("name", "n", "", "Archived plan or task to restore (exact or partial match)")
+	_ = archiveRestoreCmd.MarkFlagRequired("name")
+	archiveCmd.AddCommand(archiveLsCmd, archiveRestoreCmd)
+	rootCmd.AddCommand(archiveCmd)
+}
+
+func runArchive(name string, withTasks bool) error {
+	if strings.TrimSpace(name) == "" {
+		return fmt.Errorf("provide --name <plan> (or use logos archive ls / restore)")
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	matches := matchPlans(plans, name)
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("no plan found matching %q", name)
+	case 1:
+	default:
+		return printPlanCandidates(matches, name, nil)
+	}
+	p := matches[0]
+	slug := strings.TrimSuffix(p.Filename, ".md")
+
+	store := task.NewStore(root, &cfg)
+	tasks, err := store.List(task.Filter{PlanSlug: slug})
+	if err != nil {
+		warnf("%v", err)
+	}
+
+	dst, err := plan.Archive(root, p.Filename)
+	if err != nil {
+		return err
+	}
+	stageFiles(root, cfg, dst)
+	if _, err := index.RebuildWithOptions(root, planIndexOptions(cfg)); err != nil {
+		warnf("could not rebuild index (%v) — run `logos sync` to rebuild", err)
+	}
+	stageFiles(root, cfg, index.FilePath(root))
+	printSuccess("Archived plan %s to .logosyncx/plans/archive/", p.Filename)
+
+	switch {
+	case withTasks && len(tasks) > 0:
+		n, err := store.Archive(tasks)
+		if err != nil {
+			return fmt.Errorf("archive tasks: %w", err)
+		}
+		printSuccess("Archived %d task(s) to .logosyncx/tasks-archive/%s/", n, slug)
+	case len(tasks) > 0:
+		open := 0
+		for _, t := range tasks {
+			if t.Status != task.StatusDone {
+				open++
+			}
+		}
+		if open > 0 {
+			warnf("%d unfinished task(s) of %s remain in .logosyncx/tasks/ — pass --with-tasks to archive them too", open, slug)
+		}
+	}
+	printHint(fmt.Sprintf("Restore with: logos archive restore --name %s", slug))
+	return nil
+}
+
+// archivedPlanJSON is one plan in the logos archive ls --json output.
+type archivedPlanJSON struct {
+	Filename string   `json:"filename"`
+	ID       string   `json:"id"`
+	Topic    string   `json:"topic"`
+	Date     string   `json:"date"`
+	Tags     []string `json:"tags"`
+}
+
+// archivedTaskJSON is one task in the logos archive ls --json output. Path
+// is the name logos archive restore accepts.
+type archivedTaskJSON struct {
+	Path string `json:"path"`
+	task.TaskJSON
+}
+
+// loadArchivedPlans parses every plan in plans/archive/. Unreadable files
+// are reported as warnings.
+func loadArchivedPlans(root string) []plan.Plan {
+	files, err := loadArchivedPlanFilenames(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	var plans []plan.Plan
+	for _, f := range files {
+		p, err := plan.LoadFile(filepath.Join(plan.ArchiveDir(root), f))
+		if err != nil {
+			warnf("%s: %v", f, err)
+			continue
+		}
+		plans = append(plans, p)
+	}
+	return plans
+}
+
+func runArchiveLs(asJSON bool) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	plans := loadArchivedPlans(root)
+	tasks, err := task.NewStore(root, &cfg).ListArchived()
+	if err != nil {
+		warnf("%v", err)
+	}
+
+	if asJSON {
+		out := struct {
+			Plans []archivedPlanJSON `json:"plans"`
+			Tasks []archivedTaskJSON `json:"tasks"`
+		}{Plans: []archivedPlanJSON{}, Tasks: []archivedTaskJSON{}}
+		for _, p := range plans {
+			e := archivedPlanJSON{Filename: p.Filename, ID: p.ID, Topic: p.Topic, Tags: p.Tags}
+			if p.Date != nil {
+				e.Date = p.Date.Format("2006-01-02")
+			}
+			out.Plans = append(out.Plans, e)
+		}
+		for _, a := range tasks {
+			out.Tasks = append(out.Tasks, archivedTaskJSON{Path: a.Rel, TaskJSON: a.Task.ToJSON()})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+
+	if len(plans) == 0 && len(tasks) == 0 {
+		fmt.Println("The archive is empty.")
+		return nil
+	}
+	loc := displayLocation(cfg)
+	if len(plans) > 0 {
+		fmt.Printf("Archived plans (%d):\n", len(plans))
+		t := &textTable{headers: []string{"DATE", "TOPIC", "FILENAME"}, fitWidth: tableWidth(), shrink: []int{1}}
+		for _, p := range plans {
+			date := "-"
+			if p.Date != nil {
+				date = p.Date.In(loc).Format("2006-01-02")
+			}
+			t.addRow(date, p.Topic, p.Filename)
+		}
+		if err := t.render(os.Stdout); err != nil {
+			return err
+		}
+	}
+	if len(tasks) > 0 {
+		if len(plans) > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("Archived tasks (%d):\n", len(tasks))
+		t := &textTable{headers: []string{"STATUS", "TITLE", "PATH"}, fitWidth: tableWidth(), shrink: []int{1}}
+		for _, a := range tasks {
+			t.addRow(string(a.Task.Status), a.Task.Title, a.Rel)
+		}
+		if err := t.render(os.Stdout); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func runArchiveRestore(name string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+
+	plans := matchPlans(loadArchivedPlans(root), name)
+	archived, err := store.ListArchived()
+	if err != nil {
+		warnf("%v", err)
+	}
+	tasks := matchArchivedTasks(archived, name)
+
+	switch {
+	case len(plans)+len(tasks) == 0:
+		return fmt.Errorf("nothing in the archive matches %q (see logos archive ls)", name)
+	case len(plans)+len(tasks) > 1:
+		fmt.Fprintf(os.Stderr, "Multiple archived items match %q:\n\n", name)
+		for _, p := range plans {
+			fmt.Fprintf(os.Stderr, "  plan  %s  (topic: %s)\n", p.Filename, p.Topic)
+		}
+		for _, a := range tasks {
+			fmt.Fprintf(os.Stderr, "  task  %s  (title: %s)\n", a.Rel, a.Task.Title)
+		}
+		fmt.Fprintln(os.Stderr)
+		return fmt.Errorf("use a more specific name to select one plan or task")
+	case len(plans) == 1:
+		dst, err := plan.Restore(root, plans[0].Filename)
+		if err != nil {
+			return err
+		}
+		stageFiles(root, cfg, dst)
+		if _, err := index.RebuildWithOptions(root, planIndexOptions(cfg)); err != nil {
+			warnf("could not rebuild index (%v) — run `logos sync` to rebuild", err)
+		}
+		stageFiles(root, cfg, index.FilePath(root))
+		printSuccess("Restored plan %s", plans[0].Filename)
+		if slices.ContainsFunc(archived, func(a task.ArchivedTask) bool {
+			return a.Task.Plan == strings.TrimSuffix(plans[0].Filename, ".md")
+		}) {
+			printHint("Its archived tasks stay archived; restore each with logos archive restore --name <plan>/<task>.")
+		}
+	default:
+		dst, err := store.Restore(tasks[0].Rel)
+		if err != nil {
+			return err
+		}
+		rel, _ := relPath(root, dst)
+		printSuccess("Restored task %s", rel)
+	}
+	return nil
+}
+
+// matchArchivedTasks returns the archived tasks matching name: an exact
+// (case-insensitive) match on the "<plan>/<NNN-title>" path or directory
+// name wins; otherwise every task whose directory name contains name, or
+// whose path does when name holds a "/".
+func matchArchivedTasks(tasks []task.ArchivedTask, name string) []task.ArchivedTask {
+	lower := strings.ToLower(filepath.ToSlash(name))
+	var exact, partial []task.ArchivedTask
+	for _, a := range tasks {
+		rel := filepath.ToSlash(a.Rel)
+		target := filepath.Base(rel)
+		if strings.Contains(lower, "/") {
+			target = rel
+		}
+		switch {
+		case strings.EqualFold(rel, lower) || strings.EqualFold(filepath.Base(rel), lower):
+			exact = append(exact, a)
+		case strings.Contains(strings.ToLower(target), lower):
+			partial = append(partial, a)
+		}
+	}
+	if len(exact) > 0 {
+		return exact
+	}
+	return partial
+}
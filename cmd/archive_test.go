@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+func TestArchive_PlanWithTasksAndRestore(t *testing.T) {
+	dir, slug := setupExportProject(t)
+
+	captureOutput(t, func() {
+		if err := runArchive("auth-design", true); err != nil {
+			t.Fatalf("runArchive: %v", err)
+		}
+	})
+	if _, err := os.Stat(filepath.Join(plan.ArchiveDir(dir), slug+".md")); err != nil {
+		t.Errorf("expected the plan in plans/archive/: %v", err)
+	}
+	if entries, _ := index.ReadAll(dir); len(entries) != 0 {
+		t.Errorf("archived plan should leave the index, got %+v", entries)
+	}
+	if tasks := loadAllTasks(t, dir); len(tasks) != 0 {
+		t.Errorf("--with-tasks should archive the plan's tasks, got %d active", len(tasks))
+	}
+
+	out := captureOutput(t, func() {
+		if err := runArchiveLs(true); err != nil {
+			t.Fatalf("runArchiveLs: %v", err)
+		}
+	})
+	var listed struct {
+		Plans []archivedPlanJSON `json:"plans"`
+		Tasks []archivedTaskJSON `json:"tasks"`
+	}
+	if err := json.Unmarshal([]byte(out), &listed); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(listed.Plans) != 1 || len(listed.Tasks) != 1 || !strings.HasPrefix(listed.Tasks[0].Path, slug+string(filepath.Separator)) {
+		t.Fatalf("unexpected archive listing: %+v", listed)
+	}
+
+	captureOutput(t, func() {
+		if err := runArchiveRestore("auth-design"); err != nil {
+			t.Fatalf("restore plan: %v", err)
+		}
+		if err := runArchiveRestore("add-jwt"); err != nil {
+			t.Fatalf("restore task: %v", err)
+		}
+	})
+	if entries, _ := index.ReadAll(dir); len(entries) != 1 {
+		t.Errorf("restored plan should be back in the index, got %d entries", len(entries))
+	}
+	if tasks := loadAllTasks(t, dir); len(tasks) != 1 {
+		t.Errorf("restored task should be active again, got %d", len(tasks))
+	}
+}
+
+func TestArchive_PlanKeepsTasksAndWarns(t *testing.T) {
+	dir, _ := setupExportProject(t)
+	stderr := captureStderr(t, func() {
+		captureOutput(t, func() {
+			if err := runArchive("auth-design", false); err != nil {
+				t.Fatalf("runArchive: %v", err)
+			}
+		})
+	})
+	if !strings.Contains(stderr, "1 unfinished task(s)") {
+		t.Errorf("expected a warning about the remaining task, got %q", stderr)
+	}
+	if tasks := loadAllTasks(t, dir); len(tasks) != 1 {
+		t.Errorf("tasks should stay active without --with-tasks, got %d", len(tasks))
+	}
+}
+
+func TestTaskArchive(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "First", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Second", priority: "medium", dependsOn: []int{1}}); err != nil {
+		t.Fatal(err)
+	}
+
+	stderr := captureStderr(t, func() {
+		captureOutput(t, func() {
+			if err := runTaskArchive("first", ""); err != nil {
+				t.Fatalf("runTaskArchive: %v", err)
+			}
+		})
+	})
+	if !strings.Contains(stderr, "002-second depends on this task") {
+		t.Errorf("expected a warning about the dependent task, got %q", stderr)
+	}
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 || tasks[0].Title != "Second" {
+		t.Errorf("expected only Second to remain, got %+v", tasks)
+	}
+}
+
+func TestArchiveRestore_Ambiguous(t *testing.T) {
+	dir := setupInitedProject(t)
+	for i, topic := range []string{"cache-a", "cache-b"} {
+		p := makeTestPlan(topic, nil, time.Now())
+		p.ID = []string{"c1", "c2"}[i]
+		writePlanFileWithBody(t, dir, p)
+		if _, err := plan.Archive(dir, plan.FileName(p)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	captureStderr(t, func() {
+		if err := runArchiveRestore("cache"); err == nil {
+			t.Error("expected an error for an ambiguous name")
+		}
+	})
+}
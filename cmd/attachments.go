@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// attachmentsDir is where non-Markdown files belong under .logosyncx/.
+const attachmentsDir = "attachments"
+
+// lfsAttributesRule is the .gitattributes line logos doctor --fix-lfs adds
+// when attachments.lfs is enabled.
+const lfsAttributesRule = ".logosyncx/attachments/** filter=lfs diff=lfs merge=lfs -text"
+
+// attachmentProblem is a file under .logosyncx/ that breaks the attachment
+// policy: too large, or binary outside attachments/.
+type attachmentProblem struct {
+	rel    string // path relative to the project root
+	detail string
+}
+
+// findAttachmentProblems walks .logosyncx/ and returns every attachment
+// larger than attachments.max_size_kb and every binary file outside
+// attachments/. logos's own Markdown and JSON files are not attachments and
+// are skipped; files stored with Git LFS are exempt. When include is
+// non-nil, only files whose slash-separated project-relative path it
+// accepts are considered.
+func findAttachmentProblems(root string, cfg config.Config, include func(rel string) bool) []attachmentProblem {
+	limit := cfg.Attachments.MaxBytes()
+	isRepo := gitutil.IsRepo(root)
+	attachPrefix := filepath.Join(config.DirName, attachmentsDir) + string(filepath.Separator)
+
+	var problems []attachmentProblem
+	_ = filepath.WalkDir(filepath.Join(root, config.DirName), func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || isLogosTextFile(path) {
+			return nil
+		}
+		rel, _ := relPath(root, path)
+		if include != nil && !include(filepath.ToSlash(rel)) {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return nil
+		}
+		var detail string
+		switch {
+		case info.Size() > limit:
+			detail = fmt.Sprintf("%s exceeds attachments.max_size_kb (%d)", formatBytes(info.Size()), limit/1024)
+		case !strings.HasPrefix(rel, attachPrefix) && isBinaryFile(path):
+			detail = "binary file outside .logosyncx/attachments/"
+		default:
+			return nil
+		}
+		if isRepo {
+			if lfs, _ := gitutil.IsLFSTracked(root, filepath.ToSlash(rel)); lfs {
+				return nil
+			}
+		}
+		problems = append(problems, attachmentProblem{rel: rel, detail: detail})
+		return nil
+	})
+	return problems
+}
+
+// isLogosTextFile reports whether path is one of the Markdown, JSON, or
+// JSONL files logos itself writes.
+func isLogosTextFile(path string) bool {
+	switch filepath.Ext(path) {
+	case ".md", ".json", ".jsonl":
+		return true
+	}
+	return false
+}
+
+// isBinaryFile reports whether the file at path looks binary: a NUL byte in
+// its first 8000 bytes, the heuristic git itself uses.
+func isBinaryFile(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	buf := make([]byte, 8000)
+	n, _ := f.Read(buf)
+	return bytes.IndexByte(buf[:n], 0) >= 0
+}
+
+// hasLFSRule reports whether the project's .gitattributes contains
+// lfsAttributesRule.
+func hasLFSRule(root string) bool {
+	data, err := os.ReadFile(filepath.Join(root, ".gitattributes"))
+	if err != nil {
+		return false
+	}
+	for _, line := range strings.Split(string(data), "\n") {
+		if strings.TrimSpace(line) == lfsAttributesRule {
+			return true
+		}
+	}
+	return false
+}
+
+// addLFSRule appends lfsAttributesRule to the project's .gitattributes,
+// creating the file if needed.
+func addLFSRule(root string) error {
+	path := filepath.Join(root, ".gitattributes")
+	data, err := os.ReadFile(path)
+	if err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	if len(data) > 0 && !bytes.HasSuffix(data, []byte("\n")) {
+		data = append(data, '\n')
+	}
+	data = append(data, lfsAttributesRule+"\n"...)
+	return os.WriteFile(path, data, 0o644)
+}
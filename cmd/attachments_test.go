@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+func TestFindAttachmentProblems(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Attachments.MaxSizeKB = 1
+
+	attach := filepath.Join(dir, ".logosyncx", "attachments")
+	os.MkdirAll(attach, 0o755)
+	os.WriteFile(filepath.Join(attach, "small.png"), []byte("\x89PNG\x00\x01"), 0o644)
+	os.WriteFile(filepath.Join(attach, "big.log"), make([]byte, 2048), 0o644)
+	os.WriteFile(filepath.Join(dir, ".logosyncx", "plans", "shot.png"), []byte("\x89PNG\x00\x01"), 0o644)
+
+	got := map[string]string{}
+	for _, p := range findAttachmentProblems(dir, cfg, nil) {
+		got[filepath.ToSlash(p.rel)] = p.detail
+	}
+	if len(got) != 2 {
+		t.Fatalf("expected 2 problems, got %v", got)
+	}
+	if !strings.Contains(got[".logosyncx/attachments/big.log"], "exceeds attachments.max_size_kb (1)") {
+		t.Errorf("expected size problem for big.log, got %v", got)
+	}
+	if !strings.Contains(got[".logosyncx/plans/shot.png"], "binary file outside") {
+		t.Errorf("expected binary problem for shot.png, got %v", got)
+	}
+}
+
+func TestDoctor_FixLFSAddsRuleOnce(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Attachments.LFS = true
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	os.WriteFile(filepath.Join(dir, ".gitattributes"), []byte("*.sh text eol=lf"), 0o644)
+
+	out := captureOutput(t, func() {
+		if err := runDoctor(false, false, false, false); err != nil {
+			t.Fatalf("runDoctor: %v", err)
+		}
+	})
+	if !strings.Contains(out, "has no LFS rule") {
+		t.Errorf("expected missing-rule report, got: %q", out)
+	}
+
+	for range 2 {
+		captureOutput(t, func() {
+			if err := runDoctor(false, true, false, false); err != nil {
+				t.Fatalf("runDoctor --fix-lfs: %v", err)
+			}
+		})
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, ".gitattributes"))
+	if want := "*.sh text eol=lf\n" + lfsAttributesRule + "\n"; string(data) != want {
+		t.Errorf(".gitattributes = %q, want %q", data, want)
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// mentionToken matches candidate task IDs and plan filenames in prose.
+var mentionToken = regexp.MustCompile(`[\w.-]+`)
+
+// autoLinkResult counts the links logos sync --auto-link added.
+type autoLinkResult struct {
+	Links int `json:"links"` // plan↔task pairs newly linked
+	Plans int `json:"plans"` // plan files updated
+	Tasks int `json:"tasks"` // task files updated
+}
+
+// mentions returns the set of tokens in body that may name a task ID or a
+// plan. Plan references are normalised to their slug, so "20260101-x.md",
+// ".logosyncx/plans/20260101-x.md" and "20260101-x" all yield "20260101-x".
+func mentions(body string) map[string]bool {
+	set := map[string]bool{}
+	for _, tok := range mentionToken.FindAllString(body, -1) {
+		tok = strings.TrimRight(tok, ".")
+		set[tok] = true
+		set[strings.TrimSuffix(tok, ".md")] = true
+	}
+	return set
+}
+
+// runAutoLink links plans and tasks whose bodies mention each other: a plan
+// that mentions a task ID, or a task that mentions a plan filename, gets the
+// task's ID added to the plan's related_tasks and the plan's filename added
+// to the task's related_plans. Mentions of a task's own plan are ignored —
+// the plan field already links them. Existing links are never removed.
+func runAutoLink(root string, cfg config.Config, store *task.Store) (autoLinkResult, error) {
+	var res autoLinkResult
+
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	tasks, err := store.List(task.Filter{})
+	if err != nil {
+		return res, fmt.Errorf("load tasks: %w", err)
+	}
+
+	planBySlug := make(map[string]*plan.Plan, len(plans))
+	for i := range plans {
+		planBySlug[strings.TrimSuffix(plans[i].Filename, ".md")] = &plans[i]
+	}
+	taskByID := make(map[string]*task.Task, len(tasks))
+	for _, t := range tasks {
+		if t.ID != "" {
+			taskByID[t.ID] = t
+		}
+	}
+
+	newTasks := map[string][]string{} // plan filename → task IDs to add
+	newPlans := map[string][]string{} // task DirPath → plan filenames to add
+	seen := map[[2]string]bool{}
+	link := func(p *plan.Plan, t *task.Task) {
+		if t.Plan == strings.TrimSuffix(p.Filename, ".md") {
+			return
+		}
+		if slices.Contains(p.RelatedTasks, t.ID) && slices.Contains(t.RelatedPlans, p.Filename) {
+			return
+		}
+		pair := [2]string{p.Filename, t.DirPath}
+		if seen[pair] {
+			return
+		}
+		seen[pair] = true
+		res.Links++
+		if !slices.Contains(p.RelatedTasks, t.ID) {
+			newTasks[p.Filename] = append(newTasks[p.Filename], t.ID)
+		}
+		if !slices.Contains(t.RelatedPlans, p.Filename) {
+			newPlans[t.DirPath] = append(newPlans[t.DirPath], p.Filename)
+		}
+	}
+
+	for i := range plans {
+		for tok := range mentions(plans[i].Body) {
+			if t, ok := taskByID[tok]; ok {
+				link(&plans[i], t)
+			}
+		}
+	}
+	for _, t := range tasks {
+		for tok := range mentions(t.Body) {
+			if p, ok := planBySlug[tok]; ok {
+				link(p, t)
+			}
+		}
+	}
+
+	for i := range plans {
+		p := &plans[i]
+		ids := newTasks[p.Filename]
+		// A frozen plan keeps its related_tasks; the tasks still link back.
+		if len(ids) == 0 || p.Frozen {
+			continue
+		}
+		p.RelatedTasks = append(p.RelatedTasks, ids...)
+		data, err := plan.Marshal(*p)
+		if err != nil {
+			return res, fmt.Errorf("marshal plan %s: %w", p.Filename, err)
+		}
+		path := filepath.Join(plan.PlansDir(root), p.Filename)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return res, fmt.Errorf("write plan %s: %w", p.Filename, err)
+		}
+		stageFiles(root, cfg, path)
+		res.Plans++
+	}
+
+	n, err := store.AddRelatedPlans(newPlans)
+	res.Tasks = n
+	return res, err
+}
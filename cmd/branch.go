@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/spf13/cobra"
+)
+
+// workingBranch returns the branch checked out in the working directory —
+// the code checkout, even when plans live in a separate storage directory.
+func workingBranch() (string, error) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return "", err
+	}
+	return gitutil.CurrentBranch(cwd)
+}
+
+// recordBranch returns the branch a new plan or task is scoped to under
+// git.record_branch, or "" when it is off, the branch is a trunk branch,
+// or there is no branch (outside git, or a detached HEAD).
+func recordBranch(cfg config.Config) string {
+	if !cfg.Git.RecordBranch {
+		return ""
+	}
+	branch, err := workingBranch()
+	if err != nil {
+		return ""
+	}
+	return cfg.Git.ScopeBranch(branch)
+}
+
+// branchFlags adds --branch and --current-branch to a listing command.
+func branchFlags(c *cobra.Command, what string) {
+	c.Flags().String("branch", "", fmt.Sprintf("Show only %s created on this git branch, plus unscoped ones", what))
+	c.Flags().Bool("current-branch", false, "Like --branch with the branch checked out here")
+}
+
+// branchFilter returns the branch selected by --branch or --current-branch,
+// or "" when neither is given.
+func branchFilter(c *cobra.Command) (string, error) {
+	branch, _ := c.Flags().GetString("branch")
+	current, _ := c.Flags().GetBool("current-branch")
+	if !current {
+		return branch, nil
+	}
+	if branch != "" {
+		return "", errors.New("--branch and --current-branch cannot be used together")
+	}
+	branch, err := workingBranch()
+	if err != nil {
+		return "", fmt.Errorf("--current-branch: %w", err)
+	}
+	return branch, nil
+}
+
+// onBranch accepts the entries created on branch, plus those not scoped to
+// any branch.
+func onBranch(branch string) func(index.Entry) bool {
+	return func(e index.Entry) bool { return e.Branch == "" || e.Branch == branch }
+}
@@ -0,0 +1,143 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+)
+
+// gitCheckout runs git checkout with args in dir.
+func gitCheckout(t *testing.T, dir string, args ...string) {
+	t.Helper()
+	c := exec.Command("git", append([]string{"checkout", "-q"}, args...)...)
+	c.Dir = dir
+	if out, err := c.CombinedOutput(); err != nil {
+		t.Fatalf("git checkout %v: %v\n%s", args, err, out)
+	}
+}
+
+// onlyPlanSlug returns the slug of the single plan saved in dir.
+func onlyPlanSlug(t *testing.T, dir string) string {
+	t.Helper()
+	entries, err := os.ReadDir(filepath.Join(dir, ".logosyncx", "plans"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if strings.HasSuffix(e.Name(), ".md") {
+			return strings.TrimSuffix(e.Name(), ".md")
+		}
+	}
+	t.Fatal("no plan saved")
+	return ""
+}
+
+func TestBranchScoping_SaveAndLS(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	t.Chdir(dir)
+	if err := runInit(true); err != nil {
+		t.Fatal(err)
+	}
+	gitCheckout(t, dir, "-B", "main")
+	cfg, _ := config.Load(dir)
+	cfg.Git.RecordBranch = true
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	save := func(topic string) {
+		t.Helper()
+		if err := runSave(topic, nil, "", "", nil, nil, nil, false, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	save("Trunk plan")
+	gitCheckout(t, dir, "-b", "feature/auth")
+	save("Auth plan")
+	gitCheckout(t, dir, "-b", "feature/billing")
+	save("Billing plan")
+
+	list := func(branch string) map[string]string {
+		t.Helper()
+		out := captureOutput(t, func() {
+			if err := runLS(lsOptions{asJSON: true, branch: branch}); err != nil {
+				t.Fatal(err)
+			}
+		})
+		var entries []index.Entry
+		if err := json.Unmarshal([]byte(out), &entries); err != nil {
+			t.Fatalf("invalid JSON: %v\n%s", err, out)
+		}
+		got := map[string]string{}
+		for _, e := range entries {
+			got[e.Topic] = e.Branch
+		}
+		return got
+	}
+
+	all := list("")
+	if all["Trunk plan"] != "" || all["Auth plan"] != "feature/auth" || all["Billing plan"] != "feature/billing" {
+		t.Errorf("recorded branches = %v", all)
+	}
+	got := list("feature/auth")
+	if _, ok := got["Billing plan"]; ok || len(got) != 2 {
+		t.Errorf("ls --branch feature/auth = %v, want Auth plan and Trunk plan", got)
+	}
+}
+
+func TestBranchScoping_TaskLS(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	t.Chdir(dir)
+	if err := runInit(true); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := config.Load(dir)
+	cfg.Git.RecordBranch = true
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSave("Plan", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	slug := onlyPlanSlug(t, dir)
+
+	gitCheckout(t, dir, "-b", "feature/auth")
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Auth task", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+	gitCheckout(t, dir, "-b", "feature/billing")
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Billing task", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+	todo := filepath.Join(t.TempDir(), "todo.md")
+	if err := os.WriteFile(todo, []byte("- [ ] Imported billing task\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	captureOutput(t, func() {
+		if err := runTaskImport(dir, slug, "markdown", todo, nil, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	out := captureOutput(t, func() {
+		if err := runTaskLS(taskLSOptions{asJSON: true, branch: "feature/auth"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var entries []task.TaskJSON
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(entries) != 1 || entries[0].Title != "Auth task" || entries[0].Branch != "feature/auth" {
+		t.Errorf("task ls --branch feature/auth = %+v", entries)
+	}
+}
@@ -0,0 +1,535 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/bundle"
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/internal/version"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export or import the whole knowledge base as one portable file",
+	Long: `Move context between repositories as a single archive.
+
+  logos bundle export [--output <file>]
+  logos bundle import <file> [--dry-run]
+
+A bundle is a gzip-compressed tar holding manifest.json (format version,
+logos version, project name, counts) and every file under .logosyncx/:
+plans, tasks, knowledge, attachments, templates, indexes, and config.`,
+}
+
+var bundleExportCmd = &cobra.Command{
+	Use:   "export",
+	Args:  cobra.NoArgs,
+	Short: "Write .logosyncx/ to a bundle file",
+	Long: `Write every file under .logosyncx/ to a bundle. Without --output the
+bundle is named <project>-<YYYYMMDD>.logos.tar.gz in the current directory.
+Lock files and temp files from interrupted writes are left out.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		output, _ := cmd.Flags().GetString("output")
+		return runBundleExport(output, time.Now())
+	},
+}
+
+var bundleImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Args:  cobra.ExactArgs(1),
+	Short: "Merge a bundle into this project",
+	Long: `Merge the plans, tasks, knowledge files, and attachments of a bundle into
+this project, then rebuild both indexes. Config, templates, indexes, USAGE.md,
+and archived tasks in the bundle are not imported.
+
+Collisions are resolved without overwriting anything:
+
+  - A plan whose id and topic match an existing plan is already present and
+    is skipped; so is a task whose id and title match one in the same plan.
+  - A plan whose filename is taken gets a "-2" (or "-3", ...) suffix, and
+    its tasks directory, related and depends_on links, and the plan field
+    of its tasks follow the new name.
+  - A plan or task whose id is taken by a different plan or task gets a
+    new id; related_tasks links in imported plans follow it.
+  - Tasks added to a plan that already has tasks are numbered after the
+    existing ones, and their depends_on seqs follow.
+  - A knowledge file or attachment whose path is taken by a different file
+    gets a numeric suffix; identical files are skipped.
+
+Use --dry-run to list what would be imported without writing anything.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		return runBundleImport(args[0], dryRun)
+	},
+}
+
+func init() {
+	bundleExportCmd.Flags().StringP("output", "o", "", "Bundle file to write (default <project>-<YYYYMMDD>.logos.tar.gz)")
+	bundleImportCmd.Flags().Bool("dry-run", false, "Show what would be imported without writing any file")
+	bundleCmd.AddCommand(bundleExportCmd)
+	bundleCmd.AddCommand(bundleImportCmd)
+	rootCmd.AddCommand(bundleCmd)
+}
+
+// --- export ------------------------------------------------------------------
+
+func runBundleExport(output string, now time.Time) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	files, err := bundle.Collect(filepath.Join(root, config.DirName))
+	if err != nil {
+		return fmt.Errorf("read %s: %w", config.DirName, err)
+	}
+
+	m := bundle.Manifest{
+		LogosVersion: version.Version,
+		Project:      cfg.Project,
+		CreatedAt:    now.UTC().Truncate(time.Second),
+	}
+	for name := range files {
+		switch {
+		case isBundlePlan(name):
+			m.Plans++
+		case isBundleTask(name):
+			m.Tasks++
+		case strings.HasPrefix(name, "knowledge/") && strings.HasSuffix(name, ".md"):
+			m.Knowledge++
+		}
+	}
+
+	if output == "" {
+		output = fmt.Sprintf("%s-%s.logos.tar.gz", markdown.Slugify(cfg.Project), now.Format("20060102"))
+	}
+	var buf bytes.Buffer
+	if err := bundle.Write(&buf, m, files); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	if err := os.WriteFile(output, buf.Bytes(), 0o644); err != nil {
+		return fmt.Errorf("write bundle: %w", err)
+	}
+	printSuccess("Exported %d plan(s), %d task(s), and %d knowledge file(s) to %s",
+		m.Plans, m.Tasks, m.Knowledge, output)
+	return nil
+}
+
+// isBundlePlan reports whether a bundle path is a plan file, live or
+// archived.
+func isBundlePlan(name string) bool {
+	dir := path.Dir(name)
+	return (dir == "plans" || dir == "plans/archive") && strings.HasSuffix(name, ".md")
+}
+
+// isBundleTask reports whether a bundle path is a live task's TASK.md.
+func isBundleTask(name string) bool {
+	parts := strings.Split(name, "/")
+	return len(parts) == 4 && parts[0] == "tasks" && parts[3] == "TASK.md"
+}
+
+// --- import ------------------------------------------------------------------
+
+// importedPlan is a bundle plan chosen for import.
+type importedPlan struct {
+	src     string // bundle path
+	dest    string // destination path relative to .logosyncx/
+	data    []byte
+	p       plan.Plan
+	changed bool // frontmatter must be rewritten
+}
+
+// importedTask is a bundle task chosen for import.
+type importedTask struct {
+	srcDir  string // bundle directory, e.g. tasks/<slug>/001-x/
+	srcSlug string // plan slug in the bundle
+	destDir string // destination directory relative to .logosyncx/
+	data    []byte
+	t       task.Task
+	changed bool
+}
+
+// bundleImport holds the state of one bundle import.
+type bundleImport struct {
+	root  string
+	store *task.Store
+	b     *bundle.Bundle
+	dry   bool
+
+	plans   []*importedPlan
+	tasks   []*importedTask
+	slugMap map[string]string      // bundle plan slug → destination slug
+	taskIDs map[string]string      // bundle task ID → new ID
+	seqMap  map[string]map[int]int // bundle plan slug → old seq → new seq
+	files   map[string][]byte      // other destination paths → contents
+	skipped int
+	written []string // absolute paths written, for git add
+}
+
+func runBundleImport(file string, dryRun bool) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	f, err := os.Open(file)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	b, err := bundle.Read(f)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Bundle: %s (exported %s by logos %s)\n", dashIfEmpty(b.Manifest.Project),
+		b.Manifest.CreatedAt.In(displayLocation(cfg)).Format("2006-01-02 15:04"), dashIfEmpty(b.Manifest.LogosVersion))
+
+	im := &bundleImport{
+		root:    root,
+		store:   task.NewStore(root, &cfg),
+		b:       b,
+		dry:     dryRun,
+		slugMap: map[string]string{},
+		taskIDs: map[string]string{},
+		seqMap:  map[string]map[int]int{},
+		files:   map[string][]byte{},
+	}
+	if err := im.planPlans(); err != nil {
+		return err
+	}
+	if err := im.planTasks(); err != nil {
+		return err
+	}
+	im.planFiles()
+	im.relink()
+
+	if dryRun {
+		fmt.Printf("Dry run: would import %d plan(s), %d task(s), and %d other file(s); %d already present.\n",
+			len(im.plans), len(im.tasks), len(im.files), im.skipped)
+		return nil
+	}
+	if err := im.write(); err != nil {
+		return err
+	}
+
+	if _, err := index.RebuildWithOptions(root, planIndexOptions(cfg)); err != nil {
+		warnf("could not rebuild index (%v) — run `logos sync` to rebuild", err)
+	}
+	if _, err := im.store.RebuildTaskIndex(); err != nil {
+		warnf("could not rebuild task index (%v) — run `logos sync` to rebuild", err)
+	}
+	stageFiles(root, cfg, append(im.written, index.FilePath(root), task.TaskIndexFilePath(root))...)
+
+	printSuccess("Imported %d plan(s), %d task(s), and %d other file(s); %d already present.",
+		len(im.plans), len(im.tasks), len(im.files), im.skipped)
+	return nil
+}
+
+// planPlans decides which bundle plans to import and under which filename
+// and ID.
+func (im *bundleImport) planPlans() error {
+	existing, err := plan.LoadAll(im.root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	byID := map[string]plan.Plan{}
+	for _, p := range existing {
+		byID[p.ID] = p
+	}
+	taken := map[string]bool{}
+	for _, name := range planFilenames(im.root, existing) {
+		taken[name] = true
+	}
+
+	for _, src := range sortedBundlePaths(im.b.Files, isBundlePlan) {
+		data := im.b.Files[src]
+		base := path.Base(src)
+		p, err := plan.Parse(base, data)
+		if err != nil {
+			warnf("skipping %s: %v", src, err)
+			continue
+		}
+		slug := strings.TrimSuffix(base, ".md")
+		if ex, ok := byID[p.ID]; ok && p.ID != "" && ex.Topic == p.Topic {
+			im.slugMap[slug] = strings.TrimSuffix(ex.Filename, ".md")
+			im.skipped++
+			continue
+		}
+
+		ip := &importedPlan{src: src, data: data, p: p}
+		name := base
+		if taken[name] {
+			name = uniqueName(base, func(n string) bool { return taken[n] })
+			ip.changed = true
+			p.TasksDir = plan.DefaultTasksDir(name)
+			fmt.Printf("  plan %s → %s (filename taken)\n", base, name)
+		}
+		taken[name] = true
+		if _, ok := byID[p.ID]; ok || p.ID == "" {
+			if p.ID, err = plan.GenerateID(); err != nil {
+				return fmt.Errorf("generate plan id: %w", err)
+			}
+			ip.changed = true
+		}
+		byID[p.ID] = p
+		p.Filename = name
+		ip.p = p
+		ip.dest = path.Join(path.Dir(src), name)
+		im.slugMap[slug] = strings.TrimSuffix(name, ".md")
+		im.plans = append(im.plans, ip)
+	}
+	return nil
+}
+
+// planTasks decides which bundle tasks to import, and with which ID, plan,
+// and seq.
+func (im *bundleImport) planTasks() error {
+	existing, err := im.store.List(task.Filter{})
+	if err != nil {
+		warnf("%v", err)
+	}
+	byID := map[string]*task.Task{}
+	for _, t := range existing {
+		byID[t.ID] = t
+	}
+	nextSeq := map[string]int{} // destination slug → next free seq, for existing plans
+
+	for _, src := range sortedBundlePaths(im.b.Files, isBundleTask) {
+		data := im.b.Files[src]
+		t, err := task.Parse("TASK.md", data)
+		if err != nil {
+			warnf("skipping %s: %v", src, err)
+			continue
+		}
+		srcSlug := strings.Split(src, "/")[1]
+		dest := srcSlug
+		if s, ok := im.slugMap[srcSlug]; ok {
+			dest = s
+		}
+		if ex, ok := byID[t.ID]; ok && t.ID != "" && ex.Plan == dest && ex.Title == t.Title {
+			im.skipped++
+			continue
+		}
+
+		it := &importedTask{srcDir: path.Dir(src) + "/", srcSlug: srcSlug, data: data, t: t}
+		if t.Plan != dest {
+			it.t.Plan = dest
+			it.changed = true
+		}
+		if _, ok := byID[t.ID]; ok || t.ID == "" {
+			newID := "(new id)"
+			if !im.dry {
+				if newID, err = im.store.NextID(); err != nil {
+					return fmt.Errorf("generate task id: %w", err)
+				}
+			}
+			im.taskIDs[t.ID] = newID
+			it.t.ID = newID
+			it.changed = true
+		}
+		byID[it.t.ID] = &it.t
+
+		// A plan directory that already holds tasks keeps its numbering;
+		// imported tasks are appended after it.
+		groupDir := filepath.Join(im.root, config.DirName, "tasks", dest)
+		if _, seen := nextSeq[dest]; !seen {
+			n, err := im.store.NextSeq(groupDir)
+			if err != nil {
+				return err
+			}
+			nextSeq[dest] = n
+		}
+		if nextSeq[dest] > 1 {
+			if im.seqMap[srcSlug] == nil {
+				im.seqMap[srcSlug] = map[int]int{}
+			}
+			im.seqMap[srcSlug][t.Seq] = nextSeq[dest]
+			it.t.Seq = nextSeq[dest]
+			nextSeq[dest]++
+			it.changed = true
+		}
+		it.destDir = path.Join("tasks", dest, task.TaskDirName(it.t.Seq, it.t.Title)) + "/"
+		if it.changed {
+			fmt.Printf("  task %s → %s\n", strings.TrimSuffix(it.srcDir, "/"), strings.TrimSuffix(it.destDir, "/"))
+		}
+		im.tasks = append(im.tasks, it)
+	}
+	return nil
+}
+
+// planFiles picks the knowledge files and attachments to import.
+func (im *bundleImport) planFiles() {
+	for _, src := range sortedBundlePaths(im.b.Files, func(name string) bool {
+		return strings.HasPrefix(name, "knowledge/") || strings.HasPrefix(name, attachmentsDir+"/")
+	}) {
+		data := im.b.Files[src]
+		dest := src
+		exists := func(n string) bool {
+			if _, ok := im.files[n]; ok {
+				return true
+			}
+			_, err := os.Stat(filepath.Join(im.root, config.DirName, filepath.FromSlash(n)))
+			return err == nil
+		}
+		if cur, err := os.ReadFile(filepath.Join(im.root, config.DirName, filepath.FromSlash(src))); err == nil {
+			if bytes.Equal(cur, data) {
+				im.skipped++
+				continue
+			}
+			dest = path.Join(path.Dir(src), uniqueName(path.Base(src), func(n string) bool {
+				return exists(path.Join(path.Dir(src), n))
+			}))
+			fmt.Printf("  file %s → %s (path taken)\n", src, dest)
+		}
+		im.files[dest] = data
+	}
+}
+
+// relink rewrites links inside imported plans and tasks to follow renamed
+// plans, remapped task IDs, and renumbered seqs.
+func (im *bundleImport) relink() {
+	planRef := func(ref string) string {
+		slug := strings.TrimSuffix(ref, ".md")
+		if s, ok := im.slugMap[slug]; ok && s != slug {
+			return s + ".md"
+		}
+		return ref
+	}
+	remap := func(list []string, fn func(string) string) ([]string, bool) {
+		changed := false
+		out := make([]string, len(list))
+		for i, v := range list {
+			out[i] = fn(v)
+			changed = changed || out[i] != v
+		}
+		return out, changed
+	}
+
+	for _, ip := range im.plans {
+		var c1, c2, c3 bool
+		ip.p.Related, c1 = remap(ip.p.Related, planRef)
+		ip.p.DependsOn, c2 = remap(ip.p.DependsOn, planRef)
+		ip.p.RelatedTasks, c3 = remap(ip.p.RelatedTasks, func(id string) string {
+			if n, ok := im.taskIDs[id]; ok {
+				return n
+			}
+			return id
+		})
+		ip.changed = ip.changed || c1 || c2 || c3
+	}
+	for _, it := range im.tasks {
+		var c bool
+		it.t.RelatedPlans, c = remap(it.t.RelatedPlans, planRef)
+		it.changed = it.changed || c
+		if seqs := im.seqMap[it.srcSlug]; seqs != nil {
+			for i, dep := range it.t.DependsOn {
+				if n, ok := seqs[dep]; ok {
+					it.t.DependsOn[i] = n
+					it.changed = true
+				}
+			}
+		}
+	}
+}
+
+// write creates every imported file. Nothing is overwritten: destinations
+// were chosen to be free.
+func (im *bundleImport) write() error {
+	base := filepath.Join(im.root, config.DirName)
+	put := func(rel string, data []byte) error {
+		p := filepath.Join(base, filepath.FromSlash(rel))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(p, data, 0o644); err != nil {
+			return err
+		}
+		im.written = append(im.written, p)
+		return nil
+	}
+
+	for _, ip := range im.plans {
+		data := ip.data
+		if ip.changed {
+			var err error
+			if data, err = plan.Marshal(ip.p); err != nil {
+				return fmt.Errorf("marshal plan %s: %w", ip.p.Filename, err)
+			}
+		}
+		if err := put(ip.dest, data); err != nil {
+			return fmt.Errorf("write plan %s: %w", ip.dest, err)
+		}
+	}
+	for _, it := range im.tasks {
+		data := it.data
+		if it.changed {
+			var err error
+			if data, err = task.Marshal(it.t); err != nil {
+				return fmt.Errorf("marshal task %s: %w", it.destDir, err)
+			}
+		}
+		if err := put(it.destDir+"TASK.md", data); err != nil {
+			return fmt.Errorf("write task %s: %w", it.destDir, err)
+		}
+		// Copy the task's other files (WALKTHROUGH.md, notes, ...).
+		for name, content := range im.b.Files {
+			if rest, ok := strings.CutPrefix(name, it.srcDir); ok && rest != "TASK.md" {
+				if err := put(it.destDir+rest, content); err != nil {
+					return fmt.Errorf("write %s: %w", it.destDir+rest, err)
+				}
+			}
+		}
+	}
+	for dest, data := range im.files {
+		if err := put(dest, data); err != nil {
+			return fmt.Errorf("write %s: %w", dest, err)
+		}
+	}
+	return nil
+}
+
+// sortedBundlePaths returns the bundle paths accepted by keep, sorted.
+func sortedBundlePaths(files map[string][]byte, keep func(string) bool) []string {
+	var out []string
+	for name := range files {
+		if keep(name) {
+			out = append(out, name)
+		}
+	}
+	slices.Sort(out)
+	return out
+}
+
+// uniqueName returns name with the smallest "-N" suffix (N >= 2) inserted
+// before its extension for which taken reports false.
+func uniqueName(name string, taken func(string) bool) string {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	for n := 2; ; n++ {
+		candidate := fmt.Sprintf("%s-%d%s", stem, n, ext)
+		if !taken(candidate) {
+			return candidate
+		}
+	}
+}
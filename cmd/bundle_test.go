@@ -0,0 +1,155 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// exportTestBundle creates a project holding one plan with two tasks (the
+// second depending on the first) and a knowledge file, exports it, and
+// returns the bundle path.
+func exportTestBundle(t *testing.T) string {
+	t.Helper()
+	dir := setupInitedProject(t)
+	if err := runSave("auth refactor", []string{"auth"}, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	plans, _ := plan.LoadAll(dir)
+	slug := strings.TrimSuffix(plans[0].Filename, ".md")
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "First step", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Second step", priority: "medium", dependsOn: []int{1}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, ".logosyncx", "knowledge", "notes.md"), []byte("# Notes\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := filepath.Join(t.TempDir(), "export.logos.tar.gz")
+	captureOutput(t, func() {
+		if err := runBundleExport(out, time.Now()); err != nil {
+			t.Fatalf("runBundleExport: %v", err)
+		}
+	})
+	return out
+}
+
+func loadTestTasks(t *testing.T, dir string) []*task.Task {
+	t.Helper()
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tasks, err := task.NewStore(dir, &cfg).List(task.Filter{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return tasks
+}
+
+func TestBundle_ExportImportIntoEmptyProject(t *testing.T) {
+	bundlePath := exportTestBundle(t)
+
+	dir := setupInitedProject(t)
+	out := captureOutput(t, func() {
+		if err := runBundleImport(bundlePath, false); err != nil {
+			t.Fatalf("runBundleImport: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Imported 1 plan(s), 2 task(s), and 1 other file(s)") {
+		t.Errorf("unexpected summary:\n%s", out)
+	}
+	plans, _ := plan.LoadAll(dir)
+	if len(plans) != 1 || plans[0].Topic != "auth refactor" {
+		t.Errorf("expected the imported plan, got %+v", plans)
+	}
+	if tasks := loadTestTasks(t, dir); len(tasks) != 2 {
+		t.Errorf("expected 2 imported tasks, got %d", len(tasks))
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".logosyncx", "knowledge", "notes.md")); err != nil {
+		t.Errorf("expected knowledge file imported: %v", err)
+	}
+
+	// Importing the same bundle again finds everything already present.
+	out = captureOutput(t, func() {
+		if err := runBundleImport(bundlePath, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "Imported 0 plan(s), 0 task(s), and 0 other file(s); 4 already present.") {
+		t.Errorf("expected a no-op re-import, got:\n%s", out)
+	}
+}
+
+func TestBundle_ImportRenamesCollidingPlan(t *testing.T) {
+	bundlePath := exportTestBundle(t)
+
+	dir := setupInitedProject(t)
+	// Same topic on the same day: same filename, different plan.
+	if err := runSave("auth refactor", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	existing, _ := plan.LoadAll(dir)
+	slug := strings.TrimSuffix(existing[0].Filename, ".md")
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Local task", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+
+	captureOutput(t, func() {
+		if err := runBundleImport(bundlePath, false); err != nil {
+			t.Fatalf("runBundleImport: %v", err)
+		}
+	})
+
+	plans, _ := plan.LoadAll(dir)
+	if len(plans) != 2 {
+		t.Fatalf("expected 2 plans, got %d", len(plans))
+	}
+	renamed := slug + "-2"
+	var moved []*task.Task
+	for _, tk := range loadTestTasks(t, dir) {
+		if tk.Plan == renamed {
+			moved = append(moved, tk)
+		}
+	}
+	if len(moved) != 2 {
+		t.Fatalf("expected 2 tasks under %s, got %d", renamed, len(moved))
+	}
+	for _, tk := range moved {
+		if tk.Title == "Second step" && (len(tk.DependsOn) != 1 || tk.DependsOn[0] != 1) {
+			t.Errorf("depends_on = %v, want [1]", tk.DependsOn)
+		}
+	}
+}
+
+func TestBundle_ImportDryRunWritesNothing(t *testing.T) {
+	bundlePath := exportTestBundle(t)
+
+	dir := setupInitedProject(t)
+	out := captureOutput(t, func() {
+		if err := runBundleImport(bundlePath, true); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "Dry run: would import 1 plan(s), 2 task(s)") {
+		t.Errorf("unexpected dry-run output:\n%s", out)
+	}
+	if plans, _ := plan.LoadAll(dir); len(plans) != 0 {
+		t.Errorf("dry run wrote %d plan(s)", len(plans))
+	}
+}
+
+func TestUniqueName(t *testing.T) {
+	taken := map[string]bool{"a-2.md": true}
+	if got := uniqueName("a.md", func(n string) bool { return taken[n] }); got != "a-3.md" {
+		t.Errorf("uniqueName = %q, want a-3.md", got)
+	}
+}
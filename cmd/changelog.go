@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/updater"
+	"github.com/senna-lang/logosyncx/internal/version"
+	"github.com/spf13/cobra"
+)
+
+// fetchReleases is the GitHub Releases source used by logos changelog and
+// logos update. Tests replace it to avoid network access.
+var fetchReleases = updater.FetchReleases
+
+var changelogCmd = &cobra.Command{
+	Use:   "changelog",
+	Args:  cobra.NoArgs,
+	Short: "Show release notes from GitHub Releases",
+	Long: `Print the release notes published on GitHub Releases.
+
+Without --since, the notes for the running version are shown (the latest
+release for development builds). With --since, the notes for every release
+newer than the given version are shown, newest first.
+
+logos update prints the same notes for the versions it skipped over after a
+successful upgrade.
+
+Examples:
+  logos changelog                  # notes for the running version
+  logos changelog --since v0.2.0   # everything released after v0.2.0
+  logos changelog --json           # machine-readable output`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, _ := cmd.Flags().GetString("since")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		return runChangelog(since, asJSON)
+	},
+}
+
+func init() {
+	changelogCmd.Flags().String("since", "", "Show every release newer than this version (e.g. v0.2.0)")
+	changelogCmd.Flags().Bool("json", false, "Output releases as JSON")
+	rootCmd.AddCommand(changelogCmd)
+}
+
+func runChangelog(since string, asJSON bool) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	all, err := fetchReleases(ctx)
+	if err != nil {
+		return fmt.Errorf("could not reach GitHub Releases: %w", err)
+	}
+
+	var releases []updater.Release
+	switch {
+	case since != "":
+		releases = updater.ReleasesBetween(all, since, "")
+	case version.IsDev():
+		if len(all) > 0 {
+			releases = all[:1]
+		}
+	default:
+		for _, r := range all {
+			if strings.TrimPrefix(r.Tag, "v") == strings.TrimPrefix(version.Version, "v") {
+				releases = append(releases, r)
+			}
+		}
+	}
+
+	if asJSON {
+		if releases == nil {
+			releases = []updater.Release{}
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(releases)
+	}
+
+	if len(releases) == 0 {
+		if since != "" {
+			fmt.Printf("No releases after %s.\n", since)
+		} else {
+			fmt.Printf("No release notes found for %s.\n", version.Version)
+		}
+		return nil
+	}
+	printReleaseNotes(releases)
+	return nil
+}
+
+// printReleaseNotes prints each release's tag, date, and notes, newest first.
+func printReleaseNotes(releases []updater.Release) {
+	for i, r := range releases {
+		if i > 0 {
+			fmt.Println()
+		}
+		heading := r.Tag
+		if !r.PublishedAt.IsZero() {
+			heading += " (" + r.PublishedAt.Format("2006-01-02") + ")"
+		}
+		fmt.Println(heading)
+		fmt.Println(strings.Repeat("=", len(heading)))
+		body := strings.TrimSpace(strings.ReplaceAll(r.Body, "\r\n", "\n"))
+		if body == "" {
+			body = "(no release notes)"
+		}
+		fmt.Println(body)
+	}
+}
+
+// printUpdateReleaseNotes prints the notes for every release after from up
+// to and including to, as shown by logos update after an upgrade. A fetch
+// failure only prints a hint; the update itself has already succeeded.
+func printUpdateReleaseNotes(from, to string) {
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
+	defer cancel()
+
+	all, err := fetchReleases(ctx)
+	if err != nil {
+		warnf("could not fetch release notes: %v", err)
+		printHint(fmt.Sprintf("Run `logos changelog --since %s` to see what changed.", from))
+		return
+	}
+	releases := updater.ReleasesBetween(all, from, to)
+	if len(releases) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Printf("What's new since %s:\n\n", from)
+	printReleaseNotes(releases)
+}
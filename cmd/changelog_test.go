@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/senna-lang/logosyncx/internal/updater"
+	"github.com/senna-lang/logosyncx/internal/version"
+)
+
+// useReleases replaces fetchReleases and version.Version for one test.
+func useReleases(t *testing.T, current string, releases []updater.Release) {
+	t.Helper()
+	origFetch, origVersion := fetchReleases, version.Version
+	fetchReleases = func(context.Context) ([]updater.Release, error) { return releases, nil }
+	version.Version = current
+	t.Cleanup(func() { fetchReleases, version.Version = origFetch, origVersion })
+}
+
+var testReleases = []updater.Release{
+	{Tag: "v0.4.0", Body: "Added changelog."},
+	{Tag: "v0.3.0", Body: "Added status overview."},
+	{Tag: "v0.2.0", Body: "Initial release."},
+}
+
+func TestChangelog_Since(t *testing.T) {
+	useReleases(t, "v0.4.0", testReleases)
+	out := captureOutput(t, func() {
+		if err := runChangelog("v0.2.0", false); err != nil {
+			t.Fatalf("runChangelog: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Added changelog.") || !strings.Contains(out, "Added status overview.") {
+		t.Errorf("expected v0.3.0 and v0.4.0 notes, got:\n%s", out)
+	}
+	if strings.Contains(out, "Initial release.") {
+		t.Errorf("--since must exclude the given version, got:\n%s", out)
+	}
+	if strings.Index(out, "v0.4.0") > strings.Index(out, "v0.3.0") {
+		t.Errorf("expected newest first, got:\n%s", out)
+	}
+}
+
+func TestChangelog_DefaultsToRunningVersion(t *testing.T) {
+	useReleases(t, "v0.3.0", testReleases)
+	out := captureOutput(t, func() {
+		if err := runChangelog("", false); err != nil {
+			t.Fatalf("runChangelog: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Added status overview.") || strings.Contains(out, "Added changelog.") {
+		t.Errorf("expected only v0.3.0 notes, got:\n%s", out)
+	}
+}
+
+func TestUpdateReleaseNotes_SpansSkippedVersions(t *testing.T) {
+	useReleases(t, "v0.2.0", testReleases)
+	withForAgent(t, true)
+	out := captureOutput(t, func() { printUpdateReleaseNotes("v0.2.0", "v0.4.0") })
+	if !strings.Contains(out, "What's new since v0.2.0") ||
+		!strings.Contains(out, "Added changelog.") || !strings.Contains(out, "Added status overview.") {
+		t.Errorf("unexpected notes:\n%s", out)
+	}
+}
@@ -0,0 +1,411 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/privacy"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/knowledge"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var checkCmd = &cobra.Command{
+	Use:          "check",
+	Args:         cobra.NoArgs,
+	SilenceUsage: true,
+	Short:        "Run every project health check and exit non-zero on failure (for CI)",
+	Long: `Run all of logos's consistency checks in one pass and report a single
+pass/fail result, so CI needs one step instead of separate sync --check,
+doctor, and privacy runs. Nothing is written to .logosyncx/.
+
+Checks:
+  config    config.json parses, has no unknown keys, and every value is valid
+  index     index.jsonl and task-index.jsonl match a fresh rebuild
+  layout    no misplaced task files or unknown statuses (see logos doctor)
+  conflicts no plan, task, or knowledge file holds git conflict markers
+  attachments
+            no file under .logosyncx/ exceeds attachments.max_size_kb or is
+            binary outside attachments/ (Git LFS files are exempt); with
+            attachments.lfs, .gitattributes tracks attachments/ with LFS
+  links     related, depends_on, related_tasks, and related_plans entries
+            point at plans and tasks that exist (archived ones count)
+  sections  every plan and task fills in plans.required_sections and
+            tasks.required_sections; a section holding only template
+            comments counts as missing (journal plans are skipped)
+  privacy   no plan, task, or knowledge file matches privacy.filter_patterns
+  frozen    the body of every plan frozen with logos freeze is unchanged
+
+The command exits non-zero when any check fails. --json prints the report to
+stdout; --output writes the same JSON report to a file (for a CI artifact)
+whether or not the run passes.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		output, _ := cmd.Flags().GetString("output")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		return runCheck(asJSON, output)
+	},
+}
+
+func init() {
+	checkCmd.Flags().Bool("json", false, "Print the report as JSON")
+	checkCmd.Flags().StringP("output", "o", "", "Also write the JSON report to this file")
+	rootCmd.AddCommand(checkCmd)
+}
+
+// checkResult is the outcome of one check in the logos check report.
+type checkResult struct {
+	Name     string   `json:"name"`
+	Passed   bool     `json:"passed"`
+	Problems []string `json:"problems"`
+}
+
+// checkReport is the --json output and --output artifact of logos check.
+type checkReport struct {
+	Passed bool          `json:"passed"`
+	Checks []checkResult `json:"checks"`
+}
+
+// checkInputs is the project state shared by every check, loaded once.
+type checkInputs struct {
+	root  string
+	cfg   config.Config
+	store *task.Store
+	plans []plan.Plan
+	tasks []*task.Task
+}
+
+// projectChecks lists every check run by logos check, in report order.
+var projectChecks = []struct {
+	name string
+	run  func(in checkInputs) []string
+}{
+	{"config", checkConfig},
+	{"index", checkIndexes},
+	{"layout", checkLayout},
+	{"conflicts", checkConflicts},
+	{"attachments", checkAttachments},
+	{"links", checkLinks},
+	{"sections", checkSections},
+	{"privacy", checkPrivacy},
+	{"frozen", checkFrozen},
+}
+
+func runCheck(asJSON bool, output string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	// A config that fails to parse is itself a check failure; the remaining
+	// checks still run against the defaults.
+	cfg, err := loadConfig(root)
+	if err != nil {
+		cfg = config.Default(filepath.Base(root))
+	}
+	in := checkInputs{root: root, cfg: cfg, store: task.NewStore(root, &cfg)}
+	in.plans, err = plan.LoadAllWithOptions(root, planParseOptions(cfg))
+	if err != nil {
+		warnf("%v", err)
+	}
+	in.tasks, err = in.store.List(task.Filter{})
+	if err != nil {
+		return fmt.Errorf("load tasks: %w", err)
+	}
+
+	report := checkReport{Passed: true}
+	for _, c := range projectChecks {
+		problems := c.run(in)
+		if problems == nil {
+			problems = []string{}
+		}
+		r := checkResult{Name: c.name, Passed: len(problems) == 0, Problems: problems}
+		report.Passed = report.Passed && r.Passed
+		report.Checks = append(report.Checks, r)
+	}
+
+	data, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if output != "" {
+		if err := os.WriteFile(output, data, 0o644); err != nil {
+			return fmt.Errorf("write report: %w", err)
+		}
+	}
+
+	if asJSON {
+		os.Stdout.Write(data)
+	} else {
+		printCheckReport(report)
+	}
+
+	if !report.Passed {
+		var failed []string
+		for _, r := range report.Checks {
+			if !r.Passed {
+				failed = append(failed, r.Name)
+			}
+		}
+		return fmt.Errorf("check failed: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+// printCheckReport prints one line per check followed by its problems.
+func printCheckReport(report checkReport) {
+	for _, r := range report.Checks {
+		if r.Passed {
+			printSuccess("%s", r.Name)
+			continue
+		}
+		fmt.Printf("✗ %s — %d problem(s)\n", r.Name, len(r.Problems))
+		for _, p := range r.Problems {
+			fmt.Printf("    %s\n", p)
+		}
+	}
+	if report.Passed {
+		fmt.Println("All checks passed.")
+	}
+}
+
+// checkConfig validates config.json, including the task statuses,
+// priorities, and plan filename pattern that pkg/config cannot check on its
+// own.
+func checkConfig(in checkInputs) []string {
+	problems, err := config.Validate(in.root)
+	if err != nil {
+		return []string{err.Error()}
+	}
+	if err := plan.ValidateFilenamePattern(in.cfg.Plans.FilenamePattern); err != nil {
+		problems = append(problems, "plans.filename_pattern: "+err.Error())
+	}
+	return problems
+}
+
+// checkIndexes compares each index logos sync maintains with a fresh build.
+func checkIndexes(in checkInputs) []string {
+	var problems []string
+	for _, t := range syncTargets(in.root, in.cfg, in.store) {
+		_, ok, err := t.check()
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s index: %v", t.name, err))
+		} else if !ok {
+			problems = append(problems, fmt.Sprintf("%s index is out of date — run logos sync", t.name))
+		}
+	}
+	return problems
+}
+
+// checkLayout reports the stray task files logos doctor would list.
+func checkLayout(in checkInputs) []string {
+	strays, err := in.store.FindStrays()
+	var problems []string
+	if err != nil {
+		problems = append(problems, err.Error())
+	}
+	for _, st := range strays {
+		rel, _ := relPath(in.root, st.Path)
+		problems = append(problems, fmt.Sprintf("[%s] %s — %s", st.Kind, rel, st.Detail))
+	}
+	return problems
+}
+
+// checkConflicts reports files holding git conflict markers.
+func checkConflicts(in checkInputs) []string {
+	var problems []string
+	for _, c := range findConflicts(in.root) {
+		problems = append(problems, fmt.Sprintf("%s: git conflict markers at %s", c.rel, markdown.FormatLines(c.lines)))
+	}
+	return problems
+}
+
+// checkAttachments reports files that break the attachment policy and, when
+// attachments.lfs is set, a missing .gitattributes LFS rule.
+func checkAttachments(in checkInputs) []string {
+	var problems []string
+	if in.cfg.Attachments.LFS && !hasLFSRule(in.root) {
+		problems = append(problems, "attachments.lfs is set but .gitattributes has no LFS rule — run logos doctor --fix-lfs")
+	}
+	for _, p := range findAttachmentProblems(in.root, in.cfg, nil) {
+		problems = append(problems, fmt.Sprintf("%s: %s", p.rel, p.detail))
+	}
+	return problems
+}
+
+// checkLinks reports plan and task references whose target does not exist.
+// Archived plans and tasks are valid targets.
+func checkLinks(in checkInputs) []string {
+	planSlugs := map[string]bool{}
+	for _, p := range in.plans {
+		planSlugs[strings.TrimSuffix(p.Filename, ".md")] = true
+	}
+	if entries, err := os.ReadDir(plan.ArchiveDir(in.root)); err == nil {
+		for _, e := range entries {
+			if !e.IsDir() && strings.HasSuffix(e.Name(), ".md") {
+				planSlugs[strings.TrimSuffix(e.Name(), ".md")] = true
+			}
+		}
+	}
+
+	taskIDs := map[string]bool{}
+	seqs := map[string]map[int]bool{} // plan slug → seqs present
+	for _, t := range in.tasks {
+		taskIDs[t.ID] = true
+		if seqs[t.Plan] == nil {
+			seqs[t.Plan] = map[int]bool{}
+		}
+		seqs[t.Plan][t.Seq] = true
+	}
+	for _, id := range archivedTaskIDs(in.root) {
+		taskIDs[id] = true
+	}
+
+	var problems []string
+	for _, p := range in.plans {
+		rel := filepath.Join(".logosyncx", "plans", p.Filename)
+		for _, ref := range p.Related {
+			if !planSlugs[strings.TrimSuffix(ref, ".md")] {
+				problems = append(problems, fmt.Sprintf("%s: related plan %q not found", rel, ref))
+			}
+		}
+		for _, ref := range p.DependsOn {
+			if !planSlugs[strings.TrimSuffix(ref, ".md")] {
+				problems = append(problems, fmt.Sprintf("%s: depends_on plan %q not found", rel, ref))
+			}
+		}
+		for _, id := range p.RelatedTasks {
+			if !taskIDs[id] {
+				problems = append(problems, fmt.Sprintf("%s: related task %q not found", rel, id))
+			}
+		}
+	}
+	for _, t := range in.tasks {
+		rel, _ := relPath(in.root, filepath.Join(t.DirPath, "TASK.md"))
+		if !planSlugs[t.Plan] {
+			problems = append(problems, fmt.Sprintf("%s: plan %q not found", rel, t.Plan))
+		}
+		for _, ref := range t.RelatedPlans {
+			if !planSlugs[strings.TrimSuffix(ref, ".md")] {
+				problems = append(problems, fmt.Sprintf("%s: related plan %q not found", rel, ref))
+			}
+		}
+		for _, dep := range t.DependsOn {
+			if !seqs[t.Plan][dep] {
+				problems = append(problems, fmt.Sprintf("%s: depends_on task %03d not found in plan %s", rel, dep, t.Plan))
+			}
+		}
+	}
+	return problems
+}
+
+// archivedTaskIDs returns the IDs of tasks under the task archive. Files
+// that cannot be read or parsed are skipped.
+func archivedTaskIDs(root string) []string {
+	dirs, _ := loadArchivedTaskDirs(root)
+	var ids []string
+	for _, d := range dirs {
+		path := filepath.Join(task.ArchiveDir(root), d, "TASK.md")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			continue
+		}
+		if t, err := task.Parse(path, data); err == nil && t.ID != "" {
+			ids = append(ids, t.ID)
+		}
+	}
+	return ids
+}
+
+// checkSections reports plans and tasks whose required sections are missing
+// or still hold only the template's placeholder comments.
+func checkSections(in checkInputs) []string {
+	var problems []string
+	missing := func(rel, body string, required []string) {
+		for _, name := range required {
+			content, found := markdown.Section(body, name)
+			switch {
+			case !found:
+				problems = append(problems, fmt.Sprintf("%s: missing section %q", rel, name))
+			case markdown.StripComments(content) == "":
+				problems = append(problems, fmt.Sprintf("%s: section %q is empty", rel, name))
+			}
+		}
+	}
+	if len(in.cfg.Plans.RequiredSections) > 0 {
+		for _, p := range in.plans {
+			if slices.Contains(p.Tags, plan.JournalTag) {
+				continue
+			}
+			missing(filepath.Join(".logosyncx", "plans", p.Filename), p.Body, in.cfg.Plans.RequiredSections)
+		}
+	}
+	if len(in.cfg.Tasks.RequiredSections) > 0 {
+		for _, t := range in.tasks {
+			rel, _ := relPath(in.root, filepath.Join(t.DirPath, "TASK.md"))
+			missing(rel, t.Body, in.cfg.Tasks.RequiredSections)
+		}
+	}
+	return problems
+}
+
+// checkPrivacy scans every Markdown file under plans/, tasks/, the task
+// archive, and knowledge/ for lines matching the privacy patterns. The
+// matched text is not echoed, so the report itself does not leak it.
+func checkPrivacy(in checkInputs) []string {
+	// Invalid patterns are left out; the config check reports them.
+	patterns, _ := privacy.Compile(in.cfg.Privacy)
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	var problems []string
+	dirs := []string{
+		plan.PlansDir(in.root),
+		filepath.Join(in.root, config.DirName, "tasks"),
+		task.ArchiveDir(in.root),
+		knowledge.KnowledgeDir(in.root),
+	}
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".md") {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				problems = append(problems, err.Error())
+				return nil
+			}
+			rel, _ := relPath(in.root, path)
+			for _, m := range privacy.Find(patterns, string(data)) {
+				problems = append(problems, fmt.Sprintf("%s:%d: matches privacy pattern %s", rel, m.Line, m.Name))
+			}
+			return nil
+		})
+	}
+	return problems
+}
+
+// checkFrozen reports frozen plans whose body no longer matches the checksum
+// taken by logos freeze, i.e. plans edited by hand after they were frozen.
+func checkFrozen(in checkInputs) []string {
+	var problems []string
+	for _, p := range in.plans {
+		if p.Frozen && plan.BodySum(p.Body) != p.FrozenSum {
+			problems = append(problems, fmt.Sprintf("%s: frozen plan was edited after it was frozen", filepath.Join(".logosyncx", "plans", p.Filename)))
+		}
+	}
+	return problems
+}
@@ -0,0 +1,93 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+func TestCheck_CleanProjectPasses(t *testing.T) {
+	setupInitedProject(t)
+	out := captureOutput(t, func() {
+		if err := runCheck(false, ""); err != nil {
+			t.Fatalf("runCheck: %v", err)
+		}
+	})
+	if !strings.Contains(out, "All checks passed.") {
+		t.Errorf("expected pass summary, got: %q", out)
+	}
+}
+
+func TestCheck_FailuresAreAggregated(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Privacy.FilterPatterns = []string{`sk-[a-z0-9]{8}`}
+	cfg.Plans.RequiredSections = []string{"Spec"}
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	p := makeSyncPlan("p1", "leaky", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	p.Related = []string{"20250101-missing.md"}
+	p.Body = "## Background\nkey sk-abcd1234 pasted here\n\n## Spec\n<!-- fill me in -->\n"
+	writeSyncPlan(t, dir, p)
+
+	var runErr error
+	report := filepath.Join(dir, "report.json")
+	captureOutput(t, func() { runErr = runCheck(false, report) })
+	if runErr == nil {
+		t.Fatal("expected check to fail")
+	}
+	for _, name := range []string{"index", "links", "sections", "privacy"} {
+		if !strings.Contains(runErr.Error(), name) {
+			t.Errorf("expected %q in error, got: %v", name, runErr)
+		}
+	}
+	if strings.Contains(runErr.Error(), "config") {
+		t.Errorf("config check should pass, got: %v", runErr)
+	}
+
+	data, err := os.ReadFile(report)
+	if err != nil {
+		t.Fatalf("report not written: %v", err)
+	}
+	var got checkReport
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("invalid report JSON: %v", err)
+	}
+	if got.Passed || len(got.Checks) != len(projectChecks) {
+		t.Fatalf("unexpected report: %+v", got)
+	}
+	if strings.Contains(string(data), "sk-abcd1234") {
+		t.Error("report must not echo text matched by privacy patterns")
+	}
+}
+
+func TestCheck_InvalidConfig(t *testing.T) {
+	dir := setupInitedProject(t)
+	path := config.ConfigPath(dir)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = []byte(strings.Replace(string(data), `"default_status": "open"`, `"default_status": "todo"`, 1))
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	var runErr error
+	out := captureOutput(t, func() { runErr = runCheck(true, "") })
+	if runErr == nil || !strings.Contains(runErr.Error(), "config") {
+		t.Fatalf("expected config failure, got: %v", runErr)
+	}
+	if !strings.Contains(out, `tasks.default_status: \"todo\"`) {
+		t.Errorf("expected default_status problem in JSON, got: %s", out)
+	}
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "Validate, read, and edit .logosyncx/config.json",
+	Long: `Work with .logosyncx/config.json from scripts and agents.
+
+Settings are named by their dot-separated path in config.json, with map
+entries named by their key: plans.excerpt_section, git.auto,
+tasks.retention.done, templates.adr.tags.`,
+}
+
+var configValidateCmd = &cobra.Command{
+	Use:   "validate",
+	Short: "Check config.json against the schema",
+	Long: `Check config.json and print one line per problem:
+
+  - keys that are not settings (the file is checked against the schema
+    printed by logos config schema)
+  - values outside those a setting accepts, such as git.auto or
+    privacy.mode
+  - regular expressions that do not compile (privacy.filter_patterns,
+    privacy.patterns, excerpt_strip_prefixes)
+  - section names written as Markdown headings ("## Spec" instead of
+    "Spec")
+  - an excerpt_section or excerpt_fallback entry that is neither a heading
+    of templates/plan.md (or task.md) nor a section named elsewhere in
+    config, so the excerpt always falls back to the start of the body
+  - task statuses, priorities, and the plan filename pattern
+
+The same problems fail the config check of logos check. Exits non-zero when
+there is any problem.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		return runConfigValidate(asJSON)
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get <key>",
+	Short: "Print the effective value of a setting",
+	Long: `Print the value of a setting as logos uses it, defaults included: strings
+as they are, everything else as JSON. A setting that is unset and has no
+default prints an empty line.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigGet(args[0])
+	},
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set <key> <value>",
+	Short: "Change a setting, keeping the rest of config.json as it is",
+	Long: `Change one setting in config.json. Only the value changes: key order,
+indentation, and the settings you left out stay as they are. A setting not
+in the file yet is added at the end of its object.
+
+value is taken literally for string settings and as JSON for the others:
+
+  logos config set plans.excerpt_section Summary
+  logos config set plans.excerpt_max_runes 200
+  logos config set tasks.default_tags '["team-a"]'
+  logos config set tasks.retention.done 60d
+
+A change that would make logos config validate report a new problem is
+refused. config.json is staged when git.auto is add or above.`,
+	Args: cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigSet(args[0], args[1])
+	},
+}
+
+var configSchemaCmd = &cobra.Command{
+	Use:   "schema",
+	Short: "Print the JSON Schema of config.json",
+	Long: `Print a JSON Schema (draft 2020-12) describing config.json, for editors and
+CI. Add it to config.json as "$schema" only through your editor's settings:
+logos rejects keys that are not settings.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		suppressUpdateCheck = true
+		return runConfigSchema()
+	},
+}
+
+func init() {
+	configValidateCmd.Flags().Bool("json", false, "Output JSON (for agent consumption)")
+	configCmd.AddCommand(configValidateCmd, configGetCmd, configSetCmd, configSchemaCmd)
+	rootCmd.AddCommand(configCmd)
+}
+
+// configReport is the logos config validate --json output.
+type configReport struct {
+	Valid    bool     `json:"valid"`
+	Problems []string `json:"problems"`
+}
+
+func runConfigValidate(asJSON bool) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	// A config that fails to parse is reported by checkConfig; the checks
+	// needing settings run against the defaults.
+	cfg, err := loadConfig(root)
+	if err != nil {
+		cfg = config.Default(filepath.Base(root))
+	}
+	problems := checkConfig(checkInputs{root: root, cfg: cfg})
+	if problems == nil {
+		problems = []string{}
+	}
+
+	if asJSON {
+		data, err := json.MarshalIndent(configReport{Valid: len(problems) == 0, Problems: problems}, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	} else if len(problems) == 0 {
+		printSuccess("%s is valid", config.ConfigFileName)
+	} else {
+		for _, p := range problems {
+			fmt.Printf("  %s\n", p)
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("%s has %d problem(s)", config.ConfigFileName, len(problems))
+	}
+	return nil
+}
+
+func runConfigGet(key string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	v, err := config.Get(cfg, key)
+	if err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case nil:
+		fmt.Println()
+	case string:
+		fmt.Println(v)
+	default:
+		data, err := json.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(data))
+	}
+	return nil
+}
+
+func runConfigSet(key, value string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	if err := config.Set(root, key, value); err != nil {
+		return err
+	}
+	if cfg, err := loadConfig(root); err == nil {
+		stageFiles(root, cfg, config.ConfigPath(root))
+	}
+	printSuccess("Set %s to %s", key, value)
+	return nil
+}
+
+func runConfigSchema() error {
+	data, err := json.MarshalIndent(config.Schema(), "", "  ")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	_, err = os.Stdout.Write(data)
+	return err
+}
@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+func TestConfigValidate(t *testing.T) {
+	dir := setupInitedProject(t)
+
+	out := captureOutput(t, func() {
+		if err := runConfigValidate(false); err != nil {
+			t.Fatalf("runConfigValidate on a new project: %v", err)
+		}
+	})
+	if !strings.Contains(out, "config.json is valid") {
+		t.Errorf("output = %q", out)
+	}
+
+	data, err := os.ReadFile(config.ConfigPath(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data = []byte(strings.Replace(string(data), `"excerpt_section": "Background"`, `"excerpt_section": "## Background"`, 1))
+	if err := os.WriteFile(config.ConfigPath(dir), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	var runErr error
+	out = captureOutput(t, func() { runErr = runConfigValidate(true) })
+	if runErr == nil || !strings.Contains(runErr.Error(), "problem(s)") {
+		t.Errorf("runConfigValidate = %v, want an error", runErr)
+	}
+	if !strings.Contains(out, `"valid": false`) || !strings.Contains(out, "plans.excerpt_section") {
+		t.Errorf("JSON output = %s", out)
+	}
+}
+
+func TestConfigSetAndGet(t *testing.T) {
+	dir := setupInitedProject(t)
+
+	captureOutput(t, func() {
+		if err := runConfigSet("plans.excerpt_max_runes", "120"); err != nil {
+			t.Fatalf("runConfigSet: %v", err)
+		}
+	})
+	out := captureOutput(t, func() {
+		if err := runConfigGet("plans.excerpt_max_runes"); err != nil {
+			t.Fatalf("runConfigGet: %v", err)
+		}
+	})
+	if strings.TrimSpace(out) != "120" {
+		t.Errorf("get = %q, want 120", out)
+	}
+	cfg, _ := config.Load(dir)
+	if cfg.Plans.ExcerptMaxRunes != 120 {
+		t.Errorf("excerpt_max_runes = %d", cfg.Plans.ExcerptMaxRunes)
+	}
+
+	if err := runConfigSet("privacy.mode", "hide"); err == nil || !strings.Contains(err.Error(), "privacy.mode") {
+		t.Errorf("runConfigSet with an invalid value = %v, want an error", err)
+	}
+}
@@ -0,0 +1,216 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/spf13/cobra"
+)
+
+var dashCmd = &cobra.Command{
+	Use:   "dash [dir...]",
+	Short: "Show open tasks and recent plans across several repositories",
+	Long: `Aggregate open tasks and recent plans from several logos projects into one
+view, grouped by repository.
+
+Each directory given with --roots (repeatable, comma-separated, or glob
+patterns) or as an argument is used when it holds a .logosyncx/ directory;
+otherwise its immediate subdirectories that do are used. An unquoted
+--roots ~/code/* therefore works too: the shell's extra matches become
+arguments.
+
+Open tasks are unfinished, unsnoozed tasks, in_progress first and then in
+task ls order, up to --limit per repository. Recent plans are the --recent
+newest plans. Indexes are read, never written; a missing index is built in
+memory.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		roots, _ := cmd.Flags().GetStringSlice("roots")
+		limit, _ := cmd.Flags().GetInt("limit")
+		recent, _ := cmd.Flags().GetInt("recent")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		return runDash(append(roots, args...), limit, recent, asJSON, time.Now())
+	},
+}
+
+func init() {
+	dashCmd.Flags().StringSlice("roots", nil, "Repository or parent directory to scan (repeatable; globs allowed)")
+	dashCmd.Flags().Int("limit", 10, "Maximum open tasks to show per repository")
+	dashCmd.Flags().Int("recent", 5, "Number of recent plans to show per repository")
+	dashCmd.Flags().Bool("json", false, "Output JSON (for agent consumption)")
+	rootCmd.AddCommand(dashCmd)
+}
+
+// dashRepo is one repository in the logos dash output. Tasks holds at most
+// --limit of the OpenTasks open tasks.
+type dashRepo struct {
+	Name        string          `json:"name"`
+	Root        string          `json:"root"`
+	OpenTasks   int             `json:"open_tasks"`
+	Tasks       []task.TaskJSON `json:"tasks"`
+	RecentPlans []index.Entry   `json:"recent_plans"`
+}
+
+func runDash(patterns []string, limit, recent int, asJSON bool, now time.Time) error {
+	if len(patterns) == 0 {
+		return errors.New("provide --roots <dir> (or directories as arguments)")
+	}
+	if limit < 0 || recent < 0 {
+		return errors.New("--limit and --recent must not be negative")
+	}
+	roots, err := discoverDashRoots(patterns)
+	if err != nil {
+		return err
+	}
+	if len(roots) == 0 {
+		return fmt.Errorf("no .logosyncx/ project found under %v", patterns)
+	}
+
+	repos := make([]dashRepo, 0, len(roots))
+	for _, root := range roots {
+		repos = append(repos, loadDashRepo(root, limit, recent, now))
+	}
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(repos)
+	}
+	return printDash(repos)
+}
+
+// discoverDashRoots expands patterns and returns the project roots they
+// name, deduplicated and sorted.
+func discoverDashRoots(patterns []string) ([]string, error) {
+	var dirs []string
+	for _, p := range patterns {
+		matches, err := filepath.Glob(p)
+		if err != nil {
+			return nil, fmt.Errorf("--roots %q: %w", p, err)
+		}
+		if len(matches) == 0 {
+			warnf("%s: no such directory — skipped", p)
+		}
+		dirs = append(dirs, matches...)
+	}
+
+	var roots []string
+	add := func(dir string) bool {
+		info, err := os.Stat(filepath.Join(dir, config.DirName))
+		if err != nil || !info.IsDir() {
+			return false
+		}
+		resolved, err := project.ResolveStorage(dir)
+		if err != nil {
+			warnf("%s: %v — skipped", dir, err)
+			return true
+		}
+		if abs, err := filepath.Abs(resolved); err == nil {
+			resolved = abs
+		}
+		roots = append(roots, resolved)
+		return true
+	}
+	for _, dir := range dirs {
+		if add(dir) {
+			continue
+		}
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				add(filepath.Join(dir, e.Name()))
+			}
+		}
+	}
+	slices.Sort(roots)
+	return slices.Compact(roots), nil
+}
+
+// loadDashRepo reads the open tasks and recent plans of the project at
+// root. Unreadable indexes are reported as warnings.
+func loadDashRepo(root string, limit, recent int, now time.Time) dashRepo {
+	repo := dashRepo{Name: filepath.Base(root), Root: root, Tasks: []task.TaskJSON{}, RecentPlans: []index.Entry{}}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		warnf("%s: load config: %v", repo.Name, err)
+	}
+	repo.Name = cmp.Or(cfg.Project, repo.Name)
+
+	entries, err := index.ReadAll(root)
+	if err != nil {
+		if entries, err = index.Build(root, planParseOptions(cfg)); err != nil {
+			warnf("%s: %v", repo.Name, err)
+		}
+	}
+	sortByDateDesc(entries)
+	repo.RecentPlans = append(repo.RecentPlans, entries[:min(recent, len(entries))]...)
+
+	tasks, err := task.ReadAllTaskIndex(root)
+	if err != nil {
+		if tasks, err = task.NewStore(root, &cfg).BuildTaskIndex(); err != nil {
+			warnf("%s: %v", repo.Name, err)
+		}
+	}
+	var open []task.TaskJSON
+	for _, t := range tasks {
+		if t.Status != task.StatusDone && !t.IsSnoozed(now) {
+			open = append(open, t)
+		}
+	}
+	task.SortJSONByOrder(open)
+	slices.SortStableFunc(open, func(a, b task.TaskJSON) int {
+		return cmp.Compare(statusRank(a.Status), statusRank(b.Status))
+	})
+	repo.OpenTasks = len(open)
+	repo.Tasks = append(repo.Tasks, open[:min(limit, len(open))]...)
+	return repo
+}
+
+// printDash writes one block per repository: a header line, its open tasks,
+// and its recent plans.
+func printDash(repos []dashRepo) error {
+	for i, r := range repos {
+		if i > 0 {
+			fmt.Println()
+		}
+		fmt.Printf("== %s (%s) — %d open task(s)\n", r.Name, r.Root, r.OpenTasks)
+		if len(r.Tasks) > 0 {
+			t := &textTable{
+				headers:  []string{"STATUS", "PRIORITY", "TITLE", "PLAN"},
+				fitWidth: tableWidth(),
+				shrink:   []int{2, 3},
+			}
+			for _, e := range r.Tasks {
+				t.addRow(string(e.Status), string(e.Priority), e.Title, dashIfEmpty(e.Plan))
+			}
+			if err := t.render(os.Stdout); err != nil {
+				return err
+			}
+			if more := r.OpenTasks - len(r.Tasks); more > 0 {
+				fmt.Printf("… and %d more\n", more)
+			}
+		}
+		if len(r.RecentPlans) > 0 {
+			fmt.Println("Recent plans:")
+			for _, e := range r.RecentPlans {
+				fmt.Printf("  %s  %s\n", e.Date.Format("2006-01-02"), e.Topic)
+			}
+		}
+	}
+	return nil
+}
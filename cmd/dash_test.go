@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"encoding/json"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// setupDashRepos creates two projects under the same parent directory: the
+// first with a plan and an open task, the second with a plan only.
+func setupDashRepos(t *testing.T) (a, b string) {
+	t.Helper()
+	a = setupInitedProject(t)
+	writePlanFileWithBody(t, a, makeTestPlan("alpha-plan", nil, time.Now()))
+	if err := runTaskCreate(a, taskCreateOptions{plan: testPlan, title: "Alpha task", priority: "high"}); err != nil {
+		t.Fatal(err)
+	}
+	b = setupInitedProject(t)
+	writePlanFileWithBody(t, b, makeTestPlan("beta-plan", nil, time.Now()))
+	return a, b
+}
+
+func TestDash_JSONAcrossRoots(t *testing.T) {
+	a, b := setupDashRepos(t)
+
+	out := captureOutput(t, func() {
+		if err := runDash([]string{filepath.Dir(a)}, 10, 5, true, time.Now()); err != nil {
+			t.Errorf("runDash: %v", err)
+		}
+	})
+	var repos []dashRepo
+	if err := json.Unmarshal([]byte(out), &repos); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(repos) != 2 {
+		t.Fatalf("expected 2 repos, got %+v", repos)
+	}
+	byRoot := map[string]dashRepo{}
+	for _, r := range repos {
+		byRoot[r.Root] = r
+	}
+	ra, rb := byRoot[a], byRoot[b]
+	if ra.OpenTasks != 1 || len(ra.Tasks) != 1 || ra.Tasks[0].Title != "Alpha task" {
+		t.Errorf("unexpected tasks for %s: %+v", a, ra)
+	}
+	if len(rb.RecentPlans) != 1 || rb.RecentPlans[0].Topic != "beta-plan" || rb.Tasks == nil {
+		t.Errorf("unexpected plans for %s: %+v", b, rb)
+	}
+}
+
+func TestDash_TextLimit(t *testing.T) {
+	a, b := setupDashRepos(t)
+	if err := runTaskCreate(a, taskCreateOptions{plan: testPlan, title: "Second task", priority: "low"}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runDash([]string{a, b}, 1, 1, false, time.Now()); err != nil {
+			t.Errorf("runDash: %v", err)
+		}
+	})
+	for _, want := range []string{"2 open task(s)", "Second task", "… and 1 more", "beta-plan", "Recent plans:"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestDash_NoRoots(t *testing.T) {
+	if err := runDash(nil, 10, 5, false, time.Now()); err == nil {
+		t.Error("expected an error without roots")
+	}
+	if err := runDash([]string{t.TempDir()}, 10, 5, false, time.Now()); err == nil {
+		t.Error("expected an error when no project is found")
+	}
+}
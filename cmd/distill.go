@@ -6,12 +6,11 @@ import (
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
-	"github.com/senna-lang/logosyncx/internal/gitutil"
 	"github.com/senna-lang/logosyncx/internal/project"
 	"github.com/senna-lang/logosyncx/internal/task"
-	"github.com/senna-lang/logosyncx/pkg/config"
 	"github.com/senna-lang/logosyncx/pkg/index"
 	"github.com/senna-lang/logosyncx/pkg/knowledge"
 	"github.com/senna-lang/logosyncx/pkg/plan"
@@ -56,7 +55,7 @@ func runDistill(planPartial string, force, dryRun bool) error {
 		return err
 	}
 
-	cfg, err := config.Load(root)
+	cfg, err := loadConfig(root)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
@@ -65,7 +64,7 @@ func runDistill(planPartial string, force, dryRun bool) error {
 
 	allPlans, err := plan.LoadAll(root)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		warnf("%v", err)
 	}
 	p, err := findPlan(planPartial, allPlans)
 	if err != nil {
@@ -79,7 +78,7 @@ func runDistill(planPartial string, force, dryRun bool) error {
 	store := task.NewStore(root, &cfg)
 	tasks, err := store.List(task.Filter{Plan: planSlug})
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning loading tasks: %v\n", err)
+		warnf("could not load tasks: %v", err)
 	}
 
 	// Pre-flight check 2: no tasks.
@@ -161,7 +160,11 @@ func runDistill(planPartial string, force, dryRun bool) error {
 		Topic: p.Topic,
 		Plan:  p.Filename,
 		Tasks: taskFilenames,
-		Tags:  p.Tags,
+		Tags:  slices.Clone(p.Tags),
+	}
+	fields := append([]privacyField{{"topic", &k.Topic}, {"source", &sourceBlock}}, tagFields(k.Tags)...)
+	if err := enforcePrivacy(cfg, "knowledge", fields...); err != nil {
+		return err
 	}
 
 	relKnowledgePath, err := knowledge.Write(root, k, sourceBlock, string(templateContent))
@@ -171,7 +174,7 @@ func runDistill(planPartial string, force, dryRun bool) error {
 
 	// Append walkthrough paths under the Source Walkthroughs section (§10.5).
 	if appendErr := appendWalkthroughPaths(filepath.Join(root, relKnowledgePath), root, tasks); appendErr != nil {
-		fmt.Fprintf(os.Stderr, "warning: could not append walkthrough paths: %v\n", appendErr)
+		warnf("could not append walkthrough paths: %v", appendErr)
 	}
 
 	// --- Mark plan as distilled -----------------------------------------------
@@ -183,11 +186,10 @@ func runDistill(planPartial string, force, dryRun bool) error {
 	}
 
 	// Rebuild plan index and git add (best-effort).
-	if _, err := index.Rebuild(root, cfg.Plans.ExcerptSection); err != nil {
-		fmt.Fprintf(os.Stderr, "warning: rebuild index: %v\n", err)
+	if _, err := index.RebuildWithOptions(root, planIndexOptions(cfg)); err != nil {
+		warnf("rebuild index: %v", err)
 	}
-	_ = gitutil.Add(root, filepath.Join(root, relKnowledgePath))
-	_ = gitutil.Add(root, planPath)
+	stageFilesByDefault(root, cfg, filepath.Join(root, relKnowledgePath), planPath)
 
 	// --- Output ---------------------------------------------------------------
 
@@ -199,11 +201,10 @@ func runDistill(planPartial string, force, dryRun bool) error {
 		fmt.Printf("           %03d %s [%s]\n", t.Seq, t.Title, status)
 	}
 	fmt.Println()
-	fmt.Printf("✓ Knowledge file written: %s\n", relKnowledgePath)
+	printSuccess("Knowledge file written: %s", relKnowledgePath)
 	rel, _ := relPath(root, planPath)
-	fmt.Printf("✓ Plan marked as distilled: %s\n", rel)
-	fmt.Println()
-	fmt.Printf("Next: Open %s and fill in the sections.\n", relKnowledgePath)
+	printSuccess("Plan marked as distilled: %s", rel)
+	printHint(fmt.Sprintf("Next: Open %s and fill in the sections.", relKnowledgePath))
 
 	return nil
 }
@@ -32,7 +32,7 @@ func setupDistillProject(t *testing.T, topic string) (root, planSlug string) {
 	planSlug = strings.TrimSuffix(plan.FileName(p), ".md")
 
 	// Create a task, write WALKTHROUGH.md content, then mark done.
-	if err := runTaskCreate(root, planSlug, "Test task one", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(root, taskCreateOptions{plan: planSlug, title: "Test task one", priority: "medium"}); err != nil {
 		t.Fatalf("create task: %v", err)
 	}
 
@@ -50,7 +50,7 @@ func setupDistillProject(t *testing.T, topic string) (root, planSlug string) {
 		t.Fatalf("write WALKTHROUGH.md: %v", err)
 	}
 
-	if err := runTaskUpdate("", "test-task-one", "done", "", ""); err != nil {
+	if err := runTaskUpdate("", "test-task-one", "done", "", "", ""); err != nil {
 		t.Fatalf("update task to done: %v", err)
 	}
 
@@ -183,7 +183,7 @@ func TestDistill_IncompleteTasks_Error(t *testing.T) {
 	planSlug := strings.TrimSuffix(plan.FileName(p), ".md")
 
 	// Create a task but do NOT mark it done.
-	if err := runTaskCreate(root, planSlug, "Open task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(root, taskCreateOptions{plan: planSlug, title: "Open task", priority: "medium"}); err != nil {
 		t.Fatalf("create task: %v", err)
 	}
 
@@ -209,7 +209,7 @@ func TestDistill_NoWalkthroughs_Error(t *testing.T) {
 	planSlug := strings.TrimSuffix(plan.FileName(p), ".md")
 
 	// Create task, write WALKTHROUGH.md, mark done, then remove WALKTHROUGH.md.
-	if err := runTaskCreate(root, planSlug, "Done task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(root, taskCreateOptions{plan: planSlug, title: "Done task", priority: "medium"}); err != nil {
 		t.Fatalf("create task: %v", err)
 	}
 
@@ -221,7 +221,7 @@ func TestDistill_NoWalkthroughs_Error(t *testing.T) {
 		_ = os.WriteFile(wtPath, []byte("# Walkthrough\n\nContent.\n"), 0o644)
 	}
 
-	if err := runTaskUpdate("", "done-task", "done", "", ""); err != nil {
+	if err := runTaskUpdate("", "done-task", "done", "", "", ""); err != nil {
 		t.Fatalf("update task to done: %v", err)
 	}
 
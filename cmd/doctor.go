@@ -0,0 +1,305 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/knowledge"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var doctorCmd = &cobra.Command{
+	Use:   "doctor",
+	Args:  cobra.NoArgs,
+	Short: "Check .logosyncx/ for misplaced, conflicted, or oversized files",
+	Long: `Report problem files under .logosyncx/:
+
+  conflict        plan, task, or knowledge files holding git conflict markers
+                  (<<<<<<<, =======, >>>>>>>), with their line numbers; such
+                  files get no excerpt in the indexes until the merge is
+                  resolved by hand
+  misplaced       Markdown files outside <plan>/NNN-<title>/TASK.md, e.g. in a
+                  legacy status directory such as tasks/done/ or moved by hand
+  unknown_status  TASK.md files whose status is not open, in_progress, or done
+  plan_mismatch   TASK.md files whose frontmatter plan names another plan than
+                  the tasks/<plan>/ directory they sit in
+  attachment      files larger than attachments.max_size_kb (default 1024),
+                  or binary files outside .logosyncx/attachments/; files
+                  stored with Git LFS are exempt
+  dead_link       related entries in plan frontmatter that name no existing
+                  plan, e.g. partial names saved before --related was
+                  validated
+  limit           more active plans, or a larger index or plan/task/knowledge
+                  file, than the limits section of config.json allows
+
+With --fix-status-dirs, misplaced task files are moved into the layout their
+frontmatter describes (tasks/<plan>/NNN-<title>/TASK.md). The frontmatter
+status wins over the directory the file was found in; a legacy status
+directory only supplies the status when the frontmatter has none. Each
+corrected file is reported. Unknown statuses are fixed with
+logos task migrate-status.
+
+With --fix-plan-fields, the plan field of each plan_mismatch task is set to
+its directory, which is where every command looks the task up. (A missing
+plan field is not a problem: the directory is used.)
+
+With --fix-links, each dead related entry that resolves to exactly one plan
+(as logos save --related would resolve it) is replaced by that plan's
+filename; entries that resolve to nothing, or to several plans, are removed.
+
+When attachments.lfs is true in config.json, doctor also expects
+.gitattributes to track .logosyncx/attachments/** with Git LFS; --fix-lfs
+adds the rule.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		fix, _ := cmd.Flags().GetBool("fix-status-dirs")
+		fixLFS, _ := cmd.Flags().GetBool("fix-lfs")
+		fixLinks, _ := cmd.Flags().GetBool("fix-links")
+		fixPlans, _ := cmd.Flags().GetBool("fix-plan-fields")
+		return runDoctor(fix, fixLFS, fixLinks, fixPlans)
+	},
+}
+
+func init() {
+	doctorCmd.Flags().Bool("fix-status-dirs", false, "Move misplaced task files into <plan>/NNN-<title>/TASK.md")
+	doctorCmd.Flags().Bool("fix-plan-fields", false, "Set the plan field of each plan_mismatch task to its directory")
+	doctorCmd.Flags().Bool("fix-links", false, "Repair or remove related entries that name no existing plan")
+	doctorCmd.Flags().Bool("fix-lfs", false, "Add the Git LFS rule for .logosyncx/attachments/ to .gitattributes")
+	rootCmd.AddCommand(doctorCmd)
+}
+
+// conflictFile is a Markdown file under .logosyncx/ holding git conflict
+// markers.
+type conflictFile struct {
+	rel   string // path relative to the project root
+	lines []int  // 1-based line numbers of the markers
+}
+
+// findConflicts scans every Markdown file under plans/ (archive included),
+// tasks/, the task archive, and knowledge/ for git conflict markers.
+func findConflicts(root string) []conflictFile {
+	var found []conflictFile
+	dirs := []string{
+		plan.PlansDir(root),
+		filepath.Join(root, config.DirName, "tasks"),
+		task.ArchiveDir(root),
+		knowledge.KnowledgeDir(root),
+	}
+	for _, dir := range dirs {
+		_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+			if err != nil || d.IsDir() || !strings.HasSuffix(path, ".md") {
+				return nil
+			}
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return nil
+			}
+			if lines := markdown.ConflictMarkers(string(data)); len(lines) > 0 {
+				rel, _ := relPath(root, path)
+				found = append(found, conflictFile{rel: rel, lines: lines})
+			}
+			return nil
+		})
+	}
+	return found
+}
+
+// reportConflicts prints a warning for every file holding git conflict
+// markers, so that logos sync never indexes one silently. It returns the
+// number of files found.
+func reportConflicts(root string) int {
+	conflicts := findConflicts(root)
+	if len(conflicts) == 0 {
+		return 0
+	}
+	if warningsJSON {
+		for _, c := range conflicts {
+			warnf("git conflict markers (indexed without an excerpt): %s — %s", c.rel, markdown.FormatLines(c.lines))
+		}
+		return len(conflicts)
+	}
+	fmt.Fprintf(os.Stderr, "\nwarning: %d file(s) contain git conflict markers (indexed without an excerpt):\n", len(conflicts))
+	for _, c := range conflicts {
+		fmt.Fprintf(os.Stderr, "  %s — %s\n", c.rel, markdown.FormatLines(c.lines))
+	}
+	fmt.Fprintln(os.Stderr, "  Resolve the merge in each file, then run `logos sync` again.")
+	return len(conflicts)
+}
+
+func runDoctor(fix, fixLFS, fixLinks, fixPlans bool) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+
+	conflicts := findConflicts(root)
+	attachments := findAttachmentProblems(root, cfg, nil)
+	missingLFS := cfg.Attachments.LFS && !hasLFSRule(root)
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	deadLinks := findDeadLinks(root, plans)
+	strays, err := store.FindStrays()
+	if err != nil {
+		warnf("%v", err)
+	}
+	limits := findGuardrailProblems(root, cfg)
+	if len(strays) == 0 && len(conflicts) == 0 && len(attachments) == 0 && !missingLFS && len(deadLinks) == 0 && len(limits) == 0 {
+		printSuccess("No problems found.")
+		return nil
+	}
+
+	if len(conflicts) > 0 {
+		fmt.Printf("%d file(s) with git conflict markers:\n", len(conflicts))
+		for _, c := range conflicts {
+			fmt.Printf("  [conflict] %s — %s\n", c.rel, markdown.FormatLines(c.lines))
+		}
+		printHint("Resolve the merge in each file, then run `logos sync`.")
+		fmt.Println()
+	}
+
+	if len(attachments) > 0 {
+		fmt.Printf("%d large or binary file(s):\n", len(attachments))
+		for _, p := range attachments {
+			fmt.Printf("  [attachment] %s — %s\n", p.rel, p.detail)
+		}
+		printHint("Move attachments to .logosyncx/attachments/ and track them with Git LFS (attachments.lfs).")
+		fmt.Println()
+	}
+
+	if len(limits) > 0 {
+		fmt.Printf("%d limit(s) exceeded:\n", len(limits))
+		for _, p := range limits {
+			fmt.Printf("  [limit] %s — %s\n", p.subject, p.detail)
+			fmt.Printf("          → %s\n", p.advice)
+		}
+		fmt.Println()
+	}
+
+	if missingLFS {
+		if fixLFS {
+			if err := addLFSRule(root); err != nil {
+				return fmt.Errorf("update .gitattributes: %w", err)
+			}
+			printSuccess("Added to .gitattributes: %s", lfsAttributesRule)
+		} else {
+			fmt.Println("attachments.lfs is set but .gitattributes has no LFS rule for .logosyncx/attachments/.")
+			printHint("Run `logos doctor --fix-lfs` to add it.")
+		}
+		fmt.Println()
+	}
+
+	if len(deadLinks) > 0 {
+		if err := reportDeadLinks(root, cfg, plans, deadLinks, fixLinks); err != nil {
+			return err
+		}
+		fmt.Println()
+	}
+
+	if len(strays) == 0 {
+		return nil
+	}
+	return reportStrayFiles(root, store, strays, fix, fixPlans)
+}
+
+// reportDeadLinks lists dead related entries and, with fix, repairs them and
+// rebuilds the plan index.
+func reportDeadLinks(root string, cfg config.Config, plans []plan.Plan, dead []deadLink, fix bool) error {
+	fmt.Printf("%d dead related link(s):\n", len(dead))
+	for _, d := range dead {
+		action := "remove"
+		if d.fix != "" {
+			action = "→ " + d.fix
+		}
+		fmt.Printf("  [dead_link] %s — related plan %q not found (%s)\n",
+			filepath.Join(".logosyncx", "plans", d.plan), d.ref, action)
+	}
+	if !fix {
+		printHint("Run `logos doctor --fix-links` to repair them.")
+		return nil
+	}
+	n, err := repairDeadLinks(root, cfg, plans, dead)
+	if err != nil {
+		return err
+	}
+	if _, err := index.RebuildWithOptions(root, planIndexOptions(cfg)); err != nil {
+		warnf("rebuild index: %v", err)
+	}
+	printSuccess("Repaired related links in %d plan(s).", n)
+	return nil
+}
+
+// reportStrayFiles lists stray task files and, with fix, moves misplaced
+// ones into place.
+func reportStrayFiles(root string, store *task.Store, strays []task.Stray, fix, fixPlans bool) error {
+	counts := map[task.StrayKind]int{}
+	fmt.Printf("%d problem(s) found:\n", len(strays))
+	for _, st := range strays {
+		rel, _ := relPath(root, st.Path)
+		fmt.Printf("  [%s] %s — %s\n", st.Kind, rel, st.Detail)
+		counts[st.Kind]++
+	}
+	misplaced, mismatched := counts[task.StrayMisplaced], counts[task.StrayPlanMismatch]
+
+	if !fix && !fixPlans {
+		if misplaced > 0 {
+			printHint("Run `logos doctor --fix-status-dirs` to move misplaced task files into place.")
+		}
+		if mismatched > 0 {
+			printHint("Run `logos doctor --fix-plan-fields` to set each task's plan field to its directory.")
+		}
+		if counts[task.StrayUnknownStatus] > 0 {
+			printHint("Run `logos task migrate-status --from <old> --to <new>` to fix unknown statuses.")
+		}
+		return nil
+	}
+
+	var errs []error
+	if fixPlans && mismatched > 0 {
+		fmt.Println()
+		n, err := store.FixPlanFields(strays)
+		if err != nil {
+			warnf("%v", err)
+		}
+		printSuccess("Corrected the plan field of %d of %d task(s).", n, mismatched)
+		if n < mismatched {
+			errs = append(errs, fmt.Errorf("%d plan field(s) could not be corrected — see warnings above", mismatched-n))
+		}
+	}
+	if !fix {
+		return errors.Join(errs...)
+	}
+
+	fmt.Println()
+	fixed := 0
+	for _, r := range store.RelocateStrays(strays) {
+		from, _ := relPath(root, r.From)
+		if r.Err != nil {
+			warnf("could not move %s: %v", from, r.Err)
+			continue
+		}
+		to, _ := relPath(root, r.To)
+		fmt.Printf("  moved %s → %s\n", from, to)
+		fixed++
+	}
+	printSuccess("Corrected %d of %d misplaced task file(s).", fixed, misplaced)
+	if fixed < misplaced {
+		errs = append(errs, fmt.Errorf("%d misplaced task file(s) could not be moved — see warnings above", misplaced-fixed))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+const doctorStrayMD = "---\nid: t-doc001\ndate: 2026-01-01T00:00:00Z\ntitle: Legacy task\nseq: 1\nstatus: done\npriority: medium\nplan: " + testPlan + "\ntags: []\nassignee: \"\"\n---\n\n## What\nOld.\n"
+
+func TestDoctor_NoProblems(t *testing.T) {
+	setupInitedProject(t)
+	out := captureOutput(t, func() {
+		if err := runDoctor(false, false, false, false); err != nil {
+			t.Fatalf("runDoctor: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No problems found.") {
+		t.Errorf("expected clean report, got: %q", out)
+	}
+}
+
+func TestDoctor_ReportsAndFixesMisplacedFile(t *testing.T) {
+	dir := setupInitedProject(t)
+	legacy := filepath.Join(dir, ".logosyncx", "tasks", "done", "2026-01-01_legacy.md")
+	if err := os.MkdirAll(filepath.Dir(legacy), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(legacy, []byte(doctorStrayMD), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runDoctor(false, false, false, false); err != nil {
+			t.Fatalf("runDoctor: %v", err)
+		}
+	})
+	if !strings.Contains(out, "[misplaced]") {
+		t.Errorf("expected misplaced report, got: %q", out)
+	}
+	if _, err := os.Stat(legacy); err != nil {
+		t.Fatalf("report-only run must not move files: %v", err)
+	}
+
+	out = captureOutput(t, func() {
+		if err := runDoctor(true, false, false, false); err != nil {
+			t.Fatalf("runDoctor --fix-status-dirs: %v", err)
+		}
+	})
+	if !strings.Contains(out, "moved .logosyncx/tasks/done/2026-01-01_legacy.md") {
+		t.Errorf("expected move report, got: %q", out)
+	}
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 || tasks[0].Title != "Legacy task" {
+		t.Fatalf("expected relocated task to be listed, got %v", tasks)
+	}
+}
+
+func TestDoctor_ReportsConflictMarkers(t *testing.T) {
+	dir := setupInitedProject(t)
+	p := makeSyncPlan("cf1", "merged", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	p.Body = "## Background\n<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> main\n"
+	writeSyncPlan(t, dir, p)
+
+	out := captureOutput(t, func() {
+		if err := runDoctor(false, false, false, false); err != nil {
+			t.Fatalf("runDoctor: %v", err)
+		}
+	})
+	if !strings.Contains(out, "[conflict] .logosyncx/plans/20260101-merged.md — lines") {
+		t.Errorf("expected conflict report, got: %q", out)
+	}
+}
+
+func TestDoctor_ReportsAndFixesDeadLinks(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runSave("auth refactor", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	// A legacy plan saved before --related was validated: one partial name
+	// that resolves, one that names nothing.
+	legacy := "---\nid: p-legacy\ndate: 2026-01-01T00:00:00Z\ntopic: legacy\nrelated:\n  - auth-refactor\n  - gone-plan.md\n---\n\n## Background\nOld.\n"
+	path := filepath.Join(dir, ".logosyncx", "plans", "20260101-legacy.md")
+	if err := os.WriteFile(path, []byte(legacy), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runDoctor(false, false, false, false); err != nil {
+			t.Fatalf("runDoctor: %v", err)
+		}
+	})
+	if !strings.Contains(out, "2 dead related link(s)") || !strings.Contains(out, "(remove)") {
+		t.Errorf("expected both dead links reported, got:\n%s", out)
+	}
+
+	captureOutput(t, func() {
+		if err := runDoctor(false, false, true, false); err != nil {
+			t.Fatalf("runDoctor --fix-links: %v", err)
+		}
+	})
+	p, err := plan.LoadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(p.Related) != 1 || !strings.HasSuffix(p.Related[0], "-auth-refactor.md") {
+		t.Errorf("related after fix = %v, want only the auth-refactor filename", p.Related)
+	}
+	if !strings.Contains(p.Body, "Old.") {
+		t.Errorf("expected body preserved, got %q", p.Body)
+	}
+}
+
+func TestDoctor_ReportsAndFixesPlanMismatch(t *testing.T) {
+	dir := setupInitedProject(t)
+	path := filepath.Join(dir, ".logosyncx", "tasks", "20260101-other-plan", "001-legacy-task", "TASK.md")
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(doctorStrayMD), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runDoctor(false, false, false, false); err != nil {
+			t.Fatalf("runDoctor: %v", err)
+		}
+	})
+	if !strings.Contains(out, "[plan_mismatch]") || !strings.Contains(out, "--fix-plan-fields") {
+		t.Errorf("expected plan_mismatch report and hint, got: %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		if err := runDoctor(false, false, false, true); err != nil {
+			t.Fatalf("runDoctor --fix-plan-fields: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Corrected the plan field of 1 of 1 task(s).") {
+		t.Errorf("expected fix report, got: %q", out)
+	}
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 || tasks[0].Plan != "20260101-other-plan" {
+		t.Fatalf("expected plan field to follow the directory, got %+v", tasks)
+	}
+}
@@ -0,0 +1,58 @@
+package cmd
+
+import (
+	"cmp"
+	"fmt"
+	"path/filepath"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// escalateInterval is how often logos watch re-applies tasks.escalation
+// when no task file has changed, so due dates that come into range are
+// noticed.
+const escalateInterval = time.Hour
+
+// escalateDueTasks applies tasks.escalation: unfinished tasks due within
+// due_within of now are raised to set_priority. It returns the escalated
+// tasks as "<plan>/<dir>" and reports problems as warnings. With
+// escalation.notify each task is also reported on stderr.
+func escalateDueTasks(cfg config.Config, store *task.Store, now time.Time) []string {
+	e := cfg.Tasks.Escalation
+	if e == nil {
+		return nil
+	}
+	within, err := config.ParseAge(e.DueWithin)
+	if err != nil {
+		warnf("tasks.escalation.due_within: %v — escalation skipped", err)
+		return nil
+	}
+	priority := task.Priority(cmp.Or(e.SetPriority, string(task.PriorityHigh)))
+	escalated, err := store.Escalate(now.Add(within), priority)
+	if err != nil {
+		warnf("escalation: %v", err)
+	}
+
+	names := make([]string, 0, len(escalated))
+	loc := displayLocation(cfg)
+	for _, t := range escalated {
+		name := t.Plan + "/" + filepath.Base(t.DirPath)
+		names = append(names, name)
+		if e.Notify {
+			verb := "is due"
+			if t.Due.Before(now) {
+				verb = "was due"
+			}
+			warnf("%s %s %s — priority raised to %s", name, verb, t.Due.In(loc).Format("2006-01-02"), priority)
+		}
+	}
+	return names
+}
+
+// escalationSummary is the line logos sync prints after escalating tasks.
+func escalationSummary(cfg config.Config, n int) string {
+	return fmt.Sprintf("Escalated %d task(s) due within %s to %s priority.",
+		n, cfg.Tasks.Escalation.DueWithin, cmp.Or(cfg.Tasks.Escalation.SetPriority, string(task.PriorityHigh)))
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// setupEscalation returns a project with a low-priority task due tomorrow
+// and tasks.escalation configured as given.
+func setupEscalation(t *testing.T, esc *config.EscalationConfig) string {
+	t.Helper()
+	dir, slug := setupExportProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Ship release notes", priority: "low"}); err != nil {
+		t.Fatal(err)
+	}
+	captureOutput(t, func() {
+		if err := runTaskUpdate("", "ship-release-notes", "", "", "", time.Now().AddDate(0, 0, 1).Format("2006-01-02")); err != nil {
+			t.Fatalf("runTaskUpdate --due: %v", err)
+		}
+	})
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Tasks.Escalation = esc
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+// taskPriority returns the priority of the task titled title.
+func taskPriority(t *testing.T, dir, title string) task.Priority {
+	t.Helper()
+	for _, tk := range loadAllTasks(t, dir) {
+		if tk.Title == title {
+			return tk.Priority
+		}
+	}
+	t.Fatalf("task %q not found", title)
+	return ""
+}
+
+func TestTaskUpdate_Due(t *testing.T) {
+	dir := setupEscalation(t, nil)
+	var due *time.Time
+	for _, tk := range loadAllTasks(t, dir) {
+		if tk.Title == "Ship release notes" {
+			due = tk.Due
+		}
+	}
+	if due == nil || due.Format("2006-01-02") != time.Now().AddDate(0, 0, 1).Format("2006-01-02") {
+		t.Fatalf("due = %v, want tomorrow", due)
+	}
+
+	captureOutput(t, func() {
+		if err := runTaskUpdate("", "ship-release-notes", "", "", "", "none"); err != nil {
+			t.Fatalf("runTaskUpdate --due none: %v", err)
+		}
+	})
+	for _, tk := range loadAllTasks(t, dir) {
+		if tk.Title == "Ship release notes" && tk.Due != nil {
+			t.Errorf("--due none should clear the due date, got %v", tk.Due)
+		}
+	}
+}
+
+func TestSync_EscalatesDueTasks(t *testing.T) {
+	dir := setupEscalation(t, &config.EscalationConfig{DueWithin: "3d", Notify: true})
+
+	var out string
+	stderr := captureStderr(t, func() {
+		out = captureOutput(t, func() {
+			if err := runSync("", false, false, false); err != nil {
+				t.Errorf("runSync: %v", err)
+			}
+		})
+	})
+	if !strings.Contains(out, "Escalated 1 task(s) due within 3d to high priority.") {
+		t.Errorf("expected escalation summary, got %q", out)
+	}
+	if !strings.Contains(stderr, "ship-release-notes is due") || !strings.Contains(stderr, "priority raised to high") {
+		t.Errorf("expected notification, got %q", stderr)
+	}
+	if p := taskPriority(t, dir, "Ship release notes"); p != task.PriorityHigh {
+		t.Errorf("priority = %s, want high", p)
+	}
+	entries, err := task.ReadAllTaskIndex(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Title == "Ship release notes" && (e.Priority != task.PriorityHigh || e.Due == nil) {
+			t.Errorf("index not refreshed: %+v", e)
+		}
+	}
+}
+
+func TestSync_EscalationJSONAndCheck(t *testing.T) {
+	dir := setupEscalation(t, &config.EscalationConfig{DueWithin: "1w", SetPriority: "medium"})
+
+	captureOutput(t, func() {
+		_ = runSync("", true, false, false)
+	})
+	if p := taskPriority(t, dir, "Ship release notes"); p != task.PriorityLow {
+		t.Fatalf("--check must not escalate, priority = %s", p)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runSync("", false, true, false); err != nil {
+			t.Errorf("runSync: %v", err)
+		}
+	})
+	var summary syncSummary
+	if err := json.Unmarshal([]byte(out), &summary); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(summary.Escalated) != 1 || !strings.HasSuffix(summary.Escalated[0], "ship-release-notes") {
+		t.Errorf("escalated = %v", summary.Escalated)
+	}
+	if p := taskPriority(t, dir, "Ship release notes"); p != task.PriorityMedium {
+		t.Errorf("priority = %s, want medium", p)
+	}
+}
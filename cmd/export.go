@@ -0,0 +1,295 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+// exportFormats lists the values accepted by logos export --format.
+var exportFormats = []string{"json", "csv", "markdown"}
+
+var exportCmd = &cobra.Command{
+	Use:   "export",
+	Short: "Dump all plans and tasks as JSON, CSV, or one Markdown file",
+	Long: `Export every plan and task, with their bodies, for archiving, migration,
+or feeding into other tools:
+
+  json       one JSON document: {"project", "exported_at", "plans", "tasks"}
+  csv        plans.csv and tasks.csv, one row per plan or task
+  markdown   a single Markdown file with a table of contents, every plan,
+             then every task; body headings are nested under each entry
+
+json and markdown are written to stdout unless --output names a file; csv
+writes into the directory named by --output (default: the current
+directory). Plans are listed newest first and tasks by plan, then seq.
+Archived plans and tasks are not exported; use logos bundle export for a
+complete, re-importable copy of .logosyncx/.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		output, _ := cmd.Flags().GetString("output")
+		return runExport(format, output, time.Now())
+	},
+}
+
+func init() {
+	exportCmd.Flags().String("format", "json", "Export format: json, csv, or markdown")
+	exportCmd.Flags().StringP("output", "o", "", "File to write (directory for csv); default stdout")
+	rootCmd.AddCommand(exportCmd)
+}
+
+// exportDocument is the json export of logos export.
+type exportDocument struct {
+	Project    string       `json:"project"`
+	ExportedAt time.Time    `json:"exported_at"`
+	Plans      []exportPlan `json:"plans"`
+	Tasks      []exportTask `json:"tasks"`
+}
+
+// exportPlan is a plan index entry with the plan's full body.
+type exportPlan struct {
+	index.Entry
+	Body string `json:"body"`
+}
+
+// exportTask is a task index entry with the task's full body.
+type exportTask struct {
+	task.TaskJSON
+	Body string `json:"body"`
+}
+
+func runExport(format, output string, now time.Time) error {
+	if !slices.Contains(exportFormats, format) {
+		return fmt.Errorf("invalid --format %q (use %s)", format, strings.Join(exportFormats, ", "))
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	doc := collectExport(root, cfg, now)
+
+	if format == "csv" {
+		dir := cmp.Or(output, ".")
+		if err := os.MkdirAll(dir, 0o755); err != nil {
+			return fmt.Errorf("create %s: %w", dir, err)
+		}
+		if err := writeExportCSV(filepath.Join(dir, "plans.csv"), planCSVRows(doc.Plans, cfg)); err != nil {
+			return err
+		}
+		if err := writeExportCSV(filepath.Join(dir, "tasks.csv"), taskCSVRows(doc.Tasks, cfg)); err != nil {
+			return err
+		}
+		printSuccess("Exported %d plan(s) and %d task(s) to %s", len(doc.Plans), len(doc.Tasks),
+			filepath.Join(dir, "{plans,tasks}.csv"))
+		return nil
+	}
+
+	w := io.Writer(os.Stdout)
+	var f *os.File
+	if output != "" && output != "-" {
+		if f, err = os.Create(output); err != nil {
+			return fmt.Errorf("write %s: %w", output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+	if format == "json" {
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		err = enc.Encode(doc)
+	} else {
+		_, err = io.WriteString(w, renderExportMarkdown(doc, cfg))
+	}
+	if err != nil {
+		return fmt.Errorf("write export: %w", err)
+	}
+	if f != nil {
+		if err := f.Close(); err != nil {
+			return fmt.Errorf("write %s: %w", output, err)
+		}
+		printSuccess("Exported %d plan(s) and %d task(s) to %s", len(doc.Plans), len(doc.Tasks), output)
+	}
+	return nil
+}
+
+// collectExport loads every plan and task. Unparseable files are skipped
+// with a warning.
+func collectExport(root string, cfg config.Config, now time.Time) exportDocument {
+	doc := exportDocument{
+		Project:    cfg.Project,
+		ExportedAt: now.UTC().Truncate(time.Second),
+		Plans:      []exportPlan{},
+		Tasks:      []exportTask{},
+	}
+
+	plans, err := plan.LoadAllWithOptions(root, planParseOptions(cfg))
+	if err != nil {
+		warnf("%v", err)
+	}
+	slices.SortFunc(plans, func(a, b plan.Plan) int {
+		return cmp.Or(cmp.Compare(planUnix(b), planUnix(a)), cmp.Compare(a.Filename, b.Filename))
+	})
+	for _, p := range plans {
+		doc.Plans = append(doc.Plans, exportPlan{Entry: index.FromPlan(p, plans), Body: p.Body})
+	}
+
+	tasks, err := task.NewStore(root, &cfg).List(task.Filter{})
+	if err != nil {
+		warnf("%v", err)
+	}
+	slices.SortFunc(tasks, func(a, b *task.Task) int {
+		return cmp.Or(cmp.Compare(a.Plan, b.Plan), cmp.Compare(a.Seq, b.Seq), cmp.Compare(a.DirPath, b.DirPath))
+	})
+	for _, t := range tasks {
+		entry := task.FromTask(t)
+		entry.Blocked = t.Blocked
+		entry.CanStart = t.Status == task.StatusOpen && !t.Blocked
+		doc.Tasks = append(doc.Tasks, exportTask{TaskJSON: entry, Body: t.Body})
+	}
+	return doc
+}
+
+// writeExportCSV writes rows, header first, to path.
+func writeExportCSV(path string, rows [][]string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	defer f.Close()
+	w := csv.NewWriter(f)
+	if err := w.WriteAll(rows); err != nil {
+		return fmt.Errorf("write %s: %w", path, err)
+	}
+	return f.Close()
+}
+
+// planCSVRows returns the header and one row per plan. List fields are
+// joined with ";".
+func planCSVRows(plans []exportPlan, cfg config.Config) [][]string {
+	rows := [][]string{{"id", "filename", "date", "topic", "category", "tags", "agent",
+		"related", "depends_on", "distilled", "pinned", "excerpt", "body"}}
+	loc := displayLocation(cfg)
+	for _, p := range plans {
+		rows = append(rows, []string{p.ID, p.Filename, p.Date.In(loc).Format(time.RFC3339), p.Topic,
+			p.Category, strings.Join(p.Tags, ";"), p.Agent, strings.Join(p.Related, ";"),
+			strings.Join(p.DependsOn, ";"), strconv.FormatBool(p.Distilled), strconv.FormatBool(p.Pinned),
+			p.Excerpt, p.Body})
+	}
+	return rows
+}
+
+// taskCSVRows returns the header and one row per task. List fields are
+// joined with ";".
+func taskCSVRows(tasks []exportTask, cfg config.Config) [][]string {
+	rows := [][]string{{"id", "plan", "seq", "date", "title", "status", "priority", "assignee",
+		"tags", "depends_on", "completed_at", "blocked", "excerpt", "body"}}
+	loc := displayLocation(cfg)
+	for _, t := range tasks {
+		deps := make([]string, len(t.DependsOn))
+		for i, d := range t.DependsOn {
+			deps[i] = strconv.Itoa(d)
+		}
+		completed := ""
+		if t.CompletedAt != nil {
+			completed = t.CompletedAt.In(loc).Format(time.RFC3339)
+		}
+		rows = append(rows, []string{t.ID, t.Plan, strconv.Itoa(t.Seq), t.Date.In(loc).Format(time.RFC3339),
+			t.Title, string(t.Status), string(t.Priority), t.Assignee, strings.Join(t.Tags, ";"),
+			strings.Join(deps, ";"), completed, strconv.FormatBool(t.Blocked), t.Excerpt, t.Body})
+	}
+	return rows
+}
+
+// renderExportMarkdown returns the markdown export: a table of contents
+// linking to an HTML anchor before every plan and task, then their bodies
+// with headings nested two levels below the entry's own heading.
+func renderExportMarkdown(doc exportDocument, cfg config.Config) string {
+	loc := displayLocation(cfg)
+	var b strings.Builder
+	fmt.Fprintf(&b, "# %s export\n\n", cmp.Or(doc.Project, "Logosyncx"))
+	fmt.Fprintf(&b, "_Exported by `logos export` on %s: %d plan(s), %d task(s)._\n\n",
+		doc.ExportedAt.In(loc).Format("2006-01-02 15:04"), len(doc.Plans), len(doc.Tasks))
+
+	b.WriteString("## Contents\n\n- Plans\n")
+	for _, p := range doc.Plans {
+		fmt.Fprintf(&b, "  - [%s](#%s)\n", p.Topic, exportAnchor("plan", p.ID, p.Filename))
+	}
+	b.WriteString("- Tasks\n")
+	for _, t := range doc.Tasks {
+		fmt.Fprintf(&b, "  - [%s / %s](#%s)\n", t.Plan, t.Title, exportAnchor("task", t.ID, t.DirPath))
+	}
+
+	for _, p := range doc.Plans {
+		fmt.Fprintf(&b, "\n---\n\n<a id=\"%s\"></a>\n\n## Plan: %s\n\n", exportAnchor("plan", p.ID, p.Filename), p.Topic)
+		fmt.Fprintf(&b, "`%s` · %s", p.Filename, p.Date.In(loc).Format("2006-01-02"))
+		if len(p.Tags) > 0 {
+			b.WriteString(" · tags: " + strings.Join(p.Tags, ", "))
+		}
+		if p.Agent != "" {
+			b.WriteString(" · agent: " + p.Agent)
+		}
+		b.WriteString("\n")
+		if body := strings.TrimSpace(nestHeadings(p.Body, 2)); body != "" {
+			b.WriteString("\n" + body + "\n")
+		}
+	}
+	for _, t := range doc.Tasks {
+		fmt.Fprintf(&b, "\n---\n\n<a id=\"%s\"></a>\n\n## Task: %s\n\n", exportAnchor("task", t.ID, t.DirPath), t.Title)
+		fmt.Fprintf(&b, "`%s` #%03d · %s · %s", t.Plan, t.Seq, t.Status, t.Priority)
+		if t.Assignee != "" {
+			b.WriteString(" · @" + t.Assignee)
+		}
+		b.WriteString("\n")
+		if body := strings.TrimSpace(nestHeadings(t.Body, 2)); body != "" {
+			b.WriteString("\n" + body + "\n")
+		}
+	}
+	return b.String()
+}
+
+// exportAnchor returns the HTML anchor id of a plan or task in the
+// markdown export, from its ID or, when that is empty, its path.
+func exportAnchor(kind, id, fallback string) string {
+	return kind + "-" + markdown.Slugify(cmp.Or(id, fallback))
+}
+
+// nestHeadings increases the level of every heading outside code fences by
+// n, capped at 6.
+func nestHeadings(body string, n int) string {
+	lines := strings.Split(body, "\n")
+	inFence := false
+	for i, line := range lines {
+		if strings.HasPrefix(strings.TrimSpace(line), "```") {
+			inFence = !inFence
+			continue
+		}
+		if inFence {
+			continue
+		}
+		if text, level, ok := markdown.ParseHeading(line); ok {
+			lines[i] = strings.Repeat("#", min(level+n, 6)) + " " + text
+		}
+	}
+	return strings.Join(lines, "\n")
+}
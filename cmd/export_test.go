@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// setupExportProject creates a project with one plan holding one task.
+func setupExportProject(t *testing.T) (dir, slug string) {
+	t.Helper()
+	dir = setupInitedProject(t)
+	p := makeTestPlan("auth-design", []string{"auth"}, time.Now())
+	p.Body = "## Background\n\nWhy we need auth.\n"
+	writePlanFileWithBody(t, dir, p)
+	slug = strings.TrimSuffix(plan.FileName(p), ".md")
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Add JWT middleware", priority: "high"}); err != nil {
+		t.Fatal(err)
+	}
+	return dir, slug
+}
+
+func TestExport_JSON(t *testing.T) {
+	_, slug := setupExportProject(t)
+
+	out := captureOutput(t, func() {
+		if err := runExport("json", "", time.Now()); err != nil {
+			t.Errorf("runExport: %v", err)
+		}
+	})
+	var doc exportDocument
+	if err := json.Unmarshal([]byte(out), &doc); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(doc.Plans) != 1 || doc.Plans[0].Filename != slug+".md" || !strings.Contains(doc.Plans[0].Body, "Why we need auth.") {
+		t.Errorf("unexpected plans: %+v", doc.Plans)
+	}
+	if len(doc.Tasks) != 1 || doc.Tasks[0].Title != "Add JWT middleware" || doc.Tasks[0].Plan != slug {
+		t.Errorf("unexpected tasks: %+v", doc.Tasks)
+	}
+}
+
+func TestExport_CSV(t *testing.T) {
+	dir, _ := setupExportProject(t)
+	outDir := filepath.Join(dir, "export")
+
+	captureOutput(t, func() {
+		if err := runExport("csv", outDir, time.Now()); err != nil {
+			t.Errorf("runExport: %v", err)
+		}
+	})
+	for name, want := range map[string]string{"plans.csv": "auth-design", "tasks.csv": "Add JWT middleware"} {
+		f, err := os.Open(filepath.Join(outDir, name))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rows, err := csv.NewReader(f).ReadAll()
+		f.Close()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(rows) != 2 || rows[0][0] != "id" || !strings.Contains(strings.Join(rows[1], ","), want) {
+			t.Errorf("%s: unexpected rows %q", name, rows)
+		}
+	}
+}
+
+func TestExport_Markdown(t *testing.T) {
+	dir, _ := setupExportProject(t)
+	out := filepath.Join(dir, "export.md")
+
+	captureOutput(t, func() {
+		if err := runExport("markdown", out, time.Now()); err != nil {
+			t.Errorf("runExport: %v", err)
+		}
+	})
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	md := string(data)
+	for _, want := range []string{
+		"## Contents",
+		"  - [auth-design](#plan-test01)",
+		"<a id=\"plan-test01\"></a>",
+		"## Plan: auth-design",
+		"#### Background",
+		"## Task: Add JWT middleware",
+	} {
+		if !strings.Contains(md, want) {
+			t.Errorf("markdown export missing %q:\n%s", want, md)
+		}
+	}
+}
+
+func TestExport_InvalidFormat(t *testing.T) {
+	setupInitedProject(t)
+	if err := runExport("xml", "", time.Now()); err == nil {
+		t.Error("expected an error for an unknown format")
+	}
+}
+
+func TestNestHeadings(t *testing.T) {
+	got := nestHeadings("## A\n```\n# not a heading\n```\n##### B", 2)
+	want := "#### A\n```\n# not a heading\n```\n###### B"
+	if got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
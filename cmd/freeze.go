@@ -0,0 +1,133 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/freeze"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var freezeCmd = &cobra.Command{
+	Use:   "freeze",
+	Short: "Make a finalized plan read-only",
+	Long: `Mark a plan as frozen, e.g. a decision record that has been signed off.
+The plan gets frozen: true in its frontmatter along with the time and a
+checksum of its body, and commands that would change it refuse to
+(incident log/close, meeting actions, task create --from-plan) or leave it
+out (retag, doctor --fix-links, sync --auto-link). logos check reports frozen plans
+whose body was edited by hand since.
+
+Pinning, distilling, and adding tasks to a frozen plan still work: they do
+not change the record itself.
+
+--unfreeze lifts the freeze and requires --force. Every freeze and unfreeze
+is appended, with the user (git user.name unless --as is given) and
+--reason, to .logosyncx/freeze-log.jsonl.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		unfreeze, _ := cmd.Flags().GetBool("unfreeze")
+		force, _ := cmd.Flags().GetBool("force")
+		reason, _ := cmd.Flags().GetString("reason")
+		as, _ := cmd.Flags().GetString("as")
+		return runFreeze(name, unfreeze, force, reason, as, time.Now())
+	},
+}
+
+func init() {
+	freezeCmd.Flags().StringP("name", "n", "", "Plan to freeze (exact or partial match)")
+	freezeCmd.Flags().Bool("unfreeze", false, "Make the plan editable again (requires --force)")
+	freezeCmd.Flags().Bool("force", false, "Confirm --unfreeze")
+	freezeCmd.Flags().String("reason", "", "Why the plan is frozen or unfrozen (recorded in the log)")
+	freezeCmd.Flags().String("as", "", "Record the action as this user instead of git user.name")
+	_ = freezeCmd.MarkFlagRequired("name")
+	rootCmd.AddCommand(freezeCmd)
+}
+
+func runFreeze(name string, unfreeze, force bool, reason, as string, now time.Time) error {
+	if unfreeze && !force {
+		return errors.New("unfreezing a finalized plan requires --force")
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	user, err := ackUser(root, as)
+	if err != nil {
+		return err
+	}
+
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	matches := matchPlans(plans, name)
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("no plan found matching %q", name)
+	case 1:
+	default:
+		return printPlanCandidates(matches, name, nil)
+	}
+	p := matches[0]
+	if len(p.Conflicts) > 0 {
+		return fmt.Errorf("%s has unresolved merge conflicts — resolve them first", p.Filename)
+	}
+
+	at := now.Truncate(time.Second)
+	action := freeze.ActionFreeze
+	if unfreeze {
+		if !p.Frozen {
+			fmt.Printf("%s is not frozen.\n", p.Filename)
+			return nil
+		}
+		action = freeze.ActionUnfreeze
+		p.Frozen, p.FrozenAt, p.FrozenSum = false, nil, ""
+	} else {
+		if p.Frozen {
+			fmt.Printf("%s is already frozen.\n", p.Filename)
+			return nil
+		}
+		p.Frozen, p.FrozenAt, p.FrozenSum = true, &at, plan.BodySum(p.Body)
+	}
+
+	path, err := plan.Write(root, p)
+	if err != nil {
+		return fmt.Errorf("write plan: %w", err)
+	}
+	if _, err := index.RebuildWithOptions(root, planIndexOptions(cfg)); err != nil {
+		warnf("rebuild index: %v", err)
+	}
+	entry := freeze.Entry{Action: action, Plan: p.Filename, User: user, At: at, Reason: reason}
+	if err := freeze.Append(root, entry); err != nil {
+		return err
+	}
+	stageFiles(root, cfg, path, index.FilePath(root), freeze.Path(root))
+
+	if unfreeze {
+		printSuccess("Unfroze %s", p.Filename)
+	} else {
+		printSuccess("Froze %s", p.Filename)
+	}
+	return nil
+}
+
+// refuseFrozen returns an error when p is frozen, for commands that would
+// change it.
+func refuseFrozen(p plan.Plan) error {
+	if !p.Frozen {
+		return nil
+	}
+	return fmt.Errorf("%s is frozen — unfreeze it first with logos freeze --name %s --unfreeze --force",
+		p.Filename, strings.TrimSuffix(p.Filename, ".md"))
+}
@@ -0,0 +1,110 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/freeze"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// setupFrozenPlan writes a decision plan and freezes it as alice. It returns
+// the project root and the plan's slug.
+func setupFrozenPlan(t *testing.T) (dir, slug string) {
+	t.Helper()
+	dir = setupInitedProject(t)
+	p := makeSyncPlan("p1", "db-choice", time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC))
+	p.Body = "## Decision\nUse Postgres.\n\n## Action Items\n- Provision the database\n"
+	writeSyncPlan(t, dir, p)
+	captureOutput(t, func() {
+		if err := runFreeze("db-choice", false, false, "signed off", "alice", time.Now()); err != nil {
+			t.Fatalf("runFreeze: %v", err)
+		}
+	})
+	return dir, strings.TrimSuffix(plan.FileName(p), ".md")
+}
+
+func TestFreeze_MarksPlanAndLogs(t *testing.T) {
+	dir, slug := setupFrozenPlan(t)
+
+	p, err := plan.LoadFile(filepath.Join(plan.PlansDir(dir), slug+".md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !p.Frozen || p.FrozenAt == nil || p.FrozenSum != plan.BodySum(p.Body) {
+		t.Errorf("plan = frozen %v at %v sum %q; want frozen with the body's sum", p.Frozen, p.FrozenAt, p.FrozenSum)
+	}
+	entries, err := freeze.Load(dir)
+	if err != nil || len(entries) != 1 {
+		t.Fatalf("log = %v, %v", entries, err)
+	}
+	if e := entries[0]; e.Action != freeze.ActionFreeze || e.Plan != slug+".md" || e.User != "alice" || e.Reason != "signed off" {
+		t.Errorf("log entry = %+v", e)
+	}
+}
+
+func TestFreeze_RefusesEdits(t *testing.T) {
+	dir, slug := setupFrozenPlan(t)
+
+	err := runTaskCreateFromPlan(dir, slug, "", nil, false)
+	if err == nil || !strings.Contains(err.Error(), "is frozen") {
+		t.Errorf("runTaskCreateFromPlan = %v, want a frozen plan error", err)
+	}
+	if tasks := loadAllTasks(t, dir); len(tasks) != 0 {
+		t.Errorf("got %d tasks, want none", len(tasks))
+	}
+}
+
+func TestFreeze_UnfreezeRequiresForce(t *testing.T) {
+	dir, slug := setupFrozenPlan(t)
+
+	if err := runFreeze(slug, true, false, "", "bob", time.Now()); err == nil || !strings.Contains(err.Error(), "--force") {
+		t.Fatalf("runFreeze --unfreeze = %v, want an error asking for --force", err)
+	}
+	captureOutput(t, func() {
+		if err := runFreeze(slug, true, true, "typo in decision", "bob", time.Now()); err != nil {
+			t.Fatalf("runFreeze --unfreeze --force: %v", err)
+		}
+	})
+	p, err := plan.LoadFile(filepath.Join(plan.PlansDir(dir), slug+".md"))
+	if err != nil || p.Frozen || p.FrozenSum != "" {
+		t.Errorf("plan = %+v, %v; want it unfrozen", p, err)
+	}
+	entries, _ := freeze.Load(dir)
+	if len(entries) != 2 || entries[1].Action != freeze.ActionUnfreeze || entries[1].User != "bob" {
+		t.Errorf("log = %+v", entries)
+	}
+}
+
+func TestCheck_FrozenPlanEditedByHand(t *testing.T) {
+	dir, slug := setupFrozenPlan(t)
+	path := filepath.Join(plan.PlansDir(dir), slug+".md")
+
+	if problems := checkFrozen(checkInputs{plans: loadPlans(t, dir)}); len(problems) != 0 {
+		t.Fatalf("checkFrozen = %v, want none right after freezing", problems)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(path, []byte(strings.Replace(string(data), "Postgres", "MySQL", 1)), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	problems := checkFrozen(checkInputs{plans: loadPlans(t, dir)})
+	if len(problems) != 1 || !strings.Contains(problems[0], slug+".md") {
+		t.Errorf("checkFrozen = %v, want the edited plan", problems)
+	}
+}
+
+// loadPlans loads every plan of the project at dir.
+func loadPlans(t *testing.T, dir string) []plan.Plan {
+	t.Helper()
+	plans, err := plan.LoadAll(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return plans
+}
@@ -1,15 +1,15 @@
 package cmd
 
 import (
-	"bufio"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
-	"github.com/senna-lang/logosyncx/internal/gitutil"
 	"github.com/senna-lang/logosyncx/internal/project"
 	"github.com/senna-lang/logosyncx/internal/task"
 	"github.com/senna-lang/logosyncx/pkg/config"
@@ -38,8 +38,13 @@ A plan is a GC candidate when one of the following is true:
 Plans with at least one linked task still open or in_progress are
 protected and will never be selected.
 
+When tasks.retention is configured (e.g. {"done": "60d"}), tasks in each
+listed status older than the given age are then archived to
+.logosyncx/tasks-archive/ — or deleted, with tasks.retention_action set to
+"delete". Tasks that a remaining task depends on are kept.
+
 Use --dry-run to preview candidates without moving any files.
-Run "logos gc purge" to permanently delete all archived plans.`,
+Run "logos gc purge" to permanently delete all archived plans and tasks.`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		dryRun, _ := cmd.Flags().GetBool("dry-run")
@@ -53,11 +58,14 @@ Run "logos gc purge" to permanently delete all archived plans.`,
 
 var gcPurgeCmd = &cobra.Command{
 	Use:   "purge",
-	Short: "Permanently delete all plans in plans/archive/",
-	Long: `Delete every plan file stored in .logosyncx/plans/archive/.
+	Short: "Permanently delete all archived plans and tasks",
+	Long: `Delete every plan file stored in .logosyncx/plans/archive/ and every task
+archived by "logos task purge" under .logosyncx/tasks-archive/.
 
 This is irreversible. Use --dry-run on "logos gc" first to inspect what
-was archived before running this command.`,
+was archived before running this command. A confirmation prompt is shown
+unless --force or --yes is passed; when stdin is not a terminal the command
+fails instead of prompting.`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		force, _ := cmd.Flags().GetBool("force")
@@ -70,7 +78,7 @@ func init() {
 	gcCmd.Flags().Int("linked-days", 0, "Days since task completion before a distilled plan is archived (default from config: 30)")
 	gcCmd.Flags().Int("orphan-days", 0, "Days since creation before a plan with no tasks is archived (default from config: 90)")
 
-	gcPurgeCmd.Flags().Bool("force", false, "Skip confirmation prompt")
+	gcPurgeCmd.Flags().Bool("force", false, "Skip confirmation prompt (same as --yes)")
 
 	gcCmd.AddCommand(gcPurgeCmd)
 	rootCmd.AddCommand(gcCmd)
@@ -101,7 +109,7 @@ func runGC(dryRun bool, linkedDays, orphanDays int, linkedChanged, orphanChanged
 	if err != nil {
 		return err
 	}
-	cfg, err := config.Load(root)
+	cfg, err := loadConfig(root)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
@@ -119,6 +127,14 @@ func runGC(dryRun bool, linkedDays, orphanDays int, linkedChanged, orphanChanged
 		return err
 	}
 
+	if err := gcPlans(root, cfg, candidates, dryRun, linkedDays, orphanDays); err != nil {
+		return err
+	}
+	return runRetention(root, &cfg, dryRun, time.Now())
+}
+
+// gcPlans archives the candidate plans (or, with dryRun, lists them).
+func gcPlans(root string, cfg config.Config, candidates []gcCandidate, dryRun bool, linkedDays, orphanDays int) error {
 	if len(candidates) == 0 {
 		fmt.Println("No plans eligible for archival.")
 		return nil
@@ -135,16 +151,13 @@ func runGC(dryRun bool, linkedDays, orphanDays int, linkedChanged, orphanChanged
 	for _, c := range candidates {
 		dst, err := plan.Archive(root, c.p.Filename)
 		if err != nil {
-			fmt.Fprintf(os.Stderr, "warning: could not archive %s: %v\n", c.p.Filename, err)
+			warnf("could not archive %s: %v", c.p.Filename, err)
 			continue
 		}
 
 		// git: remove old path, stage new path (best-effort).
-		if cfg.Git.AutoPush {
-			oldPath := filepath.Join(plan.PlansDir(root), c.p.Filename)
-			_ = gitutil.Remove(root, oldPath)
-			_ = gitutil.Add(root, dst)
-		}
+		stageRemovals(root, cfg, filepath.Join(plan.PlansDir(root), c.p.Filename))
+		stageFiles(root, cfg, dst)
 
 		fmt.Printf("  → archived %s\n", c.p.Filename)
 		archived++
@@ -155,16 +168,84 @@ func runGC(dryRun bool, linkedDays, orphanDays int, linkedChanged, orphanChanged
 	}
 
 	// Rebuild plan index so archived plans no longer appear in logos ls.
-	n, err := index.Rebuild(root, cfg.Plans.ExcerptSection)
+	n, err := index.RebuildWithOptions(root, planIndexOptions(cfg))
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: plan index rebuild: %v\n", err)
+		warnf("plan index rebuild: %v", err)
+	}
+	stageFiles(root, cfg, index.FilePath(root))
+
+	printSuccess("Archived %d plan(s). Plan index rebuilt (%d active plans).", archived, n)
+	if !forAgent {
+		fmt.Println("  Run `logos gc purge --force` to permanently delete archived plans.")
+	}
+	return nil
+}
+
+// runRetention applies the per-status retention policy in tasks.retention,
+// archiving or deleting (per tasks.retention_action) every task older than
+// its status's limit. With dryRun the selection is only listed.
+func runRetention(root string, cfg *config.Config, dryRun bool, now time.Time) error {
+	if len(cfg.Tasks.Retention) == 0 {
+		return nil
+	}
+	action := cfg.Tasks.RetentionAction
+	if action == "" {
+		action = "archive"
+	}
+	if action != "archive" && action != "delete" {
+		return fmt.Errorf("invalid tasks.retention_action %q: must be archive or delete", action)
+	}
+
+	store := task.NewStore(root, cfg)
+	statuses := slices.Sorted(maps.Keys(cfg.Tasks.Retention))
+	var selected []*task.Task
+	for _, status := range statuses {
+		if !task.IsValidStatus(task.Status(status)) {
+			warnf("tasks.retention: unknown status %q — skipped", status)
+			continue
+		}
+		age, err := config.ParseAge(cfg.Tasks.Retention[status])
+		if err != nil {
+			return fmt.Errorf("invalid tasks.retention.%s: %w", status, err)
+		}
+		purge, kept, err := store.PurgeCandidates(task.PurgeFilter{Statuses: []task.Status{task.Status(status)}, Before: now.Add(-age)})
+		if err != nil {
+			warnf("%v", err)
+		}
+		for _, t := range kept {
+			warnf("keeping %s/%s — a remaining task depends on it", t.Plan, filepath.Base(t.DirPath))
+		}
+		selected = append(selected, purge...)
 	}
-	if cfg.Git.AutoPush {
-		_ = gitutil.Add(root, index.FilePath(root))
+
+	fmt.Println()
+	if len(selected) == 0 {
+		fmt.Println("No tasks past their retention period.")
+		return nil
+	}
+
+	if dryRun {
+		fmt.Printf("Tasks past their retention period (%s):\n", action)
+		for _, t := range selected {
+			fmt.Printf("  %s/%s [%s]\n", t.Plan, filepath.Base(t.DirPath), t.Status)
+		}
+		fmt.Printf("\n%d task(s) would be %sd. Run without --dry-run to proceed.\n", len(selected), action)
+		return nil
 	}
 
-	fmt.Printf("✓ Archived %d plan(s). Plan index rebuilt (%d active plans).\n", archived, n)
-	fmt.Println("  Run `logos gc purge --force` to permanently delete archived plans.")
+	if action == "delete" {
+		n, err := store.DeleteTasks(selected)
+		if err != nil {
+			return fmt.Errorf("delete tasks: %w", err)
+		}
+		printSuccess("Deleted %d task(s) past their retention period.", n)
+		return nil
+	}
+	n, err := store.Archive(selected)
+	if err != nil {
+		return fmt.Errorf("archive tasks: %w", err)
+	}
+	printSuccess("Archived %d task(s) past their retention period to .logosyncx/tasks-archive/.", n)
 	return nil
 }
 
@@ -173,51 +254,70 @@ func runGCPurge(force bool) error {
 	if err != nil {
 		return err
 	}
-	cfg, err := config.Load(root)
+	cfg, err := loadConfig(root)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
 	archivedFiles, err := loadArchivedPlanFilenames(root)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		warnf("%v", err)
 	}
-	if len(archivedFiles) == 0 {
-		fmt.Println("No archived plans to purge.")
+	archivedTasks, err := loadArchivedTaskDirs(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	if len(archivedFiles) == 0 && len(archivedTasks) == 0 {
+		fmt.Println("No archived plans or tasks to purge.")
 		return nil
 	}
 
-	fmt.Printf("This will permanently delete %d archived plan(s):\n", len(archivedFiles))
-	for _, f := range archivedFiles {
-		fmt.Printf("  - %s\n", f)
+	if len(archivedFiles) > 0 {
+		fmt.Printf("This will permanently delete %d archived plan(s):\n", len(archivedFiles))
+		for _, f := range archivedFiles {
+			fmt.Printf("  - %s\n", f)
+		}
 	}
-
-	if !force {
-		fmt.Print("\nConfirm permanent deletion? [y/N]: ")
-		scanner := bufio.NewScanner(os.Stdin)
-		scanner.Scan()
-		answer := strings.ToLower(strings.TrimSpace(scanner.Text()))
-		if answer != "y" && answer != "yes" {
-			fmt.Println("Aborted.")
-			return nil
+	if len(archivedTasks) > 0 {
+		fmt.Printf("This will permanently delete %d archived task(s):\n", len(archivedTasks))
+		for _, d := range archivedTasks {
+			fmt.Printf("  - %s\n", d)
 		}
 	}
 
+	ok, err := confirm(cfg, "Confirm permanent deletion?", force, "--force")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
 	archiveDir := plan.ArchiveDir(root)
 	count := 0
 	for _, f := range archivedFiles {
 		path := filepath.Join(archiveDir, f)
 		if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
-			fmt.Fprintf(os.Stderr, "warning: could not delete %s: %v\n", f, err)
+			warnf("could not delete %s: %v", f, err)
 			continue
 		}
-		if cfg.Git.AutoPush {
-			_ = gitutil.Remove(root, path)
-		}
+		stageRemovals(root, cfg, path)
 		count++
 	}
 
-	fmt.Printf("✓ Permanently deleted %d archived plan(s).\n", count)
+	taskCount := 0
+	for _, d := range archivedTasks {
+		path := filepath.Join(task.ArchiveDir(root), d)
+		stageRemovals(root, cfg, path)
+		if err := os.RemoveAll(path); err != nil {
+			warnf("could not delete %s: %v", d, err)
+			continue
+		}
+		taskCount++
+	}
+
+	printSuccess("Permanently deleted %d archived plan(s) and %d archived task(s).", count, taskCount)
 	return nil
 }
 
@@ -240,13 +340,41 @@ func loadArchivedPlanFilenames(root string) ([]string, error) {
 	return files, nil
 }
 
+// loadArchivedTaskDirs returns the "<plan>/<NNN-title>" paths of every task
+// directory in the task archive.
+func loadArchivedTaskDirs(root string) ([]string, error) {
+	planDirs, err := os.ReadDir(task.ArchiveDir(root))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read task archive: %w", err)
+	}
+	var dirs []string
+	for _, pd := range planDirs {
+		if !pd.IsDir() {
+			continue
+		}
+		taskDirs, err := os.ReadDir(filepath.Join(task.ArchiveDir(root), pd.Name()))
+		if err != nil {
+			return dirs, fmt.Errorf("read task archive: %w", err)
+		}
+		for _, td := range taskDirs {
+			if td.IsDir() {
+				dirs = append(dirs, filepath.Join(pd.Name(), td.Name()))
+			}
+		}
+	}
+	return dirs, nil
+}
+
 // findGCCandidates loads all active plans and evaluates each one against
 // the GC criteria, returning the list of plans eligible for archival.
 func findGCCandidates(root string, cfg *config.Config, linkedDays, orphanDays int) ([]gcCandidate, error) {
 	plans, err := plan.LoadAll(root)
 	if err != nil {
 		// Non-fatal: LoadAll returns partial results on parse errors.
-		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		warnf("%v", err)
 	}
 
 	store := task.NewStore(root, cfg)
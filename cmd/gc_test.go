@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// setupRetention creates one done task completed daysAgo days ago and
+// configures tasks.retention = {done: "30d"} with the given action.
+func setupRetention(t *testing.T, action string, daysAgo int) string {
+	t.Helper()
+	dir := setupInitedProject(t)
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	cfg.Tasks.Retention = map[string]string{"done": "30d"}
+	cfg.Tasks.RetentionAction = action
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatalf("config.Save: %v", err)
+	}
+
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Old finished task", priority: "medium"}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	tk := loadAllTasks(t, dir)[0]
+	completed := time.Now().AddDate(0, 0, -daysAgo)
+	tk.Status = task.StatusDone
+	tk.CompletedAt = &completed
+	data, err := task.Marshal(*tk)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tk.DirPath, "TASK.md"), data, 0o644); err != nil {
+		t.Fatalf("write TASK.md: %v", err)
+	}
+	return dir
+}
+
+func TestGC_Retention_DryRunListsOnly(t *testing.T) {
+	dir := setupRetention(t, "", 40)
+
+	out := captureOutput(t, func() {
+		if err := runGC(true, 0, 0, false, false); err != nil {
+			t.Fatalf("runGC: %v", err)
+		}
+	})
+	if !strings.Contains(out, "old-finished-task") || !strings.Contains(out, "1 task(s) would be archived") {
+		t.Errorf("expected retention dry-run listing, got: %q", out)
+	}
+	if len(loadAllTasks(t, dir)) != 1 {
+		t.Error("--dry-run must not remove tasks")
+	}
+}
+
+func TestGC_Retention_ArchivesByDefault(t *testing.T) {
+	dir := setupRetention(t, "", 40)
+
+	captureOutput(t, func() {
+		if err := runGC(false, 0, 0, false, false); err != nil {
+			t.Fatalf("runGC: %v", err)
+		}
+	})
+	if len(loadAllTasks(t, dir)) != 0 {
+		t.Fatal("expected the old done task to be removed from tasks/")
+	}
+	if _, err := os.Stat(filepath.Join(task.ArchiveDir(dir), testPlan)); err != nil {
+		t.Errorf("expected task in archive: %v", err)
+	}
+}
+
+func TestGC_Retention_DeleteAction(t *testing.T) {
+	dir := setupRetention(t, "delete", 40)
+
+	captureOutput(t, func() {
+		if err := runGC(false, 0, 0, false, false); err != nil {
+			t.Fatalf("runGC: %v", err)
+		}
+	})
+	if len(loadAllTasks(t, dir)) != 0 {
+		t.Fatal("expected the old done task to be deleted")
+	}
+	if _, err := os.Stat(task.ArchiveDir(dir)); !os.IsNotExist(err) {
+		t.Errorf("delete action must not create the archive, stat err = %v", err)
+	}
+}
+
+func TestGC_Retention_KeepsRecentTasks(t *testing.T) {
+	dir := setupRetention(t, "", 5)
+
+	out := captureOutput(t, func() {
+		if err := runGC(false, 0, 0, false, false); err != nil {
+			t.Fatalf("runGC: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No tasks past their retention period.") {
+		t.Errorf("expected nothing past retention, got: %q", out)
+	}
+	if len(loadAllTasks(t, dir)) != 1 {
+		t.Error("recent task must be kept")
+	}
+}
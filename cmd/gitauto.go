@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// gitOverride is the --git level of the current run, or "" without --git.
+// It is applied by loadConfig rather than through $LOGOS_GIT, so it does not
+// reach git hooks, excerpt commands, or watch jobs.
+var gitOverride string
+
+// loadConfig is config.Load with the --git override applied.
+func loadConfig(root string) (config.Config, error) {
+	cfg, err := config.Load(root)
+	if err != nil {
+		return cfg, err
+	}
+	if gitOverride != "" {
+		cfg.Git.Override = gitOverride
+	}
+	return cfg, nil
+}
+
+// stageFiles stages paths with git when git.auto (or --git) is add or
+// above. Staging is best-effort: failures are ignored.
+func stageFiles(root string, cfg config.Config, paths ...string) {
+	if !cfg.Git.Stages() {
+		return
+	}
+	for _, p := range paths {
+		_ = gitutil.Add(root, p)
+	}
+}
+
+// stageFilesByDefault is stageFiles for the commands that stage their
+// writes even when git.auto is unset (see GitConfig.StagesByDefault).
+func stageFilesByDefault(root string, cfg config.Config, paths ...string) {
+	if !cfg.Git.StagesByDefault() {
+		return
+	}
+	for _, p := range paths {
+		_ = gitutil.Add(root, p)
+	}
+}
+
+// stageRemovals stages the removal of paths with git under the same rule as
+// stageFiles.
+func stageRemovals(root string, cfg config.Config, paths ...string) {
+	if !cfg.Git.Stages() {
+		return
+	}
+	for _, p := range paths {
+		_ = gitutil.Remove(root, p)
+	}
+}
+
+// autoCommit commits paths, the files (or task directories) the command
+// wrote and staged, with the git.commit_messages template for kind, when
+// git.auto is commit or push, then pushes when it is push. Anything else the
+// user staged is left out of the commit. Git failures are warnings: the
+// files are already written.
+func autoCommit(root string, cfg config.Config, kind string, vars map[string]string, paths ...string) {
+	if !cfg.Git.Commits(kind) || len(paths) == 0 {
+		return
+	}
+	if err := gitutil.Commit(root, cfg.Git.CommitMessage(kind, vars), paths...); err != nil {
+		warnf("git commit failed: %v", err)
+		return
+	}
+	if !cfg.Git.Pushes(kind) {
+		return
+	}
+	if err := gitutil.Push(root); err != nil {
+		warnf("git push failed: %v", err)
+	}
+}
+
+// commitTaskUpdate is autoCommit for an update that moved the task titled
+// title from status from to status to; moving it to done uses the
+// task_done template. dirs are the updated task directories; the task index
+// is committed with them.
+func commitTaskUpdate(root string, cfg config.Config, title, plan string, from, to task.Status, dirs ...string) {
+	kind := config.CommitTaskUpdate
+	if to == task.StatusDone && from != task.StatusDone {
+		kind = config.CommitTaskDone
+	}
+	paths := append(slices.Clone(dirs), task.TaskIndexFilePath(root))
+	autoCommit(root, cfg, kind, map[string]string{"title": title, "plan": plan, "from": string(from), "to": string(to)}, paths...)
+}
+
+// createdTaskPaths returns the paths logos task create writes for tasks
+// created in dirs, for autoCommit: the task directories, the task index, and
+// the ID counter in sequential ID mode.
+func createdTaskPaths(root string, cfg config.Config, dirs ...string) []string {
+	paths := append(slices.Clone(dirs), task.TaskIndexFilePath(root))
+	if cfg.Tasks.IDMode == task.IDModeSequential {
+		paths = append(paths, task.IDCounterFilePath(root))
+	}
+	return paths
+}
+
+// checkGitLevel validates a --git value.
+func checkGitLevel(level string) error {
+	if !slices.Contains(config.GitLevels, level) {
+		return fmt.Errorf("--git: %q must be %s", level, strings.Join(config.GitLevels, ", "))
+	}
+	return nil
+}
@@ -0,0 +1,314 @@
+package cmd
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// lastCommitSubject returns the subject of HEAD in dir.
+func lastCommitSubject(t *testing.T, dir string) string {
+	t.Helper()
+	c := exec.Command("git", "log", "-1", "--format=%s")
+	c.Dir = dir
+	out, err := c.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git log: %v\n%s", err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestGitAuto_CommitLevelCommitsSaveAndTaskChanges(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	t.Chdir(dir)
+	if err := runInit(true); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := config.Load(dir)
+	cfg.Git.Auto = config.GitCommit
+	cfg.Git.CommitMessages = map[string]string{config.CommitSave: "docs(plan): {{topic}}"}
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runSave("Cache design", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	// config.json itself is not staged by save, so the commit holds the plan.
+	if got := lastCommitSubject(t, dir); got != "docs(plan): Cache design" {
+		t.Errorf("save commit = %q", got)
+	}
+
+	slug := onlyPlanSlug(t, dir)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Add LRU", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastCommitSubject(t, dir); got != "logos: create task: Add LRU" {
+		t.Errorf("task create commit = %q", got)
+	}
+	if err := runTaskUpdate("", "add-lru", string(task.StatusInProgress), "", "", ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastCommitSubject(t, dir); got != "logos: update task: Add LRU" {
+		t.Errorf("task update commit = %q", got)
+	}
+}
+
+// committedFiles returns the paths changed by HEAD in dir.
+func committedFiles(t *testing.T, dir string) string {
+	t.Helper()
+	c := exec.Command("git", "show", "--name-only", "--format=", "HEAD")
+	c.Dir = dir
+	out, err := c.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git show: %v\n%s", err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestGitAuto_CommitLeavesUserStagedFilesOut(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	t.Chdir(dir)
+	if err := runInit(true); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.GitEnv, config.GitCommit)
+	if err := os.WriteFile("wip.txt", []byte("work in progress\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("git", "add", "wip.txt").CombinedOutput(); err != nil {
+		t.Fatalf("git add: %v\n%s", err, out)
+	}
+
+	if err := runSave("Cache design", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastCommitSubject(t, dir); got != "logos: save plan: Cache design" {
+		t.Fatalf("save commit = %q", got)
+	}
+	if files := committedFiles(t, dir); strings.Contains(files, "wip.txt") || !strings.Contains(files, "cache-design") {
+		t.Errorf("save committed:\n%s", files)
+	}
+	if staged := stagedFiles(t, dir); staged != "wip.txt" {
+		t.Errorf("staged after save = %q, want wip.txt left staged", staged)
+	}
+}
+
+func TestGitAuto_LegacyAutoPushCommitsOnlyWhenDone(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	t.Chdir(dir)
+	if err := runInit(true); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := config.Load(dir)
+	cfg.Git.AutoPush = true
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runSave("Cache design", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	slug := onlyPlanSlug(t, dir)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Add LRU", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastCommitSubject(t, dir); got != initCommitMessage {
+		t.Fatalf("HEAD = %q, want no commit before the task is done", got)
+	}
+
+	walkthrough := filepath.Join(".logosyncx", "tasks", slug, "001-add-lru", "WALKTHROUGH.md")
+	if err := os.WriteFile(walkthrough, []byte("# Walkthrough\n\nAdded the LRU.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	captureStderr(t, func() { // no remote: the push fails with a warning
+		if err := runTaskUpdate("", "add-lru", string(task.StatusDone), "", "", ""); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if got := lastCommitSubject(t, dir); got != "logos: mark task done: Add LRU" {
+		t.Errorf("done commit = %q", got)
+	}
+}
+
+func TestGitAuto_DefaultDoesNotCommit(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	t.Chdir(dir)
+	if err := runInit(true); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSave("Cache design", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := lastCommitSubject(t, dir); got != initCommitMessage {
+		t.Errorf("HEAD = %q, want the init commit", got)
+	}
+}
+
+func TestRun_GitFlag(t *testing.T) {
+	setupInitedProject(t)
+	os.Unsetenv(config.GitEnv)
+
+	err := Run([]string{"ls", "--git=always"})
+	if err == nil || !strings.Contains(err.Error(), "--git") {
+		t.Errorf("invalid --git: err = %v", err)
+	}
+	captureOutput(t, func() {
+		if err := Run([]string{"ls", "--git=add"}); err != nil {
+			t.Errorf("ls --git=add: %v", err)
+		}
+	})
+	if v, ok := os.LookupEnv(config.GitEnv); ok {
+		t.Errorf("--git set $%s = %q", config.GitEnv, v)
+	}
+}
+
+func TestRun_GitFlagStages(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	t.Chdir(dir)
+	if err := runInit(true); err != nil {
+		t.Fatal(err)
+	}
+	os.Unsetenv(config.GitEnv)
+
+	captureOutput(t, func() {
+		if err := Run([]string{"incident", "start", "api outage", "--git=add"}); err != nil {
+			t.Fatalf("incident start --git=add: %v", err)
+		}
+	})
+	if staged := stagedFiles(t, dir); !strings.Contains(staged, "api-outage") {
+		t.Errorf("--git=add staged:\n%s", staged)
+	}
+}
+
+// stagedFiles returns the paths staged in dir.
+func stagedFiles(t *testing.T, dir string) string {
+	t.Helper()
+	c := exec.Command("git", "diff", "--cached", "--name-only")
+	c.Dir = dir
+	out, err := c.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git diff --cached: %v\n%s", err, out)
+	}
+	return strings.TrimSpace(string(out))
+}
+
+func TestGitAuto_OffStagesNothing(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	t.Chdir(dir)
+	if err := runInit(true); err != nil {
+		t.Fatal(err)
+	}
+	cfg, _ := config.Load(dir)
+	cfg.Git.Auto = config.GitOff
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	c := exec.Command("git", "commit", "-qam", "git.auto off")
+	c.Dir = dir
+	if out, err := c.CombinedOutput(); err != nil {
+		t.Fatalf("git commit: %v\n%s", err, out)
+	}
+
+	captureOutput(t, func() {
+		if err := runIncidentStart("api outage", "", nil, time.Now()); err != nil {
+			t.Fatalf("runIncidentStart: %v", err)
+		}
+		if err := runJournal("", time.Now()); err != nil {
+			t.Fatalf("runJournal: %v", err)
+		}
+	})
+	if staged := stagedFiles(t, dir); staged != "" {
+		t.Errorf("git.auto off staged:\n%s", staged)
+	}
+
+	// The add level stages the same writes.
+	t.Setenv(config.GitEnv, config.GitAdd)
+	captureOutput(t, func() {
+		if err := runIncidentStart("db outage", "", nil, time.Now()); err != nil {
+			t.Fatalf("runIncidentStart: %v", err)
+		}
+	})
+	if staged := stagedFiles(t, dir); !strings.Contains(staged, "db-outage") {
+		t.Errorf("git.auto add staged:\n%s", staged)
+	}
+}
+
+func TestGitAuto_DefaultStagesDistill(t *testing.T) {
+	root, planSlug := setupDistillProject(t, "Staged Distill")
+	gitInitDir(t, root)
+	os.Unsetenv(config.GitEnv)
+
+	captureOutput(t, func() {
+		if err := runDistill(planSlug, false, false); err != nil {
+			t.Fatalf("runDistill: %v", err)
+		}
+	})
+	staged := stagedFiles(t, root)
+	for _, want := range []string{".logosyncx/knowledge/", ".logosyncx/plans/" + planSlug + ".md"} {
+		if !strings.Contains(staged, want) {
+			t.Errorf("default config: staged files lack %q:\n%s", want, staged)
+		}
+	}
+}
+
+func TestGitAuto_CommitLevelCommitsIncidentWrites(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	t.Chdir(dir)
+	if err := runInit(true); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(config.GitEnv, config.GitCommit)
+	now := time.Date(2026, 3, 2, 9, 0, 0, 0, time.UTC)
+
+	captureOutput(t, func() {
+		if err := runIncidentStart("api outage", "", nil, now); err != nil {
+			t.Fatalf("runIncidentStart: %v", err)
+		}
+		if got := lastCommitSubject(t, dir); got != "logos: save plan: api outage" {
+			t.Errorf("incident start commit = %q", got)
+		}
+		if err := runIncidentLog("", "rolled back", now.Add(time.Minute)); err != nil {
+			t.Fatalf("runIncidentLog: %v", err)
+		}
+		if files := committedFiles(t, dir); !strings.Contains(files, "api-outage") {
+			t.Errorf("incident log committed:\n%s", files)
+		}
+		if err := runIncidentClose("", "restarted", []string{"Add alerting", "Write runbook"}, now.Add(time.Hour)); err != nil {
+			t.Fatalf("runIncidentClose: %v", err)
+		}
+	})
+
+	// close makes one commit holding the plan, the index, and both tasks.
+	files := committedFiles(t, dir)
+	for _, want := range []string{"plans/", "index.jsonl", "task-index.jsonl", "add-alerting/TASK.md", "write-runbook/TASK.md"} {
+		if !strings.Contains(files, want) {
+			t.Errorf("incident close commit lacks %s:\n%s", want, files)
+		}
+	}
+	c := exec.Command("git", "rev-list", "--count", "HEAD")
+	c.Dir = dir
+	out, err := c.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git rev-list: %v\n%s", err, out)
+	}
+	if got := strings.TrimSpace(string(out)); got != "4" {
+		t.Errorf("%s commits, want 4 (init, start, log, close)", got)
+	}
+	if staged := stagedFiles(t, dir); staged != "" {
+		t.Errorf("left staged after close:\n%s", staged)
+	}
+}
@@ -0,0 +1,261 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/knowledge"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+// graphFormats lists the values accepted by logos graph --format.
+var graphFormats = []string{"dot", "mermaid"}
+
+// Edge kinds in the relationship graph.
+const (
+	edgeHasTask       = "has_task"
+	edgeRelated       = "related"
+	edgeDependsOn     = "depends_on"
+	edgeDistilledFrom = "distilled_from"
+)
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Export how plans, tasks, and knowledge connect",
+	Long: `Build the relationship graph of the project and print it as Graphviz DOT
+(the default), a Mermaid flowchart, or with --json as adjacency lists:
+
+  plan -> task         has_task        the plan's tasks
+  plan -> plan         related         the plan's related list
+  plan -> plan         depends_on      the plan's depends_on list
+  plan -> task         related         the plan's related_tasks
+  task -> plan         related         the task's related_plans
+  task -> task         depends_on      depends_on seqs within the plan
+  knowledge -> plan    distilled_from  the plan a knowledge file came from
+  knowledge -> task    distilled_from  the tasks it was distilled from
+
+Node IDs are plan slugs, <plan-slug>/<task-dir> for tasks, and
+knowledge/<name> for knowledge files. Links to archived or missing plans
+and tasks are left out.
+
+  logos graph | dot -Tsvg > graph.svg
+  logos graph --format mermaid > graph.mmd`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		format, _ := cmd.Flags().GetString("format")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		return runGraph(format, asJSON)
+	},
+}
+
+func init() {
+	graphCmd.Flags().String("format", "dot", "Output format: dot or mermaid")
+	graphCmd.Flags().Bool("json", false, "Output adjacency lists as JSON (for agent consumption)")
+	rootCmd.AddCommand(graphCmd)
+}
+
+// graphNode is one plan, task, or knowledge file with its outgoing edges.
+type graphNode struct {
+	ID     string      `json:"id"`
+	Kind   string      `json:"kind"` // plan, task, or knowledge
+	Label  string      `json:"label"`
+	Status string      `json:"status,omitempty"` // tasks only
+	Edges  []graphEdge `json:"edges"`
+}
+
+// graphEdge is an edge to the node with ID To.
+type graphEdge struct {
+	To   string `json:"to"`
+	Kind string `json:"kind"`
+}
+
+func runGraph(format string, asJSON bool) error {
+	if !slices.Contains(graphFormats, format) {
+		return fmt.Errorf("invalid --format %q (use %s)", format, strings.Join(graphFormats, ", "))
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	nodes := buildGraph(root, cfg)
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(nodes)
+	}
+	if format == "mermaid" {
+		return writeMermaid(os.Stdout, nodes)
+	}
+	return writeDOT(os.Stdout, nodes)
+}
+
+// buildGraph returns every plan, then every task, then every knowledge
+// file, each with its edges. Edges only point at nodes in the graph.
+func buildGraph(root string, cfg config.Config) []graphNode {
+	plans, err := plan.LoadAllWithOptions(root, planParseOptions(cfg))
+	if err != nil {
+		warnf("%v", err)
+	}
+	slices.SortFunc(plans, func(a, b plan.Plan) int { return cmp.Compare(a.Filename, b.Filename) })
+	tasks, err := task.NewStore(root, &cfg).List(task.Filter{})
+	if err != nil {
+		warnf("%v", err)
+	}
+	slices.SortFunc(tasks, func(a, b *task.Task) int {
+		return cmp.Or(cmp.Compare(a.Plan, b.Plan), cmp.Compare(a.Seq, b.Seq), cmp.Compare(a.DirPath, b.DirPath))
+	})
+	kfiles, err := knowledge.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+
+	nodeOf := map[string]int{}          // node ID -> index in nodes
+	taskByID := map[string]string{}     // task frontmatter ID -> node ID
+	seqs := map[string]map[int]string{} // plan slug -> seq -> node ID
+	var nodes []graphNode
+	add := func(n graphNode) {
+		n.Edges = []graphEdge{}
+		nodeOf[n.ID] = len(nodes)
+		nodes = append(nodes, n)
+	}
+	for _, p := range plans {
+		add(graphNode{ID: strings.TrimSuffix(p.Filename, ".md"), Kind: "plan", Label: p.Topic})
+	}
+	for _, t := range tasks {
+		id := t.Plan + "/" + filepath.Base(t.DirPath)
+		add(graphNode{ID: id, Kind: "task", Label: t.Title, Status: string(t.Status)})
+		if t.ID != "" {
+			taskByID[t.ID] = id
+		}
+		if seqs[t.Plan] == nil {
+			seqs[t.Plan] = map[int]string{}
+		}
+		seqs[t.Plan][t.Seq] = id
+	}
+	for _, k := range kfiles {
+		add(graphNode{ID: "knowledge/" + strings.TrimSuffix(k.Filename, ".md"), Kind: "knowledge", Label: k.Topic})
+	}
+
+	link := func(from, to, kind string) {
+		i, ok := nodeOf[from]
+		if _, known := nodeOf[to]; !ok || !known {
+			return
+		}
+		e := graphEdge{To: to, Kind: kind}
+		if !slices.Contains(nodes[i].Edges, e) {
+			nodes[i].Edges = append(nodes[i].Edges, e)
+		}
+	}
+	planID := func(filename string) string { return strings.TrimSuffix(filename, ".md") }
+
+	for _, p := range plans {
+		from := planID(p.Filename)
+		for _, r := range p.Related {
+			link(from, planID(r), edgeRelated)
+		}
+		for _, d := range p.DependsOn {
+			link(from, planID(d), edgeDependsOn)
+		}
+		for _, id := range p.RelatedTasks {
+			link(from, taskByID[id], edgeRelated)
+		}
+	}
+	for _, t := range tasks {
+		from := t.Plan + "/" + filepath.Base(t.DirPath)
+		link(t.Plan, from, edgeHasTask)
+		for _, r := range t.RelatedPlans {
+			link(from, planID(r), edgeRelated)
+		}
+		for _, seq := range t.DependsOn {
+			link(from, seqs[t.Plan][seq], edgeDependsOn)
+		}
+	}
+	for _, k := range kfiles {
+		from := "knowledge/" + strings.TrimSuffix(k.Filename, ".md")
+		slug := planID(k.Plan)
+		link(from, slug, edgeDistilledFrom)
+		for _, name := range k.Tasks {
+			// distill records tasks as "<seq>-<title>".
+			prefix, _, _ := strings.Cut(name, "-")
+			if seq, err := strconv.Atoi(prefix); err == nil {
+				link(from, seqs[slug][seq], edgeDistilledFrom)
+			}
+		}
+	}
+	return nodes
+}
+
+// writeDOT writes nodes as a Graphviz digraph. Plans are boxes, tasks
+// ellipses, and knowledge files notes; related edges are dashed.
+func writeDOT(w io.Writer, nodes []graphNode) error {
+	var b strings.Builder
+	b.WriteString("digraph logos {\n")
+	b.WriteString("  rankdir=LR;\n")
+	shapes := map[string]string{"plan": "box", "task": "ellipse", "knowledge": "note"}
+	for _, n := range nodes {
+		fmt.Fprintf(&b, "  %s [label=%s, shape=%s];\n", strconv.Quote(n.ID), strconv.Quote(n.Label), shapes[n.Kind])
+	}
+	for _, n := range nodes {
+		for _, e := range n.Edges {
+			style := ""
+			if e.Kind == edgeRelated {
+				style = ", style=dashed"
+			}
+			fmt.Fprintf(&b, "  %s -> %s [label=%s%s];\n", strconv.Quote(n.ID), strconv.Quote(e.To), strconv.Quote(e.Kind), style)
+		}
+	}
+	b.WriteString("}\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// writeMermaid writes nodes as a Mermaid flowchart. Mermaid IDs cannot hold
+// most punctuation, so nodes are numbered n0, n1, ... in order.
+func writeMermaid(w io.Writer, nodes []graphNode) error {
+	ids := make(map[string]string, len(nodes))
+	var b strings.Builder
+	b.WriteString("flowchart LR\n")
+	for i, n := range nodes {
+		id := "n" + strconv.Itoa(i)
+		ids[n.ID] = id
+		label := strings.ReplaceAll(n.Label, `"`, "#quot;")
+		switch n.Kind {
+		case "plan":
+			fmt.Fprintf(&b, "  %s[\"%s\"]\n", id, label)
+		case "task":
+			fmt.Fprintf(&b, "  %s(\"%s\")\n", id, label)
+		default:
+			fmt.Fprintf(&b, "  %s[/\"%s\"/]\n", id, label)
+		}
+	}
+	for _, n := range nodes {
+		for _, e := range n.Edges {
+			arrow := "-->"
+			if e.Kind == edgeRelated {
+				arrow = "-.->"
+			}
+			fmt.Fprintf(&b, "  %s %s|%s| %s\n", ids[n.ID], arrow, e.Kind, ids[e.To])
+		}
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,153 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/knowledge"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// setupGraphProject creates two plans, the second related to and depending
+// on the first; the first holds two tasks, the second depending on the
+// first, and has been distilled into a knowledge file.
+func setupGraphProject(t *testing.T) (dir, auth, api string) {
+	t.Helper()
+	dir = setupInitedProject(t)
+	day := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	a := makeTestPlan("auth-design", nil, day)
+	writePlanFileWithBody(t, dir, a)
+	auth = strings.TrimSuffix(plan.FileName(a), ".md")
+
+	b := makeTestPlan("api \"v2\"", nil, day.AddDate(0, 0, 1))
+	b.Related = []string{auth + ".md", "20200101-archived.md"}
+	b.DependsOn = []string{auth + ".md"}
+	writePlanFileWithBody(t, dir, b)
+	api = strings.TrimSuffix(plan.FileName(b), ".md")
+
+	for _, c := range []struct {
+		title string
+		deps  []int
+	}{{"Add JWT middleware", nil}, {"Rotate keys", []int{1}}} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: auth, title: c.title, priority: "medium", dependsOn: c.deps}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	k := knowledge.Knowledge{Topic: "auth-design", Plan: auth + ".md", Tasks: []string{"001-Add JWT middleware"}, Date: &day}
+	if _, err := knowledge.Write(dir, k, "source", "## Summary\n"); err != nil {
+		t.Fatal(err)
+	}
+	return dir, auth, api
+}
+
+func TestGraph_JSONAdjacency(t *testing.T) {
+	_, auth, api := setupGraphProject(t)
+
+	out := captureOutput(t, func() {
+		if err := runGraph("dot", true); err != nil {
+			t.Errorf("runGraph: %v", err)
+		}
+	})
+	var nodes []graphNode
+	if err := json.Unmarshal([]byte(out), &nodes); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	byID := map[string]graphNode{}
+	for _, n := range nodes {
+		byID[n.ID] = n
+	}
+	if len(nodes) != 5 {
+		t.Fatalf("got %d nodes, want 5: %+v", len(nodes), nodes)
+	}
+
+	var jwt, rotate string
+	for _, n := range nodes {
+		switch n.Label {
+		case "Add JWT middleware":
+			jwt = n.ID
+		case "Rotate keys":
+			rotate = n.ID
+		}
+	}
+	if !strings.HasPrefix(jwt, auth+"/001-") || byID[jwt].Status != "open" {
+		t.Errorf("task node = %+v", byID[jwt])
+	}
+
+	want := map[string][]graphEdge{
+		auth:                             {{jwt, edgeHasTask}, {rotate, edgeHasTask}},
+		api:                              {{auth, edgeRelated}, {auth, edgeDependsOn}},
+		jwt:                              {},
+		rotate:                           {{jwt, edgeDependsOn}},
+		"knowledge/20260601-auth-design": {{auth, edgeDistilledFrom}, {jwt, edgeDistilledFrom}},
+	}
+	for id, edges := range want {
+		got := byID[id].Edges
+		if len(got) != len(edges) {
+			t.Errorf("%s edges = %+v, want %+v", id, got, edges)
+			continue
+		}
+		for i := range edges {
+			if got[i] != edges[i] {
+				t.Errorf("%s edges = %+v, want %+v", id, got, edges)
+				break
+			}
+		}
+	}
+}
+
+func TestGraph_DOT(t *testing.T) {
+	_, auth, api := setupGraphProject(t)
+
+	out := captureOutput(t, func() {
+		if err := runGraph("dot", false); err != nil {
+			t.Errorf("runGraph: %v", err)
+		}
+	})
+	for _, want := range []string{
+		"digraph logos {",
+		`"` + auth + `" [label="auth-design", shape=box];`,
+		`[label="api \"v2\"", shape=box];`,
+		`"` + api + `" -> "` + auth + `" [label="related", style=dashed];`,
+		`"` + api + `" -> "` + auth + `" [label="depends_on"];`,
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("DOT output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "archived") {
+		t.Errorf("DOT output links a missing plan:\n%s", out)
+	}
+}
+
+func TestGraph_Mermaid(t *testing.T) {
+	setupGraphProject(t)
+
+	out := captureOutput(t, func() {
+		if err := runGraph("mermaid", false); err != nil {
+			t.Errorf("runGraph: %v", err)
+		}
+	})
+	for _, want := range []string{
+		"flowchart LR\n",
+		`n0["auth-design"]`,
+		`n1["api #quot;v2#quot;"]`,
+		`n2("Add JWT middleware")`,
+		`n4[/"auth-design"/]`,
+		"n1 -.->|related| n0",
+		"n0 -->|has_task| n2",
+		"n4 -->|distilled_from| n0",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("Mermaid output missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestGraph_InvalidFormat(t *testing.T) {
+	setupInitedProject(t)
+	if err := runGraph("svg", false); err == nil || !strings.Contains(err.Error(), "dot, mermaid") {
+		t.Errorf("runGraph(svg) = %v, want an error listing the formats", err)
+	}
+}
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/knowledge"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// guardrailProblem is a limits threshold the project has crossed, with the
+// action that brings it back under.
+type guardrailProblem struct {
+	subject string // what crossed the limit, e.g. ".logosyncx/index.jsonl"
+	detail  string // measured value and limit
+	advice  string
+}
+
+// findGuardrailProblems checks the project at root against cfg.Limits:
+// the number of active plans, the size of each index file, and the size of
+// each plan, task, and knowledge file. Archived files are not counted.
+func findGuardrailProblems(root string, cfg config.Config) []guardrailProblem {
+	var problems []guardrailProblem
+
+	if max := cfg.Limits.PlanLimit(); max > 0 {
+		if n := countActivePlans(root); n > max {
+			problems = append(problems, guardrailProblem{
+				subject: ".logosyncx/plans/",
+				detail:  fmt.Sprintf("%d active plans (limit %d)", n, max),
+				advice:  "archive finished plans with `logos gc` or `logos archive --name <plan>`",
+			})
+		}
+	}
+
+	if max := cfg.Limits.IndexLimitBytes(); max > 0 {
+		for _, path := range []string{index.FilePath(root), task.TaskIndexFilePath(root)} {
+			info, err := os.Stat(path)
+			if err != nil || info.Size() <= max {
+				continue
+			}
+			rel, _ := relPath(root, path)
+			problems = append(problems, guardrailProblem{
+				subject: rel,
+				detail:  fmt.Sprintf("%s (limit %s)", formatKB(info.Size()), formatKB(max)),
+				advice:  "archive old plans and tasks (`logos gc`, `logos task purge`) or lower the excerpt_max_runes settings",
+			})
+		}
+	}
+
+	if max := cfg.Limits.FileLimitBytes(); max > 0 {
+		dirs := []string{plan.PlansDir(root), filepath.Join(root, config.DirName, "tasks"), knowledge.KnowledgeDir(root)}
+		for _, dir := range dirs {
+			_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+				if err != nil {
+					return nil
+				}
+				if d.IsDir() {
+					if path == plan.ArchiveDir(root) {
+						return filepath.SkipDir
+					}
+					return nil
+				}
+				if !strings.HasSuffix(path, ".md") {
+					return nil
+				}
+				info, err := d.Info()
+				if err != nil || info.Size() <= max {
+					return nil
+				}
+				rel, _ := relPath(root, path)
+				problems = append(problems, guardrailProblem{
+					subject: rel,
+					detail:  fmt.Sprintf("%s (limit %s)", formatKB(info.Size()), formatKB(max)),
+					advice:  "split it, or move logs and long pastes to .logosyncx/attachments/",
+				})
+				return nil
+			})
+		}
+	}
+	return problems
+}
+
+// countActivePlans returns the number of .md files directly in plans/.
+func countActivePlans(root string) int {
+	entries, err := os.ReadDir(plan.PlansDir(root))
+	if err != nil {
+		return 0
+	}
+	n := 0
+	for _, e := range entries {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".md") {
+			n++
+		}
+	}
+	return n
+}
+
+// formatKB renders a byte count in whole KB.
+func formatKB(n int64) string {
+	return fmt.Sprintf("%d KB", (n+1023)/1024)
+}
+
+// warnGuardrails prints one warning per limits threshold the project has
+// crossed, each with the action that fixes it.
+func warnGuardrails(root string, cfg config.Config) {
+	for _, p := range findGuardrailProblems(root, cfg) {
+		warnf("%s: %s — %s", p.subject, p.detail, p.advice)
+	}
+}
@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// setLimits writes limits into the config of the project at dir.
+func setLimits(t *testing.T, dir string, limits config.LimitsConfig) config.Config {
+	t.Helper()
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Limits = limits
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	return cfg
+}
+
+func TestFindGuardrailProblems(t *testing.T) {
+	dir, _ := setupExportProject(t)
+	p := makeTestPlan("second", nil, time.Now())
+	p.ID = "test02"
+	p.Body = strings.Repeat("long line of notes\n", 100)
+	writePlanFileWithBody(t, dir, p)
+
+	cfg := setLimits(t, dir, config.LimitsConfig{})
+	if problems := findGuardrailProblems(dir, cfg); len(problems) != 0 {
+		t.Fatalf("default limits: got %+v", problems)
+	}
+
+	cfg = setLimits(t, dir, config.LimitsConfig{MaxPlans: 1, MaxIndexKB: -1, MaxFileKB: 1})
+	problems := findGuardrailProblems(dir, cfg)
+	if len(problems) != 2 {
+		t.Fatalf("expected plan count and file size problems, got %+v", problems)
+	}
+	if problems[0].detail != "2 active plans (limit 1)" || !strings.Contains(problems[0].advice, "logos archive") {
+		t.Errorf("unexpected plan count problem: %+v", problems[0])
+	}
+	if !strings.HasPrefix(problems[1].subject, ".logosyncx/plans/") || !strings.Contains(problems[1].detail, "limit 1 KB") {
+		t.Errorf("unexpected file size problem: %+v", problems[1])
+	}
+}
+
+func TestSync_WarnsAboutLimits(t *testing.T) {
+	dir, _ := setupExportProject(t)
+	setLimits(t, dir, config.LimitsConfig{MaxIndexKB: -1})
+	sync := func() string {
+		return captureStderr(t, func() {
+			captureOutput(t, func() {
+				if err := runSync("", false, false, false); err != nil {
+					t.Errorf("runSync: %v", err)
+				}
+			})
+		})
+	}
+	if stderr := sync(); strings.Contains(stderr, "limit") {
+		t.Errorf("no limit exceeded, got %q", stderr)
+	}
+
+	p := makeTestPlan("second", nil, time.Now())
+	p.ID = "test02"
+	writePlanFileWithBody(t, dir, p)
+	setLimits(t, dir, config.LimitsConfig{MaxPlans: 1})
+	if stderr := sync(); !strings.Contains(stderr, "2 active plans (limit 1)") {
+		t.Errorf("expected plan count warning, got %q", stderr)
+	}
+}
+
+func TestDoctor_ReportsLimits(t *testing.T) {
+	dir, _ := setupExportProject(t)
+	setLimits(t, dir, config.LimitsConfig{MaxPlans: -1, MaxIndexKB: -1, MaxFileKB: -1})
+	out := captureOutput(t, func() {
+		if err := runDoctor(false, false, false, false); err != nil {
+			t.Fatalf("runDoctor: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No problems found.") {
+		t.Errorf("expected clean report, got %q", out)
+	}
+
+	p := makeTestPlan("second", nil, time.Now())
+	p.ID = "test02"
+	writePlanFileWithBody(t, dir, p)
+	setLimits(t, dir, config.LimitsConfig{MaxPlans: 1})
+	out = captureOutput(t, func() {
+		if err := runDoctor(false, false, false, false); err != nil {
+			t.Fatalf("runDoctor: %v", err)
+		}
+	})
+	if !strings.Contains(out, "[limit] .logosyncx/plans/ — 2 active plans (limit 1)") || !strings.Contains(out, "logos gc") {
+		t.Errorf("expected limit report, got %q", out)
+	}
+}
@@ -0,0 +1,375 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+var importCmd = &cobra.Command{
+	Use:   "import <dir|file.json>",
+	Short: "Create plans from external Markdown or JSON notes",
+	Long: `Convert notes kept outside logos into plans, then rebuild the plan index.
+
+  <dir>        every .md file under the directory (hidden directories such
+               as .obsidian are skipped); one plan per file
+  <file.md>    a single Markdown file
+  <file.json>  a logos export document (its "plans"; tasks are ignored) or
+               a JSON array of note objects
+
+A note's topic comes from frontmatter "topic" or "title", then the first
+"# " heading (removed from the body), then the file name. Tags come from
+frontmatter "tags" (a list or a comma/space separated string) plus --tag
+and plans.default_tags; the date from frontmatter "date" or "created",
+falling back to the file's modification time. JSON notes use the same keys
+with the body under "body", "content", or "text".
+
+Every plan gets a new ID unless the note carries one not used in this
+project. A note whose topic and date match an existing plan is already
+present and is skipped; a taken filename gets a "-2" (or "-3", ...)
+suffix. Use --dry-run to list what would be imported.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agent, _ := cmd.Flags().GetString("agent")
+		tags, _ := cmd.Flags().GetStringArray("tag")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		return runImport(args[0], agent, tags, dryRun)
+	},
+}
+
+func init() {
+	importCmd.Flags().StringP("agent", "a", "", "Agent recorded on every imported plan")
+	importCmd.Flags().StringArray("tag", []string{}, "Tag added to every imported plan (repeatable)")
+	importCmd.Flags().Bool("dry-run", false, "Show what would be imported without writing any file")
+	rootCmd.AddCommand(importCmd)
+}
+
+// importNote is one external note to be converted into a plan.
+type importNote struct {
+	source   string // file the note was read from, for messages
+	id       string
+	topic    string
+	date     time.Time
+	tags     []string
+	agent    string
+	category string
+	body     string
+}
+
+func runImport(src, agent string, tags []string, dryRun bool) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if err := plan.ValidateFilenamePattern(cfg.Plans.FilenamePattern); err != nil {
+		return fmt.Errorf("plans.filename_pattern: %w", err)
+	}
+
+	notes, err := readImportNotes(src)
+	if err != nil {
+		return err
+	}
+
+	existing, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	ids := map[string]bool{}
+	present := map[string]bool{}
+	for _, p := range existing {
+		ids[p.ID] = true
+		if p.Date != nil {
+			present[p.Topic+"\x00"+p.Date.UTC().Format(time.RFC3339)] = true
+		}
+	}
+	taken := map[string]bool{}
+	for _, name := range planFilenames(root, existing) {
+		taken[name] = true
+	}
+
+	var written []string
+	skipped := 0
+	for _, n := range notes {
+		date := n.date.Truncate(time.Second)
+		key := n.topic + "\x00" + date.UTC().Format(time.RFC3339)
+		if present[key] {
+			skipped++
+			continue
+		}
+		present[key] = true
+
+		p := plan.Plan{
+			ID:       n.id,
+			Date:     &date,
+			Topic:    n.topic,
+			Tags:     config.MergeTags(config.MergeTags(n.tags, tags), cfg.Plans.DefaultTags),
+			Agent:    cmp.Or(agent, n.agent),
+			Category: n.category,
+			Related:  []string{},
+			Body:     n.body,
+		}
+		if p.ID == "" || ids[p.ID] {
+			if p.ID, err = plan.GenerateID(); err != nil {
+				return fmt.Errorf("generate id: %w", err)
+			}
+		}
+		ids[p.ID] = true
+		p.Filename = plan.FileNameWithPattern(p, cfg.Plans.FilenamePattern)
+		if taken[p.Filename] {
+			p.Filename = uniqueName(p.Filename, func(n string) bool { return taken[n] })
+		}
+		taken[p.Filename] = true
+		p.TasksDir = plan.DefaultTasksDir(p.Filename)
+
+		if dryRun {
+			fmt.Printf("  %s → %s\n", n.source, p.Filename)
+			continue
+		}
+		fields := append([]privacyField{{"topic", &p.Topic}, {"body", &p.Body}}, tagFields(p.Tags)...)
+		if err := enforcePrivacy(cfg, "plan", fields...); err != nil {
+			return fmt.Errorf("%s: %w", n.source, err)
+		}
+		path, err := plan.Write(root, p)
+		if err != nil {
+			return fmt.Errorf("write plan %s: %w", p.Filename, err)
+		}
+		written = append(written, path)
+		fmt.Printf("  %s → %s\n", n.source, p.Filename)
+	}
+
+	if dryRun {
+		fmt.Printf("Dry run: would import %d plan(s); %d already present.\n", len(notes)-skipped, skipped)
+		return nil
+	}
+	if _, err := index.RebuildWithOptions(root, planIndexOptions(cfg)); err != nil {
+		warnf("could not rebuild index (%v) — run `logos sync` to rebuild", err)
+	}
+	stageFiles(root, cfg, append(written, index.FilePath(root))...)
+	printSuccess("Imported %d plan(s); %d already present.", len(written), skipped)
+	return nil
+}
+
+// readImportNotes reads the notes at src: a directory of Markdown files, a
+// single Markdown file, or a JSON file.
+func readImportNotes(src string) ([]importNote, error) {
+	info, err := os.Stat(src)
+	if err != nil {
+		return nil, err
+	}
+	if !info.IsDir() {
+		if strings.EqualFold(filepath.Ext(src), ".json") {
+			return readJSONNotes(src, info.ModTime())
+		}
+		n, err := readMarkdownNote(src, filepath.Base(src), info.ModTime())
+		if err != nil {
+			return nil, err
+		}
+		return []importNote{n}, nil
+	}
+
+	var notes []importNote
+	err = filepath.WalkDir(src, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if path != src && strings.HasPrefix(d.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if !strings.EqualFold(filepath.Ext(path), ".md") {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, _ := filepath.Rel(src, path)
+		n, err := readMarkdownNote(path, rel, info.ModTime())
+		if err != nil {
+			warnf("skipping %s: %v", rel, err)
+			return nil
+		}
+		notes = append(notes, n)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(notes) == 0 {
+		return nil, fmt.Errorf("no Markdown files found in %s", src)
+	}
+	return notes, nil
+}
+
+// readMarkdownNote reads one Markdown note; source names it in messages.
+func readMarkdownNote(path, source string, mtime time.Time) (importNote, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return importNote{}, err
+	}
+	fields := map[string]any{}
+	body := string(data)
+	if strings.HasPrefix(body, "---") {
+		fm, rest, err := markdown.SplitFrontmatter(data)
+		if err != nil {
+			return importNote{}, err
+		}
+		if err := yaml.Unmarshal(fm, &fields); err != nil {
+			return importNote{}, fmt.Errorf("parse frontmatter: %w", err)
+		}
+		body = string(rest)
+	}
+	stem := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	return noteFromFields(source, fields, body, stem, mtime), nil
+}
+
+// readJSONNotes reads a logos export document or a JSON array of notes.
+func readJSONNotes(path string, mtime time.Time) ([]importNote, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var items []map[string]any
+	var doc struct {
+		Plans []map[string]any `json:"plans"`
+	}
+	if err := json.Unmarshal(data, &items); err != nil {
+		if err := json.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("parse %s: expected a logos export document or an array of notes: %w", path, err)
+		}
+		items = doc.Plans
+	}
+	if len(items) == 0 {
+		return nil, fmt.Errorf("no notes found in %s", path)
+	}
+
+	notes := make([]importNote, 0, len(items))
+	for i, fields := range items {
+		body := ""
+		for _, key := range []string{"body", "content", "text"} {
+			if s, ok := fields[key].(string); ok && s != "" {
+				body = s
+				break
+			}
+		}
+		source := fmt.Sprintf("%s[%d]", filepath.Base(path), i)
+		notes = append(notes, noteFromFields(source, fields, body, fmt.Sprintf("note-%d", i+1), mtime))
+	}
+	return notes, nil
+}
+
+// noteFromFields builds a note from frontmatter or JSON fields and a body,
+// falling back to the body's first "# " heading or fallbackTopic for the
+// topic and to mtime for the date.
+func noteFromFields(source string, fields map[string]any, body, fallbackTopic string, mtime time.Time) importNote {
+	n := importNote{
+		source:   source,
+		id:       stringField(fields, "id"),
+		topic:    stringField(fields, "topic", "title"),
+		tags:     listField(fields, "tags"),
+		agent:    stringField(fields, "agent"),
+		category: stringField(fields, "category"),
+		date:     mtime,
+	}
+	if d, ok := dateField(fields, "date", "created"); ok {
+		n.date = d
+	}
+	if n.topic == "" {
+		n.topic, body = takeTitle(body)
+	}
+	if n.topic == "" {
+		n.topic = fallbackTopic
+	}
+	n.body = strings.TrimLeft(body, "\n")
+	return n
+}
+
+// takeTitle returns the text of body's first "# " heading when it comes
+// before any other content, and body without that heading.
+func takeTitle(body string) (string, string) {
+	lines := strings.Split(body, "\n")
+	for i, line := range lines {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if text, level, ok := markdown.ParseHeading(line); ok && level == 1 {
+			return text, strings.Join(lines[i+1:], "\n")
+		}
+		break
+	}
+	return "", body
+}
+
+// stringField returns the first non-empty string value among keys.
+func stringField(fields map[string]any, keys ...string) string {
+	for _, key := range keys {
+		if s, ok := fields[key].(string); ok && strings.TrimSpace(s) != "" {
+			return strings.TrimSpace(s)
+		}
+	}
+	return ""
+}
+
+// listField returns the string list under key, accepting a list or a comma
+// or space separated string. A leading "#" on each tag is dropped.
+func listField(fields map[string]any, key string) []string {
+	var raw []string
+	switch v := fields[key].(type) {
+	case string:
+		raw = strings.FieldsFunc(v, func(r rune) bool { return r == ',' || r == ' ' })
+	case []any:
+		for _, item := range v {
+			if s, ok := item.(string); ok {
+				raw = append(raw, s)
+			}
+		}
+	}
+	var out []string
+	for _, s := range raw {
+		if s = strings.TrimPrefix(strings.TrimSpace(s), "#"); s != "" && !slices.Contains(out, s) {
+			out = append(out, s)
+		}
+	}
+	return out
+}
+
+// importDateLayouts are the string date formats accepted in notes.
+var importDateLayouts = []string{time.RFC3339, "2006-01-02T15:04:05", "2006-01-02 15:04:05", "2006-01-02 15:04", "2006-01-02"}
+
+// dateField returns the first date among keys, given as a YAML timestamp
+// or a string in one of importDateLayouts (local time when no offset).
+func dateField(fields map[string]any, keys ...string) (time.Time, bool) {
+	for _, key := range keys {
+		switch v := fields[key].(type) {
+		case time.Time:
+			return v, true
+		case string:
+			for _, layout := range importDateLayouts {
+				if t, err := time.ParseInLocation(layout, strings.TrimSpace(v), time.Local); err == nil {
+					return t, true
+				}
+			}
+		}
+	}
+	return time.Time{}, false
+}
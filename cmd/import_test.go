@@ -0,0 +1,128 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+func TestImport_MarkdownDirectory(t *testing.T) {
+	dir := setupInitedProject(t)
+	notes := filepath.Join(t.TempDir(), "vault")
+	for name, content := range map[string]string{
+		"auth.md":              "---\ntitle: Auth design\ntags: [auth, \"#security\"]\ndate: 2025-03-01\n---\n\n## Background\n\nWhy.\n",
+		"sub/caching-notes.md": "# Caching\n\nUse Redis.\n",
+		"plain.md":             "Just text.\n",
+		".obsidian/skip.md":    "# Hidden\n",
+		"image.png":            "not markdown",
+	} {
+		path := filepath.Join(notes, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	captureOutput(t, func() {
+		if err := runImport(notes, "obsidian", []string{"imported"}, false); err != nil {
+			t.Fatalf("runImport: %v", err)
+		}
+	})
+	plans, err := plan.LoadAll(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byTopic := map[string]plan.Plan{}
+	for _, p := range plans {
+		byTopic[p.Topic] = p
+	}
+	if len(plans) != 3 {
+		t.Fatalf("expected 3 plans, got %d: %v", len(plans), byTopic)
+	}
+
+	auth := byTopic["Auth design"]
+	if auth.ID == "" || auth.Date == nil || auth.Date.Format("2006-01-02") != "2025-03-01" {
+		t.Errorf("unexpected auth plan: %+v", auth)
+	}
+	if !slices.Equal(auth.Tags, []string{"auth", "security", "imported"}) || auth.Agent != "obsidian" {
+		t.Errorf("unexpected tags/agent: %v %q", auth.Tags, auth.Agent)
+	}
+	if !strings.Contains(auth.Body, "## Background") {
+		t.Errorf("body not kept: %q", auth.Body)
+	}
+	if c, ok := byTopic["Caching"]; !ok || strings.Contains(c.Body, "# Caching") {
+		t.Errorf("expected the H1 as topic and removed from the body, got %+v", c)
+	}
+	if _, ok := byTopic["plain"]; !ok {
+		t.Errorf("expected the file name as fallback topic, got %v", byTopic)
+	}
+	if _, err := os.Stat(filepath.Join(dir, ".logosyncx", "index.jsonl")); err != nil {
+		t.Errorf("expected the index to be rebuilt: %v", err)
+	}
+}
+
+func TestImport_ExportRoundTripSkipsPresent(t *testing.T) {
+	_, _ = setupExportProject(t)
+	exported := filepath.Join(t.TempDir(), "export.json")
+	captureOutput(t, func() {
+		if err := runExport("json", exported, time.Now()); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	out := captureOutput(t, func() {
+		if err := runImport(exported, "", nil, false); err != nil {
+			t.Fatalf("runImport: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Imported 0 plan(s); 1 already present.") {
+		t.Errorf("expected the exported plan to be skipped, got:\n%s", out)
+	}
+}
+
+func TestImport_JSONArrayDryRun(t *testing.T) {
+	dir := setupInitedProject(t)
+	src := filepath.Join(t.TempDir(), "notes.json")
+	data := `[{"title": "Note A", "content": "Body A", "created": "2025-01-02 10:00"}, {"topic": "Note B", "body": "Body B"}]`
+	if err := os.WriteFile(src, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runImport(src, "", nil, true); err != nil {
+			t.Fatalf("runImport: %v", err)
+		}
+	})
+	if !strings.Contains(out, "notes.json[0] → 20250102-note-a.md") || !strings.Contains(out, "would import 2 plan(s)") {
+		t.Errorf("unexpected dry run output:\n%s", out)
+	}
+	if plans, _ := plan.LoadAll(dir); len(plans) != 0 {
+		t.Errorf("dry run wrote %d plan(s)", len(plans))
+	}
+}
+
+func TestImport_FilenameTaken(t *testing.T) {
+	dir := setupInitedProject(t)
+	src := filepath.Join(t.TempDir(), "notes.json")
+	data := `[{"title": "Same", "date": "2025-01-02"}, {"title": "Same", "date": "2025-01-02T12:00:00Z"}]`
+	if err := os.WriteFile(src, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	captureOutput(t, func() {
+		if err := runImport(src, "", nil, false); err != nil {
+			t.Fatalf("runImport: %v", err)
+		}
+	})
+	for _, name := range []string{"20250102-same.md", "20250102-same-2.md"} {
+		if _, err := os.Stat(filepath.Join(plan.PlansDir(dir), name)); err != nil {
+			t.Errorf("expected %s: %v", name, err)
+		}
+	}
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/dateparse"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/spf13/cobra"
+)
+
+// inboxDefaultWindow is how far back logos inbox looks before the first ack.
+const inboxDefaultWindow = 7 * 24 * time.Hour
+
+var inboxCmd = &cobra.Command{
+	Use:   "inbox",
+	Short: "List plans and tasks created since you last caught up",
+	Long: `List the plans and tasks created since you last ran logos inbox ack, newest
+first, so you can catch up on new context after time away.
+
+The acknowledged time is stored per user in .logosyncx/config.local.json,
+which is kept out of git. Before the first ack, the last 7 days are shown.
+--since overrides the stored time for one run (YYYY-MM-DD, yesterday, 3d,
+last monday, ...) without changing it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, _ := cmd.Flags().GetString("since")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		return runInbox(since, asJSON, time.Now())
+	},
+}
+
+var inboxAckCmd = &cobra.Command{
+	Use:   "ack",
+	Short: "Mark the inbox as caught up",
+	Long: `Record the current time in .logosyncx/config.local.json; logos inbox then
+lists only plans and tasks created after it.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runInboxAck(time.Now())
+	},
+}
+
+func init() {
+	inboxCmd.Flags().StringP("since", "s", "", "Show items created after this date instead of the last ack")
+	inboxCmd.Flags().Bool("json", false, "Output JSON (for agent consumption)")
+	inboxCmd.AddCommand(inboxAckCmd)
+	rootCmd.AddCommand(inboxCmd)
+}
+
+// inboxJSON is the logos inbox --json output. AckedAt is omitted before the
+// first ack.
+type inboxJSON struct {
+	Since   time.Time       `json:"since"`
+	AckedAt *time.Time      `json:"acked_at,omitempty"`
+	Plans   []index.Entry   `json:"plans"`
+	Tasks   []task.TaskJSON `json:"tasks"`
+}
+
+func runInbox(sinceStr string, asJSON bool, now time.Time) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	local, err := config.LoadLocal(root)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", config.LocalFileName, err)
+	}
+	loc := displayLocation(cfg)
+
+	out := inboxJSON{AckedAt: local.InboxAckedAt, Plans: []index.Entry{}, Tasks: []task.TaskJSON{}}
+	switch {
+	case sinceStr != "":
+		if out.Since, err = dateparse.Past(sinceStr, now.In(loc)); err != nil {
+			return fmt.Errorf("--since: %w", err)
+		}
+	case local.InboxAckedAt != nil:
+		out.Since = *local.InboxAckedAt
+	default:
+		out.Since = now.Add(-inboxDefaultWindow)
+	}
+
+	entries, err := index.ReadAll(root)
+	if err != nil {
+		if entries, err = index.Build(root, planParseOptions(cfg)); err != nil {
+			warnf("%v", err)
+		}
+	}
+	for _, e := range entries {
+		if e.Date.After(out.Since) {
+			out.Plans = append(out.Plans, e)
+		}
+	}
+	sortByDateDesc(out.Plans)
+
+	tasks, err := task.ReadAllTaskIndex(root)
+	if err != nil {
+		if tasks, err = task.NewStore(root, &cfg).BuildTaskIndex(); err != nil {
+			warnf("%v", err)
+		}
+	}
+	for _, t := range tasks {
+		if t.Date.After(out.Since) {
+			out.Tasks = append(out.Tasks, t)
+		}
+	}
+	slices.SortStableFunc(out.Tasks, func(a, b task.TaskJSON) int { return b.Date.Compare(a.Date) })
+
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+	return printInbox(out, sinceStr != "", loc)
+}
+
+// printInbox writes the new plans and tasks in out. override is true when
+// --since replaced the stored ack time.
+func printInbox(out inboxJSON, override bool, loc *time.Location) error {
+	since := out.Since.In(loc).Format("2006-01-02 15:04")
+	switch {
+	case override:
+		fmt.Printf("Since %s:\n", since)
+	case out.AckedAt != nil:
+		fmt.Printf("Since your last ack (%s):\n", since)
+	default:
+		fmt.Printf("No inbox ack yet — showing the last 7 days (since %s):\n", since)
+	}
+	if len(out.Plans) == 0 && len(out.Tasks) == 0 {
+		fmt.Println("Nothing new.")
+		return nil
+	}
+
+	if len(out.Plans) > 0 {
+		fmt.Printf("\nNew plans (%d):\n", len(out.Plans))
+		t := &textTable{headers: []string{"DATE", "TOPIC", "AGENT", "FILENAME"}, fitWidth: tableWidth(), shrink: []int{1, 3}}
+		for _, e := range out.Plans {
+			t.addRow(e.Date.In(loc).Format("2006-01-02 15:04"), e.Topic, dashIfEmpty(e.Agent), e.Filename)
+		}
+		if err := t.render(os.Stdout); err != nil {
+			return err
+		}
+	}
+	if len(out.Tasks) > 0 {
+		fmt.Printf("\nNew tasks (%d):\n", len(out.Tasks))
+		t := &textTable{headers: []string{"DATE", "STATUS", "TITLE", "PLAN"}, fitWidth: tableWidth(), shrink: []int{2, 3}}
+		for _, e := range out.Tasks {
+			t.addRow(e.Date.In(loc).Format("2006-01-02 15:04"), string(e.Status), e.Title, dashIfEmpty(e.Plan))
+		}
+		if err := t.render(os.Stdout); err != nil {
+			return err
+		}
+	}
+	printHint("Run `logos inbox ack` once you have caught up.")
+	return nil
+}
+
+func runInboxAck(now time.Time) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	local, err := config.LoadLocal(root)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", config.LocalFileName, err)
+	}
+	now = now.Truncate(time.Second)
+	local.InboxAckedAt = &now
+	if err := config.SaveLocal(root, local); err != nil {
+		return fmt.Errorf("save %s: %w", config.LocalFileName, err)
+	}
+	printSuccess("Inbox acknowledged at %s.", now.In(displayLocation(cfg)).Format("2006-01-02 15:04"))
+	return nil
+}
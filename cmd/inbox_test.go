@@ -0,0 +1,85 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+)
+
+func TestInbox_ListsItemsSinceAck(t *testing.T) {
+	dir := setupInitedProject(t)
+	now := time.Now()
+	writePlanFileWithBody(t, dir, makeTestPlan("old-plan", nil, now.Add(-48*time.Hour)))
+	fresh := makeTestPlan("fresh-plan", nil, now.Add(time.Hour))
+	fresh.ID = "test02"
+	writePlanFileWithBody(t, dir, fresh)
+	if _, err := index.Rebuild(dir, "Background"); err != nil {
+		t.Fatal(err)
+	}
+
+	captureOutput(t, func() {
+		if err := runInboxAck(now.Add(-24 * time.Hour)); err != nil {
+			t.Fatalf("runInboxAck: %v", err)
+		}
+	})
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Fresh task", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runInbox("", false, now); err != nil {
+			t.Errorf("runInbox: %v", err)
+		}
+	})
+	for _, want := range []string{"Since your last ack", "New plans (1)", "fresh-plan", "New tasks (1)", "Fresh task"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "old-plan") {
+		t.Errorf("plan older than the ack should not be listed:\n%s", out)
+	}
+}
+
+func TestInbox_JSONBeforeFirstAck(t *testing.T) {
+	dir := setupInitedProject(t)
+	now := time.Now()
+	writePlanFileWithBody(t, dir, makeTestPlan("recent", nil, now.Add(-24*time.Hour)))
+
+	out := captureOutput(t, func() {
+		if err := runInbox("", true, now); err != nil {
+			t.Errorf("runInbox: %v", err)
+		}
+	})
+	var got inboxJSON
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if got.AckedAt != nil || len(got.Plans) != 1 || got.Plans[0].Topic != "recent" {
+		t.Errorf("unexpected inbox: %+v", got)
+	}
+	if want := now.Add(-inboxDefaultWindow); !got.Since.Equal(want) {
+		t.Errorf("since = %v, want %v", got.Since, want)
+	}
+}
+
+func TestInboxAck_StoresTimeLocally(t *testing.T) {
+	dir := setupInitedProject(t)
+	at := time.Date(2026, 5, 4, 10, 0, 0, 0, time.UTC)
+	captureOutput(t, func() {
+		if err := runInboxAck(at); err != nil {
+			t.Fatalf("runInboxAck: %v", err)
+		}
+	})
+	local, err := config.LoadLocal(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if local.InboxAckedAt == nil || !local.InboxAckedAt.Equal(at) {
+		t.Errorf("InboxAckedAt = %v, want %v", local.InboxAckedAt, at)
+	}
+}
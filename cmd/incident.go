@@ -0,0 +1,348 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+// incidentCategory is the plan category of incidents.
+const incidentCategory = "incident"
+
+const (
+	timelineSection   = "Timeline"
+	resolutionSection = "Resolution"
+	followUpsSection  = "Follow-ups"
+)
+
+var incidentCmd = &cobra.Command{
+	Use:   "incident",
+	Short: "Capture an incident as a plan with a live timeline",
+	Long: `Record an incident while it happens.
+
+  logos incident start "api outage"        # new incident plan, timeline started
+  logos incident log "rolled back v1.2"    # timestamped timeline entry
+  logos incident close --resolution "..."  # resolve and create follow-up tasks
+
+An incident is a plan with category "incident", scaffolded with that
+category's sections (see plans.category_sections). log and close act on the
+only open incident; pass --name when several are open.`,
+}
+
+var incidentStartCmd = &cobra.Command{
+	Use:   "start <topic>",
+	Short: "Open an incident plan and start its timeline",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agent, _ := cmd.Flags().GetString("agent")
+		tags, _ := cmd.Flags().GetStringArray("tag")
+		return runIncidentStart(args[0], agent, tags, time.Now())
+	},
+}
+
+var incidentLogCmd = &cobra.Command{
+	Use:   "log <entry>",
+	Short: "Append a timestamped entry to an open incident's timeline",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		return runIncidentLog(name, args[0], time.Now())
+	},
+}
+
+var incidentCloseCmd = &cobra.Command{
+	Use:   "close",
+	Short: "Resolve an incident and create its follow-up tasks",
+	Long: `Resolve an open incident: a "Resolved" entry is added to the timeline,
+--resolution (when given) becomes the Resolution section, and closed_at is
+set in the frontmatter.
+
+A task is created in the incident plan for every unchecked "- [ ] ..." item
+in its Follow-ups section and for every --follow-up flag; an item is checked
+off once its task is created.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		resolution, _ := cmd.Flags().GetString("resolution")
+		followUps, _ := cmd.Flags().GetStringArray("follow-up")
+		return runIncidentClose(name, resolution, followUps, time.Now())
+	},
+}
+
+func init() {
+	incidentStartCmd.Flags().StringP("agent", "a", "", "Agent name (e.g. claude-code)")
+	incidentStartCmd.Flags().StringArray("tag", []string{}, "Tag to attach (repeatable)")
+	for _, c := range []*cobra.Command{incidentLogCmd, incidentCloseCmd} {
+		c.Flags().StringP("name", "n", "", "Incident plan (partial filename; default: the only open incident)")
+	}
+	incidentCloseCmd.Flags().String("resolution", "", "Text for the Resolution section")
+	incidentCloseCmd.Flags().StringArray("follow-up", []string{}, "Follow-up task title (repeatable)")
+	incidentCmd.AddCommand(incidentStartCmd, incidentLogCmd, incidentCloseCmd)
+	rootCmd.AddCommand(incidentCmd)
+}
+
+// runIncidentStart creates an incident plan for topic whose timeline opens
+// with a "Started" entry at now.
+func runIncidentStart(topic, agent string, tags []string, now time.Time) error {
+	if strings.TrimSpace(topic) == "" {
+		return errors.New("provide the incident topic")
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	p, err := newCategoryPlan(cfg, topic, agent, tags, incidentCategory, now)
+	if err != nil {
+		return err
+	}
+	p.Body = appendTimeline(p.Body, timelineEntry(now, displayLocation(cfg), "Started"))
+	fields := append([]privacyField{{"topic", &p.Topic}, {"body", &p.Body}}, tagFields(p.Tags)...)
+	if err := enforcePrivacy(cfg, "plan", fields...); err != nil {
+		return err
+	}
+
+	path, err := writePlanAndIndex(root, cfg, p)
+	if err != nil {
+		return err
+	}
+	commitIncident(root, cfg, p, path)
+	rel, _ := relPath(root, path)
+	printSuccess("Started incident: %s", rel)
+	printHint(`Next: logos incident log "<what happened>" as the incident unfolds`)
+	return nil
+}
+
+// runIncidentLog appends entry, stamped with now, to the timeline of the
+// incident named by name (default: the only open one).
+func runIncidentLog(name, entry string, now time.Time) error {
+	if strings.TrimSpace(entry) == "" {
+		return errors.New("provide the timeline entry")
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	p, err := findIncident(root, name)
+	if err != nil {
+		return err
+	}
+	if err := refuseFrozen(p); err != nil {
+		return err
+	}
+	if err := enforcePrivacy(cfg, "plan", privacyField{"timeline entry", &entry}); err != nil {
+		return err
+	}
+	line := timelineEntry(now, displayLocation(cfg), entry)
+	p.Body = appendTimeline(p.Body, line)
+	path, err := writePlanAndIndex(root, cfg, p)
+	if err != nil {
+		return err
+	}
+	commitIncident(root, cfg, p, path)
+	printSuccess("%s: %s", p.Filename, strings.TrimPrefix(line, "- "))
+	return nil
+}
+
+// runIncidentClose resolves the incident named by name (default: the only
+// open one) at now and creates its follow-up tasks.
+func runIncidentClose(name, resolution string, followUps []string, now time.Time) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	p, err := findIncident(root, name)
+	if err != nil {
+		return err
+	}
+	if err := refuseFrozen(p); err != nil {
+		return err
+	}
+	if err := enforcePrivacy(cfg, "plan", privacyField{"resolution", &resolution}); err != nil {
+		return err
+	}
+
+	p.Body = appendTimeline(p.Body, timelineEntry(now, displayLocation(cfg), "Resolved"))
+	if strings.TrimSpace(resolution) != "" {
+		p.Body = markdown.SetSection(p.Body, resolutionSection, resolution)
+	}
+	items := openItems(p.Body, followUpsSection, false)
+	titles := append(slices.Clone(items), followUps...)
+
+	// Create the follow-ups first, so that an item is checked off only once
+	// its task exists. They are committed with the plan below.
+	slug := strings.TrimSuffix(p.Filename, ".md")
+	var created, dirs []string
+	for i, title := range titles {
+		t := task.Task{Title: title, Plan: slug}
+		if err := createTask(root, cfg, &t, false, false); err != nil {
+			warnf("could not create follow-up %q: %v", title, err)
+			continue
+		}
+		dirs = append(dirs, t.DirPath)
+		if i < len(items) {
+			created = append(created, title)
+		}
+	}
+	p.Body = checkOffItems(p.Body, followUpsSection, created, false)
+	closed := now.Truncate(time.Second)
+	p.ClosedAt = &closed
+	path, err := writePlanAndIndex(root, cfg, p)
+	if err != nil {
+		return err
+	}
+	commitIncident(root, cfg, p, path, dirs...)
+	printSuccess("Closed incident: %s", p.Filename)
+	if len(titles) == 0 {
+		printHint("Next: fill in Impact and Root Cause in " + p.Filename)
+	}
+	return nil
+}
+
+// commitIncident is autoCommit for a write to the incident plan p at
+// planPath: the plan, the plan index, and the follow-up tasks created in
+// dirs go in one commit, with the save template.
+func commitIncident(root string, cfg config.Config, p plan.Plan, planPath string, dirs ...string) {
+	paths := []string{planPath, index.FilePath(root)}
+	if len(dirs) > 0 {
+		paths = append(paths, createdTaskPaths(root, cfg, dirs...)...)
+	}
+	autoCommit(root, cfg, config.CommitSave, map[string]string{"topic": p.Topic, "filename": filepath.Base(planPath)}, paths...)
+}
+
+// findIncident returns the open incident plan matching name, or the only
+// open incident when name is empty.
+func findIncident(root, name string) (plan.Plan, error) {
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	var open []plan.Plan
+	for _, p := range plans {
+		if p.Category == incidentCategory && p.ClosedAt == nil {
+			open = append(open, p)
+		}
+	}
+	if name != "" {
+		p, err := findPlan(name, open)
+		if err != nil {
+			return plan.Plan{}, fmt.Errorf("open incident: %w", err)
+		}
+		return p, nil
+	}
+	switch len(open) {
+	case 0:
+		return plan.Plan{}, errors.New(`no open incident (start one with logos incident start "<topic>")`)
+	case 1:
+		return open[0], nil
+	default:
+		names := make([]string, len(open))
+		for i, p := range open {
+			names[i] = p.Filename
+		}
+		return plan.Plan{}, fmt.Errorf("%d open incidents, use --name to pick one: %s", len(open), strings.Join(names, ", "))
+	}
+}
+
+// writePlanAndIndex writes p, rebuilds the plan index, and stages both.
+// Callers apply privacy.mode to the text they add (see enforcePrivacy).
+func writePlanAndIndex(root string, cfg config.Config, p plan.Plan) (string, error) {
+	path, err := plan.Write(root, p)
+	if err != nil {
+		return "", fmt.Errorf("write plan: %w", err)
+	}
+	if _, indexErr := index.RebuildWithOptions(root, planIndexOptions(cfg)); indexErr != nil {
+		warnf("could not rebuild index (%v) — run `logos sync` to rebuild", indexErr)
+	}
+	stageFiles(root, cfg, path, index.FilePath(root))
+	return path, nil
+}
+
+// timelineEntry formats one timeline bullet, stamped with now in loc.
+func timelineEntry(now time.Time, loc *time.Location, text string) string {
+	return fmt.Sprintf("- %s %s", now.In(loc).Format("2006-01-02 15:04"), text)
+}
+
+// appendTimeline adds line at the end of the Timeline section of body,
+// creating the section at the end of body when it is missing.
+func appendTimeline(body, line string) string {
+	return markdown.AppendToSection(body, timelineSection, line)
+}
+
+// openItems returns the unchecked "- [ ] ..." top-level items of the named
+// section of body. With bullets, plain "- ..." bullets count as open items
+// too.
+func openItems(body, name string, bullets bool) []string {
+	section, ok := markdown.Section(body, name)
+	if !ok {
+		return nil
+	}
+	var items []string
+	for _, line := range strings.Split(section, "\n") {
+		if text, ok := openItem(line, bullets); ok {
+			items = append(items, text)
+		}
+	}
+	return items
+}
+
+// checkOffItems returns body with the open items of the named section whose
+// text is in done checked off, so that openItems does not return them again.
+// Each entry of done checks off one item.
+func checkOffItems(body, name string, done []string, bullets bool) string {
+	section, ok := markdown.Section(body, name)
+	if !ok || len(done) == 0 {
+		return body
+	}
+	left := map[string]int{}
+	for _, text := range done {
+		left[text]++
+	}
+	lines := strings.Split(section, "\n")
+	for i, line := range lines {
+		if text, ok := openItem(line, bullets); ok && left[text] > 0 {
+			left[text]--
+			lines[i] = line[:2] + "[x] " + text
+		}
+	}
+	return markdown.SetSection(body, name, strings.Join(lines, "\n"))
+}
+
+// openItem reports whether line is an open top-level item (see openItems)
+// and returns its text.
+func openItem(line string, bullets bool) (string, bool) {
+	if len(line) < 2 || !strings.ContainsRune("-*+", rune(line[0])) || line[1] != ' ' {
+		return "", false
+	}
+	text := strings.TrimSpace(line[2:])
+	rest, checkbox := strings.CutPrefix(text, "[ ]")
+	switch {
+	case checkbox:
+		text = strings.TrimSpace(rest)
+	case !bullets || strings.HasPrefix(text, "[x]") || strings.HasPrefix(text, "[X]"):
+		return "", false
+	}
+	return text, text != ""
+}
@@ -0,0 +1,188 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// loadIncident returns the only plan in the project at dir.
+func loadIncident(t *testing.T, dir string) plan.Plan {
+	t.Helper()
+	plans, err := plan.LoadAll(dir)
+	if err != nil || len(plans) != 1 {
+		t.Fatalf("expected one plan, got %d (%v)", len(plans), err)
+	}
+	return plans[0]
+}
+
+// setUTC makes timeline stamps deterministic.
+func setUTC(t *testing.T, dir string) {
+	t.Helper()
+	cfg, _ := config.Load(dir)
+	cfg.Display.Timezone = "UTC"
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestIncidentStart_CreatesIncidentWithTimeline(t *testing.T) {
+	dir := setupInitedProject(t)
+	setUTC(t, dir)
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	if err := runIncidentStart("api outage", "", nil, now); err != nil {
+		t.Fatalf("runIncidentStart: %v", err)
+	}
+	p := loadIncident(t, dir)
+	if p.Category != "incident" || p.ClosedAt != nil {
+		t.Errorf("expected an open incident, got %+v", p)
+	}
+	timeline, _ := markdown.Section(p.Body, "Timeline")
+	if timeline != "- 2026-03-01 09:30 Started" {
+		t.Errorf("unexpected timeline: %q", timeline)
+	}
+	for _, h := range []string{"## Impact", "## Root Cause", "## Resolution", "## Follow-ups"} {
+		if !strings.Contains(p.Body, h) {
+			t.Errorf("expected %q in body:\n%s", h, p.Body)
+		}
+	}
+}
+
+func TestIncidentLog_AppendsInOrder(t *testing.T) {
+	dir := setupInitedProject(t)
+	setUTC(t, dir)
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	if err := runIncidentStart("api outage", "", nil, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := runIncidentLog("", "rolled back v1.2", now.Add(10*time.Minute)); err != nil {
+		t.Fatalf("runIncidentLog: %v", err)
+	}
+	timeline, _ := markdown.Section(loadIncident(t, dir).Body, "Timeline")
+	want := "- 2026-03-01 09:30 Started\n- 2026-03-01 09:40 rolled back v1.2"
+	if timeline != want {
+		t.Errorf("timeline = %q, want %q", timeline, want)
+	}
+}
+
+func TestIncidentLog_NoOpenIncident(t *testing.T) {
+	setupInitedProject(t)
+	if err := runIncidentLog("", "anything", time.Now()); err == nil {
+		t.Error("expected error without an open incident")
+	}
+}
+
+func TestIncidentLog_SeveralOpenRequiresName(t *testing.T) {
+	setupInitedProject(t)
+	now := time.Now()
+	if err := runIncidentStart("api outage", "", nil, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := runIncidentStart("db failover", "", nil, now); err != nil {
+		t.Fatal(err)
+	}
+	if err := runIncidentLog("", "entry", now); err == nil || !strings.Contains(err.Error(), "--name") {
+		t.Errorf("expected --name hint, got %v", err)
+	}
+	if err := runIncidentLog("db-failover", "entry", now); err != nil {
+		t.Errorf("runIncidentLog with --name: %v", err)
+	}
+}
+
+func TestIncidentClose_ResolvesAndCreatesFollowUps(t *testing.T) {
+	dir := setupInitedProject(t)
+	setUTC(t, dir)
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	if err := runIncidentStart("api outage", "", nil, now); err != nil {
+		t.Fatal(err)
+	}
+	p := loadIncident(t, dir)
+	p.Body = markdown.SetSection(p.Body, "Follow-ups", "- [ ] Add a canary stage\n- [x] Already handled")
+	if _, err := plan.Write(dir, p); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runIncidentClose("", "Rolled back to v1.1.", []string{"Page on 5xx rate"}, now.Add(time.Hour)); err != nil {
+		t.Fatalf("runIncidentClose: %v", err)
+	}
+
+	p = loadIncident(t, dir)
+	if p.ClosedAt == nil {
+		t.Error("expected closed_at to be set")
+	}
+	if res, _ := markdown.Section(p.Body, "Resolution"); res != "Rolled back to v1.1." {
+		t.Errorf("resolution = %q", res)
+	}
+	if !strings.Contains(p.Body, "- 2026-03-01 10:30 Resolved") || !strings.Contains(p.Body, "- [x] Add a canary stage") {
+		t.Errorf("unexpected body:\n%s", p.Body)
+	}
+
+	cfg, _ := config.Load(dir)
+	tasks, err := task.NewStore(dir, &cfg).List(task.Filter{Plan: strings.TrimSuffix(p.Filename, ".md")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var titles []string
+	for _, tk := range tasks {
+		titles = append(titles, tk.Title)
+	}
+	got := strings.Join(titles, "|")
+	if !strings.Contains(got, "Add a canary stage") || !strings.Contains(got, "Page on 5xx rate") || strings.Contains(got, "Already handled") {
+		t.Errorf("unexpected follow-up tasks: %v", titles)
+	}
+
+	if err := runIncidentLog("", "late entry", now); err == nil {
+		t.Error("expected closed incident to reject new entries")
+	}
+}
+
+func TestIncidentClose_ChecksOffOnlyCreatedFollowUps(t *testing.T) {
+	dir := setupInitedProject(t)
+	setPrivacy(t, dir, "block")
+	now := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+	if err := runIncidentStart("api outage", "", nil, now); err != nil {
+		t.Fatal(err)
+	}
+	p := loadIncident(t, dir)
+	p.Body = markdown.SetSection(p.Body, "Follow-ups", "- [ ] Add a canary stage\n- [ ] Revoke "+testAWSKey+"\n- Ask the vendor")
+	if _, err := plan.Write(dir, p); err != nil {
+		t.Fatal(err)
+	}
+
+	captureStderr(t, func() {
+		if err := runIncidentClose("", "", nil, now.Add(time.Hour)); err != nil {
+			t.Fatalf("runIncidentClose: %v", err)
+		}
+	})
+
+	p = loadIncident(t, dir)
+	want := "- [x] Add a canary stage\n- [ ] Revoke " + testAWSKey + "\n- Ask the vendor"
+	if got, _ := markdown.Section(p.Body, "Follow-ups"); got != want {
+		t.Errorf("Follow-ups = %q, want %q", got, want)
+	}
+	if tasks := loadAllTasks(t, dir); len(tasks) != 1 || tasks[0].Title != "Add a canary stage" {
+		t.Errorf("expected only the canary task, got %+v", tasks)
+	}
+}
+
+func TestIncidentStart_RejectedWhenCategoryNotAllowed(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, _ := config.Load(dir)
+	cfg.Plans.Categories = []string{"design"}
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := runIncidentStart("api outage", "", nil, time.Now()); err == nil {
+		t.Error("expected error when incident is not an allowed category")
+	}
+	if entries, _ := os.ReadDir(filepath.Join(dir, ".logosyncx", "plans")); len(entries) > 1 {
+		t.Errorf("expected no plan to be written, got %d entries", len(entries))
+	}
+}
@@ -0,0 +1,161 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/suggest"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// planDocument is the JSON read by logos save --from-stdin-json.
+type planDocument struct {
+	Topic    string            `json:"topic"`
+	Tags     []string          `json:"tags"`
+	Agent    string            `json:"agent"`
+	Category string            `json:"category"`
+	Sections map[string]string `json:"sections"`
+}
+
+// taskDocument is the JSON read by logos task create --from-stdin-json.
+type taskDocument struct {
+	Title     string            `json:"title"`
+	Priority  string            `json:"priority"`
+	Tags      []string          `json:"tags"`
+	DependsOn []int             `json:"depends_on"`
+	Sections  map[string]string `json:"sections"`
+}
+
+// readJSONDocument decodes a single JSON object from r into v. Unknown
+// fields and trailing data are errors, so a misspelled key is reported
+// instead of silently dropped.
+func readJSONDocument(r io.Reader, v any) error {
+	dec := json.NewDecoder(r)
+	dec.DisallowUnknownFields()
+	if err := dec.Decode(v); err != nil {
+		if err == io.EOF {
+			return fmt.Errorf("--from-stdin-json: no JSON document on stdin")
+		}
+		return fmt.Errorf("--from-stdin-json: %w", err)
+	}
+	if dec.More() {
+		return fmt.Errorf("--from-stdin-json: expected a single JSON object on stdin")
+	}
+	return nil
+}
+
+// templateHeadings returns the level-2 headings of the template
+// .logosyncx/templates/<name>, or of fallback when the file cannot be read.
+func templateHeadings(root, name, fallback string) []string {
+	body := fallback
+	if data, err := os.ReadFile(filepath.Join(root, ".logosyncx", "templates", name)); err == nil {
+		body = string(data)
+	}
+	var headings []string
+	for _, s := range markdown.Outline(body) {
+		if s.Level == 2 {
+			headings = append(headings, s.Heading)
+		}
+	}
+	return headings
+}
+
+// planSections returns the section headings a plan written by logos save
+// may fill in, in scaffold order: those of templateName (or of the category
+// when there is no template), then templates/plan.md, then the sections
+// named in the plans config.
+func planSections(root string, cfg config.Config, category, templateName string) []string {
+	var lists [][]string
+	if templateName != "" {
+		if tmpl, err := cfg.Template(templateName); err == nil {
+			lists = append(lists, templateSectionNames(tmpl))
+		}
+	} else {
+		lists = append(lists, cfg.Plans.CategorySectionsFor(category))
+	}
+	lists = append(lists,
+		templateHeadings(root, "plan.md", defaultPlanTemplate),
+		cfg.Plans.RequiredSections,
+		cfg.Plans.SummarySections,
+		[]string{cfg.Plans.ExcerptSection},
+		cfg.Plans.ExcerptFallback,
+	)
+	return mergeSections(lists...)
+}
+
+// taskSections is planSections for tasks: the sections of templateName,
+// templates/task.md, and those named in the tasks config.
+func taskSections(root string, cfg config.Config, templateName string) []string {
+	var lists [][]string
+	if templateName != "" {
+		if tmpl, err := cfg.Template(templateName); err == nil {
+			lists = append(lists, templateSectionNames(tmpl))
+		}
+	}
+	lists = append(lists,
+		templateHeadings(root, "task.md", defaultTaskTemplate),
+		cfg.Tasks.RequiredSections,
+		cfg.Tasks.SummarySections,
+		[]string{cfg.Tasks.ExcerptSection},
+		cfg.Tasks.ExcerptFallback,
+	)
+	return mergeSections(lists...)
+}
+
+func templateSectionNames(tmpl config.TemplateConfig) []string {
+	names := make([]string, len(tmpl.Sections))
+	for i, s := range tmpl.Sections {
+		names[i] = s.Name
+	}
+	return names
+}
+
+// mergeSections concatenates lists, dropping empty names and names already
+// seen (compared case-insensitively).
+func mergeSections(lists ...[]string) []string {
+	var out []string
+	for _, list := range lists {
+		for _, name := range list {
+			name = strings.TrimSpace(name)
+			if name == "" || slices.ContainsFunc(out, func(o string) bool { return strings.EqualFold(o, name) }) {
+				continue
+			}
+			out = append(out, name)
+		}
+	}
+	return out
+}
+
+// fillSections writes the content of sections into body under their
+// headings, in the order of known, adding the headings body lacks at the
+// end. Section names are matched against known case-insensitively; a name
+// that is not known is an error with a "did you mean" suggestion when one
+// is close.
+func fillSections(body string, sections map[string]string, known []string) (string, error) {
+	content := make(map[string]string, len(sections))
+	for name, text := range sections {
+		i := slices.IndexFunc(known, func(k string) bool { return strings.EqualFold(k, strings.TrimSpace(name)) })
+		if i < 0 {
+			if s := suggest.Closest(name, known); s != "" {
+				return "", fmt.Errorf("unknown section %q (did you mean %q?)", name, s)
+			}
+			return "", fmt.Errorf("unknown section %q: known sections are %s", name, strings.Join(known, ", "))
+		}
+		if _, dup := content[known[i]]; dup {
+			return "", fmt.Errorf("section %q is given more than once", known[i])
+		}
+		content[known[i]] = text
+	}
+	for _, name := range known {
+		if text, ok := content[name]; ok {
+			body = markdown.SetSection(body, name, text)
+		}
+	}
+	return body, nil
+}
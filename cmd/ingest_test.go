@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+func TestReadJSONDocument(t *testing.T) {
+	var doc planDocument
+	err := readJSONDocument(strings.NewReader(`{"topic": "auth", "sections": {"Spec": "line 1\nline 2"}}`), &doc)
+	if err != nil {
+		t.Fatalf("readJSONDocument: %v", err)
+	}
+	if doc.Topic != "auth" || doc.Sections["Spec"] != "line 1\nline 2" {
+		t.Errorf("doc = %+v", doc)
+	}
+
+	for _, in := range []string{``, `{"topic": "auth", "tpoic": "x"}`, `{"topic": "a"} {"topic": "b"}`} {
+		if err := readJSONDocument(strings.NewReader(in), &planDocument{}); err == nil {
+			t.Errorf("readJSONDocument(%q) = nil, want an error", in)
+		}
+	}
+}
+
+func TestFillSections(t *testing.T) {
+	known := []string{"Background", "Spec", "Notes"}
+	body, err := fillSections("## Background\n\n<!-- why -->\n", map[string]string{
+		"notes": "risks",
+		"Spec":  "- build it\n- ship it",
+	}, known)
+	if err != nil {
+		t.Fatalf("fillSections: %v", err)
+	}
+	want := "## Background\n\n<!-- why -->\n\n## Spec\n\n- build it\n- ship it\n\n## Notes\n\nrisks\n"
+	if body != want {
+		t.Errorf("body = %q, want %q", body, want)
+	}
+
+	_, err = fillSections("", map[string]string{"Specs": "x"}, known)
+	if err == nil || !strings.Contains(err.Error(), `did you mean "Spec"`) {
+		t.Errorf("unknown section error = %v", err)
+	}
+}
+
+func TestSaveSections_WritesBody(t *testing.T) {
+	dir := setupInitedProject(t)
+
+	captureOutput(t, func() {
+		err := runSaveSections("auth flow", []string{"auth"}, "", "", nil, nil, nil, false, "", map[string]string{
+			"Background":    "Sessions expire too early.",
+			"Key Decisions": "Decision: refresh tokens.\nRationale: fewer logins.",
+		})
+		if err != nil {
+			t.Fatalf("runSaveSections: %v", err)
+		}
+	})
+
+	plans, err := plan.LoadAll(dir)
+	if err != nil || len(plans) != 1 {
+		t.Fatalf("plans = %v (%v)", plans, err)
+	}
+	if got, _ := markdown.Section(plans[0].Body, "Key Decisions"); got != "Decision: refresh tokens.\nRationale: fewer logins." {
+		t.Errorf("Key Decisions = %q", got)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(plans[0].Body), "## Background\n") {
+		t.Errorf("body does not start with Background: %q", plans[0].Body)
+	}
+}
+
+func TestTaskCreateSections_UnknownSectionCreatesNothing(t *testing.T) {
+	dir := setupInitedProject(t)
+
+	err := runTaskCreateSections(dir, taskCreateOptions{plan: testPlan, title: "Add login"}, map[string]string{"Wat": "x"})
+	if err == nil || !strings.Contains(err.Error(), `did you mean "What"`) {
+		t.Fatalf("runTaskCreateSections = %v, want an unknown section error", err)
+	}
+	if tasks := loadAllTasks(t, dir); len(tasks) != 0 {
+		t.Errorf("created %d task(s), want none", len(tasks))
+	}
+
+	captureOutput(t, func() {
+		err := runTaskCreateSections(dir, taskCreateOptions{plan: testPlan, title: "Add login"}, map[string]string{"what": "A login form."})
+		if err != nil {
+			t.Fatalf("runTaskCreateSections: %v", err)
+		}
+	})
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 {
+		t.Fatalf("created %d task(s), want 1", len(tasks))
+	}
+	if got, _ := markdown.Section(tasks[0].Body, "What"); got != "A login form." {
+		t.Errorf("What = %q", got)
+	}
+}
@@ -2,12 +2,14 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 
+	"github.com/senna-lang/logosyncx/internal/gitutil"
 	"github.com/senna-lang/logosyncx/pkg/config"
 	"github.com/spf13/cobra"
 )
@@ -43,6 +45,11 @@ Before writing any document body (plan, task, knowledge), you MUST:
 
 The CLI produces a frontmatter scaffold only. You fill the body.
 
+Never put secrets (API keys, tokens, private keys) in a topic, title, tag, or body.
+Depending on ` + "`privacy.mode`" + `, ` + "`logos save`" + ` and ` + "`logos task create`" + ` warn about,
+redact (` + "`[REDACTED:<pattern>]`" + `), or refuse text matching the privacy patterns,
+and ` + "`logos check`" + ` fails on matches anywhere in ` + "`.logosyncx/`" + `.
+
 ---
 
 ## Workflow for finding relevant context
@@ -51,6 +58,7 @@ The CLI produces a frontmatter scaffold only. You fill the body.
 2. Read ` + "`topic`" + `, ` + "`tags`" + `, and ` + "`excerpt`" + ` fields to judge relevance yourself
 3. Run ` + "`logos refer --name <filename> --summary`" + ` on relevant plans to get details
 4. If you want to narrow down by keyword first, use ` + "`logos search --keyword <keyword>`" + `
+5. Entries with an ` + "`\"origin\"`" + ` come from a shared, read-only overlay root: read them, but never try to edit them
 
 ## Workflow for saving a plan
 
@@ -72,7 +80,16 @@ cat .logosyncx/templates/plan.md
 logos ls                       # human-readable table
 logos ls --tag auth            # filter by tag
 logos ls --since 2026-01-01    # filter by date
+logos ls --since "last monday" # relative dates work too: yesterday, 3d, 2w ago, ...
 logos ls --blocked             # show only blocked plans
+logos ls --has-open-tasks      # show only plans with unfinished tasks
+logos ls --format wide         # add an excerpt column, wrapped to the terminal width
+logos ls --full                # do not truncate long topics to fit the terminal
+logos ls --agent claude-code   # only plans saved by one agent (--show-agent adds a column)
+logos ls --category incident   # only plans of one category (design, incident, research, meeting)
+logos ls --unacked-by me       # only plans you have not acknowledged with logos ack
+logos ls --current-branch      # only plans saved on this git branch, plus unscoped ones
+logos ls --json --limit 20 --fields filename,topic,excerpt   # fewer plans and fields (saves tokens)
 logos ls --json                # structured output with excerpts (preferred for agents)
 ` + "```" + `
 
@@ -81,23 +98,113 @@ logos ls --json                # structured output with excerpts (preferred for
 logos refer --name <filename>            # full content
 logos refer --name <partial-name>        # partial match
 logos refer --name <filename> --summary  # key sections only (saves tokens, prefer this)
+logos refer --name <filename> --with-tasks  # append the tasks linked to the plan
+logos refer --name <filename> --outline     # section names with byte/word/token sizes
+logos refer --name <filename> --json        # plan + per-section sizes as JSON
+logos outline --name <plan-or-task>         # heading structure only, for plans and tasks
 ` + "```" + `
 
 ### Save a plan
 ` + "```" + `
 logos save --topic "short description"
 logos save --topic "..." --tag go --tag cli --agent claude-code --depends-on 20260304-auth.md
+logos save --topic "..." --for-task <name> --start   # link to the task worked on; mark it in_progress
+logos save --topic "..." --category design            # scaffold the body with the category's sections
+logos save --topic "..." --template retro             # scaffold from a named template (bugfix, retro, or config templates)
+logos save --topic "..." --git=commit                 # stage and commit this plan (overrides git.auto; off, add, commit, push)
+echo '{"topic": "...", "tags": ["go"], "sections": {"Background": "...", "Spec": "..."}}' | logos save --from-stdin-json
+                                                      # topic, tags, agent, category, and body sections as JSON; no escaping needed
+` + "```" + `
+
+### Weekly journal
+` + "```" + `
+logos journal                    # add today's "## YYYY-MM-DD (Day)" heading to this week's journal
+logos refer --week 2025-W12      # read the journal for an ISO week
+` + "```" + `
+
+### Incidents
+` + "```" + `
+logos incident start "api outage"        # incident plan with a live Timeline section
+logos incident log "rolled back v1.2"    # timestamped timeline entry
+logos incident close --resolution "..."  # resolve; unchecked Follow-ups become tasks
+` + "```" + `
+
+### Meeting notes
+` + "```" + `
+logos meeting --topic "..." --attendee alice --attendee bob   # meeting plan with attendees
+logos meeting actions          # "- [ ] @alice do X" under Action Items -> task assigned to alice
+` + "```" + `
+
+### Pin a plan into the agent context file
+` + "```" + `
+logos agents pin --name <filename>   # always loaded by agents via context_file
+logos agents unpin --name <filename>
 ` + "```" + `
 
 ### Search (keyword narrowing)
 ` + "```" + `
 logos search --keyword "keyword"
 logos search --keyword "auth" --tag security
+logos search --keyword "auth" --json          # JSON array (ls --json fields + matched_in); --ndjson for one object per line
+logos task search --keyword "auth" --json     # same for tasks
+logos stats --most-used    # plans and tasks you refer to most (listed first by search)
+` + "```" + `
+
+### Standup summary
+` + "```" + `
+logos standup                          # done / doing / blocked since yesterday, as markdown
+logos standup --author me --since 3d   # only your work over the last 3 days
+` + "```" + `
+
+### Resume where you left off
+` + "```" + `
+logos resume --json               # latest plan, its in_progress tasks, uncommitted .logosyncx/ files
+logos resume --agent claude-code  # latest plan saved by that agent
+` + "```" + `
+
+### Dashboard across repositories
+` + "```" + `
+logos dash --roots ~/code/* --json   # open tasks and recent plans per repository
+logos projects ls --json             # projects registered on this machine (logos ls --project <name> reads one)
+logos project rename <name>          # rename this project in config, the registry, and the context file
+logos tui                            # interactive plan/task browser (humans only; agents use ls/refer)
+` + "```" + `
+
+### Catch up after time away
+` + "```" + `
+logos inbox                # plans and tasks created since your last ack
+logos inbox ack            # mark everything as seen (stored per user, not committed)
+` + "```" + `
+
+### Acknowledge a decision
+` + "```" + `
+logos ack --name <name>    # record that you have read the plan (git user.name, or --as <user>)
+` + "```" + `
+
+### Rename a plan
+` + "```" + `
+logos rename --name <name> --topic "<new topic>"   # renames the file and rewrites links to it; never rename plan files by hand
+` + "```" + `
+
+### Freeze a finalized decision
+` + "```" + `
+logos freeze --name <name> --reason "signed off"   # frozen plans cannot be edited; do not edit them by hand
+` + "```" + `
+
+### Onboarding digest
+` + "```" + `
+logos onboard                      # brief, pinned plans, decisions, open tasks as markdown
+logos onboard --out ONBOARDING.md  # write it to a file instead
 ` + "```" + `
 
 ### Sync index
 ` + "```" + `
-logos sync
+logos sync                 # rebuild the plan and task indexes in parallel
+logos sync --only tasks    # rebuild one index (plans or tasks)
+logos sync --check         # exit non-zero if an index is out of date; writes nothing
+logos sync --json          # machine-readable summary (counts, durations, strays)
+logos sync --auto-link     # first link plans and tasks that mention each other
+logos watch                # keep both indexes in sync while files change and run watch.jobs (Ctrl-C to stop)
 ` + "```" + `
 
 Rebuilds the plan and task indexes from the filesystem.
@@ -109,6 +216,13 @@ logos gc
 logos gc purge --force
 ` + "```" + `
 
+### Archive and restore
+` + "```" + `
+logos archive --name <plan> [--with-tasks]   # move a plan (and its tasks) out of the index
+logos archive ls                             # list archived plans and tasks
+logos archive restore --name <name>          # bring an archived plan or task back
+` + "```" + `
+
 ---
 
 ## Tasks
@@ -155,26 +269,109 @@ Scaffold-only files (all HTML comment blocks) will be rejected.
 ` + "```" + `
 # List tasks
 logos task ls                                     # all tasks
-logos task ls --plan <plan-filename>              # tasks for a specific plan
+logos task ls --plan <plan-filename>              # tasks for a plan (incl. related_plans)
 logos task ls --status open                       # filter by status
+logos task ls --status open,in_progress           # several statuses (also --priority high,medium)
 logos task ls --blocked                           # show only blocked tasks
+logos task ls --include-unknown                   # also list misplaced task files
+logos task ls --status open --sort order          # backlog in manual ranking order
+logos task ls --all                               # include snoozed tasks
 logos task ls --json                              # structured output (preferred for agents)
+logos task ls --count-only [--json]               # counts by status (and priority with --json) only
+logos task ls --current-branch                    # tasks created on this git branch, plus unscoped ones
+logos task ls --json --limit 20 --offset 20 --fields seq,title,status   # page through tasks, only some fields
 
 # Read a task
 logos task refer --name <name>                    # full TASK.md content
 logos task refer --name <name> --summary          # key sections only (saves tokens)
+logos task refer --name <name> --no-related       # omit the "Possibly related" task list
 
 # Create a task
 logos task create --plan <plan-filename> --title "..."
 logos task create --plan <plan-filename> --title "..." --priority high --tag go --depends-on 1
+logos task create --plan <plan-filename> --title "..." --no-rules   # skip tasks.rules routing
+logos task create --plan <plan-filename> --title "..." --seed       # pre-fill What/Why from the plan
+logos task create --plan <plan-filename> --title "..." --template bugfix   # scaffold from a named template
+logos task create --plan <plan-filename> --from-plan   # one task per open Action Items bullet; "(high)" sets priority
+echo '{"title": "...", "sections": {"What": "...", "Acceptance Criteria": "- [ ] ..."}}' | logos task create --plan <plan-filename> --from-stdin-json
+                                                       # sections must be headings of templates/task.md (unknown ones are errors)
+logos rules test --tag infra                                          # preview routing rules
+
+# Import an existing checklist (top-level "- [ ]" items become tasks, "- [x]" become done)
+logos task import --plan <plan-filename> TODO.md [--tag <tag>] [--dry-run]
 
 # Update a task
 logos task update --plan <plan-filename> --name <name> --status in_progress
 logos task update --plan <plan-filename> --name <name> --status done
 logos task update --plan <plan-filename> --name <name> --priority high
+logos task update --plan <plan-filename> --name <name> --due 2026-05-01   # or "friday", "in 2 weeks"; "none" clears
+logos task update --status-filter open --tag-filter auth --set priority=high --force   # bulk: every matching task
 
 # Open walkthrough scaffold
 logos task walkthrough --plan <plan-filename> --name <name>
+
+# Rewrite tasks left with a renamed/unknown status (reported by logos sync)
+logos task migrate-status --from <old-status> --to <new-status>
+
+# Move context to another repository: export here, import there
+logos bundle export --output context.logos.tar.gz
+logos bundle import context.logos.tar.gz --dry-run
+
+# Dump all plans and tasks for other tools (json, csv, or one markdown file)
+logos export --format markdown --output export.md
+
+# Map how plans, tasks, and knowledge link up (Graphviz DOT, Mermaid, or --json)
+logos graph --json
+
+# Turn external Markdown/JSON notes into plans (--dry-run to preview)
+logos import ~/notes/vault --tag imported --dry-run
+
+# Rename a tag across every plan and task (--dry-run to preview)
+logos retag --filter-tag <old> --add <new> --remove <old>
+
+# Report misplaced task files; move them into <plan>/NNN-<title>/TASK.md
+logos doctor
+logos doctor --fix-status-dirs
+logos doctor --fix-links       # repair related entries that name no existing plan
+logos doctor --fix-plan-fields # set a task's plan field to the directory it sits in
+
+# Run every health check (config, indexes, layout, links, required sections,
+# privacy patterns); exits non-zero on failure — use in CI
+logos check
+logos check --json --output check.json
+
+# Check and edit config.json (settings named by path, e.g. plans.excerpt_section)
+logos config validate [--json]       # unknown keys, bad values and regexes, excerpt_section not a section
+logos config get tasks.summary_sections
+logos config set plans.excerpt_max_runes 200   # edits in place, keeping the file's formatting
+logos config schema                  # JSON Schema of config.json
+
+# Per-assignee load table + least-loaded teammate from tasks.roster in config
+logos task suggest-assignee --name <name>
+
+# Re-rank the backlog
+logos task move --name <name> --before <other-task>
+logos task move --name <name> --after <other-task>
+
+# Retitle a task (renames its directory; seq and depends_on links are kept)
+logos task rename --name <name> --title "<new title>"
+
+# Leave a note on a task (appended to its ## Log section with time and author)
+logos task comment --name <name> --message "..."
+logos task refer --name <name> --log              # log entries only
+
+# Show what a task waits on (recursively) and what it blocks; a task with
+# unfinished dependencies cannot be set to in_progress or done
+logos task deps --name <name>
+
+# Defer a task until a date (hidden from task ls until then)
+logos task snooze --name <name> --until 2025-04-01
+logos task snooze --name <name> --until "next monday"   # or tomorrow, 3d, in 2 weeks
+
+# Archive old done tasks to .logosyncx/tasks-archive/ (restorable until logos gc purge)
+logos task purge --older-than 30d --dry-run
+logos task purge --older-than 30d --tag chore --force
+logos task archive --name <name>                 # archive one task, whatever its status
 ` + "```" + `
 
 ---
@@ -200,6 +397,9 @@ cat .logosyncx/templates/knowledge.md
 - Use ` + "`logos ls --json`" + ` first to scan all plans cheaply via excerpts
 - Use ` + "`--summary`" + ` on ` + "`refer`" + ` unless you need the full plan body
 - Only use full ` + "`refer`" + ` when the summary is insufficient
+- Pass ` + "`--for-agent`" + ` (or set ` + "`LOGOS_AGENT=1`" + `) to strip check marks and "Next:" hints from command output; this profile is also used automatically when stdout is not a terminal
+- Pass ` + "`--warnings-json`" + ` (or set ` + "`LOGOS_WARNINGS_JSON=1`" + `) to get warnings on stderr as JSON lines (` + "`{\"level\":\"warning\",\"command\":\"ls\",\"message\":\"...\"}`" + `), e.g. index auto-rebuild notices, so they can be captured separately from ` + "`--json`" + ` output
+- Confirmation prompts fail instead of waiting when stdin is not a terminal; pass ` + "`--yes`" + ` (or the command's ` + "`--force`" + `) to confirm destructive commands
 `
 
 // agentsLine is appended to AGENTS.md (or CLAUDE.md) by logos init.
@@ -340,22 +540,45 @@ var initCmd = &cobra.Command{
 	Short: "Initialize Logosyncx in the current directory",
 	Long: `Create .logosyncx/ with plans/, knowledge/, templates/, config.json and USAGE.md.
 Append a reference line to AGENTS.md (or CLAUDE.md if present).
-Exits with an error if the project has already been initialized.`,
+Exits with an error if the project has already been initialized.
+
+When the current directory is inside a git repository, logos init warns if
+.logosyncx/ is matched by a .gitignore rule (plans and tasks would silently
+never be shared). Pass --commit to stage and commit the scaffold in one step.
+
+--storage <dir> keeps plans and tasks outside this repository, for teams that
+cannot commit context alongside source. <dir> (e.g. ../project-context, often
+a sibling repository) is initialised as above, and this directory only gets
+.logosyncx/config.json pointing there plus a copy of USAGE.md. Every logos
+command run here then reads and writes <dir>. $LOGOS_STORAGE overrides the
+storage directory for a single shell.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runInit()
+		commit, _ := cmd.Flags().GetBool("commit")
+		storage, _ := cmd.Flags().GetString("storage")
+		if storage != "" {
+			return runInitStorage(storage, commit)
+		}
+		return runInit(commit)
 	},
 }
 
 func init() {
+	initCmd.Flags().Bool("commit", false, "Stage and commit the .logosyncx/ scaffold and agents file with git")
+	initCmd.Flags().String("storage", "", "Store plans and tasks in this directory (e.g. ../project-context) instead of here")
 	rootCmd.AddCommand(initCmd)
 }
 
-func runInit() error {
+func runInit(commit bool) error {
 	cwd, err := os.Getwd()
 	if err != nil {
 		return fmt.Errorf("cannot determine working directory: %w", err)
 	}
+	return initProject(cwd, commit)
+}
 
+// initProject creates the .logosyncx/ scaffold in cwd and links it from the
+// agents file there.
+func initProject(cwd string, commit bool) error {
 	logosyncxDir := filepath.Join(cwd, config.DirName)
 
 	// Guard: already initialized.
@@ -399,11 +622,14 @@ func runInit() error {
 		return fmt.Errorf("write config.json: %w", err)
 	}
 
-	// 4. Write USAGE.md.
+	// 4. Write USAGE.md, and .gitignore for lock and temporary files.
 	usagePath := filepath.Join(logosyncxDir, "USAGE.md")
 	if err := os.WriteFile(usagePath, []byte(usageMD), 0o644); err != nil {
 		return fmt.Errorf("write USAGE.md: %w", err)
 	}
+	if err := config.IgnoreTransient(cwd); err != nil {
+		return fmt.Errorf("write .gitignore: %w", err)
+	}
 
 	// 5. Append reference line to agents file.
 	agentsPath := filepath.Join(cwd, agentsFile)
@@ -411,22 +637,140 @@ func runInit() error {
 		return fmt.Errorf("update %s: %w", agentsFile, err)
 	}
 
-	fmt.Printf("✓ Initialized Logosyncx in %s\n", cwd)
+	registerProject(cwd)
+
+	printSuccess("Initialized Logosyncx in %s", cwd)
 	fmt.Printf("  Created  .logosyncx/\n")
 	fmt.Printf("  Created  .logosyncx/plans/\n")
 	fmt.Printf("  Created  .logosyncx/knowledge/\n")
 	fmt.Printf("  Created  .logosyncx/templates/\n")
 	fmt.Printf("  Created  .logosyncx/config.json\n")
 	fmt.Printf("  Created  .logosyncx/USAGE.md\n")
+	fmt.Printf("  Created  .logosyncx/.gitignore\n")
 	fmt.Printf("  Updated  %s\n", agentsFile)
-	fmt.Println()
-	fmt.Println("Next steps:")
-	fmt.Printf("  1. Commit .logosyncx/ to git\n")
-	fmt.Printf("  2. Run `logos save --topic <topic>` to save your first plan\n")
+
+	// 6. Git integration: ignore-rule check and optional scaffold commit.
+	committed := false
+	if !gitutil.IsRepo(cwd) {
+		warnf("not inside a git repository — .logosyncx/ will not be shared until it is committed to git")
+	} else {
+		warnIfIgnored(cwd)
+		if commit {
+			if err := commitScaffold(cwd, logosyncxDir, agentsPath); err != nil {
+				warnf("could not commit scaffold: %v", err)
+			} else {
+				printSuccess("Committed .logosyncx/ and %s", agentsFile)
+				committed = true
+			}
+		}
+	}
+
+	if committed {
+		printHint(
+			"Next steps:",
+			"  1. Run `logos save --topic <topic>` to save your first plan",
+		)
+	} else {
+		printHint(
+			"Next steps:",
+			"  1. Commit .logosyncx/ to git (or re-run init with --commit next time)",
+			"  2. Run `logos save --topic <topic>` to save your first plan",
+		)
+	}
 
 	return nil
 }
 
+// runInitStorage initialises storage (relative to the working directory, or
+// absolute) as a full project unless it already is one, then writes a
+// pointer config in the working directory.
+func runInitStorage(storage string, commit bool) error {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return fmt.Errorf("cannot determine working directory: %w", err)
+	}
+	logosyncxDir := filepath.Join(cwd, config.DirName)
+	if _, err := os.Stat(logosyncxDir); err == nil {
+		return errors.New("already initialized: .logosyncx/ already exists")
+	}
+
+	target := storage
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(cwd, target)
+	}
+	if _, err := os.Stat(filepath.Join(target, config.DirName)); err == nil {
+		printSuccess("Using existing storage in %s", target)
+	} else {
+		if err := os.MkdirAll(target, 0o755); err != nil {
+			return fmt.Errorf("create storage directory: %w", err)
+		}
+		if err := initProject(target, commit); err != nil {
+			return err
+		}
+	}
+
+	// The pointer holds only the version and the storage path, as given,
+	// so that a relative path keeps working in every clone.
+	if err := os.MkdirAll(logosyncxDir, 0o755); err != nil {
+		return fmt.Errorf("create directory %s: %w", logosyncxDir, err)
+	}
+	pointer, err := json.MarshalIndent(struct {
+		Version string `json:"version"`
+		Storage string `json:"storage"`
+	}{"2", filepath.ToSlash(storage)}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(config.ConfigPath(cwd), append(pointer, '\n'), 0o644); err != nil {
+		return fmt.Errorf("write config.json: %w", err)
+	}
+	if err := os.WriteFile(filepath.Join(logosyncxDir, "USAGE.md"), []byte(usageMD), 0o644); err != nil {
+		return fmt.Errorf("write USAGE.md: %w", err)
+	}
+	agentsFile := detectAgentsFile(cwd)
+	if err := appendAgentsLine(filepath.Join(cwd, agentsFile)); err != nil {
+		return fmt.Errorf("update %s: %w", agentsFile, err)
+	}
+
+	printSuccess("Linked %s to storage in %s", cwd, target)
+	fmt.Printf("  Created  .logosyncx/config.json (storage: %s)\n", filepath.ToSlash(storage))
+	fmt.Printf("  Created  .logosyncx/USAGE.md\n")
+	fmt.Printf("  Updated  %s\n", agentsFile)
+	printHint("Commit .logosyncx/ here, and plans and tasks in the storage directory's repository.")
+	return nil
+}
+
+// initCommitMessage is the commit message used by logos init --commit.
+const initCommitMessage = "logos: initialize .logosyncx"
+
+// warnIfIgnored prints a warning to stderr when .logosyncx/ is matched by a
+// git ignore rule. Ignored context is a common silent misconfiguration: saves
+// succeed locally but nothing ever reaches teammates.
+func warnIfIgnored(projectRoot string) {
+	probe := filepath.Join(config.DirName, config.ConfigFileName)
+	ignored, err := gitutil.IsIgnored(projectRoot, probe)
+	if err != nil || !ignored {
+		return
+	}
+	warnf("%s/ is ignored by git (check .gitignore) — plans and tasks will not be committed", config.DirName)
+	if !warningsJSON {
+		fmt.Fprintf(os.Stderr, "         add \"!%s/\" to .gitignore to share project context\n", config.DirName)
+	}
+}
+
+// commitScaffold stages the .logosyncx/ directory and the agents file, then
+// commits just those two paths, leaving anything else the user had staged
+// alone.
+func commitScaffold(projectRoot, logosyncxDir, agentsPath string) error {
+	if err := gitutil.Add(projectRoot, logosyncxDir); err != nil {
+		return err
+	}
+	if err := gitutil.Add(projectRoot, agentsPath); err != nil {
+		return err
+	}
+	return gitutil.Commit(projectRoot, initCommitMessage, logosyncxDir, agentsPath)
+}
+
 // detectAgentsFile returns "CLAUDE.md" if it exists in the project root,
 // otherwise falls back to "AGENTS.md" (creating it if needed).
 func detectAgentsFile(projectRoot string) string {
@@ -2,10 +2,12 @@ package cmd
 
 import (
 	"os"
+	"os/exec"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/senna-lang/logosyncx/internal/gitutil"
 	"github.com/senna-lang/logosyncx/pkg/config"
 )
 
@@ -21,7 +23,7 @@ func runInitInDir(t *testing.T, dir string) error {
 		t.Fatalf("chdir: %v", err)
 	}
 	t.Cleanup(func() { _ = os.Chdir(orig) })
-	return runInit()
+	return runInit(false)
 }
 
 // --- runInit -----------------------------------------------------------------
@@ -185,6 +187,26 @@ func TestInit_CreatesUSAGEMD(t *testing.T) {
 	}
 }
 
+func TestInit_GitignoreListsTransientFiles(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	if err := runInitInDir(t, dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, probe := range []string{
+		filepath.Join(".logosyncx", "task-index.jsonl.lock"),
+		filepath.Join(".logosyncx", "tasks", "p", "001-a", ".TASK-123.tmp"),
+	} {
+		ignored, err := gitutil.IsIgnored(dir, probe)
+		if err != nil || !ignored {
+			t.Errorf("%s ignored = %v, %v; want true", probe, ignored, err)
+		}
+	}
+	if ignored, _ := gitutil.IsIgnored(dir, filepath.Join(".logosyncx", "config.json")); ignored {
+		t.Error("config.json must not be ignored")
+	}
+}
+
 func TestInit_USAGEMDIncludesTasksSection(t *testing.T) {
 	dir := t.TempDir()
 	if err := runInitInDir(t, dir); err != nil {
@@ -427,3 +449,188 @@ func TestInit_ConfigRecordsAgentsFile(t *testing.T) {
 		t.Errorf("config.json should record agents_file as CLAUDE.md, got: %s", data)
 	}
 }
+
+// --- git integration ---------------------------------------------------------
+
+// gitInitDir runs `git init` in dir and configures a throwaway identity so
+// that commits succeed in CI environments without a global git config.
+func gitInitDir(t *testing.T, dir string) {
+	t.Helper()
+	for _, args := range [][]string{
+		{"init", "-q"},
+		{"config", "user.name", "logos-test"},
+		{"config", "user.email", "logos-test@example.com"},
+		{"config", "commit.gpgsign", "false"},
+	} {
+		cmd := exec.Command("git", args...)
+		cmd.Dir = dir
+		if out, err := cmd.CombinedOutput(); err != nil {
+			t.Fatalf("git %v: %v\n%s", args, err, out)
+		}
+	}
+}
+
+func TestInit_Commit_CreatesCommit(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+
+	orig, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+
+	if err := runInit(true); err != nil {
+		t.Fatalf("runInit(commit): %v", err)
+	}
+
+	cmd := exec.Command("git", "log", "--format=%s", "--name-only")
+	cmd.Dir = dir
+	out, err := cmd.CombinedOutput()
+	if err != nil {
+		t.Fatalf("git log: %v\n%s", err, out)
+	}
+	log := string(out)
+	if !strings.Contains(log, initCommitMessage) {
+		t.Errorf("expected commit %q, got:\n%s", initCommitMessage, log)
+	}
+	if !strings.Contains(log, ".logosyncx/config.json") {
+		t.Errorf("expected .logosyncx/config.json in commit, got:\n%s", log)
+	}
+	if !strings.Contains(log, "AGENTS.md") {
+		t.Errorf("expected AGENTS.md in commit, got:\n%s", log)
+	}
+}
+
+func TestInit_Commit_LeavesOtherStagedFiles(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	t.Chdir(dir)
+	if err := os.WriteFile(filepath.Join(dir, "wip.go"), []byte("package wip\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if out, err := exec.Command("git", "add", "wip.go").CombinedOutput(); err != nil {
+		t.Fatalf("git add: %v\n%s", err, out)
+	}
+
+	if err := runInit(true); err != nil {
+		t.Fatalf("runInit(commit): %v", err)
+	}
+
+	out, err := exec.Command("git", "show", "--name-only", "--format=", "HEAD").CombinedOutput()
+	if err != nil {
+		t.Fatalf("git show: %v\n%s", err, out)
+	}
+	if strings.Contains(string(out), "wip.go") {
+		t.Errorf("init commit swept in the user's staged file:\n%s", out)
+	}
+	staged, err := exec.Command("git", "diff", "--cached", "--name-only").CombinedOutput()
+	if err != nil {
+		t.Fatalf("git diff: %v\n%s", err, staged)
+	}
+	if strings.TrimSpace(string(staged)) != "wip.go" {
+		t.Errorf("staged after init = %q, want wip.go", staged)
+	}
+}
+
+func TestInit_WithoutCommit_LeavesRepoUncommitted(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	if err := runInitInDir(t, dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	cmd := exec.Command("git", "rev-parse", "HEAD")
+	cmd.Dir = dir
+	if err := cmd.Run(); err == nil {
+		t.Error("expected no commits without --commit")
+	}
+}
+
+func TestInit_Commit_OutsideRepo_DoesNotFail(t *testing.T) {
+	dir := t.TempDir()
+	orig, _ := os.Getwd()
+	if err := os.Chdir(dir); err != nil {
+		t.Fatalf("chdir: %v", err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+
+	if err := runInit(true); err != nil {
+		t.Fatalf("runInit(commit) outside a repo should not fail: %v", err)
+	}
+}
+
+func TestIsIgnored_DetectsLogosyncxRule(t *testing.T) {
+	dir := t.TempDir()
+	gitInitDir(t, dir)
+	if err := os.WriteFile(filepath.Join(dir, ".gitignore"), []byte(".logosyncx/\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runInitInDir(t, dir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	ignored, err := gitutil.IsIgnored(dir, ".logosyncx/config.json")
+	if err != nil {
+		t.Fatalf("IsIgnored: %v", err)
+	}
+	if !ignored {
+		t.Error("expected .logosyncx/config.json to be reported as ignored")
+	}
+}
+
+// --- runInitStorage ----------------------------------------------------------
+
+func TestInitStorage_WritesPointerAndInitialisesStorage(t *testing.T) {
+	base := t.TempDir()
+	code := filepath.Join(base, "code")
+	if err := os.MkdirAll(code, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	orig, _ := os.Getwd()
+	if err := os.Chdir(code); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = os.Chdir(orig) })
+
+	if err := runInitStorage("../context", false); err != nil {
+		t.Fatalf("runInitStorage: %v", err)
+	}
+
+	storage := filepath.Join(base, "context")
+	if _, err := os.Stat(filepath.Join(storage, ".logosyncx", "plans")); err != nil {
+		t.Errorf("expected storage to be initialised: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(code, ".logosyncx", "plans")); err == nil {
+		t.Error("code directory should only hold the pointer config")
+	}
+	data, err := os.ReadFile(filepath.Join(code, ".logosyncx", "config.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), `"storage": "../context"`) {
+		t.Errorf("pointer config missing storage path:\n%s", data)
+	}
+
+	if err := runSave("storage-topic", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatalf("runSave: %v", err)
+	}
+	entries, _ := os.ReadDir(filepath.Join(storage, ".logosyncx", "plans"))
+	found := false
+	for _, e := range entries {
+		if strings.Contains(e.Name(), "storage-topic") {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected the plan to be saved in the storage directory")
+	}
+}
+
+func TestInitStorage_AlreadyInitialized_Error(t *testing.T) {
+	dir := t.TempDir()
+	if err := runInitInDir(t, dir); err != nil {
+		t.Fatal(err)
+	}
+	if err := runInitStorage("../context", false); err == nil {
+		t.Error("expected error when .logosyncx/ already exists")
+	}
+}
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/schedule"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// watchJob is a job from watch.jobs, parsed and ready to run.
+type watchJob struct {
+	config.WatchJob
+	sched schedule.Schedule
+	args  []string
+}
+
+// loadWatchJobs parses watch.jobs. An invalid schedule, or a command that
+// is not a logos subcommand, is an error so that it surfaces when logos
+// watch starts rather than at the first scheduled run.
+func loadWatchJobs(cfg config.Config) ([]watchJob, error) {
+	var jobs []watchJob
+	for _, j := range cfg.Watch.Jobs {
+		sched, err := schedule.Parse(j.Schedule)
+		if err != nil {
+			return nil, fmt.Errorf("watch.jobs %q: %w", j.Name, err)
+		}
+		args := strings.Fields(j.Command)
+		if len(args) > 0 && args[0] == "logos" {
+			args = args[1:]
+		}
+		if len(args) == 0 {
+			return nil, fmt.Errorf("watch.jobs %q: command must not be empty", j.Name)
+		}
+		sub, _, err := rootCmd.Find(args)
+		if err != nil || sub == rootCmd {
+			return nil, fmt.Errorf("watch.jobs %q: %q is not a logos command", j.Name, args[0])
+		}
+		if sub.Parent() == rootCmd && (sub.Name() == "watch" || sub.Name() == "tui") {
+			return nil, fmt.Errorf("watch.jobs %q: logos %s cannot be scheduled", j.Name, sub.Name())
+		}
+		jobs = append(jobs, watchJob{WatchJob: j, sched: sched, args: args})
+	}
+	return jobs, nil
+}
+
+// execJob runs logos with args in a child process in root and returns its
+// combined output. Tests replace it.
+var execJob = func(root string, args []string) ([]byte, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, err
+	}
+	c := exec.Command(exe, args...)
+	c.Dir = root
+	c.Env = append(os.Environ(), "LOGOS_NO_UPDATE_CHECK=1")
+	return c.CombinedOutput()
+}
+
+// runJobsSince runs the jobs due in every minute after last through the
+// minute of now, oldest first, so that minutes no tick landed in (while a
+// job ran or the machine slept) are not skipped. It returns the last minute
+// handled, which is last when the clock has not moved past it.
+func runJobsSince(root string, jobs []watchJob, last, now time.Time) time.Time {
+	for minute := last.Add(time.Minute); !minute.After(now); minute = minute.Add(time.Minute) {
+		runDueJobs(root, jobs, minute)
+		last = minute
+	}
+	return last
+}
+
+// runDueJobs runs, one after another, every job scheduled for minute.
+func runDueJobs(root string, jobs []watchJob, minute time.Time) {
+	for _, j := range jobs {
+		if j.sched.Matches(minute) {
+			runWatchJob(root, j, minute)
+		}
+	}
+}
+
+// runWatchJob runs j and prints one line about it. The output goes to
+// j.Output when set, otherwise it is printed below that line. A failing
+// command is reported in the line and the report; it never stops watching.
+func runWatchJob(root string, j watchJob, now time.Time) {
+	out, err := execJob(root, j.args)
+	status := "ok"
+	var exitErr *exec.ExitError
+	switch {
+	case errors.As(err, &exitErr):
+		status = fmt.Sprintf("failed (exit status %d)", exitErr.ExitCode())
+	case err != nil:
+		status = "failed: " + err.Error()
+	}
+	stamp := time.Now().Format("15:04:05")
+
+	if j.Output == "" {
+		fmt.Printf("%s  job %s: logos %s — %s\n", stamp, j.Name, strings.Join(j.args, " "), status)
+		if len(out) > 0 {
+			fmt.Print(indentLines(string(out), "    "))
+		}
+		return
+	}
+	path := j.Output
+	if !filepath.IsAbs(path) {
+		path = filepath.Join(root, path)
+	}
+	report := fmt.Sprintf("# %s: logos %s at %s — %s\n\n%s", j.Name, strings.Join(j.args, " "), now.Format("2006-01-02 15:04 MST"), status, out)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		warnf("job %s: %v", j.Name, err)
+		return
+	}
+	if err := os.WriteFile(path, []byte(report), 0o644); err != nil {
+		warnf("job %s: %v", j.Name, err)
+		return
+	}
+	fmt.Printf("%s  job %s: logos %s — %s, report written to %s\n", stamp, j.Name, strings.Join(j.args, " "), status, j.Output)
+}
+
+// indentLines prefixes every line of s with indent and ends it with a
+// newline.
+func indentLines(s, indent string) string {
+	lines := strings.Split(strings.TrimRight(s, "\n"), "\n")
+	return indent + strings.Join(lines, "\n"+indent) + "\n"
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+func TestLoadWatchJobs(t *testing.T) {
+	cfg := config.Default("p")
+	cfg.Watch.Jobs = []config.WatchJob{
+		{Name: "check", Schedule: "@daily", Command: "logos sync --check"},
+		{Name: "gc", Schedule: "0 9 * * 1", Command: "gc --dry-run"},
+	}
+	jobs, err := loadWatchJobs(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(jobs) != 2 || strings.Join(jobs[0].args, " ") != "sync --check" {
+		t.Errorf("unexpected jobs %+v", jobs)
+	}
+
+	for _, job := range []config.WatchJob{
+		{Name: "bad-cron", Schedule: "every day", Command: "sync"},
+		{Name: "unknown", Schedule: "@daily", Command: "frobnicate"},
+		{Name: "recursive", Schedule: "@daily", Command: "watch"},
+		{Name: "empty", Schedule: "@daily", Command: "logos"},
+	} {
+		cfg.Watch.Jobs = []config.WatchJob{job}
+		if _, err := loadWatchJobs(cfg); err == nil || !strings.Contains(err.Error(), job.Name) {
+			t.Errorf("%s: expected an error naming the job, got %v", job.Name, err)
+		}
+	}
+}
+
+func TestRunDueJobs(t *testing.T) {
+	root := t.TempDir()
+	var ran []string
+	orig := execJob
+	execJob = func(dir string, args []string) ([]byte, error) {
+		ran = append(ran, strings.Join(args, " "))
+		if args[0] == "gc" {
+			return []byte("Would remove 2 plans\n"), nil
+		}
+		return []byte("index out of date\n"), errors.New("boom")
+	}
+	t.Cleanup(func() { execJob = orig })
+
+	cfg := config.Default("p")
+	cfg.Watch.Jobs = []config.WatchJob{
+		{Name: "nightly", Schedule: "0 3 * * *", Command: "sync --check"},
+		{Name: "weekly", Schedule: "0 3 * * 1", Command: "gc --dry-run", Output: "reports/gc.txt"},
+	}
+	jobs, err := loadWatchJobs(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	monday := time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC)
+	out := captureOutput(t, func() { runDueJobs(root, jobs, monday.Add(time.Minute)) })
+	if len(ran) != 0 || out != "" {
+		t.Fatalf("no job is due at 03:01, ran %v", ran)
+	}
+
+	out = captureOutput(t, func() { runDueJobs(root, jobs, monday) })
+	if strings.Join(ran, ",") != "sync --check,gc --dry-run" {
+		t.Errorf("ran %v", ran)
+	}
+	if !strings.Contains(out, "job nightly: logos sync --check — failed: boom") || !strings.Contains(out, "    index out of date") {
+		t.Errorf("unexpected watch output:\n%s", out)
+	}
+	report, err := os.ReadFile(filepath.Join(root, "reports", "gc.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(report), "# weekly: logos gc --dry-run at 2026-03-02 03:00 UTC — ok") || !strings.Contains(string(report), "Would remove 2 plans") {
+		t.Errorf("unexpected report:\n%s", report)
+	}
+}
+
+func TestRunJobsSince_CatchesUpSkippedMinutes(t *testing.T) {
+	var ran []string
+	orig := execJob
+	execJob = func(dir string, args []string) ([]byte, error) {
+		ran = append(ran, strings.Join(args, " "))
+		return nil, nil
+	}
+	t.Cleanup(func() { execJob = orig })
+
+	cfg := config.Default("p")
+	cfg.Watch.Jobs = []config.WatchJob{
+		{Name: "at-02", Schedule: "2 3 * * *", Command: "sync --check"},
+		{Name: "at-04", Schedule: "4 3 * * *", Command: "gc --dry-run"},
+		{Name: "at-09", Schedule: "9 3 * * *", Command: "status"},
+	}
+	jobs, err := loadWatchJobs(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The clock jumps from 03:00 to 03:05:30; the jobs due at 03:02 and
+	// 03:04 run in order, and 03:09 is not yet due.
+	start := time.Date(2026, 3, 2, 3, 0, 0, 0, time.UTC)
+	var last time.Time
+	captureOutput(t, func() {
+		last = runJobsSince(t.TempDir(), jobs, start, start.Add(5*time.Minute+30*time.Second))
+	})
+	if strings.Join(ran, ",") != "sync --check,gc --dry-run" {
+		t.Errorf("ran %v", ran)
+	}
+	if want := start.Add(5 * time.Minute); !last.Equal(want) {
+		t.Errorf("last = %v, want %v", last, want)
+	}
+
+	// The same minute again runs nothing.
+	ran = nil
+	captureOutput(t, func() { last = runJobsSince(t.TempDir(), jobs, last, last.Add(10*time.Second)) })
+	if len(ran) != 0 {
+		t.Errorf("ran %v within the handled minute", ran)
+	}
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var journalCmd = &cobra.Command{
+	Use:   "journal",
+	Args:  cobra.NoArgs,
+	Short: "Add today's entry to the weekly journal plan",
+	Long: `Create or extend the journal plan for the current ISO week.
+
+Each week has one plan file (e.g. 20250317-journal-2025-w12.md, dated on the
+Monday of the week). Every run adds a "## YYYY-MM-DD (Day)" subsection for
+today unless one already exists. The CLI writes the heading only — fill in
+the entry with the Write tool.
+
+Retrieve a week's journal with: logos refer --week 2025-W12`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agent, _ := cmd.Flags().GetString("agent")
+		return runJournal(agent, time.Now())
+	},
+}
+
+func init() {
+	journalCmd.Flags().StringP("agent", "a", "", "Agent name recorded when the week's journal is created")
+	rootCmd.AddCommand(journalCmd)
+}
+
+// journalHeading returns the subsection heading for the day of now.
+func journalHeading(now time.Time) string {
+	return "## " + now.Format("2006-01-02 (Mon)")
+}
+
+// runJournal is the testable core of the journal command. now decides both
+// the ISO week (and therefore the file) and the heading that is added.
+func runJournal(agent string, now time.Time) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	week := plan.WeekOf(now)
+	path := filepath.Join(plan.PlansDir(root), week.JournalFileName())
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	j, found := plan.FindJournal(plans, week)
+	if found {
+		path = filepath.Join(plan.PlansDir(root), j.Filename)
+	}
+	heading := journalHeading(now)
+
+	data, err := os.ReadFile(path)
+	switch {
+	case os.IsNotExist(err):
+		tags := config.MergeTags([]string{plan.JournalTag}, cfg.Plans.DefaultTags)
+		if path, err = createJournal(root, cfg, week, agent, heading, tags); err != nil {
+			return err
+		}
+	case err != nil:
+		return fmt.Errorf("read journal: %w", err)
+	case strings.Contains("\n"+string(data), "\n"+heading+"\n"):
+		rel, _ := relPath(root, path)
+		fmt.Printf("Journal %s already has an entry for today.\n", rel)
+		printHint(fmt.Sprintf("Next: continue today's entry under %q in %s", heading, rel))
+		return nil
+	default:
+		if found {
+			if err := refuseFrozen(j); err != nil {
+				return err
+			}
+		}
+		if err := appendJournalHeading(path, data, heading); err != nil {
+			return err
+		}
+	}
+
+	rel, _ := relPath(root, path)
+	printSuccess("Journal %s: added %s", rel, strings.TrimPrefix(heading, "## "))
+
+	if _, indexErr := index.RebuildWithOptions(root, planIndexOptions(cfg)); indexErr != nil {
+		warnf("could not rebuild index (%v) — run `logos sync` to rebuild", indexErr)
+	}
+
+	stageFiles(root, cfg, path, index.FilePath(root))
+
+	printHint(fmt.Sprintf("Next: write today's entry under %q in %s", heading, rel))
+	return nil
+}
+
+// createJournal writes a new journal plan for week whose body starts with
+// heading, named by plans.filename_pattern, and returns its path.
+func createJournal(root string, cfg config.Config, week plan.ISOWeek, agent, heading string, tags []string) (string, error) {
+	id, err := plan.GenerateID()
+	if err != nil {
+		return "", fmt.Errorf("generate id: %w", err)
+	}
+	start := week.Start()
+	p := plan.Plan{
+		ID:    id,
+		Date:  &start,
+		Topic: week.JournalTopic(),
+		Tags:  tags,
+		Agent: agent,
+		Body:  heading + "\n\n",
+	}
+	fields := append([]privacyField{{"topic", &p.Topic}, {"body", &p.Body}}, tagFields(p.Tags)...)
+	if err := enforcePrivacy(cfg, "plan", fields...); err != nil {
+		return "", err
+	}
+	p.Filename = plan.FileNameWithPattern(p, cfg.Plans.FilenamePattern)
+	p.TasksDir = plan.DefaultTasksDir(p.Filename)
+	path, err := plan.Write(root, p)
+	if err != nil {
+		return "", fmt.Errorf("write journal: %w", err)
+	}
+	return path, nil
+}
+
+// appendJournalHeading appends heading to the existing journal file at path.
+func appendJournalHeading(path string, data []byte, heading string) error {
+	var b strings.Builder
+	if len(data) > 0 && !strings.HasSuffix(string(data), "\n") {
+		b.WriteByte('\n')
+	}
+	b.WriteString("\n" + heading + "\n\n")
+
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		return fmt.Errorf("open journal: %w", err)
+	}
+	if _, err := f.WriteString(b.String()); err != nil {
+		f.Close()
+		return fmt.Errorf("append to journal: %w", err)
+	}
+	return f.Close()
+}
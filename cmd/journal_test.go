@@ -0,0 +1,192 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+func journalPath(dir string) string {
+	return filepath.Join(dir, ".logosyncx", "plans", "20250317-journal-2025-w12.md")
+}
+
+func TestJournal_CreatesWeeklyPlan(t *testing.T) {
+	dir := setupInitedProject(t)
+	tue := time.Date(2025, 3, 18, 9, 0, 0, 0, time.UTC)
+
+	if err := runJournal("claude-code", tue); err != nil {
+		t.Fatalf("runJournal: %v", err)
+	}
+
+	p, err := plan.LoadFile(journalPath(dir))
+	if err != nil {
+		t.Fatalf("load journal: %v", err)
+	}
+	if p.Topic != "journal 2025-W12" {
+		t.Errorf("Topic = %q, want %q", p.Topic, "journal 2025-W12")
+	}
+	if len(p.Tags) != 1 || p.Tags[0] != plan.JournalTag {
+		t.Errorf("Tags = %v, want [%s]", p.Tags, plan.JournalTag)
+	}
+	if !strings.Contains(p.Body, "## 2025-03-18 (Tue)") {
+		t.Errorf("expected today's heading in body, got:\n%s", p.Body)
+	}
+}
+
+func TestJournal_AppendsDatedSubsectionsToSameFile(t *testing.T) {
+	dir := setupInitedProject(t)
+	tue := time.Date(2025, 3, 18, 9, 0, 0, 0, time.UTC)
+	thu := time.Date(2025, 3, 20, 9, 0, 0, 0, time.UTC)
+
+	if err := runJournal("", tue); err != nil {
+		t.Fatalf("runJournal tue: %v", err)
+	}
+	// Simulate the agent writing the entry body.
+	f, err := os.OpenFile(journalPath(dir), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open journal: %v", err)
+	}
+	_, _ = f.WriteString("Worked on the parser.")
+	f.Close()
+
+	if err := runJournal("", thu); err != nil {
+		t.Fatalf("runJournal thu: %v", err)
+	}
+	// Same day again must not add a duplicate heading.
+	if err := runJournal("", thu); err != nil {
+		t.Fatalf("runJournal thu (again): %v", err)
+	}
+
+	data, err := os.ReadFile(journalPath(dir))
+	if err != nil {
+		t.Fatalf("read journal: %v", err)
+	}
+	body := string(data)
+	if !strings.Contains(body, "Worked on the parser.\n\n## 2025-03-20 (Thu)\n") {
+		t.Errorf("expected Thursday heading appended after existing entry, got:\n%s", body)
+	}
+	if n := strings.Count(body, "## 2025-03-20 (Thu)"); n != 1 {
+		t.Errorf("expected 1 Thursday heading, got %d:\n%s", n, body)
+	}
+
+	entries, err := os.ReadDir(filepath.Join(dir, ".logosyncx", "plans"))
+	if err != nil {
+		t.Fatalf("read plans dir: %v", err)
+	}
+	var files int
+	for _, e := range entries {
+		if !e.IsDir() {
+			files++
+		}
+	}
+	if files != 1 {
+		t.Errorf("expected 1 plan file for the week, got %d", files)
+	}
+}
+
+func TestJournal_FrozenJournal_ReturnsError(t *testing.T) {
+	dir := setupInitedProject(t)
+	tue := time.Date(2025, 3, 18, 9, 0, 0, 0, time.UTC)
+	if err := runJournal("", tue); err != nil {
+		t.Fatalf("runJournal: %v", err)
+	}
+	captureOutput(t, func() {
+		if err := runFreeze("20250317-journal-2025-w12", false, false, "", "alice", time.Now()); err != nil {
+			t.Fatalf("runFreeze: %v", err)
+		}
+	})
+	before, _ := os.ReadFile(journalPath(dir))
+
+	if err := runJournal("", tue.AddDate(0, 0, 2)); err == nil {
+		t.Error("expected an error appending to a frozen journal")
+	}
+	if after, _ := os.ReadFile(journalPath(dir)); string(after) != string(before) {
+		t.Errorf("frozen journal was changed:\n%s", after)
+	}
+}
+
+func TestReferWeek_PrintsJournal(t *testing.T) {
+	setupInitedProject(t)
+	if err := runJournal("", time.Date(2025, 3, 18, 9, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatalf("runJournal: %v", err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runReferWeek("2025-W12", false, false, false, false); err != nil {
+			t.Fatalf("runReferWeek: %v", err)
+		}
+	})
+	if !strings.Contains(out, "## 2025-03-18 (Tue)") {
+		t.Errorf("expected journal content in output, got:\n%s", out)
+	}
+}
+
+func TestReferWeek_Missing_ReturnsError(t *testing.T) {
+	setupInitedProject(t)
+
+	err := runReferWeek("2025-W12", false, false, false, false)
+	if err == nil {
+		t.Fatal("expected error for missing journal, got nil")
+	}
+	if !strings.Contains(err.Error(), "no journal found") {
+		t.Errorf("expected 'no journal found' in error, got: %v", err)
+	}
+}
+
+func TestReferWeek_InvalidWeek_ReturnsError(t *testing.T) {
+	setupInitedProject(t)
+
+	if err := runReferWeek("2025-12", false, false, false, false); err == nil {
+		t.Fatal("expected error for invalid week, got nil")
+	}
+}
+
+func TestJournal_CustomFilenamePattern(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Plans.FilenamePattern = "{{date}}_{{id}}_{{slug}}.md"
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	tue := time.Date(2025, 3, 18, 9, 0, 0, 0, time.UTC)
+	thu := time.Date(2025, 3, 20, 9, 0, 0, 0, time.UTC)
+
+	if err := runJournal("", tue); err != nil {
+		t.Fatalf("runJournal tue: %v", err)
+	}
+	if err := runJournal("", thu); err != nil {
+		t.Fatalf("runJournal thu: %v", err)
+	}
+
+	plans, err := plan.LoadAll(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 1 {
+		t.Fatalf("expected one journal for the week, got %d plans", len(plans))
+	}
+	j := plans[0]
+	if want := "20250317_" + j.ID + "_journal-2025-w12.md"; j.Filename != want {
+		t.Errorf("Filename = %q, want %q", j.Filename, want)
+	}
+	if !strings.Contains(j.Body, "## 2025-03-20 (Thu)") {
+		t.Errorf("expected Thursday heading in the same file, got:\n%s", j.Body)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runReferWeek("2025-W12", false, false, false, false); err != nil {
+			t.Fatalf("runReferWeek: %v", err)
+		}
+	})
+	if !strings.Contains(out, "2025-03-18 (Tue)") {
+		t.Errorf("refer --week should find the journal, got: %q", out)
+	}
+}
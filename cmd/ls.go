@@ -1,15 +1,20 @@
 package cmd
 
 import (
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"slices"
-	"text/tabwriter"
+	"strings"
 	"time"
 
+	"github.com/senna-lang/logosyncx/internal/ack"
+	"github.com/senna-lang/logosyncx/internal/dateparse"
+	"github.com/senna-lang/logosyncx/internal/jsonl"
 	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
 	"github.com/senna-lang/logosyncx/pkg/config"
 	"github.com/senna-lang/logosyncx/pkg/index"
 	"github.com/spf13/cobra"
@@ -21,112 +26,393 @@ var lsCmd = &cobra.Command{
 	Long: `Display a list of saved plans in .logosyncx/plans/.
 
 Without flags, prints a human-readable table sorted by date (newest first).
+Plans with the same date are ordered by ID, then filename, so table and
+--json output is stable across runs.
+Use --format wide to add each plan's excerpt, wrapped to the terminal width.
 Use --json to get structured output with excerpts, suitable for agent consumption.
-Use --blocked to show only plans blocked by an undistilled dependency.`,
+Use --blocked to show only plans blocked by an undistilled dependency.
+Use --has-open-tasks to show only plans that still have unfinished tasks.
+Use --agent to show only plans saved by one agent, and --show-agent to add
+an AGENT column. Use --category to show only plans of one category; a
+CATEGORY column is added whenever a listed plan has one.
+Use --unacked-by to show only plans a teammate has not acknowledged with
+logos ack ("me" = git user.name); overlay plans cannot be acknowledged and
+are left out.
+
+Plans from the read-only overlay roots in config "overlays" are listed too,
+with their topic prefixed by "[<overlay>]" (and "origin" set in --json).
+
+Use --branch <name> (or --current-branch) to show only plans saved on a git
+branch, plus plans not scoped to any branch (see git.record_branch).
+Use --project <name> to list the plans of another project recorded in the
+per-user registry (see logos projects) without changing directory.
+
+Task counts (open/total) are read from the task index.
+
+Use --limit and --offset to page through long listings (after filtering
+and sorting), and --fields to output only some fields, named by their
+--json keys (e.g. --fields date,topic,open_tasks); both apply to the table
+and to --json.
+
+Defaults for --json/--format, --show-agent, and --full can be set in the
+"output" section of config.json (output.ls, output.show_agent, output.full);
+flags given on the command line override them.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		tag, _ := cmd.Flags().GetString("tag")
-		since, _ := cmd.Flags().GetString("since")
-		asJSON, _ := cmd.Flags().GetBool("json")
-		blocked, _ := cmd.Flags().GetBool("blocked")
-		if asJSON {
+		var opts lsOptions
+		opts.tag, _ = cmd.Flags().GetString("tag")
+		opts.since, _ = cmd.Flags().GetString("since")
+		opts.asJSON, _ = cmd.Flags().GetBool("json")
+		opts.blocked, _ = cmd.Flags().GetBool("blocked")
+		opts.hasOpenTasks, _ = cmd.Flags().GetBool("has-open-tasks")
+		opts.format, _ = cmd.Flags().GetString("format")
+		opts.agent, _ = cmd.Flags().GetString("agent")
+		opts.showAgent, _ = cmd.Flags().GetBool("show-agent")
+		fullTables, _ = cmd.Flags().GetBool("full")
+		defaults := outputDefaults()
+		if !cmd.Flags().Changed("json") && !cmd.Flags().Changed("format") {
+			switch defaults.LS {
+			case "json":
+				opts.asJSON = true
+			case "table", "wide":
+				opts.format = defaults.LS
+			}
+		}
+		if !cmd.Flags().Changed("show-agent") {
+			opts.showAgent = defaults.ShowAgent
+		}
+		if !cmd.Flags().Changed("full") {
+			fullTables = defaults.Full
+		}
+		if opts.asJSON {
 			suppressUpdateCheck = true
 		}
-		return runLS(tag, since, asJSON, blocked)
+		opts.category, _ = cmd.Flags().GetString("category")
+		opts.unackedBy, _ = cmd.Flags().GetString("unacked-by")
+		opts.project, _ = cmd.Flags().GetString("project")
+		var err error
+		if opts.branch, err = branchFilter(cmd); err != nil {
+			return err
+		}
+		if opts.page, err = readPageFlags(cmd); err != nil {
+			return err
+		}
+		return runLS(opts)
 	},
 }
 
 func init() {
 	lsCmd.Flags().StringP("tag", "t", "", "Filter plans by tag")
-	lsCmd.Flags().StringP("since", "s", "", "Filter plans on or after this date (YYYY-MM-DD)")
+	lsCmd.Flags().StringP("since", "s", "", "Filter plans on or after this date (YYYY-MM-DD, yesterday, 3d, last monday, ...)")
 	lsCmd.Flags().Bool("json", false, "Output structured JSON (for agent consumption)")
 	lsCmd.Flags().Bool("blocked", false, "Show only plans blocked by an undistilled dependency")
+	lsCmd.Flags().Bool("has-open-tasks", false, "Show only plans with at least one task that is not done")
+	lsCmd.Flags().String("format", "table", "Table layout: table or wide (adds an excerpt column)")
+	lsCmd.Flags().String("agent", "", "Filter plans by the agent that saved them (case-insensitive)")
+	lsCmd.Flags().Bool("show-agent", false, "Add an AGENT column to the table")
+	lsCmd.Flags().String("category", "", "Filter plans by category (e.g. design, incident)")
+	lsCmd.Flags().String("unacked-by", "", `Show only plans this user has not acknowledged with logos ack ("me" = git user.name)`)
+	lsCmd.Flags().Bool("full", false, "Do not truncate columns to fit the terminal width")
+	branchFlags(lsCmd, "plans")
+	pageFlags(lsCmd, "plans")
+	lsCmd.Flags().String("project", "", "List the plans of this registered project instead (see logos projects ls)")
 	rootCmd.AddCommand(lsCmd)
 }
 
-func runLS(tag, since string, asJSON, blocked bool) error {
-	root, err := project.FindRoot()
+// lsOptions holds the filters and output settings of logos ls.
+type lsOptions struct {
+	project      string // registered project to list; empty = the working directory's
+	tag          string
+	since        string
+	asJSON       bool
+	blocked      bool
+	hasOpenTasks bool
+	format       string // "table" or "wide"
+	agent        string
+	showAgent    bool
+	category     string
+	unackedBy    string
+	branch       string
+	page         pageOptions
+}
+
+// runLS lists the plans of the registered project opts.project, or of the
+// project in the working directory when it is empty.
+func runLS(opts lsOptions) error {
+	if opts.format != "" && opts.format != "table" && opts.format != "wide" {
+		return fmt.Errorf("--format: %q must be table or wide", opts.format)
+	}
+	if err := checkFields(opts.page.fields, jsonFieldNames(lsJSONEntry{})); err != nil {
+		return err
+	}
+
+	var root string
+	var err error
+	if opts.project != "" {
+		root, err = registeredRoot(opts.project)
+	} else {
+		root, err = project.FindRoot()
+	}
 	if err != nil {
 		return err
 	}
 
-	entries, err := index.ReadAll(root)
+	cfg, cfgErr := loadConfig(root)
+	if cfgErr != nil {
+		warnf("could not load config (%v) — using defaults", cfgErr)
+		cfg = config.Default("")
+	}
+	loc := displayLocation(cfg)
+
+	counts := loadTaskCounts(root)
+	keep, err := lsFilter(root, opts, counts, loc)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			// Auto-rebuild: inform the user and build the index on the fly.
-			fmt.Fprintln(os.Stderr, "index.jsonl not found. Building index from plans/...")
-			cfg, cfgErr := config.Load(root)
-			if cfgErr != nil {
-				fmt.Fprintf(os.Stderr, "warning: could not load config (%v) — using defaults\n", cfgErr)
-				cfg = config.Default("")
-			}
-			n, buildErr := index.Rebuild(root, cfg.Plans.ExcerptSection)
-			if buildErr != nil {
-				fmt.Fprintf(os.Stderr, "warning: %v\n", buildErr)
-			}
-			fmt.Fprintf(os.Stderr, "Done. %d plans indexed.\n\n", n)
-			entries, err = index.ReadAll(root)
-			if err != nil {
-				return fmt.Errorf("read index after rebuild: %w", err)
-			}
-		} else {
-			return fmt.Errorf("read index: %w", err)
+		return err
+	}
+	// Overlay plans are shadowed by project plans of the same filename,
+	// including those the filters or the page leave out.
+	seen := map[string]bool{}
+	entries, total, err := readPlanIndexPage(root, cfg, func(e index.Entry) bool {
+		seen[e.Filename] = true
+		return keep(e)
+	}, compareNewest, opts.page.Head())
+	if err != nil {
+		return err
+	}
+	for _, e := range overlayEntries(root, cfg, seen, counts) {
+		if keep(e) {
+			entries = append(entries, e)
+			total++
 		}
 	}
+	sortByDateDesc(entries)
+	entries = pageWindow(entries, total, opts.page.Page, "plans")
 
-	// Apply --since filter.
-	if since != "" {
-		sinceTime, err := time.Parse("2006-01-02", since)
-		if err != nil {
-			return fmt.Errorf("invalid --since date %q: expected YYYY-MM-DD", since)
+	if len(entries) == 0 && !opts.asJSON {
+		fmt.Println("No plans found.")
+		return nil
+	}
+
+	if fields := opts.page.fields; len(fields) > 0 {
+		if opts.asJSON {
+			return printFieldsJSON(lsJSONEntries(entries, counts), fields)
 		}
-		entries = filterSince(entries, sinceTime)
+		return printFieldsTable(lsJSONEntries(entries, counts), fields, loc)
 	}
+	if opts.asJSON {
+		return printJSON(entries, counts)
+	}
+	if opts.format == "wide" {
+		return printWideTable(entries, counts, loc, opts.showAgent, terminalWidth())
+	}
+	return printTable(entries, counts, loc, opts.showAgent)
+}
+
+// readPlanIndex reads index.jsonl, building it from plans/ first when it is
+// missing, was only partly written, or was written by an older logos.
+// Malformed lines are skipped with a warning.
+func readPlanIndex(root string, cfg config.Config) ([]index.Entry, error) {
+	entries, _, err := readPlanIndexPage(root, cfg, nil, nil, jsonl.Page{})
+	return entries, err
+}
 
-	// Apply --tag filter.
-	if tag != "" {
-		entries = filterTag(entries, tag)
+// readPlanIndexPage is readPlanIndex for page p of the entries keep
+// accepts, in the order of cmp; total is the number of entries accepted
+// (see index.ReadPage). When the index is rebuilt, keep may see some
+// entries twice.
+func readPlanIndexPage(root string, cfg config.Config, keep func(index.Entry) bool, cmp func(a, b index.Entry) int, p jsonl.Page) (entries []index.Entry, total int, err error) {
+	err = checkIndexVersion(index.CheckVersion(root))
+	if err == nil {
+		entries, total, err = index.ReadPage(root, keep, cmp, p)
+		if err == nil {
+			return entries, total, nil
+		}
+	}
+	if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, jsonl.ErrPartial) && !errors.Is(err, jsonl.ErrOldVersion) {
+		if warnSkippedLines("index.jsonl", err) {
+			return entries, total, nil
+		}
+		return nil, 0, fmt.Errorf("read index: %w", err)
+	}
+	// Auto-rebuild: inform the user and build the index on the fly.
+	if !warningsJSON {
+		fmt.Fprintf(os.Stderr, "index.jsonl %s. Building index from plans/...\n", indexProblem(err))
 	}
+	n, buildErr := index.RebuildWithOptions(root, planIndexOptions(cfg))
+	if buildErr != nil {
+		warnf("%v", buildErr)
+	}
+	if warningsJSON {
+		warnf("index.jsonl %s — rebuilt from plans/ (%d plans indexed)", indexProblem(err), n)
+	} else {
+		fmt.Fprintf(os.Stderr, "Done. %d plans indexed.\n\n", n)
+	}
+	entries, total, err = index.ReadPage(root, keep, cmp, p)
+	if err != nil {
+		return nil, 0, fmt.Errorf("read index after rebuild: %w", err)
+	}
+	return entries, total, nil
+}
 
-	// Apply --blocked filter.
-	if blocked {
-		entries = filterBlocked(entries)
+// checkIndexVersion filters the result of an index version check: an index
+// written by a newer logos is still readable, so it only earns a warning,
+// and other errors are left for the read that follows to report. Only
+// jsonl.ErrOldVersion, which calls for a rebuild, is returned.
+func checkIndexVersion(err error) error {
+	if errors.Is(err, jsonl.ErrNewVersion) {
+		warnf("%v; fields added since are ignored — upgrade logos", err)
+	}
+	if errors.Is(err, jsonl.ErrOldVersion) {
+		return err
 	}
+	return nil
+}
 
-	// Sort newest first.
-	sortByDateDesc(entries)
+// taskCount holds the number of tasks linked to a plan.
+type taskCount struct {
+	Open  int // tasks whose status is not done
+	Total int
+}
 
-	if len(entries) == 0 {
-		fmt.Println("No plans found.")
-		return nil
+// loadTaskCounts returns task counts keyed by plan slug, computed from the
+// task index. A missing index yields empty counts; other read errors are
+// reported as a warning so the plan listing still works.
+func loadTaskCounts(root string) map[string]taskCount {
+	counts := map[string]taskCount{}
+	tasks, err := task.ReadAllTaskIndex(root)
+	if err != nil && !errors.Is(err, os.ErrNotExist) && !warnSkippedLines("task-index.jsonl", err) {
+		warnf("%v", err)
+	}
+	for _, t := range tasks {
+		c := counts[t.Plan]
+		c.Total++
+		if t.Status != task.StatusDone {
+			c.Open++
+		}
+		counts[t.Plan] = c
+	}
+	return counts
+}
+
+// entryPlanSlug returns the task-directory slug for a plan index entry,
+// prefixed with "<origin>/" for overlay plans so that their task counts do
+// not mix with the project's.
+func entryPlanSlug(e index.Entry) string {
+	slug := strings.TrimSuffix(e.Filename, ".md")
+	if e.Origin != "" {
+		return e.Origin + "/" + slug
 	}
+	return slug
+}
 
-	if asJSON {
-		return printJSON(entries)
+// printTable writes a human-readable aligned table to stdout, with dates
+// rendered in loc. Long topics and tag lists are ellipsized to fit the
+// terminal unless --full is set. showAgent adds an AGENT column; a CATEGORY
+// column is added when any entry has a category.
+func printTable(entries []index.Entry, counts map[string]taskCount, loc *time.Location, showAgent bool) error {
+	cols := planColumns{agent: showAgent, category: anyCategory(entries)}
+	t := &textTable{
+		headers:  planHeaders(cols),
+		fitWidth: tableWidth(),
+		shrink:   []int{1, 2},
+	}
+	for _, e := range entries {
+		t.addRow(planRow(e, counts, loc, cols)...)
 	}
-	return printTable(entries)
+	return t.render(os.Stdout)
 }
 
-// printTable writes a human-readable tab-aligned table to stdout.
-func printTable(entries []index.Entry) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "DATE\tTOPIC\tTAGS\tDISTILLED")
-	fmt.Fprintln(w, "----\t-----\t----\t---------")
+// wideTopicWidth caps the TOPIC column in the wide layout, leaving room for
+// the excerpt.
+const wideTopicWidth = 40
+
+// printWideTable is printTable plus an EXCERPT column, word-wrapped so that
+// each line fits in width terminal columns. Long topics are ellipsized
+// unless --full is set.
+func printWideTable(entries []index.Entry, counts map[string]taskCount, loc *time.Location, showAgent bool, width int) error {
+	cols := planColumns{agent: showAgent, category: anyCategory(entries)}
+	t := &textTable{
+		headers:  append(planHeaders(cols), "EXCERPT"),
+		wrapLast: width,
+	}
+	if !fullTables {
+		t.maxWidths = []int{0, wideTopicWidth}
+	}
 	for _, e := range entries {
-		date := e.Date.Format("2006-01-02 15:04")
-		tags := joinTags(e.Tags)
-		distilled := "no"
-		if e.Distilled {
-			distilled = "yes"
-		}
-		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", date, e.Topic, tags, distilled)
+		t.addRow(append(planRow(e, counts, loc, cols), e.Excerpt)...)
 	}
-	return w.Flush()
+	return t.render(os.Stdout)
+}
+
+// planColumns selects the optional columns of the plan table.
+type planColumns struct {
+	agent    bool
+	category bool
+}
+
+// anyCategory reports whether any entry has a category.
+func anyCategory(entries []index.Entry) bool {
+	return slices.ContainsFunc(entries, func(e index.Entry) bool { return e.Category != "" })
+}
+
+// planHeaders returns the column headings of the plan table.
+func planHeaders(cols planColumns) []string {
+	h := []string{"DATE", "TOPIC", "TAGS", "TASKS", "DISTILLED"}
+	if cols.category {
+		h = append(h, "CATEGORY")
+	}
+	if cols.agent {
+		h = append(h, "AGENT")
+	}
+	return h
+}
+
+// planRow returns the cells of the plan table for e, matching planHeaders.
+func planRow(e index.Entry, counts map[string]taskCount, loc *time.Location, cols planColumns) []string {
+	distilled := "no"
+	if e.Distilled {
+		distilled = "yes"
+	}
+	c := counts[entryPlanSlug(e)]
+	topic := e.Topic
+	if e.Origin != "" {
+		topic = "[" + e.Origin + "] " + topic
+	}
+	row := []string{
+		e.Date.In(loc).Format("2006-01-02 15:04"),
+		topic,
+		joinTags(e.Tags),
+		fmt.Sprintf("%d/%d", c.Open, c.Total),
+		distilled,
+	}
+	if cols.category {
+		row = append(row, dashIfEmpty(e.Category))
+	}
+	if cols.agent {
+		row = append(row, dashIfEmpty(e.Agent))
+	}
+	return row
+}
+
+// lsJSONEntry is a plan index entry augmented with task counts for
+// logos ls --json.
+type lsJSONEntry struct {
+	index.Entry
+	OpenTasks  int `json:"open_tasks"`
+	TotalTasks int `json:"total_tasks"`
 }
 
 // printJSON writes the entries as a JSON array to stdout.
-func printJSON(entries []index.Entry) error {
+func printJSON(entries []index.Entry, counts map[string]taskCount) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(lsJSONEntries(entries, counts))
+}
+
+// lsJSONEntries returns entries with their task counts, as logos ls --json
+// prints them.
+func lsJSONEntries(entries []index.Entry, counts map[string]taskCount) []lsJSONEntry {
 	// Normalise nil slices so JSON output always uses [] rather than null.
-	out := make([]index.Entry, len(entries))
+	out := make([]lsJSONEntry, len(entries))
 	for i, e := range entries {
 		if e.Tags == nil {
 			e.Tags = []string{}
@@ -134,55 +420,147 @@ func printJSON(entries []index.Entry) error {
 		if e.Related == nil {
 			e.Related = []string{}
 		}
-		out[i] = e
+		c := counts[entryPlanSlug(e)]
+		out[i] = lsJSONEntry{Entry: e, OpenTasks: c.Open, TotalTasks: c.Total}
 	}
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(out)
+	return out
 }
 
 // --- filters -----------------------------------------------------------------
 
-func filterSince(entries []index.Entry, since time.Time) []index.Entry {
-	// Truncate to date only for comparison.
-	sinceDate := since.Truncate(24 * time.Hour)
+// Each filter is a predicate, which lsFilter applies while the index is
+// read. Filters other commands apply to lists already in memory also have a
+// slice form.
+
+// keepEntries returns the entries keep accepts.
+func keepEntries(entries []index.Entry, keep func(index.Entry) bool) []index.Entry {
 	var out []index.Entry
 	for _, e := range entries {
-		sessionDate := e.Date.UTC().Truncate(24 * time.Hour)
-		if !sessionDate.Before(sinceDate) {
+		if keep(e) {
 			out = append(out, e)
 		}
 	}
 	return out
 }
 
-func filterBlocked(entries []index.Entry) []index.Entry {
-	var out []index.Entry
-	for _, e := range entries {
-		if e.Blocked {
-			out = append(out, e)
+// savedSince accepts entries dated at or after since. Dates are compared as
+// instants, so plans saved in different time zones order correctly.
+func savedSince(since time.Time) func(index.Entry) bool {
+	return func(e index.Entry) bool { return !e.Date.Before(since) }
+}
+
+func isBlocked(e index.Entry) bool {
+	return e.Blocked
+}
+
+func withOpenTasks(counts map[string]taskCount) func(index.Entry) bool {
+	return func(e index.Entry) bool { return counts[entryPlanSlug(e)].Open > 0 }
+}
+
+// savedBy accepts entries saved by agent, compared case-insensitively.
+func savedBy(agent string) func(index.Entry) bool {
+	return func(e index.Entry) bool { return strings.EqualFold(e.Agent, agent) }
+}
+
+func filterAgent(entries []index.Entry, agent string) []index.Entry {
+	return keepEntries(entries, savedBy(agent))
+}
+
+func inCategory(category string) func(index.Entry) bool {
+	return func(e index.Entry) bool { return e.Category == category }
+}
+
+func filterCategory(entries []index.Entry, category string) []index.Entry {
+	return keepEntries(entries, inCategory(category))
+}
+
+// notAckedBy accepts the project plans user has not acknowledged. Overlay
+// plans are refused: they cannot be acknowledged.
+func notAckedBy(root, user string) func(index.Entry) bool {
+	return func(e index.Entry) bool {
+		if e.Origin != "" {
+			return false
 		}
+		acks, err := ack.Load(root, e.Filename)
+		if err != nil {
+			warnf("%s: %v", ack.Path(root, e.Filename), err)
+		}
+		_, ok := ack.Find(acks, user)
+		return !ok
 	}
-	return out
+}
+
+func taggedWith(tag string) func(index.Entry) bool {
+	return func(e index.Entry) bool { return slices.Contains(e.Tags, tag) }
 }
 
 func filterTag(entries []index.Entry, tag string) []index.Entry {
-	var out []index.Entry
-	for _, e := range entries {
-		if slices.Contains(e.Tags, tag) {
-			out = append(out, e)
+	return keepEntries(entries, taggedWith(tag))
+}
+
+// lsFilter returns a predicate accepting the entries that pass every
+// filter in opts, checked in the order of the flags in logos ls --help.
+func lsFilter(root string, opts lsOptions, counts map[string]taskCount, loc *time.Location) (func(index.Entry) bool, error) {
+	var keep []func(index.Entry) bool
+	if opts.since != "" {
+		since, err := dateparse.Past(opts.since, time.Now().In(loc))
+		if err != nil {
+			return nil, fmt.Errorf("--since: %w", err)
 		}
+		keep = append(keep, savedSince(since))
 	}
-	return out
+	if opts.branch != "" {
+		keep = append(keep, onBranch(opts.branch))
+	}
+	if opts.tag != "" {
+		keep = append(keep, taggedWith(opts.tag))
+	}
+	if opts.agent != "" {
+		keep = append(keep, savedBy(opts.agent))
+	}
+	if opts.category != "" {
+		keep = append(keep, inCategory(opts.category))
+	}
+	if opts.unackedBy != "" {
+		user, err := ackUser(root, opts.unackedBy)
+		if err != nil {
+			return nil, fmt.Errorf("--unacked-by: %w", err)
+		}
+		keep = append(keep, notAckedBy(root, user))
+	}
+	if opts.blocked {
+		keep = append(keep, isBlocked)
+	}
+	if opts.hasOpenTasks {
+		keep = append(keep, withOpenTasks(counts))
+	}
+	return func(e index.Entry) bool {
+		for _, k := range keep {
+			if !k(e) {
+				return false
+			}
+		}
+		return true
+	}, nil
 }
 
 // --- sort --------------------------------------------------------------------
 
-// sortByDateDesc sorts entries newest-first (in-place).
+// sortByDateDesc sorts entries newest-first (in-place). Plans saved in the
+// same second are ordered by ID, then by filename (then by overlay origin),
+// so that output is deterministic.
 func sortByDateDesc(entries []index.Entry) {
-	slices.SortFunc(entries, func(a, b index.Entry) int {
-		return b.Date.Compare(a.Date)
-	})
+	slices.SortFunc(entries, compareNewest)
+}
+
+// compareNewest orders entries as sortByDateDesc does.
+func compareNewest(a, b index.Entry) int {
+	return cmp.Or(
+		b.Date.Compare(a.Date),
+		cmp.Compare(a.ID, b.ID),
+		cmp.Compare(a.Filename, b.Filename),
+		cmp.Compare(a.Origin, b.Origin),
+	)
 }
 
 // --- helpers -----------------------------------------------------------------
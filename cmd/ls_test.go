@@ -9,6 +9,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
 	"github.com/senna-lang/logosyncx/pkg/index"
 	"github.com/senna-lang/logosyncx/pkg/plan"
 )
@@ -87,7 +89,7 @@ func TestLS_NoSessions_PrintsMessage(t *testing.T) {
 	setupInitedProject(t)
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", false, false); err != nil {
+		if err := runLS(lsOptions{}); err != nil {
 			t.Fatalf("runLS failed: %v", err)
 		}
 	})
@@ -101,15 +103,13 @@ func TestLS_NoSessions_JSON_PrintsEmptyArray(t *testing.T) {
 	setupInitedProject(t)
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", true, false); err != nil {
+		if err := runLS(lsOptions{asJSON: true}); err != nil {
 			t.Fatalf("runLS --json failed: %v", err)
 		}
 	})
 
-	// Should still output valid JSON (empty array handled by "No plans found").
-	// Actually runLS returns early with "No plans found." before printing JSON.
-	if !strings.Contains(out, "No plans found") {
-		t.Errorf("expected 'No plans found', got: %q", out)
+	if strings.TrimSpace(out) != "[]" {
+		t.Errorf("expected an empty JSON array, got: %q", out)
 	}
 }
 
@@ -121,7 +121,7 @@ func TestLS_NotInitialized_ReturnsError(t *testing.T) {
 	_ = os.Chdir(dir)
 	t.Cleanup(func() { _ = os.Chdir(orig) })
 
-	err := runLS("", "", false, false)
+	err := runLS(lsOptions{})
 	if err == nil {
 		t.Fatal("expected error when project not initialized, got nil")
 	}
@@ -139,7 +139,7 @@ func TestLS_Table_ContainsHeaders(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", false, false); err != nil {
+		if err := runLS(lsOptions{}); err != nil {
 			t.Fatalf("runLS failed: %v", err)
 		}
 	})
@@ -162,7 +162,7 @@ func TestLS_Table_ContainsSessionData(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", false, false); err != nil {
+		if err := runLS(lsOptions{}); err != nil {
 			t.Fatalf("runLS failed: %v", err)
 		}
 	})
@@ -187,7 +187,7 @@ func TestLS_Table_MultipleSessions(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", false, false); err != nil {
+		if err := runLS(lsOptions{}); err != nil {
 			t.Fatalf("runLS failed: %v", err)
 		}
 	})
@@ -210,7 +210,7 @@ func TestLS_Table_NoTagsShowsDash(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", false, false); err != nil {
+		if err := runLS(lsOptions{}); err != nil {
 			t.Fatalf("runLS failed: %v", err)
 		}
 	})
@@ -229,7 +229,7 @@ func TestLS_JSON_ValidJSON(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", true, false); err != nil {
+		if err := runLS(lsOptions{asJSON: true}); err != nil {
 			t.Fatalf("runLS --json failed: %v", err)
 		}
 	})
@@ -247,7 +247,7 @@ func TestLS_JSON_ContainsRequiredFields(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", true, false); err != nil {
+		if err := runLS(lsOptions{asJSON: true}); err != nil {
 			t.Fatalf("runLS --json failed: %v", err)
 		}
 	})
@@ -282,7 +282,7 @@ func TestLS_JSON_TagsNeverNull(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{p})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", true, false); err != nil {
+		if err := runLS(lsOptions{asJSON: true}); err != nil {
 			t.Fatalf("runLS --json failed: %v", err)
 		}
 	})
@@ -303,7 +303,7 @@ func TestLS_JSON_RelatedNeverNull(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{p})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", true, false); err != nil {
+		if err := runLS(lsOptions{asJSON: true}); err != nil {
 			t.Fatalf("runLS --json failed: %v", err)
 		}
 	})
@@ -328,7 +328,7 @@ func TestLS_FilterTag_MatchesSessions(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("auth", "", false, false); err != nil {
+		if err := runLS(lsOptions{tag: "auth"}); err != nil {
 			t.Fatalf("runLS --tag auth failed: %v", err)
 		}
 	})
@@ -351,7 +351,7 @@ func TestLS_FilterTag_NoMatchShowsNoSessions(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("nonexistenttag", "", false, false); err != nil {
+		if err := runLS(lsOptions{tag: "nonexistenttag"}); err != nil {
 			t.Fatalf("runLS failed: %v", err)
 		}
 	})
@@ -369,7 +369,7 @@ func TestLS_FilterTag_ExactMatch(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("auth", "", false, false); err != nil {
+		if err := runLS(lsOptions{tag: "auth"}); err != nil {
 			t.Fatalf("runLS failed: %v", err)
 		}
 	})
@@ -393,7 +393,7 @@ func TestLS_FilterSince_IncludesOnAndAfter(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "2025-02-01", false, false); err != nil {
+		if err := runLS(lsOptions{since: "2025-02-01"}); err != nil {
 			t.Fatalf("runLS --since failed: %v", err)
 		}
 	})
@@ -409,10 +409,17 @@ func TestLS_FilterSince_IncludesOnAndAfter(t *testing.T) {
 	}
 }
 
+func TestLS_FilterSince_RelativeDate(t *testing.T) {
+	setupInitedProject(t)
+	if err := runLS(lsOptions{since: "2w ago"}); err != nil {
+		t.Fatalf("runLS --since \"2w ago\": %v", err)
+	}
+}
+
 func TestLS_FilterSince_InvalidDate_ReturnsError(t *testing.T) {
 	setupInitedProject(t)
 
-	err := runLS("", "not-a-date", false, false)
+	err := runLS(lsOptions{since: "not-a-date"})
 	if err == nil {
 		t.Fatal("expected error for invalid --since date, got nil")
 	}
@@ -431,7 +438,7 @@ func TestLS_SortedNewestFirst(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", false, false); err != nil {
+		if err := runLS(lsOptions{}); err != nil {
 			t.Fatalf("runLS failed: %v", err)
 		}
 	})
@@ -489,6 +496,29 @@ func TestSortByDateDesc_SingleElement(t *testing.T) {
 	}
 }
 
+func TestSortByDateDesc_TiesByIDThenFilename(t *testing.T) {
+	same := time.Date(2025, 3, 1, 9, 0, 0, 0, time.UTC)
+	entries := []index.Entry{
+		{Topic: "no-id-b", Filename: "b.md", Date: same},
+		{Topic: "id-2", ID: "p-2", Filename: "a.md", Date: same},
+		{Topic: "newest", ID: "p-9", Filename: "z.md", Date: same.Add(time.Second)},
+		{Topic: "no-id-a", Filename: "a.md", Date: same},
+		{Topic: "id-1", ID: "p-1", Filename: "c.md", Date: same},
+	}
+	want := []string{"newest", "no-id-a", "no-id-b", "id-1", "id-2"}
+	// Every input order yields the same result.
+	for range 5 {
+		sortByDateDesc(entries)
+		for i, w := range want {
+			if entries[i].Topic != w {
+				t.Fatalf("position %d: got %q, want %q", i, entries[i].Topic, w)
+			}
+		}
+		entries[0], entries[3] = entries[3], entries[0]
+		entries[1], entries[4] = entries[4], entries[1]
+	}
+}
+
 // --- joinTags ----------------------------------------------------------------
 
 func TestJoinTags_Empty(t *testing.T) {
@@ -543,16 +573,16 @@ func TestFilterTag_NoMatch(t *testing.T) {
 	}
 }
 
-// --- filterSince -------------------------------------------------------------
+// --- savedSince --------------------------------------------------------------
 
-func TestFilterSince_IncludesBoundary(t *testing.T) {
+func TestSavedSince_IncludesBoundary(t *testing.T) {
 	boundary := time.Date(2025, 2, 1, 0, 0, 0, 0, time.UTC)
 	entries := []index.Entry{
 		{Topic: "on", Date: boundary},
 		{Topic: "after", Date: boundary.Add(24 * time.Hour)},
 		{Topic: "before", Date: boundary.Add(-24 * time.Hour)},
 	}
-	got := filterSince(entries, boundary)
+	got := keepEntries(entries, savedSince(boundary))
 	if len(got) != 2 {
 		t.Fatalf("expected 2 sessions (on + after), got %d", len(got))
 	}
@@ -573,7 +603,7 @@ func TestLS_TagAndSinceCombined(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("auth", "2025-02-01", false, false); err != nil {
+		if err := runLS(lsOptions{tag: "auth", since: "2025-02-01"}); err != nil {
 			t.Fatalf("runLS failed: %v", err)
 		}
 	})
@@ -607,7 +637,7 @@ func TestLS_FindsSessionsFromSubdirectory(t *testing.T) {
 	t.Cleanup(func() { _ = os.Chdir(orig) })
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", false, false); err != nil {
+		if err := runLS(lsOptions{}); err != nil {
 			t.Fatalf("runLS from subdir failed: %v", err)
 		}
 	})
@@ -654,12 +684,12 @@ func TestLS_Blocked_Filter(t *testing.T) {
 	_ = os.WriteFile(plansDir+"/"+plan.FileName(aWithDep), aData, 0o644)
 
 	// Rebuild index.
-	if err := runSync(); err != nil {
+	if err := runSync("", false, false, false); err != nil {
 		t.Fatalf("runSync: %v", err)
 	}
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", false, true); err != nil {
+		if err := runLS(lsOptions{blocked: true}); err != nil {
 			t.Fatalf("runLS --blocked failed: %v", err)
 		}
 	})
@@ -676,7 +706,7 @@ func TestLS_JSON_IncludesBlockedField(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", true, false); err != nil {
+		if err := runLS(lsOptions{asJSON: true}); err != nil {
 			t.Fatalf("runLS --json failed: %v", err)
 		}
 	})
@@ -700,7 +730,7 @@ func TestLS_JSON_IncludesDistilledField(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", true, false); err != nil {
+		if err := runLS(lsOptions{asJSON: true}); err != nil {
 			t.Fatalf("runLS --json failed: %v", err)
 		}
 	})
@@ -724,7 +754,7 @@ func TestLS_Table_ContainsDISTILLEDHeader(t *testing.T) {
 	})
 
 	out := captureOutput(t, func() {
-		if err := runLS("", "", false, false); err != nil {
+		if err := runLS(lsOptions{}); err != nil {
 			t.Fatalf("runLS failed: %v", err)
 		}
 	})
@@ -733,3 +763,271 @@ func TestLS_Table_ContainsDISTILLEDHeader(t *testing.T) {
 		t.Errorf("expected DISTILLED header in table, got: %q", out)
 	}
 }
+
+// --- task counts -------------------------------------------------------------
+
+// setupPlansWithTasks creates two plans: "busy" with one open and one done
+// task, and "idle" whose only task is done.
+func setupPlansWithTasks(t *testing.T) string {
+	t.Helper()
+	date := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	dir := setupProjectWithPlans(t, []plan.Plan{
+		makeTestPlan("busy", nil, date),
+		makeTestPlan("idle", nil, date),
+	})
+	for _, tc := range []struct{ plan, title string }{
+		{"20260301-busy", "Busy open"},
+		{"20260301-busy", "Busy done"},
+		{"20260301-idle", "Idle done"},
+	} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: tc.plan, title: tc.title, priority: "medium"}); err != nil {
+			t.Fatalf("create %q: %v", tc.title, err)
+		}
+	}
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	store := task.NewStore(dir, &cfg)
+	for _, name := range []string{"busy-done", "idle-done"} {
+		tk, err := store.Get("", name)
+		if err != nil {
+			t.Fatalf("Get %s: %v", name, err)
+		}
+		wt := filepath.Join(tk.DirPath, "WALKTHROUGH.md")
+		if err := os.WriteFile(wt, []byte("Done.\n"), 0o644); err != nil {
+			t.Fatalf("write walkthrough: %v", err)
+		}
+		if err := store.UpdateFields("", name, map[string]string{"status": "done"}); err != nil {
+			t.Fatalf("mark %s done: %v", name, err)
+		}
+	}
+	helperRebuildIndex(t, dir)
+	return dir
+}
+
+func TestLS_Table_ShowsTaskCounts(t *testing.T) {
+	setupPlansWithTasks(t)
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{}); err != nil {
+			t.Fatalf("runLS failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "TASKS") {
+		t.Errorf("expected TASKS header in table, got: %q", out)
+	}
+	for _, line := range strings.Split(out, "\n") {
+		if strings.Contains(line, "busy") && !strings.Contains(line, "1/2") {
+			t.Errorf("expected 1/2 for busy plan, got line: %q", line)
+		}
+		if strings.Contains(line, "idle") && !strings.Contains(line, "0/1") {
+			t.Errorf("expected 0/1 for idle plan, got line: %q", line)
+		}
+	}
+}
+
+func TestLS_JSON_IncludesTaskCounts(t *testing.T) {
+	setupPlansWithTasks(t)
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{asJSON: true}); err != nil {
+			t.Fatalf("runLS --json failed: %v", err)
+		}
+	})
+
+	var result []struct {
+		Topic      string `json:"topic"`
+		OpenTasks  int    `json:"open_tasks"`
+		TotalTasks int    `json:"total_tasks"`
+	}
+	if err := json.Unmarshal([]byte(out), &result); err != nil {
+		t.Fatalf("invalid JSON: %v\noutput: %q", err, out)
+	}
+	got := map[string][2]int{}
+	for _, r := range result {
+		got[r.Topic] = [2]int{r.OpenTasks, r.TotalTasks}
+	}
+	if got["busy"] != [2]int{1, 2} {
+		t.Errorf("busy counts = %v, want [1 2]", got["busy"])
+	}
+	if got["idle"] != [2]int{0, 1} {
+		t.Errorf("idle counts = %v, want [0 1]", got["idle"])
+	}
+}
+
+func TestLS_HasOpenTasks_FiltersPlans(t *testing.T) {
+	setupPlansWithTasks(t)
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{hasOpenTasks: true}); err != nil {
+			t.Fatalf("runLS --has-open-tasks failed: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "busy") {
+		t.Errorf("expected busy plan in output, got: %q", out)
+	}
+	if strings.Contains(out, "idle") {
+		t.Errorf("unexpected idle plan in output, got: %q", out)
+	}
+}
+
+func TestSavedSince_ComparesInstantsAcrossZones(t *testing.T) {
+	tokyo := time.FixedZone("JST", 9*60*60)
+	// 08:00 in Tokyo on Jan 2 is still Jan 1 in UTC.
+	entries := []index.Entry{{Topic: "tokyo-morning", Date: time.Date(2026, 1, 2, 8, 0, 0, 0, tokyo)}}
+
+	if got := keepEntries(entries, savedSince(time.Date(2026, 1, 2, 0, 0, 0, 0, tokyo))); len(got) != 1 {
+		t.Errorf("since Jan 2 in Tokyo: expected entry, got %d", len(got))
+	}
+	if got := keepEntries(entries, savedSince(time.Date(2026, 1, 2, 0, 0, 0, 0, time.UTC))); len(got) != 0 {
+		t.Errorf("since Jan 2 in UTC: expected no entry, got %d", len(got))
+	}
+}
+
+func TestPrintTable_UsesDisplayLocation(t *testing.T) {
+	entries := []index.Entry{{Topic: "x", Date: time.Date(2026, 1, 1, 23, 30, 0, 0, time.UTC)}}
+	out := captureOutput(t, func() {
+		if err := printTable(entries, nil, time.FixedZone("JST", 9*60*60), false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "2026-01-02 08:30") {
+		t.Errorf("expected date rendered in display zone, got: %q", out)
+	}
+}
+
+func TestPrintTable_AlignsCJKTopics(t *testing.T) {
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []index.Entry{
+		{Topic: "認証の設計", Date: date, Tags: []string{"a"}},
+		{Topic: "auth-design", Date: date, Tags: []string{"b"}},
+	}
+	out := captureOutput(t, func() {
+		if err := printTable(entries, nil, time.UTC, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) != 4 {
+		t.Fatalf("expected 4 lines, got %q", out)
+	}
+	col := func(line, cell string) int { return displayWidth(line[:strings.Index(line, cell)]) }
+	if a, b := col(lines[2], " a "), col(lines[3], " b "); a != b {
+		t.Errorf("TAGS column misaligned (%d vs %d):\n%s", a, b, out)
+	}
+}
+
+func TestPrintWideTable_WrapsExcerpt(t *testing.T) {
+	entries := []index.Entry{{
+		Topic:   strings.Repeat("長い題名", 20),
+		Date:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		Excerpt: strings.Repeat("word ", 40),
+	}}
+	out := captureOutput(t, func() {
+		if err := printWideTable(entries, nil, time.UTC, false, 120); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "EXCERPT") {
+		t.Errorf("expected EXCERPT header, got: %q", out)
+	}
+	if !strings.Contains(out, "…") {
+		t.Errorf("expected long topic to be ellipsized, got: %q", out)
+	}
+	lines := strings.Split(strings.TrimRight(out, "\n"), "\n")
+	if len(lines) < 4 {
+		t.Errorf("expected excerpt to wrap onto several lines, got: %q", out)
+	}
+	for _, l := range lines {
+		if w := displayWidth(l); w > 120 {
+			t.Errorf("line is %d columns wide, want <= 120: %q", w, l)
+		}
+	}
+}
+
+func TestRunLS_FormatWide(t *testing.T) {
+	dir := setupInitedProject(t)
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	writePlanFileWithBody(t, dir, plan.Plan{
+		ID: "w1", Topic: "wide", Date: &date,
+		Body: "## Background\nWhy we needed a wide format.\n",
+	})
+	t.Setenv("COLUMNS", "200")
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{format: "wide"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "Why we needed a wide format.") {
+		t.Errorf("expected excerpt in wide output, got: %q", out)
+	}
+}
+
+func TestRunLS_UnknownFormat(t *testing.T) {
+	setupInitedProject(t)
+	if err := runLS(lsOptions{format: "tall"}); err == nil {
+		t.Error("expected error for unknown --format")
+	}
+}
+
+func TestRunLS_AgentFilterAndColumn(t *testing.T) {
+	dir := setupInitedProject(t)
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	writePlanFileWithBody(t, dir, plan.Plan{ID: "a1", Topic: "from-claude", Date: &date, Agent: "claude-code"})
+	writePlanFileWithBody(t, dir, plan.Plan{ID: "a2", Topic: "from-cursor", Date: &date, Agent: "cursor"})
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{agent: "Claude-Code", showAgent: true}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "from-claude") || strings.Contains(out, "from-cursor") {
+		t.Errorf("expected only the claude-code plan, got:\n%s", out)
+	}
+	if !strings.Contains(out, "AGENT") || !strings.Contains(out, "claude-code") {
+		t.Errorf("expected AGENT column, got:\n%s", out)
+	}
+}
+
+func TestLS_CategoryFilterAndColumn(t *testing.T) {
+	dir := setupInitedProject(t)
+	d := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	incident := makeTestPlan("api-outage", nil, d)
+	incident.Category = "incident"
+	writePlanFileWithBody(t, dir, incident)
+	writePlanFileWithBody(t, dir, makeTestPlan("plain-plan", nil, d.Add(time.Hour)))
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{format: "table"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "CATEGORY") || !strings.Contains(out, "incident") {
+		t.Errorf("expected CATEGORY column, got:\n%s", out)
+	}
+
+	out = captureOutput(t, func() {
+		if err := runLS(lsOptions{format: "table", category: "incident"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "api-outage") || strings.Contains(out, "plain-plan") {
+		t.Errorf("expected only the incident plan, got:\n%s", out)
+	}
+}
+
+func TestLS_NoCategoryColumnWithoutCategories(t *testing.T) {
+	dir := setupInitedProject(t)
+	writePlanFileWithBody(t, dir, makeTestPlan("plain-plan", nil, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{format: "table"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if strings.Contains(out, "CATEGORY") {
+		t.Errorf("did not expect CATEGORY column, got:\n%s", out)
+	}
+}
@@ -0,0 +1,24 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"testing"
+)
+
+// TestMain points the user config directory at a temporary directory, so
+// that tests registering projects (every logos init does) never touch the
+// real per-user registry.
+func TestMain(m *testing.M) {
+	dir, err := os.MkdirTemp("", "logos-cmd-test-")
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Setenv("XDG_CONFIG_HOME", dir)
+	os.Setenv("HOME", dir)
+	os.Setenv("AppData", dir)
+	code := m.Run()
+	os.RemoveAll(dir)
+	os.Exit(code)
+}
@@ -0,0 +1,211 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+// meetingCategory is the plan category of meeting notes.
+const meetingCategory = "meeting"
+
+const (
+	attendeesSection   = "Attendees"
+	actionItemsSection = "Action Items"
+)
+
+var meetingCmd = &cobra.Command{
+	Use:   "meeting",
+	Short: "Scaffold meeting notes and turn action items into tasks",
+	Long: `Create a meeting-notes plan (category "meeting") with its attendees
+recorded in the frontmatter and listed under Attendees:
+
+  logos meeting --topic "sprint planning" --attendee alice --attendee bob
+
+Fill in the notes, then run logos meeting actions to turn every open item
+("- [ ] ..." or a plain "- ..." bullet) under "Action Items" into a task in
+the meeting plan. An @mention in the item assigns the task, e.g.
+"- [ ] @alice update the runbook".`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		topic, _ := cmd.Flags().GetString("topic")
+		attendees, _ := cmd.Flags().GetStringArray("attendee")
+		tags, _ := cmd.Flags().GetStringArray("tag")
+		agent, _ := cmd.Flags().GetString("agent")
+		return runMeeting(topic, attendees, tags, agent, time.Now())
+	},
+}
+
+var meetingActionsCmd = &cobra.Command{
+	Use:   "actions",
+	Short: "Create tasks from a meeting's Action Items",
+	Long: `Create a task in the meeting plan for every open item ("- [ ] ..." or a
+plain "- ..." bullet) under Action Items, as task create --from-plan does,
+then check off each item whose task was created so a second run skips it. The first @mention in an item becomes the task's
+assignee and is dropped from the title when it leads the item.
+
+Without --name the most recent meeting is used.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		return runMeetingActions(name)
+	},
+}
+
+func init() {
+	meetingCmd.Flags().StringP("topic", "t", "", "Meeting topic (required)")
+	meetingCmd.Flags().StringArray("attendee", []string{}, "Attendee (repeatable: --attendee alice --attendee bob)")
+	meetingCmd.Flags().StringArray("tag", []string{}, "Tag to attach (repeatable)")
+	meetingCmd.Flags().StringP("agent", "a", "", "Agent name (e.g. claude-code)")
+	meetingActionsCmd.Flags().StringP("name", "n", "", "Meeting plan (partial filename; default: the most recent meeting)")
+	meetingCmd.AddCommand(meetingActionsCmd)
+	rootCmd.AddCommand(meetingCmd)
+}
+
+// runMeeting creates a meeting plan for topic with attendees listed in the
+// frontmatter and the Attendees section.
+func runMeeting(topic string, attendees, tags []string, agent string, now time.Time) error {
+	if strings.TrimSpace(topic) == "" {
+		return errors.New("provide --topic <topic>")
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	p, err := newCategoryPlan(cfg, topic, agent, tags, meetingCategory, now)
+	if err != nil {
+		return err
+	}
+	p.Attendees = attendees
+	if len(attendees) > 0 {
+		p.Body = markdown.SetSection(p.Body, attendeesSection, "- "+strings.Join(attendees, "\n- "))
+	}
+	fields := append([]privacyField{{"topic", &p.Topic}, {"body", &p.Body}}, tagFields(p.Tags)...)
+	if err := enforcePrivacy(cfg, "plan", fields...); err != nil {
+		return err
+	}
+
+	path, err := writePlanAndIndex(root, cfg, p)
+	if err != nil {
+		return err
+	}
+	rel, _ := relPath(root, path)
+	printSuccess("Created meeting notes: %s", rel)
+	printHint(
+		fmt.Sprintf("Next: fill in the notes in %s", rel),
+		"      then run logos meeting actions to turn Action Items into tasks",
+	)
+	return nil
+}
+
+// runMeetingActions creates tasks from the open Action Items of the meeting
+// named by name, or of the most recent meeting when name is empty.
+func runMeetingActions(name string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	p, err := findMeeting(root, name)
+	if err != nil {
+		return err
+	}
+	if err := refuseFrozen(p); err != nil {
+		return err
+	}
+
+	items := openItems(p.Body, actionItemsSection, true)
+	if len(items) == 0 {
+		fmt.Printf("No open action items in %s.\n", p.Filename)
+		return nil
+	}
+
+	slug := strings.TrimSuffix(p.Filename, ".md")
+	var created []string
+	for _, item := range items {
+		title, assignee := parseActionItem(item)
+		t := task.Task{Title: title, Plan: slug, Assignee: assignee}
+		if err := createTask(root, cfg, &t, false, false); err != nil {
+			warnf("could not create task for %q: %v", item, err)
+			continue
+		}
+		created = append(created, item)
+	}
+	if len(created) == 0 {
+		return nil
+	}
+	p.Body = checkOffItems(p.Body, actionItemsSection, created, true)
+	_, err = writePlanAndIndex(root, cfg, p)
+	return err
+}
+
+// findMeeting returns the meeting plan matching name, or the most recent
+// meeting when name is empty.
+func findMeeting(root, name string) (plan.Plan, error) {
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	var meetings []plan.Plan
+	for _, p := range plans {
+		if p.Category == meetingCategory {
+			meetings = append(meetings, p)
+		}
+	}
+	if name != "" {
+		p, err := findPlan(name, meetings)
+		if err != nil {
+			return plan.Plan{}, fmt.Errorf("meeting: %w", err)
+		}
+		return p, nil
+	}
+	if len(meetings) == 0 {
+		return plan.Plan{}, errors.New(`no meeting notes found (create them with logos meeting --topic "...")`)
+	}
+	latest := meetings[0]
+	for _, p := range meetings[1:] {
+		if planUnix(p) > planUnix(latest) {
+			latest = p
+		}
+	}
+	return latest, nil
+}
+
+// mention matches an @mention such as @alice or @bob.smith.
+var mention = regexp.MustCompile(`@([\w.-]*\w)`)
+
+// parseActionItem splits an action item into a task title and the
+// assignee named by its first @mention. A leading mention (optionally
+// followed by ":") is dropped from the title; others lose only the "@".
+func parseActionItem(item string) (title, assignee string) {
+	m := mention.FindStringSubmatchIndex(item)
+	if m == nil {
+		return item, ""
+	}
+	assignee = item[m[2]:m[3]]
+	if m[0] == 0 {
+		title = strings.TrimSpace(strings.TrimPrefix(item[m[1]:], ":"))
+	} else {
+		title = item
+	}
+	title = mention.ReplaceAllString(title, "$1")
+	if title == "" {
+		title = item
+	}
+	return title, assignee
+}
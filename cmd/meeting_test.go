@@ -0,0 +1,132 @@
+package cmd
+
+import (
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+func TestMeeting_RecordsAttendees(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runMeeting("sprint planning", []string{"alice", "bob"}, nil, "", time.Now()); err != nil {
+		t.Fatalf("runMeeting: %v", err)
+	}
+	plans, _ := plan.LoadAll(dir)
+	if len(plans) != 1 {
+		t.Fatalf("expected one plan, got %d", len(plans))
+	}
+	p := plans[0]
+	if p.Category != "meeting" || !slices.Equal(p.Attendees, []string{"alice", "bob"}) {
+		t.Errorf("unexpected frontmatter: %+v", p)
+	}
+	if got, _ := markdown.Section(p.Body, "Attendees"); got != "- alice\n- bob" {
+		t.Errorf("Attendees section = %q", got)
+	}
+	if !strings.Contains(p.Body, "## Action Items") {
+		t.Errorf("expected Action Items section, got:\n%s", p.Body)
+	}
+}
+
+func TestMeetingActions_CreatesAssignedTasks(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runMeeting("sprint planning", []string{"alice", "bob"}, nil, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	plans, _ := plan.LoadAll(dir)
+	p := plans[0]
+	p.Body = markdown.SetSection(p.Body, "Action Items", "- [ ] @alice: update the runbook\n- [ ] Ask @bob about quotas\n- [x] Already done\n- Book the room")
+	if _, err := plan.Write(dir, p); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runMeetingActions(""); err != nil {
+		t.Fatalf("runMeetingActions: %v", err)
+	}
+
+	cfg, _ := config.Load(dir)
+	tasks, err := task.NewStore(dir, &cfg).List(task.Filter{Plan: strings.TrimSuffix(p.Filename, ".md")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := map[string]string{}
+	for _, tk := range tasks {
+		got[tk.Title] = tk.Assignee
+	}
+	want := map[string]string{
+		"update the runbook":   "alice",
+		"Ask bob about quotas": "bob",
+		"Book the room":        "",
+	}
+	if len(got) != len(want) {
+		t.Fatalf("tasks = %v, want %v", got, want)
+	}
+	for title, assignee := range want {
+		if a, ok := got[title]; !ok || a != assignee {
+			t.Errorf("task %q: assignee %q (found %v), want %q", title, a, ok, assignee)
+		}
+	}
+
+	// A second run finds nothing new.
+	if err := runMeetingActions(""); err != nil {
+		t.Fatal(err)
+	}
+	tasks, _ = task.NewStore(dir, &cfg).List(task.Filter{Plan: strings.TrimSuffix(p.Filename, ".md")})
+	if len(tasks) != 3 {
+		t.Errorf("expected no new tasks on a second run, got %d", len(tasks))
+	}
+}
+
+func TestMeetingActions_LeavesFailedItemsOpen(t *testing.T) {
+	dir := setupInitedProject(t)
+	setPrivacy(t, dir, "block")
+	if err := runMeeting("sprint planning", nil, nil, "", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	plans, _ := plan.LoadAll(dir)
+	p := plans[0]
+	p.Body = markdown.SetSection(p.Body, "Action Items", "- [ ] Revoke "+testAWSKey+"\n- [ ] Book the room")
+	if _, err := plan.Write(dir, p); err != nil {
+		t.Fatal(err)
+	}
+
+	captureStderr(t, func() {
+		if err := runMeetingActions(""); err != nil {
+			t.Fatalf("runMeetingActions: %v", err)
+		}
+	})
+
+	p, _ = plan.LoadFile(filepath.Join(plan.PlansDir(dir), p.Filename))
+	want := "- [ ] Revoke " + testAWSKey + "\n- [x] Book the room"
+	if got, _ := markdown.Section(p.Body, "Action Items"); got != want {
+		t.Errorf("Action Items = %q, want %q", got, want)
+	}
+}
+
+func TestMeetingActions_NoMeeting(t *testing.T) {
+	setupInitedProject(t)
+	if err := runMeetingActions(""); err == nil {
+		t.Error("expected error without meeting notes")
+	}
+}
+
+func TestParseActionItem(t *testing.T) {
+	cases := []struct{ item, title, assignee string }{
+		{"@alice update docs", "update docs", "alice"},
+		{"@bob.smith: file the ticket", "file the ticket", "bob.smith"},
+		{"Ping @carol and @dan", "Ping carol and dan", "carol"},
+		{"No owner yet", "No owner yet", ""},
+	}
+	for _, c := range cases {
+		title, assignee := parseActionItem(c.item)
+		if title != c.title || assignee != c.assignee {
+			t.Errorf("parseActionItem(%q) = %q, %q; want %q, %q", c.item, title, assignee, c.title, c.assignee)
+		}
+	}
+}
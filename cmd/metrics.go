@@ -0,0 +1,65 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/metrics"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/spf13/cobra"
+)
+
+// emitMetrics reports the duration and outcome of cmd, plus the current
+// index sizes when run inside a project, to the exporters configured in the
+// user config. It does nothing when metrics are not configured, and export
+// failures are ignored so that they never affect the command's result.
+func emitMetrics(cmd *cobra.Command, start time.Time, cmdErr error) {
+	cfg, err := metrics.LoadUserConfig()
+	if err != nil || !cfg.Enabled() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+	_ = metrics.Emit(ctx, cfg, commandSamples(cmd, time.Since(start), cmdErr))
+}
+
+// commandSamples returns the samples emitted after a command finishes.
+func commandSamples(cmd *cobra.Command, elapsed time.Duration, cmdErr error) []metrics.Sample {
+	name := "logos"
+	if cmd != nil {
+		name = cmd.CommandPath()
+	}
+	name = strings.ReplaceAll(strings.TrimPrefix(strings.TrimPrefix(name, "logos"), " "), " ", ".")
+	if name == "" {
+		name = "root"
+	}
+	status := "ok"
+	if cmdErr != nil {
+		status = "error"
+	}
+	tags := map[string]string{"command": name, "status": status}
+	samples := []metrics.Sample{
+		{Name: "command.duration", Kind: metrics.Timer, Value: float64(elapsed.Microseconds()) / 1000, Tags: tags},
+	}
+	if cmdErr != nil {
+		samples = append(samples, metrics.Sample{Name: "command.errors", Kind: metrics.Counter, Value: 1, Tags: map[string]string{"command": name}})
+	}
+
+	root, err := project.FindRoot()
+	if err != nil {
+		return samples
+	}
+	// A missing index counts as empty.
+	if plans, err := index.ReadAll(root); err == nil || errors.Is(err, os.ErrNotExist) {
+		samples = append(samples, metrics.Sample{Name: "index.plans", Kind: metrics.Gauge, Value: float64(len(plans))})
+	}
+	if tasks, err := task.ReadAllTaskIndex(root); err == nil || errors.Is(err, os.ErrNotExist) {
+		samples = append(samples, metrics.Sample{Name: "index.tasks", Kind: metrics.Gauge, Value: float64(len(tasks))})
+	}
+	return samples
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/metrics"
+)
+
+func TestCommandSamples(t *testing.T) {
+	setupInitedProject(t)
+	samples := commandSamples(taskCreateCmd, 1500*time.Microsecond, errors.New("boom"))
+
+	byName := map[string]metrics.Sample{}
+	for _, s := range samples {
+		byName[s.Name] = s
+	}
+	d, ok := byName["command.duration"]
+	if !ok || d.Value != 1.5 || d.Tags["command"] != "task.create" || d.Tags["status"] != "error" {
+		t.Errorf("unexpected duration sample: %+v", d)
+	}
+	if e, ok := byName["command.errors"]; !ok || e.Value != 1 {
+		t.Errorf("expected an error counter, got %+v", e)
+	}
+	for _, name := range []string{"index.plans", "index.tasks"} {
+		if _, ok := byName[name]; !ok {
+			t.Errorf("expected %s gauge in %+v", name, samples)
+		}
+	}
+}
+
+func TestCommandSamples_Success(t *testing.T) {
+	for _, s := range commandSamples(lsCmd, time.Millisecond, nil) {
+		if s.Name == "command.errors" {
+			t.Error("unexpected error counter for a successful command")
+		}
+		if s.Name == "command.duration" && s.Tags["status"] != "ok" {
+			t.Errorf("status = %q, want ok", s.Tags["status"])
+		}
+	}
+}
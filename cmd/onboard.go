@@ -0,0 +1,205 @@
+package cmd
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+// decisionSections are the plan headings logos onboard collects decisions
+// from: the plan template's Key Decisions and the meeting category's
+// Decisions.
+var decisionSections = []string{"Key Decisions", "Decisions"}
+
+// onboardTasksPerPlan caps the open tasks listed per plan in logos onboard.
+const onboardTasksPerPlan = 10
+
+var onboardCmd = &cobra.Command{
+	Use:   "onboard",
+	Short: "Print a Markdown digest of the project for new contributors",
+	Long: `Generate a newcomer-oriented digest of the project as Markdown, ready to
+paste into CONTRIBUTING.md or hand to a new agent instance:
+
+  Brief          .logosyncx/BRIEF.md, when present
+  Key plans      pinned plans (logos agents pin) with their excerpts
+  Decisions      the most recent bullets under "Key Decisions" or
+                 "Decisions" in any plan, newest plan first (--decisions)
+  Open work      unfinished, unsnoozed tasks grouped by plan
+
+The digest is printed to stdout; use --out to write it to a file instead.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		out, _ := cmd.Flags().GetString("out")
+		decisions, _ := cmd.Flags().GetInt("decisions")
+		return runOnboard(out, decisions, time.Now())
+	},
+}
+
+func init() {
+	onboardCmd.Flags().StringP("out", "o", "", "Write the digest to this file instead of stdout")
+	onboardCmd.Flags().Int("decisions", 10, "Maximum number of decisions to list")
+	rootCmd.AddCommand(onboardCmd)
+}
+
+func runOnboard(out string, maxDecisions int, now time.Time) error {
+	if maxDecisions < 0 {
+		return errors.New("--decisions must not be negative")
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	digest := renderOnboarding(root, cfg, maxDecisions, now)
+	if out == "" || out == "-" {
+		fmt.Print(digest)
+		return nil
+	}
+	if err := os.WriteFile(out, []byte(digest), 0o644); err != nil {
+		return fmt.Errorf("write %s: %w", out, err)
+	}
+	printSuccess("Onboarding digest written to %s", out)
+	return nil
+}
+
+// renderOnboarding returns the onboarding digest. Unreadable plans and
+// tasks are skipped.
+func renderOnboarding(root string, cfg config.Config, maxDecisions int, now time.Time) string {
+	var b strings.Builder
+	name := cmp.Or(cfg.Project, filepath.Base(root))
+	fmt.Fprintf(&b, "# Onboarding: %s\n\n", name)
+	fmt.Fprintf(&b, "_Generated by `logos onboard` on %s. Plans and tasks live in `.logosyncx/`; see `.logosyncx/USAGE.md` for the logos CLI._\n",
+		now.In(displayLocation(cfg)).Format("2006-01-02"))
+
+	b.WriteString("\n## Project brief\n\n")
+	if brief, err := os.ReadFile(filepath.Join(root, config.DirName, briefFileName)); err == nil && strings.TrimSpace(string(brief)) != "" {
+		b.WriteString(strings.TrimSpace(string(brief)) + "\n")
+	} else {
+		fmt.Fprintf(&b, "No brief yet — write one in `.logosyncx/%s`.\n", briefFileName)
+	}
+
+	plans, _ := plan.LoadAllWithOptions(root, planParseOptions(cfg))
+	slices.SortFunc(plans, func(a, b plan.Plan) int {
+		return cmp.Or(cmp.Compare(planUnix(b), planUnix(a)), cmp.Compare(a.Filename, b.Filename))
+	})
+
+	b.WriteString("\n## Key plans\n\n")
+	pinned := 0
+	for _, p := range plans {
+		if !p.Pinned {
+			continue
+		}
+		pinned++
+		fmt.Fprintf(&b, "- **%s** (`%s`)", p.Topic, p.Filename)
+		if p.Excerpt != "" {
+			b.WriteString(" — " + strings.ReplaceAll(p.Excerpt, "\n", " "))
+		}
+		b.WriteString("\n")
+	}
+	if pinned == 0 {
+		b.WriteString("None pinned — pin the plans every newcomer should read with `logos agents pin --name <plan>`.\n")
+	}
+
+	b.WriteString("\n## Decisions\n\n")
+	decisions := collectDecisions(plans, maxDecisions)
+	if len(decisions) == 0 {
+		b.WriteString("None recorded.\n")
+	}
+	for _, d := range decisions {
+		b.WriteString(d + "\n")
+	}
+
+	b.WriteString("\n## Open work\n")
+	writeOpenWork(&b, root, now)
+	return b.String()
+}
+
+// collectDecisions returns up to limit decision bullets from the decision
+// sections of plans, in plan order, each suffixed with its source file.
+func collectDecisions(plans []plan.Plan, limit int) []string {
+	var out []string
+	for _, p := range plans {
+		for _, name := range decisionSections {
+			section, ok := markdown.Section(p.Body, name)
+			if !ok {
+				continue
+			}
+			for _, line := range strings.Split(markdown.StripComments(section), "\n") {
+				if len(out) >= limit {
+					return out
+				}
+				if len(line) < 2 || !strings.ContainsRune("-*+", rune(line[0])) || line[1] != ' ' {
+					continue
+				}
+				text := strings.TrimSpace(line[2:])
+				if text == "" {
+					continue
+				}
+				out = append(out, fmt.Sprintf("- %s (`%s`)", text, p.Filename))
+			}
+		}
+	}
+	return out
+}
+
+// writeOpenWork writes the unfinished, unsnoozed tasks grouped by plan,
+// in_progress before open and then by seq, capped per plan.
+func writeOpenWork(b *strings.Builder, root string, now time.Time) {
+	entries, _ := task.ReadAllTaskIndex(root)
+	byPlan := map[string][]task.TaskJSON{}
+	for _, t := range entries {
+		if t.Status != task.StatusDone && !t.IsSnoozed(now) {
+			byPlan[t.Plan] = append(byPlan[t.Plan], t)
+		}
+	}
+	if len(byPlan) == 0 {
+		b.WriteString("\nNo open tasks.\n")
+		return
+	}
+	for _, slug := range slices.Sorted(maps.Keys(byPlan)) {
+		tasks := byPlan[slug]
+		slices.SortFunc(tasks, func(a, b task.TaskJSON) int {
+			return cmp.Or(
+				cmp.Compare(statusRank(a.Status), statusRank(b.Status)),
+				cmp.Compare(a.Seq, b.Seq),
+				cmp.Compare(a.ID, b.ID),
+			)
+		})
+		fmt.Fprintf(b, "\n### %s (%d open)\n\n", slug, len(tasks))
+		for _, t := range tasks[:min(len(tasks), onboardTasksPerPlan)] {
+			line := fmt.Sprintf("- [%s] %s — %s", t.Status, t.Title, t.Priority)
+			if t.Assignee != "" {
+				line += ", @" + t.Assignee
+			}
+			b.WriteString(line + "\n")
+		}
+		if extra := len(tasks) - onboardTasksPerPlan; extra > 0 {
+			fmt.Fprintf(b, "- … and %d more (`logos task ls --plan %s`)\n", extra, slug)
+		}
+	}
+}
+
+// statusRank orders in_progress tasks before other unfinished tasks.
+func statusRank(s task.Status) int {
+	if s == task.StatusInProgress {
+		return 0
+	}
+	return 1
+}
@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+func TestOnboard_Digest(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := os.WriteFile(filepath.Join(dir, config.DirName, briefFileName), []byte("We build a CLI.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	p := makeTestPlan("auth-design", nil, time.Now())
+	p.Pinned = true
+	p.Body = "## Key Decisions\n\n- Use JWT for sessions\n"
+	writePlanFileWithBody(t, dir, p)
+	slug := strings.TrimSuffix(plan.FileName(p), ".md")
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Wire the API", priority: "high"}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, _ := config.Load(dir)
+	out := renderOnboarding(dir, cfg, 10, time.Now())
+	for _, want := range []string{
+		"## Project brief\n\nWe build a CLI.",
+		"## Key plans\n\n- **auth-design** (`" + slug + ".md`)",
+		"- Use JWT for sessions (`" + slug + ".md`)",
+		"### " + slug + " (1 open)",
+		"- [open] Wire the API — high",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("digest missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestOnboard_EmptyProject(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, _ := config.Load(dir)
+	out := renderOnboarding(dir, cfg, 10, time.Now())
+	for _, want := range []string{"No brief yet", "None pinned", "None recorded.", "No open tasks."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("digest missing %q:\n%s", want, out)
+		}
+	}
+}
+
+func TestOnboard_DecisionLimit(t *testing.T) {
+	p := plan.Plan{Filename: "a.md", Body: "## Decisions\n\n- one\n- two\n- three\n"}
+	if got := collectDecisions([]plan.Plan{p}, 2); len(got) != 2 {
+		t.Errorf("expected 2 decisions, got %v", got)
+	}
+}
+
+func TestOnboard_WritesFile(t *testing.T) {
+	dir := setupInitedProject(t)
+	out := filepath.Join(dir, "ONBOARDING.md")
+	captureOutput(t, func() {
+		if err := runOnboard(out, 10, time.Now()); err != nil {
+			t.Errorf("runOnboard: %v", err)
+		}
+	})
+	data, err := os.ReadFile(out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(string(data), "# Onboarding: ") {
+		t.Errorf("unexpected digest:\n%s", data)
+	}
+}
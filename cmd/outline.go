@@ -0,0 +1,101 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var outlineCmd = &cobra.Command{
+	Use:   "outline",
+	Short: "Print the heading structure of a plan or task",
+	Long: `Print only the headings of a plan or task, indented by nesting level, so
+you can see what a file contains before asking for specific sections (e.g.
+with logos refer --summary or logos task refer --summary).
+
+--name is matched like logos refer (plans: filename, topic, or ID) and like
+logos task refer (tasks: directory name). When it matches both a plan and a
+task, the command fails; use --plan to pick the task. Section sizes are
+available from logos refer --outline.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		planPartial, _ := cmd.Flags().GetString("plan")
+		return runOutline(name, planPartial)
+	},
+}
+
+func init() {
+	outlineCmd.Flags().StringP("name", "n", "", "Plan or task name (exact or partial match)")
+	outlineCmd.Flags().StringP("plan", "p", "", "Only look up tasks in this plan (partial match)")
+	_ = outlineCmd.MarkFlagRequired("name")
+	rootCmd.AddCommand(outlineCmd)
+}
+
+func runOutline(name, planPartial string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	t, taskErr := task.NewStore(root, &cfg).Get(planPartial, name)
+	if planPartial != "" {
+		if taskErr != nil {
+			return taskErr
+		}
+		printHeadingTree("Task: "+filepath.Join(t.Plan, filepath.Base(t.DirPath), "TASK.md"), t.Body)
+		return nil
+	}
+	if taskErr != nil && !errors.Is(taskErr, task.ErrNotFound) {
+		return taskErr
+	}
+
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	matches := matchPlans(plans, name)
+	switch {
+	case len(matches) > 1:
+		return printPlanCandidates(matches, name, nil)
+	case len(matches) == 1 && t != nil:
+		return fmt.Errorf("%q matches plan %s and task %s; use --plan to select the task",
+			name, matches[0].Filename, filepath.Base(t.DirPath))
+	case len(matches) == 1:
+		printHeadingTree("Plan: "+matches[0].Filename, matches[0].Body)
+	case t != nil:
+		printHeadingTree("Task: "+filepath.Join(t.Plan, filepath.Base(t.DirPath), "TASK.md"), t.Body)
+	default:
+		return fmt.Errorf("no plan or task found matching %q", name)
+	}
+	return nil
+}
+
+// printHeadingTree prints title and then the headings of body as a nested
+// list, the shallowest heading level unindented.
+func printHeadingTree(title, body string) {
+	fmt.Println(title)
+	sections := markdown.Outline(body)
+	if len(sections) == 0 {
+		fmt.Println("(no headings)")
+		return
+	}
+	top := sections[0].Level
+	for _, s := range sections {
+		top = min(top, s.Level)
+	}
+	for _, s := range sections {
+		fmt.Printf("%s- %s\n", strings.Repeat("  ", s.Level-top), s.Heading)
+	}
+}
@@ -0,0 +1,75 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestOutline_Plan(t *testing.T) {
+	dir := setupInitedProject(t)
+	p := makeTestPlan("outline-plan", nil, time.Now())
+	p.Body = "## Background\n\ntext\n\n### Detail\n\nmore\n\n## Spec\n"
+	writePlanFileWithBody(t, dir, p)
+
+	out := captureOutput(t, func() {
+		if err := runOutline("outline-plan", ""); err != nil {
+			t.Errorf("runOutline: %v", err)
+		}
+	})
+	if !strings.Contains(out, "- Background\n  - Detail\n- Spec\n") {
+		t.Errorf("unexpected outline:\n%s", out)
+	}
+	if strings.Contains(out, "text") {
+		t.Errorf("outline should not include section content:\n%s", out)
+	}
+}
+
+func TestOutline_Task(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Outline me", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, ".logosyncx", "tasks", testPlan, "001-outline-me", "TASK.md")
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_WRONLY, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = f.WriteString("\n## What\n\nA task.\n")
+	f.Close()
+
+	out := captureOutput(t, func() {
+		if err := runOutline("outline-me", ""); err != nil {
+			t.Errorf("runOutline: %v", err)
+		}
+	})
+	if !strings.HasPrefix(out, "Task: "+testPlan+"/001-outline-me/TASK.md\n") || !strings.Contains(out, "- What") {
+		t.Errorf("unexpected outline:\n%s", out)
+	}
+}
+
+func TestOutline_PlanAndTaskMatch(t *testing.T) {
+	dir := setupInitedProject(t)
+	writePlanFileWithBody(t, dir, makeTestPlan("shared-name", nil, time.Now()))
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Shared name", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := runOutline("shared-name", ""); err == nil || !strings.Contains(err.Error(), "--plan") {
+		t.Errorf("expected an ambiguity error, got %v", err)
+	}
+	captureOutput(t, func() {
+		if err := runOutline("shared-name", testPlan); err != nil {
+			t.Errorf("runOutline with --plan: %v", err)
+		}
+	})
+}
+
+func TestOutline_NoMatch(t *testing.T) {
+	setupInitedProject(t)
+	if err := runOutline("nothing-here", ""); err == nil {
+		t.Error("expected an error when nothing matches")
+	}
+}
@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/internal/term"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// forAgent, when true, strips decoration from command output: check marks,
+// blank spacer lines, and "Next:" hints are omitted so that agents receive
+// only the lines they need to parse. It is resolved once per invocation by
+// detectAgentOutput (see rootCmd.PersistentPreRunE).
+var forAgent bool
+
+// detectAgentOutput reports whether output should use the agent profile.
+//
+// The profile is enabled when any of the following is true:
+//   - the --for-agent flag was passed
+//   - LOGOS_AGENT=1 is set in the environment
+//   - stdout is not a terminal (piped or redirected)
+func detectAgentOutput(flag bool) bool {
+	if flag {
+		return true
+	}
+	if os.Getenv("LOGOS_AGENT") == "1" {
+		return true
+	}
+	return !term.IsTerminal(os.Stdout)
+}
+
+// printSuccess prints a result line. In the human profile the line is
+// prefixed with a check mark; in the agent profile it is printed bare.
+func printSuccess(format string, args ...any) {
+	if !forAgent {
+		fmt.Print("✓ ")
+	}
+	fmt.Printf(format+"\n", args...)
+}
+
+// printHint prints follow-up guidance ("Next: ...") preceded by a blank
+// line. Hints are omitted entirely in the agent profile.
+func printHint(lines ...string) {
+	if forAgent {
+		return
+	}
+	fmt.Println()
+	for _, line := range lines {
+		fmt.Println(line)
+	}
+}
+
+// warningsJSON, when true, makes warnf and notef write each message to
+// stderr as one JSON object per line instead of a "warning: ..." or
+// "note: ..." text line, so that agent pipelines parsing --json output can
+// capture them separately from other stderr noise. It is resolved once per
+// invocation from --warnings-json or LOGOS_WARNINGS_JSON=1 (see
+// rootCmd.PersistentPreRunE).
+var warningsJSON bool
+
+// warningCommand is the command path ("task create") recorded in JSON
+// warnings. It is set in rootCmd.PersistentPreRunE.
+var warningCommand string
+
+// warningProject is the config "project" name recorded in JSON warnings, so
+// that tools collecting warnings from several repositories can tell them
+// apart. It is set in rootCmd.PersistentPreRunE when warningsJSON is on,
+// and empty outside a project.
+var warningProject string
+
+// jsonWarning is one line of the --warnings-json stream.
+type jsonWarning struct {
+	Level   string `json:"level"`
+	Project string `json:"project,omitempty"`
+	Command string `json:"command,omitempty"`
+	Message string `json:"message"`
+}
+
+// warnf reports a non-fatal problem on stderr, as "warning: <message>" or,
+// with --warnings-json, as a JSON line such as
+// {"level":"warning","project":"api","command":"ls","message":"..."}.
+func warnf(format string, args ...any) {
+	report("warning", format, args...)
+}
+
+// notef is warnf for information that is not a problem, such as results
+// left out of a listing: "note: <message>", or a JSON line with level
+// "note".
+func notef(format string, args ...any) {
+	report("note", format, args...)
+}
+
+// report writes a stderr message at level for warnf and notef.
+func report(level, format string, args ...any) {
+	msg := strings.TrimSuffix(fmt.Sprintf(format, args...), "\n")
+	if !warningsJSON {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", level, msg)
+		return
+	}
+	data, err := json.Marshal(jsonWarning{Level: level, Project: warningProject, Command: warningCommand, Message: msg})
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "%s: %s\n", level, msg)
+		return
+	}
+	fmt.Fprintln(os.Stderr, string(data))
+}
+
+func init() {
+	task.Warnf = warnf
+}
+
+// currentProjectName returns the config "project" name of the project the
+// working directory belongs to, or "" outside a project.
+func currentProjectName() string {
+	root, err := project.FindRoot()
+	if err != nil {
+		return ""
+	}
+	return projectName(root)
+}
+
+// outputDefaults returns the output section of the project config. Outside
+// a project, or when the config cannot be read, it returns the zero value
+// so that commands fall back to their flag defaults.
+func outputDefaults() config.OutputConfig {
+	root, err := project.FindRoot()
+	if err != nil {
+		return config.OutputConfig{}
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return config.OutputConfig{}
+	}
+	return cfg.Output
+}
+
+// displayLocation returns the display.timezone zone from cfg. An unknown
+// zone is reported as a warning and the local zone is used instead.
+func displayLocation(cfg config.Config) *time.Location {
+	loc, err := cfg.Display.Location()
+	if err != nil {
+		warnf("display.timezone: %v — using local time", err)
+		return time.Local
+	}
+	return loc
+}
@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+// withForAgent sets the agent output profile for the duration of a test.
+func withForAgent(t *testing.T, v bool) {
+	t.Helper()
+	orig := forAgent
+	forAgent = v
+	t.Cleanup(func() { forAgent = orig })
+}
+
+func TestDetectAgentOutput_FlagWins(t *testing.T) {
+	t.Setenv("LOGOS_AGENT", "")
+	if !detectAgentOutput(true) {
+		t.Error("expected agent profile when --for-agent is set")
+	}
+}
+
+func TestDetectAgentOutput_EnvVar(t *testing.T) {
+	t.Setenv("LOGOS_AGENT", "1")
+	if !detectAgentOutput(false) {
+		t.Error("expected agent profile when LOGOS_AGENT=1")
+	}
+}
+
+func TestPrintSuccess_HumanProfile_HasCheckMark(t *testing.T) {
+	withForAgent(t, false)
+	out := captureOutput(t, func() { printSuccess("Created plan: %s", "x.md") })
+	if out != "✓ Created plan: x.md\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestPrintSuccess_AgentProfile_NoCheckMark(t *testing.T) {
+	withForAgent(t, true)
+	out := captureOutput(t, func() { printSuccess("Created plan: %s", "x.md") })
+	if out != "Created plan: x.md\n" {
+		t.Errorf("unexpected output: %q", out)
+	}
+}
+
+func TestPrintHint_HumanProfile_Printed(t *testing.T) {
+	withForAgent(t, false)
+	out := captureOutput(t, func() { printHint("Next: do the thing") })
+	if !strings.Contains(out, "Next: do the thing") {
+		t.Errorf("expected hint in output, got %q", out)
+	}
+}
+
+func TestPrintHint_AgentProfile_Omitted(t *testing.T) {
+	withForAgent(t, true)
+	out := captureOutput(t, func() { printHint("Next: do the thing") })
+	if out != "" {
+		t.Errorf("expected no output in agent profile, got %q", out)
+	}
+}
+
+func TestSave_AgentProfile_NoDecoration(t *testing.T) {
+	setupInitedProject(t)
+	withForAgent(t, true)
+
+	out := captureOutput(t, func() {
+		if err := runSave("agent profile", nil, "", "", nil, nil, nil, false, ""); err != nil {
+			t.Fatalf("runSave: %v", err)
+		}
+	})
+	if strings.Contains(out, "✓") || strings.Contains(out, "Next:") {
+		t.Errorf("expected undecorated output, got %q", out)
+	}
+	if !strings.HasPrefix(out, "Created plan: ") {
+		t.Errorf("expected bare result line, got %q", out)
+	}
+}
+
+// captureStderr redirects stderr during f() and returns what was written.
+func captureStderr(t *testing.T, f func()) string {
+	t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		t.Fatalf("os.Pipe: %v", err)
+	}
+	orig := os.Stderr
+	os.Stderr = w
+	f()
+	w.Close()
+	os.Stderr = orig
+
+	var buf bytes.Buffer
+	if _, err := buf.ReadFrom(r); err != nil {
+		t.Fatalf("ReadFrom pipe: %v", err)
+	}
+	return buf.String()
+}
+
+// withWarningsJSON sets the --warnings-json format for the duration of a test.
+func withWarningsJSON(t *testing.T, v bool) {
+	t.Helper()
+	orig, origCmd := warningsJSON, warningCommand
+	warningsJSON, warningCommand = v, "ls"
+	t.Cleanup(func() { warningsJSON, warningCommand = orig, origCmd })
+}
+
+func TestWarnf_Text(t *testing.T) {
+	withWarningsJSON(t, false)
+	got := captureStderr(t, func() { warnf("bad line %d", 3) })
+	if got != "warning: bad line 3\n" {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestWarnf_JSON(t *testing.T) {
+	withWarningsJSON(t, true)
+	got := captureStderr(t, func() { warnf("bad line %d\n", 3) })
+	var w jsonWarning
+	if err := json.Unmarshal([]byte(got), &w); err != nil {
+		t.Fatalf("not a JSON line: %q (%v)", got, err)
+	}
+	if w != (jsonWarning{Level: "warning", Command: "ls", Message: "bad line 3"}) {
+		t.Errorf("unexpected warning: %+v", w)
+	}
+}
+
+func TestNotef_JSON(t *testing.T) {
+	withWarningsJSON(t, true)
+	got := captureStderr(t, func() { notef("%d snoozed task(s) hidden", 2) })
+	var w jsonWarning
+	if err := json.Unmarshal([]byte(got), &w); err != nil {
+		t.Fatalf("not a JSON line: %q (%v)", got, err)
+	}
+	if w != (jsonWarning{Level: "note", Command: "ls", Message: "2 snoozed task(s) hidden"}) {
+		t.Errorf("unexpected note: %+v", w)
+	}
+}
+
+func TestWarnf_JSON_IncludesProject(t *testing.T) {
+	withWarningsJSON(t, true)
+	orig := warningProject
+	warningProject = "billing-api"
+	t.Cleanup(func() { warningProject = orig })
+
+	got := captureStderr(t, func() { warnf("stale index") })
+	if !strings.HasPrefix(got, `{"level":"warning","project":"billing-api","command":"ls",`) {
+		t.Errorf("unexpected warning line %q", got)
+	}
+}
+
+func TestLS_RebuildNotice_WarningsJSON(t *testing.T) {
+	dir := setupInitedProject(t)
+	writePlanFileWithBody(t, dir, makeTestPlan("auth", nil, time.Now()))
+	withWarningsJSON(t, true)
+
+	var stdout string
+	stderr := captureStderr(t, func() {
+		stdout = captureOutput(t, func() {
+			if err := runLS(lsOptions{asJSON: true}); err != nil {
+				t.Errorf("runLS: %v", err)
+			}
+		})
+	})
+	lines := strings.Split(strings.TrimSpace(stderr), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected one JSON warning line, got %q", stderr)
+	}
+	var w jsonWarning
+	if err := json.Unmarshal([]byte(lines[0]), &w); err != nil || !strings.Contains(w.Message, "rebuilt from plans/") {
+		t.Errorf("unexpected warning %q (%v)", lines[0], err)
+	}
+	if !strings.HasPrefix(strings.TrimSpace(stdout), "[") {
+		t.Errorf("expected JSON on stdout, got %q", stdout)
+	}
+}
+
+// executeRoot runs the root command with args, restoring the global state
+// PersistentPreRunE changes and clearing the Changed mark of flagNames.
+func executeRoot(t *testing.T, cmd *cobra.Command, flagNames []string, args ...string) string {
+	t.Helper()
+	origAgent, origWarn, origFull := forAgent, warningsJSON, fullTables
+	t.Cleanup(func() {
+		forAgent, warningsJSON, fullTables = origAgent, origWarn, origFull
+		rootCmd.SetArgs(nil)
+		for _, name := range flagNames {
+			f := cmd.Flags().Lookup(name)
+			_ = f.Value.Set(f.DefValue)
+			f.Changed = false
+		}
+	})
+	rootCmd.SetArgs(args)
+	return captureOutput(t, func() {
+		if err := rootCmd.Execute(); err != nil {
+			t.Errorf("logos %v: %v", args, err)
+		}
+	})
+}
+
+func setOutputDefaults(t *testing.T, dir string, out config.OutputConfig) {
+	t.Helper()
+	cfg, _ := config.Load(dir)
+	cfg.Output = out
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestOutputDefaults_LSJSON(t *testing.T) {
+	dir := setupInitedProject(t)
+	writePlanFileWithBody(t, dir, makeTestPlan("auth", nil, time.Now()))
+	setOutputDefaults(t, dir, config.OutputConfig{LS: "json"})
+
+	out := executeRoot(t, lsCmd, nil, "ls")
+	if !strings.HasPrefix(strings.TrimSpace(out), "[") {
+		t.Errorf("expected JSON from output.ls default, got:\n%s", out)
+	}
+
+	out = executeRoot(t, lsCmd, []string{"format"}, "ls", "--format", "table")
+	if strings.HasPrefix(strings.TrimSpace(out), "[") || !strings.Contains(out, "auth") {
+		t.Errorf("expected --format to override output.ls, got:\n%s", out)
+	}
+}
+
+func TestOutputDefaults_TaskLSJSON(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Rotate keys", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+	setOutputDefaults(t, dir, config.OutputConfig{TaskLS: "json"})
+
+	out := executeRoot(t, taskLsCmd, nil, "task", "ls")
+	if !strings.HasPrefix(strings.TrimSpace(out), "[") {
+		t.Errorf("expected JSON from output.task_ls default, got:\n%s", out)
+	}
+
+	out = executeRoot(t, taskLsCmd, []string{"json"}, "task", "ls", "--json=false")
+	if strings.HasPrefix(strings.TrimSpace(out), "[") || !strings.Contains(out, "Rotate keys") {
+		t.Errorf("expected --json=false to override output.task_ls, got:\n%s", out)
+	}
+}
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// overlayRoot is one of the roots listed in config "overlays": shared,
+// read-only context (org conventions, shared decisions) layered beneath the
+// project root. logos ls, search, and refer merge its plans with the
+// project's own and mark where each came from; every write still goes to
+// the project root.
+type overlayRoot struct {
+	name string // base name of the directory, shown as the plan's origin
+	dir  string // directory holding .logosyncx/
+}
+
+// overlayRoots resolves cfg.Overlays against root, following a storage
+// pointer in the overlay the same way FindRoot does. Overlays without a
+// .logosyncx/ are skipped with a warning; one resolving to root is skipped
+// silently.
+func overlayRoots(root string, cfg config.Config) []overlayRoot {
+	var out []overlayRoot
+	for _, o := range cfg.Overlays {
+		dir := o
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(root, dir)
+		}
+		dir = filepath.Clean(dir)
+		if info, err := os.Stat(filepath.Join(dir, config.DirName)); err != nil || !info.IsDir() {
+			warnf("overlay %s has no .logosyncx/ — skipped", o)
+			continue
+		}
+		resolved, err := project.ResolveStorage(dir)
+		if err != nil {
+			warnf("overlay %s: %v — skipped", o, err)
+			continue
+		}
+		if resolved == root {
+			continue
+		}
+		out = append(out, overlayRoot{name: filepath.Base(dir), dir: resolved})
+	}
+	return out
+}
+
+// addOverlayEntries appends the plan index entries of every overlay to
+// entries (see overlayEntries).
+func addOverlayEntries(root string, cfg config.Config, entries []index.Entry, counts map[string]taskCount) []index.Entry {
+	seen := make(map[string]bool, len(entries))
+	for _, e := range entries {
+		seen[e.Filename] = true
+	}
+	return append(entries, overlayEntries(root, cfg, seen, counts)...)
+}
+
+// overlayEntries returns the plan index entries of every overlay, with
+// Origin set, and adds their task counts to counts. A plan whose filename
+// is in seen (listed by the project) or was returned for an earlier
+// overlay is shadowed; seen is updated. An overlay's index is never
+// written: when it is unreadable the entries are built from its plans in
+// memory.
+func overlayEntries(root string, cfg config.Config, seen map[string]bool, counts map[string]taskCount) []index.Entry {
+	var entries []index.Entry
+	for _, ov := range overlayRoots(root, cfg) {
+		overlay, err := index.ReadAll(ov.dir)
+		if err != nil {
+			overlay, err = index.Build(ov.dir, planParseOptions(cfg))
+			if err != nil {
+				warnf("overlay %s: %v", ov.name, err)
+			}
+		}
+		for _, e := range overlay {
+			if seen[e.Filename] {
+				continue
+			}
+			seen[e.Filename] = true
+			e.Origin = ov.name
+			entries = append(entries, e)
+		}
+		for slug, c := range loadTaskCounts(ov.dir) {
+			counts[ov.name+"/"+slug] = c
+		}
+	}
+	return entries
+}
+
+// addOverlayPlans appends the plans of every overlay to plans, shadowing
+// filenames already present, and returns the origin of each added plan
+// keyed by filename.
+func addOverlayPlans(root string, cfg config.Config, plans []plan.Plan) ([]plan.Plan, map[string]string) {
+	origins := map[string]string{}
+	seen := make(map[string]bool, len(plans))
+	for _, p := range plans {
+		seen[p.Filename] = true
+	}
+	for _, ov := range overlayRoots(root, cfg) {
+		overlay, err := plan.LoadAll(ov.dir)
+		if err != nil {
+			warnf("overlay %s: %v", ov.name, err)
+		}
+		for _, p := range overlay {
+			if seen[p.Filename] {
+				continue
+			}
+			seen[p.Filename] = true
+			origins[p.Filename] = ov.name
+			plans = append(plans, p)
+		}
+	}
+	return plans, origins
+}
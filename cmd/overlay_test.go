@@ -0,0 +1,112 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// setupOverlayProject creates an initialised project whose config lists a
+// sibling "org-context" overlay holding one plan, and returns both roots.
+func setupOverlayProject(t *testing.T) (root, overlay string) {
+	t.Helper()
+	root = setupInitedProject(t)
+	overlay = filepath.Join(t.TempDir(), "org-context")
+	d := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	writePlanFileWithBody(t, overlay, makeTestPlan("org-conventions", []string{"org"}, d))
+
+	cfg, err := config.Load(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Overlays = []string{overlay}
+	if err := config.Save(root, cfg); err != nil {
+		t.Fatal(err)
+	}
+	return root, overlay
+}
+
+func TestLS_IncludesOverlayPlansWithOrigin(t *testing.T) {
+	root, overlay := setupOverlayProject(t)
+	writePlanFileWithBody(t, root, makeTestPlan("local-plan", nil, time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)))
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{format: "table"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "local-plan") || !strings.Contains(out, "[org-context] org-conventions") {
+		t.Errorf("expected local and overlay plans, got:\n%s", out)
+	}
+	if _, err := os.Stat(filepath.Join(overlay, ".logosyncx", "index.jsonl")); err == nil {
+		t.Error("overlay index must not be written")
+	}
+}
+
+func TestLS_JSONMarksOverlayOrigin(t *testing.T) {
+	setupOverlayProject(t)
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{asJSON: true, format: "table"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var entries []lsJSONEntry
+	if err := json.Unmarshal([]byte(out), &entries); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, out)
+	}
+	if len(entries) != 1 || entries[0].Origin != "org-context" {
+		t.Errorf("expected one overlay entry with origin, got %+v", entries)
+	}
+}
+
+func TestLS_ProjectPlanShadowsOverlay(t *testing.T) {
+	root, _ := setupOverlayProject(t)
+	writePlanFileWithBody(t, root, makeTestPlan("org-conventions", nil, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{format: "table"}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if strings.Contains(out, "[org-context]") {
+		t.Errorf("expected the project plan to shadow the overlay plan, got:\n%s", out)
+	}
+}
+
+func TestSearch_IncludesOverlayPlans(t *testing.T) {
+	setupOverlayProject(t)
+	out := captureOutput(t, func() {
+		if err := runSearch("conventions", "", "", ""); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "[org-context] org-conventions") {
+		t.Errorf("expected overlay plan in search results, got:\n%s", out)
+	}
+}
+
+func TestRefer_FindsOverlayPlan(t *testing.T) {
+	setupOverlayProject(t)
+	out := captureOutput(t, func() {
+		if err := runRefer("org-conventions", false, false, false, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "This is a test plan about org-conventions") {
+		t.Errorf("expected overlay plan body, got:\n%s", out)
+	}
+}
+
+func TestOverlayRoots_SkipsMissing(t *testing.T) {
+	root := t.TempDir()
+	cfg := config.Default("p")
+	cfg.Overlays = []string{"../does-not-exist"}
+	if got := overlayRoots(root, cfg); len(got) != 0 {
+		t.Errorf("expected missing overlay to be skipped, got %+v", got)
+	}
+}
@@ -0,0 +1,203 @@
+package cmd
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/jsonl"
+	"github.com/spf13/cobra"
+)
+
+// pageOptions holds the --limit, --offset, and --fields settings of logos
+// ls and task ls.
+type pageOptions struct {
+	jsonl.Page          // Limit 0 = no limit
+	fields     []string // JSON keys to output; empty = the usual output
+}
+
+// pageFlags registers --limit, --offset, and --fields on c, which lists
+// what (e.g. "plans").
+func pageFlags(c *cobra.Command, what string) {
+	c.Flags().Int("limit", 0, fmt.Sprintf("Show at most N %s (0 = no limit)", what))
+	c.Flags().Int("offset", 0, fmt.Sprintf("Skip the first N %s", what))
+	c.Flags().StringSlice("fields", nil, "Only output these fields (JSON keys, comma-separated, e.g. date,topic)")
+}
+
+// readPageFlags returns the settings of the flags registered by pageFlags.
+func readPageFlags(c *cobra.Command) (pageOptions, error) {
+	limit, _ := c.Flags().GetInt("limit")
+	offset, _ := c.Flags().GetInt("offset")
+	fields, _ := c.Flags().GetStringSlice("fields")
+	if limit < 0 {
+		return pageOptions{}, fmt.Errorf("--limit: %d must not be negative", limit)
+	}
+	if offset < 0 {
+		return pageOptions{}, fmt.Errorf("--offset: %d must not be negative", offset)
+	}
+	return pageOptions{Page: jsonl.Page{Offset: offset, Limit: limit}, fields: fields}, nil
+}
+
+// pageWindow returns page p of items, a listing of total entries in its
+// final order of which items holds at least those up to the end of p (see
+// jsonl.Page.Head). When entries were left out after the page, a stderr
+// note says how to get the next one.
+func pageWindow[T any](items []T, total int, p jsonl.Page, what string) []T {
+	start, end := p.Bounds(len(items))
+	if end < total {
+		notef("showing %s %d-%d of %d; use --offset %d for more", what, start+1, end, total, end)
+	}
+	return items[start:end]
+}
+
+// jsonFieldNames returns the JSON keys of the struct type of v, including
+// those of embedded structs, in declaration order.
+func jsonFieldNames(v any) []string {
+	var names []string
+	var walk func(t reflect.Type)
+	walk = func(t reflect.Type) {
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if f.Anonymous && name == "" && f.Type.Kind() == reflect.Struct {
+				walk(f.Type)
+				continue
+			}
+			if name != "" && name != "-" && f.IsExported() {
+				names = append(names, name)
+			}
+		}
+	}
+	walk(reflect.TypeOf(v))
+	return names
+}
+
+// checkFields reports the first of fields that is not one of valid.
+func checkFields(fields, valid []string) error {
+	for _, f := range fields {
+		if !slices.Contains(valid, f) {
+			return fmt.Errorf("--fields: unknown field %q (use %s)", f, strings.Join(valid, ", "))
+		}
+	}
+	return nil
+}
+
+// selectFields returns, for each item, the JSON values of fields in order.
+// A field an item omits is null.
+func selectFields[T any](items []T, fields []string) ([][]json.RawMessage, error) {
+	rows := make([][]json.RawMessage, len(items))
+	for i, item := range items {
+		data, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		var all map[string]json.RawMessage
+		if err := json.Unmarshal(data, &all); err != nil {
+			return nil, err
+		}
+		row := make([]json.RawMessage, len(fields))
+		for j, f := range fields {
+			if row[j] = all[f]; row[j] == nil {
+				row[j] = json.RawMessage("null")
+			}
+		}
+		rows[i] = row
+	}
+	return rows, nil
+}
+
+// printFieldsJSON writes items as a JSON array of objects holding only
+// fields, in the order given.
+func printFieldsJSON[T any](items []T, fields []string) error {
+	rows, err := selectFields(items, fields)
+	if err != nil {
+		return err
+	}
+	var compact bytes.Buffer
+	compact.WriteString("[")
+	for i, row := range rows {
+		if i > 0 {
+			compact.WriteString(",")
+		}
+		compact.WriteString("{")
+		for j, f := range fields {
+			if j > 0 {
+				compact.WriteString(",")
+			}
+			key, _ := json.Marshal(f)
+			compact.Write(key)
+			compact.WriteString(":")
+			compact.Write(row[j])
+		}
+		compact.WriteString("}")
+	}
+	compact.WriteString("]")
+	var out bytes.Buffer
+	if err := json.Indent(&out, compact.Bytes(), "", "  "); err != nil {
+		return err
+	}
+	out.WriteString("\n")
+	_, err = os.Stdout.Write(out.Bytes())
+	return err
+}
+
+// printFieldsTable writes items as a table with one column per field,
+// headed by the field name in upper case and narrowed as needed to fit the
+// terminal. Lists are joined with ", ", booleans shown as yes/no,
+// timestamps rendered in loc, and missing values as "-".
+func printFieldsTable[T any](items []T, fields []string, loc *time.Location) error {
+	rows, err := selectFields(items, fields)
+	if err != nil {
+		return err
+	}
+	headers := make([]string, len(fields))
+	shrink := make([]int, len(fields))
+	for i, f := range fields {
+		headers[i] = strings.ToUpper(f)
+		shrink[i] = i
+	}
+	t := &textTable{headers: headers, fitWidth: tableWidth(), shrink: shrink}
+	for _, row := range rows {
+		cells := make([]string, len(row))
+		for i, v := range row {
+			cells[i] = fieldCell(v, loc)
+		}
+		t.addRow(cells...)
+	}
+	return t.render(os.Stdout)
+}
+
+// fieldCell formats one JSON value for printFieldsTable.
+func fieldCell(raw json.RawMessage, loc *time.Location) string {
+	var v any
+	if json.Unmarshal(raw, &v) != nil {
+		return string(raw)
+	}
+	switch v := v.(type) {
+	case nil:
+		return "-"
+	case string:
+		if ts, err := time.Parse(time.RFC3339Nano, v); err == nil {
+			return ts.In(loc).Format("2006-01-02 15:04")
+		}
+		return dashIfEmpty(v)
+	case bool:
+		if v {
+			return "yes"
+		}
+		return "no"
+	case []any:
+		parts := make([]string, len(v))
+		for i, e := range v {
+			parts[i] = fmt.Sprint(e)
+		}
+		return dashIfEmpty(strings.Join(parts, ", "))
+	default:
+		return fmt.Sprint(v)
+	}
+}
@@ -0,0 +1,139 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/jsonl"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// setupPagedPlans creates plans p1 (oldest) to p4 (newest).
+func setupPagedPlans(t *testing.T) string {
+	t.Helper()
+	base := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	var plans []plan.Plan
+	for i, topic := range []string{"p1", "p2", "p3", "p4"} {
+		plans = append(plans, makeTestPlan(topic, []string{"t"}, base.AddDate(0, 0, i)))
+	}
+	return setupProjectWithPlans(t, plans)
+}
+
+func TestLS_LimitAndOffset(t *testing.T) {
+	setupPagedPlans(t)
+
+	var out string
+	errOut := captureStderr(t, func() {
+		out = captureOutput(t, func() {
+			if err := runLS(lsOptions{asJSON: true, page: pageOptions{Page: jsonl.Page{Limit: 2, Offset: 1}}}); err != nil {
+				t.Fatalf("runLS: %v", err)
+			}
+		})
+	})
+	var got []lsJSONEntry
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(got) != 2 || got[0].Topic != "p3" || got[1].Topic != "p2" {
+		t.Errorf("page = %+v, want p3 then p2", got)
+	}
+	if !strings.Contains(errOut, "showing plans 2-3 of 4; use --offset 3") {
+		t.Errorf("expected a next-page note on stderr, got %q", errOut)
+	}
+}
+
+func TestLS_OffsetPastTheEnd(t *testing.T) {
+	setupPagedPlans(t)
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{page: pageOptions{Page: jsonl.Page{Offset: 10}}}); err != nil {
+			t.Fatalf("runLS: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No plans found.") {
+		t.Errorf("expected no plans, got %q", out)
+	}
+
+	out = captureOutput(t, func() {
+		if err := runLS(lsOptions{asJSON: true, page: pageOptions{Page: jsonl.Page{Offset: 10}}}); err != nil {
+			t.Fatalf("runLS --json: %v", err)
+		}
+	})
+	if strings.TrimSpace(out) != "[]" {
+		t.Errorf("expected an empty JSON array, got %q", out)
+	}
+}
+
+func TestLS_FieldsJSONKeepsOrder(t *testing.T) {
+	setupPagedPlans(t)
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{asJSON: true, page: pageOptions{Page: jsonl.Page{Limit: 1}, fields: []string{"topic", "open_tasks", "tags"}}}); err != nil {
+			t.Fatalf("runLS: %v", err)
+		}
+	})
+	want := "[\n  {\n    \"topic\": \"p4\",\n    \"open_tasks\": 0,\n    \"tags\": [\n      \"t\"\n    ]\n  }\n]\n"
+	if out != want {
+		t.Errorf("output = %q, want %q", out, want)
+	}
+}
+
+func TestLS_FieldsTable(t *testing.T) {
+	setupPagedPlans(t)
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{page: pageOptions{Page: jsonl.Page{Limit: 1}, fields: []string{"date", "topic", "distilled"}}}); err != nil {
+			t.Fatalf("runLS: %v", err)
+		}
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 3 || !strings.HasPrefix(lines[0], "DATE") || !strings.Contains(lines[0], "DISTILLED") {
+		t.Fatalf("unexpected table:\n%s", out)
+	}
+	if f := strings.Fields(lines[2]); len(f) != 4 || f[0] != "2026-03-04" || f[2] != "p4" || f[3] != "no" {
+		t.Errorf("row = %q", lines[2])
+	}
+}
+
+func TestLS_UnknownField(t *testing.T) {
+	setupPagedPlans(t)
+
+	err := runLS(lsOptions{asJSON: true, page: pageOptions{fields: []string{"topic", "owner"}}})
+	if err == nil || !strings.Contains(err.Error(), `unknown field "owner"`) {
+		t.Errorf("runLS error = %v, want an unknown field error", err)
+	}
+}
+
+func TestTaskLS_LimitAndFields(t *testing.T) {
+	dir := setupInitedProject(t)
+	for _, title := range []string{"first", "second", "third"} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: title}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var out string
+	captureStderr(t, func() {
+		out = captureOutput(t, func() {
+			if err := runTaskLS(taskLSOptions{sortBy: "order", asJSON: true, page: pageOptions{Page: jsonl.Page{Limit: 2}, fields: []string{"seq", "title"}}}); err != nil {
+				t.Fatalf("runTaskLS: %v", err)
+			}
+		})
+	})
+	var got []map[string]any
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(got) != 2 || len(got[0]) != 2 || got[0]["title"] == nil || got[0]["seq"] == nil {
+		t.Errorf("tasks = %v, want two objects with only seq and title", got)
+	}
+}
+
+func TestJSONFieldNames_FollowsEmbeddedStructs(t *testing.T) {
+	names := jsonFieldNames(lsJSONEntry{})
+	if names[0] != "id" || names[len(names)-1] != "total_tasks" {
+		t.Errorf("jsonFieldNames = %v", names)
+	}
+}
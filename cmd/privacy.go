@@ -0,0 +1,67 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/privacy"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// privacyField is one piece of text a command is about to write, such as a
+// plan's topic or body.
+type privacyField struct {
+	name string
+	text *string
+}
+
+// tagFields returns one privacyField per tag, so tags are checked and
+// redacted like any other field.
+func tagFields(tags []string) []privacyField {
+	out := make([]privacyField, len(tags))
+	for i := range tags {
+		out[i] = privacyField{name: "tag", text: &tags[i]}
+	}
+	return out
+}
+
+// enforcePrivacy applies privacy.mode to fields before what (e.g. "plan")
+// is written. In warn mode each match is reported; in redact mode matches
+// are replaced in place with [REDACTED:<name>] and reported; in block mode
+// an error naming the matches is returned and nothing may be written. The
+// matched text itself is never printed.
+func enforcePrivacy(cfg config.Config, what string, fields ...privacyField) error {
+	patterns, err := privacy.Compile(cfg.Privacy)
+	if err != nil {
+		warnf("%v — pattern ignored (run `logos check`)", err)
+	}
+	if len(patterns) == 0 {
+		return nil
+	}
+
+	mode := cfg.Privacy.EffectiveMode()
+	var found []string
+	for _, f := range fields {
+		if mode == config.PrivacyRedact {
+			var names []string
+			*f.text, names = privacy.Redact(patterns, *f.text)
+			for _, name := range names {
+				warnf("redacted %s in %s %s (privacy.mode is redact)", name, what, f.name)
+			}
+			continue
+		}
+		for _, m := range privacy.Find(patterns, *f.text) {
+			found = append(found, fmt.Sprintf("%s %s matches %s", what, f.name, m.Name))
+		}
+	}
+	if len(found) == 0 {
+		return nil
+	}
+	if mode == config.PrivacyBlock {
+		return fmt.Errorf("refusing to write the %s (privacy.mode is block): %s", what, strings.Join(found, "; "))
+	}
+	for _, msg := range found {
+		warnf("%s — remove it, or set privacy.mode to redact or block", msg)
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/registry"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var projectsCmd = &cobra.Command{
+	Use:     "projects",
+	Aliases: []string{"project"},
+	Short:   "Manage the per-user registry of logos projects",
+	Long: `Every project created with logos init is recorded in a per-user registry
+(<user config dir>/logosyncx/projects.json, e.g. ~/.config/logosyncx/ on
+Linux), so that logos ls --project <name> can list another project's plans
+without changing directory — handy in a monorepo sibling or a second
+checkout.
+
+A project is registered under its config "project" name; when another
+project already has that name, "-2", "-3", ... is appended.`,
+}
+
+var projectsLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List registered projects",
+	Long: `List registered projects by name. Projects whose directory no longer holds
+.logosyncx/ are marked missing; remove them with logos projects rm.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		return runProjectsLs(asJSON)
+	},
+}
+
+var projectsAddCmd = &cobra.Command{
+	Use:   "add [dir]",
+	Short: "Register an existing project",
+	Long: `Register the project containing dir (default: the current directory),
+following git worktrees and its storage pointer the same way other
+commands do. Use this for projects initialized before the registry existed.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		dir := "."
+		if len(args) == 1 {
+			dir = args[0]
+		}
+		return runProjectsAdd(dir)
+	},
+}
+
+var projectsRmCmd = &cobra.Command{
+	Use:   "rm <name>",
+	Short: "Remove a project from the registry",
+	Long:  `Remove a project from the registry. Its files are not touched.`,
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runProjectsRm(args[0])
+	},
+}
+
+var projectsRenameCmd = &cobra.Command{
+	Use:   "rename <name>",
+	Short: "Rename the current project",
+	Long: `Set the config "project" name of the current project and carry it through:
+the registry entry on this machine is renamed (with "-2", ... appended when
+another project already has the name), and the agent context file
+(context_file) is regenerated with the new name in its heading.
+
+Other machines keep their own registry: after pulling the config change,
+run logos project rename <name> there too to rename their entry.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runProjectsRename(args[0])
+	},
+}
+
+func init() {
+	projectsLsCmd.Flags().Bool("json", false, "Output JSON (for agent consumption)")
+	projectsCmd.AddCommand(projectsLsCmd, projectsAddCmd, projectsRmCmd, projectsRenameCmd)
+	rootCmd.AddCommand(projectsCmd)
+}
+
+// projectJSON is one project in the logos projects ls --json output.
+type projectJSON struct {
+	registry.Project
+	Missing bool `json:"missing,omitempty"`
+}
+
+func runProjectsLs(asJSON bool) error {
+	projects, err := registry.Load()
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		out := make([]projectJSON, 0, len(projects))
+		for _, p := range projects {
+			out = append(out, projectJSON{Project: p, Missing: !p.Exists()})
+		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+	if len(projects) == 0 {
+		fmt.Println("No projects registered.")
+		printHint("Run `logos projects add` in a project to register it.")
+		return nil
+	}
+	t := &textTable{headers: []string{"NAME", "ROOT", "STATUS"}}
+	for _, p := range projects {
+		status := "ok"
+		if !p.Exists() {
+			status = "missing"
+		}
+		t.addRow(p.Name, p.Root, status)
+	}
+	return t.render(os.Stdout)
+}
+
+func runProjectsAdd(dir string) error {
+	abs, err := filepath.Abs(dir)
+	if err != nil {
+		return err
+	}
+	root, err := project.ResolveWorktree(abs)
+	if err != nil {
+		return err
+	}
+	if root, err = project.ResolveStorage(root); err != nil {
+		return err
+	}
+	p, err := registry.Register(projectName(root), root)
+	if err != nil {
+		return err
+	}
+	printSuccess("Registered %s as %s", p.Root, p.Name)
+	return nil
+}
+
+func runProjectsRm(name string) error {
+	removed, err := registry.Remove(name)
+	if err != nil {
+		return err
+	}
+	if !removed {
+		return fmt.Errorf("no registered project named %q (see `logos projects ls`)", name)
+	}
+	printSuccess("Removed %s from the registry", name)
+	return nil
+}
+
+func runProjectsRename(name string) error {
+	name = strings.TrimSpace(name)
+	if name == "" || strings.ContainsAny(name, "\n\r") {
+		return fmt.Errorf("invalid project name %q", name)
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	oldName := cfg.Project
+	if oldName != name {
+		if err := config.SetProject(root, name); err != nil {
+			return fmt.Errorf("update config: %w", err)
+		}
+		cfg.Project = name
+		stageFiles(root, cfg, config.ConfigPath(root))
+		if cfg.ContextFile != "" {
+			refreshContextFile(root, cfg)
+			stageFiles(root, cfg, filepath.Join(root, cfg.ContextFile))
+		}
+		printSuccess("Renamed project %q → %q", oldName, name)
+	} else {
+		fmt.Printf("The project is already named %q.\n", name)
+	}
+
+	p, oldEntry, err := registry.Rename(root, name)
+	if err != nil {
+		warnf("could not update the project registry: %v", err)
+		return nil
+	}
+	if oldEntry != p.Name {
+		printSuccess("Registry entry %s → %s", cmp.Or(oldEntry, "(none)"), p.Name)
+	}
+
+	if oldName != name {
+		fmt.Println("\nThings that still use the old name:")
+		fmt.Printf("  - logos ls --project %s on other machines: run `logos project rename %s` there after pulling\n", cmp.Or(oldEntry, oldName), name)
+		fmt.Println("  - scripts or tools that read the project name from --json output or --warnings-json lines")
+		fmt.Println("  - bundles exported before the rename keep the old name in their manifest and filename")
+		if !cfg.Git.Stages() {
+			printHint("Commit .logosyncx/config.json to share the new name.")
+		}
+	}
+	return nil
+}
+
+// projectName is the name root is registered under: its config "project"
+// name, or the directory name when the config cannot be read.
+func projectName(root string) string {
+	cfg, err := loadConfig(root)
+	if err != nil || cfg.Project == "" {
+		return filepath.Base(root)
+	}
+	return cfg.Project
+}
+
+// registerProject records root in the project registry. Failure is only a
+// warning: the registry is a convenience and must never fail logos init.
+func registerProject(root string) {
+	if _, err := registry.Register(projectName(root), root); err != nil {
+		warnf("could not add the project to the registry: %v", err)
+	}
+}
+
+// registeredRoot returns the root of the registered project called name,
+// following its storage pointer.
+func registeredRoot(name string) (string, error) {
+	p, err := registry.Find(name)
+	if err != nil {
+		return "", err
+	}
+	if !p.Exists() {
+		return "", fmt.Errorf("project %s: %s no longer holds .logosyncx/ (run `logos projects rm %s`)", name, p.Root, name)
+	}
+	return project.ResolveStorage(p.Root)
+}
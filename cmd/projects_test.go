@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senna-lang/logosyncx/internal/registry"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// useTempRegistry gives the test its own empty project registry.
+func useTempRegistry(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+}
+
+func TestInit_RegistersProject(t *testing.T) {
+	useTempRegistry(t)
+	dir := setupInitedProject(t)
+
+	p, err := registry.Find(filepath.Base(dir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Root != dir {
+		t.Errorf("registered root = %q, want %q", p.Root, dir)
+	}
+}
+
+func TestLSProject_ListsAnotherProject(t *testing.T) {
+	useTempRegistry(t)
+	other := setupInitedProject(t)
+	if err := runSave("Cache design", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	setupInitedProject(t) // cd into a second, empty project
+
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{project: filepath.Base(other), asJSON: true}); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "Cache design") {
+		t.Errorf("ls --project output missing the other project's plan:\n%s", out)
+	}
+
+	if err := runLS(lsOptions{project: "nope", asJSON: true}); err == nil || !strings.Contains(err.Error(), "projects ls") {
+		t.Errorf("unknown project: err = %v", err)
+	}
+}
+
+func TestLSProject_MissingRoot(t *testing.T) {
+	useTempRegistry(t)
+	gone := t.TempDir()
+	if _, err := registry.Register("gone", gone); err != nil {
+		t.Fatal(err)
+	}
+	err := runLS(lsOptions{project: "gone", asJSON: true})
+	if err == nil || !strings.Contains(err.Error(), "logos projects rm gone") {
+		t.Errorf("err = %v, want a hint to remove the project", err)
+	}
+}
+
+func TestProjectsLs_JSONMarksMissing(t *testing.T) {
+	useTempRegistry(t)
+	dir := setupInitedProject(t)
+	if _, err := registry.Register("gone", t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runProjectsLs(true); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var got []projectJSON
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON: %v\n%s", err, out)
+	}
+	if len(got) != 2 {
+		t.Fatalf("got %d projects, want 2: %s", len(got), out)
+	}
+	for _, p := range got {
+		wantMissing := p.Root != dir
+		if p.Missing != wantMissing {
+			t.Errorf("%s: missing = %v, want %v", p.Name, p.Missing, wantMissing)
+		}
+	}
+}
+
+func TestProjectsAddAndRm(t *testing.T) {
+	useTempRegistry(t)
+	dir := setupInitedProject(t)
+	if err := runProjectsRm(filepath.Base(dir)); err != nil {
+		t.Fatal(err)
+	}
+	if projects, _ := registry.Load(); len(projects) != 0 {
+		t.Fatalf("projects after rm = %v", projects)
+	}
+	if err := runProjectsRm(filepath.Base(dir)); err == nil {
+		t.Error("removing an unregistered project should fail")
+	}
+
+	if err := os.MkdirAll(filepath.Join(dir, "sub"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := runProjectsAdd(filepath.Join(dir, "sub")); err != nil {
+		t.Fatal(err)
+	}
+	if p, err := registry.Find(filepath.Base(dir)); err != nil || p.Root != dir {
+		t.Errorf("Find after add = %+v, %v", p, err)
+	}
+}
+
+func TestProjectsRename(t *testing.T) {
+	useTempRegistry(t)
+	dir := setupInitedProject(t)
+	cfg, _ := config.Load(dir)
+	cfg.ContextFile = ".claude/context.md"
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runProjectsRename("billing-api"); err != nil {
+			t.Fatalf("runProjectsRename: %v", err)
+		}
+	})
+	if !strings.Contains(out, "logos project rename billing-api") {
+		t.Errorf("expected guidance for other machines, got:\n%s", out)
+	}
+
+	cfg, _ = config.Load(dir)
+	if cfg.Project != "billing-api" {
+		t.Errorf("config project = %q", cfg.Project)
+	}
+	if p, err := registry.Find("billing-api"); err != nil || p.Root != dir {
+		t.Errorf("registry entry = %+v, %v", p, err)
+	}
+	if _, err := registry.Find(filepath.Base(dir)); err == nil {
+		t.Error("the old registry name is still present")
+	}
+	data, _ := os.ReadFile(filepath.Join(dir, ".claude", "context.md"))
+	if !strings.Contains(string(data), "\n# billing-api context\n") {
+		t.Errorf("context file was not regenerated:\n%s", data)
+	}
+
+	out = captureOutput(t, func() {
+		if err := runProjectsRename("billing-api"); err != nil {
+			t.Fatalf("runProjectsRename again: %v", err)
+		}
+	})
+	if !strings.Contains(out, "already named") {
+		t.Errorf("renaming to the current name = %q", out)
+	}
+	if err := runProjectsRename("  "); err == nil {
+		t.Error("an empty name should be rejected")
+	}
+}
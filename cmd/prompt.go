@@ -0,0 +1,53 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/senna-lang/logosyncx/internal/prompt"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// assumeYes, when true, answers every confirmation prompt with "yes". It is
+// set from the global --yes flag (see rootCmd.PersistentPreRunE).
+var assumeYes bool
+
+// confirmPrompter asks confirmation questions. Tests replace it with a
+// prompt.New prompter reading scripted answers.
+var confirmPrompter = prompt.Stdin()
+
+// confirm asks question before a destructive action and reports whether to
+// proceed. skip is the command's own opt-out (e.g. --force on task delete);
+// it and the global --yes both answer yes without prompting. An empty reply
+// selects prompts.default from cfg. When nobody can answer — stdin is not a
+// terminal, as for agents and CI — confirm returns an error naming the flags
+// that skip the prompt instead of blocking on stdin. skipFlag names the
+// command's opt-out flag for that message ("" when the command has none).
+func confirm(cfg config.Config, question string, skip bool, skipFlag string) (bool, error) {
+	if skip || assumeYes {
+		return true, nil
+	}
+	ok, err := confirmPrompter.Confirm(question, cfg.Prompts.DefaultYes())
+	if errors.Is(err, prompt.ErrNotInteractive) {
+		flags := "--yes"
+		if skipFlag != "" {
+			flags = skipFlag + " or --yes"
+		}
+		return false, fmt.Errorf("%s\n%v, so the prompt cannot be answered — re-run with %s to confirm", question, err, flags)
+	}
+	return ok, err
+}
+
+// offer asks an optional yes/no question, such as a follow-up step after a
+// command has already done its work. Unlike confirm it never fails: skip and
+// --yes answer yes, and without a terminal the answer is no.
+func offer(cfg config.Config, question string, skip bool) bool {
+	if skip || assumeYes {
+		return true
+	}
+	if !confirmPrompter.Interactive() {
+		return false
+	}
+	ok, _ := confirmPrompter.Confirm(question, cfg.Prompts.DefaultYes())
+	return ok
+}
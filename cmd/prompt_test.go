@@ -0,0 +1,100 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/senna-lang/logosyncx/internal/prompt"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// usePrompter makes confirm read answers from input for the test; an
+// interactive=false prompter behaves like stdin without a terminal.
+func usePrompter(t *testing.T, input string, interactive bool) *strings.Builder {
+	t.Helper()
+	var out strings.Builder
+	orig := confirmPrompter
+	confirmPrompter = prompt.New(strings.NewReader(input), &out, interactive)
+	t.Cleanup(func() { confirmPrompter = orig })
+	return &out
+}
+
+func TestConfirm_NonInteractiveFails(t *testing.T) {
+	out := usePrompter(t, "y\n", false)
+	ok, err := confirm(config.Config{}, "Delete it?", false, "--force")
+	if ok || err == nil {
+		t.Fatalf("expected error without a terminal, got ok=%v err=%v", ok, err)
+	}
+	if !strings.Contains(err.Error(), "--force or --yes") {
+		t.Errorf("expected flag guidance, got: %v", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("must not prompt without a terminal, printed %q", out.String())
+	}
+}
+
+func TestConfirm_SkipAndYes(t *testing.T) {
+	usePrompter(t, "", false)
+	if ok, err := confirm(config.Config{}, "Delete it?", true, "--force"); !ok || err != nil {
+		t.Errorf("skip: got ok=%v err=%v", ok, err)
+	}
+	assumeYes = true
+	t.Cleanup(func() { assumeYes = false })
+	if ok, err := confirm(config.Config{}, "Delete it?", false, ""); !ok || err != nil {
+		t.Errorf("--yes: got ok=%v err=%v", ok, err)
+	}
+}
+
+func TestConfirm_ConfigDefault(t *testing.T) {
+	cfg := config.Config{Prompts: config.PromptsConfig{Default: "yes"}}
+	out := usePrompter(t, "\n", true)
+	if ok, err := confirm(cfg, "Delete it?", false, ""); !ok || err != nil {
+		t.Errorf("empty reply with prompts.default=yes: got ok=%v err=%v", ok, err)
+	}
+	if !strings.Contains(out.String(), "[Y/n]") {
+		t.Errorf("expected [Y/n] hint, got %q", out.String())
+	}
+
+	usePrompter(t, "\n", true)
+	if ok, _ := confirm(config.Config{}, "Delete it?", false, ""); ok {
+		t.Error("empty reply must decline by default")
+	}
+}
+
+func TestTaskDelete_PromptDeclined(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Keep me task", priority: "medium"}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	asked := usePrompter(t, "n\n", true)
+
+	out := captureOutput(t, func() {
+		if err := runTaskDelete("", "keep-me", false); err != nil {
+			t.Fatalf("runTaskDelete: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Aborted.") {
+		t.Errorf("expected abort message, got: %q", out)
+	}
+	if !strings.Contains(asked.String(), "Keep me task") {
+		t.Errorf("unexpected prompt: %q", asked.String())
+	}
+	if got := loadAllTasks(t, dir); len(got) != 1 {
+		t.Errorf("expected task to remain, got %d tasks", len(got))
+	}
+}
+
+func TestTaskDelete_NonInteractiveWithoutForce(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Agent task", priority: "medium"}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	usePrompter(t, "", false)
+
+	if err := runTaskDelete("", "agent-task", false); err == nil {
+		t.Fatal("expected error when prompting without a terminal")
+	}
+	if got := loadAllTasks(t, dir); len(got) != 1 {
+		t.Errorf("expected task to remain, got %d tasks", len(got))
+	}
+}
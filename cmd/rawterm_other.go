@@ -0,0 +1,14 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+package cmd
+
+import (
+	"errors"
+	"os"
+)
+
+// makeRaw fails: raw terminal mode is only implemented for Linux and the
+// BSDs (including macOS).
+func makeRaw(f *os.File) (restore func(), err error) {
+	return nil, errors.New("logos tui is not supported on this platform")
+}
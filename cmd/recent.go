@@ -0,0 +1,80 @@
+package cmd
+
+import (
+	"cmp"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/recent"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// noteReferral records that the item of kind named name was referred to,
+// for ranking (see internal/recent). It is best-effort: failing to record
+// never fails the command.
+func noteReferral(root, kind, name string) {
+	_ = recent.Record(root, kind, name, time.Now())
+}
+
+// taskRefName returns the name a task is recorded under in internal/recent.
+func taskRefName(plan, dirPath string) string {
+	return plan + "/" + filepath.Base(dirPath)
+}
+
+// boostPlanEntries moves the plans referred to most often and most recently
+// to the front of entries, keeping the existing order among plans with the
+// same score. Overlay plans are never boosted.
+func boostPlanEntries(root string, entries []index.Entry, now time.Time) {
+	records, _ := recent.Load(root)
+	scores := recent.Scores(records, recent.KindPlan, now)
+	if len(scores) == 0 {
+		return
+	}
+	score := func(e index.Entry) float64 {
+		if e.Origin != "" {
+			return 0
+		}
+		return scores[e.Filename]
+	}
+	slices.SortStableFunc(entries, func(a, b index.Entry) int { return cmp.Compare(score(b), score(a)) })
+}
+
+// frequentPlanCount is the number of plans listed under "Frequently
+// referred plans" in the agent context file.
+const frequentPlanCount = 5
+
+// frequentPlans returns up to frequentPlanCount of plans, excluding pinned
+// ones, ranked by how often and how recently they were referred to at now.
+// Plans never referred to are left out.
+func frequentPlans(root string, plans []plan.Plan, now time.Time) []plan.Plan {
+	records, _ := recent.Load(root)
+	byName := map[string]plan.Plan{}
+	for _, p := range plans {
+		byName[p.Filename] = p
+	}
+	var out []plan.Plan
+	for _, r := range recent.Ranked(records, now) {
+		p, ok := byName[r.Name]
+		if r.Kind != recent.KindPlan || !ok || p.Pinned {
+			continue
+		}
+		if out = append(out, p); len(out) == frequentPlanCount {
+			break
+		}
+	}
+	return out
+}
+
+// boostTasks is boostPlanEntries for tasks.
+func boostTasks(root string, entries []task.TaskJSON, now time.Time) {
+	records, _ := recent.Load(root)
+	scores := recent.Scores(records, recent.KindTask, now)
+	if len(scores) == 0 {
+		return
+	}
+	score := func(t task.TaskJSON) float64 { return scores[taskRefName(t.Plan, t.DirPath)] }
+	slices.SortStableFunc(entries, func(a, b task.TaskJSON) int { return cmp.Compare(score(b), score(a)) })
+}
@@ -0,0 +1,97 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// setupReferredPlans creates an older and a newer plan matching "auth" and
+// refers to the older one.
+func setupReferredPlans(t *testing.T) string {
+	t.Helper()
+	day := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	dir := setupProjectWithPlans(t, []plan.Plan{
+		makeSearchPlan("id1", "auth-legacy", nil, "Old auth notes.", day),
+		makeSearchPlan("id2", "auth-jwt", nil, "New auth notes.", day.AddDate(0, 0, 1)),
+	})
+	captureOutput(t, func() {
+		if err := runRefer("auth-legacy", false, false, false, false); err != nil {
+			t.Fatalf("runRefer: %v", err)
+		}
+	})
+	return dir
+}
+
+func TestSearch_ReferredPlansFirst(t *testing.T) {
+	setupReferredPlans(t)
+
+	out := captureOutput(t, func() {
+		if err := runSearch("auth", "", "", ""); err != nil {
+			t.Fatalf("runSearch: %v", err)
+		}
+	})
+	legacy, jwt := strings.Index(out, "auth-legacy"), strings.Index(out, "auth-jwt")
+	if legacy < 0 || jwt < 0 || legacy > jwt {
+		t.Errorf("expected the referred plan first, got:\n%s", out)
+	}
+}
+
+func TestTaskSearch_ReferredTasksFirst(t *testing.T) {
+	dir := setupInitedProject(t)
+	for _, title := range []string{"Fix login", "Fix logout"} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: title}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	captureOutput(t, func() {
+		if err := runTaskRefer("logout", "", false, false); err != nil {
+			t.Fatalf("runTaskRefer: %v", err)
+		}
+	})
+
+	out := captureOutput(t, func() {
+		if err := runTaskSearch("fix", "", "", "", ""); err != nil {
+			t.Fatalf("runTaskSearch: %v", err)
+		}
+	})
+	logout, login := strings.Index(out, "Fix logout"), strings.Index(out, "Fix login")
+	if logout < 0 || login < 0 || logout > login {
+		t.Errorf("expected the referred task first, got:\n%s", out)
+	}
+}
+
+func TestStats_MostUsed(t *testing.T) {
+	setupReferredPlans(t)
+
+	out := captureOutput(t, func() {
+		if err := runMostUsed(true, time.Now()); err != nil {
+			t.Fatalf("runMostUsed: %v", err)
+		}
+	})
+	var got []mostUsedJSON
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(got) != 1 || got[0].Kind != "plan" || !strings.HasSuffix(got[0].Name, "-auth-legacy.md") || got[0].Count != 1 {
+		t.Errorf("most used = %+v, want the referred plan once", got)
+	}
+}
+
+func TestRenderContext_FrequentlyReferredPlans(t *testing.T) {
+	dir := setupReferredPlans(t)
+	cfg, _ := config.Load(dir)
+
+	out := renderContext(dir, cfg, time.Now())
+	section, rest, ok := strings.Cut(out, "## Frequently referred plans\n")
+	if !ok || !strings.Contains(rest, "- auth-legacy (`") || strings.Contains(section, "auth-legacy") {
+		t.Errorf("expected auth-legacy under Frequently referred plans, got:\n%s", out)
+	}
+	if strings.Contains(strings.Split(rest, "##")[0], "auth-jwt") {
+		t.Errorf("did not expect an unreferred plan in the section, got:\n%s", out)
+	}
+}
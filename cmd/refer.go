@@ -2,12 +2,20 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 
+	"github.com/senna-lang/logosyncx/internal/markdown"
 	"github.com/senna-lang/logosyncx/internal/project"
-	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/internal/recent"
+	"github.com/senna-lang/logosyncx/internal/resolve"
+	"github.com/senna-lang/logosyncx/internal/task"
 	"github.com/senna-lang/logosyncx/pkg/plan"
 	"github.com/spf13/cobra"
 )
@@ -22,24 +30,56 @@ Use --summary to return only the sections listed in config's summary_sections,
 saving tokens when the command is used by agents.
 
 If multiple plans match the given name, a candidate list is printed and
-the command exits with an error so the caller knows to narrow the search.`,
+the command exits with an error so the caller knows to narrow the search.
+
+Plans from the read-only overlay roots in config "overlays" are matched too;
+the project's own plan wins when both have the same filename.
+
+Use --week YYYY-Www instead of --name to print the weekly journal created by
+logos journal for that ISO week.
+
+Use --with-tasks to append a table of the tasks linked to the plan: tasks in
+its plan group, tasks whose related_plans list it, and tasks in its
+related_tasks. The table is read from the task index.
+
+Use --outline to print only the plan's section headings with their sizes
+(bytes, words, and an estimated token count), so an agent can decide which
+sections are worth fetching. --json prints the plan as one JSON object with
+the same per-section sizes under "sections" (and its content under "body",
+omitted with --outline).`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name, _ := cmd.Flags().GetString("name")
+		week, _ := cmd.Flags().GetString("week")
 		summaryOnly, _ := cmd.Flags().GetBool("summary")
-		return runRefer(name, summaryOnly)
+		withTasks, _ := cmd.Flags().GetBool("with-tasks")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		outline, _ := cmd.Flags().GetBool("outline")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		if week != "" {
+			return runReferWeek(week, summaryOnly, withTasks, asJSON, outline)
+		}
+		return runRefer(name, summaryOnly, withTasks, asJSON, outline)
 	},
 }
 
 func init() {
 	referCmd.Flags().StringP("name", "n", "", "Plan name to look up (exact or partial match against filename, topic, or ID)")
-	_ = referCmd.MarkFlagRequired("name")
+	referCmd.Flags().String("week", "", "Print the journal for an ISO week (e.g. 2025-W12)")
+	referCmd.MarkFlagsOneRequired("name", "week")
+	referCmd.MarkFlagsMutuallyExclusive("name", "week")
 	referCmd.Flags().Bool("summary", false, "Return only summary_sections from config (saves tokens)")
+	referCmd.Flags().Bool("with-tasks", false, "Append a table of the tasks linked to the plan")
+	referCmd.Flags().Bool("outline", false, "Print only the section headings with their sizes")
+	referCmd.Flags().Bool("json", false, "Output the plan as JSON with per-section sizes (for agent consumption)")
+	referCmd.MarkFlagsMutuallyExclusive("summary", "outline")
 	rootCmd.AddCommand(referCmd)
 }
 
 // runRefer is the testable core of the refer command.
-func runRefer(name string, summaryOnly bool) error {
+func runRefer(name string, summaryOnly, withTasks, asJSON, outline bool) error {
 	root, err := project.FindRoot()
 	if err != nil {
 		return err
@@ -48,7 +88,11 @@ func runRefer(name string, summaryOnly bool) error {
 	plans, err := plan.LoadAll(root)
 	if err != nil {
 		// Non-fatal parse errors: warn but continue with what we have.
-		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		warnf("%v", err)
+	}
+	var origins map[string]string
+	if cfg, err := loadConfig(root); err == nil {
+		plans, origins = addOverlayPlans(root, cfg, plans)
 	}
 
 	matches := matchPlans(plans, name)
@@ -57,45 +101,142 @@ func runRefer(name string, summaryOnly bool) error {
 	case 0:
 		return fmt.Errorf("no plan found matching %q", name)
 	case 1:
-		return printRefer(matches[0], summaryOnly, root)
+		origin := origins[matches[0].Filename]
+		if origin != "" && !asJSON {
+			fmt.Fprintf(os.Stderr, "(from overlay %s — read-only)\n", origin)
+		}
+		return showPlan(root, matches[0], origin, summaryOnly, withTasks, asJSON, outline)
 	default:
-		return printPlanCandidates(matches, name)
+		return printPlanCandidates(matches, name, origins)
 	}
 }
 
-// matchPlans returns all plans whose filename stem, topic, or ID contains name
-// (case-insensitive). A single exact match on any of those three fields is
-// returned alone, bypassing any partial matches.
-func matchPlans(plans []plan.Plan, name string) []plan.Plan {
-	lower := strings.ToLower(name)
+// runReferWeek prints the journal plan for the ISO week given as "YYYY-Www".
+func runReferWeek(weekStr string, summaryOnly, withTasks, asJSON, outline bool) error {
+	week, err := plan.ParseISOWeek(weekStr)
+	if err != nil {
+		return err
+	}
 
-	var exact, partial []plan.Plan
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
 
-	for _, p := range plans {
-		stem := strings.TrimSuffix(p.Filename, ".md")
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	p, ok := plan.FindJournal(plans, week)
+	if !ok {
+		return fmt.Errorf("no journal found for week %s", week)
+	}
+	return showPlan(root, p, "", summaryOnly, withTasks, asJSON, outline)
+}
 
-		// Exact match (case-insensitive) on stem, topic, or ID.
-		if strings.EqualFold(stem, name) ||
-			strings.EqualFold(p.Topic, name) ||
-			strings.EqualFold(p.ID, name) {
-			exact = append(exact, p)
-			continue
-		}
+// referJSON is the --json output of logos refer. Body holds the plan body,
+// or only its summary sections with --summary, and is omitted with
+// --outline; Tasks is only set with --with-tasks.
+type referJSON struct {
+	Filename string                 `json:"filename"`
+	ID       string                 `json:"id"`
+	Topic    string                 `json:"topic"`
+	Date     *time.Time             `json:"date"`
+	Tags     []string               `json:"tags"`
+	Origin   string                 `json:"origin,omitempty"`
+	Sections []markdown.SectionSize `json:"sections"`
+	Body     string                 `json:"body,omitempty"`
+	Tasks    []task.TaskJSON        `json:"tasks,omitempty"`
+}
 
-		// Partial / substring match.
-		if strings.Contains(strings.ToLower(stem), lower) ||
-			strings.Contains(strings.ToLower(p.Topic), lower) ||
-			strings.Contains(strings.ToLower(p.ID), lower) {
-			partial = append(partial, p)
+// showPlan prints p in the mode selected by the refer flags. origin is the
+// overlay p was read from, if any; referrals of the project's own plans are
+// recorded for ranking.
+func showPlan(root string, p plan.Plan, origin string, summaryOnly, withTasks, asJSON, outline bool) error {
+	if origin == "" {
+		noteReferral(root, recent.KindPlan, p.Filename)
+	}
+	if asJSON {
+		out := referJSON{
+			Filename: p.Filename,
+			ID:       p.ID,
+			Topic:    p.Topic,
+			Date:     p.Date,
+			Tags:     p.Tags,
+			Origin:   origin,
+			Sections: markdown.Outline(p.Body),
+		}
+		if out.Tags == nil {
+			out.Tags = []string{}
+		}
+		if out.Sections == nil {
+			out.Sections = []markdown.SectionSize{}
+		}
+		switch {
+		case outline:
+		case summaryOnly:
+			cfg, err := loadConfig(root)
+			if err != nil {
+				return fmt.Errorf("load config: %w", err)
+			}
+			out.Body = plan.ExtractSections(p.Body, cfg.Plans.SummarySections)
+		default:
+			out.Body = p.Body
+		}
+		if withTasks {
+			tasks, err := linkedTasks(root, p)
+			if err != nil {
+				return err
+			}
+			out.Tasks = tasks
 		}
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
 	}
 
-	// If there is exactly one exact match, prefer it over everything else.
-	if len(exact) == 1 {
-		return exact
+	var err error
+	if outline {
+		err = printOutline(p.Body)
+	} else {
+		err = printRefer(p, summaryOnly, root)
 	}
+	if err != nil {
+		return err
+	}
+	if withTasks {
+		return printLinkedTasks(root, p)
+	}
+	return nil
+}
 
-	return append(exact, partial...)
+// printOutline writes the section headings of body with their sizes.
+func printOutline(body string) error {
+	sections := markdown.Outline(body)
+	if len(sections) == 0 {
+		fmt.Println("No sections found.")
+		return nil
+	}
+	t := &textTable{headers: []string{"SECTION", "BYTES", "WORDS", "TOKENS"}}
+	for _, sec := range sections {
+		t.addRow(strings.Repeat("#", sec.Level)+" "+sec.Heading,
+			strconv.Itoa(sec.Bytes), strconv.Itoa(sec.Words), "~"+strconv.Itoa(sec.Tokens))
+	}
+	return t.render(os.Stdout)
+}
+
+// matchPlans returns the plans name refers to (see resolve.Match): an
+// exact, case-insensitive filename stem or topic wins, then an exact ID,
+// then a substring of any of the three.
+func matchPlans(plans []plan.Plan, name string) []plan.Plan {
+	return resolve.Match(plans, name, func(p plan.Plan) resolve.Keys {
+		stem := strings.TrimSuffix(p.Filename, ".md")
+		return resolve.Keys{
+			Names:   []string{stem, p.Topic},
+			ID:      p.ID,
+			Partial: []string{stem, p.Topic, p.ID},
+		}
+	})
 }
 
 // printRefer writes the plan content to stdout.
@@ -103,13 +244,13 @@ func matchPlans(plans []plan.Plan, name string) []plan.Plan {
 // are printed; otherwise the full plan (frontmatter + body) is printed.
 func printRefer(p plan.Plan, summaryOnly bool, root string) error {
 	if summaryOnly {
-		cfg, err := config.Load(root)
+		cfg, err := loadConfig(root)
 		if err != nil {
 			return fmt.Errorf("load config: %w", err)
 		}
 		out := plan.ExtractSections(p.Body, cfg.Plans.SummarySections)
 		if out == "" {
-			fmt.Fprintln(os.Stderr, "warning: no matching summary sections found in this plan")
+			warnf("no matching summary sections found in this plan")
 		}
 		fmt.Println(out)
 		return nil
@@ -126,12 +267,60 @@ func printRefer(p plan.Plan, summaryOnly bool, root string) error {
 	return err
 }
 
+// linkedTasks returns the tasks in the task index linked to p: tasks in its
+// plan group, tasks listing it in related_plans, and its related_tasks,
+// ordered like logos task ls.
+func linkedTasks(root string, p plan.Plan) ([]task.TaskJSON, error) {
+	entries, err := task.ReadAllTaskIndex(root)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("read task index: %w", err)
+	}
+	slug := strings.TrimSuffix(p.Filename, ".md")
+	var linked []task.TaskJSON
+	for _, e := range entries {
+		if e.Plan == slug || slices.Contains(e.RelatedPlans, p.Filename) || slices.Contains(p.RelatedTasks, e.ID) {
+			linked = append(linked, e)
+		}
+	}
+	task.SortJSONByOrder(linked)
+	return linked, nil
+}
+
+// printLinkedTasks appends a compact table (title, status, priority) of
+// the tasks linked to p.
+func printLinkedTasks(root string, p plan.Plan) error {
+	linked, err := linkedTasks(root, p)
+	if err != nil {
+		return err
+	}
+	if len(linked) == 0 {
+		fmt.Println("\nLinked tasks: none")
+		return nil
+	}
+
+	fmt.Printf("\nLinked tasks (%d):\n", len(linked))
+	t := &textTable{
+		headers:  []string{"TITLE", "STATUS", "PRIORITY"},
+		fitWidth: tableWidth(),
+		shrink:   []int{0},
+	}
+	for _, e := range linked {
+		t.addRow(e.Title, string(e.Status), string(e.Priority))
+	}
+	return t.render(os.Stdout)
+}
+
 // printPlanCandidates writes a numbered list of matching plans to stderr and
-// returns an error telling the caller to narrow the search.
-func printPlanCandidates(plans []plan.Plan, name string) error {
+// returns an error telling the caller to narrow the search. Overlay plans,
+// found in origins by filename, are prefixed with "[<overlay>]".
+func printPlanCandidates(plans []plan.Plan, name string, origins map[string]string) error {
 	fmt.Fprintf(os.Stderr, "Multiple plans match %q:\n\n", name)
 	for i, p := range plans {
-		fmt.Fprintf(os.Stderr, "  %d. %s  (topic: %s)\n", i+1, p.Filename, p.Topic)
+		label := p.Filename
+		if origin := origins[p.Filename]; origin != "" {
+			label = "[" + origin + "] " + label
+		}
+		fmt.Fprintf(os.Stderr, "  %d. %s  (topic: %s)\n", i+1, label, p.Topic)
 	}
 	fmt.Fprintln(os.Stderr)
 	return fmt.Errorf("use a more specific name to select one plan")
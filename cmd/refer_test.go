@@ -1,6 +1,7 @@
 package cmd
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
@@ -37,7 +38,7 @@ func makeReferPlan(id, topic string, tags []string, date time.Time) plan.Plan {
 func TestRefer_NoPlans_ReturnsError(t *testing.T) {
 	setupInitedProject(t)
 
-	err := runRefer("anything", false)
+	err := runRefer("anything", false, false, false, false)
 	if err == nil {
 		t.Fatal("expected error when no plans exist, got nil")
 	}
@@ -52,7 +53,7 @@ func TestRefer_NoMatch_ReturnsError(t *testing.T) {
 	p := makeReferPlan("abc123", "auth-refactor", []string{"auth"}, time.Now())
 	setupProjectWithPlan(t, p)
 
-	err := runRefer("completely-unrelated", false)
+	err := runRefer("completely-unrelated", false, false, false, false)
 	if err == nil {
 		t.Fatal("expected error for non-matching name, got nil")
 	}
@@ -65,7 +66,7 @@ func TestRefer_NoMatch_ErrorContainsName(t *testing.T) {
 	p := makeReferPlan("abc123", "auth-refactor", []string{}, time.Now())
 	setupProjectWithPlan(t, p)
 
-	err := runRefer("xyz-unknown", false)
+	err := runRefer("xyz-unknown", false, false, false, false)
 	if err == nil {
 		t.Fatal("expected error, got nil")
 	}
@@ -81,7 +82,7 @@ func TestRefer_ExactTopicMatch_PrintsContent(t *testing.T) {
 	setupProjectWithPlan(t, p)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("auth-refactor", false); err != nil {
+		if err := runRefer("auth-refactor", false, false, false, false); err != nil {
 			t.Fatalf("runRefer failed: %v", err)
 		}
 	})
@@ -99,7 +100,7 @@ func TestRefer_ExactIDMatch_PrintsContent(t *testing.T) {
 	setupProjectWithPlan(t, p)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("deadbeef", false); err != nil {
+		if err := runRefer("deadbeef", false, false, false, false); err != nil {
 			t.Fatalf("runRefer failed: %v", err)
 		}
 	})
@@ -120,7 +121,7 @@ func TestRefer_ExactFilenameMatch_PrintsContent(t *testing.T) {
 	_ = os.WriteFile(filepath.Join(plansDir, "20240615-my-feature.md"), data, 0o644)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("20240615-my-feature", false); err != nil {
+		if err := runRefer("20240615-my-feature", false, false, false, false); err != nil {
 			t.Fatalf("runRefer failed: %v", err)
 		}
 	})
@@ -137,7 +138,7 @@ func TestRefer_PartialTopicMatch_PrintsContent(t *testing.T) {
 	setupProjectWithPlan(t, p)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("migration", false); err != nil {
+		if err := runRefer("migration", false, false, false, false); err != nil {
 			t.Fatalf("runRefer failed: %v", err)
 		}
 	})
@@ -153,7 +154,7 @@ func TestRefer_PartialFilenameMatch_PrintsContent(t *testing.T) {
 	setupProjectWithPlan(t, p)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("cache", false); err != nil {
+		if err := runRefer("cache", false, false, false, false); err != nil {
 			t.Fatalf("runRefer failed: %v", err)
 		}
 	})
@@ -170,7 +171,7 @@ func TestRefer_CaseInsensitive_TopicMatch(t *testing.T) {
 	setupProjectWithPlan(t, p)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("auth-refactor", false); err != nil {
+		if err := runRefer("auth-refactor", false, false, false, false); err != nil {
 			t.Fatalf("runRefer failed: %v", err)
 		}
 	})
@@ -185,7 +186,7 @@ func TestRefer_CaseInsensitive_PartialMatch(t *testing.T) {
 	setupProjectWithPlan(t, p)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("PAYMENT", false); err != nil {
+		if err := runRefer("PAYMENT", false, false, false, false); err != nil {
 			t.Fatalf("runRefer failed: %v", err)
 		}
 	})
@@ -202,7 +203,7 @@ func TestRefer_FullContent_IncludesFrontmatter(t *testing.T) {
 	setupProjectWithPlan(t, p)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("frontmatter-check", false); err != nil {
+		if err := runRefer("frontmatter-check", false, false, false, false); err != nil {
 			t.Fatalf("runRefer failed: %v", err)
 		}
 	})
@@ -220,7 +221,7 @@ func TestRefer_FullContent_IncludesBody(t *testing.T) {
 	setupProjectWithPlan(t, p)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("body-check", false); err != nil {
+		if err := runRefer("body-check", false, false, false, false); err != nil {
 			t.Fatalf("runRefer failed: %v", err)
 		}
 	})
@@ -244,7 +245,7 @@ func TestRefer_Summary_ReturnsOnlySummarySections(t *testing.T) {
 	_ = config.Save(dir, cfg)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("summary-test", true); err != nil {
+		if err := runRefer("summary-test", true, false, false, false); err != nil {
 			t.Fatalf("runRefer --summary failed: %v", err)
 		}
 	})
@@ -269,7 +270,7 @@ func TestRefer_Summary_ExcludesBodyNotInSections(t *testing.T) {
 	_ = config.Save(dir, cfg)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("exclude-test", true); err != nil {
+		if err := runRefer("exclude-test", true, false, false, false); err != nil {
 			t.Fatalf("runRefer --summary failed: %v", err)
 		}
 	})
@@ -291,7 +292,7 @@ func TestRefer_Summary_DoesNotIncludeFrontmatter(t *testing.T) {
 	_ = config.Save(dir, cfg)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("no-frontmatter", true); err != nil {
+		if err := runRefer("no-frontmatter", true, false, false, false); err != nil {
 			t.Fatalf("runRefer --summary failed: %v", err)
 		}
 	})
@@ -311,7 +312,7 @@ func TestRefer_MultipleMatches_ReturnsError(t *testing.T) {
 	}
 	setupProjectWithPlans(t, plans)
 
-	err := runRefer("auth", false)
+	err := runRefer("auth", false, false, false, false)
 	if err == nil {
 		t.Fatal("expected error when multiple plans match, got nil")
 	}
@@ -329,7 +330,7 @@ func TestRefer_MultipleMatches_DoesNotPrintContent(t *testing.T) {
 	setupProjectWithPlans(t, plans)
 
 	out := captureOutput(t, func() {
-		_ = runRefer("api", false)
+		_ = runRefer("api", false, false, false, false)
 	})
 
 	if strings.TrimSpace(out) != "" {
@@ -349,7 +350,7 @@ func TestRefer_ExactMatchPreferredOverPartial(t *testing.T) {
 	setupProjectWithPlans(t, plans)
 
 	out := captureOutput(t, func() {
-		if err := runRefer("auth", false); err != nil {
+		if err := runRefer("auth", false, false, false, false); err != nil {
 			t.Fatalf("runRefer failed: %v", err)
 		}
 	})
@@ -453,6 +454,20 @@ func TestMatchPlans_CaseInsensitive(t *testing.T) {
 	}
 }
 
+func TestPlanFilenameMatches_ExactTopicBeatsPartial(t *testing.T) {
+	plans := []plan.Plan{
+		{ID: "a", Topic: "auth", Filename: "20240101-auth.md"},
+		{ID: "b", Topic: "auth-refresh", Filename: "20240102-auth-refresh.md"},
+	}
+	result := planFilenameMatches("auth", plans)
+	if len(result) != 1 || result[0].ID != "a" {
+		t.Errorf("expected only the plan whose topic is auth, got %v", result)
+	}
+	if result := planFilenameMatches("2024", plans); len(result) != 2 {
+		t.Errorf("expected both plans for a shared substring, got %d", len(result))
+	}
+}
+
 // --- runRefer: not initialised -----------------------------------------------
 
 func TestRefer_NotInitialized_ReturnsError(t *testing.T) {
@@ -461,8 +476,109 @@ func TestRefer_NotInitialized_ReturnsError(t *testing.T) {
 	_ = os.Chdir(dir)
 	t.Cleanup(func() { _ = os.Chdir(orig) })
 
-	err := runRefer("anything", false)
+	err := runRefer("anything", false, false, false, false)
 	if err == nil {
 		t.Fatal("expected error when project not initialised, got nil")
 	}
 }
+
+// --- runRefer: --with-tasks --------------------------------------------------
+
+func TestRefer_WithTasks(t *testing.T) {
+	dir := setupInitedProject(t)
+	p := makeReferPlan("wt0001", "with-tasks", nil, time.Now())
+	writePlanFileWithBody(t, dir, p)
+	slug := strings.TrimSuffix(plan.FileName(p), ".md")
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Linked task", priority: "high"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Unrelated task", priority: "low"}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runRefer("with-tasks", false, true, false, false); err != nil {
+			t.Fatalf("runRefer --with-tasks failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, "## Background") {
+		t.Errorf("expected plan body before the task table, got:\n%s", out)
+	}
+	if !strings.Contains(out, "Linked tasks (1):") || !strings.Contains(out, "Linked task") {
+		t.Errorf("expected the linked task, got:\n%s", out)
+	}
+	if strings.Contains(out, "Unrelated task") {
+		t.Errorf("did not expect tasks of other plans, got:\n%s", out)
+	}
+}
+
+func TestRefer_WithTasks_None(t *testing.T) {
+	dir := setupInitedProject(t)
+	writePlanFileWithBody(t, dir, makeReferPlan("wt0002", "lonely", nil, time.Now()))
+
+	out := captureOutput(t, func() {
+		if err := runRefer("lonely", true, true, false, false); err != nil {
+			t.Fatalf("runRefer --with-tasks failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Linked tasks: none") {
+		t.Errorf("expected an empty task notice, got:\n%s", out)
+	}
+}
+
+// --- runRefer: --json and --outline ------------------------------------------
+
+func TestRefer_JSON_SectionSizes(t *testing.T) {
+	dir := setupInitedProject(t)
+	writePlanFileWithBody(t, dir, makeReferPlan("js0001", "json-sizes", []string{"go"}, time.Now()))
+
+	out := captureOutput(t, func() {
+		if err := runRefer("json-sizes", false, false, true, false); err != nil {
+			t.Fatalf("runRefer --json failed: %v", err)
+		}
+	})
+	var got referJSON
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if got.ID != "js0001" || !strings.Contains(got.Body, "## Notes") {
+		t.Errorf("unexpected plan: %+v", got)
+	}
+	if len(got.Sections) < 3 || got.Sections[0].Heading != "Background" {
+		t.Fatalf("unexpected sections: %+v", got.Sections)
+	}
+	if s := got.Sections[1]; s.Heading != "Spec" || s.Bytes != len("- Spec item A\n- Spec item B") || s.Words != 8 || s.Tokens == 0 {
+		t.Errorf("unexpected Spec size: %+v", s)
+	}
+}
+
+func TestRefer_JSON_OutlineOmitsBody(t *testing.T) {
+	dir := setupInitedProject(t)
+	writePlanFileWithBody(t, dir, makeReferPlan("js0002", "json-outline", nil, time.Now()))
+
+	out := captureOutput(t, func() {
+		if err := runRefer("json-outline", false, false, true, true); err != nil {
+			t.Fatalf("runRefer --json --outline failed: %v", err)
+		}
+	})
+	if strings.Contains(out, `"body"`) || !strings.Contains(out, `"sections"`) {
+		t.Errorf("expected sections without body, got:\n%s", out)
+	}
+}
+
+func TestRefer_Outline(t *testing.T) {
+	dir := setupInitedProject(t)
+	writePlanFileWithBody(t, dir, makeReferPlan("ol0001", "outline-test", nil, time.Now()))
+
+	out := captureOutput(t, func() {
+		if err := runRefer("outline-test", false, false, false, true); err != nil {
+			t.Fatalf("runRefer --outline failed: %v", err)
+		}
+	})
+	if !strings.Contains(out, "SECTION") || !strings.Contains(out, "## Background") || !strings.Contains(out, "## Notes") {
+		t.Errorf("expected the section table, got:\n%s", out)
+	}
+	if strings.Contains(out, "Spec item A") {
+		t.Errorf("outline should not print section content, got:\n%s", out)
+	}
+}
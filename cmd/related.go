@@ -0,0 +1,176 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/suggest"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// planFilenames returns the filenames of every plan a related link may point
+// at: the loaded plans plus those in plans/archive/.
+func planFilenames(root string, plans []plan.Plan) []string {
+	names := make([]string, 0, len(plans))
+	for _, p := range plans {
+		names = append(names, p.Filename)
+	}
+	if entries, err := os.ReadDir(plan.ArchiveDir(root)); err == nil {
+		for _, e := range entries {
+			if !e.IsDir() && strings.HasSuffix(e.Name(), ".md") {
+				names = append(names, e.Name())
+			}
+		}
+	}
+	return names
+}
+
+// resolveRelatedRef resolves one related-plan reference against filenames:
+// an exact filename (with or without .md) wins, otherwise the reference must
+// be a substring of exactly one filename. A reference matching nothing is
+// reported with a "did you mean" suggestion when one is close.
+func resolveRelatedRef(ref string, filenames []string) (string, error) {
+	want := strings.TrimSuffix(ref, ".md") + ".md"
+	if slices.Contains(filenames, want) {
+		return want, nil
+	}
+	var matches []string
+	for _, f := range filenames {
+		if strings.Contains(f, ref) {
+			matches = append(matches, f)
+		}
+	}
+	switch len(matches) {
+	case 1:
+		return matches[0], nil
+	case 0:
+		if s := suggestPlan(ref, filenames); s != "" {
+			return "", fmt.Errorf("related plan %q not found (did you mean %q?)", ref, s)
+		}
+		return "", fmt.Errorf("related plan %q not found", ref)
+	default:
+		return "", fmt.Errorf("ambiguous related plan %q: matches [%s]", ref, strings.Join(matches, ", "))
+	}
+}
+
+// resolveRelated resolves every --related reference to a plan filename,
+// dropping duplicates. The first reference that does not resolve is
+// returned as an error, so a typo saves nothing.
+func resolveRelated(refs, filenames []string) ([]string, error) {
+	var out []string
+	for _, ref := range refs {
+		f, err := resolveRelatedRef(ref, filenames)
+		if err != nil {
+			return nil, fmt.Errorf("--related: %w", err)
+		}
+		if !slices.Contains(out, f) {
+			out = append(out, f)
+		}
+	}
+	return out, nil
+}
+
+// suggestPlan returns the filename closest to ref. Filenames are compared
+// both whole and without their .md suffix and date prefix, so a mistyped
+// topic still finds its plan.
+func suggestPlan(ref string, filenames []string) string {
+	ref = strings.TrimSuffix(ref, ".md")
+	var keys []string
+	owner := map[string]string{}
+	for _, f := range filenames {
+		slug := strings.TrimSuffix(f, ".md")
+		for _, k := range []string{slug, stripDatePrefix(slug)} {
+			if _, ok := owner[k]; !ok {
+				owner[k] = f
+				keys = append(keys, k)
+			}
+		}
+	}
+	if k := suggest.Closest(ref, keys); k != "" {
+		return owner[k]
+	}
+	return ""
+}
+
+// stripDatePrefix removes a leading "YYYYMMDD-" from a plan slug.
+func stripDatePrefix(slug string) string {
+	if len(slug) > 9 && slug[8] == '-' && strings.Trim(slug[:8], "0123456789") == "" {
+		return slug[9:]
+	}
+	return slug
+}
+
+// deadLink is a related entry of a plan whose target does not exist.
+type deadLink struct {
+	plan string // plan filename holding the link
+	ref  string // the related entry as written
+	fix  string // filename it resolves to, or "" when it should be removed
+}
+
+// findDeadLinks returns the related entries of plans that name no existing
+// plan. Each is paired with the plan it unambiguously resolves to, if any,
+// so that legacy partial or suffix-less references can be repaired.
+func findDeadLinks(root string, plans []plan.Plan) []deadLink {
+	filenames := planFilenames(root, plans)
+	var dead []deadLink
+	for _, p := range plans {
+		for _, ref := range p.Related {
+			if slices.Contains(filenames, ref) {
+				continue
+			}
+			fix, err := resolveRelatedRef(ref, filenames)
+			if err != nil {
+				fix = ""
+			}
+			dead = append(dead, deadLink{plan: p.Filename, ref: ref, fix: fix})
+		}
+	}
+	return dead
+}
+
+// repairDeadLinks rewrites the related field of each plan in dead: entries
+// that resolve are replaced by the full filename, the rest are removed. It
+// returns the number of plan files rewritten.
+func repairDeadLinks(root string, cfg config.Config, plans []plan.Plan, dead []deadLink) (int, error) {
+	byPlan := map[string][]deadLink{}
+	for _, d := range dead {
+		byPlan[d.plan] = append(byPlan[d.plan], d)
+	}
+	n := 0
+	for _, p := range plans {
+		links := byPlan[p.Filename]
+		if len(links) == 0 || len(p.Conflicts) > 0 {
+			continue
+		}
+		if p.Frozen {
+			warnf("skipping %s: the plan is frozen", p.Filename)
+			continue
+		}
+		var related []string
+		for _, ref := range p.Related {
+			i := slices.IndexFunc(links, func(d deadLink) bool { return d.ref == ref })
+			if i >= 0 {
+				ref = links[i].fix
+			}
+			if ref != "" && !slices.Contains(related, ref) {
+				related = append(related, ref)
+			}
+		}
+		p.Related = related
+		data, err := plan.Marshal(p)
+		if err != nil {
+			return n, fmt.Errorf("marshal plan %s: %w", p.Filename, err)
+		}
+		path := filepath.Join(plan.PlansDir(root), p.Filename)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return n, fmt.Errorf("write plan %s: %w", p.Filename, err)
+		}
+		stageFiles(root, cfg, path)
+		n++
+	}
+	return n, nil
+}
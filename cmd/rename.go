@@ -0,0 +1,247 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/ack"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/recent"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/knowledge"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var renameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Change a plan's topic, renaming its file and keeping links intact",
+	Long: `Set a new topic on a plan and rename its file to match plans.filename_pattern.
+Everything that refers to the plan by filename follows it:
+
+  - related and depends_on of other plans
+  - the task group directory .logosyncx/tasks/<plan>/ and each task's plan field
+  - related_plans of tasks in other plans
+  - the plan field of knowledge files distilled from it
+  - its acknowledgments and referral ranking
+
+Both indexes are rebuilt. Frozen plans cannot be renamed, and links held by
+other frozen plans are left as they are (with a warning).
+
+To retitle a task, use logos task rename.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		topic, _ := cmd.Flags().GetString("topic")
+		return runRename(name, topic)
+	},
+}
+
+func init() {
+	renameCmd.Flags().StringP("name", "n", "", "Plan to rename (exact or partial match)")
+	renameCmd.Flags().String("topic", "", "New topic")
+	_ = renameCmd.MarkFlagRequired("name")
+	_ = renameCmd.MarkFlagRequired("topic")
+	rootCmd.AddCommand(renameCmd)
+}
+
+func runRename(name, topic string) error {
+	topic = strings.TrimSpace(topic)
+	if topic == "" {
+		return errors.New("--topic must not be empty")
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	matches := matchPlans(plans, name)
+	switch len(matches) {
+	case 0:
+		return fmt.Errorf("no plan found matching %q", name)
+	case 1:
+	default:
+		return printPlanCandidates(matches, name, nil)
+	}
+	p := matches[0]
+	if len(p.Conflicts) > 0 {
+		return fmt.Errorf("%s has unresolved merge conflicts — resolve them first", p.Filename)
+	}
+	if err := refuseFrozen(p); err != nil {
+		return err
+	}
+	if p.Topic == topic {
+		fmt.Printf("%s already has topic %q.\n", p.Filename, topic)
+		return nil
+	}
+
+	oldName := p.Filename
+	renamed := p
+	renamed.Topic = topic
+	renamed.Filename = plan.FileNameWithPattern(renamed, cfg.Plans.FilenamePattern)
+	if p.TasksDir == plan.DefaultTasksDir(oldName) {
+		renamed.TasksDir = plan.DefaultTasksDir(renamed.Filename)
+	}
+	newName := renamed.Filename
+	oldPath := filepath.Join(plan.PlansDir(root), oldName)
+	if newName != oldName {
+		if _, err := os.Stat(filepath.Join(plan.PlansDir(root), newName)); err == nil {
+			return fmt.Errorf("a plan named %s already exists", newName)
+		}
+	}
+
+	path, err := plan.Write(root, renamed)
+	if err != nil {
+		return fmt.Errorf("write plan: %w", err)
+	}
+	stageFiles(root, cfg, path)
+	if newName == oldName {
+		if _, err := index.RebuildWithOptions(root, planIndexOptions(cfg)); err != nil {
+			warnf("could not rebuild index (%v) — run `logos sync` to rebuild", err)
+		}
+		stageFiles(root, cfg, index.FilePath(root))
+		printSuccess("Changed the topic of %s to %q", oldName, topic)
+		return nil
+	}
+	if err := os.Remove(oldPath); err != nil {
+		return fmt.Errorf("remove %s: %w", oldName, err)
+	}
+	stageRemovals(root, cfg, oldPath)
+
+	// Links from other plans.
+	linked := 0
+	for _, other := range plans {
+		if other.Filename == oldName || !linksTo(other, oldName) {
+			continue
+		}
+		if other.Frozen {
+			warnf("skipping %s: the plan is frozen — its link to %s is left as is", other.Filename, oldName)
+			continue
+		}
+		other.Related = renameRefs(other.Related, oldName, newName)
+		other.DependsOn = renameRefs(other.DependsOn, oldName, newName)
+		otherPath, err := plan.Write(root, other)
+		if err != nil {
+			return fmt.Errorf("write plan %s: %w", other.Filename, err)
+		}
+		stageFiles(root, cfg, otherPath)
+		linked++
+	}
+	if _, err := index.RebuildWithOptions(root, planIndexOptions(cfg)); err != nil {
+		warnf("could not rebuild index (%v) — run `logos sync` to rebuild", err)
+	}
+	stageFiles(root, cfg, index.FilePath(root))
+
+	// Tasks, knowledge, acknowledgments, and referral ranking.
+	oldSlug, newSlug := strings.TrimSuffix(oldName, ".md"), strings.TrimSuffix(newName, ".md")
+	store := task.NewStore(root, &cfg)
+	tasks, err := store.RenamePlan(oldSlug, newSlug)
+	if err != nil {
+		warnf("%v", err)
+	}
+	distilled := rewriteKnowledge(root, cfg, func(k *knowledge.Knowledge) bool {
+		if k.Plan != oldName {
+			return false
+		}
+		k.Plan = newName
+		return true
+	})
+	if err := moveAcks(root, cfg, oldName, newName); err != nil {
+		warnf("could not move acknowledgments: %v", err)
+	}
+	_ = recent.Rename(root, recent.KindPlan, func(n string) string {
+		if n == oldName {
+			return newName
+		}
+		return n
+	})
+	_ = recent.Rename(root, recent.KindTask, func(n string) string {
+		if rest, ok := strings.CutPrefix(n, oldSlug+"/"); ok {
+			return newSlug + "/" + rest
+		}
+		return n
+	})
+
+	printSuccess("Renamed %s → %s", oldName, newName)
+	fmt.Printf("Updated links in %d plan(s), %d task(s), and %d knowledge file(s).\n", linked, tasks, distilled)
+	return nil
+}
+
+// linksTo reports whether p's related or depends_on lists name, given as a
+// filename or a slug.
+func linksTo(p plan.Plan, name string) bool {
+	slug := strings.TrimSuffix(name, ".md")
+	for _, ref := range append(append([]string{}, p.Related...), p.DependsOn...) {
+		if ref == name || ref == slug {
+			return true
+		}
+	}
+	return false
+}
+
+// renameRefs returns refs with oldName replaced by newName, keeping the
+// form (filename or slug) each reference was written in.
+func renameRefs(refs []string, oldName, newName string) []string {
+	out := make([]string, len(refs))
+	for i, ref := range refs {
+		switch ref {
+		case oldName:
+			out[i] = newName
+		case strings.TrimSuffix(oldName, ".md"):
+			out[i] = strings.TrimSuffix(newName, ".md")
+		default:
+			out[i] = ref
+		}
+	}
+	return out
+}
+
+// rewriteKnowledge applies edit to every knowledge file and writes back
+// those it changed. It returns the number of files written.
+func rewriteKnowledge(root string, cfg config.Config, edit func(*knowledge.Knowledge) bool) int {
+	all, err := knowledge.LoadAll(root)
+	if err != nil {
+		warnf("%v", err)
+	}
+	n := 0
+	for _, k := range all {
+		if !edit(&k) {
+			continue
+		}
+		path, err := knowledge.Rewrite(root, k)
+		if err != nil {
+			warnf("%v", err)
+			continue
+		}
+		stageFiles(root, cfg, path)
+		n++
+	}
+	return n
+}
+
+// moveAcks moves the acknowledgments of the plan oldName to newName.
+func moveAcks(root string, cfg config.Config, oldName, newName string) error {
+	from, to := ack.Path(root, oldName), ack.Path(root, newName)
+	if err := os.Rename(from, to); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return err
+	}
+	stageRemovals(root, cfg, from)
+	stageFiles(root, cfg, to)
+	return nil
+}
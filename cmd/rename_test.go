@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/knowledge"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+// setupRenameProject writes an auth-flow plan with one task and a knowledge
+// file, and a db-schema plan that links to it both from its frontmatter and
+// from one of its tasks. It returns the project root.
+func setupRenameProject(t *testing.T) string {
+	t.Helper()
+	dir := setupInitedProject(t)
+	date := time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)
+	auth := makeSyncPlan("p1", "auth-flow", date)
+	auth.TasksDir = plan.DefaultTasksDir(plan.FileName(auth))
+	writeSyncPlan(t, dir, auth)
+	db := makeSyncPlan("p2", "db-schema", date)
+	db.Related = []string{plan.FileName(auth)}
+	db.DependsOn = []string{plan.FileName(auth)}
+	writeSyncPlan(t, dir, db)
+
+	captureOutput(t, func() {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: "20260501-auth-flow", title: "Add login"}); err != nil {
+			t.Fatalf("runTaskCreate: %v", err)
+		}
+		if err := runTaskCreate(dir, taskCreateOptions{plan: "20260501-db-schema", title: "Add users table"}); err != nil {
+			t.Fatalf("runTaskCreate: %v", err)
+		}
+	})
+	cfg, _ := config.Load(dir)
+	store := task.NewStore(dir, &cfg)
+	users, err := store.Get("db-schema", "users")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.AddRelatedPlans(map[string][]string{users.DirPath: {"20260501-auth-flow.md"}}); err != nil {
+		t.Fatal(err)
+	}
+	k := knowledge.Knowledge{Topic: "auth-flow", Plan: "20260501-auth-flow.md", Tasks: []string{"001-Add login"}}
+	if _, err := knowledge.Write(dir, k, "source", "## Learnings\n"); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestRename_MovesPlanAndRewritesLinks(t *testing.T) {
+	dir := setupRenameProject(t)
+
+	captureOutput(t, func() {
+		if err := runRename("auth-flow", "Login Flow"); err != nil {
+			t.Fatalf("runRename: %v", err)
+		}
+	})
+
+	if _, err := os.Stat(filepath.Join(plan.PlansDir(dir), "20260501-auth-flow.md")); !os.IsNotExist(err) {
+		t.Errorf("old plan file still exists (%v)", err)
+	}
+	renamed, err := plan.LoadFile(filepath.Join(plan.PlansDir(dir), "20260501-login-flow.md"))
+	if err != nil {
+		t.Fatalf("load renamed plan: %v", err)
+	}
+	if renamed.Topic != "Login Flow" || renamed.TasksDir != plan.DefaultTasksDir("20260501-login-flow.md") {
+		t.Errorf("renamed plan = topic %q tasks_dir %q", renamed.Topic, renamed.TasksDir)
+	}
+	if !strings.Contains(renamed.Body, "auth-flow plan.") {
+		t.Errorf("body was not kept: %q", renamed.Body)
+	}
+
+	db, err := plan.LoadFile(filepath.Join(plan.PlansDir(dir), "20260501-db-schema.md"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !slices.Equal(db.Related, []string{"20260501-login-flow.md"}) || !slices.Equal(db.DependsOn, []string{"20260501-login-flow.md"}) {
+		t.Errorf("db-schema links = related %v depends_on %v", db.Related, db.DependsOn)
+	}
+
+	for _, tk := range loadAllTasks(t, dir) {
+		switch tk.Title {
+		case "Add login":
+			if tk.Plan != "20260501-login-flow" || filepath.Base(filepath.Dir(tk.DirPath)) != "20260501-login-flow" {
+				t.Errorf("task moved to plan %q dir %s", tk.Plan, tk.DirPath)
+			}
+		case "Add users table":
+			if !slices.Equal(tk.RelatedPlans, []string{"20260501-login-flow.md"}) {
+				t.Errorf("related_plans = %v", tk.RelatedPlans)
+			}
+		}
+	}
+
+	all, _ := knowledge.LoadAll(dir)
+	if len(all) != 1 || all[0].Plan != "20260501-login-flow.md" || !strings.Contains(all[0].Body, "source") {
+		t.Errorf("knowledge = %+v", all)
+	}
+
+	entries, _ := index.ReadAll(dir)
+	var names []string
+	for _, e := range entries {
+		names = append(names, e.Filename)
+	}
+	if !slices.Contains(names, "20260501-login-flow.md") || slices.Contains(names, "20260501-auth-flow.md") {
+		t.Errorf("index lists %v", names)
+	}
+}
+
+func TestRename_Refusals(t *testing.T) {
+	dir := setupRenameProject(t)
+
+	if err := runRename("auth-flow", "db-schema"); err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("runRename onto an existing plan = %v, want an error", err)
+	}
+	captureOutput(t, func() {
+		if err := runFreeze("auth-flow", false, false, "", "alice", time.Now()); err != nil {
+			t.Fatalf("runFreeze: %v", err)
+		}
+	})
+	if err := runRename("auth-flow", "Login Flow"); err == nil || !strings.Contains(err.Error(), "is frozen") {
+		t.Errorf("runRename on a frozen plan = %v, want an error", err)
+	}
+	if _, err := os.Stat(filepath.Join(plan.PlansDir(dir), "20260501-auth-flow.md")); err != nil {
+		t.Errorf("plan must stay in place: %v", err)
+	}
+}
+
+func TestTaskRename_MovesDirectory(t *testing.T) {
+	dir := setupRenameProject(t)
+
+	captureOutput(t, func() {
+		if err := runTaskRename("auth-flow", "login", "Add OAuth login"); err != nil {
+			t.Fatalf("runTaskRename: %v", err)
+		}
+	})
+
+	oldDir := filepath.Join(dir, ".logosyncx", "tasks", "20260501-auth-flow", "001-add-login")
+	if _, err := os.Stat(oldDir); !os.IsNotExist(err) {
+		t.Errorf("old task directory still exists (%v)", err)
+	}
+	var found *task.Task
+	for _, tk := range loadAllTasks(t, dir) {
+		if tk.Plan == "20260501-auth-flow" {
+			found = tk
+		}
+	}
+	if found == nil || found.Title != "Add OAuth login" || filepath.Base(found.DirPath) != "001-add-oauth-login" {
+		t.Fatalf("renamed task = %+v", found)
+	}
+
+	entries, _ := task.ReadAllTaskIndex(dir)
+	ok := slices.ContainsFunc(entries, func(e task.TaskJSON) bool { return e.Title == "Add OAuth login" })
+	if !ok {
+		t.Errorf("task index was not rebuilt: %+v", entries)
+	}
+	all, _ := knowledge.LoadAll(dir)
+	if len(all) != 1 || !slices.Equal(all[0].Tasks, []string{"001-Add OAuth login"}) {
+		t.Errorf("knowledge tasks = %+v", all)
+	}
+}
@@ -0,0 +1,164 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/spf13/cobra"
+)
+
+var resumeCmd = &cobra.Command{
+	Use:   "resume",
+	Short: "Print a briefing on where you left off",
+	Long: `Print a compact briefing for picking work back up:
+
+  Plan         the most recently saved plan (with --agent, the most recent
+               plan saved by that agent) and its excerpt
+  In progress  the plan's in_progress tasks
+  Uncommitted  files under .logosyncx/ with uncommitted git changes
+
+Use --json for the same briefing as a single JSON object, e.g. at the start
+of an agent session. This command never modifies any file or git state.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		agent, _ := cmd.Flags().GetString("agent")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		return runResume(agent, asJSON)
+	},
+}
+
+func init() {
+	resumeCmd.Flags().StringP("agent", "a", "", "Resume the most recent plan saved by this agent (case-insensitive)")
+	resumeCmd.Flags().Bool("json", false, "Output the briefing as JSON (for agent consumption)")
+	rootCmd.AddCommand(resumeCmd)
+}
+
+// resumeBriefing is the --json output of logos resume. Plan is null when
+// the project has no (matching) plans.
+type resumeBriefing struct {
+	Plan        *index.Entry      `json:"plan"`
+	OpenTasks   int               `json:"open_tasks"`
+	InProgress  []task.TaskJSON   `json:"in_progress"`
+	Uncommitted []uncommittedFile `json:"uncommitted"`
+}
+
+// uncommittedFile is one changed file under .logosyncx/.
+type uncommittedFile struct {
+	Path   string `json:"path"`
+	Status string `json:"status"`
+}
+
+func runResume(agent string, asJSON bool) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	b, err := buildResumeBriefing(root, cfg, agent)
+	if err != nil {
+		return err
+	}
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(b)
+	}
+	printResumeBriefing(b, cfg)
+	return nil
+}
+
+// buildResumeBriefing gathers the most recent plan (by agent, when set),
+// its in_progress tasks, and the uncommitted files under .logosyncx/.
+func buildResumeBriefing(root string, cfg config.Config, agent string) (resumeBriefing, error) {
+	b := resumeBriefing{InProgress: []task.TaskJSON{}, Uncommitted: []uncommittedFile{}}
+
+	entries, err := readPlanIndex(root, cfg)
+	if err != nil {
+		return b, err
+	}
+	if agent != "" {
+		entries = filterAgent(entries, agent)
+	}
+	sortByDateDesc(entries)
+	if len(entries) > 0 {
+		latest := entries[0]
+		b.Plan = &latest
+		slug := entryPlanSlug(latest)
+		b.OpenTasks = loadTaskCounts(root)[slug].Open
+
+		tasks, err := task.NewStore(root, &cfg).List(task.Filter{PlanSlug: slug, Statuses: []task.Status{task.StatusInProgress}})
+		if err != nil {
+			warnf("%v", err)
+		}
+		for _, t := range tasks {
+			b.InProgress = append(b.InProgress, t.ToJSON())
+		}
+		task.SortJSONByOrder(b.InProgress)
+	}
+
+	if gitutil.IsRepo(root) {
+		files, err := gitutil.StatusUnderDir(root, config.DirName+"/")
+		if err != nil {
+			warnf("could not query git status: %v", err)
+		}
+		for _, f := range files {
+			status := "new"
+			switch {
+			case f.Staging != gitutil.StatusUnmodified && f.Staging != gitutil.StatusUntracked:
+				status = statusLabel(f.Staging) + " (staged)"
+			case f.Worktree != gitutil.StatusUnmodified && f.Worktree != gitutil.StatusUntracked:
+				status = statusLabel(f.Worktree)
+			}
+			b.Uncommitted = append(b.Uncommitted, uncommittedFile{Path: f.Path, Status: status})
+		}
+	}
+	return b, nil
+}
+
+func printResumeBriefing(b resumeBriefing, cfg config.Config) {
+	if b.Plan == nil {
+		fmt.Println("No plans found.")
+	} else {
+		p := b.Plan
+		fmt.Printf("Plan:        %s (%s, %s)\n", p.Topic, p.Filename, p.Date.In(displayLocation(cfg)).Format("2006-01-02 15:04"))
+		if p.Excerpt != "" {
+			fmt.Printf("             %s\n", strings.ReplaceAll(p.Excerpt, "\n", " "))
+		}
+		fmt.Printf("Open tasks:  %d\n", b.OpenTasks)
+		if len(b.InProgress) == 0 {
+			fmt.Println("In progress: none")
+		} else {
+			fmt.Println("In progress:")
+			for _, t := range b.InProgress {
+				fmt.Printf("  - %s %s\n", t.ID, t.Title)
+			}
+		}
+	}
+
+	if len(b.Uncommitted) == 0 {
+		fmt.Println("Uncommitted: none")
+	} else {
+		fmt.Println("Uncommitted:")
+		for _, f := range b.Uncommitted {
+			fmt.Printf("  %-20s %s\n", "("+f.Status+")", trimPrefix(f.Path))
+		}
+	}
+
+	if b.Plan != nil {
+		printHint(fmt.Sprintf("Next: logos refer --name %s --summary", strings.TrimSuffix(b.Plan.Filename, ".md")))
+	}
+}
@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+func TestResume_LatestPlanAndInProgressTasks(t *testing.T) {
+	dir := setupInitedProject(t)
+	now := time.Now()
+	latest := makeTestPlan("test-plan", nil, now)
+	latest.ID = "test02"
+	writePlanFileWithBody(t, dir, makeTestPlan("old-work", nil, now.Add(-48*time.Hour)))
+	writePlanFileWithBody(t, dir, latest)
+	slug := strings.TrimSuffix(plan.FileName(latest), ".md")
+
+	for _, title := range []string{"Wire the API", "Write docs"} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: title, priority: "medium"}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	cfg, _ := config.Load(dir)
+	if err := task.NewStore(dir, &cfg).UpdateFields(slug, "wire-the-api", map[string]string{"status": "in_progress"}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runResume("", true); err != nil {
+			t.Errorf("runResume: %v", err)
+		}
+	})
+	var b resumeBriefing
+	if err := json.Unmarshal([]byte(out), &b); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if b.Plan == nil || b.Plan.Filename != slug+".md" {
+		t.Fatalf("expected the latest plan, got %+v", b.Plan)
+	}
+	if b.OpenTasks != 2 {
+		t.Errorf("open_tasks = %d, want 2", b.OpenTasks)
+	}
+	if len(b.InProgress) != 1 || b.InProgress[0].Title != "Wire the API" {
+		t.Errorf("unexpected in_progress tasks: %+v", b.InProgress)
+	}
+	if b.Uncommitted == nil {
+		t.Error("expected uncommitted to be an empty array, not null")
+	}
+}
+
+func TestResume_AgentFilter(t *testing.T) {
+	dir := setupInitedProject(t)
+	now := time.Now()
+	mine := makeTestPlan("mine", nil, now.Add(-time.Hour))
+	mine.Agent = "cursor"
+	writePlanFileWithBody(t, dir, mine)
+	writePlanFileWithBody(t, dir, makeTestPlan("theirs", nil, now))
+
+	out := captureOutput(t, func() {
+		if err := runResume("Cursor", false); err != nil {
+			t.Errorf("runResume: %v", err)
+		}
+	})
+	if !strings.Contains(out, "mine") || strings.Contains(out, "theirs") {
+		t.Errorf("expected only the cursor plan, got:\n%s", out)
+	}
+}
+
+func TestResume_UncommittedFiles(t *testing.T) {
+	dir := setupInitedProject(t)
+	gitInitDir(t, dir)
+	writePlanFileWithBody(t, dir, makeTestPlan("auth", nil, time.Now()))
+
+	out := captureOutput(t, func() {
+		if err := runResume("", true); err != nil {
+			t.Errorf("runResume: %v", err)
+		}
+	})
+	var b resumeBriefing
+	if err := json.Unmarshal([]byte(out), &b); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(b.Uncommitted) == 0 {
+		t.Error("expected uncommitted files in a fresh repository")
+	}
+}
+
+func TestResume_NoPlans(t *testing.T) {
+	setupInitedProject(t)
+	out := captureOutput(t, func() {
+		if err := runResume("", false); err != nil {
+			t.Errorf("runResume: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No plans found.") {
+		t.Errorf("unexpected output:\n%s", out)
+	}
+}
@@ -0,0 +1,191 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var retagCmd = &cobra.Command{
+	Use:   "retag",
+	Args:  cobra.NoArgs,
+	Short: "Add or remove tags across every plan and task carrying a tag",
+	Long: `Rewrite the tags of every plan and task that carries --filter-tag, then
+rebuild both indexes once.
+
+--remove is applied before --add, so renaming a tag is:
+
+  logos retag --filter-tag auth --add authn --remove auth
+
+Narrow the selection with --only plans|tasks and --plan <partial> (tasks of
+that plan, and the plan itself). Use --dry-run to preview the changes.
+Added tags are checked against plans.allowed_tags and tasks.allowed_tags.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		filterTag, _ := cmd.Flags().GetString("filter-tag")
+		add, _ := cmd.Flags().GetStringArray("add")
+		remove, _ := cmd.Flags().GetStringArray("remove")
+		only, _ := cmd.Flags().GetString("only")
+		planPartial, _ := cmd.Flags().GetString("plan")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		return runRetag(filterTag, add, remove, only, planPartial, dryRun)
+	},
+}
+
+func init() {
+	retagCmd.Flags().String("filter-tag", "", "Only rewrite plans and tasks carrying this tag (required)")
+	_ = retagCmd.MarkFlagRequired("filter-tag")
+	retagCmd.Flags().StringArray("add", []string{}, "Tag to add (repeatable)")
+	retagCmd.Flags().StringArray("remove", []string{}, "Tag to remove (repeatable)")
+	retagCmd.Flags().String("only", "", "Restrict to plans or tasks")
+	retagCmd.Flags().StringP("plan", "P", "", "Restrict to one plan and its tasks (partial name)")
+	retagCmd.Flags().Bool("dry-run", false, "Show what would change without writing any file")
+	rootCmd.AddCommand(retagCmd)
+}
+
+func runRetag(filterTag string, add, remove []string, only, planPartial string, dryRun bool) error {
+	if len(add) == 0 && len(remove) == 0 {
+		return errors.New("provide --add and/or --remove")
+	}
+	if only != "" && only != "plans" && only != "tasks" {
+		return fmt.Errorf("--only: %q must be plans or tasks", only)
+	}
+
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	// --plan resolves like task ls: an exact plan when one matches,
+	// otherwise a substring of the plan slug.
+	planFilter := planPartial
+	exactPlan := false
+	if planPartial != "" {
+		slug, err := resolvePlanFilter(root, planPartial)
+		if err != nil {
+			return err
+		}
+		if slug != "" {
+			planFilter, exactPlan = slug, true
+		}
+	}
+	inPlan := func(slug string) bool {
+		if exactPlan {
+			return slug == planFilter
+		}
+		return strings.Contains(slug, planFilter)
+	}
+
+	// Plans.
+	var plans []plan.Plan
+	if only != "tasks" {
+		if err := config.CheckTags(add, cfg.Plans.AllowedTags, "plans.allowed_tags"); err != nil {
+			return err
+		}
+		all, err := plan.LoadAll(root)
+		if err != nil {
+			warnf("%v", err)
+		}
+		for _, p := range all {
+			if !inPlan(strings.TrimSuffix(p.Filename, ".md")) {
+				continue
+			}
+			if len(p.Conflicts) > 0 || !slices.Contains(p.Tags, filterTag) {
+				continue
+			}
+			if p.Frozen {
+				warnf("skipping %s: the plan is frozen", p.Filename)
+				continue
+			}
+			if tags, changed := retagged(p.Tags, add, remove); changed {
+				fmt.Printf("  %s: %s → %s\n", filepath.Join(".logosyncx", "plans", p.Filename), joinTags(p.Tags), joinTags(tags))
+				p.Tags = tags
+				plans = append(plans, p)
+			}
+		}
+	}
+
+	// Tasks.
+	store := task.NewStore(root, &cfg)
+	taskTags := map[string][]string{}
+	if only != "plans" {
+		if err := config.CheckTags(add, cfg.Tasks.AllowedTags, "tasks.allowed_tags"); err != nil {
+			return err
+		}
+		tasks, err := store.List(task.Filter{Tags: []string{filterTag}})
+		if err != nil {
+			warnf("%v", err)
+		}
+		for _, t := range tasks {
+			if !inPlan(t.Plan) {
+				continue
+			}
+			if tags, changed := retagged(t.Tags, add, remove); changed {
+				rel, _ := relPath(root, filepath.Join(t.DirPath, "TASK.md"))
+				fmt.Printf("  %s: %s → %s\n", rel, joinTags(t.Tags), joinTags(tags))
+				taskTags[t.DirPath] = tags
+			}
+		}
+	}
+
+	if len(plans) == 0 && len(taskTags) == 0 {
+		fmt.Printf("No plans or tasks tagged %q need changes.\n", filterTag)
+		return nil
+	}
+	if dryRun {
+		fmt.Printf("Dry run: would retag %d plan(s) and %d task(s).\n", len(plans), len(taskTags))
+		return nil
+	}
+
+	for _, p := range plans {
+		data, err := plan.Marshal(p)
+		if err != nil {
+			return fmt.Errorf("marshal plan %s: %w", p.Filename, err)
+		}
+		path := filepath.Join(plan.PlansDir(root), p.Filename)
+		if err := os.WriteFile(path, data, 0o644); err != nil {
+			return fmt.Errorf("write plan %s: %w", p.Filename, err)
+		}
+		stageFiles(root, cfg, path)
+	}
+	if len(plans) > 0 {
+		if _, err := index.RebuildWithOptions(root, planIndexOptions(cfg)); err != nil {
+			warnf("could not rebuild index (%v) — run `logos sync` to rebuild", err)
+		}
+		stageFiles(root, cfg, index.FilePath(root))
+	}
+
+	n, err := store.SetTags(taskTags)
+	if err != nil {
+		return fmt.Errorf("retag tasks: %w", err)
+	}
+
+	printSuccess("Retagged %d plan(s) and %d task(s).", len(plans), n)
+	return nil
+}
+
+// retagged returns tags with every tag in remove dropped and every tag in
+// add appended when missing, and whether the result differs from tags.
+func retagged(tags, add, remove []string) ([]string, bool) {
+	var out []string
+	for _, t := range tags {
+		if !slices.Contains(remove, t) {
+			out = append(out, t)
+		}
+	}
+	out = config.MergeTags(out, add)
+	return out, !slices.Equal(out, tags)
+}
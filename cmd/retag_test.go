@@ -0,0 +1,105 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+func TestRetagged(t *testing.T) {
+	got, changed := retagged([]string{"auth", "go"}, []string{"authn"}, []string{"auth"})
+	if !changed || !slices.Equal(got, []string{"go", "authn"}) {
+		t.Errorf("retagged = %v (changed %v), want [go authn]", got, changed)
+	}
+	if _, changed := retagged([]string{"go"}, []string{"go"}, nil); changed {
+		t.Error("adding a tag already present should not count as a change")
+	}
+}
+
+func TestRetag_RenamesAcrossPlansAndTasks(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runSave("auth refactor", []string{"auth", "backend"}, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSave("unrelated", []string{"docs"}, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Tagged task", priority: "medium", tags: []string{"auth"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Other task", priority: "medium", tags: []string{"cli"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runRetag("auth", []string{"authn"}, []string{"auth"}, "", "", false); err != nil {
+			t.Fatalf("runRetag: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Retagged 1 plan(s) and 1 task(s).") {
+		t.Errorf("unexpected summary:\n%s", out)
+	}
+
+	entries, err := index.ReadAll(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, e := range entries {
+		if e.Topic == "auth refactor" && !slices.Equal(e.Tags, []string{"backend", "authn"}) {
+			t.Errorf("index tags = %v, want [backend authn]", e.Tags)
+		}
+		if e.Topic == "unrelated" && !slices.Equal(e.Tags, []string{"docs"}) {
+			t.Errorf("unrelated plan retagged: %v", e.Tags)
+		}
+	}
+
+	tasks, err := task.ReadAllTaskIndex(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tk := range tasks {
+		switch tk.Title {
+		case "Tagged task":
+			if !slices.Equal(tk.Tags, []string{"authn"}) {
+				t.Errorf("task tags = %v, want [authn]", tk.Tags)
+			}
+		case "Other task":
+			if !slices.Equal(tk.Tags, []string{"cli"}) {
+				t.Errorf("untagged task changed: %v", tk.Tags)
+			}
+		}
+	}
+}
+
+func TestRetag_DryRunWritesNothing(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runSave("auth refactor", []string{"auth"}, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	out := captureOutput(t, func() {
+		if err := runRetag("auth", []string{"authn"}, nil, "", "", true); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "would retag 1 plan(s)") {
+		t.Errorf("unexpected dry-run output:\n%s", out)
+	}
+	plans, _ := plan.LoadAll(dir)
+	if len(plans) != 1 || !slices.Equal(plans[0].Tags, []string{"auth"}) {
+		t.Errorf("dry run changed the plan: %+v", plans)
+	}
+}
+
+func TestRetag_Errors(t *testing.T) {
+	setupInitedProject(t)
+	if err := runRetag("auth", nil, nil, "", "", false); err == nil {
+		t.Error("expected error without --add or --remove")
+	}
+	if err := runRetag("auth", []string{"x"}, nil, "sessions", "", false); err == nil {
+		t.Error("expected error for unknown --only")
+	}
+}
@@ -5,11 +5,14 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
 	"github.com/senna-lang/logosyncx/internal/updater"
 	"github.com/senna-lang/logosyncx/internal/version"
+	"github.com/senna-lang/logosyncx/pkg/config"
 	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
 )
 
 // suppressUpdateCheck can be set to true by commands that emit --json output
@@ -23,6 +26,33 @@ var rootCmd = &cobra.Command{
 in git repositories. It lets agents save plans, track tasks, distill knowledge,
 and search past context — enabling team-wide context sharing without external
 databases or embedding servers.`,
+	// PersistentPreRunE resolves the output profile, the warning format, the
+	// --yes flag, and the --git override before any subcommand runs.
+	PersistentPreRunE: func(cmd *cobra.Command, args []string) error {
+		flag, _ := cmd.Flags().GetBool("for-agent")
+		forAgent = detectAgentOutput(flag)
+		assumeYes, _ = cmd.Flags().GetBool("yes")
+		wj, _ := cmd.Flags().GetBool("warnings-json")
+		warningsJSON = wj || os.Getenv("LOGOS_WARNINGS_JSON") == "1"
+		warningCommand = strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name()+" ")
+		warningProject = ""
+		if warningsJSON {
+			warningProject = currentProjectName()
+		}
+
+		// --git reaches the config through loadConfig.
+		if level, _ := cmd.Flags().GetString("git"); level != "" {
+			if err := checkGitLevel(level); err != nil {
+				return err
+			}
+			gitOverride = level
+		} else if level := os.Getenv(config.GitEnv); level != "" {
+			if err := checkGitLevel(level); err != nil {
+				return fmt.Errorf("$%s: %w", config.GitEnv, err)
+			}
+		}
+		return nil
+	},
 	// PersistentPostRun fires after every subcommand (including nested ones).
 	// It performs a lightweight update check and prints a one-line hint to
 	// stderr when a newer version is available.
@@ -31,6 +61,7 @@ databases or embedding servers.`,
 	//   - Skipped for dev builds (no meaningful version to compare against).
 	//   - Skipped when LOGOS_NO_UPDATE_CHECK=1 (CI / automation opt-out).
 	//   - Skipped when the subcommand set suppressUpdateCheck = true (--json output).
+	//   - Skipped in the agent output profile (--for-agent, LOGOS_AGENT=1, non-TTY).
 	//   - The check is served from a local cache file; a network call is only
 	//     made when the cache is older than 24 hours.
 	//   - A 2-second context deadline prevents any noticeable latency on the
@@ -42,21 +73,68 @@ databases or embedding servers.`,
 
 // Execute adds all child commands to the root command and sets flags appropriately.
 func Execute() {
-	if err := rootCmd.Execute(); err != nil {
+	if err := Run(os.Args[1:]); err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
+// Run executes the logos command line args (without the program name)
+// in-process and returns the command's error instead of exiting. Every flag
+// is reset to its default first, so Run can be called repeatedly in one
+// process. Commands read and write os.Stdin, os.Stdout, and os.Stderr and
+// resolve the project from the working directory; pkg/testcli redirects
+// them for tests. Run is not safe for concurrent use.
+func Run(args []string) error {
+	resetFlags(rootCmd)
+	suppressUpdateCheck, fullTables = false, false
+	defer func() { gitOverride = "" }()
+	rootCmd.SetArgs(args)
+
+	start := time.Now()
+	cmd, err := rootCmd.ExecuteC()
+	emitMetrics(cmd, start, err)
+	return err
+}
+
+// resetFlags restores every flag of c and its subcommands to its default
+// value, undoing an earlier Run.
+func resetFlags(c *cobra.Command) {
+	reset := func(f *pflag.Flag) {
+		if !f.Changed {
+			return
+		}
+		if sv, ok := f.Value.(pflag.SliceValue); ok {
+			var def []string
+			if s := strings.Trim(f.DefValue, "[]"); s != "" {
+				def = strings.Split(s, ",")
+			}
+			_ = sv.Replace(def)
+		} else {
+			_ = f.Value.Set(f.DefValue)
+		}
+		f.Changed = false
+	}
+	c.Flags().VisitAll(reset)
+	c.PersistentFlags().VisitAll(reset)
+	for _, sub := range c.Commands() {
+		resetFlags(sub)
+	}
+}
+
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.PersistentFlags().Bool("for-agent", false, "Strip decoration (check marks, hints) and print only parseable output (also: LOGOS_AGENT=1)")
+	rootCmd.PersistentFlags().Bool("warnings-json", false, "Write warnings to stderr as JSON lines ({\"level\":\"warning\",\"command\":...,\"message\":...}) (also: LOGOS_WARNINGS_JSON=1)")
+	rootCmd.PersistentFlags().BoolP("yes", "y", false, "Answer yes to confirmation prompts (required when stdin is not a terminal)")
+	rootCmd.PersistentFlags().String("git", "", "Override git.auto for this command: off, add, commit, or push (also: LOGOS_GIT)")
 }
 
 // printUpdateHintIfAvailable checks for an available update and prints a
 // one-line hint to stderr if one is found. It returns immediately without
 // printing anything on error or when the check is suppressed.
 func printUpdateHintIfAvailable() {
-	if suppressUpdateCheck {
+	if suppressUpdateCheck || forAgent {
 		return
 	}
 	if version.IsDev() {
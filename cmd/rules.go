@@ -0,0 +1,99 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/spf13/cobra"
+)
+
+var rulesCmd = &cobra.Command{
+	Use:   "rules",
+	Short: "Inspect task routing rules from config",
+	Long: `Task routing rules (tasks.rules in .logosyncx/config.json) set the
+assignee and priority of new tasks based on their tags, e.g.
+
+  "rules": [{"tag": "infra", "assignee": "ops-team", "priority": "high"}]
+
+Rules are applied by logos task create unless --no-rules is passed.`,
+}
+
+var rulesTestCmd = &cobra.Command{
+	Use:   "test",
+	Short: "Preview which rules would apply to a new task",
+	Long: `Show the configured rules and the assignee/priority a task with the given
+tags (and plan) would receive from them. Nothing is written.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		tags, _ := cmd.Flags().GetStringArray("tag")
+		planSlug, _ := cmd.Flags().GetString("plan")
+		return runRulesTest(tags, planSlug)
+	},
+}
+
+func init() {
+	rulesTestCmd.Flags().StringArray("tag", []string{}, "Tag of the hypothetical task (repeatable)")
+	rulesTestCmd.Flags().StringP("plan", "P", "", "Plan slug of the hypothetical task")
+	rulesCmd.AddCommand(rulesTestCmd)
+	rootCmd.AddCommand(rulesCmd)
+}
+
+func runRulesTest(tags []string, planSlug string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	rules := cfg.Tasks.Rules
+	if len(rules) == 0 {
+		fmt.Println("No routing rules configured (tasks.rules in .logosyncx/config.json).")
+		return nil
+	}
+
+	t := task.Task{Tags: tags, Plan: planSlug}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "#\tTAG\tPLAN\tASSIGNEE\tPRIORITY\tMATCH")
+	fmt.Fprintln(w, "-\t---\t----\t--------\t--------\t-----")
+	for i, r := range rules {
+		match := "no"
+		if task.RuleMatches(r, &t) {
+			match = "yes"
+		}
+		fmt.Fprintf(w, "%d\t%s\t%s\t%s\t%s\t%s\n", i+1, r.Tag, dashIfEmpty(r.Plan),
+			dashIfEmpty(r.Assignee), dashIfEmpty(r.Priority), match)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	effects, err := task.ApplyRules(&t, rules)
+	if err != nil {
+		return err
+	}
+	fmt.Println()
+	if len(effects) == 0 {
+		fmt.Println("No rule applies; the task would keep the defaults.")
+		return nil
+	}
+	fmt.Println("A new task would get:")
+	for _, e := range effects {
+		fmt.Printf("  %s\n", e)
+	}
+	return nil
+}
+
+// dashIfEmpty returns "-" for an empty string, for table output.
+func dashIfEmpty(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
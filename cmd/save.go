@@ -2,14 +2,17 @@
 package cmd
 
 import (
+	"cmp"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"time"
 
-	"github.com/senna-lang/logosyncx/internal/gitutil"
 	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
 	"github.com/senna-lang/logosyncx/pkg/config"
 	"github.com/senna-lang/logosyncx/pkg/index"
 	"github.com/senna-lang/logosyncx/pkg/plan"
@@ -22,31 +25,107 @@ var saveCmd = &cobra.Command{
 	Short: "Scaffold a plan file in .logosyncx/plans/",
 	Long: `Create a plan frontmatter scaffold in .logosyncx/plans/.
 
-  logos save --topic "..." [--tag <tag>] [--agent <agent>] \
-             [--related <plan>] [--depends-on <partial-plan-name>]
+  logos save --topic "..." [--tag <tag>] [--agent <agent>] [--category <c>] \
+             [--template <name>] [--related <plan>] [--depends-on <partial-plan-name>] \
+             [--for-task <partial-task-name>] [--start]
+
+Each --related value must name an existing plan (archived plans included);
+a partial name is resolved to the full filename, and a typo is an error
+with a suggestion when a plan name is close.
 
 The CLI writes frontmatter only. Open the file and fill in the body sections
-guided by .logosyncx/templates/plan.md.`,
+guided by .logosyncx/templates/plan.md.
+
+--category records the kind of plan (design, incident, research, meeting,
+or the values in plans.categories) and scaffolds the body with that
+category's sections (plans.category_sections overrides the built-in ones).
+
+--template scaffolds the body from a named template instead: its sections,
+with their default content, in order, and its tags. bugfix and retro are
+built in; the templates map in config.json adds more or overrides them.
+
+--for-task records that the plan captures work on an existing task: the
+task's ID is added to the plan's related_tasks and the plan filename to the
+task's related_plans. For each linked task that is still open, logos offers
+to mark it in_progress; --start (or --yes) does so without asking, and
+without a terminal the task is left open.
+
+A --tag not used by any plan or task yet that is within two edits of an
+existing tag (or differs only in case) prints a warning suggesting the
+existing one; --no-suggest silences it.
+
+--from-stdin-json reads the plan from a JSON object on stdin instead of
+leaving the body to be written by hand, so multiline content needs no
+shell escaping:
+
+  {"topic": "...", "tags": ["..."], "agent": "...", "category": "...",
+   "sections": {"Background": "...", "Spec": "..."}}
+
+Each sections key must be a heading of templates/plan.md, of the category
+or --template scaffold, or one named in plans.required_sections,
+summary_sections, excerpt_section, or excerpt_fallback (case-insensitive);
+an unknown heading saves nothing. Sections are written in scaffold order.
+Fields left out of the document fall back to the flags, and --tag values
+are added to the document's tags.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		topic, _ := cmd.Flags().GetString("topic")
 		tags, _ := cmd.Flags().GetStringArray("tag")
 		agent, _ := cmd.Flags().GetString("agent")
 		related, _ := cmd.Flags().GetStringArray("related")
 		dependsOn, _ := cmd.Flags().GetStringArray("depends-on")
-		return runSave(topic, tags, agent, related, dependsOn)
+		forTasks, _ := cmd.Flags().GetStringArray("for-task")
+		start, _ := cmd.Flags().GetBool("start")
+		category, _ := cmd.Flags().GetString("category")
+		templateName, _ := cmd.Flags().GetString("template")
+		var sections map[string]string
+		if fromJSON, _ := cmd.Flags().GetBool("from-stdin-json"); fromJSON {
+			var doc planDocument
+			if err := readJSONDocument(os.Stdin, &doc); err != nil {
+				return err
+			}
+			topic = cmp.Or(doc.Topic, topic)
+			agent = cmp.Or(doc.Agent, agent)
+			category = cmp.Or(doc.Category, category)
+			tags = append(doc.Tags, tags...)
+			sections = doc.Sections
+			if sections == nil {
+				sections = map[string]string{}
+			}
+		}
+		if noSuggest, _ := cmd.Flags().GetBool("no-suggest"); !noSuggest {
+			if root, err := project.FindRoot(); err == nil {
+				if cfg, err := loadConfig(root); err == nil {
+					warnTagTypos(root, tags, cfg.Plans.AllowedTags)
+				}
+			}
+		}
+		return runSaveSections(topic, tags, agent, category, related, dependsOn, forTasks, start, templateName, sections)
 	},
 }
 
 func init() {
-	saveCmd.Flags().StringP("topic", "t", "", "Plan topic (required)")
+	saveCmd.Flags().StringP("topic", "t", "", "Plan topic (required unless set by --from-stdin-json)")
 	saveCmd.Flags().StringArray("tag", []string{}, "Tag to attach (repeatable: --tag go --tag cli)")
+	saveCmd.Flags().Bool("no-suggest", false, "Do not suggest existing tags for new, similar-looking tags")
 	saveCmd.Flags().StringP("agent", "a", "", "Agent name (e.g. claude-code)")
-	saveCmd.Flags().StringArray("related", []string{}, "Related plan filename (repeatable)")
+	saveCmd.Flags().String("category", "", "Plan category: design, incident, research, meeting (or plans.categories)")
+	saveCmd.Flags().String("template", "", "Scaffold the body from a named template (built-in: bugfix, retro; or templates in config)")
+	saveCmd.Flags().StringArray("related", []string{}, "Related plan (partial name, repeatable)")
 	saveCmd.Flags().StringArray("depends-on", []string{}, "Plan this depends on (partial name, repeatable)")
+	saveCmd.Flags().StringArray("for-task", []string{}, "Task this plan records work on (partial name, repeatable)")
+	saveCmd.Flags().Bool("start", false, "Mark open --for-task tasks in_progress without asking")
+	saveCmd.Flags().Bool("from-stdin-json", false, "Read topic, tags, agent, category, and body sections from a JSON object on stdin")
 	rootCmd.AddCommand(saveCmd)
 }
 
-func runSave(topic string, tags []string, agent string, related []string, dependsOnPartials []string) error {
+func runSave(topic string, tags []string, agent, category string, related []string, dependsOnPartials []string, forTasks []string, start bool, templateName string) error {
+	return runSaveSections(topic, tags, agent, category, related, dependsOnPartials, forTasks, start, templateName, nil)
+}
+
+// runSaveSections is runSave with the content of body sections, keyed by
+// heading, written into the scaffold. Every heading must be one of
+// planSections.
+func runSaveSections(topic string, tags []string, agent, category string, related []string, dependsOnPartials []string, forTasks []string, start bool, templateName string, sections map[string]string) error {
 	if strings.TrimSpace(topic) == "" {
 		return errors.New("provide --topic <topic>")
 	}
@@ -56,11 +135,33 @@ func runSave(topic string, tags []string, agent string, related []string, depend
 		return err
 	}
 
-	cfg, err := config.Load(root)
+	cfg, err := loadConfig(root)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
+	if err := config.CheckTags(tags, cfg.Plans.AllowedTags, "plans.allowed_tags"); err != nil {
+		return err
+	}
+	tags = config.MergeTags(tags, cfg.Plans.DefaultTags)
+	if err := cfg.Plans.CheckCategory(category); err != nil {
+		return err
+	}
+	body := categoryBody(cfg.Plans.CategorySectionsFor(category))
+	if templateName != "" {
+		tmpl, err := cfg.Template(templateName)
+		if err != nil {
+			return err
+		}
+		tags = config.MergeTags(tags, tmpl.Tags)
+		body = tmpl.Body()
+	}
+	if sections != nil {
+		if body, err = fillSections(body, sections, planSections(root, cfg, category, templateName)); err != nil {
+			return err
+		}
+	}
+
 	// Load existing plans to resolve --depends-on partial matches.
 	allPlans, err := plan.LoadAll(root)
 	if err != nil {
@@ -71,10 +172,19 @@ func runSave(topic string, tags []string, agent string, related []string, depend
 	if err != nil {
 		return err
 	}
+	related, err = resolveRelated(related, planFilenames(root, allPlans))
+	if err != nil {
+		return err
+	}
 
-	// Check for circular plan dependencies.
-	candidateFilename := plan.FileName(plan.Plan{Topic: topic})
-	if err := detectCircular(candidateFilename, resolvedDeps, allPlans); err != nil {
+	if err := plan.ValidateFilenamePattern(cfg.Plans.FilenamePattern); err != nil {
+		return fmt.Errorf("plans.filename_pattern: %w", err)
+	}
+
+	// Resolve --for-task before writing anything so a typo saves nothing.
+	store := task.NewStore(root, &cfg)
+	linked, err := resolveForTasks(store, forTasks)
+	if err != nil {
 		return err
 	}
 
@@ -83,18 +193,37 @@ func runSave(topic string, tags []string, agent string, related []string, depend
 		return fmt.Errorf("generate id: %w", err)
 	}
 
+	// The date is stored as RFC 3339 with the local offset, so the index
+	// and --since filters see the same instant on every machine.
+	now := time.Now().Truncate(time.Second)
 	p := plan.Plan{
 		ID:        id,
+		Date:      &now,
 		Topic:     topic,
 		Tags:      tags,
 		Agent:     agent,
+		Category:  category,
 		Related:   related,
 		DependsOn: resolvedDeps,
+		Branch:    recordBranch(cfg),
+		Body:      body,
+	}
+	for _, t := range linked {
+		if t.ID != "" {
+			p.RelatedTasks = append(p.RelatedTasks, t.ID)
+		}
 	}
+	fields := append([]privacyField{{"topic", &p.Topic}, {"body", &p.Body}}, tagFields(p.Tags)...)
+	if err := enforcePrivacy(cfg, "plan", fields...); err != nil {
+		return err
+	}
+	p.Filename = plan.FileNameWithPattern(p, cfg.Plans.FilenamePattern)
+	p.TasksDir = plan.DefaultTasksDir(p.Filename)
 
-	// DefaultTasksDir is set after FileName is known.
-	filename := plan.FileName(p)
-	p.TasksDir = plan.DefaultTasksDir(filename)
+	// Check for circular plan dependencies.
+	if err := detectCircular(p.Filename, resolvedDeps, allPlans); err != nil {
+		return err
+	}
 
 	savedPath, err := plan.Write(root, p)
 	if err != nil {
@@ -102,23 +231,129 @@ func runSave(topic string, tags []string, agent string, related []string, depend
 	}
 
 	rel, _ := relPath(root, savedPath)
-	fmt.Printf("✓ Created plan: %s\n", rel)
+	printSuccess("Created plan: %s", rel)
 
 	// Rebuild the full plan index so logos ls reflects the new plan immediately.
-	if _, indexErr := index.Rebuild(root, cfg.Plans.ExcerptSection); indexErr != nil {
-		fmt.Fprintf(os.Stderr, "warning: could not rebuild index (%v) — run `logos sync` to rebuild\n", indexErr)
+	if _, indexErr := index.RebuildWithOptions(root, planIndexOptions(cfg)); indexErr != nil {
+		warnf("could not rebuild index (%v) — run `logos sync` to rebuild", indexErr)
 	}
+	warnGuardrails(root, cfg)
 
-	// Stage with git (best-effort).
-	_ = gitutil.Add(root, savedPath)
-	_ = gitutil.Add(root, index.FilePath(root))
+	// Stage with git (best-effort). Unlike most commands, save stages
+	// when git.auto is unset; only "off" stops it.
+	stageFilesByDefault(root, cfg, savedPath, index.FilePath(root))
 
-	fmt.Println()
-	fmt.Printf("Next: fill in the plan body in %s\n", rel)
-	fmt.Printf("      (read .logosyncx/templates/plan.md for section structure)\n")
+	written := []string{savedPath, index.FilePath(root)}
+	if len(linked) > 0 {
+		linkSavedPlan(cfg, store, filepath.Base(savedPath), linked, start)
+		for _, t := range linked {
+			written = append(written, t.DirPath)
+		}
+		written = append(written, task.TaskIndexFilePath(root))
+	}
+	autoCommit(root, cfg, config.CommitSave, map[string]string{"topic": p.Topic, "filename": filepath.Base(savedPath)}, written...)
+
+	if sections != nil {
+		printHint(fmt.Sprintf("Next: review the plan body in %s", rel))
+		return nil
+	}
+	printHint(
+		fmt.Sprintf("Next: fill in the plan body in %s", rel),
+		"      (read .logosyncx/templates/plan.md for section structure)",
+	)
 	return nil
 }
 
+// newCategoryPlan returns a new, unwritten plan of category for topic,
+// dated now, with the body scaffolded from the category's sections. The
+// category and tags are checked against config.
+func newCategoryPlan(cfg config.Config, topic, agent string, tags []string, category string, now time.Time) (plan.Plan, error) {
+	if err := cfg.Plans.CheckCategory(category); err != nil {
+		return plan.Plan{}, err
+	}
+	if err := config.CheckTags(tags, cfg.Plans.AllowedTags, "plans.allowed_tags"); err != nil {
+		return plan.Plan{}, err
+	}
+	if err := plan.ValidateFilenamePattern(cfg.Plans.FilenamePattern); err != nil {
+		return plan.Plan{}, fmt.Errorf("plans.filename_pattern: %w", err)
+	}
+	id, err := plan.GenerateID()
+	if err != nil {
+		return plan.Plan{}, fmt.Errorf("generate id: %w", err)
+	}
+	now = now.Truncate(time.Second)
+	p := plan.Plan{
+		ID:       id,
+		Date:     &now,
+		Topic:    topic,
+		Tags:     config.MergeTags(tags, cfg.Plans.DefaultTags),
+		Agent:    agent,
+		Category: category,
+		Body:     categoryBody(cfg.Plans.CategorySectionsFor(category)),
+	}
+	p.Filename = plan.FileNameWithPattern(p, cfg.Plans.FilenamePattern)
+	p.TasksDir = plan.DefaultTasksDir(p.Filename)
+	return p, nil
+}
+
+// categoryBody returns a body scaffold with an empty "## <heading>" section
+// for each of sections, or "" when there are none.
+func categoryBody(sections []string) string {
+	var b strings.Builder
+	for i, s := range sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n", s)
+	}
+	return b.String()
+}
+
+// resolveForTasks resolves each --for-task partial name to a single task.
+func resolveForTasks(store *task.Store, names []string) ([]*task.Task, error) {
+	var tasks []*task.Task
+	for _, name := range names {
+		t, err := store.Get("", name)
+		if err != nil {
+			return nil, fmt.Errorf("--for-task: %w", err)
+		}
+		if !slices.ContainsFunc(tasks, func(o *task.Task) bool { return o.DirPath == t.DirPath }) {
+			tasks = append(tasks, t)
+		}
+	}
+	return tasks, nil
+}
+
+// linkSavedPlan adds the saved plan to the related_plans of each linked task
+// and offers to move open ones to in_progress. Failures are warnings: the
+// plan itself is already saved.
+func linkSavedPlan(cfg config.Config, store *task.Store, filename string, linked []*task.Task, start bool) {
+	links := make(map[string][]string, len(linked))
+	for _, t := range linked {
+		links[t.DirPath] = []string{filename}
+	}
+	if _, err := store.AddRelatedPlans(links); err != nil {
+		warnf("could not link tasks to %s: %v", filename, err)
+	}
+
+	for _, t := range linked {
+		name := filepath.Base(t.DirPath)
+		printSuccess("Linked task %s/%s", t.Plan, name)
+		if t.Status != task.StatusOpen {
+			continue
+		}
+		if !offer(cfg, fmt.Sprintf("Mark task %q in_progress?", t.Title), start) {
+			printHint(fmt.Sprintf("Next: logos task update --plan %s --name %s --status in_progress", t.Plan, name))
+			continue
+		}
+		if err := store.UpdateFields(t.Plan, name, map[string]string{"status": string(task.StatusInProgress)}); err != nil {
+			warnf("could not start task %s: %v", name, err)
+			continue
+		}
+		printSuccess("Task %s/%s: open → in_progress", t.Plan, name)
+	}
+}
+
 // detectCircular returns an error if candidateFilename is a transitive
 // dependency of itself via the resolved deps slice.
 func detectCircular(candidateFilename string, deps []string, allPlans []plan.Plan) error {
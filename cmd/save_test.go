@@ -6,6 +6,8 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
 	"github.com/senna-lang/logosyncx/pkg/plan"
 )
 
@@ -22,7 +24,7 @@ func setupInitedProject(t *testing.T) string {
 		t.Fatalf("chdir: %v", err)
 	}
 	t.Cleanup(func() { _ = os.Chdir(orig) })
-	if err := runInit(); err != nil {
+	if err := runInit(false); err != nil {
 		t.Fatalf("runInit: %v", err)
 	}
 	return dir
@@ -31,7 +33,7 @@ func setupInitedProject(t *testing.T) string {
 // --- flag validation ---------------------------------------------------------
 
 func TestSave_ErrorWhenNoTopicProvided(t *testing.T) {
-	err := runSave("", nil, "", nil, nil)
+	err := runSave("", nil, "", "", nil, nil, nil, false, "")
 	if err == nil {
 		t.Fatal("expected error when no topic provided, got nil")
 	}
@@ -46,7 +48,7 @@ func TestSave_ErrorWhenNotInitialized(t *testing.T) {
 	_ = os.Chdir(dir)
 	t.Cleanup(func() { _ = os.Chdir(orig) })
 
-	err := runSave("no-init", nil, "", nil, nil)
+	err := runSave("no-init", nil, "", "", nil, nil, nil, false, "")
 	if err == nil {
 		t.Fatal("expected error when project not initialized, got nil")
 	}
@@ -60,7 +62,7 @@ func TestSave_ErrorWhenNotInitialized(t *testing.T) {
 func TestSave_CreatesInPlansDir(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runSave("test topic", nil, "", nil, nil); err != nil {
+	if err := runSave("test topic", nil, "", "", nil, nil, nil, false, ""); err != nil {
 		t.Fatalf("runSave failed: %v", err)
 	}
 
@@ -76,7 +78,7 @@ func TestSave_CreatesInPlansDir(t *testing.T) {
 func TestSave_FileNameFormat_YYYYMMDD(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runSave("filename format", nil, "", nil, nil); err != nil {
+	if err := runSave("filename format", nil, "", "", nil, nil, nil, false, ""); err != nil {
 		t.Fatalf("runSave failed: %v", err)
 	}
 
@@ -108,7 +110,7 @@ func TestSave_FileNameFormat_YYYYMMDD(t *testing.T) {
 func TestSave_TasksDirSetInFrontmatter(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runSave("tasks dir test", nil, "", nil, nil); err != nil {
+	if err := runSave("tasks dir test", nil, "", "", nil, nil, nil, false, ""); err != nil {
 		t.Fatalf("runSave failed: %v", err)
 	}
 
@@ -130,7 +132,7 @@ func TestSave_TasksDirSetInFrontmatter(t *testing.T) {
 func TestSave_ScaffoldOnly_NoBody(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runSave("scaffold only", nil, "", nil, nil); err != nil {
+	if err := runSave("scaffold only", nil, "", "", nil, nil, nil, false, ""); err != nil {
 		t.Fatalf("runSave failed: %v", err)
 	}
 
@@ -148,8 +150,15 @@ func TestSave_ScaffoldOnly_NoBody(t *testing.T) {
 
 func TestSave_AllFrontmatterFields(t *testing.T) {
 	dir := setupInitedProject(t)
+	// --related must name an existing plan; an archived one will do.
+	if err := os.MkdirAll(plan.ArchiveDir(dir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(plan.ArchiveDir(dir), "old-plan.md"), []byte("---\ntopic: old\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
 
-	if err := runSave("all fields", []string{"go", "cli"}, "claude-code", []string{"old-plan.md"}, nil); err != nil {
+	if err := runSave("all fields", []string{"go", "cli"}, "claude-code", "", []string{"old-plan.md"}, nil, nil, false, ""); err != nil {
 		t.Fatalf("runSave failed: %v", err)
 	}
 
@@ -181,12 +190,12 @@ func TestSave_DependsOn_ResolvesPartialMatch(t *testing.T) {
 	dir := setupInitedProject(t)
 
 	// Create a first plan to depend on.
-	if err := runSave("auth refactor", nil, "", nil, nil); err != nil {
+	if err := runSave("auth refactor", nil, "", "", nil, nil, nil, false, ""); err != nil {
 		t.Fatalf("first runSave failed: %v", err)
 	}
 
 	// Create a second plan that depends on it via partial name.
-	if err := runSave("jwt middleware", nil, "", nil, []string{"auth"}); err != nil {
+	if err := runSave("jwt middleware", nil, "", "", nil, []string{"auth"}, nil, false, ""); err != nil {
 		t.Fatalf("second runSave with --depends-on failed: %v", err)
 	}
 
@@ -216,7 +225,7 @@ func TestSave_DependsOn_ResolvesPartialMatch(t *testing.T) {
 func TestSave_DependsOn_NotFound_HardError(t *testing.T) {
 	setupInitedProject(t)
 
-	err := runSave("some plan", nil, "", nil, []string{"nonexistent-plan"})
+	err := runSave("some plan", nil, "", "", nil, []string{"nonexistent-plan"}, nil, false, "")
 	if err == nil {
 		t.Fatal("expected error for nonexistent plan, got nil")
 	}
@@ -229,14 +238,14 @@ func TestSave_DependsOn_Ambiguous_HardError(t *testing.T) {
 	setupInitedProject(t)
 
 	// Create two plans with "api" in their names.
-	if err := runSave("api auth", nil, "", nil, nil); err != nil {
+	if err := runSave("api auth", nil, "", "", nil, nil, nil, false, ""); err != nil {
 		t.Fatalf("runSave api-auth failed: %v", err)
 	}
-	if err := runSave("api gateway", nil, "", nil, nil); err != nil {
+	if err := runSave("api gateway", nil, "", "", nil, nil, nil, false, ""); err != nil {
 		t.Fatalf("runSave api-gateway failed: %v", err)
 	}
 
-	err := runSave("new plan", nil, "", nil, []string{"api"})
+	err := runSave("new plan", nil, "", "", nil, []string{"api"}, nil, false, "")
 	if err == nil {
 		t.Fatal("expected error for ambiguous plan name, got nil")
 	}
@@ -314,3 +323,271 @@ func TestBlockedByDep_NoDeps(t *testing.T) {
 		t.Errorf("expected empty blocker for plan with no deps, got %q", blocker)
 	}
 }
+
+// --- tag vocabulary / default tags -------------------------------------------
+
+func TestSave_RejectsTagOutsideVocabulary(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	cfg.Plans.AllowedTags = []string{"backend", "frontend"}
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatalf("config.Save: %v", err)
+	}
+
+	err = runSave("Typo tag", []string{"backedn"}, "", "", nil, nil, nil, false, "")
+	if err == nil {
+		t.Fatal("expected error for tag outside plans.allowed_tags")
+	}
+	if !strings.Contains(err.Error(), `did you mean "backend"`) {
+		t.Errorf("expected suggestion in error, got: %v", err)
+	}
+}
+
+func TestSave_AppliesDefaultTags(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	cfg.Plans.DefaultTags = []string{"payments"}
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatalf("config.Save: %v", err)
+	}
+
+	if err := runSave("With defaults", []string{"go"}, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatalf("runSave: %v", err)
+	}
+	plans, err := plan.LoadAll(dir)
+	if err != nil || len(plans) != 1 {
+		t.Fatalf("LoadAll: %v (%d plans)", err, len(plans))
+	}
+	if strings.Join(plans[0].Tags, ",") != "go,payments" {
+		t.Errorf("Tags = %v, want [go payments]", plans[0].Tags)
+	}
+}
+
+// --- --for-task --------------------------------------------------------------
+
+func TestSave_ForTaskLinksAndStarts(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Wire login", priority: "medium"}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	captureOutput(t, func() {
+		if err := runSave("login session", nil, "", "", nil, nil, []string{"wire-login"}, true, ""); err != nil {
+			t.Fatalf("runSave --for-task: %v", err)
+		}
+	})
+
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+	tk := tasks[0]
+	if tk.Status != task.StatusInProgress {
+		t.Errorf("status = %s, want in_progress", tk.Status)
+	}
+	plans, err := plan.LoadAll(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var saved plan.Plan
+	for _, p := range plans {
+		if p.Topic == "login session" {
+			saved = p
+		}
+	}
+	if len(tk.RelatedPlans) != 1 || tk.RelatedPlans[0] != saved.Filename {
+		t.Errorf("related_plans = %v, want [%s]", tk.RelatedPlans, saved.Filename)
+	}
+	if len(saved.RelatedTasks) != 1 || saved.RelatedTasks[0] != tk.ID {
+		t.Errorf("plan related_tasks = %v, want [%s]", saved.RelatedTasks, tk.ID)
+	}
+}
+
+func TestSave_ForTaskWithoutTerminalLeavesTaskOpen(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Wire login", priority: "medium"}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	usePrompter(t, "", false)
+
+	captureOutput(t, func() {
+		if err := runSave("login session", nil, "", "", nil, nil, []string{"wire-login"}, false, ""); err != nil {
+			t.Fatalf("runSave --for-task: %v", err)
+		}
+	})
+	tasks := loadAllTasks(t, dir)
+	if tasks[0].Status != task.StatusOpen {
+		t.Errorf("status = %s, want open", tasks[0].Status)
+	}
+	if len(tasks[0].RelatedPlans) != 1 {
+		t.Errorf("expected task to be linked, got %v", tasks[0].RelatedPlans)
+	}
+}
+
+func TestSave_ForTaskUnknownSavesNothing(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runSave("orphan", nil, "", "", nil, nil, []string{"no-such-task"}, false, ""); err == nil {
+		t.Fatal("expected error for unknown --for-task")
+	}
+	plans, _ := plan.LoadAll(dir)
+	for _, p := range plans {
+		if p.Topic == "orphan" {
+			t.Error("plan must not be written when --for-task does not resolve")
+		}
+	}
+}
+
+func TestSave_FilenamePattern(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Plans.FilenamePattern = "{{id}}_{{slug}}.md"
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSave("Pattern topic", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatalf("runSave: %v", err)
+	}
+	plans, err := plan.LoadAll(dir)
+	if err != nil || len(plans) != 1 {
+		t.Fatalf("LoadAll: %v, %d plans", err, len(plans))
+	}
+	p := plans[0]
+	if want := p.ID + "_pattern-topic.md"; p.Filename != want {
+		t.Errorf("Filename = %q, want %q", p.Filename, want)
+	}
+	if want := plan.DefaultTasksDir(p.Filename); p.TasksDir != want {
+		t.Errorf("TasksDir = %q, want %q", p.TasksDir, want)
+	}
+
+	cfg.Plans.FilenamePattern = "{{date}}.md"
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSave("Another", nil, "", "", nil, nil, nil, false, ""); err == nil || !strings.Contains(err.Error(), "plans.filename_pattern") {
+		t.Errorf("expected pattern error, got: %v", err)
+	}
+}
+
+// --- --related ---------------------------------------------------------------
+
+func TestSave_Related_ResolvesPartialName(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runSave("auth refactor", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSave("jwt middleware", nil, "", "", []string{"auth-refactor"}, nil, nil, false, ""); err != nil {
+		t.Fatalf("runSave with partial --related: %v", err)
+	}
+	plans, err := plan.LoadAll(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, p := range plans {
+		if p.Topic != "jwt middleware" {
+			continue
+		}
+		if len(p.Related) != 1 || !strings.HasSuffix(p.Related[0], "-auth-refactor.md") {
+			t.Errorf("related = %v, want the full auth-refactor filename", p.Related)
+		}
+	}
+}
+
+func TestSave_Related_TypoSuggestsPlan(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runSave("auth refactor", nil, "", "", nil, nil, nil, false, ""); err != nil {
+		t.Fatal(err)
+	}
+	err := runSave("jwt middleware", nil, "", "", []string{"auth-refactr"}, nil, nil, false, "")
+	if err == nil || !strings.Contains(err.Error(), "did you mean") {
+		t.Fatalf("expected a suggestion for the typo, got %v", err)
+	}
+	plans, _ := plan.LoadAll(dir)
+	if len(plans) != 1 {
+		t.Errorf("expected nothing saved after a bad --related, got %d plans", len(plans))
+	}
+}
+
+func TestSave_CategoryScaffoldsSections(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runSave("api outage", nil, "", "incident", nil, nil, nil, false, ""); err != nil {
+		t.Fatalf("runSave: %v", err)
+	}
+	plans, _ := plan.LoadAll(dir)
+	if len(plans) != 1 || plans[0].Category != "incident" {
+		t.Fatalf("expected one incident plan, got %+v", plans)
+	}
+	for _, h := range []string{"## Summary", "## Timeline", "## Resolution"} {
+		if !strings.Contains(plans[0].Body, h) {
+			t.Errorf("expected %q in body, got:\n%s", h, plans[0].Body)
+		}
+	}
+}
+
+func TestSave_CategorySectionsFromConfig(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, _ := config.Load(dir)
+	cfg.Plans.Categories = []string{"design", "rfc"}
+	cfg.Plans.CategorySections = map[string][]string{"rfc": {"Motivation", "Proposal"}}
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSave("new api", nil, "", "rfc", nil, nil, nil, false, ""); err != nil {
+		t.Fatalf("runSave: %v", err)
+	}
+	plans, _ := plan.LoadAll(dir)
+	if !strings.Contains(plans[0].Body, "## Motivation") || !strings.Contains(plans[0].Body, "## Proposal") {
+		t.Errorf("expected configured sections, got:\n%s", plans[0].Body)
+	}
+}
+
+func TestSave_RejectsUnknownCategory(t *testing.T) {
+	setupInitedProject(t)
+	err := runSave("typo", nil, "", "incidnet", nil, nil, nil, false, "")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "incident"`) {
+		t.Errorf("expected suggestion for unknown category, got: %v", err)
+	}
+}
+
+func TestSave_TemplateScaffoldsSections(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, _ := config.Load(dir)
+	cfg.Templates = map[string]config.TemplateConfig{
+		"adr": {
+			Sections: []config.SectionConfig{{Name: "Context"}, {Name: "Decision", Content: "We will ..."}},
+			Tags:     []string{"adr"},
+		},
+	}
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if err := runSave("queue choice", []string{"infra"}, "", "", nil, nil, nil, false, "adr"); err != nil {
+		t.Fatalf("runSave: %v", err)
+	}
+	plans, _ := plan.LoadAll(dir)
+	if len(plans) != 1 || !strings.Contains(plans[0].Body, "## Context\n\n## Decision\n\nWe will ...") {
+		t.Fatalf("expected template sections, got %+v", plans)
+	}
+	if strings.Join(plans[0].Tags, ",") != "infra,adr" {
+		t.Errorf("Tags = %v, want [infra adr]", plans[0].Tags)
+	}
+}
+
+func TestSave_UnknownTemplate(t *testing.T) {
+	dir := setupInitedProject(t)
+	err := runSave("typo", nil, "", "", nil, nil, nil, false, "retor")
+	if err == nil || !strings.Contains(err.Error(), `did you mean "retro"`) {
+		t.Errorf("expected suggestion for unknown template, got: %v", err)
+	}
+	if plans, _ := plan.LoadAll(dir); len(plans) != 0 {
+		t.Errorf("expected nothing saved, got %d plans", len(plans))
+	}
+}
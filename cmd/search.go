@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"strings"
+	"time"
 
 	"github.com/senna-lang/logosyncx/internal/project"
 	"github.com/senna-lang/logosyncx/pkg/config"
@@ -17,9 +19,17 @@ var searchCmd = &cobra.Command{
 	Short: "Keyword search across plan topic, tags, and excerpt",
 	Long: `Case-insensitive keyword search across the topic, tags, and excerpt of every
 saved plan. Results are printed as a human-readable table sorted by date
-(newest first).
+(newest first); plans with the same date are ordered by ID, then filename.
+Plans you have read with logos refer often or recently are moved to the top
+(see logos stats --most-used).
 
-Combine with --tag to pre-filter by tag before applying the keyword match.
+Combine with --tag or --category to pre-filter before applying the keyword
+match.
+Plans from the overlay roots in config "overlays" are searched too.
+
+--json prints the matches as a JSON array with the fields of logos ls --json,
+--ndjson as one JSON object per line. Each match also carries matched_in:
+the fields the keyword was found in (topic, tag, excerpt).
 
 For deeper semantic search, use 'logos ls --json' and let the agent reason
 over the full excerpt list — no embedding API required.`,
@@ -27,7 +37,13 @@ over the full excerpt list — no embedding API required.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		keyword, _ := cmd.Flags().GetString("keyword")
 		tag, _ := cmd.Flags().GetString("tag")
-		return runSearch(keyword, tag)
+		fullTables, _ = cmd.Flags().GetBool("full")
+		category, _ := cmd.Flags().GetString("category")
+		format, err := searchFormat(cmd)
+		if err != nil {
+			return err
+		}
+		return runSearch(keyword, tag, category, format)
 	},
 }
 
@@ -35,57 +51,115 @@ func init() {
 	searchCmd.Flags().StringP("keyword", "k", "", "Keyword to search for (case-insensitive, matches topic, tags, and excerpt)")
 	_ = searchCmd.MarkFlagRequired("keyword")
 	searchCmd.Flags().StringP("tag", "t", "", "Pre-filter sessions by tag before applying the keyword match")
+	searchCmd.Flags().String("category", "", "Pre-filter plans by category before applying the keyword match")
+	searchCmd.Flags().Bool("full", false, "Do not truncate columns to fit the terminal width")
+	searchFormatFlags(searchCmd, "plans")
 	rootCmd.AddCommand(searchCmd)
 }
 
-// runSearch is the testable core of the search command.
-func runSearch(keyword, tag string) error {
+// searchFormatFlags adds --json and --ndjson to a search command.
+func searchFormatFlags(cmd *cobra.Command, what string) {
+	cmd.Flags().Bool("json", false, "Output matching "+what+" as a JSON array")
+	cmd.Flags().Bool("ndjson", false, "Output matching "+what+" as one JSON object per line")
+}
+
+// searchFormat returns the output format selected by the flags added by
+// searchFormatFlags: "json", "ndjson", or "" for the table.
+func searchFormat(cmd *cobra.Command) (string, error) {
+	asJSON, _ := cmd.Flags().GetBool("json")
+	asNDJSON, _ := cmd.Flags().GetBool("ndjson")
+	switch {
+	case asJSON && asNDJSON:
+		return "", errors.New("--json and --ndjson cannot be combined")
+	case asJSON:
+		suppressUpdateCheck = true
+		return "json", nil
+	case asNDJSON:
+		suppressUpdateCheck = true
+		return "ndjson", nil
+	}
+	return "", nil
+}
+
+// printSearchJSON writes matches for search --json (an indented array,
+// [] when nothing matched) or --ndjson (one compact object per line).
+func printSearchJSON[T any](matches []T, format string) error {
+	enc := json.NewEncoder(os.Stdout)
+	if format == "ndjson" {
+		for _, m := range matches {
+			if err := enc.Encode(m); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	if matches == nil {
+		matches = []T{}
+	}
+	enc.SetIndent("", "  ")
+	return enc.Encode(matches)
+}
+
+// searchJSONEntry is a logos ls --json entry with the fields the search
+// keyword was found in.
+type searchJSONEntry struct {
+	lsJSONEntry
+	MatchedIn []string `json:"matched_in"`
+}
+
+// runSearch is the testable core of the search command. format is "json",
+// "ndjson", or "" for the table.
+func runSearch(keyword, tag, category, format string) error {
 	root, err := project.FindRoot()
 	if err != nil {
 		return err
 	}
 
-	entries, err := index.ReadAll(root)
+	cfg, cfgErr := loadConfig(root)
+	if cfgErr != nil {
+		warnf("could not load config (%v) — using defaults", cfgErr)
+		cfg = config.Default("")
+	}
+
+	entries, err := readPlanIndex(root, cfg)
 	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			// Auto-rebuild: inform the user and build the index on the fly.
-			fmt.Fprintln(os.Stderr, "index.jsonl not found. Building index from plans/...")
-			cfg, cfgErr := config.Load(root)
-			if cfgErr != nil {
-				fmt.Fprintf(os.Stderr, "warning: could not load config (%v) — using defaults\n", cfgErr)
-				cfg = config.Default("")
-			}
-			n, buildErr := index.Rebuild(root, cfg.Plans.ExcerptSection)
-			if buildErr != nil {
-				fmt.Fprintf(os.Stderr, "warning: %v\n", buildErr)
-			}
-			fmt.Fprintf(os.Stderr, "Done. %d plans indexed.\n\n", n)
-			entries, err = index.ReadAll(root)
-			if err != nil {
-				return fmt.Errorf("read index after rebuild: %w", err)
-			}
-		} else {
-			return fmt.Errorf("read index: %w", err)
-		}
+		return err
 	}
+	counts := loadTaskCounts(root)
+	entries = addOverlayEntries(root, cfg, entries, counts)
 
 	// Apply --tag pre-filter.
 	if tag != "" {
 		entries = filterTag(entries, tag)
 	}
 
+	// Apply --category pre-filter.
+	if category != "" {
+		entries = filterCategory(entries, category)
+	}
+
 	// Apply keyword filter.
 	entries = filterKeyword(entries, keyword)
 
-	// Sort newest first.
+	// Sort newest first, then bring the plans you refer to most to the top.
 	sortByDateDesc(entries)
+	boostPlanEntries(root, entries, time.Now())
+
+	if format != "" {
+		lower := strings.ToLower(keyword)
+		matches := make([]searchJSONEntry, 0, len(entries))
+		for _, e := range lsJSONEntries(entries, counts) {
+			matches = append(matches, searchJSONEntry{lsJSONEntry: e, MatchedIn: entryMatchedIn(e.Entry, lower)})
+		}
+		return printSearchJSON(matches, format)
+	}
 
 	if len(entries) == 0 {
 		fmt.Println("No plans found.")
 		return nil
 	}
 
-	return printTable(entries)
+	return printTable(entries, counts, displayLocation(cfg), false)
 }
 
 // filterKeyword returns entries whose topic, any tag, or excerpt contains
@@ -104,16 +178,24 @@ func filterKeyword(entries []index.Entry, keyword string) []index.Entry {
 // entryMatchesKeyword reports whether e contains lower (already lowercased)
 // in its topic, any of its tags, or its excerpt.
 func entryMatchesKeyword(e index.Entry, lower string) bool {
+	return len(entryMatchedIn(e, lower)) > 0
+}
+
+// entryMatchedIn returns the fields of e that contain lower (already
+// lowercased): "topic", "tag", and "excerpt", in that order.
+func entryMatchedIn(e index.Entry, lower string) []string {
+	var fields []string
 	if strings.Contains(strings.ToLower(e.Topic), lower) {
-		return true
+		fields = append(fields, "topic")
 	}
 	for _, t := range e.Tags {
 		if strings.Contains(strings.ToLower(t), lower) {
-			return true
+			fields = append(fields, "tag")
+			break
 		}
 	}
 	if strings.Contains(strings.ToLower(e.Excerpt), lower) {
-		return true
+		fields = append(fields, "excerpt")
 	}
-	return false
+	return fields
 }
@@ -1,7 +1,9 @@
 package cmd
 
 import (
+	"encoding/json"
 	"os"
+	"slices"
 	"strings"
 	"testing"
 	"time"
@@ -40,7 +42,7 @@ func TestSearch_NotInitialized_ReturnsError(t *testing.T) {
 	}
 	t.Cleanup(func() { _ = os.Chdir(orig) })
 
-	if err := runSearch("anything", ""); err == nil {
+	if err := runSearch("anything", "", "", ""); err == nil {
 		t.Fatal("expected error when project not initialised, got nil")
 	}
 }
@@ -51,7 +53,7 @@ func TestSearch_NoSessions_PrintsMessage(t *testing.T) {
 	setupInitedProject(t)
 
 	out := captureOutput(t, func() {
-		if err := runSearch("anything", ""); err != nil {
+		if err := runSearch("anything", "", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -68,7 +70,7 @@ func TestSearch_MatchesTopic(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{s})
 
 	out := captureOutput(t, func() {
-		if err := runSearch("jwt", ""); err != nil {
+		if err := runSearch("jwt", "", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -83,7 +85,7 @@ func TestSearch_MatchesTag(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{s})
 
 	out := captureOutput(t, func() {
-		if err := runSearch("oauth", ""); err != nil {
+		if err := runSearch("oauth", "", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -98,7 +100,7 @@ func TestSearch_MatchesExcerpt(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{s})
 
 	out := captureOutput(t, func() {
-		if err := runSearch("GraphQL", ""); err != nil {
+		if err := runSearch("GraphQL", "", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -113,7 +115,7 @@ func TestSearch_NoMatch_PrintsNoSessionsFound(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{s})
 
 	out := captureOutput(t, func() {
-		if err := runSearch("kubernetes", ""); err != nil {
+		if err := runSearch("kubernetes", "", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -130,7 +132,7 @@ func TestSearch_CaseInsensitive_Topic(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{s})
 
 	out := captureOutput(t, func() {
-		if err := runSearch("DATABASE", ""); err != nil {
+		if err := runSearch("DATABASE", "", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -145,7 +147,7 @@ func TestSearch_CaseInsensitive_Tag(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{s})
 
 	out := captureOutput(t, func() {
-		if err := runSearch("golang", ""); err != nil {
+		if err := runSearch("golang", "", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -160,7 +162,7 @@ func TestSearch_CaseInsensitive_Excerpt(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{s})
 
 	out := captureOutput(t, func() {
-		if err := runSearch("openapi", ""); err != nil {
+		if err := runSearch("openapi", "", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -181,7 +183,7 @@ func TestSearch_TagFilter_NarrowsResults(t *testing.T) {
 	setupProjectWithPlans(t, plans)
 
 	out := captureOutput(t, func() {
-		if err := runSearch("jwt", "auth"); err != nil {
+		if err := runSearch("jwt", "auth", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -200,7 +202,7 @@ func TestSearch_TagFilter_NoKeywordMatchAfterTagFilter(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{s})
 
 	out := captureOutput(t, func() {
-		if err := runSearch("kubernetes", "auth"); err != nil {
+		if err := runSearch("kubernetes", "auth", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -215,7 +217,7 @@ func TestSearch_TagFilter_AllSessionsExcluded(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{s})
 
 	out := captureOutput(t, func() {
-		if err := runSearch("auth", "unrelated-tag"); err != nil {
+		if err := runSearch("auth", "unrelated-tag", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -237,7 +239,7 @@ func TestSearch_MultipleMatches_AllReturned(t *testing.T) {
 	setupProjectWithPlans(t, plans)
 
 	out := captureOutput(t, func() {
-		if err := runSearch("auth", ""); err != nil {
+		if err := runSearch("auth", "", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -253,6 +255,60 @@ func TestSearch_MultipleMatches_AllReturned(t *testing.T) {
 	}
 }
 
+// --- runSearch: JSON output -------------------------------------------------
+
+func TestSearch_JSON_IncludesMatchedIn(t *testing.T) {
+	now := time.Now()
+	plans := []plan.Plan{
+		makeSearchPlan("id1", "auth-login", []string{"auth"}, "Login flow.", now.Add(-time.Hour)),
+		makeSearchPlan("id2", "sessions", []string{"web"}, "Cookie auth for the dashboard.", now),
+		makeSearchPlan("id3", "cache-layer", []string{"redis"}, "Caching.", now),
+	}
+	setupProjectWithPlans(t, plans)
+
+	out := captureStdout(t, func() {
+		if err := runSearch("auth", "", "", "json"); err != nil {
+			t.Fatalf("runSearch --json: %v", err)
+		}
+	})
+	var got []struct {
+		Topic     string   `json:"topic"`
+		MatchedIn []string `json:"matched_in"`
+		OpenTasks *int     `json:"open_tasks"`
+	}
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if len(got) != 2 || got[0].Topic != "sessions" || got[1].Topic != "auth-login" {
+		t.Fatalf("matches = %+v, want sessions then auth-login", got)
+	}
+	if !slices.Equal(got[0].MatchedIn, []string{"excerpt"}) || !slices.Equal(got[1].MatchedIn, []string{"topic", "tag"}) {
+		t.Errorf("matched_in = %v and %v", got[0].MatchedIn, got[1].MatchedIn)
+	}
+	if got[0].OpenTasks == nil {
+		t.Error("JSON entries should carry the ls --json fields (open_tasks missing)")
+	}
+
+	out = captureStdout(t, func() {
+		if err := runSearch("auth", "", "", "ndjson"); err != nil {
+			t.Fatalf("runSearch --ndjson: %v", err)
+		}
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 || !json.Valid([]byte(lines[0])) || !strings.Contains(lines[1], `"auth-login"`) {
+		t.Errorf("ndjson output = %q, want one object per line", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runSearch("nothing-matches", "", "", "json"); err != nil {
+			t.Fatalf("runSearch --json: %v", err)
+		}
+	})
+	if strings.TrimSpace(out) != "[]" {
+		t.Errorf("no matches with --json = %q, want []", out)
+	}
+}
+
 // --- runSearch: table output format ------------------------------------------
 
 func TestSearch_Output_ContainsHeaders(t *testing.T) {
@@ -260,7 +316,7 @@ func TestSearch_Output_ContainsHeaders(t *testing.T) {
 	setupProjectWithPlans(t, []plan.Plan{s})
 
 	out := captureOutput(t, func() {
-		if err := runSearch("api", ""); err != nil {
+		if err := runSearch("api", "", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
@@ -286,7 +342,7 @@ func TestSearch_Output_SortedNewestFirst(t *testing.T) {
 	setupProjectWithPlans(t, plans)
 
 	out := captureOutput(t, func() {
-		if err := runSearch("go", ""); err != nil {
+		if err := runSearch("go", "", "", ""); err != nil {
 			t.Fatalf("runSearch failed: %v", err)
 		}
 	})
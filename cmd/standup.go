@@ -0,0 +1,148 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/dateparse"
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var standupCmd = &cobra.Command{
+	Use:   "standup",
+	Args:  cobra.NoArgs,
+	Short: "Print done / doing / blocked bullets for a standup",
+	Long: `Summarise recent activity as markdown, ready to paste into chat:
+
+  Done     — tasks completed since --since, and plans saved since --since
+  Doing    — tasks currently in_progress
+  Blocked  — open or in_progress tasks waiting on unfinished dependencies
+
+--since accepts "yesterday" (default), "today", a date (YYYY-MM-DD), or a
+relative date such as "3d", "2w ago", or "last monday". --author narrows
+the report to tasks assigned to that name and plans saved by that agent;
+"me" resolves to git user.name.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		author, _ := cmd.Flags().GetString("author")
+		since, _ := cmd.Flags().GetString("since")
+		return runStandup(author, since, time.Now())
+	},
+}
+
+func init() {
+	standupCmd.Flags().String("author", "", `Only include work by this assignee/agent ("me" = git user.name)`)
+	standupCmd.Flags().String("since", "yesterday", "Start of the window: YYYY-MM-DD, yesterday, 3d, 2w ago, last monday, ...")
+	rootCmd.AddCommand(standupCmd)
+}
+
+// runStandup is the testable core of the standup command.
+func runStandup(author, sinceStr string, now time.Time) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+
+	// Day boundaries ("yesterday", YYYY-MM-DD) follow display.timezone.
+	since, err := parseStandupSince(sinceStr, now.In(displayLocation(cfg)))
+	if err != nil {
+		return err
+	}
+
+	if author == "me" {
+		name, err := gitutil.UserName(root)
+		if err != nil {
+			return fmt.Errorf("resolve --author me: %w", err)
+		}
+		author = name
+	}
+
+	tasks, err := task.NewStore(root, &cfg).List(task.Filter{})
+	if err != nil {
+		return fmt.Errorf("load tasks: %w", err)
+	}
+
+	plans, err := plan.LoadAll(root)
+	if err != nil {
+		// Non-fatal parse errors: warn but continue with what we have.
+		warnf("%v", err)
+	}
+
+	var done, doing, blocked []string
+	for _, t := range tasks {
+		if author != "" && !strings.EqualFold(t.Assignee, author) {
+			continue
+		}
+		switch {
+		case t.Status == task.StatusDone:
+			if t.CompletedAt != nil && !t.CompletedAt.Before(since) {
+				done = append(done, standupTaskLine(t))
+			}
+		case t.Blocked:
+			blocked = append(blocked, standupTaskLine(t)+" — waiting on "+formatSeqs(t.DependsOn))
+		case t.Status == task.StatusInProgress:
+			doing = append(doing, standupTaskLine(t))
+		}
+	}
+	for _, p := range plans {
+		if author != "" && !strings.EqualFold(p.Agent, author) {
+			continue
+		}
+		if p.Date != nil && !p.Date.Before(since) {
+			done = append(done, fmt.Sprintf("Saved plan: %s", p.Topic))
+		}
+	}
+
+	fmt.Printf("## Standup (since %s)\n", since.Format("2006-01-02"))
+	printStandupSection("Done", done)
+	printStandupSection("Doing", doing)
+	printStandupSection("Blocked", blocked)
+	return nil
+}
+
+// parseStandupSince converts a --since value to the start of the reporting
+// window, relative to now. An empty value means yesterday.
+func parseStandupSince(s string, now time.Time) (time.Time, error) {
+	if s == "" {
+		s = "yesterday"
+	}
+	t, err := dateparse.Past(s, now)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("--since: %w", err)
+	}
+	return t, nil
+}
+
+// standupTaskLine formats a task as "<title> (<plan>)".
+func standupTaskLine(t *task.Task) string {
+	return fmt.Sprintf("%s (%s)", t.Title, t.Plan)
+}
+
+// formatSeqs formats dependency seq numbers as "#1, #3".
+func formatSeqs(seqs []int) string {
+	parts := make([]string, len(seqs))
+	for i, s := range seqs {
+		parts[i] = fmt.Sprintf("#%d", s)
+	}
+	return strings.Join(parts, ", ")
+}
+
+func printStandupSection(title string, lines []string) {
+	fmt.Printf("\n**%s**\n", title)
+	if len(lines) == 0 {
+		fmt.Println("- (none)")
+		return
+	}
+	for _, l := range lines {
+		fmt.Printf("- %s\n", l)
+	}
+}
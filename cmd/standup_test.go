@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// setupStandupProject creates tasks covering every standup section:
+// "Ship parser" (done, alice), "Write docs" (in_progress, bob), and
+// "Release" (open, blocked on seq 2).
+func setupStandupProject(t *testing.T) string {
+	t.Helper()
+	dir := setupInitedProject(t)
+	for _, tc := range []struct {
+		title string
+		deps  []int
+	}{
+		{"Ship parser", nil},
+		{"Write docs", nil},
+		{"Release", []int{2}},
+	} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: tc.title, priority: "medium", dependsOn: tc.deps}); err != nil {
+			t.Fatalf("create %q: %v", tc.title, err)
+		}
+	}
+
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	store := task.NewStore(dir, &cfg)
+	tk, err := store.Get("", "ship-parser")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tk.DirPath, "WALKTHROUGH.md"), []byte("Done.\n"), 0o644); err != nil {
+		t.Fatalf("write walkthrough: %v", err)
+	}
+	if err := store.UpdateFields("", "ship-parser", map[string]string{"status": "done", "assignee": "alice"}); err != nil {
+		t.Fatalf("mark done: %v", err)
+	}
+	if err := store.UpdateFields("", "write-docs", map[string]string{"status": "in_progress", "assignee": "bob"}); err != nil {
+		t.Fatalf("mark in_progress: %v", err)
+	}
+	return dir
+}
+
+func TestStandup_GroupsDoneDoingBlocked(t *testing.T) {
+	setupStandupProject(t)
+
+	out := captureOutput(t, func() {
+		if err := runStandup("", "yesterday", time.Now()); err != nil {
+			t.Fatalf("runStandup: %v", err)
+		}
+	})
+
+	done := strings.Index(out, "**Done**")
+	doing := strings.Index(out, "**Doing**")
+	blocked := strings.Index(out, "**Blocked**")
+	if done < 0 || doing < done || blocked < doing {
+		t.Fatalf("expected Done, Doing, Blocked sections in order, got:\n%s", out)
+	}
+	if i := strings.Index(out, "Ship parser"); i < done || i > doing {
+		t.Errorf("expected 'Ship parser' under Done, got:\n%s", out)
+	}
+	if i := strings.Index(out, "Write docs"); i < doing || i > blocked {
+		t.Errorf("expected 'Write docs' under Doing, got:\n%s", out)
+	}
+	if !strings.Contains(out[blocked:], "Release") || !strings.Contains(out[blocked:], "#2") {
+		t.Errorf("expected 'Release' waiting on #2 under Blocked, got:\n%s", out)
+	}
+}
+
+func TestStandup_AuthorFilter(t *testing.T) {
+	setupStandupProject(t)
+
+	out := captureOutput(t, func() {
+		if err := runStandup("Alice", "today", time.Now()); err != nil {
+			t.Fatalf("runStandup: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "Ship parser") {
+		t.Errorf("expected alice's task in output, got:\n%s", out)
+	}
+	if strings.Contains(out, "Write docs") {
+		t.Errorf("unexpected bob's task in output, got:\n%s", out)
+	}
+}
+
+func TestStandup_CompletedBeforeWindow_Excluded(t *testing.T) {
+	setupStandupProject(t)
+
+	// Run the report as if it were next week: the completion is too old.
+	out := captureOutput(t, func() {
+		if err := runStandup("", "today", time.Now().AddDate(0, 0, 7)); err != nil {
+			t.Fatalf("runStandup: %v", err)
+		}
+	})
+
+	if strings.Contains(out, "Ship parser") {
+		t.Errorf("unexpected old completion in output, got:\n%s", out)
+	}
+}
+
+func TestParseStandupSince(t *testing.T) {
+	now := time.Date(2026, 3, 10, 15, 30, 0, 0, time.UTC)
+	cases := map[string]time.Time{
+		"today":      time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC),
+		"yesterday":  time.Date(2026, 3, 9, 0, 0, 0, 0, time.UTC),
+		"3d":         time.Date(2026, 3, 7, 0, 0, 0, 0, time.UTC),
+		"2026-03-01": time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC),
+	}
+	for in, want := range cases {
+		got, err := parseStandupSince(in, now)
+		if err != nil {
+			t.Errorf("parseStandupSince(%q): %v", in, err)
+			continue
+		}
+		if !got.Equal(want) {
+			t.Errorf("parseStandupSince(%q) = %v, want %v", in, got, want)
+		}
+	}
+	for _, in := range []string{"last week", "xd", "-1d", "03/01/2026"} {
+		if _, err := parseStandupSince(in, now); err == nil {
+			t.Errorf("parseStandupSince(%q): expected error, got nil", in)
+		}
+	}
+}
@@ -0,0 +1,292 @@
+package cmd
+
+import (
+	"cmp"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/recent"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Args:  cobra.NoArgs,
+	Short: "Show plan and task counts, optionally per agent",
+	Long: `Print how many plans have been saved, how many are distilled, and how
+many tasks they produced. For done tasks it also prints the median lead
+time (created to completed_at) and cycle time (started_at to completed_at);
+tasks finished before these timestamps were recorded are left out.
+
+With --by-agent, the counts are broken down by the agent recorded when each
+plan was saved (logos save --agent), so teams running several assistants can
+compare which agent produced which context. Tasks count towards the agent
+of the plan they belong to. Plans saved without an agent are grouped under
+"-".
+
+With --most-used, list instead the plans and tasks you have read with
+logos refer and logos task refer, ranked by how often and how recently
+(a referral counts half as much after 14 days). This ranking is what
+logos search, logos task search, and the agent context file use to put
+your most used plans and tasks first. It is kept per user in
+.logosyncx/recent.jsonl, which is not committed.`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		byAgent, _ := cmd.Flags().GetBool("by-agent")
+		mostUsed, _ := cmd.Flags().GetBool("most-used")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		if mostUsed {
+			if byAgent {
+				return errors.New("--most-used and --by-agent cannot be combined")
+			}
+			return runMostUsed(asJSON, time.Now())
+		}
+		return runStats(byAgent, asJSON)
+	},
+}
+
+func init() {
+	statsCmd.Flags().Bool("by-agent", false, "Break the counts down by agent")
+	statsCmd.Flags().Bool("most-used", false, "List the plans and tasks you refer to most often and most recently")
+	statsCmd.Flags().Bool("json", false, "Output structured JSON")
+	rootCmd.AddCommand(statsCmd)
+}
+
+// planStats holds the counts reported by logos stats for one agent, or for
+// the whole project when Agent is empty.
+type planStats struct {
+	Agent     string     `json:"agent,omitempty"`
+	Plans     int        `json:"plans"`
+	Distilled int        `json:"distilled"`
+	Tasks     int        `json:"tasks"`
+	DoneTasks int        `json:"done_tasks"`
+	LastSaved *time.Time `json:"last_saved,omitempty"`
+	// LeadDays and CycleDays are the median lead and cycle times of the
+	// done tasks, in days; nil when no done task has the timestamps.
+	LeadDays  *float64 `json:"median_lead_days,omitempty"`
+	CycleDays *float64 `json:"median_cycle_days,omitempty"`
+
+	lead, cycle []time.Duration
+}
+
+// add counts one plan and its tasks.
+func (s *planStats) add(date time.Time, distilled bool, c taskCount, td taskDurations) {
+	s.Plans++
+	if distilled {
+		s.Distilled++
+	}
+	s.Tasks += c.Total
+	s.DoneTasks += c.Total - c.Open
+	if s.LastSaved == nil || date.After(*s.LastSaved) {
+		d := date
+		s.LastSaved = &d
+	}
+	s.lead = append(s.lead, td.lead...)
+	s.cycle = append(s.cycle, td.cycle...)
+}
+
+// finish sets LeadDays and CycleDays from the durations added.
+func (s *planStats) finish() {
+	s.LeadDays, s.CycleDays = medianDays(s.lead), medianDays(s.cycle)
+}
+
+// taskDurations holds the lead times (creation to completion) and cycle
+// times (start to completion) of the done tasks of one plan.
+type taskDurations struct {
+	lead, cycle []time.Duration
+}
+
+// loadTaskDurations returns the lead and cycle times of done tasks keyed by
+// plan slug, computed from the task index. A task counts only when the
+// timestamps it needs are recorded and in order.
+func loadTaskDurations(root string) map[string]taskDurations {
+	durations := map[string]taskDurations{}
+	tasks, err := task.ReadAllTaskIndex(root)
+	if err != nil && !errors.Is(err, os.ErrNotExist) && !warnSkippedLines("task-index.jsonl", err) {
+		warnf("%v", err)
+	}
+	for _, t := range tasks {
+		if t.Status != task.StatusDone || t.CompletedAt == nil {
+			continue
+		}
+		d := durations[t.Plan]
+		if !t.Date.IsZero() && !t.CompletedAt.Before(t.Date) {
+			d.lead = append(d.lead, t.CompletedAt.Sub(t.Date))
+		}
+		if t.StartedAt != nil && !t.CompletedAt.Before(*t.StartedAt) {
+			d.cycle = append(d.cycle, t.CompletedAt.Sub(*t.StartedAt))
+		}
+		durations[t.Plan] = d
+	}
+	return durations
+}
+
+// medianDays returns the median of ds in days, rounded to one decimal, or
+// nil when ds is empty.
+func medianDays(ds []time.Duration) *float64 {
+	if len(ds) == 0 {
+		return nil
+	}
+	sorted := slices.Sorted(slices.Values(ds))
+	m := sorted[len(sorted)/2]
+	if len(sorted)%2 == 0 {
+		m = (sorted[len(sorted)/2-1] + m) / 2
+	}
+	days := math.Round(m.Hours()/24*10) / 10
+	return &days
+}
+
+// formatDays renders a median from medianDays for the text output.
+func formatDays(days *float64) string {
+	if days == nil {
+		return "-"
+	}
+	return strconv.FormatFloat(*days, 'f', 1, 64) + "d"
+}
+
+func runStats(byAgent, asJSON bool) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+
+	cfg, cfgErr := loadConfig(root)
+	if cfgErr != nil {
+		warnf("could not load config (%v) — using defaults", cfgErr)
+		cfg = config.Default("")
+	}
+
+	entries, err := readPlanIndex(root, cfg)
+	if err != nil {
+		return err
+	}
+	counts := loadTaskCounts(root)
+	durations := loadTaskDurations(root)
+
+	var total planStats
+	agents := map[string]*planStats{}
+	for _, e := range entries {
+		c, td := counts[entryPlanSlug(e)], durations[entryPlanSlug(e)]
+		total.add(e.Date, e.Distilled, c, td)
+		// Agent names are compared case-insensitively, as by ls --agent;
+		// the first spelling seen is the one reported.
+		key := strings.ToLower(e.Agent)
+		s, ok := agents[key]
+		if !ok {
+			s = &planStats{Agent: dashIfEmpty(e.Agent)}
+			agents[key] = s
+		}
+		s.add(e.Date, e.Distilled, c, td)
+	}
+
+	if !byAgent {
+		total.finish()
+		if asJSON {
+			return writeStatsJSON(total)
+		}
+		fmt.Printf("Plans:      %d (%d distilled)\n", total.Plans, total.Distilled)
+		fmt.Printf("Tasks:      %d (%d done)\n", total.Tasks, total.DoneTasks)
+		fmt.Printf("Lead time:  %s median (created → done)\n", formatDays(total.LeadDays))
+		fmt.Printf("Cycle time: %s median (started → done)\n", formatDays(total.CycleDays))
+		fmt.Printf("Agents:     %d\n", len(agents))
+		return nil
+	}
+
+	rows := make([]planStats, 0, len(agents))
+	for _, s := range agents {
+		s.finish()
+		rows = append(rows, *s)
+	}
+	// Most plans first; ties by name for stable output.
+	slices.SortFunc(rows, func(a, b planStats) int {
+		return cmp.Or(cmp.Compare(b.Plans, a.Plans), strings.Compare(a.Agent, b.Agent))
+	})
+
+	if asJSON {
+		return writeStatsJSON(rows)
+	}
+	if len(rows) == 0 {
+		fmt.Println("No plans found.")
+		return nil
+	}
+	loc := displayLocation(cfg)
+	t := &textTable{headers: []string{"AGENT", "PLANS", "DISTILLED", "TASKS", "DONE", "LEAD", "CYCLE", "LAST SAVED"}}
+	for _, r := range rows {
+		t.addRow(r.Agent,
+			fmt.Sprint(r.Plans), fmt.Sprint(r.Distilled),
+			fmt.Sprint(r.Tasks), fmt.Sprint(r.DoneTasks),
+			formatDays(r.LeadDays), formatDays(r.CycleDays),
+			r.LastSaved.In(loc).Format("2006-01-02"))
+	}
+	return t.render(os.Stdout)
+}
+
+// mostUsedLimit is the number of items logos stats --most-used lists.
+const mostUsedLimit = 20
+
+// mostUsedJSON is one item of logos stats --most-used --json.
+type mostUsedJSON struct {
+	Kind  string    `json:"kind"`
+	Name  string    `json:"name"`
+	Count int       `json:"count"`
+	Last  time.Time `json:"last"`
+	Score float64   `json:"score"` // decayed to now
+}
+
+// runMostUsed lists the mostUsedLimit items with the highest referral score
+// at now.
+func runMostUsed(asJSON bool, now time.Time) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, cfgErr := loadConfig(root)
+	if cfgErr != nil {
+		warnf("could not load config (%v) — using defaults", cfgErr)
+		cfg = config.Default("")
+	}
+	records, err := recent.Load(root)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", recent.FileName, err)
+	}
+	ranked := recent.Ranked(records, now)
+	if len(ranked) > mostUsedLimit {
+		ranked = ranked[:mostUsedLimit]
+	}
+
+	rows := make([]mostUsedJSON, len(ranked))
+	for i, r := range ranked {
+		rows[i] = mostUsedJSON{Kind: r.Kind, Name: r.Name, Count: r.Count, Last: r.Last, Score: math.Round(r.ScoreAt(now)*100) / 100}
+	}
+	if asJSON {
+		return writeStatsJSON(rows)
+	}
+	if len(rows) == 0 {
+		fmt.Println("Nothing referred yet — logos refer and logos task refer are counted.")
+		return nil
+	}
+	loc := displayLocation(cfg)
+	t := &textTable{headers: []string{"KIND", "NAME", "COUNT", "LAST", "SCORE"}, fitWidth: tableWidth(), shrink: []int{1}}
+	for _, r := range rows {
+		t.addRow(r.Kind, r.Name, fmt.Sprint(r.Count), r.Last.In(loc).Format("2006-01-02 15:04"), strconv.FormatFloat(r.Score, 'f', 2, 64))
+	}
+	return t.render(os.Stdout)
+}
+
+func writeStatsJSON(v any) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
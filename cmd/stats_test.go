@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+)
+
+func setupStatsProject(t *testing.T) {
+	t.Helper()
+	dir := setupInitedProject(t)
+	d1 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	d2 := time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)
+	writePlanFileWithBody(t, dir, plan.Plan{ID: "s1", Topic: "one", Date: &d1, Agent: "claude-code", Distilled: true})
+	writePlanFileWithBody(t, dir, plan.Plan{ID: "s2", Topic: "two", Date: &d2, Agent: "Claude-Code"})
+	writePlanFileWithBody(t, dir, plan.Plan{ID: "s3", Topic: "three", Date: &d1})
+}
+
+func TestStats_Totals(t *testing.T) {
+	setupStatsProject(t)
+	out := captureOutput(t, func() {
+		if err := runStats(false, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	for _, want := range []string{"3 (1 distilled)", "Agents:     2"} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestStats_ByAgentJSON(t *testing.T) {
+	setupStatsProject(t)
+	out := captureOutput(t, func() {
+		if err := runStats(true, true); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var rows []planStats
+	if err := json.Unmarshal([]byte(out), &rows); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, out)
+	}
+	if len(rows) != 2 {
+		t.Fatalf("expected 2 agents, got %+v", rows)
+	}
+	if rows[0].Plans != 2 || rows[0].Distilled != 1 || !strings.EqualFold(rows[0].Agent, "claude-code") {
+		t.Errorf("unexpected first row: %+v", rows[0])
+	}
+	if rows[0].LastSaved == nil || rows[0].LastSaved.Month() != time.February {
+		t.Errorf("expected last_saved in February, got %v", rows[0].LastSaved)
+	}
+	if rows[1].Agent != "-" || rows[1].Plans != 1 {
+		t.Errorf("expected plans without an agent under \"-\", got %+v", rows[1])
+	}
+}
+
+func TestStats_ByAgentTable(t *testing.T) {
+	setupStatsProject(t)
+	out := captureOutput(t, func() {
+		if err := runStats(true, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "AGENT") || !strings.Contains(out, "2026-02-01") {
+		t.Errorf("unexpected table:\n%s", out)
+	}
+}
+
+func TestStats_LeadAndCycleTime(t *testing.T) {
+	dir := setupInitedProject(t)
+	created := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	started := created.AddDate(0, 0, 2)
+	completed := created.AddDate(0, 0, 5)
+	p := makeSyncPlan("p1", "cache", created)
+	writeSyncPlan(t, dir, p)
+	slug := strings.TrimSuffix(plan.FileName(p), ".md")
+	if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Warm the cache"}); err != nil {
+		t.Fatal(err)
+	}
+	tk := loadAllTasks(t, dir)[0]
+	tk.Date, tk.Status, tk.StartedAt, tk.CompletedAt = created, task.StatusDone, &started, &completed
+	data, err := task.Marshal(*tk)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tk.DirPath, "TASK.md"), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	captureOutput(t, func() {
+		if err := runSync("", false, false, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	out := captureOutput(t, func() {
+		if err := runStats(false, true); err != nil {
+			t.Fatal(err)
+		}
+	})
+	var s planStats
+	if err := json.Unmarshal([]byte(out), &s); err != nil {
+		t.Fatalf("unmarshal: %v\n%s", err, out)
+	}
+	if s.LeadDays == nil || *s.LeadDays != 5 || s.CycleDays == nil || *s.CycleDays != 3 {
+		t.Errorf("lead %v, cycle %v; want 5 and 3 days\n%s", s.LeadDays, s.CycleDays, out)
+	}
+}
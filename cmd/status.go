@@ -2,18 +2,41 @@ package cmd
 
 import (
 	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/senna-lang/logosyncx/internal/gitutil"
 	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/internal/updater"
+	"github.com/senna-lang/logosyncx/internal/version"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
 	"github.com/spf13/cobra"
 )
 
 var statusCmd = &cobra.Command{
 	Use:   "status",
-	Short: "Show uncommitted sessions and tasks in .logosyncx/",
-	Long: `Display the git status of every file under .logosyncx/, grouped by
-staging state:
+	Short: "Show a project overview and uncommitted plans and tasks in .logosyncx/",
+	Long: `Print a one-shot overview of the project:
+
+  Project     project name and config version
+  Plans       number of plans (archived plans counted separately)
+  Tasks       number of tasks per status
+  Indexes     whether each index matches the files on disk, and when it
+              was last written
+  Storage     total size of .logosyncx/
+  Version     the running version and, from the cached update check, a
+              newer release if one is available (no network call)
+  Git         whether .logosyncx/ has uncommitted changes
+
+followed by the git status of every file under .logosyncx/, grouped by
+staging state. Uncommitted files larger than attachments.max_size_kb, or
+binary files outside .logosyncx/attachments/, are reported as warnings
+unless they are stored with Git LFS:
 
   Staged      — added to the index, ready to commit
   Unstaged    — modified in the worktree but not yet staged
@@ -36,10 +59,25 @@ func runStatus() error {
 		return err
 	}
 
-	entries, err := gitutil.StatusUnderDir(root, ".logosyncx/")
+	cfg, err := loadConfig(root)
 	if err != nil {
-		return fmt.Errorf("query git status: %w", err)
+		return fmt.Errorf("load config: %w", err)
+	}
+	isRepo := gitutil.IsRepo(root)
+	var entries []gitutil.FileStatus
+	if isRepo {
+		entries, err = gitutil.StatusUnderDir(root, ".logosyncx/")
+		if err != nil {
+			return fmt.Errorf("query git status: %w", err)
+		}
+	}
+
+	printOverview(root, cfg, isRepo, len(entries), time.Now())
+	if !isRepo {
+		return nil
 	}
+	warnUncommittedAttachments(root, cfg, entries)
+	fmt.Println()
 
 	// Partition entries into three buckets.
 	var staged, unstaged, untracked []gitutil.FileStatus
@@ -58,7 +96,7 @@ func runStatus() error {
 	}
 
 	if len(staged) == 0 && len(unstaged) == 0 && len(untracked) == 0 {
-		fmt.Println("✓ Nothing uncommitted in .logosyncx/ — all saved and committed.")
+		printSuccess("Nothing uncommitted in .logosyncx/ — all saved and committed.")
 		return nil
 	}
 
@@ -88,10 +126,169 @@ func runStatus() error {
 		fmt.Println()
 	}
 
-	fmt.Println("Run `git add .logosyncx/ && git commit` to commit the above.")
+	if !forAgent {
+		fmt.Println("Run `git add .logosyncx/ && git commit` to commit the above.")
+	}
 	return nil
 }
 
+// printOverview prints the project summary lines at the top of logos status.
+// uncommitted is the number of changed files under .logosyncx/.
+func printOverview(root string, cfg config.Config, isRepo bool, uncommitted int, now time.Time) {
+	row := func(label, value string) { fmt.Printf("%-10s %s\n", label+":", value) }
+
+	row("Project", fmt.Sprintf("%s (config v%s)", cfg.Project, cfg.Version))
+
+	plans, _ := os.ReadDir(plan.PlansDir(root))
+	archived, _ := loadArchivedPlanFilenames(root)
+	active := 0
+	for _, e := range plans {
+		if !e.IsDir() && strings.HasSuffix(e.Name(), ".md") {
+			active++
+		}
+	}
+	row("Plans", fmt.Sprintf("%d (%d archived)", active, len(archived)))
+
+	store := task.NewStore(root, &cfg)
+	tasks, err := store.List(task.Filter{})
+	if err != nil {
+		row("Tasks", "error: "+err.Error())
+	} else {
+		counts := map[task.Status]int{}
+		for _, t := range tasks {
+			counts[t.Status]++
+		}
+		parts := make([]string, 0, len(task.ValidStatuses))
+		for _, st := range task.ValidStatuses {
+			parts = append(parts, fmt.Sprintf("%d %s", counts[st], st))
+		}
+		row("Tasks", strings.Join(parts, ", "))
+	}
+
+	var indexes []string
+	for _, t := range syncTargets(root, cfg, store) {
+		indexes = append(indexes, t.name+" "+indexFreshness(t, now))
+	}
+	row("Indexes", strings.Join(indexes, ", "))
+
+	row("Storage", formatBytes(dirSize(filepath.Join(root, config.DirName)))+" in .logosyncx/")
+
+	ver := version.Version
+	if latest, newer, ok := updater.Cached(version.Version); !version.IsDev() && ok && newer {
+		ver += fmt.Sprintf(" — %s available, run `logos update`", latest)
+	}
+	row("Version", ver)
+
+	switch {
+	case !isRepo:
+		row("Git", "not a git repository")
+	case uncommitted == 0:
+		row("Git", "clean")
+	default:
+		row("Git", fmt.Sprintf("%d uncommitted file(s)", uncommitted))
+	}
+}
+
+// warnUncommittedAttachments warns about uncommitted files that break the
+// attachment policy (see findAttachmentProblems) before they are committed.
+// Untracked directories, which git status reports as "dir/", cover every
+// file beneath them.
+func warnUncommittedAttachments(root string, cfg config.Config, entries []gitutil.FileStatus) {
+	if len(entries) == 0 {
+		return
+	}
+	include := func(rel string) bool {
+		for _, e := range entries {
+			if e.Worktree == gitutil.StatusDeleted || e.Staging == gitutil.StatusDeleted {
+				continue
+			}
+			if rel == e.Path || (strings.HasSuffix(e.Path, "/") && strings.HasPrefix(rel, e.Path)) {
+				return true
+			}
+		}
+		return false
+	}
+	problems := findAttachmentProblems(root, cfg, include)
+	if len(problems) == 0 {
+		return
+	}
+	if warningsJSON {
+		for _, p := range problems {
+			warnf("large or binary file about to be committed: %s — %s", p.rel, p.detail)
+		}
+		return
+	}
+	fmt.Fprintf(os.Stderr, "\nwarning: %d large or binary file(s) about to be committed:\n", len(problems))
+	for _, p := range problems {
+		fmt.Fprintf(os.Stderr, "  %s — %s\n", p.rel, p.detail)
+	}
+	fmt.Fprintln(os.Stderr, "  Move attachments to .logosyncx/attachments/ and track them with Git LFS (attachments.lfs, logos doctor --fix-lfs).")
+}
+
+// indexFreshness describes one index for the overview, e.g.
+// "up to date (synced 3h ago)" or "out of date".
+func indexFreshness(t syncTarget, now time.Time) string {
+	info, statErr := os.Stat(t.indexPath)
+	_, ok, err := t.check()
+	var s string
+	switch {
+	case err != nil:
+		s = "unreadable"
+	case ok:
+		s = "up to date"
+	default:
+		s = "out of date"
+	}
+	if statErr != nil {
+		return s + " (never synced)"
+	}
+	return fmt.Sprintf("%s (synced %s)", s, formatAgo(now.Sub(info.ModTime())))
+}
+
+// formatAgo renders a duration as a coarse age, e.g. "just now", "5m ago",
+// "3h ago", or "2d ago".
+func formatAgo(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	default:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+}
+
+// dirSize returns the total size in bytes of the regular files under dir.
+func dirSize(dir string) int64 {
+	var total int64
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			total += info.Size()
+		}
+		return nil
+	})
+	return total
+}
+
+// formatBytes renders n with a binary unit, e.g. "512 B" or "1.2 KiB".
+func formatBytes(n int64) string {
+	const unit = 1024
+	if n < unit {
+		return fmt.Sprintf("%d B", n)
+	}
+	div, exp := int64(unit), 0
+	for m := n / unit; m >= unit; m /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %ciB", float64(n)/float64(div), "KMGT"[exp])
+}
+
 // statusLabel returns a short human-readable label for a git status code.
 func statusLabel(sc gitutil.StatusCode) string {
 	switch sc {
@@ -0,0 +1,55 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestStatus_OverviewOutsideGit(t *testing.T) {
+	dir := setupInitedProject(t)
+	writeSyncPlan(t, dir, makeSyncPlan("p1", "overview", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	out := captureOutput(t, func() {
+		if err := runStatus(); err != nil {
+			t.Fatalf("runStatus: %v", err)
+		}
+	})
+	for _, want := range []string{
+		"Plans:     1 (0 archived)",
+		"Tasks:     0 open, 0 in_progress, 0 done",
+		"plans out of date",
+		"Git:       not a git repository",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output, got:\n%s", want, out)
+		}
+	}
+}
+
+func TestFormatAgo(t *testing.T) {
+	cases := map[time.Duration]string{
+		10 * time.Second: "just now",
+		5 * time.Minute:  "5m ago",
+		3 * time.Hour:    "3h ago",
+		50 * time.Hour:   "2d ago",
+	}
+	for d, want := range cases {
+		if got := formatAgo(d); got != want {
+			t.Errorf("formatAgo(%v) = %q, want %q", d, got, want)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	cases := map[int64]string{
+		512:     "512 B",
+		1536:    "1.5 KiB",
+		3 << 20: "3.0 MiB",
+	}
+	for n, want := range cases {
+		if got := formatBytes(n); got != want {
+			t.Errorf("formatBytes(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
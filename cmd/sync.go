@@ -1,14 +1,23 @@
 package cmd
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
-	"github.com/senna-lang/logosyncx/internal/gitutil"
+	"github.com/senna-lang/logosyncx/internal/jsonl"
+	"github.com/senna-lang/logosyncx/internal/markdown"
 	"github.com/senna-lang/logosyncx/internal/project"
 	"github.com/senna-lang/logosyncx/internal/task"
 	"github.com/senna-lang/logosyncx/pkg/config"
 	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/plan"
 	"github.com/spf13/cobra"
 )
 
@@ -20,59 +29,418 @@ file under .logosyncx/plans/ and .logosyncx/tasks/ respectively.
 Run this after manually editing, adding, or deleting plan or task files
 to bring both indexes back in sync with the filesystem.
 
-When git.auto_push is false (the default), no git operations are performed.
-When git.auto_push is true, the rebuilt index files are staged with git add.`,
+The indexes are rebuilt in parallel; the count and duration of each is
+printed. Use --only to rebuild a single index ("plans" — also accepted as
+"sessions" — or "tasks").
+
+With --check nothing is written: each index is compared with what a rebuild
+would produce, and the command exits non-zero when any is out of date
+(useful in CI and pre-commit hooks). --json prints a machine-readable
+summary in either mode.
+
+With --auto-link, plans and tasks whose bodies mention each other (a task
+ID such as t-a1b2c3 in a plan, or a plan filename in a task) are linked in
+both directions before the rebuild: the task ID is added to the plan's
+related_tasks and the plan filename to the task's related_plans.
+
+Task files with an unrecognised status, Markdown files outside the
+<plan>/NNN-<title>/TASK.md layout, and files holding git conflict markers
+(indexed without an excerpt) are reported as warnings.
+
+When tasks.escalation is set in config.json, unfinished tasks due within
+its due_within (or overdue) are raised to its set_priority before the
+rebuild; --check never changes priorities.
+
+When context_file is set in config.json, the agent context file (see
+logos agents render-context) is regenerated after the rebuild.
+
+When git.auto is add, commit, or push, the rebuilt index files are staged
+with git add (sync never commits).`,
+	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runSync()
+		only, _ := cmd.Flags().GetString("only")
+		check, _ := cmd.Flags().GetBool("check")
+		asJSON, _ := cmd.Flags().GetBool("json")
+		autoLink, _ := cmd.Flags().GetBool("auto-link")
+		if asJSON {
+			suppressUpdateCheck = true
+		}
+		return runSync(only, check, asJSON, autoLink)
 	},
 }
 
 func init() {
+	syncCmd.Flags().String("only", "", "Rebuild a single index: plans (alias: sessions) or tasks")
+	syncCmd.Flags().Bool("check", false, "Report out-of-date indexes without writing; exit non-zero if any")
+	syncCmd.Flags().Bool("json", false, "Print a machine-readable summary")
+	syncCmd.Flags().Bool("auto-link", false, "Link plans and tasks that mention each other before rebuilding")
+	syncCmd.MarkFlagsMutuallyExclusive("check", "auto-link")
 	rootCmd.AddCommand(syncCmd)
 }
 
-func runSync() error {
+// syncTarget is one index maintained by logos sync. Adding an index to the
+// project means adding an entry to syncTargets.
+type syncTarget struct {
+	name      string // value accepted by --only
+	noun      string // plural used in progress output
+	source    string // directory scanned, for progress output
+	indexPath string
+	// rebuild rewrites the index and returns the number of entries.
+	rebuild func() (int, error)
+	// check compares the index on disk with a fresh build without writing.
+	check func() (count int, upToDate bool, err error)
+}
+
+// syncResult is the outcome of syncing one target; it is also the per-index
+// element of the --json summary.
+type syncResult struct {
+	Name       string `json:"name"`
+	Count      int    `json:"count"`
+	DurationMS int64  `json:"duration_ms"`
+	UpToDate   *bool  `json:"up_to_date,omitempty"` // --check only
+	Error      string `json:"error,omitempty"`
+}
+
+// syncSummary is the --json output of logos sync.
+type syncSummary struct {
+	Mode       string          `json:"mode"` // "rebuild" or "check"
+	Indexes    []syncResult    `json:"indexes"`
+	DurationMS int64           `json:"duration_ms"`
+	Strays     int             `json:"strays"`
+	Conflicts  int             `json:"conflicts"`
+	AutoLink   *autoLinkResult `json:"auto_link,omitempty"`
+	Escalated  []string        `json:"escalated,omitempty"`
+}
+
+// syncTargets returns every index logos sync maintains, in output order.
+func syncTargets(root string, cfg config.Config, store *task.Store) []syncTarget {
+	opts := planIndexOptions(cfg)
+	return []syncTarget{
+		{
+			name:      "plans",
+			noun:      "plans",
+			source:    "plans/",
+			indexPath: index.FilePath(root),
+			rebuild:   func() (int, error) { return index.RebuildWithOptions(root, opts) },
+			check: func() (int, bool, error) {
+				entries, err := index.Build(root, opts)
+				ok, cmpErr := indexFileMatches(index.FilePath(root), index.Header, entries)
+				return len(entries), ok, errors.Join(err, cmpErr)
+			},
+		},
+		{
+			name:      "tasks",
+			noun:      "tasks",
+			source:    "tasks/",
+			indexPath: task.TaskIndexFilePath(root),
+			rebuild:   store.RebuildTaskIndex,
+			check: func() (int, bool, error) {
+				entries, err := store.BuildTaskIndex()
+				ok, cmpErr := indexFileMatches(task.TaskIndexFilePath(root), task.TaskIndexHeader, entries)
+				return len(entries), ok, errors.Join(err, cmpErr)
+			},
+		},
+	}
+}
+
+// selectSyncTargets narrows targets to the one named by only ("" = all).
+func selectSyncTargets(targets []syncTarget, only string) ([]syncTarget, error) {
+	if only == "" {
+		return targets, nil
+	}
+	if only == "sessions" {
+		only = "plans"
+	}
+	names := make([]string, len(targets))
+	for i, t := range targets {
+		if t.name == only {
+			return []syncTarget{t}, nil
+		}
+		names[i] = t.name
+	}
+	return nil, fmt.Errorf("invalid --only %q: must be one of %s", only, strings.Join(names, ", "))
+}
+
+func runSync(only string, check, asJSON, autoLink bool) error {
 	root, err := project.FindRoot()
 	if err != nil {
 		return err
 	}
 
-	cfg, err := config.Load(root)
+	cfg, err := loadConfig(root)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: could not load config (%v) — using defaults\n", err)
+		warnf("could not load config (%v) — using defaults", err)
 		cfg = config.Config{}
 	}
 
-	// --- plans ---------------------------------------------------------------
-	fmt.Println("Rebuilding plan index from plans/...")
-	n, err := index.Rebuild(root, cfg.Plans.ExcerptSection)
+	store := task.NewStore(root, &cfg)
+	targets, err := selectSyncTargets(syncTargets(root, cfg, store), only)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		return err
 	}
-	fmt.Printf("Done. %d plans indexed.\n", n)
 
-	if cfg.Git.AutoPush {
-		planIndexPath := index.FilePath(root)
-		if gitErr := gitutil.Add(root, planIndexPath); gitErr != nil {
-			fmt.Fprintf(os.Stderr, "warning: git add failed for plan index (%v) — stage the file manually\n", gitErr)
+	var linked *autoLinkResult
+	if autoLink {
+		res, err := runAutoLink(root, cfg, store)
+		if err != nil {
+			return fmt.Errorf("auto-link: %w", err)
+		}
+		linked = &res
+		if !asJSON {
+			fmt.Printf("Auto-linked %d plan/task pair(s) (%d plan(s), %d task(s) updated).\n", res.Links, res.Plans, res.Tasks)
 		}
 	}
 
-	// --- tasks ---------------------------------------------------------------
-	fmt.Println("\nRebuilding task index from tasks/...")
-	store := task.NewStore(root, &cfg)
-	m, err := store.RebuildTaskIndex()
-	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+	var escalated []string
+	if !check {
+		escalated = escalateDueTasks(cfg, store, time.Now())
+		if len(escalated) > 0 && !asJSON {
+			fmt.Println(escalationSummary(cfg, len(escalated)))
+		}
+	}
+
+	if !asJSON {
+		verb := "Rebuilding"
+		if check {
+			verb = "Checking"
+		}
+		for _, t := range targets {
+			fmt.Printf("%s %s index from %s...\n", verb, strings.TrimSuffix(t.noun, "s"), t.source)
+		}
+	}
+
+	// Rebuild (or check) every index in parallel; results keep target order.
+	start := time.Now()
+	results := make([]syncResult, len(targets))
+	var wg sync.WaitGroup
+	for i, t := range targets {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			results[i] = syncOne(t, check)
+		}()
+	}
+	wg.Wait()
+	elapsed := time.Since(start)
+
+	summary := syncSummary{Mode: "rebuild", Indexes: results, DurationMS: elapsed.Milliseconds(), AutoLink: linked, Escalated: escalated}
+	if check {
+		summary.Mode = "check"
 	}
-	fmt.Printf("Done. %d tasks indexed.\n", m)
 
-	if cfg.Git.AutoPush {
-		taskIndexPath := task.TaskIndexFilePath(root)
-		if gitErr := gitutil.Add(root, taskIndexPath); gitErr != nil {
-			fmt.Fprintf(os.Stderr, "warning: git add failed for task index (%v) — stage the file manually\n", gitErr)
+	var stale []string
+	for i, r := range results {
+		t := targets[i]
+		if r.Error != "" {
+			warnf("%s", r.Error)
 		}
+		if r.UpToDate != nil && !*r.UpToDate {
+			stale = append(stale, t.name)
+		}
+		if !asJSON {
+			printSyncResult(t, r)
+		}
+		if !check {
+			stageFiles(root, cfg, t.indexPath)
+		}
+	}
+
+	if slices.ContainsFunc(targets, func(t syncTarget) bool { return t.name == "tasks" }) {
+		summary.Strays = reportStrays(root, store)
+	}
+	summary.Conflicts = reportConflicts(root)
+	warnGuardrails(root, cfg)
+	if !check {
+		refreshContextFile(root, cfg)
 	}
 
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(summary); err != nil {
+			return err
+		}
+	} else if !check {
+		fmt.Printf("Done. Synced %d index(es) in %s.\n", len(targets), formatSyncDuration(elapsed))
+	}
+
+	if len(stale) > 0 {
+		return fmt.Errorf("index out of date: %s — run `logos sync` to rebuild", strings.Join(stale, ", "))
+	}
 	return nil
 }
+
+// syncOne rebuilds or checks a single target and times it.
+func syncOne(t syncTarget, check bool) syncResult {
+	start := time.Now()
+	r := syncResult{Name: t.name}
+	var err error
+	if check {
+		var ok bool
+		r.Count, ok, err = t.check()
+		r.UpToDate = &ok
+	} else {
+		r.Count, err = t.rebuild()
+	}
+	if err != nil {
+		r.Error = err.Error()
+	}
+	r.DurationMS = time.Since(start).Milliseconds()
+	return r
+}
+
+func printSyncResult(t syncTarget, r syncResult) {
+	d := formatSyncDuration(time.Duration(r.DurationMS) * time.Millisecond)
+	switch {
+	case r.UpToDate == nil:
+		fmt.Printf("Done. %d %s indexed (%s).\n", r.Count, t.noun, d)
+	case *r.UpToDate:
+		fmt.Printf("%s index is up to date (%d %s).\n", strings.TrimSuffix(t.noun, "s"), r.Count, t.noun)
+	default:
+		fmt.Printf("%s index is OUT OF DATE (%d %s on disk).\n", strings.TrimSuffix(t.noun, "s"), r.Count, t.noun)
+	}
+}
+
+// formatSyncDuration renders d for progress output, e.g. "<1ms" or "35ms".
+func formatSyncDuration(d time.Duration) string {
+	if d < time.Millisecond {
+		return "<1ms"
+	}
+	return d.Round(time.Millisecond).String()
+}
+
+// indexFileMatches reports whether the JSONL file at path holds exactly the
+// given entries under header h, ignoring line order. A missing file matches
+// only when there are no entries, and a file without h (one written by
+// another version of logos) never does. The file is read strictly: unlike
+// ls and task ls, which skip malformed lines, the first malformed or
+// incomplete line makes the index out of date and is returned as the error.
+func indexFileMatches[T any](path string, h jsonl.Header, entries []T) (bool, error) {
+	if _, err := jsonl.Read[T](path); err != nil {
+		if os.IsNotExist(err) {
+			return len(entries) == 0, nil
+		}
+		return false, fmt.Errorf("%s: %w", filepath.Base(path), err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return false, err
+	}
+
+	var have []string
+	for _, line := range strings.Split(string(data), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			have = append(have, line)
+		}
+	}
+	hb, err := json.Marshal(h)
+	if err != nil {
+		return false, err
+	}
+	want := append(make([]string, 0, len(entries)+1), string(hb))
+	for _, e := range entries {
+		b, err := json.Marshal(e)
+		if err != nil {
+			return false, err
+		}
+		want = append(want, string(b))
+	}
+	slices.Sort(have)
+	slices.Sort(want)
+	return slices.Equal(have, want), nil
+}
+
+// indexProblem describes why an index read failed with err, for the
+// auto-rebuild notice shown by ls, search, and task ls.
+func indexProblem(err error) string {
+	if errors.Is(err, jsonl.ErrPartial) {
+		return "is partially written (interrupted rebuild?)"
+	}
+	if errors.Is(err, jsonl.ErrOldVersion) {
+		return "was written by an older version of logos"
+	}
+	return "not found"
+}
+
+// warnSkippedLines prints a warning when err reports malformed lines that a
+// tolerant index read skipped, suggesting logos sync. It returns false when
+// err is not such a report, leaving the caller to handle it.
+func warnSkippedLines(name string, err error) bool {
+	var skipped *jsonl.SkippedError
+	if !errors.As(err, &skipped) {
+		return false
+	}
+	warnf("%s: %v — run `logos sync` to rebuild it", name, skipped)
+	return true
+}
+
+// planParseOptions returns the plan parse options configured for the
+// project, for reading plans. An excerpt command is left out: it only runs
+// when index entries are built (see planIndexOptions), so plain reads take
+// the whole excerpt section instead.
+func planParseOptions(cfg config.Config) plan.ParseOptions {
+	opts := planIndexOptions(cfg)
+	if cfg.Plans.ExcerptStrategy == markdown.StrategyCommand {
+		opts.Strategy = nil
+	}
+	return opts
+}
+
+// planIndexOptions returns the plan parse options configured for the
+// project (excerpt section, cleanup, strategy, and length limits), for
+// building index entries. A broken excerpt strategy is reported once and
+// replaced by the whole section; invalid strip prefixes are reported and
+// left out.
+func planIndexOptions(cfg config.Config) plan.ParseOptions {
+	opts := plan.ParseOptions{
+		ExcerptSection:  cfg.Plans.ExcerptSection,
+		ExcerptFallback: cfg.Plans.ExcerptFallback,
+		ExcerptSkipBody: cfg.Plans.ExcerptSkipBody,
+		MaxRunes:        cfg.Plans.ExcerptMaxRunes,
+		CJKMaxRunes:     cfg.Plans.ExcerptCJKMaxRunes,
+		CleanMarkdown:   cfg.Plans.ExcerptCleanMarkdown,
+	}
+	prefixes, err := markdown.CompilePrefixes(cfg.Plans.ExcerptStripPrefixes)
+	if err != nil {
+		warnf("plans.excerpt_strip_prefixes: %v", err)
+	}
+	opts.StripPrefixes = prefixes
+	e, err := markdown.NewExcerpter(cfg.Plans.ExcerptStrategy, cfg.Plans.ExcerptSentences, cfg.Plans.ExcerptCommand)
+	if err != nil {
+		warnf("plans.excerpt_strategy: %v — using the whole section", err)
+		return opts
+	}
+	opts.Strategy = markdown.WithFallback(e, func(err error) {
+		warnf("plans.excerpt_strategy: %v — using the whole section", err)
+	})
+	return opts
+}
+
+// reportStrays prints a warning for every stray task file found under
+// .logosyncx/tasks/ so that misplaced or unknown-status files are never
+// silently ignored.
+func reportStrays(root string, store *task.Store) int {
+	strays, err := store.FindStrays()
+	if err != nil {
+		warnf("%v", err)
+	}
+	if len(strays) == 0 {
+		return 0
+	}
+	if warningsJSON {
+		for _, st := range strays {
+			rel, _ := relPath(root, st.Path)
+			warnf("stray task file [%s] %s — %s", st.Kind, rel, st.Detail)
+		}
+		return len(strays)
+	}
+	fmt.Fprintf(os.Stderr, "\nwarning: %d stray task file(s) found:\n", len(strays))
+	for _, st := range strays {
+		rel, _ := relPath(root, st.Path)
+		fmt.Fprintf(os.Stderr, "  [%s] %s — %s\n", st.Kind, rel, st.Detail)
+	}
+	fmt.Fprintln(os.Stderr, "  Use `logos doctor --fix-status-dirs` to move misplaced files into place,")
+	fmt.Fprintln(os.Stderr, "  `logos doctor --fix-plan-fields` to fix plan fields that disagree with their directory,")
+	fmt.Fprintln(os.Stderr, "  or `logos task migrate-status --from <old> --to <new>` to fix unknown statuses.")
+	return len(strays)
+}
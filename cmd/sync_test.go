@@ -1,12 +1,15 @@
 package cmd
 
 import (
+	"encoding/json"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
 	"github.com/senna-lang/logosyncx/pkg/index"
 	"github.com/senna-lang/logosyncx/pkg/plan"
 )
@@ -59,7 +62,7 @@ func TestSync_NotInitialized_ReturnsError(t *testing.T) {
 	}
 	t.Cleanup(func() { _ = os.Chdir(orig) })
 
-	if err := runSync(); err == nil {
+	if err := runSync("", false, false, false); err == nil {
 		t.Fatal("expected error when project not initialized, got nil")
 	}
 }
@@ -70,7 +73,7 @@ func TestSync_EmptySessions_CreatesEmptyIndex(t *testing.T) {
 	dir := setupInitedProject(t)
 
 	out := captureOutput(t, func() {
-		if err := runSync(); err != nil {
+		if err := runSync("", false, false, false); err != nil {
 			t.Fatalf("runSync failed: %v", err)
 		}
 	})
@@ -97,7 +100,7 @@ func TestSync_IndexesSessions(t *testing.T) {
 	writeSyncPlan(t, dir, makeSyncPlan("id2", "db-schema", dateMinus1))
 
 	out := captureOutput(t, func() {
-		if err := runSync(); err != nil {
+		if err := runSync("", false, false, false); err != nil {
 			t.Fatalf("runSync failed: %v", err)
 		}
 	})
@@ -121,7 +124,7 @@ func TestSync_PrintsRebuildingMessage(t *testing.T) {
 	setupInitedProject(t)
 
 	out := captureOutput(t, func() {
-		if err := runSync(); err != nil {
+		if err := runSync("", false, false, false); err != nil {
 			t.Fatalf("runSync failed: %v", err)
 		}
 	})
@@ -135,7 +138,7 @@ func TestSync_PrintsDoneMessage(t *testing.T) {
 	setupInitedProject(t)
 
 	out := captureOutput(t, func() {
-		if err := runSync(); err != nil {
+		if err := runSync("", false, false, false); err != nil {
 			t.Fatalf("runSync failed: %v", err)
 		}
 	})
@@ -165,7 +168,7 @@ func TestSync_OverwritesStaleIndex(t *testing.T) {
 	realDate := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
 	writeSyncPlan(t, dir, makeSyncPlan("real1", "real-topic", realDate))
 
-	if err := runSync(); err != nil {
+	if err := runSync("", false, false, false); err != nil {
 		t.Fatalf("runSync failed: %v", err)
 	}
 
@@ -202,7 +205,7 @@ func TestSync_IndexEntry_HasCorrectFields(t *testing.T) {
 	}
 	writeSyncPlan(t, dir, p)
 
-	if err := runSync(); err != nil {
+	if err := runSync("", false, false, false); err != nil {
 		t.Fatalf("runSync failed: %v", err)
 	}
 
@@ -247,7 +250,7 @@ func TestSync_Idempotent(t *testing.T) {
 	writeSyncPlan(t, dir, makeSyncPlan("idem1", "idempotent-test", idemDate))
 
 	for range 2 {
-		if err := runSync(); err != nil {
+		if err := runSync("", false, false, false); err != nil {
 			t.Fatalf("runSync failed: %v", err)
 		}
 	}
@@ -260,3 +263,334 @@ func TestSync_Idempotent(t *testing.T) {
 		t.Errorf("expected 1 entry after two syncs (not duplicated), got %d", len(entries))
 	}
 }
+
+// --- runSync: --only ---------------------------------------------------------
+
+func TestSync_OnlyTasks_LeavesPlanIndexAlone(t *testing.T) {
+	dir := setupInitedProject(t)
+	writeSyncPlan(t, dir, makeSyncPlan("only1", "only-test", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	_ = os.Remove(index.FilePath(dir))
+
+	out := captureOutput(t, func() {
+		if err := runSync("tasks", false, false, false); err != nil {
+			t.Fatalf("runSync: %v", err)
+		}
+	})
+	if strings.Contains(out, "plans indexed") || !strings.Contains(out, "tasks indexed") {
+		t.Errorf("expected only the task index to be rebuilt, got: %q", out)
+	}
+	if _, err := os.Stat(index.FilePath(dir)); !os.IsNotExist(err) {
+		t.Errorf("plan index should not be written with --only tasks, stat err = %v", err)
+	}
+}
+
+func TestSync_OnlySessions_IsAliasForPlans(t *testing.T) {
+	setupInitedProject(t)
+	out := captureOutput(t, func() {
+		if err := runSync("sessions", false, false, false); err != nil {
+			t.Fatalf("runSync: %v", err)
+		}
+	})
+	if !strings.Contains(out, "plans indexed") || strings.Contains(out, "tasks indexed") {
+		t.Errorf("expected only the plan index to be rebuilt, got: %q", out)
+	}
+}
+
+func TestSync_OnlyInvalid_ReturnsError(t *testing.T) {
+	setupInitedProject(t)
+	if err := runSync("knowledge", false, false, false); err == nil {
+		t.Fatal("expected error for unknown --only value")
+	}
+}
+
+// --- runSync: --check --------------------------------------------------------
+
+func TestSync_Check_UpToDateAfterSync(t *testing.T) {
+	dir := setupInitedProject(t)
+	writeSyncPlan(t, dir, makeSyncPlan("chk1", "check-test", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	captureOutput(t, func() {
+		if err := runSync("", false, false, false); err != nil {
+			t.Fatalf("runSync: %v", err)
+		}
+	})
+
+	out := captureOutput(t, func() {
+		if err := runSync("", true, false, false); err != nil {
+			t.Fatalf("runSync --check after sync: %v", err)
+		}
+	})
+	if !strings.Contains(out, "plan index is up to date") {
+		t.Errorf("expected up-to-date message, got: %q", out)
+	}
+}
+
+func TestSync_Check_StaleIndexFailsWithoutWriting(t *testing.T) {
+	dir := setupInitedProject(t)
+	captureOutput(t, func() {
+		if err := runSync("", false, false, false); err != nil {
+			t.Fatalf("runSync: %v", err)
+		}
+	})
+	writeSyncPlan(t, dir, makeSyncPlan("chk2", "added-later", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	var err error
+	captureOutput(t, func() { err = runSync("", true, false, false) })
+	if err == nil || !strings.Contains(err.Error(), "plans") {
+		t.Fatalf("expected out-of-date error naming plans, got %v", err)
+	}
+	entries, _ := index.ReadAll(dir)
+	if len(entries) != 0 {
+		t.Errorf("--check must not write the index, got %d entries", len(entries))
+	}
+}
+
+func TestSync_Check_MalformedLineIsStale(t *testing.T) {
+	dir := setupInitedProject(t)
+	writeSyncPlan(t, dir, makeSyncPlan("chk3", "conflicted", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	captureOutput(t, func() {
+		if err := runSync("", false, false, false); err != nil {
+			t.Fatalf("runSync: %v", err)
+		}
+	})
+	f, err := os.OpenFile(index.FilePath(dir), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.WriteString("<<<<<<< HEAD\n")
+	f.Close()
+
+	// ls skips the bad line and still lists the plan.
+	out := captureOutput(t, func() {
+		if err := runLS(lsOptions{}); err != nil {
+			t.Fatalf("runLS: %v", err)
+		}
+	})
+	if !strings.Contains(out, "conflicted") {
+		t.Errorf("expected ls to list the valid entry, got: %q", out)
+	}
+
+	// --check stays strict.
+	captureOutput(t, func() { err = runSync("", true, false, false) })
+	if err == nil || !strings.Contains(err.Error(), "plans") {
+		t.Fatalf("expected out-of-date error naming plans, got %v", err)
+	}
+}
+
+func TestSync_Check_HeaderlessIndexIsStale(t *testing.T) {
+	dir := setupInitedProject(t)
+	writeSyncPlan(t, dir, makeSyncPlan("chk4", "legacy", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	captureOutput(t, func() {
+		if err := runSync("", false, false, false); err != nil {
+			t.Fatalf("runSync: %v", err)
+		}
+	})
+	// Drop the schema header, as an index written before headers existed.
+	data, _ := os.ReadFile(index.FilePath(dir))
+	_, rest, _ := strings.Cut(string(data), "\n")
+	os.WriteFile(index.FilePath(dir), []byte(rest), 0o644)
+
+	var err error
+	captureOutput(t, func() { err = runSync("", true, false, false) })
+	if err == nil || !strings.Contains(err.Error(), "plans") {
+		t.Fatalf("expected out-of-date error naming plans, got %v", err)
+	}
+}
+
+func TestLS_OldIndexVersion_RebuiltAutomatically(t *testing.T) {
+	dir := setupInitedProject(t)
+	writeSyncPlan(t, dir, makeSyncPlan("old1", "on-disk", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+	// A headerless index from an older logos, listing a plan that is gone.
+	os.WriteFile(index.FilePath(dir), []byte(`{"id":"ghost","filename":"ghost.md","topic":"ghost-plan"}`+"\n"), 0o644)
+
+	var out string
+	errOut := captureStderr(t, func() {
+		out = captureOutput(t, func() {
+			if err := runLS(lsOptions{}); err != nil {
+				t.Fatalf("runLS: %v", err)
+			}
+		})
+	})
+	if !strings.Contains(errOut, "older version of logos") {
+		t.Errorf("expected rebuild notice, got: %q", errOut)
+	}
+	if !strings.Contains(out, "on-disk") || strings.Contains(out, "ghost-plan") {
+		t.Errorf("expected the rebuilt index to list only on-disk plans, got: %q", out)
+	}
+	if err := index.CheckVersion(dir); err != nil {
+		t.Errorf("CheckVersion after rebuild: %v", err)
+	}
+}
+
+func TestLS_NewerIndexVersion_ReadWithWarning(t *testing.T) {
+	dir := setupInitedProject(t)
+	data := `{"logosyncx_schema":"logosyncx.index","logosyncx_version":99}` + "\n" + `{"id":"n1","filename":"n1.md","topic":"from-the-future","new_field":[1,2]}` + "\n"
+	os.WriteFile(index.FilePath(dir), []byte(data), 0o644)
+
+	var out string
+	errOut := captureStderr(t, func() {
+		out = captureOutput(t, func() {
+			if err := runLS(lsOptions{}); err != nil {
+				t.Fatalf("runLS: %v", err)
+			}
+		})
+	})
+	if !strings.Contains(errOut, "newer version of logos") {
+		t.Errorf("expected upgrade warning, got: %q", errOut)
+	}
+	if !strings.Contains(out, "from-the-future") {
+		t.Errorf("expected the entry to be listed, got: %q", out)
+	}
+}
+
+// --- runSync: --json ---------------------------------------------------------
+
+func TestSync_JSON_Summary(t *testing.T) {
+	dir := setupInitedProject(t)
+	writeSyncPlan(t, dir, makeSyncPlan("js1", "json-test", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)))
+
+	out := captureOutput(t, func() {
+		if err := runSync("", false, true, false); err != nil {
+			t.Fatalf("runSync: %v", err)
+		}
+	})
+	var got syncSummary
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatalf("unmarshal %q: %v", out, err)
+	}
+	if got.Mode != "rebuild" || len(got.Indexes) != 2 {
+		t.Fatalf("unexpected summary: %+v", got)
+	}
+	if got.Indexes[0].Name != "plans" || got.Indexes[0].Count != 1 {
+		t.Errorf("plans result = %+v, want 1 plan", got.Indexes[0])
+	}
+	if got.Indexes[1].Name != "tasks" {
+		t.Errorf("second index = %q, want tasks", got.Indexes[1].Name)
+	}
+}
+
+// --- runSync: --auto-link ----------------------------------------------------
+
+func TestSync_AutoLink_LinksMentionsBothWays(t *testing.T) {
+	dir := setupInitedProject(t)
+	date := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	planB := "20260101-plan-b"
+
+	if err := runTaskCreate(dir, taskCreateOptions{plan: planB, title: "Mentioned by plan", priority: "medium"}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: planB, title: "Mentions plan", priority: "medium"}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	var mentioned, mentioning *task.Task
+	for _, tk := range loadAllTasks(t, dir) {
+		if tk.Title == "Mentioned by plan" {
+			mentioned = tk
+		} else {
+			mentioning = tk
+		}
+	}
+
+	pa := makeSyncPlan("pa", "plan-a", date)
+	pa.Body = "## Background\nFollow-up of " + mentioned.ID + ".\n"
+	writeSyncPlan(t, dir, pa)
+	writeSyncPlan(t, dir, makeSyncPlan("pb", "plan-b", date))
+	// Mentioning the task's own plan must not create a link.
+	taskPath := filepath.Join(mentioning.DirPath, "TASK.md")
+	f, err := os.OpenFile(taskPath, os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open TASK.md: %v", err)
+	}
+	_, _ = f.WriteString("\nSee .logosyncx/plans/20260101-plan-a.md and 20260101-plan-b.md.\n")
+	f.Close()
+
+	out := captureOutput(t, func() {
+		if err := runSync("", false, false, true); err != nil {
+			t.Fatalf("runSync: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Auto-linked 2 plan/task pair(s)") {
+		t.Errorf("expected 2 links reported, got: %q", out)
+	}
+
+	pl, err := plan.LoadFile(filepath.Join(plan.PlansDir(dir), "20260101-plan-a.md"))
+	if err != nil {
+		t.Fatalf("load plan: %v", err)
+	}
+	if len(pl.RelatedTasks) != 2 {
+		t.Errorf("plan related_tasks = %v, want both task IDs", pl.RelatedTasks)
+	}
+	if !strings.Contains(pl.Body, "Follow-up of") {
+		t.Errorf("plan body should be preserved, got %q", pl.Body)
+	}
+	for _, tk := range loadAllTasks(t, dir) {
+		if len(tk.RelatedPlans) != 1 || tk.RelatedPlans[0] != "20260101-plan-a.md" {
+			t.Errorf("task %q related_plans = %v, want [20260101-plan-a.md]", tk.Title, tk.RelatedPlans)
+		}
+	}
+
+	// A second run finds nothing new.
+	out = captureOutput(t, func() {
+		if err := runSync("", false, false, true); err != nil {
+			t.Fatalf("runSync: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Auto-linked 0 plan/task pair(s)") {
+		t.Errorf("expected no new links on second run, got: %q", out)
+	}
+}
+
+// --- runSync: excerpt strategies ---------------------------------------------
+
+func TestSync_ExcerptStrategy(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Plans.ExcerptStrategy = "sentences"
+	cfg.Plans.ExcerptSentences = 1
+	cfg.Tasks.ExcerptStrategy = "command"
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	local := config.LocalConfig{Tasks: config.LocalExcerptConfig{ExcerptCommand: "logos-no-such-summarizer"}}
+	if err := config.SaveLocal(dir, local); err != nil {
+		t.Fatal(err)
+	}
+	p := makeSyncPlan("ex1", "excerpt-test", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	p.Body = "## Background\nFirst sentence. Second sentence.\n"
+	writeSyncPlan(t, dir, p)
+	slug := strings.TrimSuffix(plan.FileName(p), ".md")
+	captureOutput(t, func() {
+		for _, title := range []string{"First task", "Second task"} {
+			if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: title}); err != nil {
+				t.Fatal(err)
+			}
+		}
+	})
+	for _, tk := range loadAllTasks(t, dir) {
+		path := filepath.Join(tk.DirPath, "TASK.md")
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, append(data, "\n## What\n\nShip it.\n"...), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	stderr := captureStderr(t, func() {
+		captureOutput(t, func() {
+			if err := runSync("", false, false, false); err != nil {
+				t.Fatalf("runSync: %v", err)
+			}
+		})
+	})
+	entries, err := index.ReadAll(dir)
+	if err != nil || len(entries) != 1 || entries[0].Excerpt != "First sentence." {
+		t.Errorf("plan excerpt: %+v, %v", entries, err)
+	}
+	if n := strings.Count(stderr, "tasks.excerpt_strategy"); n != 1 {
+		t.Errorf("want one excerpt command warning, got %d:\n%s", n, stderr)
+	}
+}
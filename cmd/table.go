@@ -0,0 +1,278 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// textTable renders rows as left-aligned columns separated by two spaces,
+// like text/tabwriter, but measures cells by display width so that CJK and
+// other wide characters line up.
+type textTable struct {
+	headers []string
+	rows    [][]string
+	// maxWidths caps each column's display width; longer cells are
+	// ellipsized. 0 (or a missing entry) means no cap.
+	maxWidths []int
+	// wrapLast, when > 0, word-wraps the last column so that each line fits
+	// in this total display width. Continuation lines are indented to the
+	// column's start.
+	wrapLast int
+	// fitWidth, when > 0, is the total display width the table should fit
+	// in. Columns listed in shrink are narrowed, widest first, and their
+	// cells ellipsized until the table fits or every shrinkable column is
+	// down to minShrinkWidth.
+	fitWidth int
+	shrink   []int
+}
+
+// minShrinkWidth is the narrowest fitWidth will make a shrinkable column.
+const minShrinkWidth = 12
+
+// addRow appends a row of cells.
+func (t *textTable) addRow(cells ...string) {
+	t.rows = append(t.rows, cells)
+}
+
+// render writes the header, a dashed rule under each heading, and the rows.
+func (t *textTable) render(w io.Writer) error {
+	n := len(t.headers)
+	cells := make([][]string, 0, len(t.rows)+2)
+	rule := make([]string, n)
+	for i, h := range t.headers {
+		rule[i] = strings.Repeat("-", displayWidth(h))
+	}
+	cells = append(cells, t.headers, rule)
+	for _, r := range t.rows {
+		row := make([]string, n)
+		for i := range row {
+			if i < len(r) {
+				row[i] = r[i]
+			}
+			if i < len(t.maxWidths) && t.maxWidths[i] > 0 {
+				row[i] = truncateWidth(row[i], t.maxWidths[i])
+			}
+		}
+		cells = append(cells, row)
+	}
+
+	widths := make([]int, n)
+	for _, row := range cells {
+		for i, c := range row {
+			widths[i] = max(widths[i], displayWidth(c))
+		}
+	}
+	if t.fitWidth > 0 {
+		t.fit(widths)
+		for _, row := range cells[2:] {
+			for _, i := range t.shrink {
+				row[i] = truncateWidth(row[i], widths[i])
+			}
+		}
+	}
+
+	lastStart := 0
+	for _, wd := range widths[:n-1] {
+		lastStart += wd + 2
+	}
+	lastWidth := 0
+	if t.wrapLast > 0 {
+		lastWidth = max(t.wrapLast-lastStart, minWrapWidth)
+	}
+
+	var b strings.Builder
+	for r, row := range cells {
+		last := []string{row[n-1]}
+		if lastWidth > 0 && r > 1 {
+			last = wrapWidth(row[n-1], lastWidth)
+		}
+		for i, c := range row[:n-1] {
+			b.WriteString(c)
+			b.WriteString(strings.Repeat(" ", widths[i]-displayWidth(c)+2))
+		}
+		b.WriteString(last[0])
+		for _, line := range last[1:] {
+			b.WriteString("\n")
+			b.WriteString(strings.Repeat(" ", lastStart))
+			b.WriteString(line)
+		}
+		// Trim padding after an empty last cell.
+		fmt.Fprintln(w, strings.TrimRight(b.String(), " "))
+		b.Reset()
+	}
+	return nil
+}
+
+// fit narrows the shrinkable columns in widths until the table fits in
+// t.fitWidth. A column never gets narrower than its heading.
+func (t *textTable) fit(widths []int) {
+	total := 2 * (len(widths) - 1)
+	for _, w := range widths {
+		total += w
+	}
+	for total > t.fitWidth {
+		widest := -1
+		for _, i := range t.shrink {
+			floor := max(minShrinkWidth, displayWidth(t.headers[i]))
+			if widths[i] > floor && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			return
+		}
+		widths[widest]--
+		total--
+	}
+}
+
+// minWrapWidth is the narrowest a wrapped column gets, however narrow the
+// terminal.
+const minWrapWidth = 20
+
+// defaultTerminalWidth is assumed when stdout is not a terminal and
+// $COLUMNS is unset.
+const defaultTerminalWidth = 120
+
+// fullTables, when true, disables fitting tables to the terminal width.
+// Set by the --full flag of listing commands.
+var fullTables bool
+
+// tableWidth returns the width listing tables should fit in, or 0 when they
+// should not be truncated: with --full, or when stdout is not a terminal and
+// $COLUMNS is unset, so that piped output keeps every character.
+func tableWidth() int {
+	if fullTables {
+		return 0
+	}
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	w, _ := ttySize(os.Stdout)
+	return w
+}
+
+// terminalWidth returns the width of the terminal stdout is attached to,
+// $COLUMNS when set, or defaultTerminalWidth.
+func terminalWidth() int {
+	if n, err := strconv.Atoi(os.Getenv("COLUMNS")); err == nil && n > 0 {
+		return n
+	}
+	if n, _ := ttySize(os.Stdout); n > 0 {
+		return n
+	}
+	return defaultTerminalWidth
+}
+
+// runeWidth returns the number of terminal columns r occupies: 2 for East
+// Asian wide and fullwidth characters, 0 for combining marks and control
+// characters, 1 otherwise.
+func runeWidth(r rune) int {
+	switch {
+	case r == 0, unicode.Is(unicode.Mn, r), unicode.Is(unicode.Me, r), unicode.IsControl(r):
+		return 0
+	case r >= 0x1100 && r <= 0x115F, // Hangul Jamo
+		r >= 0x2E80 && r <= 0x303E, // CJK radicals, punctuation
+		r >= 0x3041 && r <= 0x33FF, // kana, CJK symbols
+		r >= 0x3400 && r <= 0x4DBF, // CJK extension A
+		r >= 0x4E00 && r <= 0x9FFF, // CJK unified ideographs
+		r >= 0xA000 && r <= 0xA4CF, // Yi
+		r >= 0xAC00 && r <= 0xD7A3, // Hangul syllables
+		r >= 0xF900 && r <= 0xFAFF, // CJK compatibility ideographs
+		r >= 0xFE30 && r <= 0xFE4F, // CJK compatibility forms
+		r >= 0xFF00 && r <= 0xFF60, // fullwidth forms
+		r >= 0xFFE0 && r <= 0xFFE6,
+		r >= 0x1F300 && r <= 0x1F64F, // emoji
+		r >= 0x1F900 && r <= 0x1F9FF,
+		r >= 0x20000 && r <= 0x3FFFD: // CJK extensions B and later
+		return 2
+	}
+	return 1
+}
+
+// displayWidth returns the number of terminal columns s occupies.
+func displayWidth(s string) int {
+	n := 0
+	for _, r := range s {
+		n += runeWidth(r)
+	}
+	return n
+}
+
+// truncateWidth shortens s to at most width terminal columns, ending it
+// with "…" when anything was cut. It never splits a character.
+func truncateWidth(s string, width int) string {
+	if displayWidth(s) <= width {
+		return s
+	}
+	if width <= 0 {
+		return ""
+	}
+	var b strings.Builder
+	used := 0
+	for _, r := range s {
+		rw := runeWidth(r)
+		if used+rw > width-1 {
+			break
+		}
+		b.WriteRune(r)
+		used += rw
+	}
+	return b.String() + "…"
+}
+
+// wrapWidth breaks s into lines of at most width terminal columns. Lines
+// break at spaces where possible; a word wider than width, or a run of CJK
+// text (which has no spaces), is broken between characters.
+func wrapWidth(s string, width int) []string {
+	words := strings.Fields(s)
+	if len(words) == 0 {
+		return []string{""}
+	}
+	var lines []string
+	var line strings.Builder
+	used := 0
+	flush := func() {
+		lines = append(lines, line.String())
+		line.Reset()
+		used = 0
+	}
+	for _, word := range words {
+		ww := displayWidth(word)
+		if used > 0 && used+1+ww <= width {
+			line.WriteByte(' ')
+			line.WriteString(word)
+			used += 1 + ww
+			continue
+		}
+		if used > 0 && ww <= width {
+			flush()
+		}
+		if ww <= width {
+			line.WriteString(word)
+			used = ww
+			continue
+		}
+		// Break an over-long word between characters.
+		if used > 0 {
+			line.WriteByte(' ')
+			used++
+		}
+		for _, r := range word {
+			rw := runeWidth(r)
+			if used+rw > width {
+				flush()
+			}
+			line.WriteRune(r)
+			used += rw
+		}
+	}
+	if used > 0 {
+		flush()
+	}
+	return lines
+}
@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"os"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestDisplayWidth(t *testing.T) {
+	cases := map[string]int{
+		"":     0,
+		"abc":  3,
+		"認証":   4,
+		"a認b":  4,
+		"ｶﾀｶﾅ": 4, // halfwidth katakana
+		"Ａ":    2, // fullwidth latin
+		"é":   1, // combining accent
+	}
+	for s, want := range cases {
+		if got := displayWidth(s); got != want {
+			t.Errorf("displayWidth(%q) = %d, want %d", s, got, want)
+		}
+	}
+}
+
+func TestTruncateWidth(t *testing.T) {
+	cases := []struct {
+		s     string
+		width int
+		want  string
+	}{
+		{"short", 10, "short"},
+		{"exactly", 7, "exactly"},
+		{"truncated", 6, "trunc…"},
+		{"認証の設計", 6, "認証…"},
+		{"認証の設計", 7, "認証の…"},
+		{"abc", 0, ""},
+	}
+	for _, c := range cases {
+		if got := truncateWidth(c.s, c.width); got != c.want {
+			t.Errorf("truncateWidth(%q, %d) = %q, want %q", c.s, c.width, got, c.want)
+		}
+	}
+}
+
+func TestWrapWidth(t *testing.T) {
+	cases := []struct {
+		s     string
+		width int
+		want  []string
+	}{
+		{"", 10, []string{""}},
+		{"one two three", 7, []string{"one two", "three"}},
+		{"abcdefghij", 4, []string{"abcd", "efgh", "ij"}},
+		{"認証の設計を決めた", 6, []string{"認証の", "設計を", "決めた"}},
+	}
+	for _, c := range cases {
+		if got := wrapWidth(c.s, c.width); !slices.Equal(got, c.want) {
+			t.Errorf("wrapWidth(%q, %d) = %q, want %q", c.s, c.width, got, c.want)
+		}
+	}
+}
+
+func TestTerminalWidth_Columns(t *testing.T) {
+	t.Setenv("COLUMNS", "77")
+	if got := terminalWidth(); got != 77 {
+		t.Errorf("terminalWidth() = %d, want 77", got)
+	}
+}
+
+func TestTextTable_FitShrinksWidestColumn(t *testing.T) {
+	tbl := &textTable{
+		headers:  []string{"ID", "TITLE", "PLAN"},
+		fitWidth: 40,
+		shrink:   []int{1, 2},
+	}
+	tbl.addRow("1", strings.Repeat("t", 40), "20260101-short.md")
+	out := captureOutput(t, func() {
+		if err := tbl.render(os.Stdout); err != nil {
+			t.Fatal(err)
+		}
+	})
+	for _, l := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		if w := displayWidth(l); w > 40 {
+			t.Errorf("line is %d columns wide, want <= 40: %q", w, l)
+		}
+	}
+	if !strings.Contains(out, "t…") {
+		t.Errorf("expected the long title to be ellipsized, got:\n%s", out)
+	}
+}
+
+func TestTextTable_FitStopsAtMinimum(t *testing.T) {
+	tbl := &textTable{headers: []string{"TITLE"}, fitWidth: 5, shrink: []int{0}}
+	tbl.addRow(strings.Repeat("x", 30))
+	out := captureOutput(t, func() {
+		if err := tbl.render(os.Stdout); err != nil {
+			t.Fatal(err)
+		}
+	})
+	want := strings.Repeat("x", minShrinkWidth-1) + "…"
+	if !strings.Contains(out, want) {
+		t.Errorf("expected column shrunk to %d columns, got:\n%s", minShrinkWidth, out)
+	}
+}
+
+func TestTableWidth(t *testing.T) {
+	t.Setenv("COLUMNS", "90")
+	if got := tableWidth(); got != 90 {
+		t.Errorf("tableWidth() = %d, want 90", got)
+	}
+	fullTables = true
+	t.Cleanup(func() { fullTables = false })
+	if got := tableWidth(); got != 0 {
+		t.Errorf("tableWidth() with --full = %d, want 0", got)
+	}
+}
@@ -0,0 +1,51 @@
+package cmd
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/suggest"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/index"
+)
+
+// tagTypoDistance is the largest edit distance at which a new tag is
+// reported as a likely misspelling of an existing one.
+const tagTypoDistance = 2
+
+// knownTags returns every tag used by a plan or task in the indexes,
+// sorted. Missing indexes contribute nothing.
+func knownTags(root string) []string {
+	var tags []string
+	entries, _ := index.ReadAll(root)
+	for _, e := range entries {
+		tags = append(tags, e.Tags...)
+	}
+	tasks, _ := task.ReadAllTaskIndex(root)
+	for _, t := range tasks {
+		tags = append(tags, t.Tags...)
+	}
+	slices.Sort(tags)
+	return slices.Compact(tags)
+}
+
+// warnTagTypos warns about every tag not yet used in the project that
+// differs only in case, or by at most tagTypoDistance edits, from one that
+// is, e.g. "postgress" next to "postgres". Tags in allowed are never
+// reported.
+func warnTagTypos(root string, tags, allowed []string) {
+	if len(tags) == 0 {
+		return
+	}
+	known := knownTags(root)
+	for _, tag := range tags {
+		if slices.Contains(known, tag) || slices.Contains(allowed, tag) {
+			continue
+		}
+		s := suggest.Closest(tag, known)
+		if s == "" || suggest.Distance(strings.ToLower(tag), strings.ToLower(s)) > tagTypoDistance {
+			continue
+		}
+		warnf("tag %q is new — did you mean the existing tag %q? (--no-suggest to silence)", tag, s)
+	}
+}
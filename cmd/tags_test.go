@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWarnTagTypos(t *testing.T) {
+	withWarningsJSON(t, false)
+	dir := setupInitedProject(t)
+	writePlanFileWithBody(t, dir, makeTestPlan("db", []string{"postgres", "go"}, time.Now()))
+	captureOutput(t, func() {
+		if err := runSync("", false, false, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	got := captureStderr(t, func() {
+		warnTagTypos(dir, []string{"postgress", "Go", "go", "kubernetes", "js"}, nil)
+	})
+	for _, want := range []string{`"postgress" is new — did you mean the existing tag "postgres"?`, `"Go" is new — did you mean the existing tag "go"?`} {
+		if !strings.Contains(got, want) {
+			t.Errorf("missing warning %q in:\n%s", want, got)
+		}
+	}
+	if strings.Count(got, "warning:") != 2 {
+		t.Errorf("expected exactly 2 warnings, got:\n%s", got)
+	}
+
+	if got := captureStderr(t, func() { warnTagTypos(dir, []string{"postgress"}, []string{"postgress"}) }); got != "" {
+		t.Errorf("allowed tags should not be reported, got:\n%s", got)
+	}
+}
+
+func TestSave_NoSuggest(t *testing.T) {
+	dir := setupInitedProject(t)
+	writePlanFileWithBody(t, dir, makeTestPlan("db", []string{"postgres"}, time.Now()))
+	captureOutput(t, func() { _ = runSync("", false, false, false) })
+
+	stderr := captureStderr(t, func() {
+		executeRoot(t, saveCmd, []string{"topic", "tag", "no-suggest"}, "save", "--topic", "quiet", "--tag", "postgress", "--no-suggest")
+	})
+	if strings.Contains(stderr, "did you mean") {
+		t.Errorf("--no-suggest should silence the suggestion, got:\n%s", stderr)
+	}
+}
@@ -2,18 +2,29 @@
 package cmd
 
 import (
-	"bufio"
+	"cmp"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
 	"os"
 	"path/filepath"
+	"regexp"
+	"slices"
 	"strings"
 	"text/tabwriter"
+	"time"
 
+	"github.com/senna-lang/logosyncx/internal/dateparse"
+	"github.com/senna-lang/logosyncx/internal/jsonl"
+	"github.com/senna-lang/logosyncx/internal/markdown"
 	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/recent"
+	"github.com/senna-lang/logosyncx/internal/resolve"
 	"github.com/senna-lang/logosyncx/internal/task"
 	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/index"
+	"github.com/senna-lang/logosyncx/pkg/knowledge"
 	"github.com/senna-lang/logosyncx/pkg/plan"
 	"github.com/spf13/cobra"
 )
@@ -37,6 +48,16 @@ func init() {
 		taskDeleteCmd,
 		taskSearchCmd,
 		taskWalkthroughCmd,
+		taskMigrateStatusCmd,
+		taskSuggestAssigneeCmd,
+		taskMoveCmd,
+		taskRenameCmd,
+		taskCommentCmd,
+		taskDepsCmd,
+		taskSnoozeCmd,
+		taskPurgeCmd,
+		taskArchiveCmd,
+		taskImportCmd,
 	)
 	rootCmd.AddCommand(taskCmd)
 }
@@ -51,17 +72,84 @@ var taskCreateCmd = &cobra.Command{
 
   logos task create --plan <plan-partial> --title "..." \
                     [--priority high|medium|low] [--tag <tag>] \
-                    [--depends-on <seq>]
+                    [--depends-on <seq>] [--template <name>] [--seed]
+  logos task create --plan <plan-partial> --from-plan \
+                    [--priority high|medium|low] [--tag <tag>]
 
 Resolves --plan against plan files in .logosyncx/plans/. Writes a
 frontmatter scaffold only; the body is written by the agent using the
-Write tool after reading .logosyncx/templates/task.md.`,
+Write tool after reading .logosyncx/templates/task.md.
+
+With --seed the body is pre-filled from .logosyncx/templates/task.md with
+What taken from the plan's Spec section and Why from its Background and Key
+Decisions. Seeded content is followed by a comment naming its source; edit
+it down to what this task covers.
+
+--template scaffolds the body from a named template instead of leaving it
+empty: its sections, with their default content, in order, and its tags.
+bugfix and retro are built in; the templates map in config.json adds more
+or overrides them. With --seed as well, the template is seeded instead of
+templates/task.md.
+
+--from-plan creates one task per open item ("- [ ] ..." or a plain "- ..."
+bullet) under the plan's Action Items section (tasks.action_items_section
+in config.json names another), then checks off each item whose task was
+created so a second run skips it. An inline "(high)", "(medium)", or
+"(low)" sets the task's priority and is dropped from the title;
+--priority applies to items without one. The first @mention becomes the
+assignee, as in logos meeting actions.
+
+A --tag not used by any plan or task yet that is within two edits of an
+existing tag (or differs only in case) prints a warning suggesting the
+existing one; --no-suggest silences it.
+
+--from-stdin-json reads the task from a JSON object on stdin, so multiline
+content needs no shell escaping:
+
+  {"title": "...", "priority": "high", "tags": ["..."], "depends_on": [1],
+   "sections": {"What": "...", "Acceptance Criteria": "- [ ] ..."}}
+
+Each sections key must be a heading of templates/task.md or the --template
+scaffold, or one named in tasks.required_sections, summary_sections,
+excerpt_section, or excerpt_fallback (case-insensitive); an unknown heading
+creates nothing. --plan is still required. Fields left out of the document
+fall back to the flags, and --tag and --depends-on values are added to the
+document's.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		planPartial, _ := cmd.Flags().GetString("plan")
 		title, _ := cmd.Flags().GetString("title")
 		priority, _ := cmd.Flags().GetString("priority")
 		tags, _ := cmd.Flags().GetStringArray("tag")
 		dependsOn, _ := cmd.Flags().GetIntSlice("depends-on")
+		noRules, _ := cmd.Flags().GetBool("no-rules")
+		seed, _ := cmd.Flags().GetBool("seed")
+		templateName, _ := cmd.Flags().GetString("template")
+		fromPlan, _ := cmd.Flags().GetBool("from-plan")
+		var sections map[string]string
+		if fromJSON, _ := cmd.Flags().GetBool("from-stdin-json"); fromJSON {
+			if fromPlan || seed {
+				return errors.New("--from-stdin-json cannot be combined with --from-plan or --seed")
+			}
+			var doc taskDocument
+			if err := readJSONDocument(os.Stdin, &doc); err != nil {
+				return err
+			}
+			title = cmp.Or(doc.Title, title)
+			priority = cmp.Or(doc.Priority, priority)
+			tags = append(doc.Tags, tags...)
+			dependsOn = append(doc.DependsOn, dependsOn...)
+			sections = doc.Sections
+			if sections == nil {
+				sections = map[string]string{}
+			}
+		}
+		if fromPlan {
+			if title != "" || len(dependsOn) > 0 || seed || templateName != "" {
+				return errors.New("--from-plan takes task titles from the plan; it cannot be combined with --title, --depends-on, --seed, or --template")
+			}
+		} else if title == "" {
+			return errors.New(`required flag(s) "title" not set`)
+		}
 
 		root, err := project.FindRoot()
 		if err != nil {
@@ -85,54 +173,291 @@ Write tool after reading .logosyncx/templates/task.md.`,
 
 		planSlug := strings.TrimSuffix(resolvedPlan.Filename, ".md")
 
-		return runTaskCreate(root, planSlug, title, priority, tags, dependsOn)
+		if noSuggest, _ := cmd.Flags().GetBool("no-suggest"); !noSuggest {
+			if cfg, err := loadConfig(root); err == nil {
+				warnTagTypos(root, tags, cfg.Tasks.AllowedTags)
+			}
+		}
+		if fromPlan {
+			return runTaskCreateFromPlan(root, planSlug, priority, tags, noRules)
+		}
+		return runTaskCreateSections(root, taskCreateOptions{
+			plan:      planSlug,
+			title:     title,
+			priority:  priority,
+			tags:      tags,
+			dependsOn: dependsOn,
+			noRules:   noRules,
+			seed:      seed,
+			template:  templateName,
+		}, sections)
 	},
 }
 
 func init() {
 	taskCreateCmd.Flags().StringP("plan", "P", "", "Plan to attach this task to (partial name match, required)")
 	_ = taskCreateCmd.MarkFlagRequired("plan")
-	taskCreateCmd.Flags().StringP("title", "T", "", "Task title (required)")
-	_ = taskCreateCmd.MarkFlagRequired("title")
-	taskCreateCmd.Flags().StringP("priority", "p", "medium", "Task priority (high|medium|low)")
+	taskCreateCmd.Flags().StringP("title", "T", "", "Task title (required unless --from-plan or set by --from-stdin-json)")
+	taskCreateCmd.Flags().StringP("priority", "p", "", "Task priority (high|medium|low; default from routing rules, then tasks.default_priority)")
 	taskCreateCmd.Flags().StringArray("tag", []string{}, "Tag to attach (repeatable: --tag go --tag cli)")
 	taskCreateCmd.Flags().IntSlice("depends-on", []int{}, "Seq number of a task this depends on (repeatable)")
+	taskCreateCmd.Flags().Bool("no-rules", false, "Do not apply tasks.rules routing from config")
+	taskCreateCmd.Flags().Bool("no-suggest", false, "Do not suggest existing tags for new, similar-looking tags")
+	taskCreateCmd.Flags().String("template", "", "Scaffold the body from a named template (built-in: bugfix, retro; or templates in config)")
+	taskCreateCmd.Flags().Bool("seed", false, "Pre-fill What and Why from the plan's Spec, Background, and Key Decisions")
+	taskCreateCmd.Flags().Bool("from-plan", false, "Create one task per open item in the plan's Action Items section")
+	taskCreateCmd.Flags().Bool("from-stdin-json", false, "Read title, priority, tags, depends_on, and body sections from a JSON object on stdin")
 }
 
-// runTaskCreate creates a task under the given planSlug (resolved by caller).
-func runTaskCreate(root, planSlug, title, priority string, tags []string, dependsOn []int) error {
-	p := task.Priority(priority)
-	if priority != "" && !task.IsValidPriority(p) {
-		return fmt.Errorf("invalid priority %q: must be one of high, medium, low", priority)
+// taskCreateOptions holds the settings of logos task create.
+type taskCreateOptions struct {
+	plan      string // plan slug, resolved by the caller
+	title     string
+	priority  string
+	tags      []string
+	dependsOn []int
+	// noRules skips config tasks.rules, which otherwise fill in assignee
+	// and priority when they are not given explicitly.
+	noRules bool
+	// seed pre-fills the body from the plan (see seedTaskBody).
+	seed bool
+	// template, when set, names the config template the body and extra
+	// tags come from.
+	template string
+}
+
+// runTaskCreate creates a task under opts.plan.
+func runTaskCreate(root string, opts taskCreateOptions) error {
+	return runTaskCreateSections(root, opts, nil)
+}
+
+// runTaskCreateSections is runTaskCreate with the content of body sections,
+// keyed by heading, written into the body. Every heading must be one of
+// taskSections.
+func runTaskCreateSections(root string, opts taskCreateOptions, sections map[string]string) error {
+	p := task.Priority(opts.priority)
+	if opts.priority != "" && !task.IsValidPriority(p) {
+		return fmt.Errorf("invalid priority %q: must be one of high, medium, low", opts.priority)
 	}
 
-	cfg, err := config.Load(root)
+	cfg, err := loadConfig(root)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 
 	t := task.Task{
-		Title:     title,
+		Title:     opts.title,
 		Priority:  p,
-		Plan:      planSlug,
-		Tags:      tags,
-		DependsOn: dependsOn,
+		Plan:      opts.plan,
+		Tags:      opts.tags,
+		DependsOn: opts.dependsOn,
+	}
+	if opts.template != "" {
+		tmpl, err := cfg.Template(opts.template)
+		if err != nil {
+			return err
+		}
+		t.Tags = config.MergeTags(t.Tags, tmpl.Tags)
+		t.Body = tmpl.Body()
+	}
+	if sections != nil {
+		if t.Body, err = fillSections(t.Body, sections, taskSections(root, cfg, opts.template)); err != nil {
+			return err
+		}
+	}
+	if err := createTask(root, cfg, &t, opts.noRules, opts.seed); err != nil {
+		return err
+	}
+	autoCommit(root, cfg, config.CommitTaskCreate, map[string]string{"title": t.Title, "plan": t.Plan}, createdTaskPaths(root, cfg, t.DirPath)...)
+	return nil
+}
+
+// runTaskCreateFromPlan creates a task in planSlug for every open item in
+// the plan's tasks.action_items_section, then checks off the items whose
+// task was created. priority and tags apply to every task; an inline
+// priority marker overrides priority.
+func runTaskCreateFromPlan(root, planSlug, priority string, tags []string, noRules bool) error {
+	defaultPriority := task.Priority(priority)
+	if priority != "" && !task.IsValidPriority(defaultPriority) {
+		return fmt.Errorf("invalid priority %q: must be one of high, medium, low", priority)
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	p, err := plan.LoadFile(filepath.Join(plan.PlansDir(root), planSlug+".md"))
+	if err != nil {
+		return fmt.Errorf("load plan: %w", err)
+	}
+	if err := refuseFrozen(p); err != nil {
+		return err
+	}
+
+	section := cfg.Tasks.ActionItems()
+	items := openItems(p.Body, section, true)
+	if len(items) == 0 {
+		fmt.Printf("No open items under %q in %s.\n", section, p.Filename)
+		return nil
+	}
+
+	var created, dirs []string
+	for _, item := range items {
+		title, pr := parsePriorityMarker(item)
+		title, assignee := parseActionItem(title)
+		if pr == "" {
+			pr = defaultPriority
+		}
+		t := task.Task{Title: title, Priority: pr, Plan: planSlug, Tags: tags, Assignee: assignee}
+		if err := createTask(root, cfg, &t, noRules, false); err != nil {
+			warnf("could not create task for %q: %v", item, err)
+			continue
+		}
+		created = append(created, item)
+		dirs = append(dirs, t.DirPath)
+	}
+	if len(created) == 0 {
+		return nil
+	}
+	p.Body = checkOffItems(p.Body, section, created, true)
+	planPath, err := writePlanAndIndex(root, cfg, p)
+	if err != nil {
+		return err
+	}
+	paths := append(createdTaskPaths(root, cfg, dirs...), planPath, index.FilePath(root))
+	autoCommit(root, cfg, config.CommitTaskCreate, map[string]string{
+		"title": fmt.Sprintf("%d task(s) from %s", len(dirs), section),
+		"plan":  planSlug,
+	}, paths...)
+	return nil
+}
+
+// priorityMarker matches an inline priority such as "(high)".
+var priorityMarker = regexp.MustCompile(`(?i)\s*\((high|medium|low)\)`)
+
+// parsePriorityMarker returns item without its first priority marker, and
+// the priority it names ("" when there is none).
+func parsePriorityMarker(item string) (string, task.Priority) {
+	m := priorityMarker.FindStringSubmatchIndex(item)
+	if m == nil {
+		return item, ""
+	}
+	title := strings.TrimSpace(item[:m[0]] + item[m[1]:])
+	if title == "" {
+		title = item
+	}
+	return title, task.Priority(strings.ToLower(item[m[2]:m[3]]))
+}
+
+// createTask checks and completes t — default tags, tasks.rules unless
+// noRules is set, and the seeded body when seed is set — then creates it
+// and prints the result. Fields already set on t, such as Assignee, are
+// kept; afterwards t holds the created task.
+func createTask(root string, cfg config.Config, t *task.Task, noRules, seed bool) error {
+	if err := config.CheckTags(t.Tags, cfg.Tasks.AllowedTags, "tasks.allowed_tags"); err != nil {
+		return err
+	}
+	t.Tags = config.MergeTags(t.Tags, cfg.Tasks.DefaultTags)
+	planSlug := t.Plan
+	if t.Branch == "" {
+		t.Branch = recordBranch(cfg)
+	}
+
+	var effects []task.RuleEffect
+	if !noRules {
+		var err error
+		effects, err = task.ApplyRules(t, cfg.Tasks.Rules)
+		if err != nil {
+			return err
+		}
+	}
+
+	var seeded []string
+	if seed {
+		p, err := plan.LoadFile(filepath.Join(plan.PlansDir(root), planSlug+".md"))
+		if err != nil {
+			return fmt.Errorf("load plan for --seed: %w", err)
+		}
+		t.Body, seeded = seedTaskBody(root, t.Body, p)
+	}
+
+	fields := append([]privacyField{{"title", &t.Title}, {"body", &t.Body}}, tagFields(t.Tags)...)
+	if err := enforcePrivacy(cfg, "task", fields...); err != nil {
+		return err
 	}
 
 	store := task.NewStore(root, &cfg)
 
-	createdPath, err := store.Create(&t)
+	createdPath, err := store.Create(t)
 	if err != nil {
 		return fmt.Errorf("create task: %w", err)
 	}
 
 	rel, _ := relPath(root, createdPath)
-	fmt.Printf("✓ Created task: %s  (seq: %d)\n", rel, t.Seq)
-	fmt.Println()
-	fmt.Printf("Next: read .logosyncx/templates/task.md, then fill in %s\n", rel)
+	printSuccess("Created task: %s  (seq: %d)", rel, t.Seq)
+	for _, e := range effects {
+		fmt.Printf("  applied %s\n", e)
+	}
+	if seed {
+		if len(seeded) == 0 {
+			fmt.Printf("  nothing to seed: plan %s has no Spec, Background, or Key Decisions content\n", planSlug)
+		} else {
+			fmt.Printf("  seeded %s from %s\n", strings.Join(seeded, ", "), planSlug)
+		}
+		printHint(fmt.Sprintf("Next: review the seeded sections and fill in the rest of %s", rel))
+		return nil
+	}
+	printHint(fmt.Sprintf("Next: read .logosyncx/templates/task.md, then fill in %s", rel))
 	return nil
 }
 
+// taskSeeds maps each task template section --seed fills to the plan
+// sections it is taken from, in order.
+var taskSeeds = []struct {
+	section string
+	from    []string
+}{
+	{"What", []string{"Spec"}},
+	{"Why", []string{"Background", "Key Decisions"}},
+}
+
+// seedTaskBody returns body (the task template when body is empty) with the
+// sections in taskSeeds filled from p, and the names of the sections filled.
+// Each seeded section ends with a comment naming the plan sections it came
+// from (at the end, so the task excerpt starts with the content). Plan
+// sections holding only template comments are skipped; a task section with
+// nothing to seed keeps the template placeholder.
+func seedTaskBody(root, body string, p plan.Plan) (string, []string) {
+	if body == "" {
+		body = defaultTaskTemplate
+		if data, err := os.ReadFile(filepath.Join(root, ".logosyncx", "templates", "task.md")); err == nil {
+			body = string(data)
+		}
+	}
+
+	var seeded []string
+	for _, s := range taskSeeds {
+		var parts, sources []string
+		for _, name := range s.from {
+			content, _ := markdown.Section(p.Body, name)
+			if content = markdown.StripComments(content); content != "" {
+				parts = append(parts, content)
+				sources = append(sources, name)
+			}
+		}
+		if len(parts) == 0 {
+			continue
+		}
+		content := strings.Join(parts, "\n\n") + "\n\n" +
+			fmt.Sprintf("<!-- seeded from %s (%s) — narrow to this task -->", p.Filename, strings.Join(sources, ", "))
+		var ok bool
+		if body, ok = markdown.ReplaceSection(body, s.section, content); !ok {
+			body = strings.TrimRight(body, "\n") + "\n\n## " + s.section + "\n\n" + content + "\n"
+		}
+		seeded = append(seeded, s.section)
+	}
+	return body, seeded
+}
+
 // blockedByDep returns the filename of the first unfinished dependency of p,
 // or "" if p is not blocked. A plan is blocked when any plan listed in
 // DependsOn has Distilled == false.
@@ -155,12 +480,7 @@ func findPlan(partial string, allPlans []plan.Plan) (plan.Plan, error) {
 	if partial == "" {
 		return plan.Plan{}, fmt.Errorf("--plan is required")
 	}
-	var matches []plan.Plan
-	for _, p := range allPlans {
-		if strings.Contains(p.Filename, partial) {
-			matches = append(matches, p)
-		}
-	}
+	matches := planFilenameMatches(partial, allPlans)
 	switch len(matches) {
 	case 0:
 		return plan.Plan{}, fmt.Errorf("plan %q not found", partial)
@@ -175,88 +495,336 @@ func findPlan(partial string, allPlans []plan.Plan) (plan.Plan, error) {
 	}
 }
 
+// resolvePlanFilter resolves a --plan partial for listing commands the same
+// way task create does. It returns the matched plan slug, or "" when no plan
+// file matches (tasks may outlive their plan, e.g. after gc purge) so the
+// caller can fall back to substring matching on the task's plan field.
+// An ambiguous partial is an error.
+func resolvePlanFilter(root, partial string) (string, error) {
+	allPlans, err := plan.LoadAll(root)
+	if err != nil {
+		return "", fmt.Errorf("load plans: %w", err)
+	}
+	if len(planFilenameMatches(partial, allPlans)) == 0 {
+		return "", nil
+	}
+	p, err := findPlan(partial, allPlans)
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(p.Filename, ".md"), nil
+}
+
+// planFilenameMatches returns the plans partial refers to, tiered like
+// matchPlans: an exact filename stem or topic wins, then an exact ID, then
+// every plan whose filename contains partial.
+func planFilenameMatches(partial string, allPlans []plan.Plan) []plan.Plan {
+	return resolve.Match(allPlans, partial, func(p plan.Plan) resolve.Keys {
+		return resolve.Keys{
+			Names:   []string{strings.TrimSuffix(p.Filename, ".md"), p.Topic},
+			ID:      p.ID,
+			Partial: []string{p.Filename},
+		}
+	})
+}
+
 // --- logos task ls -----------------------------------------------------------
 
 var taskLsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "List tasks",
 	Long: `Display a table of tasks in .logosyncx/tasks/, sorted newest first.
+Tasks with the same date are ordered by ID, then directory, so table and
+--json output is stable across runs.
 Use --json for structured output suitable for agent consumption.
-Use --blocked to show only tasks blocked by unfinished dependencies.`,
+Use --plan to show a plan's tasks, including tasks from other plans that
+list it in related_plans.
+Use --blocked to show only tasks blocked by unfinished dependencies.
+Use --include-unknown to also list task files found outside the
+<plan>/NNN-<title>/TASK.md layout (e.g. in a legacy tasks/done/ directory).
+Use --sort order to list by the manual ranking set with logos task move.
+Tasks snoozed with logos task snooze are hidden until their date; use --all
+to include them.
+Use --branch <name> (or --current-branch) to show only tasks created on a
+git branch, plus tasks not scoped to any branch (see git.record_branch).
+
+Use --count-only for a fast summary for shell prompts and status bars: it
+reads only task-index.jsonl and prints "3 open / 1 in_progress / 0 done",
+or just the number when --status is given. With --json it prints total,
+by_status, and by_priority counts. The other filters apply as usual.
+
+Use --limit and --offset to page through long listings (after filtering
+and sorting), and --fields to output only some fields, named by their
+--json keys (e.g. --fields seq,title,status); both apply to the table and
+to --json.
+
+Defaults for --json and --full can be set in config.json (output.task_ls,
+output.full); flags given on the command line override them.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		planPartial, _ := cmd.Flags().GetString("plan")
-		statusStr, _ := cmd.Flags().GetString("status")
-		priorityStr, _ := cmd.Flags().GetString("priority")
-		tagStr, _ := cmd.Flags().GetString("tag")
-		asJSON, _ := cmd.Flags().GetBool("json")
-		blocked, _ := cmd.Flags().GetBool("blocked")
-		if asJSON {
+		var opts taskLSOptions
+		opts.plan, _ = cmd.Flags().GetString("plan")
+		opts.status, _ = cmd.Flags().GetString("status")
+		opts.priority, _ = cmd.Flags().GetString("priority")
+		opts.tag, _ = cmd.Flags().GetString("tag")
+		opts.asJSON, _ = cmd.Flags().GetBool("json")
+		opts.blocked, _ = cmd.Flags().GetBool("blocked")
+		opts.includeUnknown, _ = cmd.Flags().GetBool("include-unknown")
+		opts.sortBy, _ = cmd.Flags().GetString("sort")
+		opts.all, _ = cmd.Flags().GetBool("all")
+		opts.countOnly, _ = cmd.Flags().GetBool("count-only")
+		fullTables, _ = cmd.Flags().GetBool("full")
+		defaults := outputDefaults()
+		if !cmd.Flags().Changed("json") && defaults.TaskLS == "json" {
+			opts.asJSON = true
+		}
+		if !cmd.Flags().Changed("full") {
+			fullTables = defaults.Full
+		}
+		if opts.asJSON || opts.countOnly {
 			suppressUpdateCheck = true
 		}
-		return runTaskLS(planPartial, statusStr, priorityStr, tagStr, asJSON, blocked)
+		var err error
+		if opts.branch, err = branchFilter(cmd); err != nil {
+			return err
+		}
+		if opts.page, err = readPageFlags(cmd); err != nil {
+			return err
+		}
+		return runTaskLS(opts)
 	},
 }
 
 func init() {
-	taskLsCmd.Flags().StringP("plan", "P", "", "Filter by plan slug (substring match)")
-	taskLsCmd.Flags().String("status", "", "Filter by status (open, in_progress, done)")
-	taskLsCmd.Flags().String("priority", "", "Filter by priority (high, medium, low)")
+	taskLsCmd.Flags().StringP("plan", "P", "", "Filter by plan (partial name, resolved like task create)")
+	taskLsCmd.Flags().String("status", "", "Filter by status (open, in_progress, done; comma-separated for several)")
+	taskLsCmd.Flags().String("priority", "", "Filter by priority (high, medium, low; comma-separated for several)")
 	taskLsCmd.Flags().StringP("tag", "t", "", "Filter by tag (exact match)")
 	taskLsCmd.Flags().Bool("json", false, "Output structured JSON (for agent consumption)")
 	taskLsCmd.Flags().Bool("blocked", false, "Show only tasks blocked by unfinished dependencies")
+	taskLsCmd.Flags().Bool("include-unknown", false, "Also list misplaced task files outside the <plan>/NNN-<title>/ layout")
+	taskLsCmd.Flags().String("sort", "date", "Sort order: date (newest first) or order (manual ranking)")
+	taskLsCmd.Flags().Bool("all", false, "Include snoozed tasks")
+	taskLsCmd.Flags().Bool("full", false, "Do not truncate columns to fit the terminal width")
+	taskLsCmd.Flags().Bool("count-only", false, "Print task counts by status (with --json: by status and priority) instead of listing tasks")
+	branchFlags(taskLsCmd, "tasks")
+	pageFlags(taskLsCmd, "tasks")
 }
 
-func runTaskLS(planPartial, statusStr, priorityStr, tagStr string, asJSON, blocked bool) error {
+// taskLSOptions holds the filters and output settings of logos task ls.
+type taskLSOptions struct {
+	plan           string // partial plan name, resolved like task create
+	status         string // comma-separated statuses
+	priority       string // comma-separated priorities
+	tag            string
+	sortBy         string // "date" or "order"
+	asJSON         bool
+	blocked        bool
+	includeUnknown bool // also list misplaced task files
+	all            bool // include snoozed tasks
+	countOnly      bool
+	branch         string
+	page           pageOptions
+}
+
+func runTaskLS(opts taskLSOptions) error {
+	if opts.sortBy != "" && opts.sortBy != "date" && opts.sortBy != "order" {
+		return fmt.Errorf("invalid --sort %q: must be date or order", opts.sortBy)
+	}
+	if err := checkFields(opts.page.fields, jsonFieldNames(task.TaskJSON{})); err != nil {
+		return err
+	}
+
 	root, err := project.FindRoot()
 	if err != nil {
 		return err
 	}
-	cfg, err := config.Load(root)
+	cfg, err := loadConfig(root)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
 	store := task.NewStore(root, &cfg)
 
-	entries, err := task.ReadAllTaskIndex(root)
-	if err != nil {
-		if errors.Is(err, os.ErrNotExist) {
-			fmt.Fprintln(os.Stderr, "task-index.jsonl not found. Building index from tasks/...")
-			n, buildErr := store.RebuildTaskIndex()
-			if buildErr != nil {
-				fmt.Fprintf(os.Stderr, "warning: %v\n", buildErr)
-			}
-			fmt.Fprintf(os.Stderr, "Done. %d tasks indexed.\n\n", n)
-			entries, err = task.ReadAllTaskIndex(root)
-			if err != nil {
-				return fmt.Errorf("read task index after rebuild: %w", err)
-			}
+	f := task.Filter{
+		Related: true,
+		Blocked: opts.blocked,
+		Branch:  opts.branch,
+	}
+	if f.Statuses, err = task.ParseStatuses(opts.status); err != nil {
+		return err
+	}
+	if f.Priorities, err = task.ParsePriorities(opts.priority); err != nil {
+		return err
+	}
+	if opts.tag != "" {
+		f.Tags = []string{opts.tag}
+	}
+	if opts.plan != "" {
+		slug, err := resolvePlanFilter(root, opts.plan)
+		if err != nil {
+			return err
+		}
+		if slug != "" {
+			f.PlanSlug = slug
 		} else {
-			return fmt.Errorf("read task index: %w", err)
+			f.Plan = opts.plan
 		}
 	}
 
-	f := task.Filter{
-		Plan:     planPartial,
-		Status:   task.Status(statusStr),
-		Priority: task.Priority(priorityStr),
-		Blocked:  blocked,
-	}
-	if tagStr != "" {
-		f.Tags = []string{tagStr}
+	if opts.countOnly {
+		entries, _, err := readTaskIndexPage(root, store, f.MatchJSON, nil, jsonl.Page{})
+		if err != nil {
+			return err
+		}
+		return printTaskCounts(entries, opts.status != "", opts.asJSON, opts.all, time.Now())
 	}
 
-	filtered := task.ApplyToJSON(entries, f)
-	task.SortJSONByDateDesc(filtered)
+	now := time.Now()
+	// Keyed by directory: keep sees the entries again if the index is
+	// rebuilt part-way through the read.
+	hidden := map[string]bool{}
+	keep := func(e task.TaskJSON) bool {
+		if !f.MatchJSON(e) {
+			return false
+		}
+		if !opts.all && e.IsSnoozed(now) {
+			hidden[e.DirPath] = true
+			return false
+		}
+		return true
+	}
+	compare := task.CompareJSONNewest
+	if opts.sortBy == "order" {
+		compare = task.CompareJSONByOrder
+	}
+	entries, total, err := readTaskIndexPage(root, store, keep, compare, opts.page.Head())
+	if err != nil {
+		return err
+	}
+	for _, e := range loadMisplacedTasks(store, opts.includeUnknown) {
+		if keep(e) {
+			entries = append(entries, e)
+			total++
+		}
+	}
+	if len(hidden) > 0 {
+		notef("%d snoozed task(s) hidden; use --all to include them", len(hidden))
+	}
+	slices.SortFunc(entries, compare)
+	filtered := pageWindow(entries, total, opts.page.Page, "tasks")
 
-	if len(filtered) == 0 {
+	if len(filtered) == 0 && !opts.asJSON {
 		fmt.Println("No tasks found.")
 		return nil
 	}
 
-	if asJSON {
+	if fields := opts.page.fields; len(fields) > 0 {
+		if opts.asJSON {
+			return printFieldsJSON(normalizeTaskJSON(filtered), fields)
+		}
+		return printFieldsTable(normalizeTaskJSON(filtered), fields, displayLocation(cfg))
+	}
+	if opts.asJSON {
 		return printTaskJSON(filtered)
 	}
-	return printTaskTable(filtered)
+	return printTaskTable(filtered, displayLocation(cfg))
+}
+
+// printTaskCounts prints the counts of entries for task ls --count-only.
+// Snoozed tasks are left out unless all is set, without the stderr note
+// task ls prints, so the output stays a single line for prompts.
+func printTaskCounts(entries []task.TaskJSON, single, asJSON, all bool, now time.Time) error {
+	if !all {
+		entries = slices.DeleteFunc(entries, func(e task.TaskJSON) bool { return e.IsSnoozed(now) })
+	}
+	c := task.Count(entries)
+	if asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(c)
+	}
+	if single {
+		fmt.Println(c.Total)
+		return nil
+	}
+	parts := make([]string, len(task.ValidStatuses))
+	for i, s := range task.ValidStatuses {
+		parts[i] = fmt.Sprintf("%d %s", c.ByStatus[s], s)
+	}
+	fmt.Println(strings.Join(parts, " / "))
+	return nil
+}
+
+// readTaskIndexPage reads page p of the task index entries keep accepts,
+// in the order of cmp, with total, the number accepted (see
+// task.ReadTaskIndexPage). The index is built from tasks/ first when it
+// is missing, was only partly written, or was written by an older logos,
+// in which case keep may see some entries twice; malformed lines are
+// skipped with a warning.
+func readTaskIndexPage(root string, store *task.Store, keep func(task.TaskJSON) bool, cmp func(a, b task.TaskJSON) int, p jsonl.Page) (entries []task.TaskJSON, total int, err error) {
+	err = checkIndexVersion(task.CheckTaskIndexVersion(root))
+	if err == nil {
+		entries, total, err = task.ReadTaskIndexPage(root, keep, cmp, p)
+		if err == nil {
+			return entries, total, nil
+		}
+	}
+	if !errors.Is(err, os.ErrNotExist) && !errors.Is(err, jsonl.ErrPartial) && !errors.Is(err, jsonl.ErrOldVersion) {
+		if warnSkippedLines("task-index.jsonl", err) {
+			return entries, total, nil
+		}
+		return nil, 0, fmt.Errorf("read task index: %w", err)
+	}
+	if !warningsJSON {
+		fmt.Fprintf(os.Stderr, "task-index.jsonl %s. Building index from tasks/...\n", indexProblem(err))
+	}
+	n, buildErr := store.RebuildTaskIndex()
+	if buildErr != nil {
+		warnf("%v", buildErr)
+	}
+	if warningsJSON {
+		warnf("task-index.jsonl %s — rebuilt from tasks/ (%d tasks indexed)", indexProblem(err), n)
+	} else {
+		fmt.Fprintf(os.Stderr, "Done. %d tasks indexed.\n\n", n)
+	}
+	entries, total, err = task.ReadTaskIndexPage(root, keep, cmp, p)
+	if err != nil {
+		return nil, 0, fmt.Errorf("read task index after rebuild: %w", err)
+	}
+	return entries, total, nil
+}
+
+// loadMisplacedTasks returns misplaced task files as TaskJSON entries when
+// include is true. When include is false and misplaced files exist, a note
+// is printed to stderr so they are never silently ignored.
+func loadMisplacedTasks(store *task.Store, include bool) []task.TaskJSON {
+	strays, err := store.FindStrays()
+	if err != nil {
+		warnf("%v", err)
+	}
+	var misplaced []task.Stray
+	for _, st := range strays {
+		if st.Kind == task.StrayMisplaced {
+			misplaced = append(misplaced, st)
+		}
+	}
+	if len(misplaced) == 0 {
+		return nil
+	}
+	if !include {
+		notef("%d misplaced task file(s) not shown — use --include-unknown or run `logos sync` for details", len(misplaced))
+		return nil
+	}
+
+	var out []task.TaskJSON
+	for _, st := range misplaced {
+		t, err := store.LoadStray(st)
+		if err != nil {
+			warnf("%s: %v", st.Path, err)
+			continue
+		}
+		out = append(out, t.ToJSON())
+	}
+	return out
 }
 
 // --- logos task refer --------------------------------------------------------
@@ -266,13 +834,25 @@ var taskReferCmd = &cobra.Command{
 	Short: "Print the content of a task file",
 	Long: `Print a task file to stdout. Use --summary to print only the sections
 listed in config.tasks.summary_sections (saves tokens). Use --plan to
-narrow the search when task names are ambiguous across plans.`,
+narrow the search when task names are ambiguous across plans.
+
+After the task, up to five "possibly related" tasks are listed: tasks that
+share tags with it or belong to a plan linked to its plan (via related or
+depends_on). Check them before starting to avoid duplicating work. Use
+--no-related to print the task alone.
+
+Use --log to print only the task's worklog (the entries added with logos
+task comment), oldest first.`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name, _ := cmd.Flags().GetString("name")
 		planPartial, _ := cmd.Flags().GetString("plan")
 		summary, _ := cmd.Flags().GetBool("summary")
-		return runTaskRefer(name, planPartial, summary)
+		noRelated, _ := cmd.Flags().GetBool("no-related")
+		if showLog, _ := cmd.Flags().GetBool("log"); showLog {
+			return runTaskLog(name, planPartial)
+		}
+		return runTaskRefer(name, planPartial, summary, !noRelated)
 	},
 }
 
@@ -281,14 +861,17 @@ func init() {
 	_ = taskReferCmd.MarkFlagRequired("name")
 	taskReferCmd.Flags().StringP("plan", "P", "", "Plan slug to narrow the search (substring match)")
 	taskReferCmd.Flags().Bool("summary", false, "Print only summary sections (saves tokens)")
+	taskReferCmd.Flags().Bool("no-related", false, "Do not list possibly related tasks")
+	taskReferCmd.Flags().Bool("log", false, "Print only the task's worklog (see logos task comment)")
+	taskReferCmd.MarkFlagsMutuallyExclusive("log", "summary")
 }
 
-func runTaskRefer(nameOrPartial, planPartial string, summary bool) error {
+func runTaskRefer(nameOrPartial, planPartial string, summary, related bool) error {
 	root, err := project.FindRoot()
 	if err != nil {
 		return err
 	}
-	cfg, err := config.Load(root)
+	cfg, err := loadConfig(root)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
@@ -298,11 +881,12 @@ func runTaskRefer(nameOrPartial, planPartial string, summary bool) error {
 	if err != nil {
 		return err
 	}
+	noteReferral(root, recent.KindTask, taskRefName(t.Plan, t.DirPath))
 
 	if summary {
 		sections := task.ExtractSections(t.Body, cfg.Tasks.SummarySections)
 		if sections == "" {
-			fmt.Fprintln(os.Stderr, "warning: no matching summary sections found in this task")
+			warnf("no matching summary sections found in this task")
 		}
 		fmt.Println(sections)
 	} else {
@@ -312,17 +896,109 @@ func runTaskRefer(nameOrPartial, planPartial string, summary bool) error {
 		}
 		fmt.Print(string(data))
 	}
+
+	if related {
+		printRelatedTasks(root, t)
+	}
+	return nil
+}
+
+// runTaskLog prints the worklog of the task, oldest entry first.
+func runTaskLog(nameOrPartial, planPartial string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+
+	t, err := store.Get(planPartial, nameOrPartial)
+	if err != nil {
+		return err
+	}
+	noteReferral(root, recent.KindTask, taskRefName(t.Plan, t.DirPath))
+
+	entries := task.LogEntries(t.Body)
+	if len(entries) == 0 {
+		fmt.Printf("No log entries for %s/%s. Add one with logos task comment.\n", t.Plan, filepath.Base(t.DirPath))
+		return nil
+	}
+	for _, e := range entries {
+		fmt.Println("- " + e)
+	}
 	return nil
 }
 
+// printRelatedTasks lists tasks from the task index that are possibly
+// related to t. It is best-effort: a missing or unreadable index, or plans
+// that fail to load, only reduce what is shown.
+func printRelatedTasks(root string, t *task.Task) {
+	entries, err := task.ReadAllTaskIndex(root)
+	if err != nil && !errors.Is(err, os.ErrNotExist) && !warnSkippedLines("task-index.jsonl", err) {
+		warnf("%v", err)
+	}
+	plans, _ := plan.LoadAll(root)
+
+	related := task.FindRelated(t.ToJSON(), entries, linkedPlanSlugs(t.Plan, plans), 0)
+	if len(related) == 0 {
+		return
+	}
+	fmt.Println()
+	fmt.Println("Possibly related:")
+	for _, r := range related {
+		fmt.Printf("  - %s/%s [%s] — %s\n", r.Task.Plan, filepath.Base(r.Task.DirPath), r.Task.Status, r.Reason())
+	}
+}
+
+// linkedPlanSlugs returns the slugs of plans linked to planSlug through
+// related or depends_on, in either direction.
+func linkedPlanSlugs(planSlug string, plans []plan.Plan) []string {
+	var linked []string
+	for _, p := range plans {
+		slug := strings.TrimSuffix(p.Filename, ".md")
+		refs := append(slices.Clone(p.Related), p.DependsOn...)
+		for _, ref := range refs {
+			ref = strings.TrimSuffix(ref, ".md")
+			switch {
+			case slug == planSlug:
+				linked = append(linked, ref)
+			case ref == planSlug:
+				linked = append(linked, slug)
+			}
+		}
+	}
+	return linked
+}
+
 // --- logos task update -------------------------------------------------------
 
 var taskUpdateCmd = &cobra.Command{
 	Use:   "update",
 	Short: "Update task fields",
 	Long: `Update frontmatter fields of a task. Supported flags: --name, --status,
---priority, --assignee. Use --plan to narrow the search when task names are
-ambiguous across plans.`,
+--priority, --assignee, --due. Use --plan to narrow the search when task
+names are ambiguous across plans.
+
+--due takes a date (YYYY-MM-DD) or a relative date such as "friday" or
+"in 2 weeks"; "none" clears it. With tasks.escalation in config.json,
+logos sync raises the priority of tasks whose due date is near.
+
+Instead of --name, select several tasks with --status-filter,
+--priority-filter, --tag-filter, and --plan (matched as by task ls; status
+and priority take comma-separated lists). Unlike task ls, --plan selects
+only the plan's own tasks, not tasks that list it in related_plans. The
+new values can also be given as --set field=value (status, priority,
+assignee, due):
+
+  logos task update --status-filter open --tag-filter auth --set priority=high
+
+The matching tasks are listed and a confirmation prompt is shown unless
+--force or --yes is passed; --dry-run only lists them. Tasks the update is
+refused for (blocked, or marked done without a walkthrough) are skipped with
+a warning, and the task index is rebuilt once at the end.`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name, _ := cmd.Flags().GetString("name")
@@ -330,41 +1006,93 @@ ambiguous across plans.`,
 		statusStr, _ := cmd.Flags().GetString("status")
 		priorityStr, _ := cmd.Flags().GetString("priority")
 		assignee, _ := cmd.Flags().GetString("assignee")
-		return runTaskUpdate(planPartial, name, statusStr, priorityStr, assignee)
+		due, _ := cmd.Flags().GetString("due")
+		sets, _ := cmd.Flags().GetStringArray("set")
+		statusFilter, _ := cmd.Flags().GetString("status-filter")
+		priorityFilter, _ := cmd.Flags().GetString("priority-filter")
+		tagFilter, _ := cmd.Flags().GetString("tag-filter")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		force, _ := cmd.Flags().GetBool("force")
+
+		values := map[string]*string{"status": &statusStr, "priority": &priorityStr, "assignee": &assignee, "due": &due}
+		if err := applySetFlags(sets, values); err != nil {
+			return err
+		}
+		bulk := statusFilter != "" || priorityFilter != "" || tagFilter != ""
+		switch {
+		case name != "" && bulk:
+			return errors.New("--name cannot be combined with --status-filter, --priority-filter, or --tag-filter")
+		case name == "" && !bulk:
+			return errors.New("provide --name, or select tasks with --status-filter, --priority-filter, or --tag-filter")
+		case bulk:
+			return runTaskUpdateFilter(taskUpdateOptions{
+				plan: planPartial, statusFilter: statusFilter, priorityFilter: priorityFilter, tagFilter: tagFilter,
+				status: statusStr, priority: priorityStr, assignee: assignee, due: due,
+				dryRun: dryRun, force: force,
+			})
+		}
+		if dryRun || force {
+			return errors.New("--dry-run and --force apply only to updates selected with filters")
+		}
+		return runTaskUpdate(planPartial, name, statusStr, priorityStr, assignee, due)
 	},
 }
 
 func init() {
 	taskUpdateCmd.Flags().StringP("name", "n", "", "Task name to update (partial match against task dir name)")
-	_ = taskUpdateCmd.MarkFlagRequired("name")
 	taskUpdateCmd.Flags().StringP("plan", "P", "", "Plan slug to narrow the search (substring match)")
 	taskUpdateCmd.Flags().String("status", "", "New status (open, in_progress, done)")
 	taskUpdateCmd.Flags().String("priority", "", "New priority (high, medium, low)")
 	taskUpdateCmd.Flags().String("assignee", "", "New assignee")
+	taskUpdateCmd.Flags().String("due", "", "Due date (YYYY-MM-DD, friday, in 2 weeks, ...; none to clear)")
+	taskUpdateCmd.Flags().StringArray("set", nil, "New value as field=value (status, priority, assignee, due); repeatable")
+	taskUpdateCmd.Flags().String("status-filter", "", "Update every task with this status (comma-separated for several)")
+	taskUpdateCmd.Flags().String("priority-filter", "", "Update every task with this priority (comma-separated for several)")
+	taskUpdateCmd.Flags().String("tag-filter", "", "Update every task with this tag")
+	taskUpdateCmd.Flags().Bool("dry-run", false, "With filters: list the matching tasks without updating them")
+	taskUpdateCmd.Flags().Bool("force", false, "With filters: skip the confirmation prompt (same as --yes)")
 }
 
-func runTaskUpdate(planPartial, nameOrPartial, statusStr, priorityStr, assignee string) error {
-	if statusStr == "" && priorityStr == "" && assignee == "" {
-		return errors.New("provide at least one of --status, --priority, or --assignee")
+// applySetFlags stores each --set field=value in values, keyed by field.
+// A field given both by its own flag and by --set, or set twice, is an
+// error.
+func applySetFlags(sets []string, values map[string]*string) error {
+	seen := map[string]bool{}
+	for _, set := range sets {
+		field, value, ok := strings.Cut(set, "=")
+		field = strings.TrimSpace(field)
+		dst, known := values[field]
+		switch {
+		case !ok:
+			return fmt.Errorf("invalid --set %q: expected field=value", set)
+		case !known:
+			return fmt.Errorf("invalid --set %q: field must be one of status, priority, assignee, due", set)
+		case seen[field] || *dst != "":
+			return fmt.Errorf("--set %s: %s is given more than once", set, field)
+		}
+		seen[field] = true
+		*dst = strings.TrimSpace(value)
 	}
+	return nil
+}
 
+// checkTaskUpdateValues validates the new values given to task update.
+func checkTaskUpdateValues(statusStr, priorityStr, assignee, due string) error {
+	if statusStr == "" && priorityStr == "" && assignee == "" && due == "" {
+		return errors.New("provide at least one of --status, --priority, --assignee, or --due")
+	}
 	if statusStr != "" && !task.IsValidStatus(task.Status(statusStr)) {
 		return fmt.Errorf("invalid status %q: must be one of open, in_progress, done", statusStr)
 	}
 	if priorityStr != "" && !task.IsValidPriority(task.Priority(priorityStr)) {
 		return fmt.Errorf("invalid priority %q: must be one of high, medium, low", priorityStr)
 	}
+	return nil
+}
 
-	root, err := project.FindRoot()
-	if err != nil {
-		return err
-	}
-	cfg, err := config.Load(root)
-	if err != nil {
-		return fmt.Errorf("load config: %w", err)
-	}
-	store := task.NewStore(root, &cfg)
-
+// taskUpdateFields returns the UpdateFields map for the new values given to
+// task update, resolving a relative --due in display.timezone.
+func taskUpdateFields(cfg config.Config, statusStr, priorityStr, assignee, due string) (map[string]string, error) {
 	fields := make(map[string]string)
 	if statusStr != "" {
 		fields["status"] = statusStr
@@ -375,15 +1103,60 @@ func runTaskUpdate(planPartial, nameOrPartial, statusStr, priorityStr, assignee
 	if assignee != "" {
 		fields["assignee"] = assignee
 	}
-
-	if err := store.UpdateFields(planPartial, nameOrPartial, fields); err != nil {
-		return fmt.Errorf("update task: %w", err)
+	switch due {
+	case "":
+	case "none":
+		fields["due"] = ""
+	default:
+		t, err := dateparse.Future(due, time.Now().In(displayLocation(cfg)))
+		if err != nil {
+			return nil, fmt.Errorf("--due: %w", err)
+		}
+		fields["due"] = t.Format("2006-01-02")
 	}
+	return fields, nil
+}
+
+func runTaskUpdate(planPartial, nameOrPartial, statusStr, priorityStr, assignee, due string) error {
+	if err := checkTaskUpdateValues(statusStr, priorityStr, assignee, due); err != nil {
+		return err
+	}
+
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+
+	fields, err := taskUpdateFields(cfg, statusStr, priorityStr, assignee, due)
+	if err != nil {
+		return err
+	}
+
+	before, err := store.Get(planPartial, nameOrPartial)
+	if err != nil {
+		return fmt.Errorf("update task: %w", err)
+	}
+	if err := store.UpdateFields(planPartial, nameOrPartial, fields); err != nil {
+		if errors.Is(err, task.ErrBlocked) {
+			return fmt.Errorf("update task: %w (run `logos task deps --name %s` to see them)", err, nameOrPartial)
+		}
+		return fmt.Errorf("update task: %w", err)
+	}
+	to := before.Status
+	if statusStr != "" {
+		to = task.Status(statusStr)
+	}
+	commitTaskUpdate(root, cfg, before.Title, before.Plan, before.Status, to, before.DirPath)
 
 	if statusStr != "" {
-		fmt.Printf("✓ Updated task %q → status: %s\n", nameOrPartial, statusStr)
+		printSuccess("Updated task %q → status: %s", nameOrPartial, statusStr)
 	} else {
-		fmt.Printf("✓ Updated task %q.\n", nameOrPartial)
+		printSuccess("Updated task %q.", nameOrPartial)
 	}
 
 	// When marking done, print the WALKTHROUGH.md path.
@@ -393,9 +1166,8 @@ func runTaskUpdate(planPartial, nameOrPartial, statusStr, priorityStr, assignee
 			wtPath := filepath.Join(t.DirPath, "WALKTHROUGH.md")
 			if _, statErr := os.Stat(wtPath); statErr == nil {
 				rel, _ := relPath(root, wtPath)
-				fmt.Printf("✓ WALKTHROUGH.md created: %s\n", rel)
-				fmt.Println()
-				fmt.Println("Next: fill in the walkthrough body, then run `logos distill --plan <plan>` when all tasks are done.")
+				printSuccess("WALKTHROUGH.md created: %s", rel)
+				printHint("Next: fill in the walkthrough body, then run `logos distill --plan <plan>` when all tasks are done.")
 			}
 		}
 	}
@@ -403,13 +1175,137 @@ func runTaskUpdate(planPartial, nameOrPartial, statusStr, priorityStr, assignee
 	return nil
 }
 
+// taskUpdateOptions holds the settings of a logos task update that selects
+// its tasks with filters.
+type taskUpdateOptions struct {
+	// plan, statusFilter, priorityFilter, and tagFilter select the tasks,
+	// as for task ls.
+	plan           string
+	statusFilter   string
+	priorityFilter string
+	tagFilter      string
+	// status, priority, assignee, and due are the new values; "" leaves a
+	// field unchanged.
+	status   string
+	priority string
+	assignee string
+	due      string
+	dryRun   bool
+	force    bool
+}
+
+// runTaskUpdateFilter applies the new values to every task matching the
+// status, priority, tag, and plan filters, after listing them and asking
+// for confirmation. The plan filter matches plan ownership only, so a bulk
+// update never reaches into other plans through related_plans.
+func runTaskUpdateFilter(opts taskUpdateOptions) error {
+	if err := checkTaskUpdateValues(opts.status, opts.priority, opts.assignee, opts.due); err != nil {
+		return err
+	}
+	var f task.Filter
+	var err error
+	if f.Statuses, err = task.ParseStatuses(opts.statusFilter); err != nil {
+		return fmt.Errorf("--status-filter: %w", err)
+	}
+	if f.Priorities, err = task.ParsePriorities(opts.priorityFilter); err != nil {
+		return fmt.Errorf("--priority-filter: %w", err)
+	}
+	if opts.tagFilter != "" {
+		f.Tags = []string{opts.tagFilter}
+	}
+
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if opts.plan != "" {
+		slug, err := resolvePlanFilter(root, opts.plan)
+		if err != nil {
+			return err
+		}
+		if slug != "" {
+			f.PlanSlug = slug
+		} else {
+			f.Plan = opts.plan
+		}
+	}
+	fields, err := taskUpdateFields(cfg, opts.status, opts.priority, opts.assignee, opts.due)
+	if err != nil {
+		return err
+	}
+
+	store := task.NewStore(root, &cfg)
+	tasks, err := store.List(f)
+	if err != nil {
+		return err
+	}
+	if len(tasks) == 0 {
+		fmt.Println("No tasks match.")
+		return nil
+	}
+
+	fmt.Printf("%d task(s) selected:\n", len(tasks))
+	for _, t := range tasks {
+		fmt.Printf("  - %s/%s [%s, %s]\n", t.Plan, filepath.Base(t.DirPath), t.Status, t.Priority)
+	}
+	if opts.dryRun {
+		fmt.Println("\nDry run — nothing updated.")
+		return nil
+	}
+	ok, err := confirm(cfg, fmt.Sprintf("Set %s on these tasks?", describeFields(fields)), opts.force, "--force")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	updated, err := store.UpdateAll(tasks, fields)
+	var refused interface{ Unwrap() []error }
+	if errors.As(err, &refused) {
+		for _, e := range refused.Unwrap() {
+			warnf("skipped %v", e)
+		}
+	}
+	if len(updated) == 0 {
+		return errors.New("no task was updated")
+	}
+	dirs := make([]string, len(updated))
+	for i, t := range updated {
+		dirs[i] = t.DirPath
+	}
+	commitTaskUpdate(root, cfg, fmt.Sprintf("%d task(s)", len(updated)), f.PlanSlug, "", task.Status(opts.status), dirs...)
+	printSuccess("Updated %d of %d task(s): %s", len(updated), len(tasks), describeFields(fields))
+	return nil
+}
+
+// describeFields renders update fields as "priority=high, status=done" in
+// a stable order for prompts and messages.
+func describeFields(fields map[string]string) string {
+	var parts []string
+	for _, k := range slices.Sorted(maps.Keys(fields)) {
+		v := fields[k]
+		if v == "" {
+			v = "none"
+		}
+		parts = append(parts, k+"="+v)
+	}
+	return strings.Join(parts, ", ")
+}
+
 // --- logos task delete -------------------------------------------------------
 
 var taskDeleteCmd = &cobra.Command{
 	Use:   "delete",
 	Short: "Delete a task directory",
 	Long: `Delete a task directory from .logosyncx/tasks/. A confirmation prompt is
-shown unless --force is passed.`,
+shown unless --force or --yes is passed; when stdin is not a terminal the
+command fails instead of prompting.`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		name, _ := cmd.Flags().GetString("name")
@@ -423,7 +1319,7 @@ func init() {
 	taskDeleteCmd.Flags().StringP("name", "n", "", "Task name to delete (partial match against task dir name)")
 	_ = taskDeleteCmd.MarkFlagRequired("name")
 	taskDeleteCmd.Flags().StringP("plan", "P", "", "Plan slug to narrow the search (substring match)")
-	taskDeleteCmd.Flags().Bool("force", false, "Skip confirmation prompt")
+	taskDeleteCmd.Flags().Bool("force", false, "Skip confirmation prompt (same as --yes)")
 }
 
 func runTaskDelete(planPartial, nameOrPartial string, force bool) error {
@@ -431,7 +1327,7 @@ func runTaskDelete(planPartial, nameOrPartial string, force bool) error {
 	if err != nil {
 		return err
 	}
-	cfg, err := config.Load(root)
+	cfg, err := loadConfig(root)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
@@ -442,22 +1338,20 @@ func runTaskDelete(planPartial, nameOrPartial string, force bool) error {
 		return err
 	}
 
-	if !force {
-		fmt.Printf("Delete task %q (status: %s, dir: %s)? [y/N] ", t.Title, t.Status, t.DirPath)
-		reader := bufio.NewReader(os.Stdin)
-		answer, _ := reader.ReadString('\n')
-		answer = strings.TrimSpace(strings.ToLower(answer))
-		if answer != "y" && answer != "yes" {
-			fmt.Println("Aborted.")
-			return nil
-		}
+	ok, err := confirm(cfg, fmt.Sprintf("Delete task %q (status: %s, dir: %s)?", t.Title, t.Status, t.DirPath), force, "--force")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Aborted.")
+		return nil
 	}
 
 	deleted, err := store.Delete(planPartial, nameOrPartial)
 	if err != nil {
 		return fmt.Errorf("delete task: %w", err)
 	}
-	fmt.Printf("✓ Deleted task %q.\n", deleted.Title)
+	printSuccess("Deleted task %q.", deleted.Title)
 	return nil
 }
 
@@ -467,14 +1361,24 @@ var taskSearchCmd = &cobra.Command{
 	Use:   "search",
 	Short: "Keyword search across task title, tags, and excerpt",
 	Long: `Case-insensitive keyword search across the title, tags, and excerpt
-(## What section) of every task. Optionally pre-filter by --plan, --status, or --tag.`,
+(## What section) of every task. Optionally pre-filter by --plan, --status, or --tag.
+Tasks you have read with logos task refer often or recently are listed first.
+
+--json prints the matches as a JSON array with the fields of logos task ls
+--json, --ndjson as one JSON object per line. Each match also carries
+matched_in: the fields the keyword was found in (title, tag, excerpt).`,
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		keyword, _ := cmd.Flags().GetString("keyword")
 		planPartial, _ := cmd.Flags().GetString("plan")
 		statusStr, _ := cmd.Flags().GetString("status")
 		tagStr, _ := cmd.Flags().GetString("tag")
-		return runTaskSearch(keyword, planPartial, statusStr, tagStr)
+		fullTables, _ = cmd.Flags().GetBool("full")
+		format, err := searchFormat(cmd)
+		if err != nil {
+			return err
+		}
+		return runTaskSearch(keyword, planPartial, statusStr, tagStr, format)
 	},
 }
 
@@ -482,16 +1386,25 @@ func init() {
 	taskSearchCmd.Flags().StringP("keyword", "k", "", "Keyword to search for (case-insensitive, matches title, tags, and excerpt)")
 	_ = taskSearchCmd.MarkFlagRequired("keyword")
 	taskSearchCmd.Flags().StringP("plan", "P", "", "Pre-filter by plan slug before keyword match")
-	taskSearchCmd.Flags().String("status", "", "Pre-filter by status before keyword match")
+	taskSearchCmd.Flags().String("status", "", "Pre-filter by status before keyword match (comma-separated for several)")
 	taskSearchCmd.Flags().StringP("tag", "t", "", "Pre-filter by tag before keyword match")
+	taskSearchCmd.Flags().Bool("full", false, "Do not truncate columns to fit the terminal width")
+	searchFormatFlags(taskSearchCmd, "tasks")
+}
+
+// taskSearchJSONEntry is a logos task ls --json entry with the fields the
+// search keyword was found in.
+type taskSearchJSONEntry struct {
+	task.TaskJSON
+	MatchedIn []string `json:"matched_in"`
 }
 
-func runTaskSearch(keyword, planPartial, statusStr, tagStr string) error {
+func runTaskSearch(keyword, planPartial, statusStr, tagStr, format string) error {
 	root, err := project.FindRoot()
 	if err != nil {
 		return err
 	}
-	cfg, err := config.Load(root)
+	cfg, err := loadConfig(root)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
@@ -499,28 +1412,39 @@ func runTaskSearch(keyword, planPartial, statusStr, tagStr string) error {
 
 	f := task.Filter{
 		Plan:    planPartial,
-		Status:  task.Status(statusStr),
 		Keyword: keyword,
 	}
+	if f.Statuses, err = task.ParseStatuses(statusStr); err != nil {
+		return err
+	}
 	if tagStr != "" {
 		f.Tags = []string{tagStr}
 	}
 
 	tasks, err := store.List(f)
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
-	}
-
-	if len(tasks) == 0 {
-		fmt.Println("No tasks found.")
-		return nil
+		warnf("%v", err)
 	}
 
 	var jsonEntries []task.TaskJSON
 	for _, t := range tasks {
 		jsonEntries = append(jsonEntries, t.ToJSON())
 	}
-	return printTaskTable(jsonEntries)
+	boostTasks(root, jsonEntries, time.Now())
+
+	if format != "" {
+		matches := make([]taskSearchJSONEntry, 0, len(jsonEntries))
+		for _, e := range normalizeTaskJSON(jsonEntries) {
+			matches = append(matches, taskSearchJSONEntry{TaskJSON: e, MatchedIn: e.MatchedIn(keyword)})
+		}
+		return printSearchJSON(matches, format)
+	}
+
+	if len(tasks) == 0 {
+		fmt.Println("No tasks found.")
+		return nil
+	}
+	return printTaskTable(jsonEntries, displayLocation(cfg))
 }
 
 // --- logos task walkthrough --------------------------------------------------
@@ -554,7 +1478,7 @@ func runTaskWalkthrough(planPartial, namePartial string) error {
 	if err != nil {
 		return err
 	}
-	cfg, err := config.Load(root)
+	cfg, err := loadConfig(root)
 	if err != nil {
 		return fmt.Errorf("load config: %w", err)
 	}
@@ -581,7 +1505,7 @@ func runTaskWalkthrough(planPartial, namePartial string) error {
 	// List mode: show all tasks in the plan with walkthrough fill status.
 	tasks, err := store.List(task.Filter{Plan: planPartial})
 	if err != nil {
-		fmt.Fprintf(os.Stderr, "warning: %v\n", err)
+		warnf("%v", err)
 	}
 	if len(tasks) == 0 {
 		fmt.Println("No tasks found.")
@@ -633,15 +1557,479 @@ func walkthroughFillStatus(path string) string {
 	return "[scaffold only]"
 }
 
+// --- logos task migrate-status -----------------------------------------------
+
+var taskMigrateStatusCmd = &cobra.Command{
+	Use:   "migrate-status",
+	Short: "Rewrite every task with one status to another status",
+	Long: `Rewrite the status field of every task whose status is --from, setting
+it to --to. Use this after a status has been renamed so that existing task
+files are not left with an unknown status (reported by logos sync).
+
+--to must be a recognised status (open, in_progress, done); --from may be any
+value, including one that is no longer recognised.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetString("from")
+		to, _ := cmd.Flags().GetString("to")
+		return runTaskMigrateStatus(from, to)
+	},
+}
+
+func init() {
+	taskMigrateStatusCmd.Flags().String("from", "", "Status to migrate away from (required)")
+	_ = taskMigrateStatusCmd.MarkFlagRequired("from")
+	taskMigrateStatusCmd.Flags().String("to", "", "Status to migrate to (required)")
+	_ = taskMigrateStatusCmd.MarkFlagRequired("to")
+}
+
+func runTaskMigrateStatus(from, to string) error {
+	if strings.TrimSpace(from) == "" || strings.TrimSpace(to) == "" {
+		return errors.New("provide both --from and --to")
+	}
+	if !task.IsValidStatus(task.Status(to)) {
+		return fmt.Errorf("invalid status %q: must be one of open, in_progress, done", to)
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+
+	n, err := store.MigrateStatus(task.Status(from), task.Status(to))
+	if err != nil {
+		return fmt.Errorf("migrated %d task(s), but: %w", n, err)
+	}
+	printSuccess("Migrated %d task(s) from status %q to %q.", n, from, to)
+	return nil
+}
+
+// --- logos task suggest-assignee ---------------------------------------------
+
+var taskSuggestAssigneeCmd = &cobra.Command{
+	Use:   "suggest-assignee",
+	Short: "Suggest the least-loaded teammate for a task",
+	Long: `Print the number of open and in-progress tasks per assignee, then
+recommend the roster member with the fewest active tasks for --name.
+
+The roster is read from tasks.roster in .logosyncx/config.json. Without a
+roster only the load table is printed. The suggestion is not applied; use
+logos task update --assignee to assign the task.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		planPartial, _ := cmd.Flags().GetString("plan")
+		return runTaskSuggestAssignee(planPartial, name)
+	},
+}
+
+func init() {
+	taskSuggestAssigneeCmd.Flags().StringP("name", "n", "", "Task to find an assignee for (partial match against task dir name)")
+	_ = taskSuggestAssigneeCmd.MarkFlagRequired("name")
+	taskSuggestAssigneeCmd.Flags().StringP("plan", "P", "", "Plan slug to narrow the search (substring match)")
+}
+
+func runTaskSuggestAssignee(planPartial, nameOrPartial string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+
+	t, err := store.Get(planPartial, nameOrPartial)
+	if err != nil {
+		return err
+	}
+	tasks, err := store.List(task.Filter{})
+	if err != nil {
+		return fmt.Errorf("load tasks: %w", err)
+	}
+
+	loads := task.ComputeLoad(tasks, cfg.Tasks.Roster)
+	if len(loads) == 0 {
+		fmt.Println("No assigned tasks and no roster configured.")
+	} else {
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ASSIGNEE\tOPEN\tIN_PROGRESS\tACTIVE\tROSTER")
+		fmt.Fprintln(w, "--------\t----\t-----------\t------\t------")
+		for _, l := range loads {
+			inRoster := "no"
+			if l.InRoster {
+				inRoster = "yes"
+			}
+			fmt.Fprintf(w, "%s\t%d\t%d\t%d\t%s\n", l.Assignee, l.Open, l.InProgress, l.Active(), inRoster)
+		}
+		if err := w.Flush(); err != nil {
+			return err
+		}
+	}
+	fmt.Println()
+
+	if t.Assignee != "" {
+		fmt.Printf("Task %q is currently assigned to %s.\n", t.Title, t.Assignee)
+	}
+	suggested, ok := task.SuggestAssignee(loads)
+	if !ok {
+		fmt.Println("No roster configured: add tasks.roster to .logosyncx/config.json to get a suggestion.")
+		return nil
+	}
+	fmt.Printf("Suggested assignee for %q: %s\n", t.Title, suggested)
+	printHint(fmt.Sprintf("Next: logos task update --plan %s --name %s --assignee %s",
+		t.Plan, filepath.Base(t.DirPath), suggested))
+	return nil
+}
+
+// --- logos task move ---------------------------------------------------------
+
+var taskMoveCmd = &cobra.Command{
+	Use:   "move",
+	Short: "Re-rank a task before or after another task",
+	Long: `Place a task immediately before (--before) or after (--after) another
+task in the manual backlog ranking. The ranking is stored in each task's
+order field and used by logos task ls --sort order.
+
+Tasks are ranked within their plan and status: both tasks must belong to
+the same plan and have the same status, and only that group is renumbered.
+
+Tasks that have never been ranked sort after ranked ones, newest first.
+Ties are broken by ID, then directory.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		planPartial, _ := cmd.Flags().GetString("plan")
+		before, _ := cmd.Flags().GetString("before")
+		after, _ := cmd.Flags().GetString("after")
+		return runTaskMove(planPartial, name, before, after)
+	},
+}
+
+func init() {
+	taskMoveCmd.Flags().StringP("name", "n", "", "Task to move (partial match against task dir name)")
+	_ = taskMoveCmd.MarkFlagRequired("name")
+	taskMoveCmd.Flags().StringP("plan", "P", "", "Plan slug to narrow the search (substring match)")
+	taskMoveCmd.Flags().String("before", "", "Task to place it before (partial match)")
+	taskMoveCmd.Flags().String("after", "", "Task to place it after (partial match)")
+	taskMoveCmd.MarkFlagsOneRequired("before", "after")
+	taskMoveCmd.MarkFlagsMutuallyExclusive("before", "after")
+}
+
+func runTaskMove(planPartial, nameOrPartial, before, after string) error {
+	if (before == "") == (after == "") {
+		return errors.New("provide exactly one of --before or --after")
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+
+	target, where := before, "before"
+	if after != "" {
+		target, where = after, "after"
+	}
+	if _, err := store.Move(planPartial, nameOrPartial, target, after != ""); err != nil {
+		return fmt.Errorf("move task: %w", err)
+	}
+	printSuccess("Moved %q %s %q.", nameOrPartial, where, target)
+	return nil
+}
+
+// --- logos task rename -------------------------------------------------------
+
+var taskRenameCmd = &cobra.Command{
+	Use:   "rename",
+	Short: "Change a task's title, renaming its directory",
+	Long: `Set a new title on a task and move its directory to NNN-<new-title> so that
+the directory name keeps matching. Its seq is unchanged, so depends_on links
+from other tasks stay intact; the tasks list of knowledge files distilled
+from its plan and its referral ranking follow the new title. The task index
+is rebuilt.
+
+To rename a plan, use logos rename.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		planPartial, _ := cmd.Flags().GetString("plan")
+		title, _ := cmd.Flags().GetString("title")
+		return runTaskRename(planPartial, name, title)
+	},
+}
+
+func init() {
+	taskRenameCmd.Flags().StringP("name", "n", "", "Task to rename (partial match against task dir name)")
+	_ = taskRenameCmd.MarkFlagRequired("name")
+	taskRenameCmd.Flags().StringP("plan", "P", "", "Plan slug to narrow the search (substring match)")
+	taskRenameCmd.Flags().String("title", "", "New title")
+	_ = taskRenameCmd.MarkFlagRequired("title")
+}
+
+func runTaskRename(planPartial, nameOrPartial, title string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+
+	before, after, err := store.Rename(planPartial, nameOrPartial, title)
+	if err != nil {
+		return fmt.Errorf("rename task: %w", err)
+	}
+
+	oldRef := fmt.Sprintf("%03d-%s", before.Seq, before.Title)
+	newRef := fmt.Sprintf("%03d-%s", after.Seq, after.Title)
+	rewriteKnowledge(root, cfg, func(k *knowledge.Knowledge) bool {
+		if k.Plan != after.Plan+".md" {
+			return false
+		}
+		changed := false
+		for i, ref := range k.Tasks {
+			if ref == oldRef {
+				k.Tasks[i], changed = newRef, true
+			}
+		}
+		return changed
+	})
+	oldName, newName := taskRefName(before.Plan, before.DirPath), taskRefName(after.Plan, after.DirPath)
+	_ = recent.Rename(root, recent.KindTask, func(n string) string {
+		if n == oldName {
+			return newName
+		}
+		return n
+	})
+
+	from, _ := relPath(root, before.DirPath)
+	to, _ := relPath(root, after.DirPath)
+	printSuccess("Renamed %s → %s", from, to)
+	return nil
+}
+
+// --- logos task comment ------------------------------------------------------
+
+var taskCommentCmd = &cobra.Command{
+	Use:   "comment",
+	Short: "Add a timestamped entry to a task's worklog",
+	Long: `Append "- YYYY-MM-DD HH:MM <author>: <message>" to the ## Log section of a
+task's TASK.md, creating the section when it is missing. The time is shown
+in display.timezone and the author is git user.name unless --as is given.
+
+Use logos task refer --log to read the worklog back.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		planPartial, _ := cmd.Flags().GetString("plan")
+		message, _ := cmd.Flags().GetString("message")
+		as, _ := cmd.Flags().GetString("as")
+		return runTaskComment(planPartial, name, message, as, time.Now())
+	},
+}
+
+func init() {
+	taskCommentCmd.Flags().StringP("name", "n", "", "Task to comment on (partial match against task dir name)")
+	_ = taskCommentCmd.MarkFlagRequired("name")
+	taskCommentCmd.Flags().StringP("plan", "P", "", "Plan slug to narrow the search (substring match)")
+	taskCommentCmd.Flags().StringP("message", "m", "", "The entry to add")
+	_ = taskCommentCmd.MarkFlagRequired("message")
+	taskCommentCmd.Flags().String("as", "", "Record the entry as this user instead of git user.name")
+}
+
+func runTaskComment(planPartial, nameOrPartial, message, as string, now time.Time) error {
+	if strings.TrimSpace(message) == "" {
+		return errors.New("--message must not be empty")
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	author, err := ackUser(root, as)
+	if err != nil {
+		return err
+	}
+	store := task.NewStore(root, &cfg)
+
+	entry := task.LogEntry(now, displayLocation(cfg), author, message)
+	t, err := store.AppendLog(planPartial, nameOrPartial, entry)
+	if err != nil {
+		return fmt.Errorf("comment on task: %w", err)
+	}
+	printSuccess("%s/%s: %s", t.Plan, filepath.Base(t.DirPath), strings.TrimPrefix(entry, "- "))
+	return nil
+}
+
+// --- logos task deps ---------------------------------------------------------
+
+var taskDepsCmd = &cobra.Command{
+	Use:   "deps",
+	Short: "Print a task's dependency tree",
+	Long: `Print the tasks a task depends on (its depends_on seq numbers, set with
+logos task create --depends-on), recursively, with their status, followed by
+the tasks in the same plan that depend on it.
+
+A task cannot be moved to in_progress or done while any of its dependencies
+is not done. Dependencies that no longer exist are shown as "missing", and a
+dependency cycle is marked "(cycle)" instead of being expanded again.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		planPartial, _ := cmd.Flags().GetString("plan")
+		return runTaskDeps(planPartial, name)
+	},
+}
+
+func init() {
+	taskDepsCmd.Flags().StringP("name", "n", "", "Task name (partial match against task dir name)")
+	_ = taskDepsCmd.MarkFlagRequired("name")
+	taskDepsCmd.Flags().StringP("plan", "P", "", "Plan slug to narrow the search (substring match)")
+}
+
+func runTaskDeps(planPartial, nameOrPartial string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+	t, err := store.Get(planPartial, nameOrPartial)
+	if err != nil {
+		return fmt.Errorf("find task: %w", err)
+	}
+
+	tree, dependents := store.Dependencies(t)
+	fmt.Println(depLabel(tree))
+	printDepTree(tree.Deps, "")
+	if len(tree.Deps) == 0 {
+		fmt.Println("  (no dependencies)")
+	}
+
+	if len(dependents) > 0 {
+		fmt.Println("\nBlocks:")
+		for _, d := range dependents {
+			fmt.Printf("  %03d %s [%s]\n", d.Seq, d.Title, d.Status)
+		}
+	}
+	return nil
+}
+
+// printDepTree prints nodes as a tree, each line prefixed by prefix.
+func printDepTree(nodes []task.DepNode, prefix string) {
+	for i, n := range nodes {
+		branch, next := "├── ", "│   "
+		if i == len(nodes)-1 {
+			branch, next = "└── ", "    "
+		}
+		fmt.Println(prefix + branch + depLabel(n))
+		printDepTree(n.Deps, prefix+next)
+	}
+}
+
+// depLabel formats one tree node as "NNN <title> [<status>]".
+func depLabel(n task.DepNode) string {
+	if n.Task == nil {
+		return fmt.Sprintf("%03d (missing)", n.Seq)
+	}
+	label := fmt.Sprintf("%03d %s [%s]", n.Seq, n.Task.Title, n.Task.Status)
+	if n.Cycle {
+		label += " (cycle)"
+	}
+	return label
+}
+
+// --- logos task snooze -------------------------------------------------------
+
+var taskSnoozeCmd = &cobra.Command{
+	Use:   "snooze",
+	Short: "Hide a task from task ls until a date",
+	Long: `Defer a task: it is hidden from the default logos task ls output until
+--until is reached. --until takes a date (YYYY-MM-DD) or a relative date
+such as "tomorrow", "3d", "in 2 weeks", or "next monday".
+logos task ls --all still shows it. Use --clear to remove the snooze early.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		planPartial, _ := cmd.Flags().GetString("plan")
+		until, _ := cmd.Flags().GetString("until")
+		clearSnooze, _ := cmd.Flags().GetBool("clear")
+		return runTaskSnooze(planPartial, name, until, clearSnooze)
+	},
+}
+
+func init() {
+	taskSnoozeCmd.Flags().StringP("name", "n", "", "Task to snooze (partial match against task dir name)")
+	_ = taskSnoozeCmd.MarkFlagRequired("name")
+	taskSnoozeCmd.Flags().StringP("plan", "P", "", "Plan slug to narrow the search (substring match)")
+	taskSnoozeCmd.Flags().String("until", "", "Date the task reappears (YYYY-MM-DD, tomorrow, 3d, next monday, ...)")
+	taskSnoozeCmd.Flags().Bool("clear", false, "Remove an existing snooze")
+	taskSnoozeCmd.MarkFlagsOneRequired("until", "clear")
+	taskSnoozeCmd.MarkFlagsMutuallyExclusive("until", "clear")
+}
+
+func runTaskSnooze(planPartial, nameOrPartial, until string, clearSnooze bool) error {
+	if (until == "") == !clearSnooze {
+		return errors.New("provide exactly one of --until or --clear")
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+
+	if until != "" {
+		t, err := dateparse.Future(until, time.Now().In(displayLocation(cfg)))
+		if err != nil {
+			return fmt.Errorf("--until: %w", err)
+		}
+		until = t.Format("2006-01-02")
+	}
+
+	if err := store.UpdateFields(planPartial, nameOrPartial, map[string]string{"snoozed_until": until}); err != nil {
+		return fmt.Errorf("snooze task: %w", err)
+	}
+	if clearSnooze {
+		printSuccess("Cleared snooze on task %q.", nameOrPartial)
+	} else {
+		printSuccess("Snoozed task %q until %s.", nameOrPartial, until)
+	}
+	return nil
+}
+
 // --- shared output helpers ---------------------------------------------------
 
-// printTaskTable writes a human-readable tab-aligned task table to stdout.
-func printTaskTable(entries []task.TaskJSON) error {
-	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
-	fmt.Fprintln(w, "SEQ\tDATE\tTITLE\tSTATUS\tPRIORITY\tSTART\tPLAN")
-	fmt.Fprintln(w, "---\t----\t-----\t------\t--------\t-----\t----")
+// printTaskTable writes a human-readable aligned task table to stdout, with
+// dates rendered in loc. Long titles and plan names are ellipsized to fit the
+// terminal unless --full is set.
+func printTaskTable(entries []task.TaskJSON, loc *time.Location) error {
+	t := &textTable{
+		headers:  []string{"SEQ", "DATE", "TITLE", "STATUS", "PRIORITY", "START", "PLAN"},
+		fitWidth: tableWidth(),
+		shrink:   []int{2, 6},
+	}
 	for _, e := range entries {
-		date := e.Date.Format("2006-01-02")
 		planName := e.Plan
 		if planName == "" {
 			planName = "-"
@@ -650,14 +2038,22 @@ func printTaskTable(entries []task.TaskJSON) error {
 		if e.CanStart {
 			canStart = "✓"
 		}
-		fmt.Fprintf(w, "%03d\t%s\t%s\t%s\t%s\t%s\t%s\n",
-			e.Seq, date, e.Title, string(e.Status), string(e.Priority), canStart, planName)
+		t.addRow(fmt.Sprintf("%03d", e.Seq), e.Date.In(loc).Format("2006-01-02"),
+			e.Title, string(e.Status), string(e.Priority), canStart, planName)
 	}
-	return w.Flush()
+	return t.render(os.Stdout)
 }
 
 // printTaskJSON writes a JSON array of TaskJSON objects to stdout.
 func printTaskJSON(entries []task.TaskJSON) error {
+	enc := json.NewEncoder(os.Stdout)
+	enc.SetIndent("", "  ")
+	return enc.Encode(normalizeTaskJSON(entries))
+}
+
+// normalizeTaskJSON returns entries with nil lists replaced by empty ones,
+// so JSON output always uses [] rather than null.
+func normalizeTaskJSON(entries []task.TaskJSON) []task.TaskJSON {
 	out := make([]task.TaskJSON, len(entries))
 	for i, e := range entries {
 		if e.Tags == nil {
@@ -668,7 +2064,319 @@ func printTaskJSON(entries []task.TaskJSON) error {
 		}
 		out[i] = e
 	}
-	enc := json.NewEncoder(os.Stdout)
-	enc.SetIndent("", "  ")
-	return enc.Encode(out)
+	return out
+}
+
+// --- logos task purge --------------------------------------------------------
+
+var taskPurgeCmd = &cobra.Command{
+	Use:   "purge",
+	Short: "Archive old tasks matching a status, age, tag, or plan",
+	Long: `Move tasks out of .logosyncx/tasks/ into .logosyncx/tasks-archive/.
+By default all done tasks are selected; narrow the selection with:
+
+  --older-than 30d   completed (in_progress tasks: started; open tasks:
+                     created) more than 30 days ago; accepts Nd or Nw
+  --tag <tag>        tasks carrying this tag
+  --plan <partial>   tasks of one plan
+
+Tasks that a remaining task depends on are kept so the dependent does not
+become permanently blocked. Archived tasks are listed by logos archive ls
+and restored with logos archive restore; logos gc purge deletes the archive
+permanently.
+
+A confirmation prompt is shown unless --force or --yes is passed; when stdin
+is not a terminal the command fails instead of prompting. Use --dry-run to
+list the selection without archiving anything.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		statusStr, _ := cmd.Flags().GetString("status")
+		olderThan, _ := cmd.Flags().GetString("older-than")
+		tag, _ := cmd.Flags().GetString("tag")
+		planPartial, _ := cmd.Flags().GetString("plan")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+		force, _ := cmd.Flags().GetBool("force")
+		return runTaskPurge(statusStr, olderThan, tag, planPartial, dryRun, force, time.Now())
+	},
+}
+
+func init() {
+	taskPurgeCmd.Flags().StringP("status", "s", string(task.StatusDone), "Status of tasks to purge (comma-separated for several)")
+	taskPurgeCmd.Flags().String("older-than", "", "Only tasks older than this age (e.g. 30d, 2w)")
+	taskPurgeCmd.Flags().StringP("tag", "t", "", "Only tasks with this tag")
+	taskPurgeCmd.Flags().StringP("plan", "P", "", "Only tasks of this plan (partial match)")
+	taskPurgeCmd.Flags().Bool("dry-run", false, "List the tasks that would be archived without moving them")
+	taskPurgeCmd.Flags().Bool("force", false, "Skip confirmation prompt (same as --yes)")
+}
+
+func runTaskPurge(statusStr, olderThan, tag, planPartial string, dryRun, force bool, now time.Time) error {
+	statuses, err := task.ParseStatuses(statusStr)
+	if err != nil {
+		return err
+	}
+	f := task.PurgeFilter{Statuses: statuses, Tag: tag}
+	if olderThan != "" {
+		age, err := config.ParseAge(olderThan)
+		if err != nil {
+			return fmt.Errorf("invalid --older-than: %w", err)
+		}
+		f.Before = now.Add(-age)
+	}
+
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if planPartial != "" {
+		slug, err := resolvePlanFilter(root, planPartial)
+		if err != nil {
+			return err
+		}
+		if slug == "" {
+			slug = planPartial
+		}
+		f.PlanSlug = slug
+	}
+
+	store := task.NewStore(root, &cfg)
+	purge, kept, err := store.PurgeCandidates(f)
+	if err != nil {
+		warnf("%v", err)
+	}
+	for _, t := range kept {
+		warnf("keeping %s/%s — a remaining task depends on it", t.Plan, filepath.Base(t.DirPath))
+	}
+	if len(purge) == 0 {
+		fmt.Println("No tasks to purge.")
+		return nil
+	}
+
+	fmt.Printf("%d task(s) selected:\n", len(purge))
+	for _, t := range purge {
+		fmt.Printf("  - %s/%s [%s]\n", t.Plan, filepath.Base(t.DirPath), t.Status)
+	}
+	if dryRun {
+		fmt.Println("\nDry run — nothing archived.")
+		return nil
+	}
+
+	ok, err := confirm(cfg, "Move these tasks to .logosyncx/tasks-archive/?", force, "--force")
+	if err != nil {
+		return err
+	}
+	if !ok {
+		fmt.Println("Aborted.")
+		return nil
+	}
+
+	n, err := store.Archive(purge)
+	if err != nil {
+		return fmt.Errorf("archive tasks: %w", err)
+	}
+	printSuccess("Archived %d task(s) to .logosyncx/tasks-archive/.", n)
+	printHint("Restore a task with `logos archive restore --name <plan>/<task>` (see `logos archive ls`).")
+	return nil
+}
+
+// --- logos task archive ------------------------------------------------------
+
+var taskArchiveCmd = &cobra.Command{
+	Use:   "archive",
+	Short: "Move one task to the task archive",
+	Long: `Move a single task from .logosyncx/tasks/ to .logosyncx/tasks-archive/ and
+rebuild the task index, whatever its status. The task is not deleted: it
+is listed by logos archive ls and restored with logos archive restore.
+
+Unfinished tasks of the same plan that depend on the task are named in a
+warning, since they stay blocked until it is restored.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		name, _ := cmd.Flags().GetString("name")
+		planPartial, _ := cmd.Flags().GetString("plan")
+		return runTaskArchive(name, planPartial)
+	},
+}
+
+func init() {
+	taskArchiveCmd.Flags().StringP("name", "n", "", "Task name (partial match)")
+	taskArchiveCmd.Flags().StringP("plan", "P", "", "Plan to search in (partial match)")
+	_ = taskArchiveCmd.MarkFlagRequired("name")
+}
+
+func runTaskArchive(name, planPartial string) error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+	t, err := store.Get(planPartial, name)
+	if err != nil {
+		return err
+	}
+
+	siblings, err := store.List(task.Filter{PlanSlug: t.Plan})
+	if err != nil {
+		warnf("%v", err)
+	}
+	for _, o := range siblings {
+		if o.DirPath != t.DirPath && o.Status != task.StatusDone && slices.Contains(o.DependsOn, t.Seq) {
+			warnf("%s/%s depends on this task and stays blocked until it is restored", o.Plan, filepath.Base(o.DirPath))
+		}
+	}
+
+	if _, err := store.Archive([]*task.Task{t}); err != nil {
+		return fmt.Errorf("archive task: %w", err)
+	}
+	printSuccess("Archived task %s/%s to .logosyncx/tasks-archive/", t.Plan, filepath.Base(t.DirPath))
+	printHint(fmt.Sprintf("Restore with: logos archive restore --name %s/%s", t.Plan, filepath.Base(t.DirPath)))
+	return nil
+}
+
+// --- logos task import -------------------------------------------------------
+
+var taskImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Create tasks from the checklist items of a Markdown file",
+	Long: `Convert the top-level checklist items of an existing TODO.md (or any
+Markdown checklist) into tasks under --plan:
+
+  - [ ] Add login form        → open task "Add login form"
+  - [x] Set up CI             → done task "Set up CI"
+    - [ ] nested item         → kept in the task's ## Checklist section
+
+Nested lines under an item are preserved in the new task's body. Tags from
+--tag, tasks.default_tags, and tasks.rules routing apply as with
+task create. Use --dry-run to preview the tasks without creating them.`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		from, _ := cmd.Flags().GetString("from")
+		planPartial, _ := cmd.Flags().GetString("plan")
+		tags, _ := cmd.Flags().GetStringArray("tag")
+		dryRun, _ := cmd.Flags().GetBool("dry-run")
+
+		root, err := project.FindRoot()
+		if err != nil {
+			return err
+		}
+		allPlans, err := plan.LoadAll(root)
+		if err != nil {
+			return fmt.Errorf("load plans: %w", err)
+		}
+		resolvedPlan, err := findPlan(planPartial, allPlans)
+		if err != nil {
+			return err
+		}
+		if blocker := blockedByDep(resolvedPlan, allPlans); blocker != "" {
+			return fmt.Errorf("plan %q is blocked: dependency %q is not yet distilled — distill it first", strings.TrimSuffix(resolvedPlan.Filename, ".md"), blocker)
+		}
+		planSlug := strings.TrimSuffix(resolvedPlan.Filename, ".md")
+
+		return runTaskImport(root, planSlug, from, args[0], tags, dryRun)
+	},
+}
+
+func init() {
+	taskImportCmd.Flags().String("from", "markdown", "Source format (only markdown is supported)")
+	taskImportCmd.Flags().StringP("plan", "P", "", "Plan to attach the tasks to (partial name match, required)")
+	_ = taskImportCmd.MarkFlagRequired("plan")
+	taskImportCmd.Flags().StringArray("tag", []string{}, "Tag to attach to every imported task (repeatable)")
+	taskImportCmd.Flags().Bool("dry-run", false, "List the tasks that would be created without writing them")
+}
+
+// runTaskImport creates one task per top-level checklist item in the file at
+// path, under planSlug (resolved by caller).
+func runTaskImport(root, planSlug, from, path string, tags []string, dryRun bool) error {
+	if from != "markdown" {
+		return fmt.Errorf("unsupported --from %q: only markdown is supported", from)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", path, err)
+	}
+	items := markdown.ParseChecklist(string(data))
+	if len(items) == 0 {
+		return fmt.Errorf("no checklist items (\"- [ ] ...\") found in %s", path)
+	}
+
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	if err := config.CheckTags(tags, cfg.Tasks.AllowedTags, "tasks.allowed_tags"); err != nil {
+		return err
+	}
+	tags = config.MergeTags(tags, cfg.Tasks.DefaultTags)
+
+	if dryRun {
+		fmt.Printf("%d task(s) would be created in %s:\n", len(items), planSlug)
+		for _, it := range items {
+			status := task.StatusOpen
+			if it.Checked {
+				status = task.StatusDone
+			}
+			fmt.Printf("  - %s [%s]\n", it.Text, status)
+		}
+		return nil
+	}
+
+	store := task.NewStore(root, &cfg)
+	branch := recordBranch(cfg)
+	created := 0
+	for _, it := range items {
+		t := task.Task{
+			Title:  it.Text,
+			Plan:   planSlug,
+			Tags:   slices.Clone(tags),
+			Body:   importedTaskBody(it),
+			Branch: branch,
+		}
+		if it.Checked {
+			now := time.Now()
+			t.Status = task.StatusDone
+			t.CompletedAt = &now
+		}
+		if _, err := task.ApplyRules(&t, cfg.Tasks.Rules); err != nil {
+			return err
+		}
+		fields := append([]privacyField{{"title", &t.Title}, {"body", &t.Body}}, tagFields(t.Tags)...)
+		if err := enforcePrivacy(cfg, "task", fields...); err != nil {
+			return err
+		}
+
+		createdPath, err := store.Create(&t)
+		if err != nil {
+			return fmt.Errorf("create task %q: %w", it.Text, err)
+		}
+		if t.Status == task.StatusDone {
+			if err := store.CreateWalkthroughScaffold(&t); err != nil {
+				warnf("could not create walkthrough scaffold: %v", err)
+			}
+		}
+		rel, _ := relPath(root, createdPath)
+		fmt.Printf("  created %s [%s]\n", rel, t.Status)
+		created++
+	}
+
+	printSuccess("Imported %d task(s) from %s into %s.", created, path, planSlug)
+	printHint("Next: fill in the ## What / ## Why sections of the imported tasks.")
+	return nil
+}
+
+// importedTaskBody builds the TASK.md body for an imported checklist item:
+// the item text under ## What and any nested lines under ## Checklist.
+func importedTaskBody(it markdown.ChecklistItem) string {
+	var b strings.Builder
+	b.WriteString("\n## What\n\n" + it.Text + "\n")
+	if len(it.Children) > 0 {
+		b.WriteString("\n## Checklist\n\n" + strings.Join(it.Children, "\n") + "\n")
+	}
+	return b.String()
 }
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestTaskComment_AppendsToLogAndReadsBack(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Wire up login", priority: "medium"}); err != nil {
+		t.Fatalf("runTaskCreate: %v", err)
+	}
+	first := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	captureOutput(t, func() {
+		if err := runTaskComment("", "login", "Started on the form", "alice", first); err != nil {
+			t.Fatalf("runTaskComment: %v", err)
+		}
+		if err := runTaskComment("", "login", "Blocked on\nthe API", "bob", first.Add(time.Hour)); err != nil {
+			t.Fatalf("runTaskComment: %v", err)
+		}
+	})
+
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+	if !strings.Contains(tasks[0].Body, "## Log") {
+		t.Errorf("expected a ## Log section, got:\n%s", tasks[0].Body)
+	}
+
+	out := captureOutput(t, func() {
+		if err := runTaskLog("login", ""); err != nil {
+			t.Fatalf("runTaskLog: %v", err)
+		}
+	})
+	lines := strings.Split(strings.TrimSpace(out), "\n")
+	if len(lines) != 2 {
+		t.Fatalf("expected 2 log lines, got:\n%s", out)
+	}
+	if !strings.Contains(lines[0], "alice: Started on the form") || !strings.Contains(lines[1], "bob: Blocked on the API") {
+		t.Errorf("unexpected log:\n%s", out)
+	}
+}
+
+func TestTaskComment_EmptyMessageIsError(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Quiet task", priority: "medium"}); err != nil {
+		t.Fatalf("runTaskCreate: %v", err)
+	}
+	if err := runTaskComment("", "quiet", "  ", "alice", time.Now()); err == nil {
+		t.Error("expected an error for an empty message")
+	}
+	out := captureOutput(t, func() {
+		if err := runTaskLog("quiet", ""); err != nil {
+			t.Fatalf("runTaskLog: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No log entries") {
+		t.Errorf("expected the empty-log message, got: %q", out)
+	}
+}
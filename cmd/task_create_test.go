@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/senna-lang/logosyncx/internal/markdown"
 	"github.com/senna-lang/logosyncx/internal/task"
 	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
 )
 
 // --- helpers -----------------------------------------------------------------
@@ -32,7 +37,7 @@ func loadAllTasks(t *testing.T, projectRoot string) []*task.Task {
 func TestTaskCreate_TitleOnly(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "My new task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "My new task", priority: "medium"}); err != nil {
 		t.Fatalf("runTaskCreate with --title failed: %v", err)
 	}
 
@@ -48,7 +53,7 @@ func TestTaskCreate_TitleOnly(t *testing.T) {
 func TestTaskCreate_AllFrontmatterFields(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Full flag task", "high", []string{"go", "cli"}, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Full flag task", priority: "high", tags: []string{"go", "cli"}}); err != nil {
 		t.Fatalf("runTaskCreate with all flags failed: %v", err)
 	}
 
@@ -71,7 +76,7 @@ func TestTaskCreate_AllFrontmatterFields(t *testing.T) {
 func TestTaskCreate_DefaultPriorityIsMedium(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Default priority task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Default priority task", priority: "medium"}); err != nil {
 		t.Fatalf("runTaskCreate failed: %v", err)
 	}
 
@@ -87,7 +92,7 @@ func TestTaskCreate_DefaultPriorityIsMedium(t *testing.T) {
 func TestTaskCreate_AutoFillsIDAndDate(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Autofill test task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Autofill test task", priority: "medium"}); err != nil {
 		t.Fatalf("runTaskCreate failed: %v", err)
 	}
 
@@ -107,7 +112,7 @@ func TestTaskCreate_AutoFillsIDAndDate(t *testing.T) {
 func TestTaskCreate_DefaultStatusIsOpen(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Status test task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Status test task", priority: "medium"}); err != nil {
 		t.Fatalf("runTaskCreate failed: %v", err)
 	}
 
@@ -123,7 +128,7 @@ func TestTaskCreate_DefaultStatusIsOpen(t *testing.T) {
 func TestTaskCreate_ErrorOnInvalidPriority(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	err := runTaskCreate(dir, testPlan, "Bad priority task", "urgent", nil, nil)
+	err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Bad priority task", priority: "urgent"})
 	if err == nil {
 		t.Fatal("expected error for invalid priority, got nil")
 	}
@@ -137,7 +142,7 @@ func TestTaskCreate_ErrorWhenNoTitleProvided(t *testing.T) {
 
 	// runTaskCreate bypasses cobra flag validation, so store returns its own
 	// error. We check for the word "title" (not the cobra flag name "--title").
-	err := runTaskCreate(dir, testPlan, "", "medium", nil, nil)
+	err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, priority: "medium"})
 	if err == nil {
 		t.Fatal("expected error when no title provided, got nil")
 	}
@@ -149,7 +154,7 @@ func TestTaskCreate_ErrorWhenNoTitleProvided(t *testing.T) {
 func TestTaskCreate_ErrorWhenNoPlanProvided(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	err := runTaskCreate(dir, "", "Some task", "medium", nil, nil)
+	err := runTaskCreate(dir, taskCreateOptions{title: "Some task", priority: "medium"})
 	if err == nil {
 		t.Fatal("expected error when no plan provided, got nil")
 	}
@@ -161,7 +166,7 @@ func TestTaskCreate_ErrorWhenNoPlanProvided(t *testing.T) {
 func TestTaskCreate_PlanGroupDirIsCreated(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Dir check task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Dir check task", priority: "medium"}); err != nil {
 		t.Fatalf("runTaskCreate failed: %v", err)
 	}
 
@@ -173,3 +178,296 @@ func TestTaskCreate_PlanGroupDirIsCreated(t *testing.T) {
 		t.Errorf("plan = %q, want %q", tasks[0].Plan, testPlan)
 	}
 }
+
+// --- routing rules -----------------------------------------------------------
+
+func saveRules(t *testing.T, dir string, rules []config.TaskRule) {
+	t.Helper()
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	cfg.Tasks.Rules = rules
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatalf("config.Save: %v", err)
+	}
+}
+
+func TestTaskCreate_AppliesRoutingRules(t *testing.T) {
+	dir := setupInitedProject(t)
+	saveRules(t, dir, []config.TaskRule{{Tag: "infra", Assignee: "ops-team", Priority: "high"}})
+
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Rotate certs", tags: []string{"infra"}}); err != nil {
+		t.Fatalf("runTaskCreate: %v", err)
+	}
+
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+	if tasks[0].Assignee != "ops-team" || tasks[0].Priority != task.PriorityHigh {
+		t.Errorf("got assignee=%q priority=%q, want ops-team/high", tasks[0].Assignee, tasks[0].Priority)
+	}
+}
+
+func TestTaskCreate_NoRules_SkipsRouting(t *testing.T) {
+	dir := setupInitedProject(t)
+	saveRules(t, dir, []config.TaskRule{{Tag: "infra", Assignee: "ops-team", Priority: "high"}})
+
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Rotate certs", tags: []string{"infra"}, noRules: true}); err != nil {
+		t.Fatalf("runTaskCreate: %v", err)
+	}
+
+	tasks := loadAllTasks(t, dir)
+	if tasks[0].Assignee != "" || tasks[0].Priority != task.PriorityMedium {
+		t.Errorf("got assignee=%q priority=%q, want unassigned/medium", tasks[0].Assignee, tasks[0].Priority)
+	}
+}
+
+func TestRulesTest_PreviewsEffects(t *testing.T) {
+	dir := setupInitedProject(t)
+	saveRules(t, dir, []config.TaskRule{
+		{Tag: "infra", Assignee: "ops-team", Priority: "high"},
+		{Tag: "docs", Assignee: "writers"},
+	})
+
+	out := captureStdout(t, func() {
+		if err := runRulesTest([]string{"infra"}, ""); err != nil {
+			t.Fatalf("runRulesTest: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "assignee=ops-team") || !strings.Contains(out, "priority=high") {
+		t.Errorf("expected previewed effects, got:\n%s", out)
+	}
+	if strings.Contains(out, "writers\n") || strings.Contains(out, "assignee=writers") {
+		t.Errorf("unexpected effect from non-matching rule, got:\n%s", out)
+	}
+}
+
+// --- tag vocabulary / default tags -------------------------------------------
+
+func TestTaskCreate_TagVocabularyAndDefaults(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	cfg.Tasks.AllowedTags = []string{"infra", "docs"}
+	cfg.Tasks.DefaultTags = []string{"area-core"}
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatalf("config.Save: %v", err)
+	}
+
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Bad tag", tags: []string{"infro"}}); err == nil {
+		t.Fatal("expected error for tag outside tasks.allowed_tags")
+	}
+
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Good tag", tags: []string{"infra"}}); err != nil {
+		t.Fatalf("runTaskCreate: %v", err)
+	}
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+	if strings.Join(tasks[0].Tags, ",") != "infra,area-core" {
+		t.Errorf("Tags = %v, want [infra area-core]", tasks[0].Tags)
+	}
+}
+
+// --- --seed ------------------------------------------------------------------
+
+func TestTaskCreate_SeedFromPlan(t *testing.T) {
+	dir := setupInitedProject(t)
+	p := makeSyncPlan("p1", "seeded", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	p.Body = "## Background\nUsers get logged out hourly.\n\n## Spec\nRefresh tokens silently.\n\n## Key Decisions\n<!-- none yet -->\n"
+	writeSyncPlan(t, dir, p)
+	slug := strings.TrimSuffix(plan.FileName(p), ".md")
+
+	out := captureOutput(t, func() {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Token refresh", seed: true}); err != nil {
+			t.Fatalf("runTaskCreate --seed: %v", err)
+		}
+	})
+	if !strings.Contains(out, "seeded What, Why from "+slug) {
+		t.Errorf("expected seed summary, got: %q", out)
+	}
+
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+	body := tasks[0].Body
+	what, _ := markdown.Section(body, "What")
+	if !strings.Contains(what, "Refresh tokens silently.") || !strings.Contains(what, "seeded from "+slug+".md (Spec)") {
+		t.Errorf("What not seeded from Spec: %q", what)
+	}
+	why, _ := markdown.Section(body, "Why")
+	if !strings.Contains(why, "Users get logged out hourly.") || !strings.Contains(why, "(Background)") {
+		t.Errorf("Why not seeded from Background only: %q", why)
+	}
+	if _, ok := markdown.Section(body, "Acceptance Criteria"); !ok {
+		t.Error("expected the rest of the task template to be kept")
+	}
+	if !strings.HasPrefix(tasks[0].Excerpt, "Refresh tokens silently.") {
+		t.Errorf("excerpt should start with the seeded content, got: %q", tasks[0].Excerpt)
+	}
+}
+
+// --- --template --------------------------------------------------------------
+
+func TestTaskCreate_Template(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Fix login loop", template: "bugfix"}); err != nil {
+		t.Fatalf("runTaskCreate --template: %v", err)
+	}
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 {
+		t.Fatalf("expected 1 task, got %d", len(tasks))
+	}
+	for _, h := range []string{"Symptom", "Root Cause", "Fix", "Verification"} {
+		if _, ok := markdown.Section(tasks[0].Body, h); !ok {
+			t.Errorf("expected section %q, got:\n%s", h, tasks[0].Body)
+		}
+	}
+}
+
+func TestTaskCreate_TemplateWithSeed(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, _ := config.Load(dir)
+	cfg.Templates = map[string]config.TemplateConfig{
+		"small": {Sections: []config.SectionConfig{{Name: "What"}, {Name: "Done When"}}},
+	}
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	p := makeSyncPlan("p1", "seeded", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	p.Body = "## Spec\nRefresh tokens silently.\n"
+	writeSyncPlan(t, dir, p)
+	slug := strings.TrimSuffix(plan.FileName(p), ".md")
+
+	captureOutput(t, func() {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: slug, title: "Token refresh", seed: true, template: "small"}); err != nil {
+			t.Fatalf("runTaskCreate: %v", err)
+		}
+	})
+	body := loadAllTasks(t, dir)[0].Body
+	if what, _ := markdown.Section(body, "What"); !strings.Contains(what, "Refresh tokens silently.") {
+		t.Errorf("What not seeded: %q", what)
+	}
+	if _, ok := markdown.Section(body, "Done When"); !ok {
+		t.Errorf("expected the template's sections, got:\n%s", body)
+	}
+	if _, ok := markdown.Section(body, "Acceptance Criteria"); ok {
+		t.Errorf("templates/task.md should not be used with --template, got:\n%s", body)
+	}
+}
+
+// --- task create --from-plan -------------------------------------------------
+
+func TestTaskCreateFromPlan_CreatesOneTaskPerOpenItem(t *testing.T) {
+	dir := setupInitedProject(t)
+	p := makeSyncPlan("p1", "retro", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	p.Body = "## Action Items\n- Fix flaky login test (high)\n- [ ] @alice write the runbook\n- [x] Already done\n- Tidy logs\n"
+	writeSyncPlan(t, dir, p)
+	slug := strings.TrimSuffix(plan.FileName(p), ".md")
+
+	captureOutput(t, func() {
+		if err := runTaskCreateFromPlan(dir, slug, "low", []string{"retro"}, false); err != nil {
+			t.Fatalf("runTaskCreateFromPlan: %v", err)
+		}
+	})
+	type want struct {
+		priority task.Priority
+		assignee string
+	}
+	wants := map[string]want{
+		"Fix flaky login test": {task.PriorityHigh, ""},
+		"write the runbook":    {task.PriorityLow, "alice"},
+		"Tidy logs":            {task.PriorityLow, ""},
+	}
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != len(wants) {
+		t.Fatalf("got %d tasks, want %d", len(tasks), len(wants))
+	}
+	for _, tk := range tasks {
+		w, ok := wants[tk.Title]
+		if !ok || tk.Priority != w.priority || tk.Assignee != w.assignee || tk.Plan != slug || !slices.Contains(tk.Tags, "retro") {
+			t.Errorf("unexpected task %q: priority %q, assignee %q, plan %q, tags %v", tk.Title, tk.Priority, tk.Assignee, tk.Plan, tk.Tags)
+		}
+	}
+
+	// The items are checked off, so a second run creates nothing.
+	out := captureOutput(t, func() {
+		if err := runTaskCreateFromPlan(dir, slug, "", nil, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, "No open items") || len(loadAllTasks(t, dir)) != 3 {
+		t.Errorf("second run should create nothing, got %d tasks and output %q", len(loadAllTasks(t, dir)), out)
+	}
+}
+
+func TestTaskCreateFromPlan_LeavesFailedItemsOpen(t *testing.T) {
+	dir := setupInitedProject(t)
+	setPrivacy(t, dir, "block")
+	p := makeSyncPlan("p1", "retro", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	p.Body = "## Action Items\n- Revoke " + testAWSKey + "\n- Tidy logs\n"
+	writeSyncPlan(t, dir, p)
+
+	captureStderr(t, func() {
+		captureOutput(t, func() {
+			if err := runTaskCreateFromPlan(dir, strings.TrimSuffix(plan.FileName(p), ".md"), "", nil, false); err != nil {
+				t.Fatal(err)
+			}
+		})
+	})
+	got, err := plan.LoadFile(filepath.Join(plan.PlansDir(dir), plan.FileName(p)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "- Revoke " + testAWSKey + "\n- [x] Tidy logs"
+	if section, _ := markdown.Section(got.Body, "Action Items"); section != want {
+		t.Errorf("Action Items = %q, want %q", section, want)
+	}
+}
+
+func TestTaskCreateFromPlan_ConfiguredSection(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, _ := config.Load(dir)
+	cfg.Tasks.ActionItemsSection = "Next Steps"
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	p := makeSyncPlan("p1", "design", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC))
+	p.Body = "## Action Items\n- Ignored\n\n## Next Steps\n- Ship it\n"
+	writeSyncPlan(t, dir, p)
+
+	captureOutput(t, func() {
+		if err := runTaskCreateFromPlan(dir, strings.TrimSuffix(plan.FileName(p), ".md"), "", nil, false); err != nil {
+			t.Fatal(err)
+		}
+	})
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 || tasks[0].Title != "Ship it" {
+		t.Errorf("expected one task from Next Steps, got %d", len(tasks))
+	}
+}
+
+func TestParsePriorityMarker(t *testing.T) {
+	cases := []struct {
+		item, title string
+		priority    task.Priority
+	}{
+		{"Fix login (high)", "Fix login", task.PriorityHigh},
+		{"(LOW) tidy logs", "tidy logs", task.PriorityLow},
+		{"Rotate (medium) keys", "Rotate keys", task.PriorityMedium},
+		{"Call (urgent)", "Call (urgent)", ""},
+	}
+	for _, c := range cases {
+		title, priority := parsePriorityMarker(c.item)
+		if title != c.title || priority != c.priority {
+			t.Errorf("parsePriorityMarker(%q) = %q, %q; want %q, %q", c.item, title, priority, c.title, c.priority)
+		}
+	}
+}
@@ -3,12 +3,15 @@ package cmd
 import (
 	"bytes"
 	"encoding/json"
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
+	"slices"
 	"sort"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/senna-lang/logosyncx/internal/task"
 	"github.com/senna-lang/logosyncx/pkg/config"
@@ -54,10 +57,10 @@ func helperRebuildIndex(t *testing.T, root string) {
 func TestTaskCreate_AutoAssignsSeq(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Alpha task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Alpha task", priority: "medium"}); err != nil {
 		t.Fatalf("create first: %v", err)
 	}
-	if err := runTaskCreate(dir, testPlan, "Beta task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Beta task", priority: "medium"}); err != nil {
 		t.Fatalf("create second: %v", err)
 	}
 
@@ -76,7 +79,7 @@ func TestTaskCreate_PrintsRelativePath(t *testing.T) {
 	dir := setupInitedProject(t)
 
 	out := captureStdout(t, func() {
-		if err := runTaskCreate(dir, testPlan, "Path check", "medium", nil, nil); err != nil {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Path check", priority: "medium"}); err != nil {
 			t.Fatalf("create task: %v", err)
 		}
 	})
@@ -91,7 +94,7 @@ func TestTaskCreate_PrintsRelativePath(t *testing.T) {
 func TestTaskUpdate_Done_CreatesWalkthrough(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Walkthrough task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Walkthrough task", priority: "medium"}); err != nil {
 		t.Fatalf("create task: %v", err)
 	}
 
@@ -105,7 +108,7 @@ func TestTaskUpdate_Done_CreatesWalkthrough(t *testing.T) {
 		t.Fatalf("write WALKTHROUGH.md: %v", err)
 	}
 
-	if err := runTaskUpdate("", "walkthrough-task", "done", "", ""); err != nil {
+	if err := runTaskUpdate("", "walkthrough-task", "done", "", "", ""); err != nil {
 		t.Fatalf("update to done: %v", err)
 	}
 
@@ -117,7 +120,7 @@ func TestTaskUpdate_Done_CreatesWalkthrough(t *testing.T) {
 func TestTaskUpdate_NoFileMove(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Stable path task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Stable path task", priority: "medium"}); err != nil {
 		t.Fatalf("create task: %v", err)
 	}
 
@@ -127,7 +130,7 @@ func TestTaskUpdate_NoFileMove(t *testing.T) {
 	}
 	originalDir := tasks[0].DirPath
 
-	if err := runTaskUpdate("", "stable-path", "in_progress", "", ""); err != nil {
+	if err := runTaskUpdate("", "stable-path", "in_progress", "", "", ""); err != nil {
 		t.Fatalf("update to in_progress: %v", err)
 	}
 
@@ -140,15 +143,15 @@ func TestTaskUpdate_InProgress_BlockedByDep(t *testing.T) {
 	dir := setupInitedProject(t)
 
 	// Create task 1 (no deps) — remains open.
-	if err := runTaskCreate(dir, testPlan, "Prereq task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Prereq task", priority: "medium"}); err != nil {
 		t.Fatalf("create prereq: %v", err)
 	}
 	// Create task 2 that depends on task 1 (which is still open).
-	if err := runTaskCreate(dir, testPlan, "Dependent task", "medium", nil, []int{1}); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Dependent task", priority: "medium", dependsOn: []int{1}}); err != nil {
 		t.Fatalf("create dependent: %v", err)
 	}
 
-	err := runTaskUpdate("", "dependent-task", "in_progress", "", "")
+	err := runTaskUpdate("", "dependent-task", "in_progress", "", "", "")
 	if err == nil {
 		t.Fatal("expected error when moving blocked task to in_progress, got nil")
 	}
@@ -163,16 +166,16 @@ func TestTaskUpdate_InProgress_BlockedByDep(t *testing.T) {
 func TestTaskLS_PlanFilter(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Plan one task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Plan one task", priority: "medium"}); err != nil {
 		t.Fatalf("create plan1 task: %v", err)
 	}
-	if err := runTaskCreate(dir, testPlan2, "Plan two task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan2, title: "Plan two task", priority: "medium"}); err != nil {
 		t.Fatalf("create plan2 task: %v", err)
 	}
 	helperRebuildIndex(t, dir)
 
 	out := captureStdout(t, func() {
-		if err := runTaskLS(testPlan, "", "", "", false, false); err != nil {
+		if err := runTaskLS(taskLSOptions{plan: testPlan}); err != nil {
 			t.Fatalf("runTaskLS with plan filter: %v", err)
 		}
 	})
@@ -185,20 +188,106 @@ func TestTaskLS_PlanFilter(t *testing.T) {
 	}
 }
 
+func TestTaskLS_PlanFilter_IncludesRelatedPlans(t *testing.T) {
+	dir := setupInitedProject(t)
+
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan2, title: "Linked task", priority: "medium"}); err != nil {
+		t.Fatalf("create linked task: %v", err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan2, title: "Unlinked task", priority: "medium"}); err != nil {
+		t.Fatalf("create unlinked task: %v", err)
+	}
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	store := task.NewStore(dir, &cfg)
+	linked, err := store.Get(testPlan2, "linked-task")
+	if err != nil {
+		t.Fatalf("get linked task: %v", err)
+	}
+	if _, err := store.AddRelatedPlans(map[string][]string{linked.DirPath: {testPlan + ".md"}}); err != nil {
+		t.Fatalf("AddRelatedPlans: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{plan: testPlan}); err != nil {
+			t.Fatalf("runTaskLS: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Linked task") {
+		t.Errorf("expected task listing the plan in related_plans, got:\n%s", out)
+	}
+	if strings.Contains(out, "Unlinked task") {
+		t.Errorf("unexpected 'Unlinked task' in output, got:\n%s", out)
+	}
+}
+
+func TestTaskLS_PlanFilter_ResolvesToExactPlan(t *testing.T) {
+	dir := setupInitedProject(t)
+	date := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	writePlanFileWithBody(t, dir, makeTestPlan("auth", nil, date))
+	writePlanFileWithBody(t, dir, makeTestPlan("auth-v2", nil, date))
+
+	if err := runTaskCreate(dir, taskCreateOptions{plan: "20260301-auth", title: "Auth task", priority: "medium"}); err != nil {
+		t.Fatalf("create auth task: %v", err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: "20260301-auth-v2", title: "Auth v2 task", priority: "medium"}); err != nil {
+		t.Fatalf("create auth-v2 task: %v", err)
+	}
+	helperRebuildIndex(t, dir)
+
+	// "-auth.md" matches a single plan file; tasks of auth-v2 must not leak
+	// in through substring matching on the slug.
+	out := captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{plan: "-auth.md"}); err != nil {
+			t.Fatalf("runTaskLS: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Auth task") {
+		t.Errorf("expected 'Auth task' in output, got:\n%s", out)
+	}
+	if strings.Contains(out, "Auth v2 task") {
+		t.Errorf("unexpected 'Auth v2 task' in output, got:\n%s", out)
+	}
+}
+
+func TestTaskLS_PlanFilter_AmbiguousIsError(t *testing.T) {
+	dir := setupInitedProject(t)
+	date := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	writePlanFileWithBody(t, dir, makeTestPlan("auth", nil, date))
+	writePlanFileWithBody(t, dir, makeTestPlan("auth-v2", nil, date))
+
+	// "auth" is the exact topic of one plan, so it is not ambiguous; "aut"
+	// is only a substring of both.
+	captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{plan: "auth"}); err != nil {
+			t.Errorf("runTaskLS with an exact topic: %v", err)
+		}
+	})
+	err := runTaskLS(taskLSOptions{plan: "aut"})
+	if err == nil {
+		t.Fatal("expected error for ambiguous --plan, got nil")
+	}
+	if !strings.Contains(err.Error(), "ambiguous") {
+		t.Errorf("expected 'ambiguous' in error, got: %v", err)
+	}
+}
+
 func TestTaskLS_Blocked(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Unblocked task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Unblocked task", priority: "medium"}); err != nil {
 		t.Fatalf("create unblocked: %v", err)
 	}
-	if err := runTaskCreate(dir, testPlan, "Blocked task", "medium", nil, []int{1}); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Blocked task", priority: "medium", dependsOn: []int{1}}); err != nil {
 		t.Fatalf("create blocked: %v", err)
 	}
 	// Rebuild so Blocked field is computed in the index.
 	helperRebuildIndex(t, dir)
 
 	out := captureStdout(t, func() {
-		if err := runTaskLS("", "", "", "", false, true); err != nil {
+		if err := runTaskLS(taskLSOptions{blocked: true}); err != nil {
 			t.Fatalf("runTaskLS --blocked: %v", err)
 		}
 	})
@@ -214,13 +303,13 @@ func TestTaskLS_Blocked(t *testing.T) {
 func TestTaskLS_JSON_IncludesBlockedField(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "JSON field task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "JSON field task", priority: "medium"}); err != nil {
 		t.Fatalf("create task: %v", err)
 	}
 	helperRebuildIndex(t, dir)
 
 	out := captureStdout(t, func() {
-		if err := runTaskLS("", "", "", "", true, false); err != nil {
+		if err := runTaskLS(taskLSOptions{asJSON: true}); err != nil {
 			t.Fatalf("runTaskLS --json: %v", err)
 		}
 	})
@@ -243,21 +332,21 @@ func TestTaskRefer_Disambiguate_WithPlan(t *testing.T) {
 	dir := setupInitedProject(t)
 
 	// Create tasks with the same title stem in two different plans.
-	if err := runTaskCreate(dir, testPlan, "Shared name task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Shared name task", priority: "medium"}); err != nil {
 		t.Fatalf("create plan1 task: %v", err)
 	}
-	if err := runTaskCreate(dir, testPlan2, "Shared name task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan2, title: "Shared name task", priority: "medium"}); err != nil {
 		t.Fatalf("create plan2 task: %v", err)
 	}
 
 	// Without --plan filter: ambiguous → error.
-	err := runTaskRefer("shared-name", "", false)
+	err := runTaskRefer("shared-name", "", false, true)
 	if err == nil {
 		t.Fatal("expected ambiguity error when two tasks match without --plan filter")
 	}
 
 	// With --plan filter: resolves to exactly one.
-	err = runTaskRefer("shared-name", testPlan, false)
+	err = runTaskRefer("shared-name", testPlan, false, true)
 	if err != nil {
 		t.Errorf("expected no error with --plan filter, got: %v", err)
 	}
@@ -268,7 +357,7 @@ func TestTaskRefer_Disambiguate_WithPlan(t *testing.T) {
 func TestTaskDelete_RemovesDir(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Delete me task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Delete me task", priority: "medium"}); err != nil {
 		t.Fatalf("create task: %v", err)
 	}
 
@@ -290,7 +379,7 @@ func TestTaskDelete_RemovesDir(t *testing.T) {
 func TestTaskDelete_Force_SkipsPrompt(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Force delete task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Force delete task", priority: "medium"}); err != nil {
 		t.Fatalf("create task: %v", err)
 	}
 
@@ -307,18 +396,52 @@ func TestTaskDelete_Force_SkipsPrompt(t *testing.T) {
 
 // --- task search -------------------------------------------------------------
 
+func TestTaskSearch_NDJSON_IncludesMatchedIn(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Auth refactor", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Session cookies", priority: "medium", tags: []string{"auth"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskSearch("auth", "", "", "", "ndjson"); err != nil {
+			t.Fatalf("runTaskSearch --ndjson: %v", err)
+		}
+	})
+	matched := map[string][]string{}
+	for _, line := range strings.Split(strings.TrimSpace(out), "\n") {
+		var e struct {
+			Title     string   `json:"title"`
+			Status    string   `json:"status"`
+			MatchedIn []string `json:"matched_in"`
+		}
+		if err := json.Unmarshal([]byte(line), &e); err != nil {
+			t.Fatalf("invalid JSON line %q: %v", line, err)
+		}
+		if e.Status != "open" {
+			t.Errorf("%s: status = %q, want the task ls --json fields", e.Title, e.Status)
+		}
+		matched[e.Title] = e.MatchedIn
+	}
+	if !slices.Equal(matched["Auth refactor"], []string{"title"}) || !slices.Equal(matched["Session cookies"], []string{"tag"}) {
+		t.Errorf("matched_in = %v", matched)
+	}
+}
+
 func TestTaskSearch_PlanFilter(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Auth refactor task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Auth refactor task", priority: "medium"}); err != nil {
 		t.Fatalf("create plan1 task: %v", err)
 	}
-	if err := runTaskCreate(dir, testPlan2, "Auth review task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan2, title: "Auth review task", priority: "medium"}); err != nil {
 		t.Fatalf("create plan2 task: %v", err)
 	}
 
 	out := captureStdout(t, func() {
-		if err := runTaskSearch("auth", testPlan, "", ""); err != nil {
+		if err := runTaskSearch("auth", testPlan, "", "", ""); err != nil {
 			t.Fatalf("runTaskSearch with plan filter: %v", err)
 		}
 	})
@@ -382,7 +505,7 @@ func TestTaskWalkthrough_FillStatusDetection(t *testing.T) {
 func TestTaskWalkthrough_ListMode(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "List walk task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "List walk task", priority: "medium"}); err != nil {
 		t.Fatalf("create task: %v", err)
 	}
 
@@ -407,7 +530,7 @@ func TestTaskWalkthrough_ListMode(t *testing.T) {
 func TestTaskWalkthrough_PrintContent(t *testing.T) {
 	dir := setupInitedProject(t)
 
-	if err := runTaskCreate(dir, testPlan, "Print walk task", "medium", nil, nil); err != nil {
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Print walk task", priority: "medium"}); err != nil {
 		t.Fatalf("create task: %v", err)
 	}
 
@@ -423,7 +546,7 @@ func TestTaskWalkthrough_PrintContent(t *testing.T) {
 	}
 
 	// Mark done.
-	if err := runTaskUpdate("", "print-walk-task", "done", "", ""); err != nil {
+	if err := runTaskUpdate("", "print-walk-task", "done", "", "", ""); err != nil {
 		t.Fatalf("update to done: %v", err)
 	}
 
@@ -437,3 +560,716 @@ func TestTaskWalkthrough_PrintContent(t *testing.T) {
 		t.Errorf("expected walkthrough content in output, got:\n%s", out)
 	}
 }
+
+// --- stray task files --------------------------------------------------------
+
+func TestTaskLS_IncludeUnknown_ListsMisplacedFiles(t *testing.T) {
+	dir := setupInitedProject(t)
+	legacy := filepath.Join(dir, ".logosyncx", "tasks", "done")
+	if err := os.MkdirAll(legacy, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	content := "---\nid: t-old001\ndate: 2026-01-01T00:00:00Z\ntitle: Legacy done task\nseq: 1\nstatus: done\npriority: low\nplan: old\ntags: []\nassignee: \"\"\n---\n"
+	if err := os.WriteFile(filepath.Join(legacy, "2026-01-01_legacy.md"), []byte(content), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	helperRebuildIndex(t, dir)
+
+	hidden := captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{}); err != nil {
+			t.Fatalf("runTaskLS: %v", err)
+		}
+	})
+	if strings.Contains(hidden, "Legacy done task") {
+		t.Errorf("misplaced task should be hidden by default, got:\n%s", hidden)
+	}
+
+	shown := captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{includeUnknown: true}); err != nil {
+			t.Fatalf("runTaskLS --include-unknown: %v", err)
+		}
+	})
+	if !strings.Contains(shown, "Legacy done task") {
+		t.Errorf("expected misplaced task with --include-unknown, got:\n%s", shown)
+	}
+}
+
+func TestTaskMigrateStatus_RewritesStatus(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Review me", priority: "medium"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := runTaskMigrateStatus("open", "in_progress"); err != nil {
+		t.Fatalf("runTaskMigrateStatus: %v", err)
+	}
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 || tasks[0].Status != task.StatusInProgress {
+		t.Errorf("expected task migrated to in_progress, got %+v", tasks)
+	}
+}
+
+func TestTaskMigrateStatus_UnreadableTask_ReturnsError(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Review me", priority: "medium"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	broken := filepath.Join(dir, ".logosyncx", "tasks", testPlan, "002-broken", "TASK.md")
+	if err := os.MkdirAll(filepath.Dir(broken), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(broken, []byte("---\ntitle: [unclosed\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := runTaskMigrateStatus("open", "in_progress"); err == nil {
+		t.Error("expected an error for a task that cannot be read")
+	}
+}
+
+func TestTaskMigrateStatus_InvalidTarget_ReturnsError(t *testing.T) {
+	setupInitedProject(t)
+	if err := runTaskMigrateStatus("open", "archived"); err == nil {
+		t.Error("expected error for invalid --to status")
+	}
+}
+
+// --- task suggest-assignee ---------------------------------------------------
+
+func TestTaskSuggestAssignee_PicksLeastLoadedRosterMember(t *testing.T) {
+	dir := setupInitedProject(t)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	cfg.Tasks.Roster = []string{"alice", "bob"}
+	if err := config.Save(dir, cfg); err != nil {
+		t.Fatalf("config.Save: %v", err)
+	}
+
+	for _, title := range []string{"Alpha", "Beta", "Gamma"} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: title, priority: "medium"}); err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+	}
+	if err := runTaskUpdate("", "alpha", "", "", "alice", ""); err != nil {
+		t.Fatalf("assign alpha: %v", err)
+	}
+	if err := runTaskUpdate("", "beta", "", "", "carol", ""); err != nil {
+		t.Fatalf("assign beta: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskSuggestAssignee("", "gamma"); err != nil {
+			t.Fatalf("runTaskSuggestAssignee: %v", err)
+		}
+	})
+
+	if !strings.Contains(out, "ASSIGNEE") || !strings.Contains(out, "carol") {
+		t.Errorf("expected load table including non-roster assignee, got:\n%s", out)
+	}
+	if !strings.Contains(out, `Suggested assignee for "Gamma": bob`) {
+		t.Errorf("expected bob to be suggested, got:\n%s", out)
+	}
+}
+
+func TestTaskSuggestAssignee_NoRoster_PrintsLoadOnly(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Alpha", priority: "medium"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskSuggestAssignee("", "alpha"); err != nil {
+			t.Fatalf("runTaskSuggestAssignee: %v", err)
+		}
+	})
+
+	if strings.Contains(out, "Suggested assignee") {
+		t.Errorf("unexpected suggestion without roster, got:\n%s", out)
+	}
+	if !strings.Contains(out, "tasks.roster") {
+		t.Errorf("expected hint about tasks.roster, got:\n%s", out)
+	}
+}
+
+// --- task move / ls --sort order ---------------------------------------------
+
+func TestTaskMove_LSSortOrder(t *testing.T) {
+	dir := setupInitedProject(t)
+	for _, title := range []string{"Alpha", "Beta", "Gamma"} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: title, priority: "medium"}); err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+	}
+
+	if err := runTaskMove("", "alpha", "gamma", ""); err != nil {
+		t.Fatalf("runTaskMove --before: %v", err)
+	}
+	if err := runTaskMove("", "beta", "", "gamma"); err != nil {
+		t.Fatalf("runTaskMove --after: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{status: "open", sortBy: "order"}); err != nil {
+			t.Fatalf("runTaskLS --sort order: %v", err)
+		}
+	})
+	a, g, b := strings.Index(out, "Alpha"), strings.Index(out, "Gamma"), strings.Index(out, "Beta")
+	if a < 0 || !(a < g && g < b) {
+		t.Errorf("expected Alpha, Gamma, Beta order, got:\n%s", out)
+	}
+}
+
+func TestTaskMove_RequiresExactlyOneTarget(t *testing.T) {
+	setupInitedProject(t)
+	if err := runTaskMove("", "alpha", "", ""); err == nil {
+		t.Error("expected error with neither --before nor --after")
+	}
+	if err := runTaskMove("", "alpha", "beta", "gamma"); err == nil {
+		t.Error("expected error with both --before and --after")
+	}
+}
+
+func TestTaskLS_InvalidSort_ReturnsError(t *testing.T) {
+	setupInitedProject(t)
+	if err := runTaskLS(taskLSOptions{sortBy: "priority"}); err == nil {
+		t.Error("expected error for invalid --sort")
+	}
+}
+
+func TestTaskLS_StatusAndPriorityLists(t *testing.T) {
+	dir := setupInitedProject(t)
+	for _, tc := range []struct{ title, priority string }{
+		{"Open high", "high"}, {"Started low", "low"}, {"Finished high", "high"},
+	} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: tc.title, priority: tc.priority}); err != nil {
+			t.Fatalf("create %s: %v", tc.title, err)
+		}
+	}
+	cfg, _ := config.Load(dir)
+	store := task.NewStore(dir, &cfg)
+	if err := store.UpdateFields("", "started-low", map[string]string{"status": "in_progress"}); err != nil {
+		t.Fatal(err)
+	}
+	finished, err := store.Get("", "finished-high")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(finished.DirPath, "WALKTHROUGH.md"), []byte("Done.\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.UpdateFields("", "finished-high", map[string]string{"status": "done"}); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{status: "open, in_progress"}); err != nil {
+			t.Fatalf("runTaskLS: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Open high") || !strings.Contains(out, "Started low") || strings.Contains(out, "Finished high") {
+		t.Errorf("--status open,in_progress listed:\n%s", out)
+	}
+	out = captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{priority: "high,low", countOnly: true}); err != nil {
+			t.Fatalf("runTaskLS: %v", err)
+		}
+	})
+	if out != "1 open / 1 in_progress / 1 done\n" {
+		t.Errorf("--priority high,low counts = %q", out)
+	}
+
+	for _, args := range [][2]string{{"open,closed", ""}, {"", "urgent"}} {
+		err := runTaskLS(taskLSOptions{status: args[0], priority: args[1]})
+		if err == nil || !strings.Contains(err.Error(), "invalid") {
+			t.Errorf("runTaskLS(status %q, priority %q) = %v, want an invalid-value error", args[0], args[1], err)
+		}
+	}
+	if err := runTaskSearch("high", "", "blocked", "", ""); err == nil {
+		t.Error("runTaskSearch with an unknown status should fail")
+	}
+}
+
+// --- task ls --count-only ----------------------------------------------------
+
+func TestTaskLS_CountOnly(t *testing.T) {
+	dir := setupInitedProject(t)
+	for _, title := range []string{"First task", "Second task", "Third task"} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: title, priority: "high"}); err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+	}
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := task.NewStore(dir, &cfg).UpdateFields("", "first-task", map[string]string{"status": "in_progress"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runTaskSnooze("", "third-task", time.Now().AddDate(0, 0, 7).Format("2006-01-02"), false); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		status string
+		asJSON bool
+		all    bool
+		want   string
+	}{
+		{"", false, false, "1 open / 1 in_progress / 0 done\n"},
+		{"", false, true, "2 open / 1 in_progress / 0 done\n"},
+		{"open", false, true, "2\n"},
+	} {
+		out := captureStdout(t, func() {
+			if err := runTaskLS(taskLSOptions{status: tc.status, asJSON: tc.asJSON, all: tc.all, countOnly: true}); err != nil {
+				t.Fatalf("runTaskLS: %v", err)
+			}
+		})
+		if out != tc.want {
+			t.Errorf("status %q all %v: got %q, want %q", tc.status, tc.all, out, tc.want)
+		}
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{asJSON: true, countOnly: true}); err != nil {
+			t.Fatalf("runTaskLS: %v", err)
+		}
+	})
+	var c task.Counts
+	if err := json.Unmarshal([]byte(out), &c); err != nil {
+		t.Fatalf("invalid JSON %q: %v", out, err)
+	}
+	if c.Total != 2 || c.ByStatus[task.StatusInProgress] != 1 || c.ByPriority[task.PriorityHigh] != 2 || c.ByStatus[task.StatusDone] != 0 {
+		t.Errorf("unexpected counts: %+v", c)
+	}
+}
+
+// --- task snooze -------------------------------------------------------------
+
+func TestTaskSnooze_HidesUntilDateUnlessAll(t *testing.T) {
+	dir := setupInitedProject(t)
+	for _, title := range []string{"Now task", "Later task"} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: title, priority: "medium"}); err != nil {
+			t.Fatalf("create %s: %v", title, err)
+		}
+	}
+	future := time.Now().AddDate(0, 0, 7).Format("2006-01-02")
+	if err := runTaskSnooze("", "later-task", future, false); err != nil {
+		t.Fatalf("runTaskSnooze: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{}); err != nil {
+			t.Fatalf("runTaskLS: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Now task") || strings.Contains(out, "Later task") {
+		t.Errorf("expected only 'Now task' by default, got:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{all: true}); err != nil {
+			t.Fatalf("runTaskLS --all: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Later task") {
+		t.Errorf("expected snoozed task with --all, got:\n%s", out)
+	}
+
+	if err := runTaskSnooze("", "later-task", "", true); err != nil {
+		t.Fatalf("runTaskSnooze --clear: %v", err)
+	}
+	out = captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{}); err != nil {
+			t.Fatalf("runTaskLS: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Later task") {
+		t.Errorf("expected task visible after clearing snooze, got:\n%s", out)
+	}
+}
+
+func TestTaskSnooze_PastDateIsVisible(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Expired snooze", priority: "medium"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := runTaskSnooze("", "expired-snooze", "2020-01-01", false); err != nil {
+		t.Fatalf("runTaskSnooze: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskLS(taskLSOptions{}); err != nil {
+			t.Fatalf("runTaskLS: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Expired snooze") {
+		t.Errorf("expected task with past snooze date to be listed, got:\n%s", out)
+	}
+}
+
+func TestTaskSnooze_InvalidDate_ReturnsError(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Bad date", priority: "medium"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := runTaskSnooze("", "bad-date", "next week", false); err == nil {
+		t.Error("expected error for invalid --until date")
+	}
+}
+
+func TestTaskSnooze_RelativeDate(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Relative snooze", priority: "medium"}); err != nil {
+		t.Fatalf("create: %v", err)
+	}
+	if err := runTaskSnooze("", "relative-snooze", "3d", false); err != nil {
+		t.Fatalf("runTaskSnooze: %v", err)
+	}
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 1 || tasks[0].SnoozedUntil == nil {
+		t.Fatalf("expected one snoozed task, got %+v", tasks)
+	}
+	want := time.Now().AddDate(0, 0, 3).Format("2006-01-02")
+	if got := tasks[0].SnoozedUntil.Format("2006-01-02"); got != want {
+		t.Errorf("snoozed_until = %s, want %s", got, want)
+	}
+}
+
+func TestTaskRefer_ListsPossiblyRelatedTasks(t *testing.T) {
+	dir := setupInitedProject(t)
+
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Add login form", priority: "medium", tags: []string{"auth"}}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan2, title: "Rotate auth tokens", priority: "medium", tags: []string{"auth"}}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskRefer("login-form", "", false, true); err != nil {
+			t.Fatalf("runTaskRefer: %v", err)
+		}
+	})
+	if !strings.Contains(out, "Possibly related:") || !strings.Contains(out, "rotate-auth-tokens") {
+		t.Errorf("expected related task in output, got:\n%s", out)
+	}
+	if !strings.Contains(out, "shared tags: auth") {
+		t.Errorf("expected reason in output, got:\n%s", out)
+	}
+
+	out = captureStdout(t, func() {
+		if err := runTaskRefer("login-form", "", false, false); err != nil {
+			t.Fatalf("runTaskRefer: %v", err)
+		}
+	})
+	if strings.Contains(out, "Possibly related:") {
+		t.Errorf("--no-related should suppress the section, got:\n%s", out)
+	}
+}
+
+// --- task purge --------------------------------------------------------------
+
+func TestTaskPurge_OlderThanDryRunAndArchive(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Finished task", priority: "medium", tags: []string{"ops"}}); err != nil {
+		t.Fatalf("create task: %v", err)
+	}
+	tk := loadAllTasks(t, dir)[0]
+	if err := os.WriteFile(filepath.Join(tk.DirPath, "WALKTHROUGH.md"), []byte("## What Was Done\nAll of it.\n"), 0o644); err != nil {
+		t.Fatalf("write walkthrough: %v", err)
+	}
+	if err := runTaskUpdate("", "finished-task", "done", "", "", ""); err != nil {
+		t.Fatalf("mark done: %v", err)
+	}
+
+	// Just completed: not older than 30 days.
+	out := captureStdout(t, func() {
+		if err := runTaskPurge("done", "30d", "", "", false, true, time.Now()); err != nil {
+			t.Fatalf("runTaskPurge: %v", err)
+		}
+	})
+	if !strings.Contains(out, "No tasks to purge.") {
+		t.Errorf("expected nothing to purge, got: %s", out)
+	}
+
+	later := time.Now().AddDate(0, 0, 45)
+	out = captureStdout(t, func() {
+		if err := runTaskPurge("done", "30d", "ops", "", true, true, later); err != nil {
+			t.Fatalf("runTaskPurge --dry-run: %v", err)
+		}
+	})
+	if !strings.Contains(out, "finished-task") || !strings.Contains(out, "Dry run") {
+		t.Errorf("expected dry-run listing, got: %s", out)
+	}
+	if len(loadAllTasks(t, dir)) != 1 {
+		t.Fatal("--dry-run must not archive")
+	}
+
+	captureStdout(t, func() {
+		if err := runTaskPurge("done", "30d", "ops", "", false, true, later); err != nil {
+			t.Fatalf("runTaskPurge: %v", err)
+		}
+	})
+	if len(loadAllTasks(t, dir)) != 0 {
+		t.Error("expected the task to be archived")
+	}
+	if _, err := os.Stat(filepath.Join(task.ArchiveDir(dir), testPlan)); err != nil {
+		t.Errorf("expected archived plan group dir: %v", err)
+	}
+}
+
+func TestTaskPurge_InvalidOlderThan(t *testing.T) {
+	setupInitedProject(t)
+	if err := runTaskPurge("done", "30", "", "", false, true, time.Now()); err == nil {
+		t.Fatal("expected error for --older-than without unit")
+	}
+}
+
+// --- task import -------------------------------------------------------------
+
+func TestTaskImport_CreatesTasksFromChecklist(t *testing.T) {
+	dir := setupInitedProject(t)
+	todo := filepath.Join(dir, "TODO.md")
+	content := "# TODO\n\n- [ ] Add login form\n  - [ ] email field\n  - [ ] password field\n- [x] Set up CI\n"
+	if err := os.WriteFile(todo, []byte(content), 0o644); err != nil {
+		t.Fatalf("write TODO.md: %v", err)
+	}
+
+	captureStdout(t, func() {
+		if err := runTaskImport(dir, testPlan, "markdown", todo, []string{"imported"}, false); err != nil {
+			t.Fatalf("runTaskImport: %v", err)
+		}
+	})
+
+	tasks := loadAllTasks(t, dir)
+	if len(tasks) != 2 {
+		t.Fatalf("expected 2 tasks, got %d", len(tasks))
+	}
+	byTitle := map[string]*task.Task{}
+	for _, tk := range tasks {
+		byTitle[tk.Title] = tk
+	}
+	login, ci := byTitle["Add login form"], byTitle["Set up CI"]
+	if login == nil || ci == nil {
+		t.Fatalf("unexpected titles: %v", byTitle)
+	}
+	if login.Status != task.StatusOpen || ci.Status != task.StatusDone || ci.CompletedAt == nil {
+		t.Errorf("statuses = %s / %s, want open / done with completed_at", login.Status, ci.Status)
+	}
+	if !strings.Contains(login.Body, "## Checklist\n\n- [ ] email field\n- [ ] password field") {
+		t.Errorf("nested items not preserved:\n%s", login.Body)
+	}
+	if login.Excerpt != "Add login form" {
+		t.Errorf("Excerpt = %q, want the item text", login.Excerpt)
+	}
+	if len(login.Tags) != 1 || login.Tags[0] != "imported" {
+		t.Errorf("Tags = %v, want [imported]", login.Tags)
+	}
+	if _, err := os.Stat(filepath.Join(ci.DirPath, "WALKTHROUGH.md")); err != nil {
+		t.Errorf("done task should get a walkthrough scaffold: %v", err)
+	}
+}
+
+func TestTaskImport_DryRunAndErrors(t *testing.T) {
+	dir := setupInitedProject(t)
+	todo := filepath.Join(dir, "TODO.md")
+	if err := os.WriteFile(todo, []byte("- [ ] One\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskImport(dir, testPlan, "markdown", todo, nil, true); err != nil {
+			t.Fatalf("runTaskImport --dry-run: %v", err)
+		}
+	})
+	if !strings.Contains(out, "One [open]") || len(loadAllTasks(t, dir)) != 0 {
+		t.Errorf("dry run should list without creating, got: %s", out)
+	}
+
+	if err := runTaskImport(dir, testPlan, "csv", todo, nil, false); err == nil {
+		t.Error("expected error for unsupported --from")
+	}
+	empty := filepath.Join(dir, "EMPTY.md")
+	_ = os.WriteFile(empty, []byte("# nothing\n"), 0o644)
+	if err := runTaskImport(dir, testPlan, "markdown", empty, nil, false); err == nil {
+		t.Error("expected error when the file has no checklist items")
+	}
+}
+
+func TestPrintTaskTable_FitsTerminalWidth(t *testing.T) {
+	entries := []task.TaskJSON{{
+		Seq:    1,
+		Title:  strings.Repeat("a very long task title ", 5),
+		Status: task.StatusOpen,
+		Plan:   "20260301-a-plan-with-a-rather-long-filename-slug",
+	}}
+	t.Setenv("COLUMNS", "80")
+
+	out := captureStdout(t, func() {
+		if err := printTaskTable(entries, time.UTC); err != nil {
+			t.Fatal(err)
+		}
+	})
+	for _, l := range strings.Split(strings.TrimRight(out, "\n"), "\n") {
+		if w := displayWidth(l); w > 80 {
+			t.Errorf("line is %d columns wide, want <= 80: %q", w, l)
+		}
+	}
+
+	fullTables = true
+	t.Cleanup(func() { fullTables = false })
+	out = captureStdout(t, func() {
+		if err := printTaskTable(entries, time.UTC); err != nil {
+			t.Fatal(err)
+		}
+	})
+	if !strings.Contains(out, entries[0].Plan) {
+		t.Errorf("expected full plan name with --full, got:\n%s", out)
+	}
+}
+
+// --- logos task deps ---------------------------------------------------------
+
+func TestTaskDeps_PrintsTreeAndDependents(t *testing.T) {
+	dir := setupInitedProject(t)
+	for _, c := range []struct {
+		title string
+		deps  []int
+	}{{"Schema", nil}, {"API", []int{1}}, {"Deploy", []int{2}}} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: c.title, priority: "medium", dependsOn: c.deps}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskDeps("", "api"); err != nil {
+			t.Errorf("runTaskDeps: %v", err)
+		}
+	})
+	want := "002 API [open]\n└── 001 Schema [open]\n\nBlocks:\n  003 Deploy [open]\n"
+	if out != want {
+		t.Errorf("got:\n%s\nwant:\n%s", out, want)
+	}
+}
+
+func TestTaskUpdateFilter_UpdatesMatchingTasks(t *testing.T) {
+	dir := setupInitedProject(t)
+	for _, tc := range []struct {
+		plan, title string
+		tags        []string
+	}{
+		{testPlan, "Login form", []string{"auth"}},
+		{testPlan2, "Token refresh", []string{"auth"}},
+		{testPlan, "Billing page", []string{"billing"}},
+	} {
+		if err := runTaskCreate(dir, taskCreateOptions{plan: tc.plan, title: tc.title, priority: "low", tags: tc.tags}); err != nil {
+			t.Fatalf("create %s: %v", tc.title, err)
+		}
+	}
+
+	out := captureStdout(t, func() {
+		if err := runTaskUpdateFilter(taskUpdateOptions{statusFilter: "open", tagFilter: "auth", priority: "high", dryRun: true}); err != nil {
+			t.Fatalf("runTaskUpdateFilter --dry-run: %v", err)
+		}
+	})
+	if !strings.Contains(out, "2 task(s) selected") || !strings.Contains(out, "Dry run") {
+		t.Errorf("dry run output:\n%s", out)
+	}
+	for _, tk := range loadAllTasks(t, dir) {
+		if tk.Priority != task.PriorityLow {
+			t.Fatalf("dry run changed %s to %s", tk.Title, tk.Priority)
+		}
+	}
+
+	captureOutput(t, func() {
+		if err := runTaskUpdateFilter(taskUpdateOptions{statusFilter: "open", tagFilter: "auth", priority: "high", force: true}); err != nil {
+			t.Fatalf("runTaskUpdateFilter: %v", err)
+		}
+	})
+	for _, tk := range loadAllTasks(t, dir) {
+		want := task.PriorityHigh
+		if tk.Title == "Billing page" {
+			want = task.PriorityLow
+		}
+		if tk.Priority != want {
+			t.Errorf("%s priority = %s, want %s", tk.Title, tk.Priority, want)
+		}
+	}
+	entries, _ := task.ReadAllTaskIndex(dir)
+	for _, e := range entries {
+		if e.Title == "Token refresh" && e.Priority != task.PriorityHigh {
+			t.Errorf("task index was not rebuilt: %+v", e)
+		}
+	}
+
+	if err := runTaskUpdateFilter(taskUpdateOptions{statusFilter: "opened", priority: "high", force: true}); err == nil || !strings.Contains(err.Error(), "--status-filter") {
+		t.Errorf("unknown --status-filter = %v, want an error", err)
+	}
+}
+
+func TestTaskUpdateFilter_PlanIgnoresRelatedPlans(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Own task", priority: "low"}); err != nil {
+		t.Fatalf("create own task: %v", err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan2, title: "Linked task", priority: "low"}); err != nil {
+		t.Fatalf("create linked task: %v", err)
+	}
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatalf("config.Load: %v", err)
+	}
+	store := task.NewStore(dir, &cfg)
+	linked, err := store.Get(testPlan2, "linked-task")
+	if err != nil {
+		t.Fatalf("get linked task: %v", err)
+	}
+	if _, err := store.AddRelatedPlans(map[string][]string{linked.DirPath: {testPlan + ".md"}}); err != nil {
+		t.Fatalf("AddRelatedPlans: %v", err)
+	}
+
+	captureOutput(t, func() {
+		if err := runTaskUpdateFilter(taskUpdateOptions{plan: testPlan, priority: "high", force: true}); err != nil {
+			t.Fatalf("runTaskUpdateFilter: %v", err)
+		}
+	})
+	for _, tk := range loadAllTasks(t, dir) {
+		want := task.PriorityHigh
+		if tk.Title == "Linked task" {
+			want = task.PriorityLow
+		}
+		if tk.Priority != want {
+			t.Errorf("%s priority = %s, want %s", tk.Title, tk.Priority, want)
+		}
+	}
+}
+
+func TestApplySetFlags(t *testing.T) {
+	var status, priority, assignee, due string
+	values := map[string]*string{"status": &status, "priority": &priority, "assignee": &assignee, "due": &due}
+	if err := applySetFlags([]string{"priority=high", "assignee = alice"}, values); err != nil {
+		t.Fatalf("applySetFlags: %v", err)
+	}
+	if priority != "high" || assignee != "alice" || status != "" {
+		t.Errorf("values = status %q priority %q assignee %q", status, priority, assignee)
+	}
+	for _, sets := range [][]string{{"priority"}, {"title=x"}, {"priority=low"}} {
+		if err := applySetFlags(sets, values); err == nil {
+			t.Errorf("applySetFlags(%v) should fail", sets)
+		}
+	}
+}
+
+func TestTaskUpdate_DoneBlockedByDep_SuggestsDeps(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Schema", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "API", priority: "medium", dependsOn: []int{1}}); err != nil {
+		t.Fatal(err)
+	}
+	err := runTaskUpdate("", "api", "done", "", "", "")
+	if !errors.Is(err, task.ErrBlocked) || !strings.Contains(err.Error(), "logos task deps") {
+		t.Errorf("expected ErrBlocked with a deps hint, got %v", err)
+	}
+}
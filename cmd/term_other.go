@@ -0,0 +1,11 @@
+//go:build !unix
+
+package cmd
+
+import "os"
+
+// ttySize returns zeros: terminal size detection is only implemented for
+// Unix. Set $COLUMNS to control table width on other platforms.
+func ttySize(f *os.File) (width, height int) {
+	return 0, 0
+}
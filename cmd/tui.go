@@ -0,0 +1,510 @@
+package cmd
+
+import (
+	"bufio"
+	"cmp"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/senna-lang/logosyncx/pkg/plan"
+	"github.com/spf13/cobra"
+)
+
+var tuiCmd = &cobra.Command{
+	Use:   "tui",
+	Short: "Browse plans and tasks in an interactive terminal UI",
+	Long: `Open a full-screen browser with a plans pane and a tasks pane. The
+highlighted item is previewed on the right: its metadata and the
+summary sections configured in plans.summary_sections or
+tasks.summary_sections.
+
+Keys:
+  j/k, ↑/↓   move              Tab       switch pane
+  /          fuzzy filter      Esc       clear filter or plan scope
+  Enter      on a plan: show only its tasks
+  o / i / d  set task status to open / in_progress / done
+  e          open the file in $VISUAL or $EDITOR (default vi)
+  r          reload            q, Ctrl-C quit
+
+Requires an interactive terminal on Linux or macOS/BSD.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTUI()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(tuiCmd)
+}
+
+// tuiPane identifies one of the two lists in logos tui.
+type tuiPane int
+
+const (
+	tuiPlans tuiPane = iota
+	tuiTasks
+)
+
+// tuiItem is one row of a logos tui list.
+type tuiItem struct {
+	title   string
+	meta    string // short right-hand detail shown in the list
+	path    string // file opened by the e key
+	preview []string
+	// planSlug is the plan's filename without .md for plans, and the owning
+	// plan for tasks.
+	planSlug string
+	// taskName is the task directory name; empty for plans.
+	taskName string
+}
+
+// tuiActionKind is what runTUI must do after a key press.
+type tuiActionKind int
+
+const (
+	tuiNone tuiActionKind = iota
+	tuiQuit
+	tuiEdit
+	tuiSetStatus
+	tuiReload
+)
+
+// tuiAction is the result of tuiModel.handleKey.
+type tuiAction struct {
+	kind   tuiActionKind
+	item   tuiItem
+	status task.Status
+}
+
+// tuiModel is the state of logos tui. It performs no I/O, so key handling
+// and rendering can be tested without a terminal.
+type tuiModel struct {
+	plans, tasks []tuiItem
+	pane         tuiPane
+	cursor       [2]int
+	filter       string
+	filtering    bool
+	// planScope limits the tasks pane to one plan's tasks (set with Enter
+	// on a plan).
+	planScope string
+	message   string
+	width     int
+	height    int
+}
+
+// visible returns the items of the active pane that pass the filter and,
+// for tasks, the plan scope.
+func (m *tuiModel) visible() []tuiItem {
+	items := m.plans
+	if m.pane == tuiTasks {
+		items = m.tasks
+	}
+	var out []tuiItem
+	for _, it := range items {
+		if m.pane == tuiTasks && m.planScope != "" && it.planSlug != m.planScope {
+			continue
+		}
+		if !fuzzyMatch(m.filter, it.title+" "+it.meta) {
+			continue
+		}
+		out = append(out, it)
+	}
+	return out
+}
+
+// selected returns the highlighted item of the active pane.
+func (m *tuiModel) selected() (tuiItem, bool) {
+	items := m.visible()
+	m.clampCursor(len(items))
+	if len(items) == 0 {
+		return tuiItem{}, false
+	}
+	return items[m.cursor[m.pane]], true
+}
+
+func (m *tuiModel) clampCursor(n int) {
+	c := &m.cursor[m.pane]
+	*c = max(0, min(*c, n-1))
+}
+
+// handleKey applies one key (as returned by readKey) and reports what the
+// caller has to do.
+func (m *tuiModel) handleKey(key string) tuiAction {
+	m.message = ""
+	switch key {
+	case "ctrl-c":
+		return tuiAction{kind: tuiQuit}
+	case "up":
+		m.cursor[m.pane]--
+		m.clampCursor(len(m.visible()))
+		return tuiAction{}
+	case "down":
+		m.cursor[m.pane]++
+		m.clampCursor(len(m.visible()))
+		return tuiAction{}
+	case "tab":
+		m.pane = 1 - m.pane
+		m.clampCursor(len(m.visible()))
+		return tuiAction{}
+	}
+
+	if m.filtering {
+		switch key {
+		case "enter":
+			m.filtering = false
+		case "esc":
+			m.filtering, m.filter = false, ""
+		case "backspace":
+			if m.filter != "" {
+				_, size := utf8.DecodeLastRuneInString(m.filter)
+				m.filter = m.filter[:len(m.filter)-size]
+			}
+		default:
+			if utf8.RuneCountInString(key) == 1 {
+				m.filter += key
+			}
+		}
+		m.cursor[m.pane] = 0
+		return tuiAction{}
+	}
+
+	switch key {
+	case "q":
+		return tuiAction{kind: tuiQuit}
+	case "k":
+		return m.handleKey("up")
+	case "j":
+		return m.handleKey("down")
+	case "/":
+		m.filtering = true
+	case "esc":
+		if m.filter != "" {
+			m.filter = ""
+		} else {
+			m.planScope = ""
+		}
+		m.cursor[m.pane] = 0
+	case "r":
+		return tuiAction{kind: tuiReload}
+	case "enter":
+		if it, ok := m.selected(); ok && m.pane == tuiPlans {
+			m.planScope, m.filter, m.pane = it.planSlug, "", tuiTasks
+			m.cursor[tuiTasks] = 0
+		}
+	case "e":
+		if it, ok := m.selected(); ok {
+			return tuiAction{kind: tuiEdit, item: it}
+		}
+	case "o", "i", "d":
+		it, ok := m.selected()
+		if !ok || m.pane != tuiTasks {
+			m.message = "select a task to change its status"
+			return tuiAction{}
+		}
+		status := map[string]task.Status{"o": task.StatusOpen, "i": task.StatusInProgress, "d": task.StatusDone}[key]
+		return tuiAction{kind: tuiSetStatus, item: it, status: status}
+	}
+	return tuiAction{}
+}
+
+// render returns one full screen. Lines end in "\r\n" because raw mode
+// turns off the terminal's newline translation.
+func (m *tuiModel) render() string {
+	width, height := max(m.width, 40), max(m.height, 6)
+	items := m.visible()
+	m.clampCursor(len(items))
+
+	var lines []string
+	tab := func(p tuiPane, name string, n int) string {
+		if p == m.pane {
+			return fmt.Sprintf("[%s %d]", name, n)
+		}
+		return fmt.Sprintf(" %s %d ", name, n)
+	}
+	header := tab(tuiPlans, "Plans", len(m.plans)) + " " + tab(tuiTasks, "Tasks", len(m.tasks))
+	if m.planScope != "" {
+		header += "  plan: " + m.planScope
+	}
+	lines = append(lines, truncateWidth(header, width))
+
+	listWidth := width * 2 / 5
+	previewWidth := width - listWidth - 3
+	rows := height - 2
+	var preview []string
+	if it, ok := m.selected(); ok {
+		for _, l := range it.preview {
+			preview = append(preview, wrapWidth(l, previewWidth)...)
+		}
+	}
+	offset := max(0, m.cursor[m.pane]-rows+1)
+	for r := 0; r < rows; r++ {
+		left := ""
+		if i := offset + r; i < len(items) {
+			mark := "  "
+			if i == m.cursor[m.pane] {
+				mark = "> "
+			}
+			left = mark + items[i].title
+			if items[i].meta != "" {
+				left += "  " + items[i].meta
+			}
+		} else if r == 0 {
+			left = "  (nothing to show)"
+		}
+		left = truncateWidth(left, listWidth)
+		left += strings.Repeat(" ", listWidth-displayWidth(left))
+		right := ""
+		if r < len(preview) {
+			right = truncateWidth(preview[r], previewWidth)
+		}
+		lines = append(lines, strings.TrimRight(left+" │ "+right, " "))
+	}
+
+	footer := "j/k move · Tab pane · / filter · Enter scope · o/i/d status · e edit · r reload · q quit"
+	switch {
+	case m.filtering:
+		footer = "/" + m.filter
+	case m.message != "":
+		footer = m.message
+	case m.filter != "":
+		footer = "filter: " + m.filter + "  (Esc clears) · " + footer
+	}
+	lines = append(lines, truncateWidth(footer, width))
+	return strings.Join(lines, "\r\n")
+}
+
+// fuzzyMatch reports whether the runes of pattern occur in s in order,
+// ignoring case. An empty pattern matches everything.
+func fuzzyMatch(pattern, s string) bool {
+	rest := []rune(strings.ToLower(pattern))
+	for _, r := range strings.ToLower(s) {
+		if len(rest) == 0 {
+			break
+		}
+		if r == rest[0] {
+			rest = rest[1:]
+		}
+	}
+	return len(rest) == 0
+}
+
+// readKey reads one key press from a terminal in raw mode. Special keys are
+// returned as names ("up", "down", "tab", "enter", "esc", "backspace",
+// "ctrl-c"); printable keys as the character itself. Unknown escape
+// sequences and control characters yield "".
+func readKey(r *bufio.Reader) (string, error) {
+	c, _, err := r.ReadRune()
+	if err != nil {
+		return "", err
+	}
+	switch c {
+	case 3:
+		return "ctrl-c", nil
+	case '\t':
+		return "tab", nil
+	case '\r', '\n':
+		return "enter", nil
+	case 127, 8:
+		return "backspace", nil
+	case 27:
+		// A lone Esc has nothing buffered behind it; arrow keys arrive as
+		// ESC [ A or ESC O A in one read.
+		if r.Buffered() == 0 {
+			return "esc", nil
+		}
+		b, _ := r.ReadByte()
+		if b != '[' && b != 'O' {
+			return "", nil
+		}
+		b, _ = r.ReadByte()
+		switch b {
+		case 'A':
+			return "up", nil
+		case 'B':
+			return "down", nil
+		}
+		// Skip the rest of a longer sequence such as ESC [ 3 ~.
+		for b >= '0' && b <= '9' || b == ';' {
+			if b, err = r.ReadByte(); err != nil {
+				break
+			}
+		}
+		return "", nil
+	}
+	if unicode.IsControl(c) {
+		return "", nil
+	}
+	return string(c), nil
+}
+
+// loadTUIItems reads all plans (newest first) and tasks of the project.
+func loadTUIItems(root string, cfg config.Config, store *task.Store) (plans, tasks []tuiItem, err error) {
+	ps, err := plan.LoadAllWithOptions(root, planParseOptions(cfg))
+	if err != nil {
+		return nil, nil, err
+	}
+	slices.SortFunc(ps, func(a, b plan.Plan) int {
+		return cmp.Or(planTime(b).Compare(planTime(a)), strings.Compare(a.Filename, b.Filename))
+	})
+	for _, p := range ps {
+		date := ""
+		if p.Date != nil {
+			date = p.Date.Format("2006-01-02")
+		}
+		preview := []string{p.Topic, "", "date: " + dashIfEmpty(date), "tags: " + dashIfEmpty(strings.Join(p.Tags, ", "))}
+		preview = append(preview, previewSections(plan.ExtractSections(p.Body, cfg.Plans.SummarySections))...)
+		plans = append(plans, tuiItem{
+			title:    p.Topic,
+			meta:     date,
+			path:     filepath.Join(plan.PlansDir(root), p.Filename),
+			preview:  preview,
+			planSlug: strings.TrimSuffix(p.Filename, ".md"),
+		})
+	}
+
+	ts, err := store.List(task.Filter{})
+	if err != nil {
+		return nil, nil, err
+	}
+	for _, t := range ts {
+		preview := []string{
+			t.Title, "",
+			"status: " + string(t.Status) + "   priority: " + string(t.Priority),
+			"plan: " + dashIfEmpty(t.Plan),
+		}
+		preview = append(preview, previewSections(task.ExtractSections(t.Body, cfg.Tasks.SummarySections))...)
+		tasks = append(tasks, tuiItem{
+			title:    t.Title,
+			meta:     string(t.Status),
+			path:     filepath.Join(t.DirPath, "TASK.md"),
+			preview:  preview,
+			planSlug: t.Plan,
+			taskName: filepath.Base(t.DirPath),
+		})
+	}
+	return plans, tasks, nil
+}
+
+// planTime returns p's date, or the zero time when it has none.
+func planTime(p plan.Plan) time.Time {
+	if p.Date != nil {
+		return *p.Date
+	}
+	return time.Time{}
+}
+
+// previewSections splits extracted summary sections into preview lines,
+// preceded by a blank line when there is anything to show.
+func previewSections(s string) []string {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return nil
+	}
+	return append([]string{""}, strings.Split(s, "\n")...)
+}
+
+// editorCommand returns the command opening path in $VISUAL, $EDITOR, or
+// vi. The variables may hold arguments, e.g. "code --wait".
+func editorCommand(path string) *exec.Cmd {
+	editor := cmp.Or(os.Getenv("VISUAL"), os.Getenv("EDITOR"), "vi")
+	fields := strings.Fields(editor)
+	c := exec.Command(fields[0], append(fields[1:], path)...)
+	c.Stdin, c.Stdout, c.Stderr = os.Stdin, os.Stdout, os.Stderr
+	return c
+}
+
+const (
+	tuiEnterScreen = "\x1b[?1049h\x1b[?25l"
+	tuiLeaveScreen = "\x1b[?25h\x1b[?1049l"
+	tuiClear       = "\x1b[H\x1b[2J"
+)
+
+func runTUI() error {
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+
+	if w, _ := ttySize(os.Stdout); w == 0 {
+		return errors.New("logos tui needs an interactive terminal")
+	}
+	m := &tuiModel{}
+	if m.plans, m.tasks, err = loadTUIItems(root, cfg, store); err != nil {
+		return err
+	}
+
+	restore, err := makeRaw(os.Stdin)
+	if err != nil {
+		return fmt.Errorf("enter raw mode: %w", err)
+	}
+	fmt.Print(tuiEnterScreen)
+	defer func() {
+		fmt.Print(tuiLeaveScreen)
+		restore()
+	}()
+
+	in := bufio.NewReader(os.Stdin)
+	for {
+		m.width, m.height = ttySize(os.Stdout)
+		fmt.Print(tuiClear + m.render())
+		key, err := readKey(in)
+		if err != nil {
+			return err
+		}
+		act := m.handleKey(key)
+		switch act.kind {
+		case tuiQuit:
+			return nil
+		case tuiReload:
+			m.message = "reloaded"
+		case tuiSetStatus:
+			var from task.Status
+			dir := filepath.Dir(act.item.path)
+			if t, err := store.Get(act.item.planSlug, act.item.taskName); err == nil {
+				from, dir = t.Status, t.DirPath
+			}
+			if err := store.UpdateFields(act.item.planSlug, act.item.taskName, map[string]string{"status": string(act.status)}); err != nil {
+				m.message = "error: " + err.Error()
+				continue
+			}
+			commitTaskUpdate(root, cfg, act.item.title, act.item.planSlug, from, act.status, dir)
+			m.message = fmt.Sprintf("%s → %s", act.item.title, act.status)
+		case tuiEdit:
+			fmt.Print(tuiLeaveScreen)
+			restore()
+			editErr := editorCommand(act.item.path).Run()
+			raw, err := makeRaw(os.Stdin)
+			if err != nil {
+				return fmt.Errorf("enter raw mode: %w", err)
+			}
+			restore = raw
+			fmt.Print(tuiEnterScreen)
+			if editErr != nil {
+				m.message = "editor: " + editErr.Error()
+			}
+		default:
+			continue
+		}
+		msg := m.message
+		if m.plans, m.tasks, err = loadTUIItems(root, cfg, store); err != nil {
+			return err
+		}
+		m.message = msg
+	}
+}
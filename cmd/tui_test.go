@@ -0,0 +1,144 @@
+package cmd
+
+import (
+	"bufio"
+	"strings"
+	"testing"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+func newTestTUIModel() *tuiModel {
+	return &tuiModel{
+		plans: []tuiItem{
+			{title: "Auth design", planSlug: "20260115-auth", preview: []string{"Auth design", "", "Why we need auth."}},
+			{title: "Cache layer", planSlug: "20260110-cache"},
+		},
+		tasks: []tuiItem{
+			{title: "Add JWT middleware", meta: "open", planSlug: "20260115-auth", taskName: "001-add-jwt-middleware"},
+			{title: "Warm the cache", meta: "open", planSlug: "20260110-cache", taskName: "001-warm-the-cache"},
+		},
+		width:  80,
+		height: 10,
+	}
+}
+
+func TestFuzzyMatch(t *testing.T) {
+	for _, tc := range []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"", "anything", true},
+		{"jwt", "Add JWT middleware", true},
+		{"adm", "Add JWT middleware", true},
+		{"wjt", "Add JWT middleware", false},
+	} {
+		if got := fuzzyMatch(tc.pattern, tc.s); got != tc.want {
+			t.Errorf("fuzzyMatch(%q, %q) = %v, want %v", tc.pattern, tc.s, got, tc.want)
+		}
+	}
+}
+
+func TestTUIModel_FilterAndMove(t *testing.T) {
+	m := newTestTUIModel()
+	for _, k := range []string{"/", "c", "a", "c", "enter"} {
+		m.handleKey(k)
+	}
+	if got := m.visible(); len(got) != 1 || got[0].title != "Cache layer" {
+		t.Fatalf("filter cac: got %+v", got)
+	}
+	m.handleKey("esc")
+	m.handleKey("j")
+	if it, _ := m.selected(); it.title != "Cache layer" {
+		t.Errorf("after j: selected %q", it.title)
+	}
+	m.handleKey("j")
+	if it, _ := m.selected(); it.title != "Cache layer" {
+		t.Errorf("cursor should stop at the last item, selected %q", it.title)
+	}
+}
+
+func TestTUIModel_EnterScopesTasks(t *testing.T) {
+	m := newTestTUIModel()
+	m.handleKey("enter")
+	if m.pane != tuiTasks || m.planScope != "20260115-auth" {
+		t.Fatalf("pane %v scope %q", m.pane, m.planScope)
+	}
+	if got := m.visible(); len(got) != 1 || got[0].title != "Add JWT middleware" {
+		t.Errorf("scoped tasks: %+v", got)
+	}
+	m.handleKey("esc")
+	if len(m.visible()) != 2 {
+		t.Errorf("Esc should clear the plan scope")
+	}
+}
+
+func TestTUIModel_Actions(t *testing.T) {
+	m := newTestTUIModel()
+	if act := m.handleKey("d"); act.kind != tuiNone || m.message == "" {
+		t.Errorf("d on a plan: %+v, message %q", act, m.message)
+	}
+	m.handleKey("tab")
+	act := m.handleKey("i")
+	if act.kind != tuiSetStatus || act.status != task.StatusInProgress || act.item.taskName != "001-add-jwt-middleware" {
+		t.Errorf("i on a task: %+v", act)
+	}
+	if act := m.handleKey("e"); act.kind != tuiEdit {
+		t.Errorf("e: %+v", act)
+	}
+	if act := m.handleKey("q"); act.kind != tuiQuit {
+		t.Errorf("q: %+v", act)
+	}
+}
+
+func TestTUIModel_Render(t *testing.T) {
+	m := newTestTUIModel()
+	out := m.render()
+	lines := strings.Split(out, "\r\n")
+	if len(lines) != m.height {
+		t.Fatalf("got %d lines, want %d:\n%s", len(lines), m.height, out)
+	}
+	for _, want := range []string{"[Plans 2]", "> Auth design", "Why we need auth."} {
+		if !strings.Contains(out, want) {
+			t.Errorf("render missing %q:\n%s", want, out)
+		}
+	}
+	for _, l := range lines {
+		if displayWidth(l) > m.width {
+			t.Errorf("line wider than %d: %q", m.width, l)
+		}
+	}
+}
+
+func TestReadKey(t *testing.T) {
+	in := bufio.NewReader(strings.NewReader("j\x1b[A\x1b[B\t\r\x7f\x03é\x1b[3~x"))
+	want := []string{"j", "up", "down", "tab", "enter", "backspace", "ctrl-c", "é", "", "x"}
+	for i, w := range want {
+		got, err := readKey(in)
+		if err != nil {
+			t.Fatalf("key %d: %v", i, err)
+		}
+		if got != w {
+			t.Errorf("key %d = %q, want %q", i, got, w)
+		}
+	}
+}
+
+func TestLoadTUIItems(t *testing.T) {
+	dir, slug := setupExportProject(t)
+	cfg, err := config.Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	plans, tasks, err := loadTUIItems(dir, cfg, task.NewStore(dir, &cfg))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(plans) != 1 || plans[0].planSlug != slug || !strings.Contains(strings.Join(plans[0].preview, "\n"), "Why we need auth.") {
+		t.Errorf("plans: %+v", plans)
+	}
+	if len(tasks) != 1 || tasks[0].planSlug != slug || !strings.HasSuffix(tasks[0].path, "TASK.md") || tasks[0].taskName == "" {
+		t.Errorf("tasks: %+v", tasks)
+	}
+}
@@ -21,7 +21,8 @@ var updateCmd = &cobra.Command{
 	Long: `Check for a newer version of logos on GitHub Releases and install it.
 
 By default, logos update downloads and installs the latest release,
-atomically replacing the current binary.
+atomically replacing the current binary, then prints the release notes for
+every version between the old and the new one (see logos changelog).
 
 Use --check to only report whether an update is available without installing.
 
@@ -96,6 +97,8 @@ func runUpdate(cmd *cobra.Command, args []string) error {
 	fmt.Printf("Updated logos to %s\n", latest)
 	fmt.Println("Run 'logos version' to confirm.")
 
+	printUpdateReleaseNotes(current, latest)
+
 	// Invalidate the local update-check cache so that the next invocation
 	// does not immediately show an (already resolved) update hint.
 	_ = clearUpdateCache()
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/project"
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/config"
+	"github.com/spf13/cobra"
+)
+
+var watchCmd = &cobra.Command{
+	Use:   "watch",
+	Short: "Keep the plan and task indexes in sync while files change",
+	Long: `Run in the foreground and rebuild index.jsonl and task-index.jsonl whenever
+files under .logosyncx/plans/ or .logosyncx/tasks/ change, so manual edits
+never need a manual logos sync.
+
+Both indexes are rebuilt once at startup. After that the directories are
+polled every --interval; only the index whose directory changed is
+rebuilt, once the changes have settled for one interval (so a burst of
+edits causes a single rebuild). Stop with Ctrl-C.
+
+When tasks.escalation is set in config.json, due tasks are escalated as in
+logos sync at startup and then every hour.
+
+Jobs in watch.jobs run logos commands on a cron schedule, evaluated in
+display.timezone — for example a nightly sync --check, or a weekly
+gc --dry-run whose report is written to a file:
+
+  "watch": {"jobs": [
+    {"name": "nightly-check", "schedule": "0 3 * * *", "command": "sync --check"},
+    {"name": "weekly-gc", "schedule": "0 9 * * 1", "command": "gc --dry-run",
+     "output": ".logosyncx/reports/gc.txt"}
+  ]}
+
+Each job runs as a separate logos process, one at a time, first at the
+next matching minute after startup. Minutes missed while a job ran or the
+machine slept are caught up: their due jobs run at the next check. Its
+output goes to the output file (replaced on every run) or is printed by
+logos watch.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		interval, _ := cmd.Flags().GetDuration("interval")
+		ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+		defer stop()
+		return runWatch(ctx, interval)
+	},
+}
+
+func init() {
+	watchCmd.Flags().Duration("interval", time.Second, "How often to check for changes")
+	rootCmd.AddCommand(watchCmd)
+}
+
+// fileStamp is what logos watch compares to detect a changed file.
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// runWatch rebuilds the indexes at startup and then whenever their source
+// directories change, until ctx is done.
+func runWatch(ctx context.Context, interval time.Duration) error {
+	if interval <= 0 {
+		return errors.New("--interval must be positive")
+	}
+	root, err := project.FindRoot()
+	if err != nil {
+		return err
+	}
+	cfg, err := loadConfig(root)
+	if err != nil {
+		return fmt.Errorf("load config: %w", err)
+	}
+	store := task.NewStore(root, &cfg)
+	targets := syncTargets(root, cfg, store)
+	jobs, err := loadWatchJobs(cfg)
+	if err != nil {
+		return err
+	}
+	loc := displayLocation(cfg)
+	watchEscalate(cfg, store)
+	lastEscalation := time.Now()
+	lastMinute := time.Now().In(loc).Truncate(time.Minute)
+
+	last := make([]map[string]fileStamp, len(targets))
+	pending := make([]bool, len(targets))
+	for i, t := range targets {
+		last[i] = snapshotDir(filepath.Join(root, config.DirName, t.source))
+		watchRebuild(t, "startup")
+	}
+	fmt.Printf("Watching .logosyncx/plans/ and .logosyncx/tasks/ every %s — press Ctrl-C to stop.\n", interval)
+	if len(jobs) > 0 {
+		fmt.Printf("%d scheduled job(s) from watch.jobs.\n", len(jobs))
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+		if time.Since(lastEscalation) >= escalateInterval {
+			// Escalated TASK.md files are picked up as changes below.
+			watchEscalate(cfg, store)
+			lastEscalation = time.Now()
+		}
+		lastMinute = runJobsSince(root, jobs, lastMinute, time.Now().In(loc))
+		for i, t := range targets {
+			cur := snapshotDir(filepath.Join(root, config.DirName, t.source))
+			if !maps.Equal(cur, last[i]) {
+				last[i], pending[i] = cur, true
+				continue
+			}
+			if pending[i] {
+				pending[i] = false
+				watchRebuild(t, "change")
+			}
+		}
+	}
+}
+
+// watchRebuild rebuilds t's index and prints one line about it. Errors are
+// reported as warnings so that watching continues.
+func watchRebuild(t syncTarget, reason string) {
+	n, err := t.rebuild()
+	if err != nil {
+		warnf("%s: %v", t.name, err)
+	}
+	fmt.Printf("%s  %s index rebuilt (%d %s, %s)\n", time.Now().Format("15:04:05"), t.name, n, t.noun, reason)
+}
+
+// watchEscalate applies tasks.escalation and prints one line when any task
+// was escalated.
+func watchEscalate(cfg config.Config, store *task.Store) {
+	if n := len(escalateDueTasks(cfg, store, time.Now())); n > 0 {
+		fmt.Printf("%s  %s\n", time.Now().Format("15:04:05"), escalationSummary(cfg, n))
+	}
+}
+
+// snapshotDir returns the modification time and size of every Markdown
+// file under dir. A missing dir yields an empty snapshot.
+func snapshotDir(dir string) map[string]fileStamp {
+	out := map[string]fileStamp{}
+	_ = filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			out[path] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+		}
+		return nil
+	})
+	return out
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/task"
+	"github.com/senna-lang/logosyncx/pkg/index"
+)
+
+// waitFor polls cond until it holds or the deadline passes.
+func waitFor(t *testing.T, what string, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(3 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timed out waiting for %s", what)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestWatch_RebuildsIndexesOnChange(t *testing.T) {
+	dir := setupInitedProject(t)
+	if err := runTaskCreate(dir, taskCreateOptions{plan: testPlan, title: "Watched task", priority: "medium"}); err != nil {
+		t.Fatal(err)
+	}
+	// Empty the task index so that only logos watch can fill it again.
+	if err := os.WriteFile(task.TaskIndexFilePath(dir), nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	out := captureOutput(t, func() {
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() { done <- runWatch(ctx, 10*time.Millisecond) }()
+
+		waitFor(t, "the startup rebuild", func() bool {
+			entries, _ := task.ReadAllTaskIndex(dir)
+			return len(entries) == 1
+		})
+
+		writePlanFileWithBody(t, dir, makeTestPlan("watched", nil, time.Now()))
+		waitFor(t, "the plan index", func() bool {
+			entries, _ := index.ReadAll(dir)
+			return len(entries) == 1 && entries[0].Topic == "watched"
+		})
+
+		if err := os.WriteFile(task.TaskIndexFilePath(dir), nil, 0o644); err != nil {
+			t.Fatal(err)
+		}
+		taskFile := filepath.Join(dir, ".logosyncx", "tasks", testPlan, "001-watched-task", "TASK.md")
+		f, err := os.OpenFile(taskFile, os.O_APPEND|os.O_WRONLY, 0o644)
+		if err != nil {
+			t.Fatal(err)
+		}
+		_, _ = f.WriteString("\n## Notes\n")
+		f.Close()
+		waitFor(t, "the task index", func() bool {
+			entries, _ := task.ReadAllTaskIndex(dir)
+			return len(entries) == 1
+		})
+
+		cancel()
+		if err := <-done; err != nil {
+			t.Errorf("runWatch: %v", err)
+		}
+	})
+	if !strings.Contains(out, "startup") || !strings.Contains(out, "change") {
+		t.Errorf("expected startup and change rebuild lines, got:\n%s", out)
+	}
+}
+
+func TestWatch_InvalidInterval(t *testing.T) {
+	setupInitedProject(t)
+	if err := runWatch(context.Background(), 0); err == nil {
+		t.Error("expected an error for a zero interval")
+	}
+}
@@ -0,0 +1,70 @@
+// Package ack records which teammates have acknowledged (read and signed
+// off on) a plan. Acknowledgments live in one sidecar file per plan,
+// .logosyncx/acks/<plan-slug>.jsonl, so that acks of different plans never
+// touch the same file and the plan itself is left unchanged.
+package ack
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/jsonl"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// Ack is one user's acknowledgment of a plan.
+type Ack struct {
+	User string    `json:"user"`
+	At   time.Time `json:"at"`
+}
+
+// Dir returns the directory holding the acknowledgment files.
+func Dir(projectRoot string) string {
+	return filepath.Join(projectRoot, config.DirName, "acks")
+}
+
+// Path returns the acknowledgment file of the plan named planFilename.
+func Path(projectRoot, planFilename string) string {
+	return filepath.Join(Dir(projectRoot), strings.TrimSuffix(planFilename, ".md")+".jsonl")
+}
+
+// Load returns the acknowledgments of the plan named planFilename, oldest
+// first. A plan nobody has acknowledged yields no acks and no error.
+func Load(projectRoot, planFilename string) ([]Ack, error) {
+	acks, err := jsonl.Read[Ack](Path(projectRoot, planFilename))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return acks, err
+}
+
+// Find returns the acknowledgment by user (compared case-insensitively).
+func Find(acks []Ack, user string) (Ack, bool) {
+	for _, a := range acks {
+		if strings.EqualFold(a.User, user) {
+			return a, true
+		}
+	}
+	return Ack{}, false
+}
+
+// Record adds user's acknowledgment of the plan named planFilename at at and
+// returns the plan's acknowledgments. When user has already acknowledged
+// the plan nothing is written and added is false.
+func Record(projectRoot, planFilename, user string, at time.Time) (acks []Ack, added bool, err error) {
+	acks, err = Load(projectRoot, planFilename)
+	if err != nil {
+		return nil, false, err
+	}
+	if _, ok := Find(acks, user); ok {
+		return acks, false, nil
+	}
+	acks = append(acks, Ack{User: user, At: at})
+	if err := jsonl.WriteAtomic(Path(projectRoot, planFilename), acks); err != nil {
+		return nil, false, err
+	}
+	return acks, true, nil
+}
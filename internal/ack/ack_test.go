@@ -0,0 +1,38 @@
+package ack
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRecord_AddsOncePerUser(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2026, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	acks, added, err := Record(dir, "20260401-db-choice.md", "Alice", at)
+	if err != nil || !added || len(acks) != 1 {
+		t.Fatalf("first Record = %v, %v, %v", acks, added, err)
+	}
+	acks, added, err = Record(dir, "20260401-db-choice.md", "alice", at.Add(time.Hour))
+	if err != nil || added || len(acks) != 1 || !acks[0].At.Equal(at) {
+		t.Fatalf("repeated Record = %v, %v, %v; want the original ack kept", acks, added, err)
+	}
+	if _, _, err := Record(dir, "20260401-db-choice.md", "Bob", at); err != nil {
+		t.Fatal(err)
+	}
+
+	acks, err = Load(dir, "20260401-db-choice.md")
+	if err != nil || len(acks) != 2 {
+		t.Fatalf("Load = %v, %v", acks, err)
+	}
+	if _, ok := Find(acks, "BOB"); !ok {
+		t.Error("expected Find to match case-insensitively")
+	}
+}
+
+func TestLoad_NoAcks(t *testing.T) {
+	acks, err := Load(t.TempDir(), "20260401-none.md")
+	if err != nil || acks != nil {
+		t.Errorf("Load = %v, %v; want nothing", acks, err)
+	}
+}
@@ -0,0 +1,179 @@
+// Package bundle reads and writes portable archives of a project's
+// .logosyncx/ directory: a gzip-compressed tar holding manifest.json followed
+// by every file under the directory, stored by its slash-separated path
+// relative to .logosyncx/.
+package bundle
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// FormatVersion is the bundle layout written by Write. Read rejects bundles
+// with a different version.
+const FormatVersion = 1
+
+// ManifestName is the archive entry holding the Manifest.
+const ManifestName = "manifest.json"
+
+// Manifest describes a bundle.
+type Manifest struct {
+	FormatVersion int       `json:"format_version"`
+	LogosVersion  string    `json:"logos_version"`
+	Project       string    `json:"project"`
+	CreatedAt     time.Time `json:"created_at"`
+	Plans         int       `json:"plans"`
+	Tasks         int       `json:"tasks"`
+	Knowledge     int       `json:"knowledge"`
+	Files         int       `json:"files"`
+}
+
+// Bundle is a bundle read into memory.
+type Bundle struct {
+	Manifest Manifest
+	// Files maps slash-separated paths relative to .logosyncx/ to contents.
+	Files map[string][]byte
+}
+
+// Collect reads every file under dir that belongs in a bundle, keyed by its
+// slash-separated path relative to dir. Lock files and temp files left by
+// interrupted writes are skipped.
+func Collect(dir string) (map[string][]byte, error) {
+	files := map[string][]byte{}
+	err := filepath.WalkDir(dir, func(p string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() || !d.Type().IsRegular() || skip(d.Name()) {
+			return nil
+		}
+		rel, err := filepath.Rel(dir, p)
+		if err != nil {
+			return err
+		}
+		data, err := os.ReadFile(p)
+		if err != nil {
+			return err
+		}
+		files[filepath.ToSlash(rel)] = data
+		return nil
+	})
+	return files, err
+}
+
+// skip reports whether a file named name is transient state rather than
+// project content.
+func skip(name string) bool {
+	return strings.HasSuffix(name, ".lock") ||
+		(strings.HasPrefix(name, ".") && strings.HasSuffix(name, ".tmp"))
+}
+
+// Write writes m and files to w as a bundle. m.FormatVersion and m.Files are
+// filled in.
+func Write(w io.Writer, m Manifest, files map[string][]byte) error {
+	m.FormatVersion = FormatVersion
+	m.Files = len(files)
+	manifest, err := json.MarshalIndent(m, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+	add := func(name string, data []byte) error {
+		hdr := &tar.Header{
+			Name:    name,
+			Mode:    0o644,
+			Size:    int64(len(data)),
+			ModTime: m.CreatedAt,
+			Format:  tar.FormatPAX,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			return err
+		}
+		_, err := tw.Write(data)
+		return err
+	}
+	if err := add(ManifestName, manifest); err != nil {
+		return err
+	}
+	for _, name := range sortedKeys(files) {
+		if err := add(name, files[name]); err != nil {
+			return err
+		}
+	}
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Read reads a bundle written by Write. Entries whose path would leave
+// .logosyncx/ (absolute paths or "..") are rejected.
+func Read(r io.Reader) (*Bundle, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("not a logos bundle: %w", err)
+	}
+	defer gz.Close()
+
+	b := &Bundle{Files: map[string][]byte{}}
+	haveManifest := false
+	tr := tar.NewReader(gz)
+	for {
+		hdr, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("read bundle: %w", err)
+		}
+		if hdr.Typeflag != tar.TypeReg {
+			continue
+		}
+		name := path.Clean(hdr.Name)
+		if path.IsAbs(name) || name == ".." || strings.HasPrefix(name, "../") {
+			return nil, fmt.Errorf("read bundle: unsafe path %q", hdr.Name)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("read bundle: %w", err)
+		}
+		if name == ManifestName {
+			if err := json.Unmarshal(data, &b.Manifest); err != nil {
+				return nil, fmt.Errorf("read bundle manifest: %w", err)
+			}
+			haveManifest = true
+			continue
+		}
+		b.Files[name] = data
+	}
+	if !haveManifest {
+		return nil, errors.New("not a logos bundle: " + ManifestName + " missing")
+	}
+	if b.Manifest.FormatVersion != FormatVersion {
+		return nil, fmt.Errorf("bundle format version %d is not supported (expected %d) — upgrade logos",
+			b.Manifest.FormatVersion, FormatVersion)
+	}
+	return b, nil
+}
+
+func sortedKeys(m map[string][]byte) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,97 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCollect_SkipsTransientFiles(t *testing.T) {
+	dir := t.TempDir()
+	for name, content := range map[string]string{
+		"plans/a.md":                 "plan",
+		"tasks/p/001-x/TASK.md":      "task",
+		"index.jsonl":                "{}\n",
+		"tasks/p/001-x/TASK.md.lock": "",
+		".index-123.tmp":             "partial",
+	} {
+		p := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	files, err := Collect(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 3 {
+		t.Errorf("expected 3 files, got %v", files)
+	}
+	if string(files["tasks/p/001-x/TASK.md"]) != "task" {
+		t.Errorf("expected slash-separated keys, got %v", files)
+	}
+}
+
+func TestWriteRead_RoundTrip(t *testing.T) {
+	files := map[string][]byte{"plans/a.md": []byte("plan"), "config.json": []byte("{}")}
+	m := Manifest{Project: "demo", CreatedAt: time.Date(2026, 1, 2, 3, 4, 5, 0, time.UTC), Plans: 1}
+
+	var buf bytes.Buffer
+	if err := Write(&buf, m, files); err != nil {
+		t.Fatal(err)
+	}
+	b, err := Read(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Manifest.FormatVersion != FormatVersion || b.Manifest.Project != "demo" || b.Manifest.Files != 2 {
+		t.Errorf("unexpected manifest: %+v", b.Manifest)
+	}
+	if len(b.Files) != 2 || string(b.Files["plans/a.md"]) != "plan" {
+		t.Errorf("unexpected files: %v", b.Files)
+	}
+}
+
+// rawBundle builds a bundle archive from raw entries, bypassing Write.
+func rawBundle(t *testing.T, entries map[string]string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	for name, content := range entries {
+		if err := tw.WriteHeader(&tar.Header{Name: name, Mode: 0o644, Size: int64(len(content))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(content)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	tw.Close()
+	gz.Close()
+	return &buf
+}
+
+func TestRead_Rejects(t *testing.T) {
+	cases := map[string]map[string]string{
+		"missing manifest": {"plans/a.md": "x"},
+		"format version":   {ManifestName: `{"format_version": 99}`},
+		"unsafe path":      {ManifestName: `{"format_version": 1}`, "../evil.md": "x"},
+	}
+	for name, entries := range cases {
+		if _, err := Read(rawBundle(t, entries)); err == nil {
+			t.Errorf("%s: expected error", name)
+		}
+	}
+	if _, err := Read(strings.NewReader("not gzip")); err == nil {
+		t.Error("expected error for a non-gzip file")
+	}
+}
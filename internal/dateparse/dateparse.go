@@ -0,0 +1,134 @@
+// Package dateparse parses the date values accepted by logos date flags
+// (--since, --until): absolute dates such as 2026-03-04 and relative ones
+// such as "yesterday", "3d", "2w ago", or "last monday". Every result is the
+// start of a day (00:00) in the time zone of the reference time passed in,
+// so callers control the zone by choosing now's location.
+package dateparse
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Formats lists the accepted forms, for flag help and error messages.
+const Formats = `YYYY-MM-DD, today, yesterday, tomorrow, Nd or Nw (e.g. 3d, 2w), "N days ago", "in N weeks", or "[last|next] <weekday>"`
+
+// direction says which way an unqualified relative value such as "3d" or
+// "monday" points.
+type direction int
+
+const (
+	past   direction = -1
+	future direction = 1
+)
+
+// amountRe matches "3d", "3 d", "3 days", "2w", "2 weeks" with optional
+// "ago" suffix or "in" prefix.
+var amountRe = regexp.MustCompile(`^(in\s+)?(\d+)\s*(d|days?|w|weeks?)(\s+ago)?$`)
+
+// Past parses s as a point at or before now: "3d" and "monday" mean three
+// days ago and the most recent Monday (today included). Used for --since.
+func Past(s string, now time.Time) (time.Time, error) {
+	return parse(s, now, past)
+}
+
+// Future parses s as a point after now: "3d" and "monday" mean three days
+// from now and the next Monday (today excluded). Used for --until.
+func Future(s string, now time.Time) (time.Time, error) {
+	return parse(s, now, future)
+}
+
+func parse(s string, now time.Time, dir direction) (time.Time, error) {
+	v := strings.ToLower(strings.Join(strings.Fields(s), " "))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch v {
+	case "today":
+		return today, nil
+	case "yesterday":
+		return today.AddDate(0, 0, -1), nil
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	}
+
+	if t, err := time.ParseInLocation("2006-01-02", v, now.Location()); err == nil {
+		return t, nil
+	}
+
+	if m := amountRe.FindStringSubmatch(v); m != nil {
+		if m[1] != "" && m[4] != "" {
+			return time.Time{}, invalid(s) // "in 3d ago"
+		}
+		n, err := strconv.Atoi(m[2])
+		if err != nil {
+			return time.Time{}, invalid(s)
+		}
+		if strings.HasPrefix(m[3], "w") {
+			n *= 7
+		}
+		d := dir
+		switch {
+		case m[1] != "":
+			d = future
+		case m[4] != "":
+			d = past
+		}
+		return today.AddDate(0, 0, int(d)*n), nil
+	}
+
+	qualifier, day, ok := strings.Cut(v, " ")
+	if !ok {
+		qualifier, day = "", v
+	}
+	if wd, ok := weekday(day); ok {
+		switch qualifier {
+		case "last":
+			return weekdayBefore(today, wd), nil
+		case "next":
+			return weekdayAfter(today, wd), nil
+		case "":
+			if dir == past {
+				return weekdayBefore(today.AddDate(0, 0, 1), wd), nil
+			}
+			return weekdayAfter(today, wd), nil
+		}
+	}
+
+	return time.Time{}, invalid(s)
+}
+
+// weekdayBefore returns the latest day strictly before t falling on wd.
+func weekdayBefore(t time.Time, wd time.Weekday) time.Time {
+	back := (int(t.Weekday()) - int(wd) + 7) % 7
+	if back == 0 {
+		back = 7
+	}
+	return t.AddDate(0, 0, -back)
+}
+
+// weekdayAfter returns the earliest day strictly after t falling on wd.
+func weekdayAfter(t time.Time, wd time.Weekday) time.Time {
+	ahead := (int(wd) - int(t.Weekday()) + 7) % 7
+	if ahead == 0 {
+		ahead = 7
+	}
+	return t.AddDate(0, 0, ahead)
+}
+
+// weekday parses a full or three-letter English weekday name.
+func weekday(s string) (time.Weekday, bool) {
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		name := strings.ToLower(d.String())
+		if s == name || s == name[:3] {
+			return d, true
+		}
+	}
+	return 0, false
+}
+
+func invalid(s string) error {
+	return fmt.Errorf("invalid date %q: expected %s", s, Formats)
+}
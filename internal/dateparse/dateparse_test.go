@@ -0,0 +1,69 @@
+package dateparse
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// now is Wednesday 2026-03-04 15:30 in a fixed zone.
+var now = time.Date(2026, 3, 4, 15, 30, 0, 0, time.FixedZone("JST", 9*60*60))
+
+func day(d int) time.Time {
+	return time.Date(2026, 3, d, 0, 0, 0, 0, now.Location())
+}
+
+func TestPast(t *testing.T) {
+	tests := map[string]time.Time{
+		"2026-02-01":  time.Date(2026, 2, 1, 0, 0, 0, 0, now.Location()),
+		"today":       day(4),
+		"Yesterday":   day(3),
+		"3d":          day(1),
+		"3 days ago":  day(1),
+		"1w":          time.Date(2026, 2, 25, 0, 0, 0, 0, now.Location()),
+		"2w ago":      time.Date(2026, 2, 18, 0, 0, 0, 0, now.Location()),
+		"in 2 days":   day(6),
+		"monday":      day(2),
+		"wednesday":   day(4), // today counts for a past weekday
+		"last monday": day(2),
+		"last wed":    time.Date(2026, 2, 25, 0, 0, 0, 0, now.Location()),
+		"next monday": day(9),
+	}
+	for in, want := range tests {
+		got, err := Past(in, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("Past(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+}
+
+func TestFuture(t *testing.T) {
+	tests := map[string]time.Time{
+		"tomorrow":   day(5),
+		"3d":         day(7),
+		"1 week":     day(11),
+		"2d ago":     day(2),
+		"friday":     day(6),
+		"wednesday":  day(11), // today does not count for a future weekday
+		"2026-04-01": time.Date(2026, 4, 1, 0, 0, 0, 0, now.Location()),
+	}
+	for in, want := range tests {
+		got, err := Future(in, now)
+		if err != nil || !got.Equal(want) {
+			t.Errorf("Future(%q) = %v, %v; want %v", in, got, err, want)
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	for _, in := range []string{"", "soon", "3x", "-3d", "in 3d ago", "last", "2026-13-01", "last someday"} {
+		_, err := Past(in, now)
+		if err == nil {
+			t.Errorf("Past(%q): expected error", in)
+			continue
+		}
+		if !strings.Contains(err.Error(), "YYYY-MM-DD") {
+			t.Errorf("Past(%q): error should list accepted formats, got %v", in, err)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// Package filelock provides advisory, cross-process locks for files under
+// .logosyncx/. A lock on path is held by creating "<path>.lock" with O_EXCL,
+// which works on every platform logos is built for and needs no cgo or
+// syscall-specific code. Locks older than StaleAfter are assumed to belong to
+// a crashed process and are broken automatically.
+package filelock
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// lockSuffix is appended to the locked file's path to form the lock file path.
+const lockSuffix = ".lock"
+
+// pollInterval is how long Acquire sleeps after its first failed attempt.
+// The wait doubles after each further attempt, up to maxPollInterval.
+const (
+	pollInterval    = 10 * time.Millisecond
+	maxPollInterval = 200 * time.Millisecond
+)
+
+// DefaultTimeout is the default time Acquire waits for a contended lock.
+const DefaultTimeout = 10 * time.Second
+
+// StaleAfter is the age after which an existing lock file is considered
+// abandoned and removed.
+const StaleAfter = 30 * time.Second
+
+// ErrTimeout is returned by Acquire when the lock could not be obtained
+// within the given timeout.
+var ErrTimeout = errors.New("timed out waiting for file lock")
+
+// Lock is a held advisory lock. Call Release when done.
+type Lock struct {
+	path string // path of the lock file
+}
+
+// Path returns the path of the lock file that guards target.
+func Path(target string) string {
+	return target + lockSuffix
+}
+
+// Acquire blocks until it obtains the lock for target or timeout elapses,
+// retrying with exponential backoff. The directory containing target must
+// already exist.
+func Acquire(target string, timeout time.Duration) (*Lock, error) {
+	lockPath := Path(target)
+	deadline := time.Now().Add(timeout)
+	wait := pollInterval
+
+	for {
+		f, err := os.OpenFile(lockPath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0o644)
+		if err == nil {
+			fmt.Fprintf(f, "%d\n", os.Getpid())
+			f.Close()
+			return &Lock{path: lockPath}, nil
+		}
+		if !errors.Is(err, os.ErrExist) {
+			return nil, fmt.Errorf("create lock %s: %w", lockPath, err)
+		}
+
+		// Break locks left behind by a crashed process.
+		if info, statErr := os.Stat(lockPath); statErr == nil && time.Since(info.ModTime()) > StaleAfter {
+			_ = os.Remove(lockPath)
+			continue
+		}
+
+		if time.Now().After(deadline) {
+			return nil, fmt.Errorf("%w: %s", ErrTimeout, lockPath)
+		}
+		time.Sleep(min(wait, time.Until(deadline)+time.Millisecond))
+		wait = min(2*wait, maxPollInterval)
+	}
+}
+
+// Release removes the lock file. Releasing an already released lock is a
+// no-op.
+func (l *Lock) Release() error {
+	if l == nil || l.path == "" {
+		return nil
+	}
+	err := os.Remove(l.path)
+	l.path = ""
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("release lock: %w", err)
+	}
+	return nil
+}
+
+// With runs fn while holding the lock for target, creating the directory
+// containing target first when it is missing.
+func With(target string, timeout time.Duration, fn func() error) error {
+	if err := os.MkdirAll(filepath.Dir(target), 0o755); err != nil {
+		return err
+	}
+	l, err := Acquire(target, timeout)
+	if err != nil {
+		return err
+	}
+	defer l.Release()
+	return fn()
+}
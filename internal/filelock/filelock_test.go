@@ -0,0 +1,123 @@
+package filelock
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAcquire_CreatesAndReleasesLockFile(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "TASK.md")
+	l, err := Acquire(target, time.Second)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if _, err := os.Stat(Path(target)); err != nil {
+		t.Errorf("expected lock file to exist: %v", err)
+	}
+	if err := l.Release(); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if _, err := os.Stat(Path(target)); !os.IsNotExist(err) {
+		t.Errorf("expected lock file to be removed, stat err = %v", err)
+	}
+}
+
+func TestAcquire_Contended_TimesOut(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "TASK.md")
+	l, err := Acquire(target, time.Second)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	defer l.Release()
+
+	_, err = Acquire(target, 50*time.Millisecond)
+	if !errors.Is(err, ErrTimeout) {
+		t.Errorf("expected ErrTimeout, got %v", err)
+	}
+}
+
+func TestAcquire_BreaksStaleLock(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "TASK.md")
+	if err := os.WriteFile(Path(target), []byte("1\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	old := time.Now().Add(-2 * StaleAfter)
+	if err := os.Chtimes(Path(target), old, old); err != nil {
+		t.Fatal(err)
+	}
+	l, err := Acquire(target, 100*time.Millisecond)
+	if err != nil {
+		t.Fatalf("expected stale lock to be broken, got %v", err)
+	}
+	_ = l.Release()
+}
+
+func TestAcquire_SerialisesGoroutines(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "counter")
+	var inside, maxInside int
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			l, err := Acquire(target, 5*time.Second)
+			if err != nil {
+				t.Errorf("Acquire: %v", err)
+				return
+			}
+			mu.Lock()
+			inside++
+			if inside > maxInside {
+				maxInside = inside
+			}
+			mu.Unlock()
+			time.Sleep(2 * time.Millisecond)
+			mu.Lock()
+			inside--
+			mu.Unlock()
+			_ = l.Release()
+		}()
+	}
+	wg.Wait()
+	if maxInside != 1 {
+		t.Errorf("expected at most one holder at a time, saw %d", maxInside)
+	}
+}
+
+func TestRelease_Twice_IsNoOp(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "TASK.md")
+	l, err := Acquire(target, time.Second)
+	if err != nil {
+		t.Fatalf("Acquire: %v", err)
+	}
+	if err := l.Release(); err != nil {
+		t.Fatalf("first Release: %v", err)
+	}
+	if err := l.Release(); err != nil {
+		t.Errorf("second Release: %v", err)
+	}
+}
+
+func TestWith_CreatesDirAndReleases(t *testing.T) {
+	target := filepath.Join(t.TempDir(), "sub", "index.jsonl")
+	called := false
+	err := With(target, time.Second, func() error {
+		called = true
+		if _, err := os.Stat(Path(target)); err != nil {
+			t.Errorf("expected lock to be held: %v", err)
+		}
+		return errors.New("boom")
+	})
+	if !called || err == nil || err.Error() != "boom" {
+		t.Errorf("expected fn's error, got called=%v err=%v", called, err)
+	}
+	if _, err := os.Stat(Path(target)); !os.IsNotExist(err) {
+		t.Errorf("expected lock to be released, stat err = %v", err)
+	}
+}
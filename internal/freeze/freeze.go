@@ -0,0 +1,59 @@
+// Package freeze keeps the audit log of logos freeze: every time a plan is
+// frozen or unfrozen, who did it and why is appended to
+// .logosyncx/freeze-log.jsonl, which is committed with the project. The
+// frozen flag itself lives in the plan's frontmatter.
+package freeze
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/filelock"
+	"github.com/senna-lang/logosyncx/internal/jsonl"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// Actions recorded in the log.
+const (
+	ActionFreeze   = "freeze"
+	ActionUnfreeze = "unfreeze"
+)
+
+// Entry is one freeze or unfreeze of a plan.
+type Entry struct {
+	Action string    `json:"action"`
+	Plan   string    `json:"plan"` // plan filename
+	User   string    `json:"user"`
+	At     time.Time `json:"at"`
+	Reason string    `json:"reason,omitempty"`
+}
+
+// Path returns the path of the freeze log.
+func Path(projectRoot string) string {
+	return filepath.Join(projectRoot, config.DirName, "freeze-log.jsonl")
+}
+
+// Load returns the log entries, oldest first. A missing log yields no
+// entries and no error.
+func Load(projectRoot string) ([]Entry, error) {
+	entries, err := jsonl.Read[Entry](Path(projectRoot))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	return entries, err
+}
+
+// Append adds e at the end of the log, creating it when needed.
+func Append(projectRoot string, e Entry) error {
+	path := Path(projectRoot)
+	err := filelock.With(path, filelock.DefaultTimeout, func() error {
+		return jsonl.AppendAtomic(path, e)
+	})
+	if err != nil {
+		return fmt.Errorf("append freeze log: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,35 @@
+package freeze
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAppend_KeepsOrder(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2026, 5, 1, 9, 0, 0, 0, time.UTC)
+
+	for _, e := range []Entry{
+		{Action: ActionFreeze, Plan: "20260501-db-choice.md", User: "alice", At: at},
+		{Action: ActionUnfreeze, Plan: "20260501-db-choice.md", User: "bob", At: at.Add(time.Hour), Reason: "typo"},
+	} {
+		if err := Append(dir, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	entries, err := Load(dir)
+	if err != nil || len(entries) != 2 {
+		t.Fatalf("Load = %v, %v", entries, err)
+	}
+	if entries[0].Action != ActionFreeze || entries[1].Reason != "typo" || !entries[1].At.Equal(at.Add(time.Hour)) {
+		t.Errorf("entries = %+v", entries)
+	}
+}
+
+func TestLoad_NoLog(t *testing.T) {
+	entries, err := Load(t.TempDir())
+	if err != nil || entries != nil {
+		t.Errorf("Load = %v, %v; want nothing", entries, err)
+	}
+}
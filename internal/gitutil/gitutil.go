@@ -1,10 +1,12 @@
 // Package gitutil provides helpers for automating git operations via go-git
 // and os/exec.  It covers git add (staging), git rm (staging deletions),
-// git commit, git push, and git status queries.
+// git commit, git push, git status queries, repository/ignore/LFS detection,
+// and reading the configured user name and current branch.
 package gitutil
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"os/exec"
 	"path/filepath"
@@ -109,10 +111,18 @@ func Add(projectRoot, filePath string) error {
 // that the user's configured author identity and credential helpers are
 // honoured transparently.
 //
+// When paths are given, only they are committed (git commit -- <paths>);
+// anything else in the index stays staged and out of the commit. A path may
+// be a directory, which commits the tracked files under it.
+//
 // An error is returned when git is not available, the working directory is not
 // a repository, or the commit itself fails (e.g. nothing staged).
-func Commit(projectRoot, message string) error {
-	cmd := exec.Command("git", "commit", "-m", message)
+func Commit(projectRoot, message string, paths ...string) error {
+	args := []string{"commit", "-m", message}
+	if len(paths) > 0 {
+		args = append(append(args, "--"), paths...)
+	}
+	cmd := exec.Command("git", args...)
 	cmd.Dir = projectRoot
 	var out bytes.Buffer
 	cmd.Stdout = &out
@@ -171,3 +181,78 @@ func Remove(projectRoot, filePath string) error {
 
 	return nil
 }
+
+// IsRepo reports whether dir is inside a git repository (the repository root
+// itself or any directory beneath it).
+func IsRepo(dir string) bool {
+	_, err := gogit.PlainOpenWithOptions(dir, &gogit.PlainOpenOptions{
+		DetectDotGit: true,
+	})
+	return err == nil
+}
+
+// IsIgnored reports whether path (relative to projectRoot) is matched by a
+// .gitignore rule, the repository's info/exclude, or the user's global
+// excludes file. It uses the system git binary (git check-ignore) so that
+// every source of ignore rules is honoured.
+func IsIgnored(projectRoot, path string) (bool, error) {
+	cmd := exec.Command("git", "check-ignore", "-q", "--", path)
+	cmd.Dir = projectRoot
+	var errOut bytes.Buffer
+	cmd.Stderr = &errOut
+	err := cmd.Run()
+	if err == nil {
+		return true, nil
+	}
+	// Exit status 1 means "not ignored"; anything else is a real failure.
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitCode() == 1 {
+		return false, nil
+	}
+	return false, fmt.Errorf("git check-ignore: %w\n%s", err, errOut.String())
+}
+
+// IsLFSTracked reports whether path (relative to projectRoot) has the lfs
+// filter attribute, i.e. whether git add would store it with Git LFS. It
+// uses the system git binary (git check-attr), so every .gitattributes file
+// is honoured; the file itself need not exist.
+func IsLFSTracked(projectRoot, path string) (bool, error) {
+	cmd := exec.Command("git", "check-attr", "filter", "--", path)
+	cmd.Dir = projectRoot
+	out, err := cmd.Output()
+	if err != nil {
+		return false, fmt.Errorf("git check-attr: %w", err)
+	}
+	return strings.HasSuffix(strings.TrimSpace(string(out)), ": filter: lfs"), nil
+}
+
+// UserName returns the configured git user.name for the repository that
+// contains projectRoot (falling back to global configuration, as git does).
+// An error is returned when git is unavailable or no name is configured.
+func UserName(projectRoot string) (string, error) {
+	cmd := exec.Command("git", "config", "user.name")
+	cmd.Dir = projectRoot
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git config user.name: %w", err)
+	}
+	name := strings.TrimSpace(string(out))
+	if name == "" {
+		return "", errors.New("git config user.name is empty")
+	}
+	return name, nil
+}
+
+// CurrentBranch returns the short name of the branch checked out in the
+// repository that contains dir (e.g. "feature/auth"). A branch with no
+// commits yet is reported too. An error is returned when dir is not in a
+// repository or HEAD is detached.
+func CurrentBranch(dir string) (string, error) {
+	cmd := exec.Command("git", "symbolic-ref", "--quiet", "--short", "HEAD")
+	cmd.Dir = dir
+	out, err := cmd.Output()
+	if err != nil {
+		return "", fmt.Errorf("git symbolic-ref HEAD (detached HEAD or not a git repository): %w", err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
@@ -0,0 +1,370 @@
+// Package jsonl reads and writes the JSON Lines files logos uses for its
+// indexes (.logosyncx/index.jsonl and task-index.jsonl): one JSON value per
+// line, each line terminated by a newline.
+package jsonl
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrPartial is returned by Read when the file's last line has no trailing
+// newline — the sign of a write that was interrupted part-way through. The
+// rows before the incomplete line are still returned.
+var ErrPartial = errors.New("file ends in an incomplete line (interrupted write?)")
+
+// ErrOldVersion is returned by CheckHeader when a file has no header or
+// was written with an older schema version than the reader expects.
+var ErrOldVersion = errors.New("written by an older version of logos")
+
+// ErrNewVersion is returned by CheckHeader when a file was written with a
+// newer schema version than the reader knows. Rows still decode — unknown
+// fields are ignored — but fields the reader does not know are lost if it
+// rewrites the file.
+var ErrNewVersion = errors.New("written by a newer version of logos")
+
+// Header identifies the schema of a JSONL file. It is stored as the first
+// line in the form {"logosyncx_schema":"<schema>","logosyncx_version":<n>}.
+// Readers in this package recognise it and skip it. No row type has these
+// keys, so binaries that predate headers decode the line as a row with
+// every field empty: they keep reading the file, but list that blank row.
+// This is a breaking change for projects shared with such binaries, and is
+// documented as one in the README.
+type Header struct {
+	Schema  string `json:"logosyncx_schema"`
+	Version int    `json:"logosyncx_version"`
+}
+
+// parseHeader reports whether line is a header line.
+func parseHeader(line []byte) (Header, bool) {
+	var h Header
+	if len(line) == 0 || line[0] != '{' || !bytes.Contains(line, []byte(`"logosyncx_schema"`)) {
+		return h, false
+	}
+	if err := json.Unmarshal(line, &h); err != nil || h.Schema == "" {
+		return h, false
+	}
+	return h, true
+}
+
+// ReadHeader returns the header of the file at path. ok is false when the
+// file has no header (its first non-blank line is not one). A missing file
+// returns an error satisfying errors.Is(err, os.ErrNotExist).
+func ReadHeader(path string) (h Header, ok bool, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return h, false, err
+	}
+	lines, _ := splitLines(data)
+	for _, line := range lines {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		h, ok = parseHeader(line)
+		return h, ok, nil
+	}
+	return h, false, nil
+}
+
+// CheckHeader compares the header of the file at path with want. It
+// returns nil when the versions match or the file does not exist, an error
+// wrapping ErrOldVersion when the header is missing, names another schema,
+// or is older, and one wrapping ErrNewVersion when it is newer.
+func CheckHeader(path string, want Header) error {
+	h, ok, err := ReadHeader(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil
+	case err != nil:
+		return err
+	case !ok:
+		return fmt.Errorf("no schema header: %w", ErrOldVersion)
+	case h.Schema != want.Schema:
+		return fmt.Errorf("schema %q, want %q: %w", h.Schema, want.Schema, ErrOldVersion)
+	case h.Version < want.Version:
+		return fmt.Errorf("schema v%d, want v%d: %w", h.Version, want.Version, ErrOldVersion)
+	case h.Version > want.Version:
+		return fmt.Errorf("schema v%d, this binary knows v%d: %w", h.Version, want.Version, ErrNewVersion)
+	}
+	return nil
+}
+
+// SkippedError is returned by ReadTolerant when malformed lines were
+// skipped.
+type SkippedError struct {
+	Lines []int // 1-based line numbers of the skipped lines
+}
+
+func (e *SkippedError) Error() string {
+	nums := make([]string, len(e.Lines))
+	for i, n := range e.Lines {
+		nums[i] = strconv.Itoa(n)
+	}
+	return fmt.Sprintf("skipped %d malformed line(s): %s", len(e.Lines), strings.Join(nums, ", "))
+}
+
+// Read decodes every line of the file at path into a T. Blank lines and
+// Header lines are skipped. A malformed line stops the read: the
+// rows decoded so far are returned with an error naming the line. A missing
+// file returns an error satisfying errors.Is(err, os.ErrNotExist).
+func Read[T any](path string) ([]T, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	lines, partial := splitLines(data)
+
+	var rows []T
+	for i, line := range lines {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if _, ok := parseHeader(line); ok {
+			continue
+		}
+		var v T
+		if err := json.Unmarshal(line, &v); err != nil {
+			return rows, fmt.Errorf("line %d: %w", i+1, err)
+		}
+		rows = append(rows, v)
+	}
+	if partial {
+		return rows, fmt.Errorf("line %d: %w", len(lines)+1, ErrPartial)
+	}
+	return rows, nil
+}
+
+// ReadTolerant is like Read but skips malformed lines instead of stopping at
+// the first one: every well-formed row is returned, and the skipped line
+// numbers are reported in a *SkippedError. An incomplete last line still
+// yields an error wrapping ErrPartial (joined with the *SkippedError when
+// both apply).
+func ReadTolerant[T any](path string) ([]T, error) {
+	var rows []T
+	err := readTolerant(path, func(v T) { rows = append(rows, v) })
+	return rows, err
+}
+
+// Page selects a window of a listing: the first Offset rows are skipped
+// and at most Limit (0 = no limit) of the rest are kept.
+type Page struct {
+	Offset int
+	Limit  int
+}
+
+// Head returns the page of every row up to the end of p, for listings that
+// merge rows from elsewhere before applying p.
+func (p Page) Head() Page {
+	if p.Limit == 0 {
+		return Page{}
+	}
+	return Page{Limit: p.Offset + p.Limit}
+}
+
+// Bounds returns the start and end of p in a listing of n rows.
+func (p Page) Bounds(n int) (start, end int) {
+	start = min(p.Offset, n)
+	end = n
+	if p.Limit > 0 {
+		end = min(start+p.Limit, n)
+	}
+	return start, end
+}
+
+// ReadPage reads the file at path like ReadTolerant and returns page p of
+// the rows keep accepts, in the order of cmp, with total, the number of
+// rows accepted. A nil keep accepts every row; a nil cmp keeps file order,
+// as does cmp for rows that compare equal. While reading, only the rows up
+// to the end of the page are held, so a short page of a long file stays
+// small.
+func ReadPage[T any](path string, keep func(T) bool, cmp func(a, b T) int, p Page) (rows []T, total int, err error) {
+	n := p.Offset + p.Limit
+	err = readTolerant(path, func(v T) {
+		if keep != nil && !keep(v) {
+			return
+		}
+		total++
+		if p.Limit == 0 || cmp == nil {
+			if p.Limit == 0 || len(rows) < n {
+				rows = append(rows, v)
+			}
+			return
+		}
+		// Insert v after the rows that sort before or with it.
+		i := sort.Search(len(rows), func(i int) bool { return cmp(rows[i], v) > 0 })
+		if i < n {
+			rows = slices.Insert(rows, i, v)
+			rows = rows[:min(len(rows), n)]
+		}
+	})
+	if p.Limit == 0 && cmp != nil {
+		slices.SortStableFunc(rows, cmp)
+	}
+	start, _ := p.Bounds(len(rows))
+	return rows[start:], total, err
+}
+
+// readTolerant decodes the lines of the file at path as ReadTolerant does,
+// passing each well-formed row to add.
+func readTolerant[T any](path string, add func(T)) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return err
+	}
+	lines, partial := splitLines(data)
+
+	var skipped []int
+	for i, line := range lines {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+		if _, ok := parseHeader(line); ok {
+			continue
+		}
+		var v T
+		if err := json.Unmarshal(line, &v); err != nil {
+			skipped = append(skipped, i+1)
+			continue
+		}
+		add(v)
+	}
+
+	var errs []error
+	if len(skipped) > 0 {
+		errs = append(errs, &SkippedError{Lines: skipped})
+	}
+	if partial {
+		errs = append(errs, fmt.Errorf("line %d: %w", len(lines)+1, ErrPartial))
+	}
+	return errors.Join(errs...)
+}
+
+// splitLines splits data into lines, dropping an incomplete last line
+// (one without a trailing newline) and reporting whether there was one.
+func splitLines(data []byte) (lines [][]byte, partial bool) {
+	partial = len(data) > 0 && data[len(data)-1] != '\n'
+	lines = bytes.Split(data, []byte("\n"))
+	if partial {
+		lines = lines[:len(lines)-1]
+	}
+	return lines, partial
+}
+
+// WriteAtomic writes rows to path, one JSON value per line. The data is
+// written to a temporary file in the same directory and renamed over path,
+// so an interrupted write leaves the previous file intact instead of a
+// truncated one. Missing parent directories are created.
+func WriteAtomic[T any](path string, rows []T) error {
+	var buf bytes.Buffer
+	if err := encodeRows(&buf, rows); err != nil {
+		return err
+	}
+	return writeFile(path, buf.Bytes())
+}
+
+// WriteAtomicWithHeader is like WriteAtomic but writes h as the first line.
+func WriteAtomicWithHeader[T any](path string, h Header, rows []T) error {
+	var buf bytes.Buffer
+	if err := encodeRows(&buf, []Header{h}); err != nil {
+		return err
+	}
+	if err := encodeRows(&buf, rows); err != nil {
+		return err
+	}
+	return writeFile(path, buf.Bytes())
+}
+
+func encodeRows[T any](buf *bytes.Buffer, rows []T) error {
+	for _, r := range rows {
+		data, err := json.Marshal(r)
+		if err != nil {
+			return fmt.Errorf("marshal: %w", err)
+		}
+		buf.Write(data)
+		buf.WriteByte('\n')
+	}
+	return nil
+}
+
+// AppendAtomic adds row as one line at the end of the file at path,
+// creating it (and missing parent directories) when needed. Unlike an
+// O_APPEND write, the whole file is rewritten through a temporary file and
+// renamed into place, so readers never see a half-written line. An
+// incomplete last line left by an earlier interrupted write is terminated
+// first, so that only that line is malformed. Callers that may race with
+// other writers must hold a lock around the call (see internal/filelock).
+func AppendAtomic[T any](path string, row T) error {
+	return appendAtomic(path, nil, row)
+}
+
+// AppendAtomicWithHeader is like AppendAtomic but starts a new or empty
+// file with h as its first line. An existing file's header is left as is.
+func AppendAtomicWithHeader[T any](path string, h Header, row T) error {
+	return appendAtomic(path, &h, row)
+}
+
+func appendAtomic[T any](path string, h *Header, row T) error {
+	line, err := json.Marshal(row)
+	if err != nil {
+		return fmt.Errorf("marshal: %w", err)
+	}
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	if h != nil && len(bytes.TrimSpace(data)) == 0 {
+		hdr, err := json.Marshal(h)
+		if err != nil {
+			return fmt.Errorf("marshal: %w", err)
+		}
+		data = append(hdr, '\n')
+	}
+	data = append(append(data, line...), '\n')
+	return writeFile(path, data)
+}
+
+// writeFile writes data to a temporary file next to path and renames it
+// over path.
+func writeFile(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(dir, "."+filepath.Base(path)+"-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return nil
+}
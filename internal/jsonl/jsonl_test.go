@@ -0,0 +1,261 @@
+package jsonl
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"slices"
+	"testing"
+)
+
+type row struct {
+	N int `json:"n"`
+}
+
+func TestWriteAtomic_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "index.jsonl")
+	if err := WriteAtomic(path, []row{{1}, {2}}); err != nil {
+		t.Fatalf("WriteAtomic: %v", err)
+	}
+	got, err := Read[row](path)
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if len(got) != 2 || got[0].N != 1 || got[1].N != 2 {
+		t.Errorf("unexpected rows: %+v", got)
+	}
+	entries, _ := os.ReadDir(filepath.Dir(path))
+	if len(entries) != 1 {
+		t.Errorf("expected only the index file, found %d entries", len(entries))
+	}
+}
+
+func TestWriteAtomic_EmptyCreatesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	if err := WriteAtomic[row](path, nil); err != nil {
+		t.Fatalf("WriteAtomic: %v", err)
+	}
+	got, err := Read[row](path)
+	if err != nil || len(got) != 0 {
+		t.Errorf("expected empty read, got %+v, %v", got, err)
+	}
+}
+
+func TestRead_Missing(t *testing.T) {
+	_, err := Read[row](filepath.Join(t.TempDir(), "none.jsonl"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("expected os.ErrNotExist, got %v", err)
+	}
+}
+
+func TestRead_Partial(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	os.WriteFile(path, []byte("{\"n\":1}\n{\"n\":2}\n{\"n\":"), 0o644)
+	got, err := Read[row](path)
+	if !errors.Is(err, ErrPartial) {
+		t.Fatalf("expected ErrPartial, got %v", err)
+	}
+	if len(got) != 2 {
+		t.Errorf("expected 2 complete rows, got %d", len(got))
+	}
+}
+
+func TestRead_MalformedLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	os.WriteFile(path, []byte("{\"n\":1}\nnot json\n"), 0o644)
+	got, err := Read[row](path)
+	if err == nil || errors.Is(err, ErrPartial) {
+		t.Fatalf("expected a parse error, got %v", err)
+	}
+	if len(got) != 1 {
+		t.Errorf("expected rows before the bad line, got %d", len(got))
+	}
+}
+
+func TestReadTolerant_SkipsMalformedLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	os.WriteFile(path, []byte("{\"n\":1}\n<<<<<<< HEAD\n{\"n\":2}\n=======\n"), 0o644)
+	got, err := ReadTolerant[row](path)
+	var skipped *SkippedError
+	if !errors.As(err, &skipped) {
+		t.Fatalf("expected *SkippedError, got %v", err)
+	}
+	if len(skipped.Lines) != 2 || skipped.Lines[0] != 2 || skipped.Lines[1] != 4 {
+		t.Errorf("expected lines [2 4], got %v", skipped.Lines)
+	}
+	if len(got) != 2 {
+		t.Errorf("expected 2 rows, got %d", len(got))
+	}
+}
+
+func TestReadTolerant_PartialStillReported(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	os.WriteFile(path, []byte("{\"n\":1}\n{\"n\""), 0o644)
+	got, err := ReadTolerant[row](path)
+	if !errors.Is(err, ErrPartial) {
+		t.Fatalf("expected ErrPartial, got %v", err)
+	}
+	if len(got) != 1 {
+		t.Errorf("expected 1 row, got %d", len(got))
+	}
+}
+
+func TestAppendAtomic_CreatesAndAppends(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sub", "index.jsonl")
+	for _, n := range []int{1, 2} {
+		if err := AppendAtomic(path, row{n}); err != nil {
+			t.Fatalf("AppendAtomic: %v", err)
+		}
+	}
+	got, err := Read[row](path)
+	if err != nil || len(got) != 2 || got[1].N != 2 {
+		t.Errorf("unexpected rows %+v, %v", got, err)
+	}
+}
+
+func TestAppendAtomic_TerminatesPartialLine(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	os.WriteFile(path, []byte("{\"n\":1}\n{\"n\":"), 0o644)
+	if err := AppendAtomic(path, row{3}); err != nil {
+		t.Fatalf("AppendAtomic: %v", err)
+	}
+	got, err := ReadTolerant[row](path)
+	var skipped *SkippedError
+	if !errors.As(err, &skipped) || len(skipped.Lines) != 1 || skipped.Lines[0] != 2 {
+		t.Fatalf("expected only line 2 to be skipped, got %v", err)
+	}
+	if len(got) != 2 || got[1].N != 3 {
+		t.Errorf("unexpected rows: %+v", got)
+	}
+}
+
+func TestWriteAtomicWithHeader_HeaderSkippedOnRead(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	h := Header{Schema: "test", Version: 2}
+	if err := WriteAtomicWithHeader(path, h, []row{{1}, {2}}); err != nil {
+		t.Fatalf("WriteAtomicWithHeader: %v", err)
+	}
+	data, _ := os.ReadFile(path)
+	if want := "{\"logosyncx_schema\":\"test\",\"logosyncx_version\":2}\n{\"n\":1}\n{\"n\":2}\n"; string(data) != want {
+		t.Errorf("file = %q, want %q", data, want)
+	}
+	got, err := Read[row](path)
+	if err != nil || len(got) != 2 {
+		t.Errorf("Read = %+v, %v; want 2 rows", got, err)
+	}
+	got, err = ReadTolerant[row](path)
+	if err != nil || len(got) != 2 {
+		t.Errorf("ReadTolerant = %+v, %v; want 2 rows", got, err)
+	}
+	gotH, ok, err := ReadHeader(path)
+	if err != nil || !ok || gotH != h {
+		t.Errorf("ReadHeader = %+v, %v, %v", gotH, ok, err)
+	}
+}
+
+func TestAppendAtomicWithHeader_OnlyForNewFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	h := Header{Schema: "test", Version: 1}
+	AppendAtomicWithHeader(path, h, row{1})
+	AppendAtomicWithHeader(path, h, row{2})
+	data, _ := os.ReadFile(path)
+	if want := "{\"logosyncx_schema\":\"test\",\"logosyncx_version\":1}\n{\"n\":1}\n{\"n\":2}\n"; string(data) != want {
+		t.Errorf("file = %q, want %q", data, want)
+	}
+}
+
+func TestCheckHeader(t *testing.T) {
+	dir := t.TempDir()
+	want := Header{Schema: "test", Version: 2}
+	write := func(name, content string) string {
+		p := filepath.Join(dir, name)
+		os.WriteFile(p, []byte(content), 0o644)
+		return p
+	}
+	cases := []struct {
+		name    string
+		path    string
+		wantErr error
+	}{
+		{"current", write("cur.jsonl", "{\"logosyncx_schema\":\"test\",\"logosyncx_version\":2}\n{\"n\":1}\n"), nil},
+		{"missing file", filepath.Join(dir, "none.jsonl"), nil},
+		{"no header", write("old.jsonl", "{\"n\":1}\n"), ErrOldVersion},
+		{"older", write("v1.jsonl", "{\"logosyncx_schema\":\"test\",\"logosyncx_version\":1}\n"), ErrOldVersion},
+		{"other schema", write("other.jsonl", "{\"logosyncx_schema\":\"other\",\"logosyncx_version\":2}\n"), ErrOldVersion},
+		{"newer", write("v3.jsonl", "{\"logosyncx_schema\":\"test\",\"logosyncx_version\":3}\n{\"n\":1,\"extra\":true}\n"), ErrNewVersion},
+	}
+	for _, c := range cases {
+		err := CheckHeader(c.path, want)
+		if c.wantErr == nil && err != nil || c.wantErr != nil && !errors.Is(err, c.wantErr) {
+			t.Errorf("%s: CheckHeader = %v, want %v", c.name, err, c.wantErr)
+		}
+	}
+}
+
+func TestReadTolerant_HeaderSkippedAnywhere(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	os.WriteFile(path, []byte("{\"n\":1}\n{\"logosyncx_schema\":\"test\",\"logosyncx_version\":1}\n"), 0o644)
+	got, err := ReadTolerant[row](path)
+	if err != nil || len(got) != 1 || got[0].N != 1 {
+		t.Errorf("ReadTolerant = %+v, %v; want the header line skipped", got, err)
+	}
+}
+
+// A binary that predates headers decodes the header line as a row with
+// every field empty instead of failing.
+func TestHeader_DecodesAsEmptyRow(t *testing.T) {
+	data, err := json.Marshal(Header{Schema: "test", Version: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var r row
+	if err := json.Unmarshal(data, &r); err != nil || r != (row{}) {
+		t.Errorf("Unmarshal(%s) = %+v, %v; want an empty row", data, r, err)
+	}
+}
+
+func TestReadPage(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "index.jsonl")
+	if err := WriteAtomic(path, []row{{3}, {8}, {1}, {6}, {5}, {2}, {7}, {4}}); err != nil {
+		t.Fatal(err)
+	}
+	even := func(r row) bool { return r.N%2 == 0 }
+	desc := func(a, b row) int { return b.N - a.N }
+
+	for _, tt := range []struct {
+		name string
+		keep func(row) bool
+		cmp  func(a, b row) int
+		page Page
+		want []int
+	}{
+		{"all", nil, nil, Page{}, []int{3, 8, 1, 6, 5, 2, 7, 4}},
+		{"sorted", nil, desc, Page{}, []int{8, 7, 6, 5, 4, 3, 2, 1}},
+		{"page", nil, desc, Page{Offset: 2, Limit: 3}, []int{6, 5, 4}},
+		{"kept page", even, desc, Page{Offset: 1, Limit: 2}, []int{6, 4}},
+		{"file order page", nil, nil, Page{Offset: 1, Limit: 2}, []int{8, 1}},
+		{"past the end", even, desc, Page{Offset: 10, Limit: 2}, []int{}},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, total, err := ReadPage(path, tt.keep, tt.cmp, tt.page)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got := []int{}
+			for _, r := range rows {
+				got = append(got, r.N)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("rows = %v, want %v", got, tt.want)
+			}
+			wantTotal := 8
+			if tt.keep != nil {
+				wantTotal = 4
+			}
+			if total != wantTotal {
+				t.Errorf("total = %d, want %d", total, wantTotal)
+			}
+		})
+	}
+}
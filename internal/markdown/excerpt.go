@@ -0,0 +1,261 @@
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Excerpt strategy names, as used in the excerpt_strategy config keys.
+const (
+	StrategySection   = "section"
+	StrategyParagraph = "paragraph"
+	StrategySentences = "sentences"
+	StrategyCommand   = "command"
+)
+
+// StrategyNames lists the valid excerpt strategy names.
+var StrategyNames = []string{StrategySection, StrategyParagraph, StrategySentences, StrategyCommand}
+
+// DefaultExcerptSentences is the sentence count used by the sentences
+// strategy when none is configured.
+const DefaultExcerptSentences = 2
+
+// ExcerptCommandTimeout bounds one run of an external excerpt command.
+const ExcerptCommandTimeout = 10 * time.Second
+
+// Excerpter condenses the text chosen for an excerpt — the content of the
+// excerpt section, or the body — before it is truncated to the rune limit.
+type Excerpter interface {
+	Excerpt(text string) (string, error)
+}
+
+// NewExcerpter returns the strategy called name. sentences is the count
+// for the sentences strategy (0 uses DefaultExcerptSentences) and command
+// the command line for the command strategy. An empty name is the section
+// strategy.
+func NewExcerpter(name string, sentences int, command string) (Excerpter, error) {
+	switch name {
+	case "", StrategySection:
+		return SectionExcerpter{}, nil
+	case StrategyParagraph:
+		return ParagraphExcerpter{}, nil
+	case StrategySentences:
+		if sentences <= 0 {
+			sentences = DefaultExcerptSentences
+		}
+		return SentenceExcerpter{N: sentences}, nil
+	case StrategyCommand:
+		args := strings.Fields(command)
+		if len(args) == 0 {
+			return nil, errors.New("the command excerpt strategy needs a command")
+		}
+		return CommandExcerpter{Args: args, Timeout: ExcerptCommandTimeout}, nil
+	}
+	return nil, fmt.Errorf("unknown excerpt strategy %q (want %s)", name, strings.Join(StrategyNames, ", "))
+}
+
+// SectionExcerpter keeps the whole text; the excerpt is the section
+// truncated to the rune limit. This is the default.
+type SectionExcerpter struct{}
+
+func (SectionExcerpter) Excerpt(text string) (string, error) {
+	return text, nil
+}
+
+// ParagraphExcerpter keeps the first paragraph: everything up to the first
+// blank line.
+type ParagraphExcerpter struct{}
+
+func (ParagraphExcerpter) Excerpt(text string) (string, error) {
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(text), "\n") {
+		if strings.TrimSpace(line) == "" {
+			break
+		}
+		lines = append(lines, strings.TrimSpace(line))
+	}
+	return strings.Join(lines, " "), nil
+}
+
+// SentenceExcerpter keeps the first N sentences. A sentence ends at ".",
+// "!" or "?" followed by white space or the end of the text, or at a CJK
+// full stop, exclamation or question mark. Line breaks become spaces.
+type SentenceExcerpter struct {
+	N int
+}
+
+func (e SentenceExcerpter) Excerpt(text string) (string, error) {
+	runes := []rune(strings.Join(strings.Fields(text), " "))
+	count := 0
+	for i, r := range runes {
+		end := false
+		switch r {
+		case '。', '！', '？':
+			end = true
+		case '.', '!', '?':
+			end = i+1 == len(runes) || runes[i+1] == ' '
+		}
+		if end {
+			if count++; count == e.N {
+				return string(runes[:i+1]), nil
+			}
+		}
+	}
+	return string(runes), nil
+}
+
+// CommandExcerpter runs an external summarizer: Args[0] with the remaining
+// Args, the text on stdin, and the trimmed stdout as the excerpt. No shell
+// is involved. The command is killed after Timeout when it is positive.
+//
+// Results are remembered for the life of the process, keyed by the command
+// line and a hash of the text, so the same text is summarized once. After
+// a command line fails, later calls return that error without running it
+// again, so a broken summarizer costs at most one timeout.
+type CommandExcerpter struct {
+	Args    []string
+	Timeout time.Duration
+}
+
+// commandRuns holds the results and failures of CommandExcerpter runs.
+var commandRuns = struct {
+	sync.Mutex
+	out    map[string]string
+	failed map[string]error
+}{out: map[string]string{}, failed: map[string]error{}}
+
+func (e CommandExcerpter) Excerpt(text string) (string, error) {
+	line := strings.Join(e.Args, "\x00")
+	sum := sha256.Sum256([]byte(text))
+	key := line + "\x00" + hex.EncodeToString(sum[:])
+	commandRuns.Lock()
+	if err := commandRuns.failed[line]; err != nil {
+		commandRuns.Unlock()
+		return "", err
+	}
+	if out, ok := commandRuns.out[key]; ok {
+		commandRuns.Unlock()
+		return out, nil
+	}
+	commandRuns.Unlock()
+
+	out, err := e.run(text)
+	commandRuns.Lock()
+	defer commandRuns.Unlock()
+	if err != nil {
+		commandRuns.failed[line] = err
+		return "", err
+	}
+	commandRuns.out[key] = out
+	return out, nil
+}
+
+// run runs the command once on text.
+func (e CommandExcerpter) run(text string) (string, error) {
+	ctx := context.Background()
+	if e.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, e.Timeout)
+		defer cancel()
+	}
+	c := exec.CommandContext(ctx, e.Args[0], e.Args[1:]...)
+	c.Stdin = strings.NewReader(text)
+	var stderr bytes.Buffer
+	c.Stderr = &stderr
+	out, err := c.Output()
+	if err != nil {
+		if msg := strings.TrimSpace(stderr.String()); msg != "" {
+			return "", fmt.Errorf("excerpt command %s: %w: %s", e.Args[0], err, msg)
+		}
+		return "", fmt.Errorf("excerpt command %s: %w", e.Args[0], err)
+	}
+	return strings.TrimSpace(string(out)), nil
+}
+
+// WithFallback wraps e so that a failure yields the text unchanged, as the
+// section strategy would. The first failure is passed to warn (when not
+// nil); later ones are dropped so a broken command is reported once.
+func WithFallback(e Excerpter, warn func(error)) Excerpter {
+	return &fallbackExcerpter{e: e, warn: warn}
+}
+
+type fallbackExcerpter struct {
+	e    Excerpter
+	warn func(error)
+	once sync.Once
+}
+
+func (f *fallbackExcerpter) Excerpt(text string) (string, error) {
+	out, err := f.e.Excerpt(text)
+	if err != nil {
+		if f.warn != nil {
+			f.once.Do(func() { f.warn(err) })
+		}
+		return text, nil
+	}
+	return out, nil
+}
+
+var (
+	mdImage     = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	mdLink      = regexp.MustCompile(`\[([^\]]+)\](?:\([^)]*\)|\[[^\]]*\])`)
+	mdAutolink  = regexp.MustCompile(`<((?:https?|mailto):[^>\s]+)>`)
+	mdCodeSpan  = regexp.MustCompile("`` ?(.+?) ?``|`([^`\n]+)`")
+	blankSpaces = regexp.MustCompile(`[ \t]{2,}`)
+)
+
+// CleanInline removes markup that is noise in an excerpt: HTML comments,
+// images (replaced by their alt text), links (replaced by their text, or by
+// the URL for autolinks), and the backticks around inline code.
+func CleanInline(text string) string {
+	text = htmlComment.ReplaceAllString(text, "")
+	text = mdImage.ReplaceAllString(text, "$1")
+	text = mdLink.ReplaceAllString(text, "$1")
+	text = mdAutolink.ReplaceAllString(text, "$1")
+	text = mdCodeSpan.ReplaceAllString(text, "$1$2")
+	text = blankSpaces.ReplaceAllString(text, " ")
+	return strings.TrimSpace(text)
+}
+
+// CompilePrefixes compiles the excerpt_strip_prefixes patterns, each
+// anchored at the start of the text. Invalid patterns are left out and
+// reported in the returned error.
+func CompilePrefixes(patterns []string) ([]*regexp.Regexp, error) {
+	var out []*regexp.Regexp
+	var errs []error
+	for _, p := range patterns {
+		re, err := regexp.Compile(`^(?:` + p + `)`)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%q: %w", p, err))
+			continue
+		}
+		out = append(out, re)
+	}
+	return out, errors.Join(errs...)
+}
+
+// StripPrefixes removes boilerplate from the start of text: while one of
+// prefixes (as returned by CompilePrefixes) matches a non-empty prefix, the
+// match and the white space after it are cut.
+func StripPrefixes(text string, prefixes []*regexp.Regexp) string {
+	text = strings.TrimSpace(text)
+	for stripped := true; stripped && text != ""; {
+		stripped = false
+		for _, re := range prefixes {
+			if loc := re.FindStringIndex(text); loc != nil && loc[1] > 0 {
+				text = strings.TrimSpace(text[loc[1]:])
+				stripped = true
+			}
+		}
+	}
+	return text
+}
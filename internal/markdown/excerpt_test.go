@@ -0,0 +1,160 @@
+package markdown
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestNewExcerpter(t *testing.T) {
+	for _, tc := range []struct {
+		name, command string
+		want          Excerpter
+	}{
+		{"", "", SectionExcerpter{}},
+		{"section", "", SectionExcerpter{}},
+		{"paragraph", "", ParagraphExcerpter{}},
+		{"sentences", "", SentenceExcerpter{N: DefaultExcerptSentences}},
+	} {
+		got, err := NewExcerpter(tc.name, 0, tc.command)
+		if err != nil || got != tc.want {
+			t.Errorf("NewExcerpter(%q) = %#v, %v; want %#v", tc.name, got, err, tc.want)
+		}
+	}
+	if _, err := NewExcerpter("command", 0, "  "); err == nil {
+		t.Error("command strategy without a command should fail")
+	}
+	if _, err := NewExcerpter("llm", 0, ""); err == nil {
+		t.Error("unknown strategy should fail")
+	}
+}
+
+func TestParagraphExcerpter(t *testing.T) {
+	got, _ := ParagraphExcerpter{}.Excerpt("\nFirst line\nsecond line.\n\nNext paragraph.")
+	if got != "First line second line." {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestSentenceExcerpter(t *testing.T) {
+	for _, tc := range []struct {
+		n          int
+		text, want string
+	}{
+		{1, "Use JWTs. They are stateless.", "Use JWTs."},
+		{2, "Version 1.2 is out!\nIs it stable? Yes.", "Version 1.2 is out! Is it stable?"},
+		{3, "Only one sentence", "Only one sentence"},
+		{1, "トークンを使う。状態を持たない。", "トークンを使う。"},
+	} {
+		if got, _ := (SentenceExcerpter{N: tc.n}).Excerpt(tc.text); got != tc.want {
+			t.Errorf("Excerpt(%q, %d) = %q, want %q", tc.text, tc.n, got, tc.want)
+		}
+	}
+}
+
+func TestCommandExcerpter(t *testing.T) {
+	if _, err := exec.LookPath("tr"); err != nil {
+		t.Skip("tr not available")
+	}
+	got, err := CommandExcerpter{Args: []string{"tr", "a-z", "A-Z"}}.Excerpt("short summary\n")
+	if err != nil || got != "SHORT SUMMARY" {
+		t.Errorf("got %q, %v", got, err)
+	}
+	if _, err := (CommandExcerpter{Args: []string{"logos-no-such-summarizer"}}).Excerpt("x"); err == nil {
+		t.Error("expected an error for a missing command")
+	}
+}
+
+func TestCommandExcerpter_MemoizesAndStopsAfterFailure(t *testing.T) {
+	if _, err := exec.LookPath("sh"); err != nil {
+		t.Skip("sh not available")
+	}
+	runs := filepath.Join(t.TempDir(), "runs")
+	count := func() int {
+		data, _ := os.ReadFile(runs)
+		return strings.Count(string(data), "run")
+	}
+
+	ok := CommandExcerpter{Args: []string{"sh", "-c", "echo run >> " + runs + "; cat"}}
+	for range 2 {
+		if got, err := ok.Excerpt("same text"); err != nil || got != "same text" {
+			t.Fatalf("got %q, %v", got, err)
+		}
+	}
+	if _, err := ok.Excerpt("other text"); err != nil {
+		t.Fatal(err)
+	}
+	if n := count(); n != 2 {
+		t.Errorf("command ran %d times, want 2 (once per distinct text)", n)
+	}
+
+	failing := CommandExcerpter{Args: []string{"sh", "-c", "echo run >> " + runs + "; exit 1"}}
+	for _, text := range []string{"a", "b", "c"} {
+		if _, err := failing.Excerpt(text); err == nil {
+			t.Fatalf("Excerpt(%q): expected an error", text)
+		}
+	}
+	if n := count(); n != 3 {
+		t.Errorf("command ran %d times in all, want the failing one to run once", n)
+	}
+}
+
+func TestWithFallback(t *testing.T) {
+	var warnings []string
+	e := WithFallback(CommandExcerpter{Args: []string{"logos-no-such-summarizer"}}, func(err error) {
+		warnings = append(warnings, err.Error())
+	})
+	for range 2 {
+		if got, err := e.Excerpt("whole section"); err != nil || got != "whole section" {
+			t.Errorf("got %q, %v; want the text unchanged", got, err)
+		}
+	}
+	if len(warnings) != 1 || !strings.Contains(warnings[0], "logos-no-such-summarizer") {
+		t.Errorf("want one warning, got %q", warnings)
+	}
+}
+
+func TestExtractExcerptWithOptions_Strategy(t *testing.T) {
+	body := []byte("## Background\n\nFirst sentence. Second sentence.\n\nMore.\n")
+	got := ExtractExcerptWithOptions(body, ExcerptOptions{Section: "Background", Strategy: SentenceExcerpter{N: 1}})
+	if got != "First sentence." {
+		t.Errorf("got %q", got)
+	}
+}
+
+func TestCleanInline(t *testing.T) {
+	in := "See ![diagram](img/a.png) and [the RFC](https://x.test/rfc) or [ref][1], <https://x.test>. Run `make  test` or ``a ` b``. <!-- todo -->"
+	want := "See diagram and the RFC or ref, https://x.test. Run make test or a ` b."
+	if got := CleanInline(in); got != want {
+		t.Errorf("CleanInline = %q, want %q", got, want)
+	}
+}
+
+func TestStripPrefixes(t *testing.T) {
+	prefixes, err := CompilePrefixes([]string{`(?i)this session covers:?`, `In summary,?`, `(`})
+	if err == nil || len(prefixes) != 2 {
+		t.Fatalf("CompilePrefixes = %d patterns, %v; want 2 and an error for the invalid one", len(prefixes), err)
+	}
+	if got := StripPrefixes("This session covers: In summary, the auth rewrite.", prefixes); got != "the auth rewrite." {
+		t.Errorf("StripPrefixes = %q", got)
+	}
+	if got := StripPrefixes("Nothing to strip. This session covers x", prefixes); got != "Nothing to strip. This session covers x" {
+		t.Errorf("StripPrefixes stripped a match that is not a prefix: %q", got)
+	}
+}
+
+func TestExtractExcerptWithOptions_CleanAndStripBeforeStrategy(t *testing.T) {
+	prefixes, _ := CompilePrefixes([]string{`This session covers`})
+	body := []byte("## Background\n\nThis session covers [JWT](https://jwt.io) rotation. Second sentence.\n")
+	got := ExtractExcerptWithOptions(body, ExcerptOptions{
+		Section:       "Background",
+		Clean:         true,
+		StripPrefixes: prefixes,
+		Strategy:      SentenceExcerpter{N: 1},
+	})
+	if got != "JWT rotation." {
+		t.Errorf("got %q", got)
+	}
+}
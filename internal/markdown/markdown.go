@@ -4,7 +4,10 @@ package markdown
 
 import (
 	"errors"
+	"regexp"
+	"strconv"
 	"strings"
+	"unicode"
 	"unicode/utf8"
 )
 
@@ -64,42 +67,263 @@ func SplitFrontmatter(data []byte) (frontmatter, body []byte, err error) {
 	return []byte(fm), []byte(remainder), nil
 }
 
+// ExcerptOptions controls ExtractExcerptWithOptions.
+type ExcerptOptions struct {
+	// Section is the heading whose content becomes the excerpt.
+	Section string
+	// Fallback lists further headings tried in order when Section is
+	// missing or empty.
+	Fallback []string
+	// SkipBody leaves the excerpt empty, instead of using the start of the
+	// body, when neither Section nor any Fallback section has content.
+	SkipBody bool
+	// MaxRunes limits the excerpt length. Defaults to ExcerptMaxRunes.
+	MaxRunes int
+	// CJKMaxRunes, when > 0, replaces MaxRunes for excerpts detected as
+	// Chinese, Japanese, or Korean, which carry more meaning per rune.
+	CJKMaxRunes int
+	// Strategy condenses the chosen section or body before truncation.
+	// nil keeps it whole (SectionExcerpter). When Strategy fails, the text
+	// is used unchanged.
+	Strategy Excerpter
+	// Clean removes links, images, inline code markers, and HTML comments
+	// from the chosen text (see CleanInline).
+	Clean bool
+	// StripPrefixes are cut from the start of the chosen text (see
+	// StripPrefixes), after Clean and before Strategy.
+	StripPrefixes []*regexp.Regexp
+}
+
 // ExtractExcerpt returns the first ExcerptMaxRunes runes of the named
 // section's content. Falls back to the beginning of the body if the section
 // is not found or excerptSection is empty.
 func ExtractExcerpt(body []byte, excerptSection string) string {
-	text := string(body)
-
-	if excerptSection != "" {
-		lines := strings.Split(text, "\n")
-		inSection := false
-		currentLevel := 0
-		var content strings.Builder
-
-		for _, line := range lines {
-			if heading, level, ok := ParseHeading(line); ok {
-				if inSection && level <= currentLevel {
-					break
-				}
-				if strings.EqualFold(strings.TrimSpace(heading), excerptSection) {
-					inSection = true
-					currentLevel = level
-					continue
-				}
+	return ExtractExcerptWithOptions(body, ExcerptOptions{Section: excerptSection})
+}
+
+// ExtractExcerptWithOptions is like ExtractExcerpt but with a configurable
+// strategy and length limit, the latter optionally chosen by the detected
+// language of the excerpt.
+func ExtractExcerptWithOptions(body []byte, opts ExcerptOptions) string {
+	excerpt := sectionOrBody(string(body), opts)
+	if opts.Clean {
+		excerpt = CleanInline(excerpt)
+	}
+	if len(opts.StripPrefixes) > 0 {
+		excerpt = StripPrefixes(excerpt, opts.StripPrefixes)
+	}
+	if opts.Strategy != nil && excerpt != "" {
+		if out, err := opts.Strategy.Excerpt(excerpt); err == nil {
+			excerpt = strings.TrimSpace(out)
+		}
+	}
+	limit := opts.MaxRunes
+	if limit <= 0 {
+		limit = ExcerptMaxRunes
+	}
+	if opts.CJKMaxRunes > 0 && IsCJKLanguage(DetectLanguage(excerpt)) {
+		limit = opts.CJKMaxRunes
+	}
+	return TruncateRunes(excerpt, limit)
+}
+
+// sectionOrBody returns the trimmed content of opts.Section or, failing
+// that, of the first opts.Fallback section with content. When none has
+// content it returns the trimmed body, or "" with opts.SkipBody.
+func sectionOrBody(text string, opts ExcerptOptions) string {
+	for _, name := range append([]string{opts.Section}, opts.Fallback...) {
+		if name == "" {
+			continue
+		}
+		if excerpt, _ := Section(text, name); excerpt != "" {
+			return excerpt
+		}
+	}
+	if opts.SkipBody {
+		return ""
+	}
+	return strings.TrimSpace(text)
+}
+
+// Section returns the trimmed content under the first heading matching name
+// (case-insensitive), up to the next heading of the same or a higher level.
+// found is false when no such heading exists.
+func Section(text, name string) (content string, found bool) {
+	currentLevel := 0
+	var b strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		if heading, level, ok := ParseHeading(line); ok {
+			if found && level <= currentLevel {
+				break
 			}
-			if inSection {
-				content.WriteString(line)
-				content.WriteByte('\n')
+			if !found && strings.EqualFold(strings.TrimSpace(heading), strings.TrimSpace(name)) {
+				found = true
+				currentLevel = level
+				continue
 			}
 		}
+		if found {
+			b.WriteString(line)
+			b.WriteByte('\n')
+		}
+	}
+	return strings.TrimSpace(b.String()), found
+}
 
-		excerpt := strings.TrimSpace(content.String())
-		if excerpt != "" {
-			return TruncateRunes(excerpt, ExcerptMaxRunes)
+// ReplaceSection replaces the content under the first heading matching name
+// (case-insensitive) with content, keeping the heading itself. The section
+// ends at the next heading of the same or a higher level. ok is false, and
+// text is returned unchanged, when no such heading exists.
+func ReplaceSection(text, name, content string) (out string, ok bool) {
+	lines := strings.Split(text, "\n")
+	start, end, level := -1, len(lines), 0
+	for i, line := range lines {
+		heading, l, isHeading := ParseHeading(line)
+		if !isHeading {
+			continue
+		}
+		if start >= 0 && l <= level {
+			end = i
+			break
 		}
+		if start < 0 && strings.EqualFold(strings.TrimSpace(heading), strings.TrimSpace(name)) {
+			start, level = i, l
+		}
+	}
+	if start < 0 {
+		return text, false
 	}
+	var b strings.Builder
+	b.WriteString(strings.Join(lines[:start+1], "\n"))
+	b.WriteString("\n\n")
+	b.WriteString(strings.TrimSpace(content))
+	b.WriteString("\n")
+	if end < len(lines) {
+		b.WriteString("\n")
+		b.WriteString(strings.Join(lines[end:], "\n"))
+	}
+	return b.String(), true
+}
 
-	return TruncateRunes(strings.TrimSpace(text), ExcerptMaxRunes)
+// SetSection replaces the content of the named section of text, appending
+// the section as a level-2 heading at the end of text when it has none.
+func SetSection(text, name, content string) string {
+	if out, ok := ReplaceSection(text, name, content); ok {
+		return out
+	}
+	if text != "" && !strings.HasSuffix(text, "\n") {
+		text += "\n"
+	}
+	if text != "" {
+		text += "\n"
+	}
+	return text + "## " + name + "\n\n" + strings.TrimSpace(content) + "\n"
+}
+
+// AppendToSection adds line at the end of the named section of text,
+// dropping template placeholder comments from it, and creates the section
+// at the end of text when it is missing.
+func AppendToSection(text, name, line string) string {
+	existing, _ := Section(text, name)
+	if existing = StripComments(existing); existing != "" {
+		line = existing + "\n" + line
+	}
+	return SetSection(text, name, line)
+}
+
+// htmlComment matches an HTML comment, including multi-line ones.
+var htmlComment = regexp.MustCompile(`(?s)<!--.*?-->`)
+
+// StripComments removes HTML comments — the placeholders left by templates —
+// from s and trims the result.
+func StripComments(s string) string {
+	return strings.TrimSpace(htmlComment.ReplaceAllString(s, ""))
+}
+
+// ConflictMarkers returns the 1-based line numbers of git merge conflict
+// markers in text: lines beginning with "<<<<<<<", "|||||||", or ">>>>>>>",
+// and "=======" separator lines. Nothing is reported unless text contains an
+// opening "<<<<<<<" marker, so a setext heading underline of "=" signs on
+// its own is not mistaken for a conflict.
+func ConflictMarkers(text string) []int {
+	var lines []int
+	opened := false
+	for i, line := range strings.Split(text, "\n") {
+		line = strings.TrimRight(line, "\r")
+		switch {
+		case strings.HasPrefix(line, "<<<<<<<"):
+			opened = true
+			lines = append(lines, i+1)
+		case strings.HasPrefix(line, "|||||||"), strings.HasPrefix(line, ">>>>>>>"):
+			lines = append(lines, i+1)
+		case strings.TrimRight(line, " \t") == "=======":
+			lines = append(lines, i+1)
+		}
+	}
+	if !opened {
+		return nil
+	}
+	return lines
+}
+
+// ConflictHint returns a parse-error suffix naming the conflict marker
+// lines, or "" when there are none.
+func ConflictHint(lines []int) string {
+	if len(lines) == 0 {
+		return ""
+	}
+	return " (hint: git conflict markers at " + FormatLines(lines) + " — resolve the merge first)"
+}
+
+// FormatLines renders line numbers as "line 3" or "lines 3, 7, 9".
+func FormatLines(lines []int) string {
+	nums := make([]string, len(lines))
+	for i, n := range lines {
+		nums[i] = strconv.Itoa(n)
+	}
+	if len(lines) == 1 {
+		return "line " + nums[0]
+	}
+	return "lines " + strings.Join(nums, ", ")
+}
+
+// DetectLanguage makes a script-based guess at the language of s: "ja" when
+// kana is present, "ko" for Hangul, "zh" for Han without kana, "en" for
+// Latin-script text, and "" when s has no letters. It counts letters only,
+// so Markdown syntax and code do not skew the result.
+func DetectLanguage(s string) string {
+	var han, kana, hangul, latin int
+	for _, r := range s {
+		switch {
+		case unicode.Is(unicode.Hiragana, r), unicode.Is(unicode.Katakana, r):
+			kana++
+		case unicode.Is(unicode.Hangul, r):
+			hangul++
+		case unicode.Is(unicode.Han, r):
+			han++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+	cjk := han + kana + hangul
+	switch {
+	case cjk == 0 && latin == 0:
+		return ""
+	case cjk < latin:
+		return "en"
+	case kana > 0:
+		return "ja"
+	case hangul >= han:
+		return "ko"
+	default:
+		return "zh"
+	}
+}
+
+// IsCJKLanguage reports whether lang (as returned by DetectLanguage) is
+// Chinese, Japanese, or Korean.
+func IsCJKLanguage(lang string) bool {
+	return lang == "ja" || lang == "zh" || lang == "ko"
 }
 
 // ParseHeading returns the heading text, its level (1–6), and true if the
@@ -119,6 +343,62 @@ func ParseHeading(line string) (text string, level int, ok bool) {
 	return strings.TrimSpace(trimmed[i+1:]), i, true
 }
 
+// SectionSize describes one heading of a document and the size of its
+// content, measured the way Section returns it (trimmed, including any
+// nested subsections).
+type SectionSize struct {
+	Heading string `json:"heading"`
+	Level   int    `json:"level"`
+	Bytes   int    `json:"bytes"`
+	Words   int    `json:"words"`
+	Tokens  int    `json:"tokens"`
+}
+
+// Outline returns the headings of text in document order with the size of
+// each section's content.
+func Outline(text string) []SectionSize {
+	lines := strings.Split(text, "\n")
+	var out []SectionSize
+	for i, line := range lines {
+		heading, level, ok := ParseHeading(line)
+		if !ok {
+			continue
+		}
+		end := len(lines)
+		for j := i + 1; j < len(lines); j++ {
+			if _, l, ok := ParseHeading(lines[j]); ok && l <= level {
+				end = j
+				break
+			}
+		}
+		content := strings.TrimSpace(strings.Join(lines[i+1:end], "\n"))
+		out = append(out, SectionSize{
+			Heading: heading,
+			Level:   level,
+			Bytes:   len(content),
+			Words:   len(strings.Fields(content)),
+			Tokens:  EstimateTokens(content),
+		})
+	}
+	return out
+}
+
+// EstimateTokens roughly estimates the number of LLM tokens in s: one per
+// Chinese, Japanese, or Korean character and one per four bytes of
+// everything else, rounded up. It is meant for comparing sizes, not for
+// exact budgeting.
+func EstimateTokens(s string) int {
+	cjk, other := 0, 0
+	for _, r := range s {
+		if unicode.In(r, unicode.Han, unicode.Hiragana, unicode.Katakana, unicode.Hangul) {
+			cjk++
+		} else {
+			other += utf8.RuneLen(r)
+		}
+	}
+	return cjk + (other+3)/4
+}
+
 // TruncateRunes truncates s to at most n runes, appending "…" if truncated.
 func TruncateRunes(s string, n int) string {
 	if utf8.RuneCountInString(s) <= n {
@@ -127,3 +407,112 @@ func TruncateRunes(s string, n int) string {
 	runes := []rune(s)
 	return string(runes[:n]) + "…"
 }
+
+// ChecklistItem is a top-level "- [ ] text" / "- [x] text" item returned by
+// ParseChecklist.
+type ChecklistItem struct {
+	Text    string
+	Checked bool
+	// Children holds the lines nested under the item, de-indented so the
+	// shallowest one starts at column 0. Nested checklist items are kept as
+	// they are.
+	Children []string
+}
+
+// ParseChecklist returns the top-level checklist items of a Markdown
+// document. An item is top-level when it is the least-indented list item
+// seen so far; lines indented deeper than it (and blank lines between them)
+// become its Children. Other lines end the current item. Lines inside
+// fenced code blocks are never treated as items.
+func ParseChecklist(text string) []ChecklistItem {
+	var items []ChecklistItem
+	var cur *ChecklistItem
+	itemIndent := -1
+	inFence := false
+
+	flush := func() {
+		if cur == nil {
+			return
+		}
+		for len(cur.Children) > 0 && strings.TrimSpace(cur.Children[len(cur.Children)-1]) == "" {
+			cur.Children = cur.Children[:len(cur.Children)-1]
+		}
+		cur.Children = dedent(cur.Children)
+		items = append(items, *cur)
+		cur = nil
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		trimmed := strings.TrimSpace(line)
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+
+		if cur != nil && (trimmed == "" || indent > itemIndent) {
+			cur.Children = append(cur.Children, line)
+			if strings.HasPrefix(trimmed, "```") {
+				inFence = !inFence
+			}
+			continue
+		}
+		if strings.HasPrefix(trimmed, "```") {
+			inFence = !inFence
+		}
+		if inFence {
+			flush()
+			continue
+		}
+
+		flush()
+		text, checked, ok := parseCheckbox(trimmed)
+		if !ok || (itemIndent >= 0 && indent > itemIndent) {
+			continue
+		}
+		itemIndent = indent
+		cur = &ChecklistItem{Text: text, Checked: checked}
+	}
+	flush()
+	return items
+}
+
+// parseCheckbox parses "- [ ] text" (also "*" / "+" bullets and "[x]" /
+// "[X]") from an already-trimmed line.
+func parseCheckbox(line string) (text string, checked, ok bool) {
+	if len(line) < 2 || !strings.ContainsRune("-*+", rune(line[0])) || line[1] != ' ' {
+		return "", false, false
+	}
+	rest := strings.TrimLeft(line[2:], " ")
+	switch {
+	case strings.HasPrefix(rest, "[ ]"):
+	case strings.HasPrefix(rest, "[x]"), strings.HasPrefix(rest, "[X]"):
+		checked = true
+	default:
+		return "", false, false
+	}
+	text = strings.TrimSpace(rest[3:])
+	if text == "" {
+		return "", false, false
+	}
+	return text, checked, true
+}
+
+// dedent removes the common leading whitespace of the non-blank lines.
+func dedent(lines []string) []string {
+	minIndent := -1
+	for _, l := range lines {
+		if strings.TrimSpace(l) == "" {
+			continue
+		}
+		n := len(l) - len(strings.TrimLeft(l, " \t"))
+		if minIndent < 0 || n < minIndent {
+			minIndent = n
+		}
+	}
+	out := make([]string, len(lines))
+	for i, l := range lines {
+		if len(l) >= minIndent && minIndent > 0 {
+			out[i] = l[minIndent:]
+		} else {
+			out[i] = strings.TrimLeft(l, " \t")
+		}
+	}
+	return out
+}
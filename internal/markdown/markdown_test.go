@@ -138,3 +138,205 @@ func TestTruncateRunes(t *testing.T) {
 		}
 	})
 }
+
+func TestExtractExcerptWithOptions(t *testing.T) {
+	body := []byte("## What\n\n" + strings.Repeat("a", 50) + "\n")
+
+	t.Run("custom max runes", func(t *testing.T) {
+		got := ExtractExcerptWithOptions(body, ExcerptOptions{Section: "What", MaxRunes: 10})
+		if want := strings.Repeat("a", 10) + "…"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("CJK limit applies to CJK text only", func(t *testing.T) {
+		opts := ExcerptOptions{Section: "What", MaxRunes: 20, CJKMaxRunes: 5}
+		if got := ExtractExcerptWithOptions(body, opts); got != strings.Repeat("a", 20)+"…" {
+			t.Errorf("latin excerpt = %q, want 20 runes", got)
+		}
+		ja := []byte("## What\n\nこれは日本語の説明文です。\n")
+		if got := ExtractExcerptWithOptions(ja, opts); got != "これは日本…" {
+			t.Errorf("japanese excerpt = %q, want %q", got, "これは日本…")
+		}
+	})
+
+	t.Run("fallback sections in order", func(t *testing.T) {
+		doc := []byte("Raw intro.\n\n## Summary\n\n## Notes\n\nFrom notes.\n\n## What\n\nFrom what.\n")
+		opts := ExcerptOptions{Section: "Background", Fallback: []string{"Summary", "Notes", "What"}}
+		if got := ExtractExcerptWithOptions(doc, opts); got != "From notes." {
+			t.Errorf("got %q, want %q", got, "From notes.")
+		}
+	})
+
+	t.Run("skip body leaves excerpt empty", func(t *testing.T) {
+		doc := []byte("Raw intro.\n\n## Other\n\ntext\n")
+		opts := ExcerptOptions{Section: "Background", Fallback: []string{"Summary"}, SkipBody: true}
+		if got := ExtractExcerptWithOptions(doc, opts); got != "" {
+			t.Errorf("got %q, want empty excerpt", got)
+		}
+		opts.SkipBody = false
+		if got := ExtractExcerptWithOptions(doc, opts); !strings.HasPrefix(got, "Raw intro.") {
+			t.Errorf("got %q, want the body", got)
+		}
+	})
+}
+
+func TestOutline(t *testing.T) {
+	doc := "intro\n\n## Background\n\nfour words of text\n\n### Detail\n\nabc\n\n## Spec\n"
+	got := Outline(doc)
+	want := []SectionSize{
+		{Heading: "Background", Level: 2, Bytes: 35, Words: 7, Tokens: 9},
+		{Heading: "Detail", Level: 3, Bytes: 3, Words: 1, Tokens: 1},
+		{Heading: "Spec", Level: 2},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Outline = %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("section %d = %+v, want %+v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestEstimateTokens(t *testing.T) {
+	if got := EstimateTokens("abcdefgh"); got != 2 {
+		t.Errorf("latin: got %d, want 2", got)
+	}
+	if got := EstimateTokens("日本語"); got != 3 {
+		t.Errorf("CJK: got %d, want 3", got)
+	}
+	if got := EstimateTokens(""); got != 0 {
+		t.Errorf("empty: got %d, want 0", got)
+	}
+}
+
+func TestDetectLanguage(t *testing.T) {
+	cases := []struct {
+		in, want string
+	}{
+		{"Refactor the auth middleware", "en"},
+		{"認証ミドルウェアをリファクタする", "ja"},
+		{"重构认证中间件", "zh"},
+		{"인증 미들웨어 리팩터링", "ko"},
+		{"JWT の検証を追加", "ja"},
+		{"", ""},
+		{"123 -- ##", ""},
+	}
+	for _, c := range cases {
+		if got := DetectLanguage(c.in); got != c.want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", c.in, got, c.want)
+		}
+	}
+}
+
+func TestParseChecklist(t *testing.T) {
+	doc := strings.Join([]string{
+		"# TODO",
+		"",
+		"Some intro text.",
+		"- [ ] Add login form",
+		"  - [ ] email field",
+		"  - [x] password field",
+		"",
+		"    more detail",
+		"* [x] Set up CI",
+		"- plain bullet, not a task",
+		"- [ ] ",
+		"```",
+		"- [ ] inside a code fence",
+		"```",
+		"+ [X] Write README",
+	}, "\n")
+
+	got := ParseChecklist(doc)
+	if len(got) != 3 {
+		t.Fatalf("expected 3 items, got %d: %+v", len(got), got)
+	}
+	if got[0].Text != "Add login form" || got[0].Checked {
+		t.Errorf("item 0 = %+v", got[0])
+	}
+	wantChildren := []string{"- [ ] email field", "- [x] password field", "", "  more detail"}
+	if strings.Join(got[0].Children, "\n") != strings.Join(wantChildren, "\n") {
+		t.Errorf("item 0 children = %q, want %q", got[0].Children, wantChildren)
+	}
+	if got[1].Text != "Set up CI" || !got[1].Checked || len(got[1].Children) != 0 {
+		t.Errorf("item 1 = %+v", got[1])
+	}
+	if got[2].Text != "Write README" || !got[2].Checked {
+		t.Errorf("item 2 = %+v", got[2])
+	}
+}
+
+func TestSection(t *testing.T) {
+	body := "## What\nDo it.\n\n### Detail\nMore.\n\n## Checklist\n<!-- items -->\n"
+	if got, ok := Section(body, "what"); !ok || got != "Do it.\n\n### Detail\nMore." {
+		t.Errorf("Section(what) = %q, %v", got, ok)
+	}
+	if got, ok := Section(body, "Checklist"); !ok || StripComments(got) != "" {
+		t.Errorf("Section(Checklist) = %q, %v; want comment-only content", got, ok)
+	}
+	if _, ok := Section(body, "Notes"); ok {
+		t.Error("Section(Notes) found a missing section")
+	}
+}
+
+func TestReplaceSection(t *testing.T) {
+	body := "## What\n<!-- placeholder -->\n\n## Why\n<!-- placeholder -->\n"
+	got, ok := ReplaceSection(body, "what", "Build it.")
+	if !ok {
+		t.Fatal("expected What to be found")
+	}
+	want := "## What\n\nBuild it.\n\n## Why\n<!-- placeholder -->\n"
+	if got != want {
+		t.Errorf("ReplaceSection(What) = %q, want %q", got, want)
+	}
+	got, _ = ReplaceSection(got, "Why", "Because.")
+	if want := "## What\n\nBuild it.\n\n## Why\n\nBecause.\n"; got != want {
+		t.Errorf("ReplaceSection(Why) = %q, want %q", got, want)
+	}
+	if got, ok := ReplaceSection(body, "Notes", "x"); ok || got != body {
+		t.Errorf("missing section: got %q, %v", got, ok)
+	}
+}
+
+func TestAppendToSection(t *testing.T) {
+	body := "## What\nBuild it.\n\n## Log\n<!-- notes -->\n"
+	got := AppendToSection(body, "Log", "- one")
+	got = AppendToSection(got, "Log", "- two")
+	if want := "## What\nBuild it.\n\n## Log\n\n- one\n- two\n"; got != want {
+		t.Errorf("AppendToSection = %q, want %q", got, want)
+	}
+	got = AppendToSection("## What\nBuild it.", "Log", "- one")
+	if want := "## What\nBuild it.\n\n## Log\n\n- one\n"; got != want {
+		t.Errorf("AppendToSection (new section) = %q, want %q", got, want)
+	}
+}
+
+func TestConflictMarkers(t *testing.T) {
+	text := "## What\n<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> feature\n"
+	got := ConflictMarkers(text)
+	want := []int{2, 4, 6}
+	if len(got) != len(want) {
+		t.Fatalf("ConflictMarkers = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("ConflictMarkers = %v, want %v", got, want)
+		}
+	}
+
+	// A setext heading underline alone is not a conflict.
+	if got := ConflictMarkers("Title\n=======\n\nbody\n"); got != nil {
+		t.Errorf("expected no markers for setext heading, got %v", got)
+	}
+}
+
+func TestFormatLines(t *testing.T) {
+	if got := FormatLines([]int{3}); got != "line 3" {
+		t.Errorf("FormatLines([3]) = %q", got)
+	}
+	if got := FormatLines([]int{3, 7}); got != "lines 3, 7" {
+		t.Errorf("FormatLines([3 7]) = %q", got)
+	}
+}
@@ -0,0 +1,250 @@
+// Package metrics emits optional usage metrics — command durations, error
+// counts, and index sizes — to a StatsD daemon (UDP, DogStatsD tag syntax)
+// and/or an OpenTelemetry collector (OTLP/HTTP with JSON encoding), so
+// platform teams can monitor heavily used shared deployments.
+//
+// Metrics are configured per user, not per project, in the "metrics" key of
+// <user config dir>/logosyncx/config.json, and are off unless an endpoint
+// is set there. Emitting is best-effort: callers ignore the returned error
+// so that an unreachable collector never fails a command.
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DefaultPrefix is prepended to metric names when Config.Prefix is empty.
+const DefaultPrefix = "logos"
+
+// Config is the "metrics" section of the user config file.
+type Config struct {
+	// StatsD is the host:port of a StatsD daemon, e.g. "127.0.0.1:8125".
+	StatsD string `json:"statsd,omitempty"`
+	// OTLPEndpoint is the URL of an OTLP/HTTP metrics receiver, e.g.
+	// "http://localhost:4318/v1/metrics".
+	OTLPEndpoint string `json:"otlp_endpoint,omitempty"`
+	// Prefix is prepended to every metric name. Default "logos".
+	Prefix string `json:"prefix,omitempty"`
+}
+
+// Enabled reports whether any exporter is configured.
+func (c Config) Enabled() bool {
+	return c.StatsD != "" || c.OTLPEndpoint != ""
+}
+
+func (c Config) prefix() string {
+	if c.Prefix == "" {
+		return DefaultPrefix
+	}
+	return c.Prefix
+}
+
+// UserConfigPath returns the path of the per-user config file.
+func UserConfigPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "logosyncx", "config.json"), nil
+}
+
+// LoadUserConfig reads the "metrics" section of the user config file. A
+// missing file yields a zero (disabled) Config and no error.
+func LoadUserConfig() (Config, error) {
+	path, err := UserConfigPath()
+	if err != nil {
+		return Config{}, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return Config{}, nil
+	}
+	if err != nil {
+		return Config{}, err
+	}
+	var file struct {
+		Metrics Config `json:"metrics"`
+	}
+	if err := json.Unmarshal(data, &file); err != nil {
+		return Config{}, fmt.Errorf("%s: %w", path, err)
+	}
+	return file.Metrics, nil
+}
+
+// Kind is the type of a metric sample.
+type Kind int
+
+const (
+	Counter Kind = iota // a count of events since the last sample (delta)
+	Gauge               // a point-in-time value
+	Timer               // a duration in milliseconds
+)
+
+// Sample is one metric value. Name excludes the configured prefix.
+type Sample struct {
+	Name  string
+	Kind  Kind
+	Value float64
+	Tags  map[string]string
+}
+
+// Emit sends samples to every configured exporter and returns the joined
+// errors. ctx bounds the OTLP request.
+func Emit(ctx context.Context, c Config, samples []Sample) error {
+	if !c.Enabled() || len(samples) == 0 {
+		return nil
+	}
+	var errs []error
+	if c.StatsD != "" {
+		errs = append(errs, sendStatsD(c.StatsD, c.prefix(), samples))
+	}
+	if c.OTLPEndpoint != "" {
+		errs = append(errs, sendOTLP(ctx, c.OTLPEndpoint, c.prefix(), samples, time.Now()))
+	}
+	return errors.Join(errs...)
+}
+
+// sendStatsD writes one datagram per sample to addr over UDP.
+func sendStatsD(addr, prefix string, samples []Sample) error {
+	conn, err := net.Dial("udp", addr)
+	if err != nil {
+		return fmt.Errorf("statsd: %w", err)
+	}
+	defer conn.Close()
+	for _, s := range samples {
+		if _, err := conn.Write([]byte(formatStatsD(prefix, s))); err != nil {
+			return fmt.Errorf("statsd: %w", err)
+		}
+	}
+	return nil
+}
+
+// formatStatsD renders s as a StatsD line, e.g.
+// "logos.command.duration:12.5|ms|#command:ls". Tags are sorted by key.
+func formatStatsD(prefix string, s Sample) string {
+	typ := map[Kind]string{Counter: "c", Gauge: "g", Timer: "ms"}[s.Kind]
+	line := fmt.Sprintf("%s.%s:%s|%s", prefix, s.Name, strconv.FormatFloat(s.Value, 'f', -1, 64), typ)
+	if len(s.Tags) > 0 {
+		tags := make([]string, 0, len(s.Tags))
+		for _, k := range sortedKeys(s.Tags) {
+			tags = append(tags, k+":"+s.Tags[k])
+		}
+		line += "|#" + strings.Join(tags, ",")
+	}
+	return line
+}
+
+// sendOTLP posts samples to endpoint as an OTLP/HTTP JSON
+// ExportMetricsServiceRequest.
+func sendOTLP(ctx context.Context, endpoint, prefix string, samples []Sample, now time.Time) error {
+	body, err := json.Marshal(otlpRequest(prefix, samples, now))
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("otlp: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("otlp: %w", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode/100 != 2 {
+		return fmt.Errorf("otlp: %s returned %s", endpoint, resp.Status)
+	}
+	return nil
+}
+
+// The otlp* types mirror the subset of the OTLP JSON encoding used here.
+type (
+	otlpAttr struct {
+		Key   string `json:"key"`
+		Value struct {
+			StringValue string `json:"stringValue"`
+		} `json:"value"`
+	}
+	otlpPoint struct {
+		Attributes   []otlpAttr `json:"attributes,omitempty"`
+		TimeUnixNano string     `json:"timeUnixNano"`
+		AsDouble     float64    `json:"asDouble"`
+	}
+	otlpMetric struct {
+		Name  string `json:"name"`
+		Unit  string `json:"unit,omitempty"`
+		Gauge *struct {
+			DataPoints []otlpPoint `json:"dataPoints"`
+		} `json:"gauge,omitempty"`
+		Sum *struct {
+			DataPoints             []otlpPoint `json:"dataPoints"`
+			AggregationTemporality int         `json:"aggregationTemporality"`
+			IsMonotonic            bool        `json:"isMonotonic"`
+		} `json:"sum,omitempty"`
+	}
+)
+
+// otlpRequest builds the JSON body sent by sendOTLP. Counters become
+// monotonic delta sums; gauges and timers (unit "ms") become gauges.
+func otlpRequest(prefix string, samples []Sample, now time.Time) map[string]any {
+	ts := strconv.FormatInt(now.UnixNano(), 10)
+	metrics := make([]otlpMetric, 0, len(samples))
+	for _, s := range samples {
+		point := otlpPoint{TimeUnixNano: ts, AsDouble: s.Value}
+		for _, k := range sortedKeys(s.Tags) {
+			a := otlpAttr{Key: k}
+			a.Value.StringValue = s.Tags[k]
+			point.Attributes = append(point.Attributes, a)
+		}
+		m := otlpMetric{Name: prefix + "." + s.Name}
+		switch s.Kind {
+		case Counter:
+			m.Sum = &struct {
+				DataPoints             []otlpPoint `json:"dataPoints"`
+				AggregationTemporality int         `json:"aggregationTemporality"`
+				IsMonotonic            bool        `json:"isMonotonic"`
+			}{DataPoints: []otlpPoint{point}, AggregationTemporality: 1, IsMonotonic: true}
+		default:
+			if s.Kind == Timer {
+				m.Unit = "ms"
+			}
+			m.Gauge = &struct {
+				DataPoints []otlpPoint `json:"dataPoints"`
+			}{DataPoints: []otlpPoint{point}}
+		}
+		metrics = append(metrics, m)
+	}
+
+	service := otlpAttr{Key: "service.name"}
+	service.Value.StringValue = prefix
+	return map[string]any{
+		"resourceMetrics": []any{map[string]any{
+			"resource": map[string]any{"attributes": []otlpAttr{service}},
+			"scopeMetrics": []any{map[string]any{
+				"scope":   map[string]any{"name": "logosyncx"},
+				"metrics": metrics,
+			}},
+		}},
+	}
+}
+
+func sortedKeys(m map[string]string) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	return keys
+}
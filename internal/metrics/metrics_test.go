@@ -0,0 +1,144 @@
+package metrics
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadUserConfig(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	path, err := UserConfigPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := LoadUserConfig()
+	if err != nil || cfg.Enabled() {
+		t.Fatalf("missing file: got %+v, %v; want disabled config", cfg, err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"metrics": {"statsd": "127.0.0.1:8125", "prefix": "team"}}`
+	if err := os.WriteFile(path, []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err = LoadUserConfig()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Enabled() || cfg.StatsD != "127.0.0.1:8125" || cfg.prefix() != "team" {
+		t.Errorf("unexpected config: %+v", cfg)
+	}
+}
+
+func TestFormatStatsD(t *testing.T) {
+	cases := []struct {
+		s    Sample
+		want string
+	}{
+		{Sample{Name: "command.duration", Kind: Timer, Value: 12.5, Tags: map[string]string{"status": "ok", "command": "ls"}},
+			"logos.command.duration:12.5|ms|#command:ls,status:ok"},
+		{Sample{Name: "command.errors", Kind: Counter, Value: 1}, "logos.command.errors:1|c"},
+		{Sample{Name: "index.plans", Kind: Gauge, Value: 42}, "logos.index.plans:42|g"},
+	}
+	for _, c := range cases {
+		if got := formatStatsD("logos", c.s); got != c.want {
+			t.Errorf("formatStatsD(%+v) = %q, want %q", c.s, got, c.want)
+		}
+	}
+}
+
+func TestEmit_StatsD(t *testing.T) {
+	conn, err := net.ListenPacket("udp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+
+	cfg := Config{StatsD: conn.LocalAddr().String()}
+	if err := Emit(context.Background(), cfg, []Sample{{Name: "index.tasks", Kind: Gauge, Value: 3}}); err != nil {
+		t.Fatalf("Emit: %v", err)
+	}
+	buf := make([]byte, 512)
+	_ = conn.SetReadDeadline(time.Now().Add(2 * time.Second))
+	n, _, err := conn.ReadFrom(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := string(buf[:n]); got != "logos.index.tasks:3|g" {
+		t.Errorf("datagram = %q", got)
+	}
+}
+
+func TestEmit_OTLP(t *testing.T) {
+	var body []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		body, _ = io.ReadAll(r.Body)
+	}))
+	defer srv.Close()
+
+	cfg := Config{OTLPEndpoint: srv.URL}
+	samples := []Sample{
+		{Name: "command.duration", Kind: Timer, Value: 7, Tags: map[string]string{"command": "ls"}},
+		{Name: "command.errors", Kind: Counter, Value: 1},
+	}
+	if err := Emit(context.Background(), cfg, samples); err != nil {
+		t.Fatalf("Emit: %v", err)
+	}
+	var req struct {
+		ResourceMetrics []struct {
+			ScopeMetrics []struct {
+				Metrics []otlpMetric `json:"metrics"`
+			} `json:"scopeMetrics"`
+		} `json:"resourceMetrics"`
+	}
+	if err := json.Unmarshal(body, &req); err != nil {
+		t.Fatalf("invalid body %s: %v", body, err)
+	}
+	metrics := req.ResourceMetrics[0].ScopeMetrics[0].Metrics
+	if len(metrics) != 2 {
+		t.Fatalf("expected 2 metrics, got %d", len(metrics))
+	}
+	timer, counter := metrics[0], metrics[1]
+	if timer.Name != "logos.command.duration" || timer.Unit != "ms" || timer.Gauge == nil ||
+		timer.Gauge.DataPoints[0].Attributes[0].Value.StringValue != "ls" {
+		t.Errorf("unexpected timer metric: %s", body)
+	}
+	if counter.Sum == nil || !counter.Sum.IsMonotonic || counter.Sum.DataPoints[0].AsDouble != 1 {
+		t.Errorf("unexpected counter metric: %s", body)
+	}
+}
+
+func TestEmit_OTLPErrorStatus(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer srv.Close()
+
+	err := Emit(context.Background(), Config{OTLPEndpoint: srv.URL}, []Sample{{Name: "x", Kind: Gauge}})
+	if err == nil || !strings.Contains(err.Error(), "503") {
+		t.Errorf("expected 503 error, got %v", err)
+	}
+}
+
+func TestEmit_DisabledIsNoop(t *testing.T) {
+	if err := Emit(context.Background(), Config{}, []Sample{{Name: "x"}}); err != nil {
+		t.Errorf("expected nil, got %v", err)
+	}
+}
@@ -0,0 +1,97 @@
+// Package privacy matches secrets and other text that must not be committed
+// to .logosyncx/: the project's privacy patterns from config.json and,
+// optionally, built-in detectors for common API key formats.
+package privacy
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// Pattern is a named regular expression. The name appears in reports and in
+// the [REDACTED:<name>] placeholder, never the matched text.
+type Pattern struct {
+	Name string
+	Re   *regexp.Regexp
+}
+
+// Builtins are the detectors enabled by privacy.builtin_detectors. They
+// aim at formats with a distinctive prefix, so false positives are rare.
+var Builtins = []Pattern{
+	{"aws-access-key-id", regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`)},
+	{"github-token", regexp.MustCompile(`\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`)},
+	{"slack-token", regexp.MustCompile(`\bxox[abposr]-[A-Za-z0-9-]{10,}`)},
+	{"anthropic-api-key", regexp.MustCompile(`\bsk-ant-[A-Za-z0-9_-]{20,}`)},
+	{"openai-api-key", regexp.MustCompile(`\bsk-(?:proj-[A-Za-z0-9_-]{20,}|[A-Za-z0-9]{20,})`)},
+	{"stripe-secret-key", regexp.MustCompile(`\b[rs]k_live_[0-9A-Za-z]{16,}`)},
+	{"google-api-key", regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}`)},
+	{"private-key", regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----`)},
+}
+
+// Compile returns the patterns configured in cfg, in order: the named
+// patterns, filter_patterns (named "filter_patterns[i]"), then Builtins
+// when enabled. Patterns that do not compile are left out and reported in
+// the returned error; the others are still returned.
+func Compile(cfg config.PrivacyConfig) ([]Pattern, error) {
+	var out []Pattern
+	var errs []error
+	add := func(name, expr string) {
+		re, err := regexp.Compile(expr)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("privacy pattern %s: %w", name, err))
+			return
+		}
+		out = append(out, Pattern{Name: name, Re: re})
+	}
+	for _, p := range cfg.Patterns {
+		add(p.Name, p.Pattern)
+	}
+	for i, expr := range cfg.FilterPatterns {
+		add(fmt.Sprintf("filter_patterns[%d]", i), expr)
+	}
+	if cfg.BuiltinDetectors {
+		out = append(out, Builtins...)
+	}
+	return out, errors.Join(errs...)
+}
+
+// Match is one pattern matching on one line.
+type Match struct {
+	// Name is the name of the pattern that matched.
+	Name string
+	// Line is the 1-based line number of the match.
+	Line int
+}
+
+// Find returns every (pattern, line) pair in text where a pattern matches,
+// in line order.
+func Find(patterns []Pattern, text string) []Match {
+	var out []Match
+	for n, line := range strings.Split(text, "\n") {
+		for _, p := range patterns {
+			if p.Re.MatchString(line) {
+				out = append(out, Match{Name: p.Name, Line: n + 1})
+			}
+		}
+	}
+	return out
+}
+
+// Redact replaces every match of patterns in text with
+// "[REDACTED:<name>]", applying the patterns in order, and returns the
+// names of the patterns that matched (once each, in order).
+func Redact(patterns []Pattern, text string) (string, []string) {
+	var names []string
+	for _, p := range patterns {
+		if !p.Re.MatchString(text) {
+			continue
+		}
+		text = p.Re.ReplaceAllLiteralString(text, "[REDACTED:"+p.Name+"]")
+		names = append(names, p.Name)
+	}
+	return text, names
+}
@@ -2,32 +2,199 @@
 package project
 
 import (
+	"encoding/json"
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 )
 
 // ErrNotInitialized is returned when no .logosyncx/ directory can be found
 // by walking up the directory tree from the current working directory.
 var ErrNotInitialized = errors.New("not a logosyncx project (run `logos init` first)")
 
+// StorageEnv names the environment variable that, when set, overrides where
+// plans and tasks are stored: it is used as the project root (a directory
+// holding .logosyncx/) regardless of the working directory.
+const StorageEnv = "LOGOS_STORAGE"
+
 // FindRoot walks up the directory tree from the current working directory
 // until it finds a directory containing .logosyncx/, then returns that
 // directory as the project root. Returns ErrNotInitialized if not found.
+//
+// In a linked git worktree the main worktree's .logosyncx/ is used when the
+// worktree has none of its own, so context kept out of git is shared by
+// every checkout (see ResolveWorktree).
+//
+// When $LOGOS_STORAGE is set, or the found .logosyncx/config.json has a
+// "storage" entry, the root returned is that storage directory instead (see
+// ResolveStorage).
 func FindRoot() (string, error) {
+	if env := os.Getenv(StorageEnv); env != "" {
+		abs, err := filepath.Abs(env)
+		if err != nil {
+			return "", err
+		}
+		return checkStorage(abs, "$"+StorageEnv)
+	}
 	cwd, err := os.Getwd()
 	if err != nil {
 		return "", err
 	}
-	return findRootFrom(cwd)
+	root, err := ResolveWorktree(cwd)
+	if err != nil {
+		return "", err
+	}
+	return ResolveStorage(root)
+}
+
+// ResolveWorktree is like FindRootFrom, but when dir is inside a linked git
+// worktree it may return the main worktree instead, following the
+// git.worktrees setting of the config that would otherwise be used:
+//
+//   - "auto" (or unset): the worktree's own root when it has one, otherwise
+//     the main worktree when that holds .logosyncx/
+//   - "main": the main worktree whenever it holds .logosyncx/
+//   - "local": never the main worktree
+//
+// A root found above the worktree (e.g. a worktree checked out inside the
+// main one) is returned as is. The main worktree is found from the .git
+// file git writes in a linked worktree, without running git.
+func ResolveWorktree(dir string) (string, error) {
+	root, err := findRootFrom(dir)
+	top, main := linkedWorktree(dir)
+	if main == "" || !hasLogosyncx(main) {
+		return root, err
+	}
+	if err != nil {
+		if worktreesMode(main) == "local" {
+			return "", err
+		}
+		return main, nil
+	}
+	if !within(root, top) || worktreesMode(root) != "main" {
+		return root, nil
+	}
+	return main, nil
 }
 
-// FindRootFrom is like FindRoot but starts from the given directory.
-// Exported for use in tests.
+// linkedWorktree returns the top directory of the linked git worktree
+// containing dir and the top directory of its main worktree. Both are ""
+// when dir is not in a linked worktree, or the main worktree is bare.
+func linkedWorktree(dir string) (top, main string) {
+	current := filepath.Clean(dir)
+	for {
+		info, err := os.Stat(filepath.Join(current, ".git"))
+		if err == nil {
+			if info.IsDir() {
+				return "", "" // the main worktree or a plain repository
+			}
+			break
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return "", ""
+		}
+		current = parent
+	}
+	// A linked worktree's .git file reads "gitdir: <main>/.git/worktrees/<name>",
+	// and that directory's commondir file points back to <main>/.git.
+	data, err := os.ReadFile(filepath.Join(current, ".git"))
+	if err != nil {
+		return "", ""
+	}
+	gitdir, ok := strings.CutPrefix(strings.TrimSpace(string(data)), "gitdir:")
+	if !ok {
+		return "", ""
+	}
+	gitdir = strings.TrimSpace(gitdir)
+	if !filepath.IsAbs(gitdir) {
+		gitdir = filepath.Join(current, gitdir)
+	}
+	data, err = os.ReadFile(filepath.Join(gitdir, "commondir"))
+	if err != nil {
+		return "", "" // e.g. a submodule, whose .git file has no commondir
+	}
+	common := strings.TrimSpace(string(data))
+	if !filepath.IsAbs(common) {
+		common = filepath.Join(gitdir, common)
+	}
+	common = filepath.Clean(common)
+	if filepath.Base(common) != ".git" {
+		return "", "" // bare repository: there is no main worktree
+	}
+	return current, filepath.Dir(common)
+}
+
+// worktreesMode returns git.worktrees from root's config.json, read directly
+// like the storage pointer; "" when unset or unreadable.
+func worktreesMode(root string) string {
+	data, err := os.ReadFile(filepath.Join(root, ".logosyncx", "config.json"))
+	if err != nil {
+		return ""
+	}
+	var cfg struct {
+		Git struct {
+			Worktrees string `json:"worktrees"`
+		} `json:"git"`
+	}
+	if json.Unmarshal(data, &cfg) != nil {
+		return ""
+	}
+	return cfg.Git.Worktrees
+}
+
+func hasLogosyncx(dir string) bool {
+	info, err := os.Stat(filepath.Join(dir, ".logosyncx"))
+	return err == nil && info.IsDir()
+}
+
+// within reports whether path is dir or inside it.
+func within(path, dir string) bool {
+	rel, err := filepath.Rel(dir, path)
+	return err == nil && rel != ".." && !strings.HasPrefix(rel, ".."+string(filepath.Separator))
+}
+
+// FindRootFrom is like FindRoot but starts from the given directory and
+// does not follow storage pointers. Exported for use in tests.
 func FindRootFrom(dir string) (string, error) {
 	return findRootFrom(dir)
 }
 
+// ResolveStorage returns the directory holding the plans and tasks of the
+// project at root. That is root itself unless root's config.json has a
+// "storage" entry, a path (relative to root, or absolute) to another
+// directory holding .logosyncx/ — e.g. a sibling repository for teams that
+// cannot commit context alongside source. Only one hop is followed.
+func ResolveStorage(root string) (string, error) {
+	data, err := os.ReadFile(filepath.Join(root, ".logosyncx", "config.json"))
+	if err != nil {
+		// A missing or unreadable config is reported by config.Load.
+		return root, nil
+	}
+	var pointer struct {
+		Storage string `json:"storage"`
+	}
+	if json.Unmarshal(data, &pointer) != nil || pointer.Storage == "" {
+		return root, nil
+	}
+	target := pointer.Storage
+	if !filepath.IsAbs(target) {
+		target = filepath.Join(root, target)
+	}
+	return checkStorage(filepath.Clean(target), "storage in "+filepath.Join(root, ".logosyncx", "config.json"))
+}
+
+// checkStorage returns dir when it holds .logosyncx/, and an error naming
+// source (where dir came from) otherwise.
+func checkStorage(dir, source string) (string, error) {
+	if info, err := os.Stat(filepath.Join(dir, ".logosyncx")); err != nil || !info.IsDir() {
+		return "", fmt.Errorf("%s points to %s, which has no .logosyncx/ (run `logos init` there)", source, dir)
+	}
+	return dir, nil
+}
+
 func findRootFrom(dir string) (string, error) {
 	current := filepath.Clean(dir)
 	for {
@@ -121,3 +121,159 @@ func TestErrNotInitialized_ContainsLogosInitHint(t *testing.T) {
 		t.Errorf("error message too short: %q", msg)
 	}
 }
+
+func writePointer(t *testing.T, root, storage string) {
+	t.Helper()
+	dir := filepath.Join(root, ".logosyncx")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"version": "2", "storage": "` + storage + `"}`
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveStorage_FollowsRelativePointer(t *testing.T) {
+	parent := t.TempDir()
+	code := filepath.Join(parent, "code")
+	context := filepath.Join(parent, "context")
+	if err := os.MkdirAll(filepath.Join(context, ".logosyncx"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	writePointer(t, code, "../context")
+
+	got, err := ResolveStorage(code)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != context {
+		t.Errorf("ResolveStorage = %q, want %q", got, context)
+	}
+}
+
+func TestResolveStorage_NoPointer(t *testing.T) {
+	root := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(root, ".logosyncx"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ResolveStorage(root); err != nil || got != root {
+		t.Errorf("ResolveStorage = %q, %v; want %q", got, err, root)
+	}
+}
+
+func TestResolveStorage_MissingTarget(t *testing.T) {
+	root := t.TempDir()
+	writePointer(t, root, "../nowhere")
+	if _, err := ResolveStorage(root); err == nil {
+		t.Error("expected error for a pointer to a directory without .logosyncx/")
+	}
+}
+
+func TestFindRoot_StorageEnv(t *testing.T) {
+	storage := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(storage, ".logosyncx"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv(StorageEnv, storage)
+	got, err := FindRoot()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != storage {
+		t.Errorf("FindRoot = %q, want %q", got, storage)
+	}
+}
+
+// makeWorktree lays out what git writes for a main worktree and a linked
+// worktree of it, and returns both directories.
+func makeWorktree(t *testing.T) (main, linked string) {
+	t.Helper()
+	parent := t.TempDir()
+	main = filepath.Join(parent, "main")
+	linked = filepath.Join(parent, "feat")
+	gitdir := filepath.Join(main, ".git", "worktrees", "feat")
+	for _, d := range []string{gitdir, linked} {
+		if err := os.MkdirAll(d, 0o755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(gitdir, "commondir"), []byte("../..\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(linked, ".git"), []byte("gitdir: "+gitdir+"\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	return main, linked
+}
+
+func writeWorktreesMode(t *testing.T, root, mode string) {
+	t.Helper()
+	dir := filepath.Join(root, ".logosyncx")
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"version": "2", "git": {"worktrees": "` + mode + `"}}`
+	if err := os.WriteFile(filepath.Join(dir, "config.json"), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestResolveWorktree_UsesMainWhenWorktreeHasNone(t *testing.T) {
+	main, linked := makeWorktree(t)
+	writeWorktreesMode(t, main, "")
+	nested := filepath.Join(linked, "pkg")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if got, err := ResolveWorktree(nested); err != nil || got != main {
+		t.Errorf("ResolveWorktree = %q, %v; want %q", got, err, main)
+	}
+}
+
+func TestResolveWorktree_PrefersOwnUnlessMain(t *testing.T) {
+	main, linked := makeWorktree(t)
+	writeWorktreesMode(t, main, "")
+	writeWorktreesMode(t, linked, "auto")
+	if got, err := ResolveWorktree(linked); err != nil || got != linked {
+		t.Errorf("auto: ResolveWorktree = %q, %v; want %q", got, err, linked)
+	}
+	writeWorktreesMode(t, linked, "main")
+	if got, err := ResolveWorktree(linked); err != nil || got != main {
+		t.Errorf("main: ResolveWorktree = %q, %v; want %q", got, err, main)
+	}
+}
+
+func TestResolveWorktree_Local(t *testing.T) {
+	main, linked := makeWorktree(t)
+	writeWorktreesMode(t, main, "local")
+	if _, err := ResolveWorktree(linked); err != ErrNotInitialized {
+		t.Errorf("ResolveWorktree error = %v, want ErrNotInitialized", err)
+	}
+}
+
+func TestResolveWorktree_MainWithoutLogosyncx(t *testing.T) {
+	_, linked := makeWorktree(t)
+	if _, err := ResolveWorktree(linked); err != ErrNotInitialized {
+		t.Errorf("ResolveWorktree error = %v, want ErrNotInitialized", err)
+	}
+}
+
+func TestResolveWorktree_SubmoduleIsNotAWorktree(t *testing.T) {
+	parent := t.TempDir()
+	sub := filepath.Join(parent, "vendor", "lib")
+	if err := os.MkdirAll(filepath.Join(parent, ".git", "modules", "lib"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(sub, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(sub, ".git"), []byte("gitdir: ../../.git/modules/lib\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	writeWorktreesMode(t, parent, "")
+	// Found by walking up, not through worktree resolution.
+	if got, err := ResolveWorktree(sub); err != nil || got != parent {
+		t.Errorf("ResolveWorktree = %q, %v; want %q", got, err, parent)
+	}
+}
@@ -0,0 +1,139 @@
+// Package prompt asks the user questions — yes/no confirmations, a choice
+// from a list, or free text — on behalf of CLI commands. A Prompter reads
+// answers from any io.Reader, so commands use Stdin while tests inject
+// scripted answers with New. A Prompter that is not interactive refuses to
+// ask and returns ErrNotInteractive rather than blocking on input nobody
+// will provide.
+package prompt
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/term"
+)
+
+// maxAttempts is how many times Select re-asks after an invalid answer.
+const maxAttempts = 3
+
+// ErrNotInteractive is returned when a question is asked of a Prompter
+// whose input is not a terminal.
+var ErrNotInteractive = errors.New("stdin is not a terminal")
+
+// Prompter asks questions on out and reads one answer per line from in.
+type Prompter struct {
+	in          *bufio.Reader
+	out         io.Writer
+	interactive bool
+}
+
+// New returns a Prompter reading answers from in and writing questions to
+// out. interactive reports whether a person can answer; when false every
+// question fails with ErrNotInteractive.
+func New(in io.Reader, out io.Writer, interactive bool) *Prompter {
+	return &Prompter{in: bufio.NewReader(in), out: out, interactive: interactive}
+}
+
+// Stdin returns a Prompter on os.Stdin and os.Stdout that is interactive
+// only when stdin is a terminal.
+func Stdin() *Prompter {
+	return New(os.Stdin, os.Stdout, term.IsTerminal(os.Stdin))
+}
+
+// Interactive reports whether p can ask questions.
+func (p *Prompter) Interactive() bool {
+	return p.interactive
+}
+
+// Confirm asks a yes/no question and reports whether the answer was yes.
+// An empty answer selects def, which is shown capitalised in the hint
+// ("[Y/n]" or "[y/N]"); any answer other than y/yes/n/no counts as no.
+func (p *Prompter) Confirm(question string, def bool) (bool, error) {
+	hint := "[y/N]"
+	if def {
+		hint = "[Y/n]"
+	}
+	answer, err := p.ask(fmt.Sprintf("%s %s ", question, hint))
+	if err != nil {
+		return false, err
+	}
+	switch strings.ToLower(answer) {
+	case "":
+		return def, nil
+	case "y", "yes":
+		return true, nil
+	default:
+		return false, nil
+	}
+}
+
+// Select asks the user to pick one of options by number and returns its
+// index. An empty answer selects def; an invalid answer is asked again, up
+// to maxAttempts times.
+func (p *Prompter) Select(question string, options []string, def int) (int, error) {
+	if len(options) == 0 {
+		return 0, errors.New("prompt: no options to select from")
+	}
+	if !p.interactive {
+		return 0, ErrNotInteractive
+	}
+	fmt.Fprintln(p.out, question)
+	for i, o := range options {
+		fmt.Fprintf(p.out, "  %d) %s\n", i+1, o)
+	}
+	for range maxAttempts {
+		answer, err := p.ask(fmt.Sprintf("Choose 1-%d [%d]: ", len(options), def+1))
+		if err != nil {
+			return 0, err
+		}
+		if answer == "" {
+			return def, nil
+		}
+		if n, err := strconv.Atoi(answer); err == nil && n >= 1 && n <= len(options) {
+			return n - 1, nil
+		}
+		fmt.Fprintf(p.out, "%q is not a number between 1 and %d.\n", answer, len(options))
+	}
+	return 0, fmt.Errorf("prompt: no valid choice after %d attempts", maxAttempts)
+}
+
+// Input asks for free text. An empty answer selects def, which is shown in
+// brackets when non-empty.
+func (p *Prompter) Input(question, def string) (string, error) {
+	q := question + ": "
+	if def != "" {
+		q = fmt.Sprintf("%s [%s]: ", question, def)
+	}
+	answer, err := p.ask(q)
+	if err != nil {
+		return "", err
+	}
+	if answer == "" {
+		return def, nil
+	}
+	return answer, nil
+}
+
+// ask writes q and returns the trimmed answer line. End of input before an
+// answer is an error, so a closed stdin never selects a default.
+func (p *Prompter) ask(q string) (string, error) {
+	if !p.interactive {
+		return "", ErrNotInteractive
+	}
+	fmt.Fprint(p.out, q)
+	line, err := p.in.ReadString('\n')
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			return "", err
+		}
+		if line == "" {
+			return "", fmt.Errorf("prompt: no answer: %w", err)
+		}
+	}
+	return strings.TrimSpace(line), nil
+}
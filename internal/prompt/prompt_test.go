@@ -0,0 +1,102 @@
+package prompt
+
+import (
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestConfirm(t *testing.T) {
+	tests := []struct {
+		input string
+		def   bool
+		want  bool
+	}{
+		{"y\n", false, true},
+		{"YES\n", false, true},
+		{"n\n", true, false},
+		{"\n", false, false},
+		{"\n", true, true},
+		{"maybe\n", true, false},
+		{"y", false, true}, // final line without newline
+	}
+	for _, tt := range tests {
+		p := New(strings.NewReader(tt.input), io.Discard, true)
+		got, err := p.Confirm("Proceed?", tt.def)
+		if err != nil || got != tt.want {
+			t.Errorf("Confirm(%q, def=%v) = %v, %v; want %v", tt.input, tt.def, got, err, tt.want)
+		}
+	}
+}
+
+func TestConfirm_HintShowsDefault(t *testing.T) {
+	var out strings.Builder
+	p := New(strings.NewReader("\n"), &out, true)
+	_, _ = p.Confirm("Proceed?", true)
+	if out.String() != "Proceed? [Y/n] " {
+		t.Errorf("got %q", out.String())
+	}
+}
+
+func TestNotInteractive(t *testing.T) {
+	var out strings.Builder
+	p := New(strings.NewReader("y\n"), &out, false)
+	if _, err := p.Confirm("Proceed?", true); !errors.Is(err, ErrNotInteractive) {
+		t.Errorf("Confirm: got %v, want ErrNotInteractive", err)
+	}
+	if _, err := p.Select("Pick", []string{"a"}, 0); !errors.Is(err, ErrNotInteractive) {
+		t.Errorf("Select: got %v, want ErrNotInteractive", err)
+	}
+	if _, err := p.Input("Name", "x"); !errors.Is(err, ErrNotInteractive) {
+		t.Errorf("Input: got %v, want ErrNotInteractive", err)
+	}
+	if out.Len() != 0 {
+		t.Errorf("nothing should be printed, got %q", out.String())
+	}
+}
+
+func TestEOFIsNotAnAnswer(t *testing.T) {
+	p := New(strings.NewReader(""), io.Discard, true)
+	if ok, err := p.Confirm("Proceed?", true); ok || err == nil {
+		t.Errorf("Confirm at EOF = %v, %v; want error", ok, err)
+	}
+}
+
+func TestSelect(t *testing.T) {
+	opts := []string{"alpha", "beta", "gamma"}
+
+	p := New(strings.NewReader("3\n"), io.Discard, true)
+	if got, err := p.Select("Pick", opts, 0); err != nil || got != 2 {
+		t.Errorf("Select(3) = %d, %v", got, err)
+	}
+
+	p = New(strings.NewReader("\n"), io.Discard, true)
+	if got, err := p.Select("Pick", opts, 1); err != nil || got != 1 {
+		t.Errorf("Select(default) = %d, %v", got, err)
+	}
+
+	var out strings.Builder
+	p = New(strings.NewReader("9\nx\n2\n"), &out, true)
+	if got, err := p.Select("Pick", opts, 0); err != nil || got != 1 {
+		t.Errorf("Select after retries = %d, %v", got, err)
+	}
+	if !strings.Contains(out.String(), `"9" is not a number between 1 and 3`) {
+		t.Errorf("expected retry message, got %q", out.String())
+	}
+
+	p = New(strings.NewReader("0\n0\n0\n"), io.Discard, true)
+	if _, err := p.Select("Pick", opts, 0); err == nil {
+		t.Error("expected error after repeated invalid answers")
+	}
+}
+
+func TestInput(t *testing.T) {
+	p := New(strings.NewReader("  custom  \n\n"), io.Discard, true)
+	if got, _ := p.Input("Name", "dflt"); got != "custom" {
+		t.Errorf("Input = %q, want custom", got)
+	}
+	if got, _ := p.Input("Name", "dflt"); got != "dflt" {
+		t.Errorf("Input(empty) = %q, want dflt", got)
+	}
+}
@@ -0,0 +1,157 @@
+// Package recent tracks which plans and tasks a user refers to most often
+// and most recently, so that search results and the agent context can rank
+// them higher. The records live in .logosyncx/recent.jsonl, which is listed
+// in .logosyncx/.gitignore: they describe one user's reading habits, not
+// the project.
+//
+// Each record keeps a score that grows by one per referral and halves every
+// HalfLife, so a plan read often last month ranks below one read a few
+// times this week. At most MaxEntries records are kept; the least recently
+// referred ones are dropped first.
+package recent
+
+import (
+	"cmp"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/filelock"
+	"github.com/senna-lang/logosyncx/internal/jsonl"
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// FileName is the name of the records file under .logosyncx/.
+const FileName = "recent.jsonl"
+
+// HalfLife is how long it takes a score to halve.
+const HalfLife = 14 * 24 * time.Hour
+
+// MaxEntries is the number of records kept.
+const MaxEntries = 500
+
+// Kinds of referred items.
+const (
+	KindPlan = "plan"
+	KindTask = "task"
+)
+
+// Entry is the referral record of one plan or task.
+type Entry struct {
+	Kind string `json:"kind"`
+	// Name is the plan filename, or <plan-slug>/<task-dir> for a task.
+	Name  string    `json:"name"`
+	Count int       `json:"count"`
+	Last  time.Time `json:"last"`
+	// Score is the decayed referral count as of Last; see ScoreAt.
+	Score float64 `json:"score"`
+}
+
+// ScoreAt returns e's score decayed to now.
+func (e Entry) ScoreAt(now time.Time) float64 {
+	elapsed := now.Sub(e.Last)
+	if elapsed <= 0 {
+		return e.Score
+	}
+	return e.Score * math.Exp2(-float64(elapsed)/float64(HalfLife))
+}
+
+// Path returns the path of the records file.
+func Path(projectRoot string) string {
+	return filepath.Join(projectRoot, config.DirName, FileName)
+}
+
+// Load returns every record. A missing file yields no records and no error.
+// The records only affect ranking, so malformed lines are dropped silently;
+// the next Record rewrites the file without them.
+func Load(projectRoot string) ([]Entry, error) {
+	entries, err := jsonl.ReadTolerant[Entry](Path(projectRoot))
+	var skipped *jsonl.SkippedError
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+		return nil, nil
+	case err != nil && !errors.As(err, &skipped) && !errors.Is(err, jsonl.ErrPartial):
+		return nil, err
+	}
+	return entries, nil
+}
+
+// Record counts one referral of the item of kind named name at now.
+func Record(projectRoot, kind, name string, now time.Time) error {
+	path := Path(projectRoot)
+	err := filelock.With(path, filelock.DefaultTimeout, func() error {
+		entries, err := Load(projectRoot)
+		if err != nil {
+			return err
+		}
+		i := slices.IndexFunc(entries, func(e Entry) bool { return e.Kind == kind && e.Name == name })
+		if i < 0 {
+			entries = append(entries, Entry{Kind: kind, Name: name})
+			i = len(entries) - 1
+		}
+		e := &entries[i]
+		e.Score = e.ScoreAt(now) + 1
+		e.Count++
+		e.Last = now
+		if len(entries) > MaxEntries {
+			slices.SortStableFunc(entries, func(a, b Entry) int { return b.Last.Compare(a.Last) })
+			entries = entries[:MaxEntries]
+		}
+		return jsonl.WriteAtomic(path, entries)
+	})
+	if err != nil {
+		return err
+	}
+	return config.IgnoreLocal(projectRoot, FileName)
+}
+
+// Rename renames the records of kind through rename, which returns a
+// record's new name (or its name unchanged), so that renamed plans and
+// tasks keep their ranking. Nothing is written when no name changes.
+func Rename(projectRoot, kind string, rename func(name string) string) error {
+	path := Path(projectRoot)
+	return filelock.With(path, filelock.DefaultTimeout, func() error {
+		entries, err := Load(projectRoot)
+		if err != nil || len(entries) == 0 {
+			return err
+		}
+		changed := false
+		for i, e := range entries {
+			if e.Kind != kind {
+				continue
+			}
+			if name := rename(e.Name); name != e.Name {
+				entries[i].Name = name
+				changed = true
+			}
+		}
+		if !changed {
+			return nil
+		}
+		return jsonl.WriteAtomic(path, entries)
+	})
+}
+
+// Scores returns the score at now of every item of kind, by name.
+func Scores(entries []Entry, kind string, now time.Time) map[string]float64 {
+	scores := map[string]float64{}
+	for _, e := range entries {
+		if e.Kind == kind {
+			scores[e.Name] = e.ScoreAt(now)
+		}
+	}
+	return scores
+}
+
+// Ranked returns entries ordered by their score at now, highest first;
+// ties go to the most recently referred.
+func Ranked(entries []Entry, now time.Time) []Entry {
+	out := slices.Clone(entries)
+	slices.SortStableFunc(out, func(a, b Entry) int {
+		return cmp.Or(cmp.Compare(b.ScoreAt(now), a.ScoreAt(now)), b.Last.Compare(a.Last), cmp.Compare(a.Name, b.Name))
+	})
+	return out
+}
@@ -0,0 +1,110 @@
+package recent
+
+import (
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecord_CountsAndDecays(t *testing.T) {
+	dir := t.TempDir()
+	start := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+
+	for i := 0; i < 3; i++ {
+		if err := Record(dir, KindPlan, "20260501-old.md", start); err != nil {
+			t.Fatal(err)
+		}
+	}
+	later := start.Add(2 * HalfLife)
+	if err := Record(dir, KindPlan, "20260601-new.md", later); err != nil {
+		t.Fatal(err)
+	}
+	if err := Record(dir, KindTask, "20260501-old/001-fix", later); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Load(dir)
+	if err != nil || len(entries) != 3 {
+		t.Fatalf("Load = %v, %v", entries, err)
+	}
+	scores := Scores(entries, KindPlan, later)
+	if got := scores["20260501-old.md"]; got != 0.75 {
+		t.Errorf("old score = %v, want 3 halved twice", got)
+	}
+	if got := scores["20260601-new.md"]; got != 1 {
+		t.Errorf("new score = %v, want 1", got)
+	}
+	ranked := Ranked(entries, later)
+	if ranked[0].Name == "20260501-old.md" || ranked[2].Name != "20260501-old.md" || ranked[2].Count != 3 {
+		t.Errorf("ranked = %+v, want the old plan last", ranked)
+	}
+}
+
+func TestRecord_IgnoresFileInGit(t *testing.T) {
+	dir := t.TempDir()
+	if err := Record(dir, KindPlan, "p.md", time.Now()); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dir, ".logosyncx", ".gitignore"))
+	if err != nil || !strings.Contains(string(data), FileName+"\n") {
+		t.Errorf(".gitignore = %q, %v; want %s listed", data, err, FileName)
+	}
+}
+
+func TestRecord_DropsLeastRecentlyUsed(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+	var rows strings.Builder
+	for i := 0; i < MaxEntries; i++ {
+		rows.WriteString(`{"kind":"plan","name":"p` + strconv.Itoa(i) + `","count":1,"last":"` + at.Add(time.Duration(i)*time.Minute).Format(time.RFC3339) + `","score":1}` + "\n")
+	}
+	rows.WriteString("not json\n")
+	if err := os.MkdirAll(filepath.Dir(Path(dir)), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(Path(dir), []byte(rows.String()), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Record(dir, KindTask, "plan/001-new", at.Add(time.Hour*24)); err != nil {
+		t.Fatal(err)
+	}
+	entries, err := Load(dir)
+	if err != nil || len(entries) != MaxEntries {
+		t.Fatalf("Load = %d entries, %v; want %d", len(entries), err, MaxEntries)
+	}
+	for _, e := range entries {
+		if e.Last.Equal(at) {
+			t.Fatal("expected the least recently referred entry to be dropped")
+		}
+	}
+}
+
+func TestRename_KeepsCountsUnderNewName(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 6, 1, 9, 0, 0, 0, time.UTC)
+	Record(dir, KindPlan, "20260501-old.md", now)
+	Record(dir, KindPlan, "20260501-old.md", now)
+	Record(dir, KindTask, "20260501-old/001-fix", now)
+
+	err := Rename(dir, KindPlan, func(name string) string {
+		if name == "20260501-old.md" {
+			return "20260501-new.md"
+		}
+		return name
+	})
+	if err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	entries, _ := Load(dir)
+	scores := Scores(entries, KindPlan, now)
+	if scores["20260501-new.md"] != 2 || scores["20260501-old.md"] != 0 {
+		t.Errorf("plan scores = %v, want the count moved to the new name", scores)
+	}
+	if Scores(entries, KindTask, now)["20260501-old/001-fix"] != 1 {
+		t.Errorf("task records must not be renamed with plans: %+v", entries)
+	}
+}
@@ -0,0 +1,196 @@
+// Package registry keeps the per-user list of logosyncx projects on this
+// machine, so that a command run in one checkout can read another
+// project's plans by name (logos ls --project <name>).
+//
+// The list lives in <user config dir>/logosyncx/projects.json. logos init
+// adds each project it creates; logos projects add registers one created
+// before the registry existed. Entries are keyed by root: registering a
+// root again keeps its name.
+package registry
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/filelock"
+)
+
+// Project is one registered project.
+type Project struct {
+	// Name identifies the project in logos ls --project. It is the
+	// project's config "project" name, with "-2", "-3", ... appended when
+	// another root already uses it.
+	Name string `json:"name"`
+	// Root is the absolute path of the directory holding .logosyncx/.
+	Root string `json:"root"`
+	// RegisteredAt is when the project was first registered.
+	RegisteredAt time.Time `json:"registered_at"`
+}
+
+// Exists reports whether p's root still holds a .logosyncx/ directory.
+func (p Project) Exists() bool {
+	info, err := os.Stat(filepath.Join(p.Root, ".logosyncx"))
+	return err == nil && info.IsDir()
+}
+
+type file struct {
+	Projects []Project `json:"projects"`
+}
+
+// Path returns the path of the registry file.
+func Path() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "logosyncx", "projects.json"), nil
+}
+
+// Load returns the registered projects sorted by name. A missing registry
+// is empty, not an error.
+func Load() ([]Project, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+	return load(path)
+}
+
+func load(path string) ([]Project, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var f file
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, fmt.Errorf("parse %s: %w", path, err)
+	}
+	sort.Slice(f.Projects, func(i, j int) bool { return f.Projects[i].Name < f.Projects[j].Name })
+	return f.Projects, nil
+}
+
+// Register adds the project at root under name and returns its entry.
+// When root is already registered its existing entry is returned unchanged.
+func Register(name, root string) (Project, error) {
+	root, err := filepath.Abs(root)
+	if err != nil {
+		return Project{}, err
+	}
+	var out Project
+	err = update(func(projects []Project) []Project {
+		taken := map[string]bool{}
+		for _, p := range projects {
+			if p.Root == root {
+				out = p
+				return projects
+			}
+			taken[p.Name] = true
+		}
+		unique := name
+		for n := 2; taken[unique]; n++ {
+			unique = name + "-" + strconv.Itoa(n)
+		}
+		out = Project{Name: unique, Root: root, RegisteredAt: time.Now().UTC().Truncate(time.Second)}
+		return append(projects, out)
+	})
+	return out, err
+}
+
+// Rename changes the name of the project at root to name, appending "-2",
+// "-3", ... when another root already uses it, and returns the entry with
+// the name it had before ("" when root was not registered, in which case
+// it is registered now).
+func Rename(root, name string) (p Project, oldName string, err error) {
+	root, err = filepath.Abs(root)
+	if err != nil {
+		return Project{}, "", err
+	}
+	err = update(func(projects []Project) []Project {
+		taken := map[string]bool{}
+		at := -1
+		for i, q := range projects {
+			if q.Root == root {
+				at = i
+				continue
+			}
+			taken[q.Name] = true
+		}
+		unique := name
+		for n := 2; taken[unique]; n++ {
+			unique = name + "-" + strconv.Itoa(n)
+		}
+		if at < 0 {
+			p = Project{Name: unique, Root: root, RegisteredAt: time.Now().UTC().Truncate(time.Second)}
+			return append(projects, p)
+		}
+		oldName = projects[at].Name
+		projects[at].Name = unique
+		p = projects[at]
+		return projects
+	})
+	return p, oldName, err
+}
+
+// Remove drops the project called name. It returns false when there is no
+// such project.
+func Remove(name string) (bool, error) {
+	removed := false
+	err := update(func(projects []Project) []Project {
+		kept := projects[:0]
+		for _, p := range projects {
+			if p.Name == name {
+				removed = true
+				continue
+			}
+			kept = append(kept, p)
+		}
+		return kept
+	})
+	return removed, err
+}
+
+// Find returns the project called name.
+func Find(name string) (Project, error) {
+	projects, err := Load()
+	if err != nil {
+		return Project{}, err
+	}
+	for _, p := range projects {
+		if p.Name == name {
+			return p, nil
+		}
+	}
+	return Project{}, fmt.Errorf("no registered project named %q (see `logos projects ls`)", name)
+}
+
+// update rewrites the registry with fn's result while holding its lock.
+func update(fn func([]Project) []Project) error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+	return filelock.With(path, filelock.DefaultTimeout, func() error {
+		projects, err := load(path)
+		if err != nil {
+			return err
+		}
+		data, err := json.MarshalIndent(file{Projects: fn(projects)}, "", "  ")
+		if err != nil {
+			return err
+		}
+		tmp := path + ".tmp"
+		if err := os.WriteFile(tmp, append(data, '\n'), 0o644); err != nil {
+			return err
+		}
+		return os.Rename(tmp, path)
+	})
+}
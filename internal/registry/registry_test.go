@@ -0,0 +1,143 @@
+package registry
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func useTempConfigDir(t *testing.T) {
+	t.Helper()
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("AppData", dir)
+}
+
+func TestLoad_MissingRegistryIsEmpty(t *testing.T) {
+	useTempConfigDir(t)
+	projects, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(projects) != 0 {
+		t.Errorf("Load = %v, want none", projects)
+	}
+}
+
+func TestRegister_AddsFindsAndKeepsExisting(t *testing.T) {
+	useTempConfigDir(t)
+	root := t.TempDir()
+
+	p, err := Register("api", root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.Name != "api" || p.Root != root || p.RegisteredAt.IsZero() {
+		t.Errorf("Register = %+v", p)
+	}
+	again, err := Register("renamed", root)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if again != p {
+		t.Errorf("re-registering returned %+v, want %+v", again, p)
+	}
+
+	got, err := Find("api")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Root != root {
+		t.Errorf("Find root = %q, want %q", got.Root, root)
+	}
+	if _, err := Find("web"); err == nil {
+		t.Error("Find of an unknown name should fail")
+	}
+}
+
+func TestRegister_SuffixesTakenNames(t *testing.T) {
+	useTempConfigDir(t)
+	for i := 0; i < 3; i++ {
+		if _, err := Register("api", t.TempDir()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	projects, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, p := range projects {
+		names = append(names, p.Name)
+	}
+	want := []string{"api", "api-2", "api-3"}
+	if len(names) != len(want) {
+		t.Fatalf("names = %v, want %v", names, want)
+	}
+	for i := range want {
+		if names[i] != want[i] {
+			t.Errorf("names = %v, want %v", names, want)
+			break
+		}
+	}
+}
+
+func TestRename(t *testing.T) {
+	useTempConfigDir(t)
+	api, web := t.TempDir(), t.TempDir()
+	if _, err := Register("api", api); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Register("web", web); err != nil {
+		t.Fatal(err)
+	}
+
+	p, old, err := Rename(api, "gateway")
+	if err != nil || p.Name != "gateway" || p.Root != api || old != "api" {
+		t.Errorf("Rename = %+v, %q, %v", p, old, err)
+	}
+	if _, err := Find("api"); err == nil {
+		t.Error("the old name is still registered")
+	}
+	if p, _, _ := Rename(web, "gateway"); p.Name != "gateway-2" {
+		t.Errorf("Rename onto a taken name = %q, want gateway-2", p.Name)
+	}
+	other := t.TempDir()
+	if p, old, err := Rename(other, "fresh"); err != nil || p.Name != "fresh" || old != "" {
+		t.Errorf("Rename of an unregistered root = %+v, %q, %v", p, old, err)
+	}
+	if projects, _ := Load(); len(projects) != 3 {
+		t.Errorf("projects = %v, want 3", projects)
+	}
+}
+
+func TestRemove(t *testing.T) {
+	useTempConfigDir(t)
+	if _, err := Register("api", t.TempDir()); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := Remove("web"); err != nil || ok {
+		t.Errorf("Remove(web) = %v, %v; want false, nil", ok, err)
+	}
+	if ok, err := Remove("api"); err != nil || !ok {
+		t.Errorf("Remove(api) = %v, %v; want true, nil", ok, err)
+	}
+	if projects, _ := Load(); len(projects) != 0 {
+		t.Errorf("projects after Remove = %v", projects)
+	}
+}
+
+func TestProject_Exists(t *testing.T) {
+	root := t.TempDir()
+	p := Project{Name: "api", Root: root}
+	if p.Exists() {
+		t.Error("Exists without .logosyncx/ should be false")
+	}
+	if err := os.Mkdir(filepath.Join(root, ".logosyncx"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if !p.Exists() {
+		t.Error("Exists with .logosyncx/ should be true")
+	}
+}
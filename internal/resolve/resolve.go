@@ -0,0 +1,77 @@
+// Package resolve picks the plans or tasks a name typed on the command line
+// refers to. Matching is tiered so that a precise name is never reported as
+// ambiguous just because it also occurs inside other names: an exact
+// filename, slug, or title wins over an exact ID, which wins over a
+// substring match.
+package resolve
+
+import "strings"
+
+// Keys are the strings an item can be named by.
+type Keys struct {
+	// Names are matched exactly first: the filename stem, the slug, the
+	// topic or title.
+	Names []string
+	// ID is matched exactly when no name matches.
+	ID string
+	// Partial are matched as case-insensitive substrings when neither
+	// names nor ID match exactly.
+	Partial []string
+}
+
+// Match returns the items name refers to. The tiers — an exact,
+// case-insensitive match on one of Names, an exact match on ID, a substring
+// of one of Partial — are tried in order, and the first tier holding
+// exactly one item returns it alone. When a tier holds several, they are
+// returned together with every looser match so that the caller can report
+// the ambiguity with all candidates. No match at all returns nil.
+func Match[T any](items []T, name string, keys func(T) Keys) []T {
+	lower := strings.ToLower(name)
+
+	var exact, byID, partial []T
+	for _, it := range items {
+		k := keys(it)
+		switch {
+		case anyEqualFold(k.Names, name):
+			exact = append(exact, it)
+		case k.ID != "" && strings.EqualFold(k.ID, name):
+			byID = append(byID, it)
+		case anyContains(k.Partial, lower):
+			partial = append(partial, it)
+		}
+	}
+
+	tiers := [][]T{exact, byID, partial}
+	for i, tier := range tiers {
+		switch len(tier) {
+		case 0:
+			continue
+		case 1:
+			return tier
+		}
+		var out []T
+		for _, rest := range tiers[i:] {
+			out = append(out, rest...)
+		}
+		return out
+	}
+	return nil
+}
+
+func anyEqualFold(list []string, name string) bool {
+	for _, s := range list {
+		if s != "" && strings.EqualFold(s, name) {
+			return true
+		}
+	}
+	return false
+}
+
+func anyContains(list []string, lower string) bool {
+	for _, s := range list {
+		if strings.Contains(strings.ToLower(s), lower) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package resolve
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+type item struct{ name, id string }
+
+func keys(it item) Keys {
+	return Keys{Names: []string{it.name}, ID: it.id, Partial: []string{it.name, it.id}}
+}
+
+func names(items []item) []string {
+	var out []string
+	for _, it := range items {
+		out = append(out, it.name)
+	}
+	return out
+}
+
+func TestMatch_Tiers(t *testing.T) {
+	items := []item{
+		{"auth", "x1"},
+		{"auth-refresh", "x2"},
+		{"oauth", "auth2"},
+		{"billing", "b1"},
+	}
+	cases := []struct {
+		name string
+		want []string
+	}{
+		{"auth", []string{"auth"}},   // exact name beats substrings
+		{"AUTH", []string{"auth"}},   // case-insensitive
+		{"auth2", []string{"oauth"}}, // exact ID beats a substring
+		{"refresh", []string{"auth-refresh"}},
+		{"aut", []string{"auth", "auth-refresh", "oauth"}},
+		{"zzz", nil},
+	}
+	for _, c := range cases {
+		got := names(Match(items, c.name, keys))
+		if !slices.Equal(got, c.want) {
+			t.Errorf("Match(%q) = %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestMatch_AmbiguousTierKeepsLooserMatches(t *testing.T) {
+	items := []item{{"auth", "a"}, {"auth", "b"}, {"auth-refresh", "c"}}
+	got := Match(items, "auth", keys)
+	if len(got) != 3 || strings.Join(names(got), ",") != "auth,auth,auth-refresh" {
+		t.Errorf("Match = %v, want both exact matches then the partial one", got)
+	}
+}
+
+func TestMatch_IDBeatsPartial(t *testing.T) {
+	items := []item{{"t-12-notes", "n"}, {"other", "t-12"}}
+	got := names(Match(items, "t-12", keys))
+	if !slices.Equal(got, []string{"other"}) {
+		t.Errorf("Match = %v, want the exact ID match", got)
+	}
+}
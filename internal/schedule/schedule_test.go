@@ -0,0 +1,50 @@
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func at(s string) time.Time {
+	t, err := time.Parse("2006-01-02 15:04", s)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func TestMatches(t *testing.T) {
+	for _, tc := range []struct {
+		expr, time string
+		want       bool
+	}{
+		{"0 3 * * *", "2026-03-04 03:00", true},
+		{"0 3 * * *", "2026-03-04 03:01", false},
+		{"*/15 * * * *", "2026-03-04 10:45", true},
+		{"*/15 * * * *", "2026-03-04 10:50", false},
+		{"0 9-17/4 * * 1-5", "2026-03-04 13:00", true},  // Wednesday
+		{"0 9-17/4 * * 1-5", "2026-03-07 13:00", false}, // Saturday
+		{"30 4 * * 7", "2026-03-08 04:30", true},        // Sunday as 7
+		{"0 0 1 * 1", "2026-03-02 00:00", true},         // Monday, not the 1st: either day field
+		{"0 0 1 * 1", "2026-03-03 00:00", false},
+		{"0 0 1,15 6 *", "2026-06-15 00:00", true},
+		{"@weekly", "2026-03-08 00:00", true},
+		{"@daily", "2026-03-08 00:01", false},
+	} {
+		s, err := Parse(tc.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tc.expr, err)
+		}
+		if got := s.Matches(at(tc.time)); got != tc.want {
+			t.Errorf("%q at %s = %v, want %v", tc.expr, tc.time, got, tc.want)
+		}
+	}
+}
+
+func TestParse_Errors(t *testing.T) {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *", "@yearly"} {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q): expected an error", expr)
+		}
+	}
+}
@@ -0,0 +1,33 @@
+package suggest
+
+import "testing"
+
+func TestDistance(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"", "", 0},
+		{"abc", "", 3},
+		{"kitten", "sitting", 3},
+		{"認証", "認可", 1},
+	}
+	for _, c := range cases {
+		if got := Distance(c.a, c.b); got != c.want {
+			t.Errorf("Distance(%q, %q) = %d, want %d", c.a, c.b, got, c.want)
+		}
+	}
+}
+
+func TestClosest(t *testing.T) {
+	candidates := []string{"backend", "frontend", "infra"}
+	if got := Closest("bakend", candidates); got != "backend" {
+		t.Errorf("Closest(bakend) = %q, want backend", got)
+	}
+	if got := Closest("INFRA", candidates); got != "infra" {
+		t.Errorf("Closest(INFRA) = %q, want infra", got)
+	}
+	if got := Closest("database", candidates); got != "" {
+		t.Errorf("Closest(database) = %q, want no suggestion", got)
+	}
+}
@@ -0,0 +1,217 @@
+// archive.go moves purged or archived tasks out of .logosyncx/tasks/ into
+// .logosyncx/tasks-archive/<plan>/<NNN-title>/ instead of deleting them, so
+// they can be listed and restored later. The archive lives
+// outside the tasks directory so it is never indexed or reported as stray;
+// logos gc purge deletes it permanently.
+package task
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+)
+
+const archiveDirName = "tasks-archive"
+
+// ArchiveDir returns the path of the task archive under projectRoot.
+func ArchiveDir(projectRoot string) string {
+	return filepath.Join(projectRoot, ".logosyncx", archiveDirName)
+}
+
+// PurgeFilter selects tasks for Purge. Zero fields match everything.
+type PurgeFilter struct {
+	Statuses []Status  // any of these
+	PlanSlug string    // exact plan slug
+	Tag      string    // case-insensitive
+	Before   time.Time // task completed, started, or created before this time (see purgeAge)
+}
+
+// matches reports whether t is selected by f.
+func (f PurgeFilter) matches(t *Task) bool {
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, t.Status) {
+		return false
+	}
+	if f.PlanSlug != "" && t.Plan != f.PlanSlug {
+		return false
+	}
+	if f.Tag != "" && !hasAnyTag(t.Tags, []string{f.Tag}) {
+		return false
+	}
+	if !f.Before.IsZero() && !purgeAge(t).Before(f.Before) {
+		return false
+	}
+	return true
+}
+
+// purgeAge is the time a task's age is measured from: its completion time
+// when done, its start time when in progress, otherwise its creation date.
+func purgeAge(t *Task) time.Time {
+	switch {
+	case t.CompletedAt != nil:
+		return *t.CompletedAt
+	case t.Status == StatusInProgress && t.StartedAt != nil:
+		return *t.StartedAt
+	}
+	return t.Date
+}
+
+// PurgeCandidates returns the tasks matching f, split into those that can be
+// archived and those kept because a task that stays behind depends on them
+// (archiving those would leave the dependent blocked forever). An unknown
+// status in f is an error.
+func (s *Store) PurgeCandidates(f PurgeFilter) (purge, kept []*Task, err error) {
+	if err := (Filter{Statuses: f.Statuses}).Validate(); err != nil {
+		return nil, nil, err
+	}
+	tasks, loadErr := s.loadAll()
+
+	selected := map[string]bool{}
+	for _, t := range tasks {
+		if f.matches(t) {
+			selected[t.DirPath] = true
+		}
+	}
+
+	// A selected task is kept while any unselected task in its plan depends
+	// on it. Keeping a task can in turn keep its own dependencies, so repeat
+	// until nothing changes.
+	for changed := true; changed; {
+		changed = false
+		for _, t := range tasks {
+			if selected[t.DirPath] {
+				continue
+			}
+			for _, dep := range tasks {
+				if dep.Plan == t.Plan && selected[dep.DirPath] && slices.Contains(t.DependsOn, dep.Seq) {
+					selected[dep.DirPath] = false
+					kept = append(kept, dep)
+					changed = true
+				}
+			}
+		}
+	}
+
+	for _, t := range tasks {
+		if selected[t.DirPath] {
+			purge = append(purge, t)
+		}
+	}
+	sortByDateDesc(purge)
+	return purge, kept, loadErr
+}
+
+// Archive moves each task directory into ArchiveDir, keeping its
+// <plan>/<NNN-title> path (a timestamp suffix is added if that path is
+// already taken), then rebuilds the task index. Returns the number of tasks
+// archived.
+func (s *Store) Archive(tasks []*Task) (int, error) {
+	n := 0
+	for _, t := range tasks {
+		dst := filepath.Join(ArchiveDir(s.projectRoot), t.Plan, filepath.Base(t.DirPath))
+		if _, err := os.Stat(dst); err == nil {
+			dst += "-" + time.Now().Format("20060102150405")
+		}
+		if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+			return n, fmt.Errorf("create task archive: %w", err)
+		}
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Remove(s.projectRoot, t.DirPath)
+		}
+		if err := os.Rename(t.DirPath, dst); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return n, fmt.Errorf("archive %s: %w", t.DirPath, err)
+		}
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Add(s.projectRoot, dst)
+		}
+		n++
+	}
+
+	_, _ = s.RebuildTaskIndex()
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+	}
+	return n, nil
+}
+
+// ArchivedTask is a task in the archive. Rel is its directory relative to
+// ArchiveDir ("<plan>/<NNN-title>").
+type ArchivedTask struct {
+	Rel  string
+	Task *Task
+}
+
+// ListArchived returns every task in the archive, sorted by Rel. Task
+// files that cannot be read or parsed are skipped and their errors joined.
+func (s *Store) ListArchived() ([]ArchivedTask, error) {
+	root := ArchiveDir(s.projectRoot)
+	planDirs, err := os.ReadDir(root)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read task archive: %w", err)
+	}
+	var out []ArchivedTask
+	var errs []error
+	for _, pd := range planDirs {
+		if !pd.IsDir() {
+			continue
+		}
+		taskDirs, err := os.ReadDir(filepath.Join(root, pd.Name()))
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, td := range taskDirs {
+			if !td.IsDir() {
+				continue
+			}
+			rel := filepath.Join(pd.Name(), td.Name())
+			t, err := s.loadFile(filepath.Join(root, rel, taskFileName))
+			if err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", rel, err))
+				continue
+			}
+			out = append(out, ArchivedTask{Rel: rel, Task: t})
+		}
+	}
+	return out, errors.Join(errs...)
+}
+
+// archiveSuffix matches the timestamp Archive appends when a path in the
+// archive is already taken.
+var archiveSuffix = regexp.MustCompile(`-\d{14}$`)
+
+// Restore moves the archived task directory rel (as in ArchivedTask.Rel)
+// back to .logosyncx/tasks/<plan>/<NNN-title>, dropping a timestamp suffix
+// added by Archive, then rebuilds the task index. It fails when a task
+// already occupies that path. Returns the restored directory.
+func (s *Store) Restore(rel string) (string, error) {
+	src := filepath.Join(ArchiveDir(s.projectRoot), rel)
+	dst := filepath.Join(s.dir, filepath.Dir(rel), archiveSuffix.ReplaceAllString(filepath.Base(rel), ""))
+	if _, err := os.Stat(dst); err == nil {
+		return "", fmt.Errorf("cannot restore %s: %s already exists", rel, dst)
+	}
+	if err := os.MkdirAll(filepath.Dir(dst), 0o755); err != nil {
+		return "", fmt.Errorf("create tasks dir: %w", err)
+	}
+	if err := os.Rename(src, dst); err != nil {
+		return "", fmt.Errorf("restore %s: %w", rel, err)
+	}
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Remove(s.projectRoot, src)
+		_ = gitutil.Add(s.projectRoot, dst)
+	}
+
+	_, _ = s.RebuildTaskIndex()
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+	}
+	return dst, nil
+}
@@ -0,0 +1,176 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// setCompletedAt rewrites tk's TASK.md with the given completion time.
+func setCompletedAt(t *testing.T, tk *Task, at time.Time) {
+	t.Helper()
+	tk.CompletedAt = &at
+	data, err := Marshal(*tk)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tk.DirPath, taskFileName), data, 0o644); err != nil {
+		t.Fatalf("write TASK.md: %v", err)
+	}
+}
+
+func TestPurgeCandidates_FiltersByStatusAgeAndTag(t *testing.T) {
+	_, store := setupStore(t)
+	now := time.Now()
+
+	old := createTask(t, store, "plan-a", "Old done", "done", "medium", nil)
+	setCompletedAt(t, old, now.AddDate(0, 0, -40))
+	recent := createTask(t, store, "plan-a", "Recent done", "done", "medium", nil)
+	setCompletedAt(t, recent, now.AddDate(0, 0, -5))
+	createTask(t, store, "plan-a", "Still open", "open", "medium", nil)
+
+	purge, kept, err := store.PurgeCandidates(PurgeFilter{Statuses: []Status{StatusDone}, Before: now.AddDate(0, 0, -30)})
+	if err != nil {
+		t.Fatalf("PurgeCandidates: %v", err)
+	}
+	if len(purge) != 1 || purge[0].Title != "Old done" || len(kept) != 0 {
+		t.Fatalf("purge = %v, kept = %v; want only 'Old done'", purge, kept)
+	}
+
+	purge, _, _ = store.PurgeCandidates(PurgeFilter{Statuses: []Status{StatusDone}, Tag: "nope"})
+	if len(purge) != 0 {
+		t.Errorf("tag filter should exclude untagged tasks, got %d", len(purge))
+	}
+}
+
+func TestPurgeCandidates_InProgressAgeCountsFromStart(t *testing.T) {
+	_, store := setupStore(t)
+	now := time.Now()
+
+	// Both tasks were created long ago; only the one started long ago is stale.
+	for _, tc := range []struct {
+		title     string
+		startedAt time.Time
+	}{
+		{"Stale work", now.AddDate(0, 0, -40)},
+		{"Fresh work", now.AddDate(0, 0, -5)},
+	} {
+		tk := createTask(t, store, "plan-a", tc.title, "in_progress", "medium", nil)
+		tk.Date = now.AddDate(0, 0, -60)
+		tk.StartedAt = &tc.startedAt
+		data, err := Marshal(*tk)
+		if err != nil {
+			t.Fatalf("Marshal: %v", err)
+		}
+		if err := os.WriteFile(filepath.Join(tk.DirPath, taskFileName), data, 0o644); err != nil {
+			t.Fatalf("write TASK.md: %v", err)
+		}
+	}
+
+	purge, _, err := store.PurgeCandidates(PurgeFilter{Statuses: []Status{StatusInProgress}, Before: now.AddDate(0, 0, -30)})
+	if err != nil {
+		t.Fatalf("PurgeCandidates: %v", err)
+	}
+	if len(purge) != 1 || purge[0].Title != "Stale work" {
+		t.Errorf("purge = %v, want only 'Stale work'", purge)
+	}
+}
+
+func TestPurgeCandidates_KeepsDependenciesOfRemainingTasks(t *testing.T) {
+	_, store := setupStore(t)
+
+	dep := createTask(t, store, "plan-a", "Base work", "done", "medium", nil)
+	createTask(t, store, "plan-a", "Follow-up", "open", "medium", []int{dep.Seq})
+	createTask(t, store, "plan-a", "Unrelated", "done", "medium", nil)
+
+	purge, kept, err := store.PurgeCandidates(PurgeFilter{Statuses: []Status{StatusDone}})
+	if err != nil {
+		t.Fatalf("PurgeCandidates: %v", err)
+	}
+	if len(purge) != 1 || purge[0].Title != "Unrelated" {
+		t.Errorf("purge = %v, want only 'Unrelated'", purge)
+	}
+	if len(kept) != 1 || kept[0].Title != "Base work" {
+		t.Errorf("kept = %v, want 'Base work'", kept)
+	}
+}
+
+func TestArchive_MovesTaskDirOutOfTasks(t *testing.T) {
+	dir, store := setupStore(t)
+	tk := createTask(t, store, "plan-a", "Archive me", "done", "medium", nil)
+
+	n, err := store.Archive([]*Task{tk})
+	if err != nil || n != 1 {
+		t.Fatalf("Archive = %d, %v", n, err)
+	}
+	if _, err := os.Stat(tk.DirPath); !os.IsNotExist(err) {
+		t.Errorf("task dir should be gone, stat err = %v", err)
+	}
+	archived := filepath.Join(ArchiveDir(dir), "plan-a", filepath.Base(tk.DirPath), taskFileName)
+	if _, err := os.Stat(archived); err != nil {
+		t.Errorf("expected archived TASK.md at %s: %v", archived, err)
+	}
+	entries, _ := ReadAllTaskIndex(dir)
+	if len(entries) != 0 {
+		t.Errorf("archived task should drop out of the index, got %d entries", len(entries))
+	}
+	if strays, _ := store.FindStrays(); len(strays) != 0 {
+		t.Errorf("archive must not produce strays, got %v", strays)
+	}
+}
+
+func TestRestore_ReturnsArchivedTask(t *testing.T) {
+	dir, store := setupStore(t)
+	tk := createTask(t, store, "plan-a", "Bring me back", "open", "medium", nil)
+	if _, err := store.Archive([]*Task{tk}); err != nil {
+		t.Fatal(err)
+	}
+
+	archived, err := store.ListArchived()
+	if err != nil || len(archived) != 1 || archived[0].Task.Title != "Bring me back" {
+		t.Fatalf("ListArchived = %+v, %v", archived, err)
+	}
+	rel := archived[0].Rel
+	if want := filepath.Join("plan-a", filepath.Base(tk.DirPath)); rel != want {
+		t.Errorf("Rel = %q, want %q", rel, want)
+	}
+
+	dst, err := store.Restore(rel)
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if dst != tk.DirPath {
+		t.Errorf("restored to %s, want %s", dst, tk.DirPath)
+	}
+	if entries, _ := ReadAllTaskIndex(dir); len(entries) != 1 {
+		t.Errorf("restored task should be back in the index, got %d entries", len(entries))
+	}
+	if archived, _ := store.ListArchived(); len(archived) != 0 {
+		t.Errorf("archive should be empty, got %+v", archived)
+	}
+}
+
+func TestRestore_DropsTimestampSuffixAndRefusesToOverwrite(t *testing.T) {
+	_, store := setupStore(t)
+	tk := createTask(t, store, "plan-a", "Twice", "done", "medium", nil)
+	base := filepath.Base(tk.DirPath)
+	suffixed := filepath.Join(ArchiveDir(store.projectRoot), "plan-a", base+"-20260101120000")
+	if err := os.MkdirAll(suffixed, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := store.Restore(filepath.Join("plan-a", base+"-20260101120000")); err == nil {
+		t.Fatal("expected an error while the active task occupies the path")
+	}
+	if _, err := store.Archive([]*Task{tk}); err != nil {
+		t.Fatal(err)
+	}
+	dst, err := store.Restore(filepath.Join("plan-a", base+"-20260101120000"))
+	if err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if filepath.Base(dst) != base {
+		t.Errorf("restored to %s, want the suffix dropped", dst)
+	}
+}
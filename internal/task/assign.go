@@ -0,0 +1,85 @@
+package task
+
+import (
+	"slices"
+	"strings"
+)
+
+// AssigneeLoad counts the unfinished tasks assigned to one person.
+type AssigneeLoad struct {
+	Assignee   string `json:"assignee"`
+	Open       int    `json:"open"`
+	InProgress int    `json:"in_progress"`
+	// InRoster is true when Assignee is listed in config tasks.roster.
+	InRoster bool `json:"in_roster"`
+}
+
+// Active returns the number of open plus in-progress tasks.
+func (l AssigneeLoad) Active() int {
+	return l.Open + l.InProgress
+}
+
+// ComputeLoad returns the unfinished-task load of every roster member and
+// every other assignee found in tasks. Roster members come first in roster
+// order (so members with no tasks are still listed), followed by the
+// remaining assignees sorted by name. Unassigned and done tasks are ignored.
+// Names are compared case-insensitively; the roster spelling wins.
+func ComputeLoad(tasks []*Task, roster []string) []AssigneeLoad {
+	var loads []AssigneeLoad
+	byKey := map[string]int{}
+	for _, name := range roster {
+		key := strings.ToLower(strings.TrimSpace(name))
+		if key == "" {
+			continue
+		}
+		if _, ok := byKey[key]; ok {
+			continue
+		}
+		byKey[key] = len(loads)
+		loads = append(loads, AssigneeLoad{Assignee: name, InRoster: true})
+	}
+	rosterLen := len(loads)
+
+	for _, t := range tasks {
+		if t.Assignee == "" || t.Status == StatusDone {
+			continue
+		}
+		key := strings.ToLower(t.Assignee)
+		i, ok := byKey[key]
+		if !ok {
+			i = len(loads)
+			byKey[key] = i
+			loads = append(loads, AssigneeLoad{Assignee: t.Assignee})
+		}
+		switch t.Status {
+		case StatusOpen:
+			loads[i].Open++
+		case StatusInProgress:
+			loads[i].InProgress++
+		}
+	}
+
+	slices.SortFunc(loads[rosterLen:], func(a, b AssigneeLoad) int {
+		return strings.Compare(a.Assignee, b.Assignee)
+	})
+	return loads
+}
+
+// SuggestAssignee returns the roster member with the fewest active tasks.
+// Ties are broken by roster order. ok is false when loads contains no
+// roster members.
+func SuggestAssignee(loads []AssigneeLoad) (name string, ok bool) {
+	best := -1
+	for i, l := range loads {
+		if !l.InRoster {
+			continue
+		}
+		if best < 0 || l.Active() < loads[best].Active() {
+			best = i
+		}
+	}
+	if best < 0 {
+		return "", false
+	}
+	return loads[best].Assignee, true
+}
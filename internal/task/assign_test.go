@@ -0,0 +1,54 @@
+package task
+
+import "testing"
+
+func loadTask(assignee string, status Status) *Task {
+	return &Task{Assignee: assignee, Status: status}
+}
+
+func TestComputeLoad_CountsUnfinishedTasksPerAssignee(t *testing.T) {
+	tasks := []*Task{
+		loadTask("alice", StatusOpen),
+		loadTask("Alice", StatusInProgress),
+		loadTask("alice", StatusDone),
+		loadTask("carol", StatusOpen),
+		loadTask("", StatusOpen),
+	}
+	loads := ComputeLoad(tasks, []string{"alice", "bob"})
+
+	if len(loads) != 3 {
+		t.Fatalf("expected 3 assignees, got %d: %+v", len(loads), loads)
+	}
+	want := []AssigneeLoad{
+		{Assignee: "alice", Open: 1, InProgress: 1, InRoster: true},
+		{Assignee: "bob", InRoster: true},
+		{Assignee: "carol", Open: 1},
+	}
+	for i, w := range want {
+		if loads[i] != w {
+			t.Errorf("loads[%d] = %+v, want %+v", i, loads[i], w)
+		}
+	}
+}
+
+func TestSuggestAssignee_PicksLeastLoadedRosterMember(t *testing.T) {
+	loads := []AssigneeLoad{
+		{Assignee: "alice", Open: 2, InRoster: true},
+		{Assignee: "bob", Open: 1, InRoster: true},
+		{Assignee: "dave", Open: 1, InRoster: true},
+		{Assignee: "carol"}, // not in roster: never suggested
+	}
+	got, ok := SuggestAssignee(loads)
+	if !ok {
+		t.Fatal("expected a suggestion")
+	}
+	if got != "bob" {
+		t.Errorf("SuggestAssignee = %q, want bob (tie broken by roster order)", got)
+	}
+}
+
+func TestSuggestAssignee_NoRoster(t *testing.T) {
+	if _, ok := SuggestAssignee([]AssigneeLoad{{Assignee: "carol", Open: 1}}); ok {
+		t.Error("expected no suggestion without roster members")
+	}
+}
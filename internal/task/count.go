@@ -0,0 +1,30 @@
+package task
+
+// Counts aggregates a list of tasks by status and priority. Every valid
+// status and priority has an entry, so consumers can read a zero count
+// without checking for presence; unrecognised values get entries too.
+type Counts struct {
+	Total      int              `json:"total"`
+	ByStatus   map[Status]int   `json:"by_status"`
+	ByPriority map[Priority]int `json:"by_priority"`
+}
+
+// Count returns the Counts of entries.
+func Count(entries []TaskJSON) Counts {
+	c := Counts{
+		Total:      len(entries),
+		ByStatus:   make(map[Status]int, len(ValidStatuses)),
+		ByPriority: make(map[Priority]int, len(ValidPriorities)),
+	}
+	for _, s := range ValidStatuses {
+		c.ByStatus[s] = 0
+	}
+	for _, p := range ValidPriorities {
+		c.ByPriority[p] = 0
+	}
+	for _, e := range entries {
+		c.ByStatus[e.Status]++
+		c.ByPriority[e.Priority]++
+	}
+	return c
+}
@@ -0,0 +1,23 @@
+package task
+
+import "testing"
+
+func TestCount(t *testing.T) {
+	c := Count([]TaskJSON{
+		{Status: StatusOpen, Priority: PriorityHigh},
+		{Status: StatusOpen, Priority: PriorityLow},
+		{Status: StatusInProgress, Priority: PriorityHigh},
+	})
+	if c.Total != 3 {
+		t.Errorf("Total = %d, want 3", c.Total)
+	}
+	want := map[Status]int{StatusOpen: 2, StatusInProgress: 1, StatusDone: 0}
+	for s, n := range want {
+		if got, ok := c.ByStatus[s]; !ok || got != n {
+			t.Errorf("ByStatus[%s] = %d (present %v), want %d", s, got, ok, n)
+		}
+	}
+	if c.ByPriority[PriorityHigh] != 2 || c.ByPriority[PriorityMedium] != 0 || c.ByPriority[PriorityLow] != 1 {
+		t.Errorf("ByPriority = %v", c.ByPriority)
+	}
+}
@@ -0,0 +1,58 @@
+// deps.go resolves depends_on links into trees. depends_on lists the seq
+// numbers of tasks in the same plan group; a task is blocked until every
+// one of them is done (see IsBlocked).
+package task
+
+import (
+	"path/filepath"
+	"slices"
+)
+
+// DepNode is one task in a dependency tree. Task is nil when the seq in
+// its parent's depends_on does not exist in the plan. Cycle is true when
+// the task already appears higher up the same branch; its dependencies are
+// then not expanded again.
+type DepNode struct {
+	Seq   int
+	Task  *Task
+	Deps  []DepNode
+	Cycle bool
+}
+
+// Dependencies returns the dependency tree rooted at t and the tasks in
+// the same plan that list t in their depends_on (its direct dependents),
+// ordered by seq.
+func (s *Store) Dependencies(t *Task) (DepNode, []*Task) {
+	planTasks, _ := s.loadPlanTasks(filepath.Dir(t.DirPath))
+	bySeq := make(map[int]*Task, len(planTasks))
+	for _, pt := range planTasks {
+		bySeq[pt.Seq] = pt
+	}
+
+	var dependents []*Task
+	for _, pt := range planTasks {
+		if slices.Contains(pt.DependsOn, t.Seq) {
+			dependents = append(dependents, pt)
+		}
+	}
+	return depTree(t, bySeq, nil), dependents
+}
+
+// depTree builds the node for t; path holds the seqs of its ancestors.
+func depTree(t *Task, bySeq map[int]*Task, path []int) DepNode {
+	node := DepNode{Seq: t.Seq, Task: t}
+	if slices.Contains(path, t.Seq) {
+		node.Cycle = true
+		return node
+	}
+	path = append(path, t.Seq)
+	for _, seq := range t.DependsOn {
+		dep, ok := bySeq[seq]
+		if !ok {
+			node.Deps = append(node.Deps, DepNode{Seq: seq})
+			continue
+		}
+		node.Deps = append(node.Deps, depTree(dep, bySeq, slices.Clone(path)))
+	}
+	return node
+}
@@ -0,0 +1,62 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestDependencies_TreeAndDependents(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "20260304-auth", "Schema", "open", "medium", nil)
+	createTask(t, store, "20260304-auth", "API", "open", "medium", []int{1})
+	createTask(t, store, "20260304-auth", "Deploy", "open", "medium", []int{1, 2})
+	createTask(t, store, "20260304-auth", "Announce", "open", "medium", []int{3})
+
+	deploy, err := store.Get("auth", "deploy")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, dependents := store.Dependencies(deploy)
+	if tree.Task.Title != "Deploy" || len(tree.Deps) != 2 {
+		t.Fatalf("unexpected root: %+v", tree)
+	}
+	if tree.Deps[0].Task.Title != "Schema" || tree.Deps[1].Task.Title != "API" {
+		t.Errorf("unexpected deps: %s, %s", tree.Deps[0].Task.Title, tree.Deps[1].Task.Title)
+	}
+	if api := tree.Deps[1]; len(api.Deps) != 1 || api.Deps[0].Seq != 1 {
+		t.Errorf("expected API to depend on seq 1, got %+v", api.Deps)
+	}
+	if len(dependents) != 1 || dependents[0].Title != "Announce" {
+		t.Errorf("unexpected dependents: %v", dependents)
+	}
+}
+
+func TestDependencies_MissingAndCycle(t *testing.T) {
+	_, store := setupStore(t)
+	a := createTask(t, store, "20260304-auth", "A", "open", "medium", nil)
+	createTask(t, store, "20260304-auth", "B", "open", "medium", []int{1})
+
+	// Hand-edit A to depend on B (a cycle) and on a seq that does not exist.
+	a.DependsOn = []int{2, 9}
+	data, err := Marshal(*a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(a.DirPath, taskFileName), data, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	a, _ = store.Get("auth", "001-a")
+
+	tree, _ := store.Dependencies(a)
+	if len(tree.Deps) != 2 {
+		t.Fatalf("expected two deps, got %+v", tree.Deps)
+	}
+	b := tree.Deps[0]
+	if b.Task.Title != "B" || len(b.Deps) != 1 || !b.Deps[0].Cycle || b.Deps[0].Deps != nil {
+		t.Errorf("expected B -> A (cycle), got %+v", b)
+	}
+	if missing := tree.Deps[1]; missing.Task != nil || missing.Seq != 9 {
+		t.Errorf("expected missing seq 9, got %+v", missing)
+	}
+}
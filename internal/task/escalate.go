@@ -0,0 +1,47 @@
+// escalate.go implements deadline-aware priority escalation: unfinished
+// tasks whose due date is near are raised to a configured priority.
+package task
+
+import (
+	"fmt"
+	"path/filepath"
+	"time"
+)
+
+// priorityRank orders priorities from low (1) to high (3). Unknown values
+// rank 0.
+func priorityRank(p Priority) int {
+	switch p {
+	case PriorityHigh:
+		return 3
+	case PriorityMedium:
+		return 2
+	case PriorityLow:
+		return 1
+	}
+	return 0
+}
+
+// Escalate raises the priority of every unfinished task due before
+// deadline (overdue tasks included) to p. Tasks already at p or above are
+// left alone; priorities are never lowered. Each TASK.md is rewritten under
+// its advisory lock; the task index is not rebuilt, callers do that. The
+// escalated tasks are returned as written.
+func (s *Store) Escalate(deadline time.Time, p Priority) ([]*Task, error) {
+	if !IsValidPriority(p) {
+		return nil, fmt.Errorf("invalid priority %q: must be one of low, medium, high", p)
+	}
+	tasks, loadErr := s.loadAll()
+	var escalated []*Task
+	for _, t := range tasks {
+		if t.Status == StatusDone || t.Due == nil || !t.Due.Before(deadline) || priorityRank(t.Priority) >= priorityRank(p) {
+			continue
+		}
+		updated, _, err := s.updateLocked(filepath.Join(t.DirPath, taskFileName), map[string]string{"priority": string(p)})
+		if err != nil {
+			return escalated, fmt.Errorf("escalate %s: %w", filepath.Base(t.DirPath), err)
+		}
+		escalated = append(escalated, updated)
+	}
+	return escalated, loadErr
+}
@@ -0,0 +1,73 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// setDue rewrites tk's TASK.md with the given due date.
+func setDue(t *testing.T, tk *Task, due time.Time) {
+	t.Helper()
+	tk.Due = &due
+	data, err := Marshal(*tk)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(tk.DirPath, taskFileName), data, 0o644); err != nil {
+		t.Fatalf("write TASK.md: %v", err)
+	}
+}
+
+func TestEscalate_RaisesTasksDueSoon(t *testing.T) {
+	_, store := setupStore(t)
+	now := time.Now()
+
+	soon := createTask(t, store, "plan-a", "Due soon", "open", "low", nil)
+	setDue(t, soon, now.AddDate(0, 0, 2))
+	overdue := createTask(t, store, "plan-a", "Overdue", "in_progress", "medium", nil)
+	setDue(t, overdue, now.AddDate(0, 0, -1))
+	later := createTask(t, store, "plan-a", "Due later", "open", "low", nil)
+	setDue(t, later, now.AddDate(0, 0, 10))
+	done := createTask(t, store, "plan-a", "Finished", "done", "low", nil)
+	setDue(t, done, now.AddDate(0, 0, 1))
+	createTask(t, store, "plan-a", "No due date", "open", "low", nil)
+
+	escalated, err := store.Escalate(now.AddDate(0, 0, 3), PriorityHigh)
+	if err != nil {
+		t.Fatalf("Escalate: %v", err)
+	}
+	if len(escalated) != 2 {
+		t.Fatalf("expected 2 escalated tasks, got %v", escalated)
+	}
+	for _, tk := range []*Task{soon, overdue, later, done} {
+		got, err := store.loadFile(filepath.Join(tk.DirPath, taskFileName))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := tk.Priority
+		if tk == soon || tk == overdue {
+			want = PriorityHigh
+		}
+		if got.Priority != want {
+			t.Errorf("%s: priority %s, want %s", tk.Title, got.Priority, want)
+		}
+	}
+
+	again, err := store.Escalate(now.AddDate(0, 0, 3), PriorityHigh)
+	if err != nil || len(again) != 0 {
+		t.Errorf("second run should change nothing, got %v, %v", again, err)
+	}
+}
+
+func TestEscalate_NeverLowersPriority(t *testing.T) {
+	_, store := setupStore(t)
+	tk := createTask(t, store, "plan-a", "Urgent", "open", "high", nil)
+	setDue(t, tk, time.Now())
+
+	escalated, err := store.Escalate(time.Now().AddDate(0, 0, 1), PriorityMedium)
+	if err != nil || len(escalated) != 0 {
+		t.Errorf("expected no change, got %v, %v", escalated, err)
+	}
+}
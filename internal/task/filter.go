@@ -2,6 +2,7 @@
 package task
 
 import (
+	"fmt"
 	"slices"
 	"strings"
 )
@@ -11,10 +12,17 @@ import (
 type Filter struct {
 	// Plan is a substring matched against each task's Plan field.
 	Plan string
-	// Status is an exact match on task status (empty = any status).
-	Status Status
-	// Priority is an exact match on task priority (empty = any priority).
-	Priority Priority
+	// PlanSlug is an exact match on the task's Plan field. Callers set it
+	// after resolving a partial plan name to a single plan file.
+	PlanSlug string
+	// Related, when true, lets Plan and PlanSlug also match tasks that list
+	// the plan in related_plans, not only tasks in the plan's directory.
+	Related bool
+	// Statuses keeps tasks whose status is one of these (empty = any status).
+	Statuses []Status
+	// Priorities keeps tasks whose priority is one of these (empty = any
+	// priority).
+	Priorities []Priority
 	// Tags requires the task to have at least one tag in this list.
 	Tags []string
 	// Keyword is a case-insensitive substring matched against title, tags,
@@ -23,6 +31,80 @@ type Filter struct {
 	// Blocked, when true, restricts results to tasks whose DependsOn seq
 	// numbers contain at least one task that is not yet done.
 	Blocked bool
+	// Branch restricts results to tasks created on this git branch, plus
+	// tasks not scoped to any branch.
+	Branch string
+}
+
+// ParseStatuses parses a comma-separated list of statuses such as
+// "open,in_progress", as given to --status. Spaces around items are ignored
+// and an empty list yields nil, which matches any status. An unknown status
+// is an error.
+func ParseStatuses(list string) ([]Status, error) {
+	var out []Status
+	for _, item := range splitList(list) {
+		s := Status(item)
+		if !IsValidStatus(s) {
+			return nil, fmt.Errorf("invalid status %q: must be one of %s", item, joinValues(ValidStatuses))
+		}
+		if !slices.Contains(out, s) {
+			out = append(out, s)
+		}
+	}
+	return out, nil
+}
+
+// ParsePriorities parses a comma-separated list of priorities such as
+// "high,medium", like ParseStatuses.
+func ParsePriorities(list string) ([]Priority, error) {
+	var out []Priority
+	for _, item := range splitList(list) {
+		p := Priority(item)
+		if !IsValidPriority(p) {
+			return nil, fmt.Errorf("invalid priority %q: must be one of %s", item, joinValues(ValidPriorities))
+		}
+		if !slices.Contains(out, p) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// Validate returns an error for the first status or priority in f that is
+// not recognised. Such a filter would silently match nothing, so List
+// refuses it.
+func (f Filter) Validate() error {
+	for _, s := range f.Statuses {
+		if !IsValidStatus(s) {
+			return fmt.Errorf("invalid status %q: must be one of %s", s, joinValues(ValidStatuses))
+		}
+	}
+	for _, p := range f.Priorities {
+		if !IsValidPriority(p) {
+			return fmt.Errorf("invalid priority %q: must be one of %s", p, joinValues(ValidPriorities))
+		}
+	}
+	return nil
+}
+
+// splitList splits a comma-separated flag value, dropping blank items.
+func splitList(list string) []string {
+	var out []string
+	for _, item := range strings.Split(list, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
+
+// joinValues renders values as "a, b, c" for error messages.
+func joinValues[T ~string](values []T) string {
+	parts := make([]string, len(values))
+	for i, v := range values {
+		parts[i] = string(v)
+	}
+	return strings.Join(parts, ", ")
 }
 
 // Apply returns the subset of tasks that satisfy every non-zero field of f.
@@ -52,22 +134,25 @@ func ApplyToJSON(entries []TaskJSON, f Filter) []TaskJSON {
 	return out
 }
 
+// MatchJSON reports whether e satisfies every non-zero field of f, as
+// ApplyToJSON checks each entry.
+func (f Filter) MatchJSON(e TaskJSON) bool {
+	return matchesJSONFilter(e, f)
+}
+
 // matchesJSONFilter reports whether e satisfies all active constraints in f.
 func matchesJSONFilter(e TaskJSON, f Filter) bool {
-	if f.Plan != "" {
-		if !strings.Contains(strings.ToLower(e.Plan), strings.ToLower(f.Plan)) {
-			return false
-		}
+	if !matchesPlan(e.Plan, e.RelatedPlans, f) {
+		return false
 	}
-	if f.Status != "" {
-		if e.Status != f.Status {
-			return false
-		}
+	if f.Branch != "" && e.Branch != "" && e.Branch != f.Branch {
+		return false
 	}
-	if f.Priority != "" {
-		if e.Priority != f.Priority {
-			return false
-		}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, e.Status) {
+		return false
+	}
+	if len(f.Priorities) > 0 && !slices.Contains(f.Priorities, e.Priority) {
+		return false
 	}
 	if len(f.Tags) > 0 {
 		if !hasAnyTag(e.Tags, f.Tags) {
@@ -75,13 +160,7 @@ func matchesJSONFilter(e TaskJSON, f Filter) bool {
 		}
 	}
 	if f.Keyword != "" {
-		lower := strings.ToLower(f.Keyword)
-		titleMatch := strings.Contains(strings.ToLower(e.Title), lower)
-		excerptMatch := strings.Contains(strings.ToLower(e.Excerpt), lower)
-		tagMatch := slices.ContainsFunc(e.Tags, func(tag string) bool {
-			return strings.Contains(strings.ToLower(tag), lower)
-		})
-		if !titleMatch && !excerptMatch && !tagMatch {
+		if len(matchedIn(e.Title, e.Tags, e.Excerpt, strings.ToLower(f.Keyword))) == 0 {
 			return false
 		}
 	}
@@ -95,22 +174,20 @@ func matchesJSONFilter(e TaskJSON, f Filter) bool {
 
 // matchesFilter reports whether t satisfies all active constraints in f.
 func matchesFilter(t *Task, f Filter) bool {
-	if f.Plan != "" {
-		if !strings.Contains(strings.ToLower(t.Plan), strings.ToLower(f.Plan)) {
-			return false
-		}
+	if !matchesPlan(t.Plan, t.RelatedPlans, f) {
+		return false
 	}
 
-	if f.Status != "" {
-		if t.Status != f.Status {
-			return false
-		}
+	if f.Branch != "" && t.Branch != "" && t.Branch != f.Branch {
+		return false
 	}
 
-	if f.Priority != "" {
-		if t.Priority != f.Priority {
-			return false
-		}
+	if len(f.Statuses) > 0 && !slices.Contains(f.Statuses, t.Status) {
+		return false
+	}
+
+	if len(f.Priorities) > 0 && !slices.Contains(f.Priorities, t.Priority) {
+		return false
 	}
 
 	if len(f.Tags) > 0 {
@@ -134,6 +211,30 @@ func matchesFilter(t *Task, f Filter) bool {
 	return true
 }
 
+// matchesPlan reports whether a task with the given plan and related_plans
+// satisfies f.Plan and f.PlanSlug. Related plans only count when f.Related
+// is set; they are plan filenames, so ".md" is dropped before comparing.
+func matchesPlan(plan string, related []string, f Filter) bool {
+	plans := []string{plan}
+	if f.Related {
+		for _, name := range related {
+			plans = append(plans, strings.TrimSuffix(name, ".md"))
+		}
+	}
+	if f.Plan != "" {
+		lower := strings.ToLower(f.Plan)
+		if !slices.ContainsFunc(plans, func(p string) bool {
+			return strings.Contains(strings.ToLower(p), lower)
+		}) {
+			return false
+		}
+	}
+	if f.PlanSlug != "" && !slices.Contains(plans, f.PlanSlug) {
+		return false
+	}
+	return true
+}
+
 // hasAnyTag reports whether taskTags contains at least one tag from wantTags
 // (case-insensitive comparison).
 func hasAnyTag(taskTags, wantTags []string) bool {
@@ -148,9 +249,30 @@ func hasAnyTag(taskTags, wantTags []string) bool {
 // matchesKeyword reports whether t's title, any tag, or excerpt contains
 // lower (already lower-cased) as a substring.
 func matchesKeyword(t *Task, lower string) bool {
-	return strings.Contains(strings.ToLower(t.Title), lower) ||
-		slices.ContainsFunc(t.Tags, func(tag string) bool {
-			return strings.Contains(strings.ToLower(tag), lower)
-		}) ||
-		strings.Contains(strings.ToLower(t.Excerpt), lower)
+	return len(matchedIn(t.Title, t.Tags, t.Excerpt, lower)) > 0
+}
+
+// MatchedIn returns the fields of e that contain keyword, compared
+// case-insensitively: "title", "tag", and "excerpt", in that order. It is
+// empty when the keyword filter would not select e.
+func (e TaskJSON) MatchedIn(keyword string) []string {
+	return matchedIn(e.Title, e.Tags, e.Excerpt, strings.ToLower(keyword))
+}
+
+// matchedIn is MatchedIn over the searched fields, with lower already
+// lower-cased.
+func matchedIn(title string, tags []string, excerpt, lower string) []string {
+	var fields []string
+	if strings.Contains(strings.ToLower(title), lower) {
+		fields = append(fields, "title")
+	}
+	if slices.ContainsFunc(tags, func(tag string) bool {
+		return strings.Contains(strings.ToLower(tag), lower)
+	}) {
+		fields = append(fields, "tag")
+	}
+	if strings.Contains(strings.ToLower(excerpt), lower) {
+		fields = append(fields, "excerpt")
+	}
+	return fields
 }
@@ -1,6 +1,8 @@
 package task
 
 import (
+	"slices"
+	"strings"
 	"testing"
 	"time"
 )
@@ -83,6 +85,44 @@ func TestApply_PlanFilter_NoMatch(t *testing.T) {
 	}
 }
 
+func TestApply_PlanSlugFilter_ExactMatchOnly(t *testing.T) {
+	tasks := []*Task{
+		makeFilterTask("t-1", "auth-task", StatusOpen, PriorityMedium, "20260304-auth", nil, ""),
+		makeFilterTask("t-2", "auth-v2-task", StatusOpen, PriorityMedium, "20260304-auth-v2", nil, ""),
+	}
+	got := Apply(tasks, Filter{PlanSlug: "20260304-auth"})
+	if len(got) != 1 {
+		t.Fatalf("expected 1 match, got %d", len(got))
+	}
+	if got[0].Title != "auth-task" {
+		t.Errorf("expected 'auth-task', got %q", got[0].Title)
+	}
+}
+
+func TestApply_PlanSlugFilter_RelatedPlans(t *testing.T) {
+	linked := makeFilterTask("t-2", "linked-task", StatusOpen, PriorityMedium, "20260301-other", nil, "")
+	linked.RelatedPlans = []string{"20260304-auth.md"}
+	tasks := []*Task{
+		makeFilterTask("t-1", "auth-task", StatusOpen, PriorityMedium, "20260304-auth", nil, ""),
+		linked,
+	}
+
+	if got := Apply(tasks, Filter{PlanSlug: "20260304-auth"}); len(got) != 1 {
+		t.Errorf("without Related: expected 1 match, got %d", len(got))
+	}
+	for _, f := range []Filter{
+		{PlanSlug: "20260304-auth", Related: true},
+		{Plan: "AUTH", Related: true},
+	} {
+		if got := Apply(tasks, f); len(got) != 2 {
+			t.Errorf("%+v: expected 2 matches, got %d", f, len(got))
+		}
+		if got := ApplyToJSON([]TaskJSON{tasks[0].ToJSON(), linked.ToJSON()}, f); len(got) != 2 {
+			t.Errorf("%+v: expected 2 JSON matches, got %d", f, len(got))
+		}
+	}
+}
+
 func TestApply_PlanFilter_Empty_MatchesAll(t *testing.T) {
 	tasks := []*Task{
 		makeFilterTask("t-1", "task", StatusOpen, PriorityMedium, "", nil, ""),
@@ -114,7 +154,7 @@ func TestApply_StatusFilter_ExactMatch(t *testing.T) {
 		makeFilterTask("t-2", "wip-task", StatusInProgress, PriorityMedium, "", nil, ""),
 		makeFilterTask("t-3", "done-task", StatusDone, PriorityMedium, "", nil, ""),
 	}
-	got := Apply(tasks, Filter{Status: StatusOpen})
+	got := Apply(tasks, Filter{Statuses: []Status{StatusOpen}})
 	if len(got) != 1 {
 		t.Fatalf("expected 1 open task, got %d", len(got))
 	}
@@ -128,7 +168,7 @@ func TestApply_StatusFilter_InProgress(t *testing.T) {
 		makeFilterTask("t-1", "open-task", StatusOpen, PriorityMedium, "", nil, ""),
 		makeFilterTask("t-2", "wip-task", StatusInProgress, PriorityMedium, "", nil, ""),
 	}
-	got := Apply(tasks, Filter{Status: StatusInProgress})
+	got := Apply(tasks, Filter{Statuses: []Status{StatusInProgress}})
 	if len(got) != 1 || got[0].Title != "wip-task" {
 		t.Errorf("expected 'wip-task', got %v", got)
 	}
@@ -138,7 +178,7 @@ func TestApply_StatusFilter_NoMatch(t *testing.T) {
 	tasks := []*Task{
 		makeFilterTask("t-1", "task", StatusOpen, PriorityMedium, "", nil, ""),
 	}
-	got := Apply(tasks, Filter{Status: StatusDone})
+	got := Apply(tasks, Filter{Statuses: []Status{StatusDone}})
 	if len(got) != 0 {
 		t.Errorf("expected 0 matches, got %d", len(got))
 	}
@@ -149,7 +189,7 @@ func TestApply_StatusFilter_Empty_MatchesAll(t *testing.T) {
 		makeFilterTask("t-1", "a", StatusOpen, PriorityMedium, "", nil, ""),
 		makeFilterTask("t-2", "b", StatusInProgress, PriorityMedium, "", nil, ""),
 	}
-	got := Apply(tasks, Filter{Status: ""})
+	got := Apply(tasks, Filter{Statuses: nil})
 	if len(got) != 2 {
 		t.Errorf("empty status filter should match all, got %d", len(got))
 	}
@@ -162,7 +202,7 @@ func TestApply_PriorityFilter_High(t *testing.T) {
 		makeFilterTask("t-1", "high-task", StatusOpen, PriorityHigh, "", nil, ""),
 		makeFilterTask("t-2", "low-task", StatusOpen, PriorityLow, "", nil, ""),
 	}
-	got := Apply(tasks, Filter{Priority: PriorityHigh})
+	got := Apply(tasks, Filter{Priorities: []Priority{PriorityHigh}})
 	if len(got) != 1 || got[0].Title != "high-task" {
 		t.Errorf("expected 'high-task', got %v", got)
 	}
@@ -173,7 +213,7 @@ func TestApply_PriorityFilter_Medium(t *testing.T) {
 		makeFilterTask("t-1", "med-task", StatusOpen, PriorityMedium, "", nil, ""),
 		makeFilterTask("t-2", "high-task", StatusOpen, PriorityHigh, "", nil, ""),
 	}
-	got := Apply(tasks, Filter{Priority: PriorityMedium})
+	got := Apply(tasks, Filter{Priorities: []Priority{PriorityMedium}})
 	if len(got) != 1 || got[0].Title != "med-task" {
 		t.Errorf("expected 'med-task', got %v", got)
 	}
@@ -184,7 +224,7 @@ func TestApply_PriorityFilter_Empty_MatchesAll(t *testing.T) {
 		makeFilterTask("t-1", "a", StatusOpen, PriorityHigh, "", nil, ""),
 		makeFilterTask("t-2", "b", StatusOpen, PriorityLow, "", nil, ""),
 	}
-	got := Apply(tasks, Filter{Priority: ""})
+	got := Apply(tasks, Filter{Priorities: nil})
 	if len(got) != 2 {
 		t.Errorf("empty priority filter should match all, got %d", len(got))
 	}
@@ -411,7 +451,7 @@ func TestApply_CombinedStatusAndPriority(t *testing.T) {
 		makeFilterTask("t-2", "med-open", StatusOpen, PriorityMedium, "", nil, ""),
 		makeFilterTask("t-3", "high-wip", StatusInProgress, PriorityHigh, "", nil, ""),
 	}
-	got := Apply(tasks, Filter{Status: StatusOpen, Priority: PriorityHigh})
+	got := Apply(tasks, Filter{Statuses: []Status{StatusOpen}, Priorities: []Priority{PriorityHigh}})
 	if len(got) != 1 || got[0].Title != "high-open" {
 		t.Errorf("expected 'high-open', got %v", got)
 	}
@@ -423,7 +463,7 @@ func TestApply_CombinedKeywordAndStatus(t *testing.T) {
 		makeFilterTask("t-2", "auth-wip", StatusInProgress, PriorityMedium, "", []string{"auth"}, ""),
 		makeFilterTask("t-3", "cache-open", StatusOpen, PriorityMedium, "", []string{"redis"}, ""),
 	}
-	got := Apply(tasks, Filter{Status: StatusOpen, Keyword: "auth"})
+	got := Apply(tasks, Filter{Statuses: []Status{StatusOpen}, Keyword: "auth"})
 	if len(got) != 1 || got[0].Title != "auth-open" {
 		t.Errorf("expected 'auth-open', got %v", got)
 	}
@@ -446,7 +486,7 @@ func TestApply_CombinedPlanAndStatus(t *testing.T) {
 		makeFilterTask("t-2", "task-b", StatusInProgress, PriorityMedium, "20260304-auth-refactor", nil, ""),
 		makeFilterTask("t-3", "task-c", StatusOpen, PriorityMedium, "20260305-db-schema", nil, ""),
 	}
-	got := Apply(tasks, Filter{Plan: "auth", Status: StatusOpen})
+	got := Apply(tasks, Filter{Plan: "auth", Statuses: []Status{StatusOpen}})
 	if len(got) != 1 || got[0].Title != "task-a" {
 		t.Errorf("expected 'task-a', got %v", got)
 	}
@@ -459,11 +499,11 @@ func TestApply_AllFiltersActive_NarrowsToOne(t *testing.T) {
 		makeFilterTask("t-3", "cache-layer", StatusInProgress, PriorityHigh, "20260305-cache", []string{"redis"}, "Redis caching."),
 	}
 	got := Apply(tasks, Filter{
-		Plan:     "auth",
-		Status:   StatusOpen,
-		Priority: PriorityHigh,
-		Tags:     []string{"jwt"},
-		Keyword:  "login",
+		Plan:       "auth",
+		Statuses:   []Status{StatusOpen},
+		Priorities: []Priority{PriorityHigh},
+		Tags:       []string{"jwt"},
+		Keyword:    "login",
 	})
 	if len(got) != 1 || got[0].Title != "auth-login" {
 		t.Errorf("expected only 'auth-login', got %v", got)
@@ -538,3 +578,85 @@ func TestHasAnyTag_CaseInsensitive(t *testing.T) {
 		t.Error("expected case-insensitive tag match")
 	}
 }
+
+// --- Status and priority lists ---------------------------------------------
+
+func TestApply_StatusList_MatchesAny(t *testing.T) {
+	tasks := []*Task{
+		makeFilterTask("t-1", "open-task", StatusOpen, PriorityHigh, "", nil, ""),
+		makeFilterTask("t-2", "wip-task", StatusInProgress, PriorityLow, "", nil, ""),
+		makeFilterTask("t-3", "done-task", StatusDone, PriorityHigh, "", nil, ""),
+	}
+	got := Apply(tasks, Filter{Statuses: []Status{StatusOpen, StatusInProgress}})
+	if titles := titlesOf(got); !slices.Equal(titles, []string{"open-task", "wip-task"}) {
+		t.Errorf("Apply = %v, want open-task and wip-task", titles)
+	}
+	got = Apply(tasks, Filter{Priorities: []Priority{PriorityLow, PriorityHigh}, Statuses: []Status{StatusDone, StatusInProgress}})
+	if titles := titlesOf(got); !slices.Equal(titles, []string{"wip-task", "done-task"}) {
+		t.Errorf("Apply = %v, want wip-task and done-task", titles)
+	}
+}
+
+func TestParseStatuses(t *testing.T) {
+	got, err := ParseStatuses(" open, in_progress,,open ")
+	if err != nil || !slices.Equal(got, []Status{StatusOpen, StatusInProgress}) {
+		t.Errorf("ParseStatuses = %v, %v", got, err)
+	}
+	if got, err := ParseStatuses(""); err != nil || got != nil {
+		t.Errorf("ParseStatuses(\"\") = %v, %v, want nil", got, err)
+	}
+	if _, err := ParseStatuses("open,closed"); err == nil || !strings.Contains(err.Error(), `"closed"`) {
+		t.Errorf("ParseStatuses with an unknown status = %v, want an error naming it", err)
+	}
+}
+
+func TestParsePriorities(t *testing.T) {
+	got, err := ParsePriorities("high,low")
+	if err != nil || !slices.Equal(got, []Priority{PriorityHigh, PriorityLow}) {
+		t.Errorf("ParsePriorities = %v, %v", got, err)
+	}
+	if _, err := ParsePriorities("urgent"); err == nil || !strings.Contains(err.Error(), "high, medium, low") {
+		t.Errorf("ParsePriorities with an unknown priority = %v, want an error listing the valid ones", err)
+	}
+}
+
+func TestFilterValidate(t *testing.T) {
+	if err := (Filter{Statuses: []Status{StatusDone}, Priorities: []Priority{PriorityLow}}).Validate(); err != nil {
+		t.Errorf("Validate on a valid filter = %v", err)
+	}
+	if err := (Filter{Statuses: []Status{"Open"}}).Validate(); err == nil {
+		t.Error("Validate should reject an unknown status")
+	}
+	if err := (Filter{Priorities: []Priority{"urgent"}}).Validate(); err == nil {
+		t.Error("Validate should reject an unknown priority")
+	}
+}
+
+// --- Branch filter -----------------------------------------------------------
+
+func TestApply_BranchFilter_KeepsUnscoped(t *testing.T) {
+	tasks := []*Task{
+		makeFilterTask("t-1", "auth-task", StatusOpen, PriorityMedium, "p", nil, ""),
+		makeFilterTask("t-2", "billing-task", StatusOpen, PriorityMedium, "p", nil, ""),
+		makeFilterTask("t-3", "shared-task", StatusOpen, PriorityMedium, "p", nil, ""),
+	}
+	tasks[0].Branch = "feature/auth"
+	tasks[1].Branch = "feature/billing"
+
+	got := Apply(tasks, Filter{Branch: "feature/auth"})
+	if len(got) != 2 || got[0].Title != "auth-task" || got[1].Title != "shared-task" {
+		t.Errorf("Apply = %v, want auth-task and shared-task", titlesOf(got))
+	}
+	entries := []TaskJSON{tasks[0].ToJSON(), tasks[1].ToJSON(), tasks[2].ToJSON()}
+	if got := ApplyToJSON(entries, Filter{Branch: "feature/billing"}); len(got) != 2 || got[0].Title != "billing-task" {
+		t.Errorf("ApplyToJSON = %v, want billing-task and shared-task", got)
+	}
+}
+
+func titlesOf(tasks []*Task) []string {
+	out := make([]string, len(tasks))
+	for i, t := range tasks {
+		out[i] = t.Title
+	}
+	return out
+}
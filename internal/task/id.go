@@ -0,0 +1,114 @@
+// id.go allocates task IDs. By default IDs are "<prefix><6 hex chars>"; with
+// tasks.id_mode = "sequential" they are "<prefix><n>" where n comes from a
+// counter file (.logosyncx/task-id-counter) updated under a file lock so
+// concurrent creates never hand out the same number.
+package task
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/filelock"
+)
+
+// idPrefix is the default prefix of auto-generated task IDs.
+const idPrefix = "t-"
+
+const idCounterFileName = "task-id-counter"
+
+// ID modes accepted in config tasks.id_mode.
+const (
+	IDModeRandom     = "random"
+	IDModeSequential = "sequential"
+)
+
+// IDCounterFilePath returns the absolute path to the sequential task ID
+// counter file under projectRoot.
+func IDCounterFilePath(projectRoot string) string {
+	return filepath.Join(projectRoot, ".logosyncx", idCounterFileName)
+}
+
+// NextID returns a new task ID according to the configured prefix and mode.
+func (s *Store) NextID() (string, error) {
+	prefix := s.cfg.Tasks.IDPrefix
+	if prefix == "" {
+		prefix = idPrefix
+	}
+	switch s.cfg.Tasks.IDMode {
+	case "", IDModeRandom:
+		return generateID(prefix)
+	case IDModeSequential:
+		n, err := s.nextCounter(prefix)
+		if err != nil {
+			return "", err
+		}
+		return prefix + strconv.Itoa(n), nil
+	default:
+		return "", fmt.Errorf("invalid tasks.id_mode %q: must be %q or %q",
+			s.cfg.Tasks.IDMode, IDModeRandom, IDModeSequential)
+	}
+}
+
+// nextCounter increments the counter file under lock and returns the new
+// value. When the counter file does not exist yet it is seeded from the
+// highest "<prefix><n>" ID already in use, so enabling sequential mode on an
+// existing project (or losing the counter file) cannot reuse a number.
+func (s *Store) nextCounter(prefix string) (int, error) {
+	path := IDCounterFilePath(s.projectRoot)
+	lock, err := filelock.Acquire(path, filelock.DefaultTimeout)
+	if err != nil {
+		return 0, fmt.Errorf("lock task id counter: %w", err)
+	}
+	defer lock.Release()
+
+	var last int
+	data, err := os.ReadFile(path)
+	switch {
+	case err == nil:
+		last, err = strconv.Atoi(strings.TrimSpace(string(data)))
+		if err != nil {
+			return 0, fmt.Errorf("parse %s: %w", idCounterFileName, err)
+		}
+	case os.IsNotExist(err):
+		last = s.maxSequentialID(prefix)
+	default:
+		return 0, fmt.Errorf("read %s: %w", idCounterFileName, err)
+	}
+
+	next := last + 1
+	if err := os.WriteFile(path, []byte(strconv.Itoa(next)+"\n"), 0o644); err != nil {
+		return 0, fmt.Errorf("write %s: %w", idCounterFileName, err)
+	}
+	return next, nil
+}
+
+// maxSequentialID returns the largest n among existing task IDs of the form
+// "<prefix><n>", or 0 when there are none.
+func (s *Store) maxSequentialID(prefix string) int {
+	tasks, _ := s.loadAll()
+	maxN := 0
+	for _, t := range tasks {
+		rest, ok := strings.CutPrefix(t.ID, prefix)
+		if !ok {
+			continue
+		}
+		if n, err := strconv.Atoi(rest); err == nil && n > maxN {
+			maxN = n
+		}
+	}
+	return maxN
+}
+
+// generateID returns a new unique task ID of the form "<prefix><6 hex chars>".
+func generateID(prefix string) (string, error) {
+	b := make([]byte, 3)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(b), nil
+}
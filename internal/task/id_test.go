@@ -0,0 +1,104 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestCreate_CustomIDPrefix_Random(t *testing.T) {
+	_, store := setupStore(t)
+	store.cfg.Tasks.IDPrefix = "API-"
+
+	tk := createTask(t, store, "plan-a", "Prefixed", "", "", nil)
+	if !strings.HasPrefix(tk.ID, "API-") || len(tk.ID) != len("API-")+6 {
+		t.Errorf("ID = %q, want API-<6 hex chars>", tk.ID)
+	}
+}
+
+func TestCreate_SequentialIDs(t *testing.T) {
+	dir, store := setupStore(t)
+	store.cfg.Tasks.IDPrefix = "WEB-"
+	store.cfg.Tasks.IDMode = IDModeSequential
+
+	for i := 1; i <= 3; i++ {
+		tk := createTask(t, store, "plan-a", fmt.Sprintf("Task %d", i), "", "", nil)
+		if want := fmt.Sprintf("WEB-%d", i); tk.ID != want {
+			t.Errorf("task %d: ID = %q, want %q", i, tk.ID, want)
+		}
+	}
+
+	data, err := os.ReadFile(IDCounterFilePath(dir))
+	if err != nil {
+		t.Fatalf("read counter: %v", err)
+	}
+	if strings.TrimSpace(string(data)) != "3" {
+		t.Errorf("counter = %q, want 3", data)
+	}
+}
+
+func TestCreate_SequentialIDs_SeededFromExistingTasks(t *testing.T) {
+	dir, store := setupStore(t)
+	store.cfg.Tasks.IDMode = IDModeSequential
+
+	createTask(t, store, "plan-a", "First", "", "", nil)
+	createTask(t, store, "plan-a", "Second", "", "", nil)
+	// Losing the counter file must not cause IDs to be reused.
+	if err := os.Remove(IDCounterFilePath(dir)); err != nil {
+		t.Fatalf("remove counter: %v", err)
+	}
+
+	tk := createTask(t, store, "plan-b", "Third", "", "", nil)
+	if tk.ID != "t-3" {
+		t.Errorf("ID = %q, want t-3", tk.ID)
+	}
+}
+
+func TestCreate_SequentialIDs_ConcurrentCreatesAreUnique(t *testing.T) {
+	_, store := setupStore(t)
+	store.cfg.Tasks.IDMode = IDModeSequential
+
+	const n = 8
+	ids := make(chan string, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			id, err := store.NextID()
+			if err != nil {
+				t.Errorf("NextID: %v", err)
+				return
+			}
+			ids <- id
+		}(i)
+	}
+	wg.Wait()
+	close(ids)
+
+	seen := map[string]bool{}
+	for id := range ids {
+		if seen[id] {
+			t.Errorf("duplicate ID %q", id)
+		}
+		seen[id] = true
+	}
+	if len(seen) != n {
+		t.Errorf("expected %d unique IDs, got %d", n, len(seen))
+	}
+}
+
+func TestCreate_InvalidIDMode_ReturnsError(t *testing.T) {
+	_, store := setupStore(t)
+	store.cfg.Tasks.IDMode = "uuid"
+
+	_, err := store.Create(&Task{Title: "Bad mode", Plan: "plan-a"})
+	if err == nil {
+		t.Fatal("expected error for invalid id_mode, got nil")
+	}
+	if !strings.Contains(err.Error(), "id_mode") {
+		t.Errorf("expected 'id_mode' in error, got: %v", err)
+	}
+}
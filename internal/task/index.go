@@ -5,17 +5,28 @@
 package task
 
 import (
-	"bufio"
-	"encoding/json"
+	"cmp"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
+	"slices"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/filelock"
+	"github.com/senna-lang/logosyncx/internal/jsonl"
 )
 
 const taskIndexFileName = "task-index.jsonl"
 
+// TaskIndexSchemaVersion is the version of the task index format written by
+// this binary. Bump it whenever TaskJSON gains, drops, or reinterprets a
+// field.
+const TaskIndexSchemaVersion = 2
+
+// TaskIndexHeader is the first line of the task index file.
+var TaskIndexHeader = jsonl.Header{Schema: "logosyncx.task-index", Version: TaskIndexSchemaVersion}
+
 // TaskIndexFilePath returns the absolute path to the task index file under
 // projectRoot.
 func TaskIndexFilePath(projectRoot string) string {
@@ -25,71 +36,70 @@ func TaskIndexFilePath(projectRoot string) string {
 // ReadAllTaskIndex reads every entry from the task index file under
 // projectRoot.  If the file does not exist os.ErrNotExist is returned
 // (unwrapped) so callers can use errors.Is.  Blank lines are silently
-// skipped; a malformed line causes ReadAllTaskIndex to return whatever it has
-// collected so far plus an error.
+// skipped.  Malformed lines are skipped too: every well-formed entry is
+// returned along with an error wrapping a *jsonl.SkippedError that lists
+// them.  A file whose last line is incomplete (an interrupted write) also
+// returns an error wrapping jsonl.ErrPartial.
 func ReadAllTaskIndex(projectRoot string) ([]TaskJSON, error) {
-	path := TaskIndexFilePath(projectRoot)
-	f, err := os.Open(path)
+	entries, err := jsonl.ReadTolerant[TaskJSON](TaskIndexFilePath(projectRoot))
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			return nil, os.ErrNotExist
 		}
-		return nil, fmt.Errorf("open task index: %w", err)
-	}
-	defer f.Close()
-
-	var entries []TaskJSON
-	scanner := bufio.NewScanner(f)
-	lineNum := 0
-	for scanner.Scan() {
-		lineNum++
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-		var e TaskJSON
-		if err := json.Unmarshal([]byte(line), &e); err != nil {
-			return entries, fmt.Errorf("parse task index line %d: %w", lineNum, err)
-		}
-		entries = append(entries, e)
-	}
-	if err := scanner.Err(); err != nil {
 		return entries, fmt.Errorf("read task index: %w", err)
 	}
 	return entries, nil
 }
 
-// AppendTaskIndex serialises e as a single JSON line and appends it to the
-// task index file under projectRoot.  The file and any missing parent
-// directories are created automatically.
-func AppendTaskIndex(projectRoot string, e TaskJSON) error {
-	path := TaskIndexFilePath(projectRoot)
-
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
-		return fmt.Errorf("create task index directory: %w", err)
+// ReadTaskIndexPage is the task index counterpart of index.ReadPage.
+func ReadTaskIndexPage(projectRoot string, keep func(TaskJSON) bool, cmp func(a, b TaskJSON) int, p jsonl.Page) (entries []TaskJSON, total int, err error) {
+	entries, total, err = jsonl.ReadPage(TaskIndexFilePath(projectRoot), keep, cmp, p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, 0, os.ErrNotExist
+		}
+		return entries, total, fmt.Errorf("read task index: %w", err)
 	}
+	return entries, total, nil
+}
 
-	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
-	if err != nil {
-		return fmt.Errorf("open task index for append: %w", err)
+// CheckTaskIndexVersion is the task index counterpart of
+// index.CheckVersion.
+func CheckTaskIndexVersion(projectRoot string) error {
+	if err := jsonl.CheckHeader(TaskIndexFilePath(projectRoot), TaskIndexHeader); err != nil {
+		return fmt.Errorf("task index: %w", err)
 	}
-	defer f.Close()
+	return nil
+}
 
-	data, err := json.Marshal(e)
+// AppendTaskIndex adds e as a single JSON line at the end of the task
+// index file under projectRoot. The file and any missing parent directories
+// are created automatically. The index is locked and replaced atomically, as
+// with index.Append.
+func AppendTaskIndex(projectRoot string, e TaskJSON) error {
+	path := TaskIndexFilePath(projectRoot)
+	err := filelock.With(path, filelock.DefaultTimeout, func() error {
+		return jsonl.AppendAtomicWithHeader(path, TaskIndexHeader, e)
+	})
 	if err != nil {
-		return fmt.Errorf("marshal task index entry: %w", err)
-	}
-	if _, err := fmt.Fprintf(f, "%s\n", data); err != nil {
-		return fmt.Errorf("write task index entry: %w", err)
+		return fmt.Errorf("append task index entry: %w", err)
 	}
 	return nil
 }
 
-// SortJSONByDateDesc sorts TaskJSON entries newest-first in-place.
+// compareNewest orders tasks newest first. Tasks created in the same second
+// are ordered by ID, then by directory, so that listings are deterministic.
+func compareNewest(aDate, bDate time.Time, aID, bID, aDir, bDir string) int {
+	return cmp.Or(bDate.Compare(aDate), cmp.Compare(aID, bID), cmp.Compare(aDir, bDir))
+}
+
+// SortJSONByDateDesc sorts TaskJSON entries newest-first in-place. Ties are
+// broken by ID, then by directory (see compareNewest).
 func SortJSONByDateDesc(entries []TaskJSON) {
-	for i := 1; i < len(entries); i++ {
-		for j := i; j > 0 && entries[j].Date.After(entries[j-1].Date); j-- {
-			entries[j], entries[j-1] = entries[j-1], entries[j]
-		}
-	}
+	slices.SortFunc(entries, CompareJSONNewest)
+}
+
+// CompareJSONNewest orders TaskJSON entries as SortJSONByDateDesc does.
+func CompareJSONNewest(a, b TaskJSON) int {
+	return compareNewest(a.Date, b.Date, a.ID, b.ID, a.DirPath, b.DirPath)
 }
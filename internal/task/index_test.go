@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/senna-lang/logosyncx/internal/jsonl"
 	"github.com/senna-lang/logosyncx/internal/markdown"
 	"github.com/senna-lang/logosyncx/pkg/config"
 )
@@ -181,6 +182,28 @@ func TestReadAllTaskIndex_MalformedLine_ReturnsError(t *testing.T) {
 	}
 }
 
+func TestReadAllTaskIndex_TruncatedLastLine_ReturnsErrPartial(t *testing.T) {
+	dir, _ := setupTaskIndex(t)
+	date := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	if err := AppendTaskIndex(dir, makeTaskEntry("t-1", "complete", StatusOpen, date)); err != nil {
+		t.Fatalf("AppendTaskIndex: %v", err)
+	}
+	f, err := os.OpenFile(TaskIndexFilePath(dir), os.O_APPEND|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatalf("open index: %v", err)
+	}
+	f.WriteString(`{"id":"t-2","title":"cut`)
+	f.Close()
+
+	entries, err := ReadAllTaskIndex(dir)
+	if !errors.Is(err, jsonl.ErrPartial) {
+		t.Fatalf("expected jsonl.ErrPartial, got %v", err)
+	}
+	if len(entries) != 1 || entries[0].ID != "t-1" {
+		t.Errorf("expected the complete entry to be returned, got %+v", entries)
+	}
+}
+
 // --- AppendTaskIndex ---------------------------------------------------------
 
 func TestAppendTaskIndex_CreatesFileIfNotExists(t *testing.T) {
@@ -379,6 +402,19 @@ func TestRebuildTaskIndex_OverwritesExistingIndex(t *testing.T) {
 	}
 }
 
+func TestRebuildTaskIndex_LeavesNoTempFiles(t *testing.T) {
+	dir, store := setupTaskIndex(t)
+	writeTaskToStore(t, store, "a task", "open", time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC))
+
+	if _, err := store.RebuildTaskIndex(); err != nil {
+		t.Fatalf("RebuildTaskIndex: %v", err)
+	}
+	matches, _ := filepath.Glob(filepath.Join(dir, ".logosyncx", ".*.tmp"))
+	if len(matches) != 0 {
+		t.Errorf("expected no temp files after rebuild, found %v", matches)
+	}
+}
+
 func TestRebuildTaskIndex_PopulatesExcerpt(t *testing.T) {
 	dir, store := setupTaskIndex(t)
 	date := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
@@ -431,6 +467,50 @@ func TestRebuildTaskIndex_PopulatesExcerpt(t *testing.T) {
 	}
 }
 
+func TestRebuildTaskIndex_ScansOutsideTheLock(t *testing.T) {
+	dir, store := setupTaskIndex(t)
+	tk := &Task{Title: "first task", Plan: "20260101-test", Body: "## What\n\nThe first task.\n"}
+	if _, err := store.Create(tk); err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+
+	// The excerpt command reports whether the index is locked while it runs.
+	// The first time, it also plays another process: it adds a task and
+	// rewrites the index, so the rebuild must scan again to keep that task.
+	index := TaskIndexFilePath(dir)
+	flag := filepath.Join(dir, "added")
+	second := filepath.Join(dir, ".logosyncx", "tasks", "20260101-test", "002-second-task")
+	script := filepath.Join(dir, "excerpt.sh")
+	src := "#!/bin/sh\ncat >/dev/null\n" +
+		"if [ ! -e " + flag + " ]; then touch " + flag + "; mkdir -p " + second +
+		"; sed 's/^title: .*/title: second task/; s/^id: .*/id: t-second/' $(ls " + filepath.Dir(second) + "/*/TASK.md | head -1) > " + second + "/TASK.md.tmp" +
+		"; mv " + second + "/TASK.md.tmp " + second + "/TASK.md; echo >> " + index + "; fi\n" +
+		"if [ -e " + index + ".lock ]; then echo locked; else echo unlocked; fi\n"
+	if err := os.WriteFile(script, []byte(src), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	cfg := config.Default("test-project")
+	cfg.Tasks.ExcerptStrategy = "command"
+	cfg.Tasks.ExcerptCommand = script
+
+	n, err := NewStore(dir, &cfg).RebuildTaskIndex()
+	if err != nil {
+		t.Fatalf("RebuildTaskIndex: %v", err)
+	}
+	entries, err := ReadAllTaskIndex(dir)
+	if err != nil {
+		t.Fatalf("ReadAllTaskIndex: %v", err)
+	}
+	if n != 2 || len(entries) != 2 {
+		t.Fatalf("rebuilt %d, indexed %d tasks; want the task added during the scan too", n, len(entries))
+	}
+	for _, e := range entries {
+		if e.Excerpt != "unlocked" {
+			t.Errorf("%s: excerpt command ran with the index %s", e.Title, e.Excerpt)
+		}
+	}
+}
+
 func TestRebuildTaskIndex_NoTasksDir_ReturnsZero(t *testing.T) {
 	// Project root has .logosyncx/ but no tasks/ subdir.
 	dir := t.TempDir()
@@ -557,3 +637,19 @@ func TestDelete_RebuildsIndex(t *testing.T) {
 		t.Errorf("remaining task title = %q, want 'keep me'", after[0].Title)
 	}
 }
+
+func TestCheckTaskIndexVersion(t *testing.T) {
+	dir, _ := setupTaskIndex(t)
+	date := time.Date(2025, 3, 1, 10, 0, 0, 0, time.UTC)
+	if err := AppendTaskIndex(dir, makeTaskEntry("t-1", "first", StatusOpen, date)); err != nil {
+		t.Fatalf("AppendTaskIndex: %v", err)
+	}
+	if err := CheckTaskIndexVersion(dir); err != nil {
+		t.Errorf("CheckTaskIndexVersion after append: %v", err)
+	}
+
+	os.WriteFile(TaskIndexFilePath(dir), []byte(`{"id":"t-1","title":"legacy"}`+"\n"), 0o644)
+	if err := CheckTaskIndexVersion(dir); !errors.Is(err, jsonl.ErrOldVersion) {
+		t.Errorf("expected jsonl.ErrOldVersion for a headerless index, got %v", err)
+	}
+}
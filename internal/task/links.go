@@ -0,0 +1,56 @@
+// links.go records cross-references from tasks to plans other than their
+// own, and applies other list-valued updates across many tasks.
+package task
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+)
+
+// SetTags replaces the tags of each task in tags, keyed by the task's
+// DirPath. Like AddRelatedPlans, each update runs under the task's file lock
+// and the task index is rebuilt once at the end. Returns the number of task
+// files updated.
+func (s *Store) SetTags(tags map[string][]string) (int, error) {
+	return s.updateMany(tags, "tags")
+}
+
+// AddRelatedPlans adds plan filenames to the related_plans of each task in
+// links, keyed by the task's DirPath. Filenames already present are skipped.
+// Every update runs under the task's file lock; the task index is rebuilt
+// once at the end. Returns the number of task files updated.
+func (s *Store) AddRelatedPlans(links map[string][]string) (int, error) {
+	return s.updateMany(links, "related_plans")
+}
+
+// updateMany sets the list field of each task in values, keyed by DirPath,
+// then rebuilds the task index once. Empty lists are skipped for
+// related_plans, which is additive, but applied for other fields.
+func (s *Store) updateMany(values map[string][]string, field string) (int, error) {
+	n := 0
+	for dir, list := range values {
+		if len(list) == 0 && field == "related_plans" {
+			continue
+		}
+		taskPath := filepath.Join(dir, taskFileName)
+		fields := map[string]string{field: strings.Join(list, ",")}
+		if _, _, err := s.updateLocked(taskPath, fields); err != nil {
+			return n, err
+		}
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Add(s.projectRoot, taskPath)
+		}
+		n++
+	}
+	if n == 0 {
+		return 0, nil
+	}
+
+	_, _ = s.RebuildTaskIndex()
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+	}
+	return n, nil
+}
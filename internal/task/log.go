@@ -0,0 +1,65 @@
+// log.go keeps a task's worklog: timestamped, attributed entries appended
+// to the ## Log section of its TASK.md by logos task comment.
+package task
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+	"github.com/senna-lang/logosyncx/internal/markdown"
+)
+
+// LogSection is the heading of the section holding a task's worklog.
+const LogSection = "Log"
+
+// LogEntry formats one worklog line: "- YYYY-MM-DD HH:MM author: message",
+// with the time in loc. Line breaks in message are folded into spaces so
+// that every entry stays on one line.
+func LogEntry(at time.Time, loc *time.Location, author, message string) string {
+	message = strings.Join(strings.Fields(message), " ")
+	return fmt.Sprintf("- %s %s: %s", at.In(loc).Format("2006-01-02 15:04"), author, message)
+}
+
+// LogEntries returns the entries of the Log section of body, oldest first,
+// without their list markers. A task without a log yields none.
+func LogEntries(body string) []string {
+	section, _ := markdown.Section(body, LogSection)
+	var entries []string
+	for _, line := range strings.Split(markdown.StripComments(section), "\n") {
+		if entry, ok := strings.CutPrefix(strings.TrimSpace(line), "- "); ok {
+			entries = append(entries, entry)
+		}
+	}
+	return entries
+}
+
+// AppendLog adds entry (see LogEntry) at the end of the Log section of the
+// task identified by (planPartial, nameOrPartial), creating the section
+// when the task has none, and rebuilds the task index. The update runs under
+// the task's file lock, like UpdateFields.
+func (s *Store) AppendLog(planPartial, nameOrPartial, entry string) (*Task, error) {
+	found, err := s.Get(planPartial, nameOrPartial)
+	if err != nil {
+		return nil, err
+	}
+	taskPath := filepath.Join(found.DirPath, taskFileName)
+	t, err := s.rewriteLocked(taskPath, func(t *Task) bool {
+		t.Body = markdown.AppendToSection(t.Body, LogSection, entry)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, taskPath)
+	}
+
+	_, _ = s.RebuildTaskIndex()
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+	}
+	return t, nil
+}
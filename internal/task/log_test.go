@@ -0,0 +1,46 @@
+package task
+
+import (
+	"slices"
+	"testing"
+	"time"
+)
+
+func TestLogEntry_FoldsLines(t *testing.T) {
+	at := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	got := LogEntry(at, time.UTC, "alice", "first line\n  second line ")
+	if want := "- 2026-03-02 09:30 alice: first line second line"; got != want {
+		t.Errorf("LogEntry = %q, want %q", got, want)
+	}
+}
+
+func TestLogEntries(t *testing.T) {
+	body := "## What\nDo it.\n\n## Log\n<!-- progress notes -->\n- 2026-03-02 09:30 alice: started\n- 2026-03-02 10:30 bob: done\n\n## Notes\n- not a log entry\n"
+	got := LogEntries(body)
+	want := []string{"2026-03-02 09:30 alice: started", "2026-03-02 10:30 bob: done"}
+	if !slices.Equal(got, want) {
+		t.Errorf("LogEntries = %q, want %q", got, want)
+	}
+	if got := LogEntries("## What\nNo log.\n"); len(got) != 0 {
+		t.Errorf("LogEntries without a log = %q, want none", got)
+	}
+}
+
+func TestStore_AppendLog(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "plan-a", "First", "open", "medium", nil)
+
+	at := time.Date(2026, 3, 2, 9, 30, 0, 0, time.UTC)
+	for _, msg := range []string{"one", "two"} {
+		if _, err := store.AppendLog("", "first", LogEntry(at, time.UTC, "alice", msg)); err != nil {
+			t.Fatalf("AppendLog: %v", err)
+		}
+	}
+	got, err := store.Get("", "first")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if entries := LogEntries(got.Body); len(entries) != 2 || entries[1] != "2026-03-02 09:30 alice: two" {
+		t.Errorf("entries = %q", entries)
+	}
+}
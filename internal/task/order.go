@@ -0,0 +1,120 @@
+// order.go implements manual backlog ranking. A task's Order field holds its
+// rank (1 = first); unranked tasks (Order 0) sort after every ranked task,
+// newest first, so existing projects keep their date ordering until a task
+// is moved.
+package task
+
+import (
+	"fmt"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+)
+
+// compareRank orders ranked tasks by ascending Order, then unranked tasks
+// newest first. Remaining ties are broken by ID, then by directory.
+func compareRank(aOrder, bOrder int, aDate, bDate time.Time, aID, bID, aDir, bDir string) int {
+	switch {
+	case aOrder > 0 && bOrder > 0 && aOrder != bOrder:
+		return aOrder - bOrder
+	case aOrder > 0 && bOrder <= 0:
+		return -1
+	case bOrder > 0 && aOrder <= 0:
+		return 1
+	}
+	return compareNewest(aDate, bDate, aID, bID, aDir, bDir)
+}
+
+// SortByOrder sorts tasks by manual rank (in-place). See compareRank.
+func SortByOrder(tasks []*Task) {
+	slices.SortFunc(tasks, func(a, b *Task) int {
+		return compareRank(a.Order, b.Order, a.Date, b.Date, a.ID, b.ID, a.DirPath, b.DirPath)
+	})
+}
+
+// SortJSONByOrder is the index-based counterpart of SortByOrder.
+func SortJSONByOrder(entries []TaskJSON) {
+	slices.SortFunc(entries, CompareJSONByOrder)
+}
+
+// CompareJSONByOrder orders TaskJSON entries as SortJSONByOrder does.
+func CompareJSONByOrder(a, b TaskJSON) int {
+	return compareRank(a.Order, b.Order, a.Date, b.Date, a.ID, b.ID, a.DirPath, b.DirPath)
+}
+
+// Move re-ranks the task matching nameOrPartial so that it sits immediately
+// before (or, with after set, immediately after) the task matching
+// targetName. Both names are resolved like Get, narrowed by planPartial.
+// Ranks are kept per plan and status, so both tasks must share the same plan
+// and status; tasks outside that group are never renumbered.
+//
+// Every task in the group from the top of the ranking down to the moved task,
+// its target, and the last previously ranked task is renumbered 1..n; only
+// files whose Order actually changes are rewritten. Returns the number of
+// tasks updated.
+func (s *Store) Move(planPartial, nameOrPartial, targetName string, after bool) (int, error) {
+	moving, err := s.Get(planPartial, nameOrPartial)
+	if err != nil {
+		return 0, err
+	}
+	target, err := s.Get(planPartial, targetName)
+	if err != nil {
+		return 0, err
+	}
+	if moving.DirPath == target.DirPath {
+		return 0, fmt.Errorf("cannot move a task relative to itself")
+	}
+	if moving.Plan != target.Plan || moving.Status != target.Status {
+		return 0, fmt.Errorf("cannot rank %q (%s, %s) relative to %q (%s, %s): tasks are ranked within a plan and status",
+			moving.Title, moving.Plan, moving.Status, target.Title, target.Plan, target.Status)
+	}
+
+	tasks, loadErr := s.loadAll()
+	tasks = slices.DeleteFunc(tasks, func(t *Task) bool {
+		return t.Plan != target.Plan || t.Status != target.Status
+	})
+	SortByOrder(tasks)
+
+	tasks = slices.DeleteFunc(tasks, func(t *Task) bool { return t.DirPath == moving.DirPath })
+	idx := slices.IndexFunc(tasks, func(t *Task) bool { return t.DirPath == target.DirPath })
+	if idx < 0 {
+		return 0, fmt.Errorf("target task %q not found", targetName)
+	}
+	if after {
+		idx++
+	}
+	tasks = slices.Insert(tasks, idx, moving)
+
+	// Renumber the ranked prefix of the list: everything up to the moved
+	// task, its target, or the last previously ranked task.
+	last := idx
+	for i, t := range tasks {
+		if t.Order > 0 || t.DirPath == target.DirPath {
+			last = max(last, i)
+		}
+	}
+
+	n := 0
+	for i, t := range tasks[:last+1] {
+		if t.Order == i+1 {
+			continue
+		}
+		taskPath := filepath.Join(t.DirPath, taskFileName)
+		if _, _, err := s.updateLocked(taskPath, map[string]string{"order": strconv.Itoa(i + 1)}); err != nil {
+			return n, err
+		}
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Add(s.projectRoot, taskPath)
+		}
+		n++
+	}
+
+	_, _ = s.RebuildTaskIndex()
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+	}
+	return n, loadErr
+}
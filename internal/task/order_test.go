@@ -0,0 +1,154 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func titles(tasks []*Task) []string {
+	out := make([]string, len(tasks))
+	for i, t := range tasks {
+		out[i] = t.Title
+	}
+	return out
+}
+
+func assertTitles(t *testing.T, got []*Task, want ...string) {
+	t.Helper()
+	g := titles(got)
+	if len(g) != len(want) {
+		t.Fatalf("got %v, want %v", g, want)
+	}
+	for i := range want {
+		if g[i] != want[i] {
+			t.Fatalf("got %v, want %v", g, want)
+		}
+	}
+}
+
+func TestSortByOrder_RankedFirstThenNewest(t *testing.T) {
+	d := func(day int) time.Time { return time.Date(2026, 1, day, 0, 0, 0, 0, time.UTC) }
+	tasks := []*Task{
+		{Title: "old-unranked", Date: d(1)},
+		{Title: "second", Date: d(2), Order: 2},
+		{Title: "new-unranked", Date: d(5)},
+		{Title: "first", Date: d(3), Order: 1},
+	}
+	SortByOrder(tasks)
+	assertTitles(t, tasks, "first", "second", "new-unranked", "old-unranked")
+}
+
+func TestSortByOrder_TiesByIDThenDir(t *testing.T) {
+	same := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	tasks := []*Task{
+		{Title: "unranked-b", ID: "t-b", Date: same},
+		{Title: "dup-2", ID: "t-2", Order: 1, Date: same},
+		{Title: "unranked-a", ID: "t-a", Date: same},
+		{Title: "dup-1", ID: "t-1", Order: 1, Date: same},
+	}
+	SortByOrder(tasks)
+	assertTitles(t, tasks, "dup-1", "dup-2", "unranked-a", "unranked-b")
+}
+
+// rankedList returns the store's tasks sorted by manual rank.
+func rankedList(t *testing.T, store *Store) []*Task {
+	t.Helper()
+	tasks, err := store.List(Filter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	SortByOrder(tasks)
+	return tasks
+}
+
+func TestStore_Move_Before(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "plan-a", "Alpha", "open", "", nil)
+	createTask(t, store, "plan-a", "Beta", "open", "", nil)
+	createTask(t, store, "plan-a", "Gamma", "open", "", nil)
+
+	if _, err := store.Move("", "gamma", "alpha", false); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	if _, err := store.Move("", "beta", "alpha", true); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	assertTitles(t, rankedList(t, store), "Gamma", "Alpha", "Beta")
+
+	// Moving to the front renumbers the ranked tasks behind it.
+	if _, err := store.Move("", "beta", "gamma", false); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	got := rankedList(t, store)
+	assertTitles(t, got, "Beta", "Gamma", "Alpha")
+	for i, tk := range got {
+		if tk.Order != i+1 {
+			t.Errorf("%s: Order = %d, want %d", tk.Title, tk.Order, i+1)
+		}
+	}
+}
+
+func TestStore_Move_UpdatesIndex(t *testing.T) {
+	dir, store := setupStore(t)
+	createTask(t, store, "plan-a", "Alpha", "open", "", nil)
+	createTask(t, store, "plan-a", "Beta", "open", "", nil)
+
+	if _, err := store.Move("", "alpha", "beta", false); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	entries, err := ReadAllTaskIndex(dir)
+	if err != nil {
+		t.Fatalf("ReadAllTaskIndex: %v", err)
+	}
+	SortJSONByOrder(entries)
+	if entries[0].Title != "Alpha" || entries[0].Order != 1 {
+		t.Errorf("expected Alpha ranked first in index, got %+v", entries[0])
+	}
+}
+
+func TestStore_Move_SelfIsError(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "plan-a", "Alpha", "open", "", nil)
+
+	if _, err := store.Move("", "alpha", "alpha", false); err == nil {
+		t.Fatal("expected error moving a task relative to itself")
+	}
+}
+
+func TestStore_Move_OnlyRenumbersSamePlanAndStatus(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "plan-a", "Alpha", "open", "", nil)
+	createTask(t, store, "plan-a", "Beta", "open", "", nil)
+	createTask(t, store, "plan-a", "Shipped", "done", "", nil)
+	createTask(t, store, "plan-b", "Other", "open", "", nil)
+
+	if _, err := store.Move("", "beta", "alpha", false); err != nil {
+		t.Fatalf("Move: %v", err)
+	}
+	for _, tk := range rankedList(t, store) {
+		switch tk.Title {
+		case "Beta":
+			if tk.Order != 1 {
+				t.Errorf("Beta: Order = %d, want 1", tk.Order)
+			}
+		case "Alpha":
+			if tk.Order != 2 {
+				t.Errorf("Alpha: Order = %d, want 2", tk.Order)
+			}
+		default:
+			if tk.Order != 0 {
+				t.Errorf("%s: Order = %d, want it left unranked", tk.Title, tk.Order)
+			}
+		}
+	}
+}
+
+func TestStore_Move_AcrossStatusIsError(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "plan-a", "Alpha", "open", "", nil)
+	createTask(t, store, "plan-a", "Shipped", "done", "", nil)
+
+	if _, err := store.Move("", "alpha", "shipped", false); err == nil {
+		t.Fatal("expected error ranking tasks with different statuses")
+	}
+}
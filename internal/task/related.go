@@ -0,0 +1,82 @@
+// related.go estimates which other tasks are related to a given task, so
+// task refer can point agents at existing work before they duplicate it.
+package task
+
+import (
+	"slices"
+	"strings"
+)
+
+// relatedLimit is the default maximum number of tasks FindRelated returns.
+const relatedLimit = 5
+
+// RelatedTask is a task FindRelated judged possibly related to the target.
+type RelatedTask struct {
+	Task       TaskJSON
+	Score      int
+	SharedTags []string
+	LinkedPlan bool // the task belongs to a plan linked to the target's plan
+}
+
+// Reason returns a short human-readable explanation of the match, e.g.
+// "shared tags: auth, jwt; linked plan".
+func (r RelatedTask) Reason() string {
+	var parts []string
+	if len(r.SharedTags) > 0 {
+		parts = append(parts, "shared tags: "+strings.Join(r.SharedTags, ", "))
+	}
+	if r.LinkedPlan {
+		parts = append(parts, "linked plan")
+	}
+	return strings.Join(parts, "; ")
+}
+
+// FindRelated scores every task in all against target and returns the
+// highest-scoring ones (at most limit; <= 0 means the default of 5).
+//
+// Each shared tag (case-insensitive) scores 2 and membership of one of
+// linkedPlans (plan slugs related to the target's plan) scores 1. Tasks that
+// score 0 are omitted — in particular, sibling tasks in the same plan are
+// only reported when they share a tag, since task ls already lists them.
+// Ties are broken newest first.
+func FindRelated(target TaskJSON, all []TaskJSON, linkedPlans []string, limit int) []RelatedTask {
+	if limit <= 0 {
+		limit = relatedLimit
+	}
+	targetTags := make(map[string]bool, len(target.Tags))
+	for _, tag := range target.Tags {
+		targetTags[strings.ToLower(tag)] = true
+	}
+
+	var out []RelatedTask
+	for _, t := range all {
+		if t.DirPath == target.DirPath {
+			continue
+		}
+		r := RelatedTask{Task: t}
+		for _, tag := range t.Tags {
+			if targetTags[strings.ToLower(tag)] {
+				r.SharedTags = append(r.SharedTags, tag)
+				r.Score += 2
+			}
+		}
+		if t.Plan != target.Plan && slices.Contains(linkedPlans, t.Plan) {
+			r.LinkedPlan = true
+			r.Score++
+		}
+		if r.Score > 0 {
+			out = append(out, r)
+		}
+	}
+
+	slices.SortStableFunc(out, func(a, b RelatedTask) int {
+		if a.Score != b.Score {
+			return b.Score - a.Score
+		}
+		return compareNewest(a.Task.Date, b.Task.Date, a.Task.ID, b.Task.ID, a.Task.DirPath, b.Task.DirPath)
+	})
+	if len(out) > limit {
+		out = out[:limit]
+	}
+	return out
+}
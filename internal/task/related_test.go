@@ -0,0 +1,53 @@
+package task
+
+import (
+	"testing"
+	"time"
+)
+
+func relatedEntry(dir, plan string, date time.Time, tags ...string) TaskJSON {
+	return TaskJSON{DirPath: dir, Plan: plan, Date: date, Tags: tags, Status: StatusOpen}
+}
+
+func TestFindRelated_ScoresSharedTagsAndLinkedPlans(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	target := relatedEntry("a/001-login", "plan-a", day, "auth", "jwt")
+	all := []TaskJSON{
+		target,
+		relatedEntry("a/002-sibling", "plan-a", day),               // same plan, no tags: omitted
+		relatedEntry("b/001-tokens", "plan-b", day, "JWT", "auth"), // two shared tags
+		relatedEntry("c/001-docs", "plan-c", day),                  // linked plan only
+		relatedEntry("d/001-other", "plan-d", day, "ui"),           // unrelated
+	}
+
+	got := FindRelated(target, all, []string{"plan-c"}, 0)
+	if len(got) != 2 {
+		t.Fatalf("expected 2 related tasks, got %+v", got)
+	}
+	if got[0].Task.DirPath != "b/001-tokens" || got[0].Score != 4 {
+		t.Errorf("first = %s (score %d), want b/001-tokens (4)", got[0].Task.DirPath, got[0].Score)
+	}
+	if got[0].Reason() != "shared tags: JWT, auth" {
+		t.Errorf("reason = %q", got[0].Reason())
+	}
+	if got[1].Task.DirPath != "c/001-docs" || got[1].Reason() != "linked plan" {
+		t.Errorf("second = %s (%q), want c/001-docs (linked plan)", got[1].Task.DirPath, got[1].Reason())
+	}
+}
+
+func TestFindRelated_LimitAndTieBreakNewestFirst(t *testing.T) {
+	day := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	target := relatedEntry("a/001", "plan-a", day, "auth")
+	var all []TaskJSON
+	for i := range 8 {
+		all = append(all, relatedEntry("b/00"+string(rune('1'+i)), "plan-b", day.AddDate(0, 0, i), "auth"))
+	}
+
+	got := FindRelated(target, all, nil, 3)
+	if len(got) != 3 {
+		t.Fatalf("expected limit of 3, got %d", len(got))
+	}
+	if got[0].Task.DirPath != "b/008" {
+		t.Errorf("expected newest task first, got %s", got[0].Task.DirPath)
+	}
+}
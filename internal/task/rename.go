@@ -0,0 +1,154 @@
+// rename.go retitles tasks and moves task groups along with a renamed plan,
+// keeping directory names and cross-references in step.
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/filelock"
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+)
+
+// Rename sets the title of the task identified by (planPartial,
+// nameOrPartial) and moves its directory to NNN-<slug of title>, then
+// rebuilds the task index. It returns the task as it was before the rename
+// and as it is after. A directory already holding the new name is an error.
+func (s *Store) Rename(planPartial, nameOrPartial, title string) (before, after *Task, err error) {
+	title = strings.TrimSpace(title)
+	if title == "" {
+		return nil, nil, fmt.Errorf("task title is required")
+	}
+	before, err = s.Get(planPartial, nameOrPartial)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	newDir := filepath.Join(filepath.Dir(before.DirPath), TaskDirName(before.Seq, title))
+	if newDir != before.DirPath {
+		if _, err := os.Stat(newDir); err == nil {
+			rel, _ := filepath.Rel(s.projectRoot, newDir)
+			return nil, nil, fmt.Errorf("%s already exists", rel)
+		}
+	}
+
+	after, err = s.rewriteLocked(filepath.Join(before.DirPath, taskFileName), func(t *Task) bool {
+		t.Title = title
+		return true
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if newDir != before.DirPath {
+		if err := os.Rename(before.DirPath, newDir); err != nil {
+			return nil, nil, fmt.Errorf("move task directory: %w", err)
+		}
+		after.DirPath = newDir
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Remove(s.projectRoot, before.DirPath)
+		}
+	}
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, newDir)
+	}
+
+	_, _ = s.RebuildTaskIndex()
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+	}
+	return before, after, nil
+}
+
+// RenamePlan follows a plan renamed from oldSlug to newSlug: the task group
+// directory tasks/<oldSlug>/ moves to tasks/<newSlug>/ with every task's
+// plan field rewritten, and oldSlug.md is replaced by newSlug.md in the
+// related_plans of tasks in other plans. The task index is rebuilt once at
+// the end. Returns the number of task files updated; tasks that could not
+// be parsed are left alone and reported in the error.
+func (s *Store) RenamePlan(oldSlug, newSlug string) (int, error) {
+	oldDir := filepath.Join(s.dir, oldSlug)
+	newDir := filepath.Join(s.dir, newSlug)
+	if oldSlug == newSlug {
+		return 0, nil
+	}
+	if _, err := os.Stat(oldDir); err == nil {
+		if _, err := os.Stat(newDir); err == nil {
+			rel, _ := filepath.Rel(s.projectRoot, newDir)
+			return 0, fmt.Errorf("%s already exists", rel)
+		}
+		if err := os.Rename(oldDir, newDir); err != nil {
+			return 0, fmt.Errorf("move task group: %w", err)
+		}
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Remove(s.projectRoot, oldDir)
+			_ = gitutil.Add(s.projectRoot, newDir)
+		}
+	}
+
+	tasks, loadErr := s.loadAll()
+	oldRef, newRef := oldSlug+".md", newSlug+".md"
+	n := 0
+	for _, t := range tasks {
+		if t.Plan != oldSlug && !slices.Contains(t.RelatedPlans, oldRef) {
+			continue
+		}
+		taskPath := filepath.Join(t.DirPath, taskFileName)
+		changed := false
+		_, err := s.rewriteLocked(taskPath, func(t *Task) bool {
+			if t.Plan == oldSlug {
+				t.Plan, changed = newSlug, true
+			}
+			for i, ref := range t.RelatedPlans {
+				if ref == oldRef {
+					t.RelatedPlans[i], changed = newRef, true
+				}
+			}
+			return changed
+		})
+		if err != nil {
+			return n, err
+		}
+		if !changed {
+			continue
+		}
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Add(s.projectRoot, taskPath)
+		}
+		n++
+	}
+
+	_, _ = s.RebuildTaskIndex()
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+	}
+	return n, loadErr
+}
+
+// rewriteLocked re-reads the TASK.md at taskPath under its advisory lock,
+// applies edit, and writes the file back when edit reports a change.
+func (s *Store) rewriteLocked(taskPath string, edit func(*Task) bool) (*Task, error) {
+	lock, err := filelock.Acquire(taskPath, filelock.DefaultTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("lock task: %w", err)
+	}
+	defer lock.Release()
+
+	t, err := s.loadFile(taskPath)
+	if err != nil {
+		return nil, err
+	}
+	if !edit(t) {
+		return t, nil
+	}
+	data, err := Marshal(*t)
+	if err != nil {
+		return nil, fmt.Errorf("marshal task: %w", err)
+	}
+	if err := writeFileAtomic(taskPath, data); err != nil {
+		return nil, fmt.Errorf("write TASK.md: %w", err)
+	}
+	return t, nil
+}
@@ -0,0 +1,67 @@
+package task
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRename_KeepsSeqAndDependents(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "plan-a", "First", "open", "medium", nil)
+	createTask(t, store, "plan-a", "Second", "open", "medium", []int{1})
+
+	before, after, err := store.Rename("plan-a", "first", "Initial step")
+	if err != nil {
+		t.Fatalf("Rename: %v", err)
+	}
+	if filepath.Base(before.DirPath) != "001-first" || filepath.Base(after.DirPath) != "001-initial-step" {
+		t.Errorf("moved %s → %s", before.DirPath, after.DirPath)
+	}
+	if _, err := os.Stat(filepath.Join(after.DirPath, taskFileName)); err != nil {
+		t.Errorf("renamed TASK.md missing: %v", err)
+	}
+	second, err := store.Get("plan-a", "second")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(second.DependsOn) != 1 || second.DependsOn[0] != before.Seq {
+		t.Errorf("second task lost its dependency: %+v", second.DependsOn)
+	}
+}
+
+func TestRename_RefusesTakenDirectory(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "plan-a", "First", "open", "medium", nil)
+	if err := os.MkdirAll(filepath.Join(store.dir, "plan-a", "001-taken"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	_, _, err := store.Rename("plan-a", "first", "Taken")
+	if err == nil || !strings.Contains(err.Error(), "already exists") {
+		t.Errorf("Rename onto a taken directory = %v, want an error", err)
+	}
+}
+
+func TestRenamePlan_MovesGroupAndRewritesLinks(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "old-plan", "Work", "open", "medium", nil)
+	other := createTask(t, store, "other", "Elsewhere", "open", "medium", nil)
+	if _, err := store.AddRelatedPlans(map[string][]string{other.DirPath: {"old-plan.md"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	n, err := store.RenamePlan("old-plan", "new-plan")
+	if err != nil || n != 2 {
+		t.Fatalf("RenamePlan = %d, %v; want 2 tasks updated", n, err)
+	}
+	moved, err := store.Get("new-plan", "work")
+	if err != nil || moved.Plan != "new-plan" {
+		t.Errorf("moved task = %+v, %v", moved, err)
+	}
+	linked, err := store.Get("other", "elsewhere")
+	if err != nil || len(linked.RelatedPlans) != 1 || linked.RelatedPlans[0] != "new-plan.md" {
+		t.Errorf("linked task = %+v, %v", linked, err)
+	}
+}
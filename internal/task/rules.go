@@ -0,0 +1,58 @@
+package task
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+// RuleEffect records a field set on a task by a routing rule.
+type RuleEffect struct {
+	Rule  int    // index of the rule in config tasks.rules
+	Field string // "assignee" or "priority"
+	Value string
+}
+
+// String formats the effect as e.g. "rule 1: priority=high".
+func (e RuleEffect) String() string {
+	return fmt.Sprintf("rule %d: %s=%s", e.Rule+1, e.Field, e.Value)
+}
+
+// RuleMatches reports whether r applies to t.
+func RuleMatches(r config.TaskRule, t *Task) bool {
+	if r.Tag == "" || !slices.Contains(t.Tags, r.Tag) {
+		return false
+	}
+	if r.Plan != "" && !strings.Contains(strings.ToLower(t.Plan), strings.ToLower(r.Plan)) {
+		return false
+	}
+	return true
+}
+
+// ApplyRules sets Assignee and Priority on t from the first matching rule
+// that provides each field, leaving fields that t already sets untouched.
+// It returns the effects applied, or an error when a matching rule names an
+// invalid priority.
+func ApplyRules(t *Task, rules []config.TaskRule) ([]RuleEffect, error) {
+	var effects []RuleEffect
+	for i, r := range rules {
+		if !RuleMatches(r, t) {
+			continue
+		}
+		if r.Assignee != "" && t.Assignee == "" {
+			t.Assignee = r.Assignee
+			effects = append(effects, RuleEffect{Rule: i, Field: "assignee", Value: r.Assignee})
+		}
+		if r.Priority != "" && t.Priority == "" {
+			p := Priority(r.Priority)
+			if !IsValidPriority(p) {
+				return effects, fmt.Errorf("tasks.rules[%d]: invalid priority %q: must be one of high, medium, low", i, r.Priority)
+			}
+			t.Priority = p
+			effects = append(effects, RuleEffect{Rule: i, Field: "priority", Value: r.Priority})
+		}
+	}
+	return effects, nil
+}
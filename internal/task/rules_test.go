@@ -0,0 +1,67 @@
+package task
+
+import (
+	"testing"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+func TestApplyRules_FirstMatchingRuleWinsPerField(t *testing.T) {
+	rules := []config.TaskRule{
+		{Tag: "docs", Assignee: "writers"},
+		{Tag: "infra", Priority: "high"},
+		{Tag: "infra", Assignee: "ops-team", Priority: "low"},
+	}
+	tk := &Task{Tags: []string{"infra"}}
+
+	effects, err := ApplyRules(tk, rules)
+	if err != nil {
+		t.Fatalf("ApplyRules: %v", err)
+	}
+	if tk.Priority != PriorityHigh || tk.Assignee != "ops-team" {
+		t.Errorf("got priority=%q assignee=%q, want high/ops-team", tk.Priority, tk.Assignee)
+	}
+	if len(effects) != 2 {
+		t.Errorf("expected 2 effects, got %v", effects)
+	}
+}
+
+func TestApplyRules_ExplicitFieldsAreKept(t *testing.T) {
+	rules := []config.TaskRule{{Tag: "infra", Assignee: "ops-team", Priority: "high"}}
+	tk := &Task{Tags: []string{"infra"}, Priority: PriorityLow, Assignee: "alice"}
+
+	effects, err := ApplyRules(tk, rules)
+	if err != nil {
+		t.Fatalf("ApplyRules: %v", err)
+	}
+	if tk.Priority != PriorityLow || tk.Assignee != "alice" || len(effects) != 0 {
+		t.Errorf("explicit fields overridden: %+v effects=%v", tk, effects)
+	}
+}
+
+func TestApplyRules_PlanConstraint(t *testing.T) {
+	rules := []config.TaskRule{{Tag: "infra", Plan: "billing", Assignee: "billing-ops"}}
+
+	other := &Task{Tags: []string{"infra"}, Plan: "20260101-auth"}
+	if _, err := ApplyRules(other, rules); err != nil {
+		t.Fatalf("ApplyRules: %v", err)
+	}
+	if other.Assignee != "" {
+		t.Errorf("rule applied to wrong plan: assignee=%q", other.Assignee)
+	}
+
+	billing := &Task{Tags: []string{"infra"}, Plan: "20260101-billing-v2"}
+	if _, err := ApplyRules(billing, rules); err != nil {
+		t.Fatalf("ApplyRules: %v", err)
+	}
+	if billing.Assignee != "billing-ops" {
+		t.Errorf("assignee = %q, want billing-ops", billing.Assignee)
+	}
+}
+
+func TestApplyRules_InvalidPriority_ReturnsError(t *testing.T) {
+	rules := []config.TaskRule{{Tag: "infra", Priority: "urgent"}}
+	if _, err := ApplyRules(&Task{Tags: []string{"infra"}}, rules); err == nil {
+		t.Error("expected error for invalid rule priority")
+	}
+}
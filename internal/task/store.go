@@ -3,25 +3,27 @@
 package task
 
 import (
-	"crypto/rand"
-	"encoding/hex"
+	"cmp"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"slices"
-	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/senna-lang/logosyncx/internal/filelock"
 	"github.com/senna-lang/logosyncx/internal/gitutil"
+	"github.com/senna-lang/logosyncx/internal/jsonl"
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/resolve"
 	"github.com/senna-lang/logosyncx/pkg/config"
+	"gopkg.in/yaml.v3"
 )
 
-// idPrefix is prepended to every auto-generated task ID.
-const idPrefix = "t-"
-
 // taskFileName is the canonical filename for every task file.
 const taskFileName = "TASK.md"
 
@@ -35,9 +37,17 @@ var ErrNotFound = errors.New("not found")
 var ErrAmbiguous = errors.New("ambiguous: multiple matches")
 
 // ErrBlocked is returned by UpdateFields when a task cannot be moved to
-// in_progress because one or more of its depends_on tasks are not yet done.
+// in_progress or done because one or more of its depends_on tasks are not
+// yet done.
 var ErrBlocked = errors.New("task is blocked by unfinished dependencies")
 
+// Warnf reports a non-fatal problem, such as a failed auto-commit. It prints
+// "warning: ..." to stderr by default; the CLI replaces it so that warnings
+// follow its --warnings-json format.
+var Warnf = func(format string, args ...any) {
+	fmt.Fprintf(os.Stderr, "warning: "+format+"\n", args...)
+}
+
 // Store is the read/write gateway for task files under .logosyncx/tasks/.
 //
 // Directory layout:
@@ -52,6 +62,11 @@ type Store struct {
 	dir         string // absolute path to .logosyncx/tasks/
 	plansDir    string // absolute path to .logosyncx/plans/
 	cfg         *config.Config
+
+	excerptOnce    sync.Once
+	excerpter      markdown.Excerpter // for reads: never an excerpt command
+	indexExcerpter markdown.Excerpter // for index entries
+	prefixes       []*regexp.Regexp
 }
 
 // NewStore creates a Store rooted at projectRoot using the provided config.
@@ -110,7 +125,7 @@ func (s *Store) Create(t *Task) (string, error) {
 
 	// Auto-fill ID.
 	if t.ID == "" {
-		id, err := generateID()
+		id, err := s.NextID()
 		if err != nil {
 			return "", fmt.Errorf("generate task id: %w", err)
 		}
@@ -188,13 +203,16 @@ func (s *Store) Create(t *Task) (string, error) {
 	}
 
 	// Best-effort git add.
-	if s.cfg.Git.AutoPush {
+	if s.cfg.Git.Stages() {
 		_ = gitutil.Add(s.projectRoot, taskPath)
+		if s.cfg.Tasks.IDMode == IDModeSequential {
+			_ = gitutil.Add(s.projectRoot, IDCounterFilePath(s.projectRoot))
+		}
 	}
 
 	// Best-effort index rebuild (full rebuild for consistency).
 	_, _ = s.RebuildTaskIndex()
-	if s.cfg.Git.AutoPush {
+	if s.cfg.Git.Stages() {
 		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
 	}
 
@@ -230,7 +248,11 @@ func (s *Store) Get(planPartial, nameOrPartial string) (*Task, error) {
 }
 
 // List loads all tasks, applies the filter, and returns them sorted newest-first.
+// A filter naming an unknown status or priority is an error (see Validate).
 func (s *Store) List(f Filter) ([]*Task, error) {
+	if err := f.Validate(); err != nil {
+		return nil, err
+	}
 	tasks, err := s.loadAll()
 	if err != nil {
 		return nil, err
@@ -244,17 +266,109 @@ func (s *Store) List(f Filter) ([]*Task, error) {
 // applies the supplied field updates, and writes the TASK.md back in-place
 // (no directory move — status lives in frontmatter only).
 //
-// Supported keys: "status", "priority", "assignee".
+// Supported keys: "status", "priority", "assignee", "order", "snoozed_until"
+// and "due" (YYYY-MM-DD in display.timezone, or "" to clear), "related_plans"
+// (comma-separated plan filenames, added to the existing list).
 //
 // Special behaviour:
 //   - "status" → "in_progress": hard error if IsBlocked returns true.
+//   - "status" → "in_progress": sets StartedAt.
 //   - "status" → "done": sets CompletedAt; calls CreateWalkthroughScaffold.
+//     Moving a done task back to open or in_progress clears CompletedAt.
+//
+// The read-modify-write of TASK.md runs under a per-file advisory lock, so
+// concurrent updates to the same task are serialised rather than lost.
 func (s *Store) UpdateFields(planPartial, nameOrPartial string, fields map[string]string) error {
-	t, err := s.Get(planPartial, nameOrPartial)
+	found, err := s.Get(planPartial, nameOrPartial)
+	if err != nil {
+		return err
+	}
+	if err := s.applyFields(filepath.Join(found.DirPath, taskFileName), fields); err != nil {
+		return err
+	}
+
+	// Best-effort index rebuild.
+	_, _ = s.RebuildTaskIndex()
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+	}
+
+	return nil
+}
+
+// UpdateAll applies fields to each of tasks as UpdateFields does, then
+// rebuilds the task index once. A task the update is refused for (blocked,
+// or marked done without a walkthrough) is skipped and the rest are still
+// updated; the refusals are returned joined, each naming its task. It
+// returns the tasks that were updated, as written, or as they were before
+// the update when the written file cannot be read back.
+func (s *Store) UpdateAll(tasks []*Task, fields map[string]string) ([]*Task, error) {
+	var updated []*Task
+	var errs []error
+	for _, t := range tasks {
+		taskPath := filepath.Join(t.DirPath, taskFileName)
+		if err := s.applyFields(taskPath, fields); err != nil {
+			errs = append(errs, fmt.Errorf("%s/%s: %w", t.Plan, filepath.Base(t.DirPath), err))
+			continue
+		}
+		after, err := s.loadFile(taskPath)
+		if err != nil {
+			// The update was written; report the task as it was before.
+			Warnf("could not re-read %s/%s after updating it: %v", t.Plan, filepath.Base(t.DirPath), err)
+			after = t
+		}
+		updated = append(updated, after)
+	}
+	if len(updated) > 0 {
+		if _, err := s.RebuildTaskIndex(); err != nil {
+			Warnf("could not rebuild the task index (%v) — run `logos sync` to rebuild", err)
+		}
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+		}
+	}
+	return updated, errors.Join(errs...)
+}
+
+// applyFields updates the TASK.md at taskPath under its lock, creates the
+// walkthrough scaffold when the task is now done, and stages the file.
+func (s *Store) applyFields(taskPath string, fields map[string]string) error {
+	t, _, err := s.updateLocked(taskPath, fields)
 	if err != nil {
 		return err
 	}
 
+	// Create walkthrough scaffold when task is marked done.
+	if t.Status == StatusDone {
+		if err := s.CreateWalkthroughScaffold(t); err != nil {
+			// Non-fatal: warn but don't fail the update.
+			Warnf("could not create walkthrough scaffold: %v", err)
+		}
+	}
+
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, taskPath)
+	}
+	return nil
+}
+
+// updateLocked applies fields to the TASK.md at taskPath while holding an
+// advisory lock on it. The file is re-read under the lock so that concurrent
+// read-modify-write sequences (e.g. two `logos task update` calls on the same
+// task) cannot lose each other's changes. Returns the updated task and whether
+// this call transitioned it to done.
+func (s *Store) updateLocked(taskPath string, fields map[string]string) (*Task, bool, error) {
+	lock, err := filelock.Acquire(taskPath, filelock.DefaultTimeout)
+	if err != nil {
+		return nil, false, fmt.Errorf("lock task: %w", err)
+	}
+	defer lock.Release()
+
+	t, err := s.loadFile(taskPath)
+	if err != nil {
+		return nil, false, err
+	}
+
 	transitionedToDone := false
 
 	for k, v := range fields {
@@ -263,14 +377,14 @@ func (s *Store) UpdateFields(planPartial, nameOrPartial string, fields map[strin
 			newStatus := Status(v)
 
 			if !IsValidStatus(newStatus) {
-				return fmt.Errorf("invalid status %q: must be one of open, in_progress, done", v)
+				return nil, false, fmt.Errorf("invalid status %q: must be one of open, in_progress, done", v)
 			}
 
-			if newStatus == StatusInProgress {
+			if newStatus == StatusInProgress || (newStatus == StatusDone && t.Status != StatusDone) {
 				// Load sibling tasks to check dependencies.
 				planTasks, _ := s.loadPlanTasks(filepath.Dir(t.DirPath))
 				if IsBlocked(t, planTasks) {
-					return fmt.Errorf("%w: complete dependencies first", ErrBlocked)
+					return nil, false, fmt.Errorf("%w: complete dependencies first", ErrBlocked)
 				}
 			}
 
@@ -281,71 +395,116 @@ func (s *Store) UpdateFields(planPartial, nameOrPartial string, fields map[strin
 					if relErr != nil {
 						relWPath = wPath
 					}
-					return fmt.Errorf("WALKTHROUGH.md has no content: write content to\n  %s\nthen re-run", relWPath)
+					return nil, false, fmt.Errorf("WALKTHROUGH.md has no content: write content to\n  %s\nthen re-run", relWPath)
 				}
 				now := time.Now()
 				t.CompletedAt = &now
 				transitionedToDone = true
 			}
+			if newStatus == StatusInProgress && t.Status != StatusInProgress {
+				now := time.Now()
+				t.StartedAt = &now
+			}
+			if newStatus != StatusDone {
+				t.CompletedAt = nil
+			}
 
 			t.Status = newStatus
 
 		case "priority":
 			newPriority := Priority(v)
 			if !IsValidPriority(newPriority) {
-				return fmt.Errorf("invalid priority %q: must be one of low, medium, high", v)
+				return nil, false, fmt.Errorf("invalid priority %q: must be one of low, medium, high", v)
 			}
 			t.Priority = newPriority
 
 		case "assignee":
 			t.Assignee = v
 
+		case "order":
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				return nil, false, fmt.Errorf("invalid order %q: must be a non-negative integer", v)
+			}
+			t.Order = n
+
+		case "snoozed_until":
+			if v == "" {
+				t.SnoozedUntil = nil
+				break
+			}
+			until, err := s.parseDay(v)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid snooze date %q: expected YYYY-MM-DD", v)
+			}
+			t.SnoozedUntil = &until
+
+		case "due":
+			if v == "" {
+				t.Due = nil
+				break
+			}
+			due, err := s.parseDay(v)
+			if err != nil {
+				return nil, false, fmt.Errorf("invalid due date %q: expected YYYY-MM-DD", v)
+			}
+			t.Due = &due
+
+		case "tags":
+			// Replacing: v is the full comma-separated tag list.
+			t.Tags = nil
+			for _, tag := range strings.Split(v, ",") {
+				if tag = strings.TrimSpace(tag); tag != "" && !slices.Contains(t.Tags, tag) {
+					t.Tags = append(t.Tags, tag)
+				}
+			}
+
+		case "related_plans":
+			// Additive: v is a comma-separated list of plan filenames to link.
+			for _, name := range strings.Split(v, ",") {
+				if name = strings.TrimSpace(name); name != "" && !slices.Contains(t.RelatedPlans, name) {
+					t.RelatedPlans = append(t.RelatedPlans, name)
+				}
+			}
+
 		default:
-			return fmt.Errorf("unknown updatable field %q", k)
+			return nil, false, fmt.Errorf("unknown updatable field %q", k)
 		}
 	}
 
 	// Write back in-place — no directory move.
-	taskPath := filepath.Join(t.DirPath, taskFileName)
 	data, err := Marshal(*t)
 	if err != nil {
-		return fmt.Errorf("marshal task: %w", err)
+		return nil, false, fmt.Errorf("marshal task: %w", err)
 	}
-	if err := os.WriteFile(taskPath, data, 0o644); err != nil {
-		return fmt.Errorf("write TASK.md: %w", err)
+	if err := writeFileAtomic(taskPath, data); err != nil {
+		return nil, false, fmt.Errorf("write TASK.md: %w", err)
 	}
+	return t, transitionedToDone, nil
+}
 
-	// Create walkthrough scaffold when task is marked done.
-	if t.Status == StatusDone {
-		if err := s.CreateWalkthroughScaffold(t); err != nil {
-			// Non-fatal: warn but don't fail the update.
-			fmt.Fprintf(os.Stderr, "warning: could not create walkthrough scaffold: %v\n", err)
-		}
+// writeFileAtomic writes data to a temp file beside path and renames it into
+// place, so readers that do not take the lock (Get, List) never observe a
+// truncated TASK.md.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".TASK-*.tmp")
+	if err != nil {
+		return err
 	}
-
-	if s.cfg.Git.AutoPush {
-		_ = gitutil.Add(s.projectRoot, taskPath)
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
 	}
-
-	// Best-effort index rebuild.
-	_, _ = s.RebuildTaskIndex()
-	if s.cfg.Git.AutoPush {
-		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
 	}
-
-	// Auto commit+push on done transition when auto_push is enabled.
-	if transitionedToDone && s.cfg.Git.AutoPush {
-		commitMsg := fmt.Sprintf("logos: mark task done: %s", t.Title)
-		if err := gitutil.Commit(s.projectRoot, commitMsg); err != nil {
-			fmt.Fprintf(os.Stderr, "warning: git commit failed: %v\n", err)
-		} else {
-			if err := gitutil.Push(s.projectRoot); err != nil {
-				fmt.Fprintf(os.Stderr, "warning: git push failed: %v\n", err)
-			}
-		}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		os.Remove(tmp.Name())
+		return err
 	}
-
-	return nil
+	return os.Rename(tmp.Name(), path)
 }
 
 // Delete removes the task directory (including TASK.md and WALKTHROUGH.md)
@@ -356,7 +515,7 @@ func (s *Store) Delete(planPartial, nameOrPartial string) (*Task, error) {
 		return nil, err
 	}
 
-	if s.cfg.Git.AutoPush {
+	if s.cfg.Git.Stages() {
 		_ = gitutil.Remove(s.projectRoot, t.DirPath)
 	}
 
@@ -365,13 +524,34 @@ func (s *Store) Delete(planPartial, nameOrPartial string) (*Task, error) {
 	}
 
 	_, _ = s.RebuildTaskIndex()
-	if s.cfg.Git.AutoPush {
+	if s.cfg.Git.Stages() {
 		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
 	}
 
 	return t, nil
 }
 
+// DeleteTasks removes the directories of the given tasks and rebuilds the
+// task index once. Returns the number of tasks deleted.
+func (s *Store) DeleteTasks(tasks []*Task) (int, error) {
+	n := 0
+	for _, t := range tasks {
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Remove(s.projectRoot, t.DirPath)
+		}
+		if err := os.RemoveAll(t.DirPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return n, fmt.Errorf("remove task dir %s: %w", t.DirPath, err)
+		}
+		n++
+	}
+
+	_, _ = s.RebuildTaskIndex()
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+	}
+	return n, nil
+}
+
 // IsBlocked reports whether t has any unfinished dependencies within
 // planTasks (same plan group).  A task is blocked when at least one seq
 // number listed in t.DependsOn belongs to a task whose status is not done.
@@ -445,24 +625,80 @@ func (s *Store) CreateWalkthroughScaffold(t *Task) error {
 		return fmt.Errorf("write WALKTHROUGH.md: %w", err)
 	}
 
-	if s.cfg.Git.AutoPush {
+	if s.cfg.Git.Stages() {
 		_ = gitutil.Add(s.projectRoot, path)
 	}
 
 	return nil
 }
 
+// parseDay parses a YYYY-MM-DD date at midnight in display.timezone.
+func (s *Store) parseDay(v string) (time.Time, error) {
+	loc, err := s.cfg.Display.Location()
+	if err != nil {
+		loc = time.Local
+	}
+	return time.ParseInLocation("2006-01-02", v, loc)
+}
+
 // RebuildTaskIndex discards the existing task index and reconstructs it by
 // scanning all TASK.md files. An empty index file is always created so that
 // subsequent ReadAllTaskIndex calls succeed without triggering another rebuild.
+// The new index is written to a temp file and renamed into place, so an
+// interrupted rebuild leaves the previous index intact.
+//
+// The tasks are scanned, and excerpt commands run, before the index is
+// locked; the lock is held only for the write. When another process wrote
+// the index during the scan, the scan is repeated so that its tasks are not
+// dropped, and after rebuildAttempts scans the last one runs under the lock.
 func (s *Store) RebuildTaskIndex() (int, error) {
 	path := TaskIndexFilePath(s.projectRoot)
+	var entries []TaskJSON
+	var loadErr error
+	for attempt := 1; ; attempt++ {
+		locked := attempt == rebuildAttempts
+		before, _ := os.Stat(path)
+		if !locked {
+			entries, loadErr = s.BuildTaskIndex()
+		}
+		written := false
+		err := filelock.With(path, filelock.DefaultTimeout, func() error {
+			if locked {
+				entries, loadErr = s.BuildTaskIndex()
+			} else if after, _ := os.Stat(path); !sameFileState(before, after) {
+				return nil
+			}
+			written = true
+			return jsonl.WriteAtomicWithHeader(path, TaskIndexHeader, entries)
+		})
+		if err != nil {
+			return 0, fmt.Errorf("write task index: %w", err)
+		}
+		if written {
+			return len(entries), loadErr
+		}
+	}
+}
 
-	if err := os.WriteFile(path, []byte{}, 0o644); err != nil {
-		return 0, fmt.Errorf("create task index: %w", err)
+// rebuildAttempts is how many times RebuildTaskIndex scans the tasks before
+// it gives up on scanning outside the lock.
+const rebuildAttempts = 3
+
+// sameFileState reports whether a and b, results of os.Stat on one path
+// (nil when it did not exist), describe the same unchanged file. Every
+// index write either grows the file or replaces it by rename, so a write
+// between the two calls always shows.
+func sameFileState(a, b os.FileInfo) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
 	}
+	return os.SameFile(a, b) && a.Size() == b.Size() && a.ModTime().Equal(b.ModTime())
+}
 
-	tasks, loadErr := s.loadAll()
+// BuildTaskIndex returns the entries RebuildTaskIndex would write, without
+// touching the task index file.
+func (s *Store) BuildTaskIndex() ([]TaskJSON, error) {
+	tasks, loadErr := s.loadAllWith(s.indexParseOptions())
 
 	// Group by plan to compute blocked status per plan group.
 	planGroups := make(map[string][]*Task)
@@ -470,16 +706,15 @@ func (s *Store) RebuildTaskIndex() (int, error) {
 		planGroups[t.Plan] = append(planGroups[t.Plan], t)
 	}
 
+	entries := make([]TaskJSON, 0, len(tasks))
 	for _, t := range tasks {
 		entry := FromTask(t)
 		entry.Blocked = IsBlocked(t, planGroups[t.Plan])
 		entry.CanStart = t.Status == StatusOpen && !entry.Blocked
-		if err := AppendTaskIndex(s.projectRoot, entry); err != nil {
-			return 0, fmt.Errorf("append task index entry for %s: %w", t.DirPath, err)
-		}
+		entries = append(entries, entry)
 	}
 
-	return len(tasks), loadErr
+	return entries, loadErr
 }
 
 // ---------------------------------------------------------------------------
@@ -499,6 +734,11 @@ func (s *Store) GetByName(nameOrPartial string) (*Task, error) {
 // loadAll walks .logosyncx/tasks/<plan>/<task>/TASK.md and returns every
 // successfully parsed task.  Parse errors are accumulated (non-fatal).
 func (s *Store) loadAll() ([]*Task, error) {
+	return s.loadAllWith(s.parseOptions())
+}
+
+// loadAllWith is loadAll, parsing each task with opts.
+func (s *Store) loadAllWith(opts ParseOptions) ([]*Task, error) {
 	var tasks []*Task
 	var errs []string
 
@@ -515,7 +755,7 @@ func (s *Store) loadAll() ([]*Task, error) {
 			continue
 		}
 		planGroupDir := filepath.Join(s.dir, planEntry.Name())
-		planTasks, parseErrs := s.loadPlanTasks(planGroupDir)
+		planTasks, parseErrs := s.loadPlanTasksWith(planGroupDir, opts)
 		errs = append(errs, parseErrs...)
 
 		// Compute Blocked for each task in this plan group and set it on
@@ -536,6 +776,11 @@ func (s *Store) loadAll() ([]*Task, error) {
 // loadPlanTasks loads all TASK.md files inside a single plan group directory.
 // Returns parsed tasks and a (possibly empty) slice of error strings.
 func (s *Store) loadPlanTasks(planGroupDir string) ([]*Task, []string) {
+	return s.loadPlanTasksWith(planGroupDir, s.parseOptions())
+}
+
+// loadPlanTasksWith is loadPlanTasks, parsing each task with opts.
+func (s *Store) loadPlanTasksWith(planGroupDir string, opts ParseOptions) ([]*Task, []string) {
 	var tasks []*Task
 	var errs []string
 
@@ -552,7 +797,7 @@ func (s *Store) loadPlanTasks(planGroupDir string) ([]*Task, []string) {
 			continue
 		}
 		taskPath := filepath.Join(planGroupDir, taskEntry.Name(), taskFileName)
-		t, err := s.loadFile(taskPath)
+		t, err := s.loadFileWith(taskPath, opts)
 		if err != nil {
 			errs = append(errs, fmt.Sprintf("%s: %v", taskPath, err))
 			continue
@@ -560,33 +805,94 @@ func (s *Store) loadPlanTasks(planGroupDir string) ([]*Task, []string) {
 		tasks = append(tasks, t)
 	}
 
-	// Sort by Seq so dependency resolution is deterministic.
-	sort.Slice(tasks, func(i, j int) bool {
-		return tasks[i].Seq < tasks[j].Seq
+	// Sort by Seq so dependency resolution is deterministic; duplicate
+	// sequence numbers fall back to the directory name.
+	slices.SortFunc(tasks, func(a, b *Task) int {
+		return cmp.Or(cmp.Compare(a.Seq, b.Seq), cmp.Compare(a.DirPath, b.DirPath))
 	})
 
 	return tasks, errs
 }
 
+// parseOptions returns the parse options configured under tasks, for
+// reading tasks. An excerpt command is left out: it only runs when index
+// entries are built (see indexParseOptions), so plain reads take the whole
+// excerpt section instead. The excerpt strategy and strip prefixes are
+// built once per Store, so an invalid pattern is reported once.
+func (s *Store) parseOptions() ParseOptions {
+	s.excerptOnce.Do(s.initExcerpt)
+	return ParseOptions{
+		ExcerptSection:  s.cfg.Tasks.ExcerptSection,
+		ExcerptFallback: s.cfg.Tasks.ExcerptFallback,
+		ExcerptSkipBody: s.cfg.Tasks.ExcerptSkipBody,
+		MaxRunes:        s.cfg.Tasks.ExcerptMaxRunes,
+		CJKMaxRunes:     s.cfg.Tasks.ExcerptCJKMaxRunes,
+		Strategy:        s.excerpter,
+		CleanMarkdown:   s.cfg.Tasks.ExcerptCleanMarkdown,
+		StripPrefixes:   s.prefixes,
+	}
+}
+
+// indexParseOptions is parseOptions with the configured excerpt strategy,
+// including an excerpt command, for building task index entries.
+func (s *Store) indexParseOptions() ParseOptions {
+	opts := s.parseOptions()
+	opts.Strategy = s.indexExcerpter
+	return opts
+}
+
+// initExcerpt builds the excerpt strategies and strip prefixes.
+func (s *Store) initExcerpt() {
+	prefixes, err := markdown.CompilePrefixes(s.cfg.Tasks.ExcerptStripPrefixes)
+	if err != nil {
+		Warnf("tasks.excerpt_strip_prefixes: %v", err)
+	}
+	s.prefixes = prefixes
+	e, err := markdown.NewExcerpter(s.cfg.Tasks.ExcerptStrategy, s.cfg.Tasks.ExcerptSentences, s.cfg.Tasks.ExcerptCommand)
+	if err != nil {
+		Warnf("tasks.excerpt_strategy: %v — using the whole section", err)
+		return
+	}
+	s.indexExcerpter = markdown.WithFallback(e, func(err error) {
+		Warnf("tasks.excerpt_strategy: %v — using the whole section", err)
+	})
+	if s.cfg.Tasks.ExcerptStrategy != markdown.StrategyCommand {
+		s.excerpter = s.indexExcerpter
+	}
+}
+
 // loadFile reads and parses a single TASK.md file at path.
 // DirPath is set to the directory containing the file.
 func (s *Store) loadFile(path string) (*Task, error) {
+	return s.loadFileWith(path, s.parseOptions())
+}
+
+// loadFileWith is loadFile, parsing with opts.
+func (s *Store) loadFileWith(path string, opts ParseOptions) (*Task, error) {
 	data, err := os.ReadFile(path)
 	if err != nil {
 		return nil, err
 	}
-	t, err := ParseWithOptions(taskFileName, data, ParseOptions{
-		ExcerptSection: s.cfg.Tasks.ExcerptSection,
-	})
+	t, err := ParseWithOptions(taskFileName, data, opts)
 	if err != nil {
 		return nil, err
 	}
 	t.DirPath = filepath.Dir(path)
+	if strings.TrimSpace(t.Plan) == "" {
+		// The plan group directory is the fallback for a missing plan field;
+		// a plan field naming another plan is reported by FindStrays.
+		t.Plan = filepath.Base(filepath.Dir(t.DirPath))
+		normalizePlanLinks(&t)
+	}
 	return &t, nil
 }
 
 // findTaskPaths returns the TASK.md paths that match planPartial and
-// nameOrPartial.  planPartial empty → search all plan groups.
+// nameOrPartial.  planPartial empty → search all plan groups.  Within those
+// groups nameOrPartial is resolved like a plan name (see resolve.Match): an
+// exact directory name, slug (the directory name without its seq prefix),
+// or title wins, then an exact task ID, then a substring of the directory
+// name.
 func (s *Store) findTaskPaths(planPartial, nameOrPartial string) ([]string, error) {
 	planEntries, err := os.ReadDir(s.dir)
 	if err != nil {
@@ -597,9 +903,8 @@ func (s *Store) findTaskPaths(planPartial, nameOrPartial string) ([]string, erro
 	}
 
 	lowerPlan := strings.ToLower(planPartial)
-	lowerName := strings.ToLower(nameOrPartial)
 
-	var matches []string
+	var candidates []string
 	for _, planEntry := range planEntries {
 		if !planEntry.IsDir() {
 			continue
@@ -618,26 +923,47 @@ func (s *Store) findTaskPaths(planPartial, nameOrPartial string) ([]string, erro
 			if !taskEntry.IsDir() {
 				continue
 			}
-			if lowerName != "" && !strings.Contains(strings.ToLower(taskEntry.Name()), lowerName) {
-				continue
-			}
 			candidate := filepath.Join(planGroupDir, taskEntry.Name(), taskFileName)
 			if _, err := os.Stat(candidate); err == nil {
-				matches = append(matches, candidate)
+				candidates = append(candidates, candidate)
 			}
 		}
 	}
 
-	return matches, nil
+	return resolve.Match(candidates, nameOrPartial, func(path string) resolve.Keys {
+		dirName := filepath.Base(filepath.Dir(path))
+		k := resolve.Keys{Names: []string{dirName}, Partial: []string{dirName}}
+		if _, slug, ok := strings.Cut(dirName, "-"); ok && parseSeqPrefix(dirName) > 0 {
+			k.Names = append(k.Names, slug)
+		}
+		if id, title, err := readIdentity(path); err == nil {
+			k.Names = append(k.Names, title)
+			k.ID = id
+		}
+		return k
+	}), nil
 }
 
-// generateID returns a new unique task ID of the form "t-<6 hex chars>".
-func generateID() (string, error) {
-	b := make([]byte, 3)
-	if _, err := rand.Read(b); err != nil {
-		return "", err
+// readIdentity reads the id and title from the frontmatter of the TASK.md
+// at path. Unlike loadFile it leaves the body alone, so resolving a name
+// does not run the excerpt strategy on every candidate.
+func readIdentity(path string) (id, title string, err error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return "", "", err
+	}
+	fm, _, err := markdown.SplitFrontmatter(data)
+	if err != nil {
+		return "", "", err
+	}
+	var v struct {
+		ID    string `yaml:"id"`
+		Title string `yaml:"title"`
+	}
+	if err := yaml.Unmarshal(fm, &v); err != nil {
+		return "", "", err
 	}
-	return idPrefix + hex.EncodeToString(b), nil
+	return v.ID, v.Title, nil
 }
 
 // parseSeqPrefix extracts the leading decimal number from a directory name
@@ -672,9 +998,10 @@ func walkthroughHasContent(path string) bool {
 	return false
 }
 
-// sortByDateDesc sorts tasks newest-first in-place.
+// sortByDateDesc sorts tasks newest-first in-place. Ties are broken by ID,
+// then by directory (see compareNewest).
 func sortByDateDesc(tasks []*Task) {
 	slices.SortFunc(tasks, func(a, b *Task) int {
-		return b.Date.Compare(a.Date)
+		return compareNewest(a.Date, b.Date, a.ID, b.ID, a.DirPath, b.DirPath)
 	})
 }
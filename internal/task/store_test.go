@@ -2,12 +2,16 @@ package task
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/senna-lang/logosyncx/internal/filelock"
 	"github.com/senna-lang/logosyncx/pkg/config"
 )
 
@@ -377,6 +381,53 @@ func TestStore_Get_CaseInsensitive(t *testing.T) {
 	}
 }
 
+func TestStore_ExcerptCommand_RunsOnlyForIndexEntries(t *testing.T) {
+	dir, store := setupStore(t)
+	for _, title := range []string{"Add JWT middleware", "Add login form", "Write docs"} {
+		tk := &Task{Title: title, Plan: "20260304-auth", Body: "## What\n\n" + title + ".\n"}
+		if _, err := store.Create(tk); err != nil {
+			t.Fatalf("Create %q: %v", title, err)
+		}
+	}
+
+	// The excerpt command records each run, one line per task summarized.
+	runs := filepath.Join(dir, "runs")
+	script := filepath.Join(dir, "excerpt.sh")
+	if err := os.WriteFile(script, []byte("#!/bin/sh\necho run >> "+runs+"\ncat\n"), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	count := func() int {
+		data, _ := os.ReadFile(runs)
+		return strings.Count(string(data), "run")
+	}
+	cfg := config.Default("test-project")
+	cfg.Tasks.ExcerptStrategy = "command"
+	cfg.Tasks.ExcerptCommand = script
+
+	got, err := NewStore(dir, &cfg).Get("", "login")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Title != "Add login form" {
+		t.Errorf("Title = %q, want 'Add login form'", got.Title)
+	}
+	if _, err := NewStore(dir, &cfg).List(Filter{}); err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if n := count(); n != 0 {
+		t.Errorf("excerpt command ran %d times on reads, want 0", n)
+	}
+
+	for range 2 {
+		if _, err := NewStore(dir, &cfg).RebuildTaskIndex(); err != nil {
+			t.Fatalf("RebuildTaskIndex: %v", err)
+		}
+	}
+	if n := count(); n != 3 {
+		t.Errorf("excerpt command ran %d times for two rebuilds, want 3 (once per task)", n)
+	}
+}
+
 func TestStore_GetByName_SearchesAllPlans(t *testing.T) {
 	_, store := setupStore(t)
 	createTask(t, store, "20260304-auth", "Unique task name", "open", "medium", nil)
@@ -425,7 +476,7 @@ func TestStore_List_FilterByStatus(t *testing.T) {
 	createTask(t, store, "20260304-auth", "Open task", "open", "medium", nil)
 	createTask(t, store, "20260304-auth", "Done task", "done", "medium", nil)
 
-	tasks, err := store.List(Filter{Status: StatusOpen})
+	tasks, err := store.List(Filter{Statuses: []Status{StatusOpen}})
 	if err != nil {
 		t.Fatalf("List: %v", err)
 	}
@@ -437,6 +488,15 @@ func TestStore_List_FilterByStatus(t *testing.T) {
 	}
 }
 
+func TestStore_List_UnknownStatus_ReturnsError(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "20260304-auth", "Open task", "open", "medium", nil)
+
+	if _, err := store.List(Filter{Statuses: []Status{"opne"}}); err == nil {
+		t.Error("expected an error for an unknown status, got nil")
+	}
+}
+
 func TestStore_List_FilterByPlan(t *testing.T) {
 	_, store := setupStore(t)
 	createTask(t, store, "20260304-auth", "Auth task", "open", "medium", nil)
@@ -574,6 +634,43 @@ func TestStore_UpdateFields_Done_SetsCompletedAt(t *testing.T) {
 	}
 }
 
+func TestStore_UpdateFields_TransitionTimestamps(t *testing.T) {
+	_, store := setupStore(t)
+	tk := createTask(t, store, "20260304-auth", "Tracked task", "open", "medium", nil)
+	if err := os.WriteFile(filepath.Join(tk.DirPath, walkthroughFileName), []byte("Content.\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+
+	before := time.Now()
+	if err := store.UpdateFields("", "tracked-task", map[string]string{"status": "in_progress"}); err != nil {
+		t.Fatalf("UpdateFields: %v", err)
+	}
+	got, _ := store.GetByName("tracked-task")
+	if got.StartedAt == nil || got.StartedAt.Before(before) {
+		t.Fatalf("StartedAt = %v, want a time after %v", got.StartedAt, before)
+	}
+	started := *got.StartedAt
+
+	if err := store.UpdateFields("", "tracked-task", map[string]string{"status": "done"}); err != nil {
+		t.Fatalf("UpdateFields: %v", err)
+	}
+	got, _ = store.GetByName("tracked-task")
+	if got.CompletedAt == nil || got.StartedAt == nil || !got.StartedAt.Equal(started) {
+		t.Errorf("after done: started_at %v completed_at %v", got.StartedAt, got.CompletedAt)
+	}
+	if j := got.ToJSON(); j.StartedAt == nil || j.CompletedAt == nil {
+		t.Errorf("TaskJSON = started_at %v completed_at %v, want both set", j.StartedAt, j.CompletedAt)
+	}
+
+	if err := store.UpdateFields("", "tracked-task", map[string]string{"status": "open"}); err != nil {
+		t.Fatalf("UpdateFields: %v", err)
+	}
+	got, _ = store.GetByName("tracked-task")
+	if got.CompletedAt != nil {
+		t.Errorf("CompletedAt = %v after reopening, want nil", got.CompletedAt)
+	}
+}
+
 func TestStore_UpdateFields_Done_RequiresWalkthroughContent(t *testing.T) {
 	_, store := setupStore(t)
 	createTask(t, store, "20260304-auth", "Walkthrough task", "open", "medium", nil)
@@ -661,6 +758,59 @@ func TestStore_UpdateFields_InProgress_BlockedByDep_HardError(t *testing.T) {
 	}
 }
 
+func TestStore_UpdateAll_SkipsRefusedTasks(t *testing.T) {
+	dir, store := setupStore(t)
+	createTask(t, store, "20260304-auth", "Dep task", "open", "medium", nil)
+	createTask(t, store, "20260304-auth", "Blocked task", "open", "medium", []int{1})
+	createTask(t, store, "20260305-db", "Free task", "open", "low", nil)
+
+	tasks, err := store.List(Filter{})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	updated, err := store.UpdateAll(tasks, map[string]string{"status": "in_progress", "priority": "high"})
+	if !errors.Is(err, ErrBlocked) || !strings.Contains(err.Error(), "002-blocked-task") {
+		t.Errorf("UpdateAll error = %v, want ErrBlocked naming the blocked task", err)
+	}
+	if len(updated) != 2 {
+		t.Fatalf("updated %d tasks, want 2", len(updated))
+	}
+	for _, u := range updated {
+		if u.Status != StatusInProgress || u.Priority != PriorityHigh {
+			t.Errorf("%s = %s/%s, want in_progress/high", u.Title, u.Status, u.Priority)
+		}
+	}
+
+	entries, err := ReadAllTaskIndex(dir)
+	if err != nil {
+		t.Fatalf("ReadAllTaskIndex: %v", err)
+	}
+	high := 0
+	for _, e := range entries {
+		if e.Priority == PriorityHigh {
+			high++
+		}
+	}
+	if high != 2 {
+		t.Errorf("task index lists %d high-priority tasks, want 2", high)
+	}
+}
+
+func TestStore_UpdateFields_Done_BlockedByDep(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "20260304-auth", "Dep task", "open", "medium", nil)
+	blocked := createTask(t, store, "20260304-auth", "Blocked task", "open", "medium", []int{1})
+	wtPath := filepath.Join(blocked.DirPath, walkthroughFileName)
+	if err := os.WriteFile(wtPath, []byte("# Walkthrough\n\nDone.\n"), 0o644); err != nil {
+		t.Fatalf("WriteFile walkthrough: %v", err)
+	}
+
+	err := store.UpdateFields("auth", "blocked-task", map[string]string{"status": "done"})
+	if !errors.Is(err, ErrBlocked) {
+		t.Errorf("expected ErrBlocked when marking done with an open dependency, got %v", err)
+	}
+}
+
 func TestStore_UpdateFields_InProgress_NotBlocked_WhenDepDone(t *testing.T) {
 	_, store := setupStore(t)
 	// Create dep task and mark it done (requires WALKTHROUGH.md with content).
@@ -1191,7 +1341,7 @@ func TestStore_Delete_RebuildsIndex(t *testing.T) {
 // ---------------------------------------------------------------------------
 
 func TestGenerateTaskID_HasTPrefix(t *testing.T) {
-	id, err := generateID()
+	id, err := generateID(idPrefix)
 	if err != nil {
 		t.Fatalf("generateID: %v", err)
 	}
@@ -1201,7 +1351,7 @@ func TestGenerateTaskID_HasTPrefix(t *testing.T) {
 }
 
 func TestGenerateTaskID_CorrectLength(t *testing.T) {
-	id, err := generateID()
+	id, err := generateID(idPrefix)
 	if err != nil {
 		t.Fatalf("generateID: %v", err)
 	}
@@ -1214,7 +1364,7 @@ func TestGenerateTaskID_CorrectLength(t *testing.T) {
 func TestGenerateTaskID_IsUnique(t *testing.T) {
 	seen := make(map[string]bool)
 	for i := 0; i < 20; i++ {
-		id, err := generateID()
+		id, err := generateID(idPrefix)
 		if err != nil {
 			t.Fatalf("generateID: %v", err)
 		}
@@ -1238,6 +1388,31 @@ func TestSortByDateDesc_Tasks(t *testing.T) {
 	}
 }
 
+func TestSortByDateDesc_TiesByIDThenDir(t *testing.T) {
+	same := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	tasks := []*Task{
+		{Title: "b", ID: "t-2", DirPath: "/x/001-b", Date: same},
+		{Title: "c2", ID: "t-3", DirPath: "/x/003-c", Date: same},
+		{Title: "a", ID: "t-1", DirPath: "/x/002-a", Date: same},
+		{Title: "c1", ID: "t-3", DirPath: "/x/002-c", Date: same},
+	}
+	sortByDateDesc(tasks)
+	assertTitles(t, tasks, "a", "b", "c1", "c2")
+}
+
+func TestSortJSONByDateDesc_TiesByID(t *testing.T) {
+	same := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	entries := []TaskJSON{
+		{Title: "b", ID: "t-2", Date: same},
+		{Title: "old", ID: "t-0", Date: same.Add(-time.Second)},
+		{Title: "a", ID: "t-1", Date: same},
+	}
+	SortJSONByDateDesc(entries)
+	if entries[0].Title != "a" || entries[1].Title != "b" || entries[2].Title != "old" {
+		t.Errorf("unexpected order: %v", entries)
+	}
+}
+
 func TestSortByDateDesc_SingleElement(t *testing.T) {
 	tasks := []*Task{{Title: "only", Date: time.Now()}}
 	sortByDateDesc(tasks) // should not panic
@@ -1345,10 +1520,11 @@ func TestStore_UpdateFields_Done_ErrorIncludesWalkthroughPath(t *testing.T) {
 }
 
 // ---------------------------------------------------------------------------
-// Task 1: auto git commit+push when auto_push enabled (done transition)
+// Task 1: git staging when auto_push enabled (done transition); commits
+// are made by the logos task update command, not the store
 // ---------------------------------------------------------------------------
 
-func TestStore_UpdateFields_Done_AutoPush_CommitsAndPushes(t *testing.T) {
+func TestStore_UpdateFields_Done_AutoPush_StagesBestEffort(t *testing.T) {
 	_, store := setupStore(t)
 
 	// Enable auto_push in config.
@@ -1364,8 +1540,8 @@ func TestStore_UpdateFields_Done_AutoPush_CommitsAndPushes(t *testing.T) {
 		t.Fatalf("WriteFile: %v", err)
 	}
 
-	// UpdateFields should succeed — commit+push will fail (not a real git repo)
-	// but the function must not return an error (they are non-fatal warnings).
+	// UpdateFields should succeed — git add will fail (not a real git repo)
+	// but the function must not return an error (staging is best-effort).
 	err := store.UpdateFields("", "auto-push-task", map[string]string{"status": "done"})
 	if err != nil {
 		t.Fatalf("UpdateFields with auto_push=true should succeed even if git fails: %v", err)
@@ -1479,3 +1655,114 @@ func TestStore_RebuildTaskIndex_DoneTask_CanStartFalse(t *testing.T) {
 		t.Error("done task: expected can_start=false")
 	}
 }
+
+// ---------------------------------------------------------------------------
+// Concurrency
+// ---------------------------------------------------------------------------
+
+func TestStore_UpdateFields_ConcurrentUpdates_NoLostWrites(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "plan-a", "Contended task", "open", "low", nil)
+
+	priorities := []string{"high", "medium", "low"}
+	for i := 0; i < 10; i++ {
+		wantPriority := priorities[i%len(priorities)]
+		wantAssignee := fmt.Sprintf("agent-%d", i)
+
+		var wg sync.WaitGroup
+		errs := make(chan error, 2)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			errs <- store.UpdateFields("plan-a", "contended", map[string]string{"priority": wantPriority})
+		}()
+		go func() {
+			defer wg.Done()
+			errs <- store.UpdateFields("plan-a", "contended", map[string]string{"assignee": wantAssignee})
+		}()
+		wg.Wait()
+		close(errs)
+		for err := range errs {
+			if err != nil {
+				t.Fatalf("UpdateFields: %v", err)
+			}
+		}
+
+		got, err := store.Get("plan-a", "contended")
+		if err != nil {
+			t.Fatalf("Get: %v", err)
+		}
+		if string(got.Priority) != wantPriority || got.Assignee != wantAssignee {
+			t.Fatalf("iteration %d: lost update: priority=%q assignee=%q, want %q/%q",
+				i, got.Priority, got.Assignee, wantPriority, wantAssignee)
+		}
+	}
+}
+
+func TestStore_UpdateFields_ReleasesLock(t *testing.T) {
+	_, store := setupStore(t)
+	tk := createTask(t, store, "plan-a", "Lock release", "open", "low", nil)
+
+	if err := store.UpdateFields("plan-a", "lock-release", map[string]string{"priority": "high"}); err != nil {
+		t.Fatalf("UpdateFields: %v", err)
+	}
+	lockPath := filelock.Path(filepath.Join(tk.DirPath, taskFileName))
+	if _, err := os.Stat(lockPath); !os.IsNotExist(err) {
+		t.Errorf("expected lock file to be released, stat err = %v", err)
+	}
+}
+
+func TestStore_AddRelatedPlans_AddsWithoutDuplicates(t *testing.T) {
+	_, store := setupStore(t)
+	tk := createTask(t, store, "20260101-plan", "Linked task", "open", "medium", nil)
+
+	links := map[string][]string{tk.DirPath: {"20260102-other.md"}}
+	if _, err := store.AddRelatedPlans(links); err != nil {
+		t.Fatalf("AddRelatedPlans: %v", err)
+	}
+	links[tk.DirPath] = []string{"20260102-other.md", "20260103-third.md"}
+	n, err := store.AddRelatedPlans(links)
+	if err != nil {
+		t.Fatalf("AddRelatedPlans: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("expected 1 task updated, got %d", n)
+	}
+
+	got, err := store.Get("", "linked-task")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	want := []string{"20260102-other.md", "20260103-third.md"}
+	if !slices.Equal(got.RelatedPlans, want) {
+		t.Errorf("RelatedPlans = %v, want %v", got.RelatedPlans, want)
+	}
+}
+
+func TestStore_Get_ExactSlugBeatsPartial(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "20260304-auth", "Auth", "open", "medium", nil)
+	createTask(t, store, "20260304-auth", "Auth refresh", "open", "medium", nil)
+
+	got, err := store.Get("", "auth")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Title != "Auth" {
+		t.Errorf("Get(auth) = %q, want the task whose slug is exactly auth", got.Title)
+	}
+	if _, err := store.Get("", "aut"); !errors.Is(err, ErrAmbiguous) {
+		t.Errorf("Get(aut) = %v, want ErrAmbiguous", err)
+	}
+}
+
+func TestStore_Get_ByExactID(t *testing.T) {
+	_, store := setupStore(t)
+	first := createTask(t, store, "20260304-auth", "First", "open", "medium", nil)
+	createTask(t, store, "20260304-auth", "Second", "open", "medium", nil)
+
+	got, err := store.Get("", first.ID)
+	if err != nil || got.Title != "First" {
+		t.Errorf("Get(%q) = %+v, %v; want the task with that ID", first.ID, got, err)
+	}
+}
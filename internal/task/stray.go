@@ -0,0 +1,359 @@
+// stray.go detects task files that the Store cannot list normally: TASK.md
+// files whose status is not recognised, and Markdown files sitting outside
+// the canonical <plan-slug>/NNN-<title>/TASK.md layout (for example in a
+// legacy v1 status directory such as tasks/done/).
+package task
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/filelock"
+	"github.com/senna-lang/logosyncx/internal/gitutil"
+)
+
+// StrayKind classifies why a file under tasks/ is considered stray.
+type StrayKind string
+
+const (
+	// StrayUnknownStatus is a well-placed TASK.md whose status is not one of
+	// ValidStatuses (e.g. a status that was renamed or removed).
+	StrayUnknownStatus StrayKind = "unknown_status"
+	// StrayMisplaced is a Markdown file outside <plan-slug>/NNN-<title>/.
+	StrayMisplaced StrayKind = "misplaced"
+	// StrayPlanMismatch is a well-placed TASK.md whose frontmatter plan
+	// names a different plan than the directory it sits in, e.g. after a
+	// hand edit or a directory moved by hand.
+	StrayPlanMismatch StrayKind = "plan_mismatch"
+)
+
+// Stray describes a single file that List and the task index do not cover
+// as expected.
+type Stray struct {
+	Path   string    // absolute path to the file
+	Kind   StrayKind // why the file is stray
+	Status Status    // frontmatter status, when the file could be parsed
+	Detail string    // human-readable explanation
+}
+
+// FindStrays walks .logosyncx/tasks/ and returns every stray file, sorted by
+// path. A missing tasks directory yields no strays and no error.
+func (s *Store) FindStrays() ([]Stray, error) {
+	var strays []Stray
+
+	err := filepath.WalkDir(s.dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			if errors.Is(err, os.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+		if d.IsDir() || !strings.HasSuffix(d.Name(), ".md") {
+			return nil
+		}
+
+		rel, err := filepath.Rel(s.dir, path)
+		if err != nil {
+			return err
+		}
+		parts := strings.Split(filepath.ToSlash(rel), "/")
+
+		if len(parts) == 3 {
+			switch parts[2] {
+			case walkthroughFileName:
+				return nil
+			case taskFileName:
+				t, loadErr := s.loadFile(path)
+				if loadErr != nil {
+					// Parse errors are reported by loadAll; not a layout problem.
+					return nil
+				}
+				if !IsValidStatus(t.Status) {
+					strays = append(strays, Stray{
+						Path:   path,
+						Kind:   StrayUnknownStatus,
+						Status: t.Status,
+						Detail: fmt.Sprintf("unknown status %q", t.Status),
+					})
+				}
+				if t.Plan != parts[0] {
+					strays = append(strays, Stray{
+						Path:   path,
+						Kind:   StrayPlanMismatch,
+						Status: t.Status,
+						Detail: fmt.Sprintf("frontmatter plan %q does not match directory %q", t.Plan, parts[0]),
+					})
+				}
+				return nil
+			}
+		}
+
+		stray := Stray{Path: path, Kind: StrayMisplaced, Detail: "not in <plan>/NNN-<title>/TASK.md layout"}
+		if len(parts) > 1 && IsValidStatus(Status(parts[0])) {
+			stray.Detail = fmt.Sprintf("inside legacy status directory %q", parts[0])
+		}
+		if data, readErr := os.ReadFile(path); readErr == nil {
+			if t, parseErr := Parse(d.Name(), data); parseErr == nil {
+				stray.Status = t.Status
+			}
+		}
+		strays = append(strays, stray)
+		return nil
+	})
+	if err != nil {
+		return strays, fmt.Errorf("scan tasks dir: %w", err)
+	}
+	return strays, nil
+}
+
+// LoadStray parses a misplaced task file so it can be shown alongside
+// regular tasks (logos task ls --include-unknown). DirPath is set to the
+// directory containing the file.
+func (s *Store) LoadStray(st Stray) (*Task, error) {
+	data, err := os.ReadFile(st.Path)
+	if err != nil {
+		return nil, err
+	}
+	t, err := ParseWithOptions(filepath.Base(st.Path), data, s.parseOptions())
+	if err != nil {
+		return nil, err
+	}
+	t.DirPath = filepath.Dir(st.Path)
+	return &t, nil
+}
+
+// MigrateStatus rewrites the status of every task whose frontmatter status
+// equals from, setting it to to. It is used when a status is renamed so that
+// existing task files do not become stray. to must be a valid status.
+// Returns the number of tasks rewritten, along with every task that could
+// not be read or rewritten; the task index is rebuilt when at least one
+// file changed.
+func (s *Store) MigrateStatus(from, to Status) (int, error) {
+	if !IsValidStatus(to) {
+		return 0, fmt.Errorf("invalid target status %q: must be one of open, in_progress, done", to)
+	}
+	if from == to {
+		return 0, nil
+	}
+
+	tasks, loadErr := s.loadAll()
+
+	n := 0
+	for _, t := range tasks {
+		if t.Status != from {
+			continue
+		}
+		migrated, err := s.migrateStatusFile(filepath.Join(t.DirPath, taskFileName), from, to)
+		if err != nil {
+			loadErr = errors.Join(loadErr, fmt.Errorf("%s: %w", t.DirPath, err))
+			continue
+		}
+		if migrated {
+			n++
+		}
+	}
+
+	if n > 0 {
+		_, _ = s.RebuildTaskIndex()
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+		}
+	}
+	return n, loadErr
+}
+
+// migrateStatusFile sets the status of the TASK.md at taskPath to to under
+// the task's lock, re-reading it first so that a concurrent logos task
+// update is not overwritten. It reports false when the status is no longer
+// from.
+func (s *Store) migrateStatusFile(taskPath string, from, to Status) (bool, error) {
+	lock, err := filelock.Acquire(taskPath, filelock.DefaultTimeout)
+	if err != nil {
+		return false, fmt.Errorf("lock task: %w", err)
+	}
+	defer lock.Release()
+
+	t, err := s.loadFile(taskPath)
+	if err != nil {
+		return false, err
+	}
+	if t.Status != from {
+		return false, nil
+	}
+	t.Status = to
+	data, err := Marshal(*t)
+	if err != nil {
+		return false, fmt.Errorf("marshal task: %w", err)
+	}
+	if err := writeFileAtomic(taskPath, data); err != nil {
+		return false, fmt.Errorf("write TASK.md: %w", err)
+	}
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, taskPath)
+	}
+	return true, nil
+}
+
+// FixPlanFields sets the frontmatter plan of every plan_mismatch stray to
+// the plan directory the task sits in, which is where every command looks
+// it up. Strays of other kinds are ignored. Returns the number of files
+// rewritten; the task index is rebuilt when at least one changed.
+func (s *Store) FixPlanFields(strays []Stray) (int, error) {
+	n := 0
+	var errs []error
+	for _, st := range strays {
+		if st.Kind != StrayPlanMismatch {
+			continue
+		}
+		if err := s.fixPlanField(st.Path); err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", st.Path, err))
+			continue
+		}
+		n++
+	}
+	if n > 0 {
+		_, _ = s.RebuildTaskIndex()
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+		}
+	}
+	return n, errors.Join(errs...)
+}
+
+// fixPlanField rewrites the plan field of the TASK.md at taskPath under
+// the task's lock, replacing the file atomically.
+func (s *Store) fixPlanField(taskPath string) error {
+	lock, err := filelock.Acquire(taskPath, filelock.DefaultTimeout)
+	if err != nil {
+		return fmt.Errorf("lock task: %w", err)
+	}
+	defer lock.Release()
+
+	t, err := s.loadFile(taskPath)
+	if err != nil {
+		return err
+	}
+	t.Plan = filepath.Base(filepath.Dir(t.DirPath))
+	normalizePlanLinks(t)
+	data, err := Marshal(*t)
+	if err != nil {
+		return fmt.Errorf("marshal task: %w", err)
+	}
+	if err := writeFileAtomic(taskPath, data); err != nil {
+		return err
+	}
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, taskPath)
+	}
+	return nil
+}
+
+// Relocation records the outcome of moving one misplaced task file.
+type Relocation struct {
+	From string // original absolute path
+	To   string // new TASK.md path; empty when the move failed
+	Err  error
+}
+
+// RelocateStrays moves each misplaced stray (Kind StrayMisplaced) into the
+// canonical <plan>/NNN-<title>/TASK.md layout derived from its frontmatter.
+// Frontmatter is authoritative; the only thing taken from the old location
+// is the status of a file in a legacy status directory (e.g. tasks/done/)
+// whose own status is missing. The file keeps its seq when that number is
+// free in the plan, so depends_on references stay valid; otherwise it gets
+// the next free seq. Strays of other kinds are ignored.
+//
+// Failures are reported per file in the returned Relocations; the task
+// index is rebuilt once when at least one file moved.
+func (s *Store) RelocateStrays(strays []Stray) []Relocation {
+	var out []Relocation
+	moved := 0
+	for _, st := range strays {
+		if st.Kind != StrayMisplaced {
+			continue
+		}
+		to, err := s.relocate(st)
+		out = append(out, Relocation{From: st.Path, To: to, Err: err})
+		if err == nil {
+			moved++
+		}
+	}
+
+	if moved > 0 {
+		_, _ = s.RebuildTaskIndex()
+		if s.cfg.Git.Stages() {
+			_ = gitutil.Add(s.projectRoot, TaskIndexFilePath(s.projectRoot))
+		}
+	}
+	return out
+}
+
+// relocate moves a single misplaced task file and returns its new path.
+func (s *Store) relocate(st Stray) (string, error) {
+	t, err := s.LoadStray(st)
+	if err != nil {
+		return "", fmt.Errorf("parse: %w", err)
+	}
+	if strings.TrimSpace(t.Plan) == "" || strings.TrimSpace(t.Title) == "" {
+		return "", fmt.Errorf("frontmatter has no plan or title — move it by hand")
+	}
+
+	if !IsValidStatus(t.Status) {
+		rel, _ := filepath.Rel(s.dir, st.Path)
+		dirStatus := Status(strings.Split(filepath.ToSlash(rel), "/")[0])
+		if t.Status != "" || !IsValidStatus(dirStatus) {
+			return "", fmt.Errorf("unknown status %q — fix it with logos task migrate-status after moving by hand", t.Status)
+		}
+		t.Status = dirStatus
+	}
+	if t.ID == "" {
+		if t.ID, err = s.NextID(); err != nil {
+			return "", fmt.Errorf("generate task id: %w", err)
+		}
+	}
+
+	planGroupDir := filepath.Join(s.dir, t.Plan)
+	existing, _ := s.loadPlanTasks(planGroupDir)
+	if t.Seq <= 0 || slices.ContainsFunc(existing, func(e *Task) bool { return e.Seq == t.Seq }) {
+		if t.Seq, err = s.NextSeq(planGroupDir); err != nil {
+			return "", err
+		}
+	}
+
+	taskDir := filepath.Join(planGroupDir, TaskDirName(t.Seq, t.Title))
+	if _, err := os.Stat(taskDir); err == nil {
+		return "", fmt.Errorf("destination %s already exists", taskDir)
+	}
+	if err := os.MkdirAll(taskDir, 0o755); err != nil {
+		return "", fmt.Errorf("create task dir: %w", err)
+	}
+	data, err := Marshal(*t)
+	if err != nil {
+		return "", fmt.Errorf("marshal task: %w", err)
+	}
+	taskPath := filepath.Join(taskDir, taskFileName)
+	if err := os.WriteFile(taskPath, data, 0o644); err != nil {
+		return "", fmt.Errorf("write TASK.md: %w", err)
+	}
+
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Remove(s.projectRoot, st.Path)
+	}
+	if err := os.Remove(st.Path); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return taskPath, fmt.Errorf("remove original: %w", err)
+	}
+	// Drop the old directory if the move left it empty (best-effort; a
+	// non-empty directory is left alone).
+	if dir := filepath.Dir(st.Path); dir != s.dir {
+		_ = os.Remove(dir)
+	}
+	if s.cfg.Git.Stages() {
+		_ = gitutil.Add(s.projectRoot, taskPath)
+	}
+	return taskPath, nil
+}
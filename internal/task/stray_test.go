@@ -0,0 +1,275 @@
+package task
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/senna-lang/logosyncx/pkg/config"
+)
+
+const strayTaskMD = "---\nid: t-stray1\ndate: 2026-01-01T00:00:00Z\ntitle: Stray task\nseq: 1\nstatus: %s\npriority: medium\nplan: p\ntags: []\nassignee: \"\"\n---\n\n## What\nStray.\n"
+
+func writeRaw(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		t.Fatalf("mkdir: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write %s: %v", path, err)
+	}
+}
+
+func TestFindStrays_NoTasksDir_ReturnsNothing(t *testing.T) {
+	cfg := config.Default("test-project")
+	store := NewStore(t.TempDir(), &cfg)
+	strays, err := store.FindStrays()
+	if err != nil {
+		t.Fatalf("FindStrays: %v", err)
+	}
+	if len(strays) != 0 {
+		t.Errorf("expected no strays, got %d", len(strays))
+	}
+}
+
+func TestFindStrays_WellFormedTasks_ReturnsNothing(t *testing.T) {
+	_, store := setupStore(t)
+	createTask(t, store, "plan-a", "Normal task", "open", "medium", nil)
+
+	strays, err := store.FindStrays()
+	if err != nil {
+		t.Fatalf("FindStrays: %v", err)
+	}
+	if len(strays) != 0 {
+		t.Errorf("expected no strays, got %+v", strays)
+	}
+}
+
+func TestFindStrays_UnknownStatus(t *testing.T) {
+	dir, store := setupStore(t)
+	writePlanTaskMD(t, dir, "p", "001-stray-task", fmtStray("review"))
+
+	strays, err := store.FindStrays()
+	if err != nil {
+		t.Fatalf("FindStrays: %v", err)
+	}
+	if len(strays) != 1 {
+		t.Fatalf("expected 1 stray, got %d", len(strays))
+	}
+	if strays[0].Kind != StrayUnknownStatus || strays[0].Status != "review" {
+		t.Errorf("unexpected stray: %+v", strays[0])
+	}
+}
+
+func TestFindStrays_LegacyStatusDirectory(t *testing.T) {
+	dir, store := setupStore(t)
+	writeRaw(t, filepath.Join(dir, ".logosyncx", "tasks", "done", "2026-01-01_old.md"), fmtStray("done"))
+
+	strays, err := store.FindStrays()
+	if err != nil {
+		t.Fatalf("FindStrays: %v", err)
+	}
+	if len(strays) != 1 {
+		t.Fatalf("expected 1 stray, got %d", len(strays))
+	}
+	if strays[0].Kind != StrayMisplaced {
+		t.Errorf("Kind = %q, want misplaced", strays[0].Kind)
+	}
+	if strays[0].Status != StatusDone {
+		t.Errorf("Status = %q, want done", strays[0].Status)
+	}
+}
+
+func TestFindStrays_IgnoresWalkthrough(t *testing.T) {
+	dir, store := setupStore(t)
+	createTask(t, store, "plan-a", "With walkthrough", "open", "medium", nil)
+	writeRaw(t, filepath.Join(dir, ".logosyncx", "tasks", "plan-a", "001-with-walkthrough", walkthroughFileName), "# Walkthrough\n")
+
+	strays, err := store.FindStrays()
+	if err != nil {
+		t.Fatalf("FindStrays: %v", err)
+	}
+	if len(strays) != 0 {
+		t.Errorf("expected no strays, got %+v", strays)
+	}
+}
+
+func TestLoadStray_SetsDirPath(t *testing.T) {
+	dir, store := setupStore(t)
+	path := filepath.Join(dir, ".logosyncx", "tasks", "done", "old.md")
+	writeRaw(t, path, fmtStray("done"))
+
+	got, err := store.LoadStray(Stray{Path: path, Kind: StrayMisplaced})
+	if err != nil {
+		t.Fatalf("LoadStray: %v", err)
+	}
+	if got.Title != "Stray task" {
+		t.Errorf("Title = %q", got.Title)
+	}
+	if got.DirPath != filepath.Dir(path) {
+		t.Errorf("DirPath = %q, want %q", got.DirPath, filepath.Dir(path))
+	}
+}
+
+func TestMigrateStatus_RewritesMatchingTasks(t *testing.T) {
+	dir, store := setupStore(t)
+	writePlanTaskMD(t, dir, "plan-a", "001-stray-task", fmtStray("review"))
+	createTask(t, store, "plan-a", "Untouched", "open", "medium", nil)
+
+	n, err := store.MigrateStatus("review", StatusInProgress)
+	if err != nil {
+		t.Fatalf("MigrateStatus: %v", err)
+	}
+	if n != 1 {
+		t.Errorf("migrated %d tasks, want 1", n)
+	}
+	got, err := store.Get("plan-a", "001-stray")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != StatusInProgress {
+		t.Errorf("Status = %q, want in_progress", got.Status)
+	}
+	other, _ := store.Get("plan-a", "untouched")
+	if other.Status != StatusOpen {
+		t.Errorf("untouched task status changed to %q", other.Status)
+	}
+}
+
+func TestMigrateStatus_InvalidTarget_ReturnsError(t *testing.T) {
+	_, store := setupStore(t)
+	if _, err := store.MigrateStatus("review", "archived"); err == nil {
+		t.Error("expected error for invalid target status")
+	}
+}
+
+func fmtStray(status string) string {
+	return fmt.Sprintf(strayTaskMD, status)
+}
+
+func TestRelocateStrays_MovesLegacyFileIntoLayout(t *testing.T) {
+	dir, store := setupStore(t)
+	legacy := filepath.Join(dir, ".logosyncx", "tasks", "done", "2026-01-01_old.md")
+	writeRaw(t, legacy, fmtStray("done"))
+
+	strays, _ := store.FindStrays()
+	rels := store.RelocateStrays(strays)
+	if len(rels) != 1 || rels[0].Err != nil {
+		t.Fatalf("RelocateStrays = %+v", rels)
+	}
+	want := filepath.Join(dir, ".logosyncx", "tasks", "p", "001-stray-task", taskFileName)
+	if rels[0].To != want {
+		t.Errorf("To = %s, want %s", rels[0].To, want)
+	}
+	if _, err := os.Stat(legacy); !os.IsNotExist(err) {
+		t.Errorf("legacy file should be gone, stat err = %v", err)
+	}
+	if _, err := os.Stat(filepath.Dir(legacy)); !os.IsNotExist(err) {
+		t.Errorf("empty legacy directory should be removed, stat err = %v", err)
+	}
+
+	got, err := store.Get("p", "stray-task")
+	if err != nil {
+		t.Fatalf("Get after relocate: %v", err)
+	}
+	if got.ID != "t-stray1" || got.Status != StatusDone {
+		t.Errorf("relocated task = id %q status %q, want t-stray1/done", got.ID, got.Status)
+	}
+	if strays, _ := store.FindStrays(); len(strays) != 0 {
+		t.Errorf("expected no strays after relocation, got %+v", strays)
+	}
+}
+
+func TestRelocateStrays_StatusFromLegacyDirWhenMissing(t *testing.T) {
+	dir, store := setupStore(t)
+	writeRaw(t, filepath.Join(dir, ".logosyncx", "tasks", "in_progress", "x.md"), fmtStray(""))
+
+	strays, _ := store.FindStrays()
+	rels := store.RelocateStrays(strays)
+	if len(rels) != 1 || rels[0].Err != nil {
+		t.Fatalf("RelocateStrays = %+v", rels)
+	}
+	got, err := store.Get("p", "stray-task")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Status != StatusInProgress {
+		t.Errorf("Status = %q, want in_progress", got.Status)
+	}
+}
+
+func TestRelocateStrays_TakenSeqGetsNext(t *testing.T) {
+	dir, store := setupStore(t)
+	createTask(t, store, "p", "Existing", "open", "medium", nil)
+	writeRaw(t, filepath.Join(dir, ".logosyncx", "tasks", "open", "x.md"), fmtStray("open"))
+
+	strays, _ := store.FindStrays()
+	rels := store.RelocateStrays(strays)
+	if len(rels) != 1 || rels[0].Err != nil {
+		t.Fatalf("RelocateStrays = %+v", rels)
+	}
+	if filepath.Base(filepath.Dir(rels[0].To)) != "002-stray-task" {
+		t.Errorf("expected seq 2, got %s", rels[0].To)
+	}
+}
+
+func TestRelocateStrays_NoPlanIsReported(t *testing.T) {
+	dir, store := setupStore(t)
+	path := filepath.Join(dir, ".logosyncx", "tasks", "done", "x.md")
+	writeRaw(t, path, "---\ntitle: Orphan\nstatus: done\n---\n")
+
+	strays, _ := store.FindStrays()
+	rels := store.RelocateStrays(strays)
+	if len(rels) != 1 || rels[0].Err == nil {
+		t.Fatalf("expected an error for a file without plan, got %+v", rels)
+	}
+	if _, err := os.Stat(path); err != nil {
+		t.Errorf("file must be left in place: %v", err)
+	}
+}
+
+func TestFindStrays_PlanMismatch(t *testing.T) {
+	dir, store := setupStore(t)
+	path := writePlanTaskMD(t, dir, "plan-a", "001-stray-task", fmtStray("open"))
+
+	strays, err := store.FindStrays()
+	if err != nil {
+		t.Fatalf("FindStrays: %v", err)
+	}
+	if len(strays) != 1 || strays[0].Kind != StrayPlanMismatch || strays[0].Path != path {
+		t.Fatalf("expected one plan_mismatch stray, got %+v", strays)
+	}
+
+	n, err := store.FixPlanFields(strays)
+	if err != nil || n != 1 {
+		t.Fatalf("FixPlanFields = %d, %v", n, err)
+	}
+	got, err := store.Get("plan-a", "001-stray")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Plan != "plan-a" {
+		t.Errorf("Plan = %q, want plan-a", got.Plan)
+	}
+	if strays, _ := store.FindStrays(); len(strays) != 0 {
+		t.Errorf("expected no strays after fix, got %+v", strays)
+	}
+}
+
+func TestLoad_MissingPlanFallsBackToDirectory(t *testing.T) {
+	dir, store := setupStore(t)
+	writePlanTaskMD(t, dir, "plan-a", "001-no-plan", strings.Replace(fmtStray("open"), "plan: p\n", "", 1))
+
+	got, err := store.Get("plan-a", "001-no-plan")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	if got.Plan != "plan-a" {
+		t.Errorf("Plan = %q, want plan-a", got.Plan)
+	}
+	if strays, _ := store.FindStrays(); len(strays) != 0 {
+		t.Errorf("a missing plan field is not a mismatch, got %+v", strays)
+	}
+}
@@ -6,6 +6,7 @@ package task
 import (
 	"bytes"
 	"fmt"
+	"regexp"
 	"slices"
 	"strings"
 	"time"
@@ -43,41 +44,70 @@ var ValidPriorities = []Priority{PriorityHigh, PriorityMedium, PriorityLow}
 // Task represents a single task file stored under .logosyncx/tasks/<plan-slug>/.
 type Task struct {
 	// Frontmatter fields (serialised to/from YAML).
-	ID          string     `yaml:"id"`
-	Date        time.Time  `yaml:"date"`
-	Title       string     `yaml:"title"`
-	Seq         int        `yaml:"seq"`
-	Status      Status     `yaml:"status"`
-	Priority    Priority   `yaml:"priority"`
-	Plan        string     `yaml:"plan"`
-	DependsOn   []int      `yaml:"depends_on,omitempty"`
-	Tags        []string   `yaml:"tags"`
-	Assignee    string     `yaml:"assignee"`
+	ID        string    `yaml:"id"`
+	Date      time.Time `yaml:"date"`
+	Title     string    `yaml:"title"`
+	Seq       int       `yaml:"seq"`
+	Status    Status    `yaml:"status"`
+	Priority  Priority  `yaml:"priority"`
+	Plan      string    `yaml:"plan"`
+	DependsOn []int     `yaml:"depends_on,omitempty"`
+	Tags      []string  `yaml:"tags"`
+	Assignee  string    `yaml:"assignee"`
+	// StartedAt is when the task last moved to in_progress; CompletedAt is
+	// when it moved to done, and is cleared when it is reopened. Both are
+	// set by UpdateFields. Date records when the task was created.
+	StartedAt   *time.Time `yaml:"started_at,omitempty"`
 	CompletedAt *time.Time `yaml:"completed_at,omitempty"`
+	// Order is the manual backlog rank set by logos task move (1 = first).
+	// Zero means unranked.
+	Order int `yaml:"order,omitempty"`
+	// SnoozedUntil hides the task from default task ls output until this
+	// time (set by logos task snooze).
+	SnoozedUntil *time.Time `yaml:"snoozed_until,omitempty"`
+	// Due is the date the task should be finished by (set by logos task
+	// update --due). tasks.escalation raises the priority of tasks whose
+	// due date is near.
+	Due *time.Time `yaml:"due,omitempty"`
+	// RelatedPlans lists plan filenames (other than Plan) linked to this
+	// task, e.g. by logos sync --auto-link when either body mentions the other.
+	RelatedPlans []string `yaml:"related_plans,omitempty"`
+	// Branch is the git branch the task was created on, when
+	// git.record_branch is set and it was not a trunk branch.
+	Branch string `yaml:"branch,omitempty"`
 
 	// Derived fields — not written to frontmatter.
 	DirPath string `yaml:"-"` // absolute path to the task's directory (set by store)
 	Blocked bool   `yaml:"-"` // true when at least one depends_on seq is not yet done
 	Excerpt string `yaml:"-"` // first excerptMaxRunes runes of the excerpt section
 	Body    string `yaml:"-"` // full markdown body (everything after frontmatter)
+	// Conflicts lists the file's line numbers holding git conflict markers
+	// (see markdown.ConflictMarkers). A conflicted task gets no excerpt.
+	Conflicts []int `yaml:"-"`
 }
 
 // TaskJSON is the shape used for --json output and the task-index.jsonl.
 // It includes all frontmatter fields plus the derived DirPath, Blocked, CanStart, and Excerpt.
 type TaskJSON struct {
-	ID          string     `json:"id"`
-	DirPath     string     `json:"dir_path"`
-	Date        time.Time  `json:"date"`
-	Title       string     `json:"title"`
-	Seq         int        `json:"seq"`
-	Status      Status     `json:"status"`
-	Priority    Priority   `json:"priority"`
-	Plan        string     `json:"plan"`
-	DependsOn   []int      `json:"depends_on"`
-	Tags        []string   `json:"tags"`
-	Assignee    string     `json:"assignee"`
-	CompletedAt *time.Time `json:"completed_at,omitempty"`
-	Blocked     bool       `json:"blocked"`
+	ID           string     `json:"id"`
+	DirPath      string     `json:"dir_path"`
+	Date         time.Time  `json:"date"`
+	Title        string     `json:"title"`
+	Seq          int        `json:"seq"`
+	Status       Status     `json:"status"`
+	Priority     Priority   `json:"priority"`
+	Plan         string     `json:"plan"`
+	DependsOn    []int      `json:"depends_on"`
+	Tags         []string   `json:"tags"`
+	Assignee     string     `json:"assignee"`
+	StartedAt    *time.Time `json:"started_at,omitempty"`
+	CompletedAt  *time.Time `json:"completed_at,omitempty"`
+	Order        int        `json:"order"`
+	SnoozedUntil *time.Time `json:"snoozed_until,omitempty"`
+	Due          *time.Time `json:"due,omitempty"`
+	RelatedPlans []string   `json:"related_plans,omitempty"`
+	Branch       string     `json:"branch,omitempty"`
+	Blocked      bool       `json:"blocked"`
 	// CanStart is true when the task is open and not blocked by unfinished dependencies.
 	// Agents can use this to identify immediately actionable tasks without reasoning
 	// about the dependency graph themselves.
@@ -89,24 +119,35 @@ type TaskJSON struct {
 // Nil slice fields are normalised to empty slices.
 func (t *Task) ToJSON() TaskJSON {
 	return TaskJSON{
-		ID:          t.ID,
-		DirPath:     t.DirPath,
-		Date:        t.Date,
-		Title:       t.Title,
-		Seq:         t.Seq,
-		Status:      t.Status,
-		Priority:    t.Priority,
-		Plan:        t.Plan,
-		DependsOn:   normalizeInts(t.DependsOn),
-		Tags:        normalizeStrings(t.Tags),
-		Assignee:    t.Assignee,
-		CompletedAt: t.CompletedAt,
-		Blocked:     false, // store sets this during loadAll
-		CanStart:    false, // store sets this during loadAll (open && !blocked)
-		Excerpt:     t.Excerpt,
+		ID:           t.ID,
+		DirPath:      t.DirPath,
+		Date:         t.Date,
+		Title:        t.Title,
+		Seq:          t.Seq,
+		Status:       t.Status,
+		Priority:     t.Priority,
+		Plan:         t.Plan,
+		DependsOn:    normalizeInts(t.DependsOn),
+		Tags:         normalizeStrings(t.Tags),
+		Assignee:     t.Assignee,
+		StartedAt:    t.StartedAt,
+		CompletedAt:  t.CompletedAt,
+		Order:        t.Order,
+		SnoozedUntil: t.SnoozedUntil,
+		Due:          t.Due,
+		RelatedPlans: t.RelatedPlans,
+		Branch:       t.Branch,
+		Blocked:      false, // store sets this during loadAll
+		CanStart:     false, // store sets this during loadAll (open && !blocked)
+		Excerpt:      t.Excerpt,
 	}
 }
 
+// IsSnoozed reports whether the task is snoozed at now.
+func (e TaskJSON) IsSnoozed(now time.Time) bool {
+	return e.SnoozedUntil != nil && now.Before(*e.SnoozedUntil)
+}
+
 // FromTask converts a *Task to TaskJSON (package-level function form of ToJSON).
 // Nil slices are normalised to empty slices. Blocked and CanStart are always false here;
 // the store sets them during loadAll after evaluating depends_on.
@@ -136,6 +177,26 @@ type ParseOptions struct {
 	// Defaults to "What" when empty. Matched case-insensitively at any
 	// heading level (h1–h6).
 	ExcerptSection string
+	// ExcerptFallback lists sections tried in order when ExcerptSection is
+	// missing or empty.
+	ExcerptFallback []string
+	// ExcerptSkipBody leaves the excerpt empty instead of falling back to
+	// the start of the body.
+	ExcerptSkipBody bool
+	// MaxRunes limits the excerpt length. 0 uses markdown.ExcerptMaxRunes.
+	MaxRunes int
+	// CJKMaxRunes, when > 0, replaces MaxRunes for excerpts detected as
+	// Chinese, Japanese, or Korean.
+	CJKMaxRunes int
+	// Strategy condenses the excerpt section before truncation (see
+	// markdown.NewExcerpter). nil keeps the whole section.
+	Strategy markdown.Excerpter
+	// CleanMarkdown removes links, images, and inline code markers from the
+	// excerpt (see markdown.CleanInline).
+	CleanMarkdown bool
+	// StripPrefixes are cut from the start of the excerpt (see
+	// markdown.CompilePrefixes).
+	StripPrefixes []*regexp.Regexp
 }
 
 // Parse reads a task markdown file from data.
@@ -149,26 +210,61 @@ func Parse(filename string, data []byte) (Task, error) {
 // extraction. Use this when the project's tasks.excerpt_section differs from
 // the default "What".
 func ParseWithOptions(filename string, data []byte, opts ParseOptions) (Task, error) {
+	conflicts := markdown.ConflictMarkers(string(data))
 	fm, body, err := markdown.SplitFrontmatter(data)
 	if err != nil {
-		return Task{}, fmt.Errorf("parse %s: %w", filename, err)
+		return Task{}, fmt.Errorf("parse %s: %w%s", filename, err, markdown.ConflictHint(conflicts))
 	}
 
 	var t Task
 	if err := yaml.Unmarshal(fm, &t); err != nil {
-		return Task{}, fmt.Errorf("parse frontmatter in %s: %w", filename, err)
+		return Task{}, fmt.Errorf("parse frontmatter in %s: %w%s", filename, err, markdown.ConflictHint(conflicts))
 	}
 
+	normalizePlanLinks(&t)
+
 	t.Body = string(body)
+	if len(conflicts) > 0 {
+		t.Conflicts = conflicts
+		return t, nil
+	}
 	section := opts.ExcerptSection
 	if section == "" {
 		section = "What"
 	}
-	t.Excerpt = markdown.ExtractExcerpt(body, section)
+	t.Excerpt = markdown.ExtractExcerptWithOptions(body, markdown.ExcerptOptions{
+		Section:       section,
+		Fallback:      opts.ExcerptFallback,
+		SkipBody:      opts.ExcerptSkipBody,
+		MaxRunes:      opts.MaxRunes,
+		CJKMaxRunes:   opts.CJKMaxRunes,
+		Strategy:      opts.Strategy,
+		Clean:         opts.CleanMarkdown,
+		StripPrefixes: opts.StripPrefixes,
+	})
 
 	return t, nil
 }
 
+// normalizePlanLinks keeps related_plans consistent with plan: duplicate
+// entries and entries naming the task's own plan (with or without ".md")
+// are dropped. Because the cleaned list is what Marshal writes, the next
+// write of the file repairs it.
+func normalizePlanLinks(t *Task) {
+	if len(t.RelatedPlans) == 0 {
+		return
+	}
+	var out []string
+	for _, name := range t.RelatedPlans {
+		name = strings.TrimSpace(name)
+		if name == "" || (t.Plan != "" && strings.TrimSuffix(name, ".md") == t.Plan) || slices.Contains(out, name) {
+			continue
+		}
+		out = append(out, name)
+	}
+	t.RelatedPlans = out
+}
+
 // Marshal serialises a Task back to its markdown representation
 // (YAML frontmatter + body).
 func Marshal(t Task) ([]byte, error) {
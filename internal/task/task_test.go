@@ -1,11 +1,13 @@
 package task
 
 import (
+	"slices"
 	"strings"
 	"testing"
 	"time"
 
 	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/pkg/config"
 )
 
 // --- helpers -----------------------------------------------------------------
@@ -79,6 +81,19 @@ func TestParse_ExtractsExcerpt(t *testing.T) {
 	}
 }
 
+func TestParseWithOptions_CleansExcerpt(t *testing.T) {
+	content := taskMarkdown("t-1", "title", "open", "medium", "", 0, nil,
+		"## What\nThis task adds `--limit` to [ls](cmd/ls.go).\n")
+	prefixes, _ := markdown.CompilePrefixes([]string{`This task`})
+	got, err := ParseWithOptions("TASK.md", []byte(content), ParseOptions{CleanMarkdown: true, StripPrefixes: prefixes})
+	if err != nil {
+		t.Fatalf("ParseWithOptions: %v", err)
+	}
+	if got.Excerpt != "adds --limit to ls." {
+		t.Errorf("Excerpt = %q, want the cleaned text without the prefix", got.Excerpt)
+	}
+}
+
 func TestParse_ParsesTags(t *testing.T) {
 	content := taskMarkdown("t-1", "title", "open", "medium", "", 0, []string{"auth", "jwt"},
 		"## What\nbody\n")
@@ -105,6 +120,17 @@ func TestParse_ParsesPlan(t *testing.T) {
 	}
 }
 
+func TestParse_NormalizesRelatedPlans(t *testing.T) {
+	raw := "---\nid: t-1\ntitle: test\nstatus: open\npriority: medium\nplan: 20260304-auth\nrelated_plans:\n  - 20260304-auth.md\n  - 20260301-db.md\n  - 20260301-db.md\n  - 20260304-auth\ntags: []\nassignee: \n---\n\n## What\nbody\n"
+	got, err := Parse("TASK.md", []byte(raw))
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if len(got.RelatedPlans) != 1 || got.RelatedPlans[0] != "20260301-db.md" {
+		t.Errorf("RelatedPlans = %v, want [20260301-db.md]", got.RelatedPlans)
+	}
+}
+
 func TestParse_ParsesDependsOn(t *testing.T) {
 	raw := "---\nid: t-1\ntitle: test\nstatus: open\npriority: medium\nplan: myplan\ndepends_on:\n  - 1\n  - 2\ntags: []\nassignee: \n---\n\n## What\nbody\n"
 	got, err := Parse("TASK.md", []byte(raw))
@@ -395,6 +421,22 @@ func TestIsValidPriority_UnknownValue(t *testing.T) {
 	}
 }
 
+func TestValidValues_MatchConfig(t *testing.T) {
+	var statuses, priorities []string
+	for _, s := range ValidStatuses {
+		statuses = append(statuses, string(s))
+	}
+	for _, p := range ValidPriorities {
+		priorities = append(priorities, string(p))
+	}
+	if !slices.Equal(statuses, config.TaskStatuses) {
+		t.Errorf("ValidStatuses = %v, config.TaskStatuses = %v", statuses, config.TaskStatuses)
+	}
+	if !slices.Equal(priorities, config.TaskPriorities) {
+		t.Errorf("ValidPriorities = %v, config.TaskPriorities = %v", priorities, config.TaskPriorities)
+	}
+}
+
 // --- ToJSON ------------------------------------------------------------------
 
 func TestToJSON_NilTagsBecomesEmpty(t *testing.T) {
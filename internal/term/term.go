@@ -0,0 +1,18 @@
+//go:build linux || darwin || dragonfly || freebsd || netbsd || openbsd
+
+// Package term reports whether files are attached to a terminal and holds
+// the termios ioctl requests of the platforms logos supports.
+package term
+
+import (
+	"os"
+
+	"golang.org/x/sys/unix"
+)
+
+// IsTerminal reports whether f is attached to a terminal. Unlike a check
+// for a character device, it is false for /dev/null.
+func IsTerminal(f *os.File) bool {
+	_, err := unix.IoctlGetTermios(int(f.Fd()), ReadTermios)
+	return err == nil
+}
@@ -0,0 +1,11 @@
+//go:build darwin || dragonfly || freebsd || netbsd || openbsd
+
+package term
+
+import "golang.org/x/sys/unix"
+
+// The ioctl requests reading and writing the termios of a terminal.
+const (
+	ReadTermios  = unix.TIOCGETA
+	WriteTermios = unix.TIOCSETA
+)
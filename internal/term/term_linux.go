@@ -0,0 +1,11 @@
+//go:build linux
+
+package term
+
+import "golang.org/x/sys/unix"
+
+// The ioctl requests reading and writing the termios of a terminal.
+const (
+	ReadTermios  = unix.TCGETS
+	WriteTermios = unix.TCSETS
+)
@@ -0,0 +1,17 @@
+//go:build !(linux || darwin || dragonfly || freebsd || netbsd || openbsd)
+
+// Package term reports whether files are attached to a terminal and holds
+// the termios ioctl requests of the platforms logos supports.
+package term
+
+import "os"
+
+// IsTerminal reports whether f is attached to a character device, the
+// closest check available without termios.
+func IsTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	if err != nil {
+		return false
+	}
+	return info.Mode()&os.ModeCharDevice != 0
+}
@@ -0,0 +1,17 @@
+package term
+
+import (
+	"os"
+	"testing"
+)
+
+func TestIsTerminal_DevNull(t *testing.T) {
+	f, err := os.Open(os.DevNull)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if IsTerminal(f) {
+		t.Errorf("IsTerminal(%s) = true, want false", os.DevNull)
+	}
+}
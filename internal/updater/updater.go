@@ -17,6 +17,7 @@ import (
 	"os"
 	"path/filepath"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
@@ -86,14 +87,89 @@ func CheckWithCache(ctx context.Context, currentVersion string) (string, error)
 	return "", nil
 }
 
+// Cached returns the latest version recorded by the last update check,
+// without any network call, and whether it is newer than currentVersion.
+// ok is false when no check has been cached yet.
+func Cached(currentVersion string) (latest string, newer, ok bool) {
+	cacheFile, err := cacheFilePath()
+	if err != nil {
+		return "", false, false
+	}
+	entry, err := readCache(cacheFile)
+	if err != nil || entry.LatestVersion == "" {
+		return "", false, false
+	}
+	return entry.LatestVersion, semverGreater(entry.LatestVersion, currentVersion), true
+}
+
+// Release is a published GitHub release of logos.
+type Release struct {
+	Tag         string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"` // release notes (Markdown)
+	PublishedAt time.Time `json:"published_at"`
+	Draft       bool      `json:"draft"`
+	Prerelease  bool      `json:"prerelease"`
+}
+
 // FetchLatestVersion queries the GitHub Releases API and returns the tag name of
 // the latest release (e.g. "v0.3.0").
 func FetchLatestVersion(ctx context.Context) (string, error) {
+	var release Release
 	url := fmt.Sprintf("%s/repos/%s/releases/latest", apiBaseURL, githubRepo)
+	if err := getJSON(ctx, url, &release); err != nil {
+		return "", err
+	}
+	if release.Tag == "" {
+		return "", fmt.Errorf("empty tag_name in github response")
+	}
+	return release.Tag, nil
+}
+
+// FetchReleases queries the GitHub Releases API and returns the most recent
+// published releases (up to 100), newest first. Drafts and pre-releases are
+// omitted.
+func FetchReleases(ctx context.Context) ([]Release, error) {
+	var all []Release
+	url := fmt.Sprintf("%s/repos/%s/releases?per_page=100", apiBaseURL, githubRepo)
+	if err := getJSON(ctx, url, &all); err != nil {
+		return nil, err
+	}
+	releases := make([]Release, 0, len(all))
+	for _, r := range all {
+		if !r.Draft && !r.Prerelease && r.Tag != "" {
+			releases = append(releases, r)
+		}
+	}
+	sort.SliceStable(releases, func(i, j int) bool {
+		return semverGreater(releases[i].Tag, releases[j].Tag)
+	})
+	return releases, nil
+}
 
+// ReleasesBetween returns the releases newer than from and not newer than
+// to, keeping the input order. An empty from or to leaves that end of the
+// range open.
+func ReleasesBetween(releases []Release, from, to string) []Release {
+	var out []Release
+	for _, r := range releases {
+		if from != "" && !semverGreater(r.Tag, from) {
+			continue
+		}
+		if to != "" && semverGreater(r.Tag, to) {
+			continue
+		}
+		out = append(out, r)
+	}
+	return out
+}
+
+// getJSON performs a GitHub API GET request and decodes the JSON response
+// into v.
+func getJSON(ctx context.Context, url string, v any) error {
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
 	if err != nil {
-		return "", fmt.Errorf("build request: %w", err)
+		return fmt.Errorf("build request: %w", err)
 	}
 	req.Header.Set("Accept", "application/vnd.github+json")
 	req.Header.Set("User-Agent", userAgent)
@@ -101,24 +177,17 @@ func FetchLatestVersion(ctx context.Context) (string, error) {
 	client := &http.Client{Timeout: httpTimeout}
 	resp, err := client.Do(req)
 	if err != nil {
-		return "", fmt.Errorf("github API request: %w", err)
+		return fmt.Errorf("github API request: %w", err)
 	}
 	defer resp.Body.Close()
 
 	if resp.StatusCode != http.StatusOK {
-		return "", fmt.Errorf("github API returned status %d", resp.StatusCode)
-	}
-
-	var release struct {
-		TagName string `json:"tag_name"`
+		return fmt.Errorf("github API returned status %d", resp.StatusCode)
 	}
-	if err := json.NewDecoder(resp.Body).Decode(&release); err != nil {
-		return "", fmt.Errorf("decode github response: %w", err)
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return fmt.Errorf("decode github response: %w", err)
 	}
-	if release.TagName == "" {
-		return "", fmt.Errorf("empty tag_name in github response")
-	}
-	return release.TagName, nil
+	return nil
 }
 
 // Apply downloads targetVersion from GitHub Releases, verifies its SHA256 checksum,
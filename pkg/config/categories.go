@@ -0,0 +1,55 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/suggest"
+)
+
+// DefaultCategories are the plan categories accepted when
+// plans.categories is not set.
+var DefaultCategories = []string{"design", "incident", "research", "meeting"}
+
+// DefaultCategorySections are the body headings logos save scaffolds for a
+// plan of a built-in category, unless plans.category_sections overrides them.
+var DefaultCategorySections = map[string][]string{
+	"design":   {"Background", "Spec", "Alternatives"},
+	"incident": {"Summary", "Timeline", "Impact", "Root Cause", "Resolution", "Follow-ups"},
+	"research": {"Question", "Findings", "Conclusion"},
+	"meeting":  {"Attendees", "Notes", "Decisions", "Action Items"},
+}
+
+// AllowedCategories returns plans.categories, or DefaultCategories when it
+// is empty.
+func (c PlansConfig) AllowedCategories() []string {
+	if len(c.Categories) == 0 {
+		return DefaultCategories
+	}
+	return c.Categories
+}
+
+// CategorySectionsFor returns the headings to scaffold for a new plan of
+// category: plans.category_sections[category] when set, otherwise the
+// built-in default (nil for a custom category without one).
+func (c PlansConfig) CategorySectionsFor(category string) []string {
+	if sections, ok := c.CategorySections[category]; ok {
+		return sections
+	}
+	return DefaultCategorySections[category]
+}
+
+// CheckCategory returns an error when category is not allowed, with a "did
+// you mean" suggestion when an allowed category is a likely typo fix. An
+// empty category is always accepted.
+func (c PlansConfig) CheckCategory(category string) error {
+	allowed := c.AllowedCategories()
+	if category == "" || slices.Contains(allowed, category) {
+		return nil
+	}
+	if s := suggest.Closest(category, allowed); s != "" {
+		return fmt.Errorf("category %q is not in plans.categories (did you mean %q?)", category, s)
+	}
+	return fmt.Errorf("category %q is not in plans.categories: allowed categories are %s", category, strings.Join(allowed, ", "))
+}
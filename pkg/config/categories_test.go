@@ -0,0 +1,51 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestAllowedCategories_DefaultsWhenUnset(t *testing.T) {
+	if got := (PlansConfig{}).AllowedCategories(); !slices.Equal(got, DefaultCategories) {
+		t.Errorf("got %v, want %v", got, DefaultCategories)
+	}
+	c := PlansConfig{Categories: []string{"rfc"}}
+	if got := c.AllowedCategories(); !slices.Equal(got, []string{"rfc"}) {
+		t.Errorf("got %v, want [rfc]", got)
+	}
+}
+
+func TestCategorySectionsFor_ConfigOverridesDefault(t *testing.T) {
+	c := PlansConfig{CategorySections: map[string][]string{"meeting": {"Notes"}}}
+	if got := c.CategorySectionsFor("meeting"); !slices.Equal(got, []string{"Notes"}) {
+		t.Errorf("got %v, want [Notes]", got)
+	}
+	if got := c.CategorySectionsFor("design"); !slices.Equal(got, DefaultCategorySections["design"]) {
+		t.Errorf("got %v, want built-in design sections", got)
+	}
+	if got := c.CategorySectionsFor(""); got != nil {
+		t.Errorf("expected no sections without a category, got %v", got)
+	}
+}
+
+func TestCheckCategory(t *testing.T) {
+	c := PlansConfig{}
+	if err := c.CheckCategory(""); err != nil {
+		t.Errorf("empty category: %v", err)
+	}
+	if err := c.CheckCategory("research"); err != nil {
+		t.Errorf("research: %v", err)
+	}
+	if err := c.CheckCategory("zzz"); err == nil || !strings.Contains(err.Error(), "design, incident") {
+		t.Errorf("expected allowed list in error, got %v", err)
+	}
+}
+
+func TestValidateValues_CategorySectionsForUnknownCategory(t *testing.T) {
+	cfg := Default("p")
+	cfg.Plans.CategorySections = map[string][]string{"postmortem": {"Summary"}}
+	if problems := ValidateValues(cfg); len(problems) != 1 || !strings.Contains(problems[0], "plans.category_sections") {
+		t.Errorf("expected plans.category_sections problem, got %v", problems)
+	}
+}
@@ -9,6 +9,9 @@ import (
 	"errors"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
+	"time"
 )
 
 const (
@@ -16,13 +19,70 @@ const (
 	ConfigFileName = "config.json"
 )
 
+// ExcerptConfig holds the settings that build the excerpt of a plan or
+// task stored in its index. PlansConfig and TasksConfig embed it, so its
+// keys sit directly under "plans" and "tasks" in config.json.
+type ExcerptConfig struct {
+	// ExcerptSection is the section whose content is used as the excerpt.
+	ExcerptSection string `json:"excerpt_section"`
+	// ExcerptFallback lists sections tried in order when ExcerptSection is
+	// missing or empty, e.g. ["Summary", "Notes"].
+	ExcerptFallback []string `json:"excerpt_fallback,omitempty"`
+	// ExcerptSkipBody leaves the excerpt empty when no excerpt section has
+	// content, instead of using the start of the body.
+	ExcerptSkipBody bool `json:"excerpt_skip_body,omitempty"`
+	// ExcerptMaxRunes limits the length of the excerpt in runes.
+	// 0 uses the built-in default (300).
+	ExcerptMaxRunes int `json:"excerpt_max_runes,omitempty"`
+	// ExcerptCJKMaxRunes, when > 0, replaces ExcerptMaxRunes for excerpts
+	// detected as Chinese, Japanese, or Korean.
+	ExcerptCJKMaxRunes int `json:"excerpt_cjk_max_runes,omitempty"`
+	// ExcerptStrategy condenses the excerpt section before it is truncated:
+	// "section" (default, the whole section), "paragraph" (its first
+	// paragraph), "sentences" (its first ExcerptSentences sentences), or
+	// "command" (the output of ExcerptCommand).
+	ExcerptStrategy string `json:"excerpt_strategy,omitempty"`
+	// ExcerptSentences is the sentence count for the "sentences" strategy.
+	// 0 uses the built-in default (2).
+	ExcerptSentences int `json:"excerpt_sentences,omitempty"`
+	// ExcerptCommand is the summarizer run by the "command" strategy, split
+	// on white space (no shell). It reads the section on stdin and writes
+	// the excerpt to stdout; on failure the whole section is used. It runs
+	// only when index entries are built, and not again after a failure. It is
+	// read from config.local.json only (see LocalConfig), so a cloned
+	// repository cannot make logos run a command.
+	ExcerptCommand string `json:"-"`
+	// ExcerptStripPrefixes are regular expressions for boilerplate cut from
+	// the start of the excerpt, e.g. "This (plan|session) (covers|is about):?".
+	ExcerptStripPrefixes []string `json:"excerpt_strip_prefixes,omitempty"`
+	// ExcerptCleanMarkdown removes links (keeping their text), images, and
+	// inline code markers from the excerpt.
+	ExcerptCleanMarkdown bool `json:"excerpt_clean_markdown,omitempty"`
+}
+
 // PlansConfig holds settings related to plan files.
 type PlansConfig struct {
 	// SummarySections lists the section headings returned by logos refer --summary.
 	SummarySections []string `json:"summary_sections"`
-	// ExcerptSection is the section whose content is used as the plan excerpt
-	// stored in the index.
-	ExcerptSection string `json:"excerpt_section"`
+	ExcerptConfig
+	// AllowedTags, when non-empty, is the vocabulary --tag values on
+	// logos save must come from.
+	AllowedTags []string `json:"allowed_tags,omitempty"`
+	// DefaultTags are added to every new plan.
+	DefaultTags []string `json:"default_tags,omitempty"`
+	// FilenamePattern names new plan files, with placeholders {{date}}
+	// (YYYYMMDD), {{id}}, and {{slug}}. Default "{{date}}-{{slug}}.md".
+	// Existing files keep their names; lookups do not depend on the pattern.
+	FilenamePattern string `json:"filename_pattern,omitempty"`
+	// RequiredSections lists headings logos check expects every plan to
+	// fill in; a missing section or one holding only template comments fails.
+	RequiredSections []string `json:"required_sections,omitempty"`
+	// Categories lists the values accepted by logos save --category.
+	// Empty uses DefaultCategories.
+	Categories []string `json:"categories,omitempty"`
+	// CategorySections maps a category to the body headings logos save
+	// scaffolds for it, overriding DefaultCategorySections.
+	CategorySections map[string][]string `json:"category_sections,omitempty"`
 }
 
 // TasksConfig holds settings related to task management.
@@ -30,9 +90,74 @@ type TasksConfig struct {
 	DefaultStatus   string   `json:"default_status"`
 	DefaultPriority string   `json:"default_priority"`
 	SummarySections []string `json:"summary_sections"`
-	// ExcerptSection is the section whose content is used as the task excerpt
-	// stored in the task index.
-	ExcerptSection string `json:"excerpt_section"`
+	ExcerptConfig
+	// AllowedTags, when non-empty, is the vocabulary --tag values on
+	// logos task create must come from.
+	AllowedTags []string `json:"allowed_tags,omitempty"`
+	// DefaultTags are added to every new task.
+	DefaultTags []string `json:"default_tags,omitempty"`
+	// Roster lists the teammates logos task suggest-assignee chooses from.
+	// Optional; without it the command only prints the per-assignee load.
+	Roster []string `json:"roster,omitempty"`
+	// IDPrefix is prepended to generated task IDs (e.g. "API-"). Default "t-".
+	IDPrefix string `json:"id_prefix,omitempty"`
+	// IDMode selects how task IDs are generated: "random" (default, 6 hex
+	// chars) or "sequential" (1, 2, 3… from .logosyncx/task-id-counter).
+	IDMode string `json:"id_mode,omitempty"`
+	// Rules set fields on new tasks based on their tags (see TaskRule).
+	Rules []TaskRule `json:"rules,omitempty"`
+	// Retention maps a task status to the age ("60d", "8w") after which
+	// logos gc removes tasks in that status, e.g. {"done": "60d"}. Age is
+	// measured from completion for done tasks and from creation otherwise.
+	Retention map[string]string `json:"retention,omitempty"`
+	// RetentionAction is what logos gc does with tasks past their retention
+	// period: "archive" (default, move to .logosyncx/tasks-archive/) or
+	// "delete".
+	RetentionAction string `json:"retention_action,omitempty"`
+	// Escalation raises the priority of unfinished tasks as their due date
+	// approaches. Nil (the default) disables it.
+	Escalation *EscalationConfig `json:"escalation,omitempty"`
+	// RequiredSections lists headings logos check expects every task to
+	// fill in; a missing section or one holding only template comments fails.
+	RequiredSections []string `json:"required_sections,omitempty"`
+	// ActionItemsSection is the plan section logos task create --from-plan
+	// turns into tasks, one per open bullet. Default "Action Items".
+	ActionItemsSection string `json:"action_items_section,omitempty"`
+}
+
+// DefaultActionItemsSection is tasks.action_items_section when unset.
+const DefaultActionItemsSection = "Action Items"
+
+// ActionItems returns the section logos task create --from-plan reads.
+func (c TasksConfig) ActionItems() string {
+	if c.ActionItemsSection == "" {
+		return DefaultActionItemsSection
+	}
+	return c.ActionItemsSection
+}
+
+// TaskRule routes new tasks: when a task created by logos task create
+// carries Tag (and, if set, belongs to a plan whose slug contains Plan),
+// Assignee and Priority are applied unless the task already sets them.
+// Rules are evaluated in order; the first rule to set a field wins.
+type TaskRule struct {
+	Tag      string `json:"tag"`
+	Plan     string `json:"plan,omitempty"`
+	Assignee string `json:"assignee,omitempty"`
+	Priority string `json:"priority,omitempty"`
+}
+
+// EscalationConfig is applied by logos sync and logos watch: every
+// unfinished task due within DueWithin (or overdue) whose priority is lower
+// than SetPriority is raised to it.
+type EscalationConfig struct {
+	// DueWithin is how close the due date must be, e.g. "3d" or "1w".
+	DueWithin string `json:"due_within"`
+	// SetPriority is the priority escalated tasks get. Default "high".
+	SetPriority string `json:"set_priority,omitempty"`
+	// Notify prints a warning for every escalated task, naming its due date,
+	// instead of only a count.
+	Notify bool `json:"notify,omitempty"`
 }
 
 // KnowledgeConfig holds settings related to knowledge files.
@@ -59,27 +184,401 @@ type GcConfig struct {
 
 // GitConfig holds settings related to git automation behaviour.
 type GitConfig struct {
-	// AutoPush, when true, makes logos save automatically run git commit and
-	// git push after staging the plan file. Defaults to false.
+	// Auto is how far logos carries its own writes into git: "off", "add"
+	// (stage), "commit" (stage, then commit after logos save, task create,
+	// task update, and incident), or "push" (commit, then push). Unset
+	// keeps the behaviour from before this setting existed: logos save and
+	// distill stage their writes (see StagesByDefault) and nothing else
+	// touches git, unless AutoPush is set.
+	Auto string `json:"auto,omitempty"`
+	// AutoPush is the older switch for git automation. When Auto is unset,
+	// true stages every write like "add" and also commits and pushes when a
+	// task is marked done.
 	AutoPush bool `json:"auto_push"`
+	// CommitMessages overrides the commit message templates, keyed by
+	// "save", "task_create", "task_update", and "task_done" (see
+	// DefaultCommitMessages for the placeholders each may use).
+	CommitMessages map[string]string `json:"commit_messages,omitempty"`
+	// RecordBranch, when true, records the branch checked out when a plan
+	// or task is created in its "branch" frontmatter, so logos ls and task
+	// ls --branch can scope context to a branch. Plans and tasks created on
+	// a trunk branch are left unscoped.
+	RecordBranch bool `json:"record_branch,omitempty"`
+	// TrunkBranches lists the branches whose plans and tasks are not
+	// scoped to a branch. Default ["main", "master"].
+	TrunkBranches []string `json:"trunk_branches,omitempty"`
+	// Worktrees says which .logosyncx/ commands run in a linked git
+	// worktree use: "auto" (the default) uses the worktree's own when it
+	// has one and the main worktree's otherwise, "main" always uses the
+	// main worktree's, and "local" never looks outside the worktree. It is
+	// read from the config that would otherwise be used (see
+	// project.FindRoot).
+	Worktrees string `json:"worktrees,omitempty"`
+	// Override, when set, replaces Auto and AutoPush for this run; it is
+	// never saved. Load takes it from $LOGOS_GIT, and the CLI replaces that
+	// with the --git flag when given, so the flag wins over the variable
+	// and both win over the saved settings.
+	Override string `json:"-"`
+}
+
+// DefaultTrunkBranches is git.trunk_branches when unset.
+var DefaultTrunkBranches = []string{"main", "master"}
+
+// ScopeBranch returns the branch a plan or task created on branch is
+// scoped to: branch itself, or "" when RecordBranch is off or branch is a
+// trunk branch.
+func (g GitConfig) ScopeBranch(branch string) string {
+	if !g.RecordBranch {
+		return ""
+	}
+	trunk := g.TrunkBranches
+	if len(trunk) == 0 {
+		trunk = DefaultTrunkBranches
+	}
+	if slices.Contains(trunk, branch) {
+		return ""
+	}
+	return branch
+}
+
+// Values of git.worktrees.
+const (
+	WorktreesAuto  = "auto"
+	WorktreesMain  = "main"
+	WorktreesLocal = "local"
+)
+
+// Git automation levels for git.auto.
+const (
+	GitOff    = "off"
+	GitAdd    = "add"
+	GitCommit = "commit"
+	GitPush   = "push"
+)
+
+// GitLevels lists the valid git.auto values, from least to most automated.
+var GitLevels = []string{GitOff, GitAdd, GitCommit, GitPush}
+
+// GitEnv names the environment variable that overrides git.auto for one
+// run, as the --git flag does.
+const GitEnv = "LOGOS_GIT"
+
+// Level returns the effective automation level: Override, then Auto, then
+// GitAdd when AutoPush is set. It is "" when none is set.
+func (g GitConfig) Level() string {
+	switch {
+	case g.Override != "":
+		return g.Override
+	case g.Auto != "":
+		return g.Auto
+	case g.AutoPush:
+		return GitAdd
+	}
+	return ""
+}
+
+// legacyPush reports whether only the older auto_push switch is in effect.
+// It stages every write, but commits and pushes only when a task is marked
+// done, as it did before git.auto existed.
+func (g GitConfig) legacyPush() bool {
+	return g.Override == "" && g.Auto == "" && g.AutoPush
+}
+
+// Stages reports whether logos stages the files it writes.
+func (g GitConfig) Stages() bool {
+	l := g.Level()
+	return l == GitAdd || l == GitCommit || l == GitPush
+}
+
+// StagesByDefault is Stages with an unset level treated as GitAdd: only an
+// explicit GitOff stops staging. It is the rule for the commands that
+// staged their writes before git.auto existed (logos save and distill).
+func (g GitConfig) StagesByDefault() bool {
+	return g.Level() == "" || g.Stages()
+}
+
+// Commits reports whether logos commits after a write of the given kind
+// (CommitSave, CommitTaskCreate, CommitTaskUpdate, or CommitTaskDone).
+func (g GitConfig) Commits(kind string) bool {
+	if g.legacyPush() {
+		return kind == CommitTaskDone
+	}
+	l := g.Level()
+	return l == GitCommit || l == GitPush
+}
+
+// Pushes reports whether logos pushes after committing a write of the given
+// kind.
+func (g GitConfig) Pushes(kind string) bool {
+	if g.legacyPush() {
+		return kind == CommitTaskDone
+	}
+	return g.Level() == GitPush
+}
+
+// Commit message kinds, the keys of git.commit_messages.
+const (
+	CommitSave       = "save"
+	CommitTaskCreate = "task_create"
+	CommitTaskUpdate = "task_update"
+	CommitTaskDone   = "task_done"
+)
+
+// DefaultCommitMessages are the commit message templates used when
+// git.commit_messages does not set one. Placeholders: {{topic}} and
+// {{filename}} for save; {{title}} and {{plan}} for the task kinds, plus
+// {{from}} and {{to}} (the status before and after) for task_update and
+// task_done. task_done is used when an update moves a task to done.
+var DefaultCommitMessages = map[string]string{
+	CommitSave:       "logos: save plan: {{topic}}",
+	CommitTaskCreate: "logos: create task: {{title}}",
+	CommitTaskUpdate: "logos: update task: {{title}}",
+	CommitTaskDone:   "logos: mark task done: {{title}}",
+}
+
+// commitPlaceholders lists the placeholders each commit message kind may
+// use.
+var commitPlaceholders = map[string][]string{
+	CommitSave:       {"topic", "filename"},
+	CommitTaskCreate: {"title", "plan"},
+	CommitTaskUpdate: {"title", "plan", "from", "to"},
+	CommitTaskDone:   {"title", "plan", "from", "to"},
+}
+
+// CommitMessage renders the commit message template for kind, replacing
+// each {{name}} with vars[name].
+func (g GitConfig) CommitMessage(kind string, vars map[string]string) string {
+	tmpl := g.CommitMessages[kind]
+	if tmpl == "" {
+		tmpl = DefaultCommitMessages[kind]
+	}
+	pairs := make([]string, 0, 2*len(vars))
+	for k, v := range vars {
+		pairs = append(pairs, "{{"+k+"}}", v)
+	}
+	return strings.NewReplacer(pairs...).Replace(tmpl)
+}
+
+// AttachmentsConfig holds the policy for non-Markdown files (images, logs,
+// binaries) stored under .logosyncx/, normally in .logosyncx/attachments/.
+type AttachmentsConfig struct {
+	// MaxSizeKB is the size above which logos status, check, and doctor
+	// warn about a file under .logosyncx/ unless it is stored with Git LFS.
+	// 0 uses the built-in default (1024).
+	MaxSizeKB int `json:"max_size_kb,omitempty"`
+	// LFS, when true, expects .logosyncx/attachments/** to be tracked by
+	// Git LFS; logos doctor --fix-lfs adds the .gitattributes rule.
+	LFS bool `json:"lfs,omitempty"`
+}
+
+// DefaultAttachmentMaxSizeKB is the attachment size limit used when
+// attachments.max_size_kb is unset.
+const DefaultAttachmentMaxSizeKB = 1024
+
+// MaxBytes returns the attachment size limit in bytes.
+func (c AttachmentsConfig) MaxBytes() int64 {
+	kb := c.MaxSizeKB
+	if kb == 0 {
+		kb = DefaultAttachmentMaxSizeKB
+	}
+	return int64(kb) * 1024
+}
+
+// LimitsConfig holds the size guardrails beyond which logos sync, save,
+// and doctor warn and suggest trimming the project, before listing and
+// searching slow down. For each field 0 uses the built-in default and a
+// negative value turns the check off.
+type LimitsConfig struct {
+	// MaxPlans is the number of active (unarchived) plans. Default 500.
+	MaxPlans int `json:"max_plans,omitempty"`
+	// MaxIndexKB is the size of index.jsonl or task-index.jsonl.
+	// Default 2048.
+	MaxIndexKB int `json:"max_index_kb,omitempty"`
+	// MaxFileKB is the size of a single plan, task, or knowledge file.
+	// Default 200.
+	MaxFileKB int `json:"max_file_kb,omitempty"`
+}
+
+// Built-in guardrail thresholds used when the limits fields are unset.
+const (
+	DefaultMaxPlans   = 500
+	DefaultMaxIndexKB = 2048
+	DefaultMaxFileKB  = 200
+)
+
+// limit returns v, def when v is 0, or 0 (no limit) when v is negative.
+func limit(v, def int) int {
+	switch {
+	case v < 0:
+		return 0
+	case v == 0:
+		return def
+	}
+	return v
+}
+
+// PlanLimit returns the active plan count limit, or 0 when disabled.
+func (c LimitsConfig) PlanLimit() int {
+	return limit(c.MaxPlans, DefaultMaxPlans)
+}
+
+// IndexLimitBytes returns the index file size limit, or 0 when disabled.
+func (c LimitsConfig) IndexLimitBytes() int64 {
+	return int64(limit(c.MaxIndexKB, DefaultMaxIndexKB)) * 1024
+}
+
+// FileLimitBytes returns the single-file size limit, or 0 when disabled.
+func (c LimitsConfig) FileLimitBytes() int64 {
+	return int64(limit(c.MaxFileKB, DefaultMaxFileKB)) * 1024
 }
 
 // PrivacyConfig holds settings related to privacy filtering.
 type PrivacyConfig struct {
+	// FilterPatterns are regular expressions for text that must not be
+	// committed. They are reported as "filter_patterns[i]".
 	FilterPatterns []string `json:"filter_patterns"`
+	// Patterns are named regular expressions; the name is used in reports
+	// and in the [REDACTED:<name>] placeholder.
+	Patterns []NamedPattern `json:"patterns,omitempty"`
+	// BuiltinDetectors adds detectors for common API key formats (AWS,
+	// GitHub, Slack, Anthropic, OpenAI, Stripe, Google, private keys).
+	BuiltinDetectors bool `json:"builtin_detectors,omitempty"`
+	// Mode is what logos save and logos task create do when a pattern
+	// matches what they are about to write: "warn" (default) prints a
+	// warning, "redact" replaces the match with [REDACTED:<name>], and
+	// "block" refuses to write the file.
+	Mode string `json:"mode,omitempty"`
+}
+
+// NamedPattern is a privacy pattern with a name.
+type NamedPattern struct {
+	Name    string `json:"name"`
+	Pattern string `json:"pattern"`
+}
+
+// Privacy modes (privacy.mode).
+const (
+	PrivacyWarn   = "warn"
+	PrivacyRedact = "redact"
+	PrivacyBlock  = "block"
+)
+
+// EffectiveMode returns Mode, or PrivacyWarn when it is unset.
+func (c PrivacyConfig) EffectiveMode() string {
+	if c.Mode == "" {
+		return PrivacyWarn
+	}
+	return c.Mode
+}
+
+// DisplayConfig holds settings for human-readable output.
+type DisplayConfig struct {
+	// Timezone is the IANA time zone (e.g. "Asia/Tokyo" or "UTC") in which
+	// tables render dates and date-only values such as --since 2026-01-02
+	// are interpreted. Empty uses the local time zone. Stored dates keep
+	// their own offset and compare as instants regardless of this setting.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// Location returns the time zone named by Timezone, or time.Local when it
+// is empty.
+func (c DisplayConfig) Location() (*time.Location, error) {
+	if c.Timezone == "" {
+		return time.Local, nil
+	}
+	return time.LoadLocation(c.Timezone)
+}
+
+// OutputConfig holds per-command output defaults, so that a team or agent
+// gets the same output without repeating flags. A flag given on the command
+// line always overrides its default here.
+type OutputConfig struct {
+	// LS is the default output of logos ls: "table" (default), "wide", or
+	// "json".
+	LS string `json:"ls,omitempty"`
+	// TaskLS is the default output of logos task ls: "table" (default) or
+	// "json".
+	TaskLS string `json:"task_ls,omitempty"`
+	// Full, when true, stops ls and task ls tables from truncating columns
+	// to the terminal width, like --full.
+	Full bool `json:"full,omitempty"`
+	// ShowAgent, when true, adds the AGENT column to logos ls, like
+	// --show-agent.
+	ShowAgent bool `json:"show_agent,omitempty"`
+}
+
+// PromptsConfig holds settings for interactive confirmation prompts.
+type PromptsConfig struct {
+	// Default is the answer an empty reply (just Enter) selects at a
+	// confirmation prompt: "no" (default) or "yes". It never answers for a
+	// non-interactive session; use --yes there.
+	Default string `json:"default,omitempty"`
+}
+
+// DefaultYes reports whether an empty reply confirms a prompt.
+func (c PromptsConfig) DefaultYes() bool {
+	return c.Default == "yes"
+}
+
+// WatchConfig holds settings for logos watch.
+type WatchConfig struct {
+	// Jobs are logos commands logos watch runs on a cron schedule, such as
+	// a nightly sync --check.
+	Jobs []WatchJob `json:"jobs,omitempty"`
+}
+
+// WatchJob is one scheduled job of logos watch.
+type WatchJob struct {
+	// Name identifies the job in watch output.
+	Name string `json:"name"`
+	// Schedule is a five-field cron expression ("0 3 * * *") or one of
+	// @hourly, @daily, @weekly, @monthly, evaluated in display.timezone.
+	Schedule string `json:"schedule"`
+	// Command is the logos command line to run, without "logos", e.g.
+	// "gc --dry-run". It is split on white space.
+	Command string `json:"command"`
+	// Output, when set, is a file (relative to the project root) that
+	// receives the command's output after each run, replacing the previous
+	// report. Otherwise the output is printed by logos watch.
+	Output string `json:"output,omitempty"`
 }
 
 // Config represents the contents of .logosyncx/config.json.
 type Config struct {
-	Version    string          `json:"version"`
-	Project    string          `json:"project"`
-	AgentsFile string          `json:"agents_file"`
-	Plans      PlansConfig     `json:"plans"`
-	Tasks      TasksConfig     `json:"tasks"`
-	Knowledge  KnowledgeConfig `json:"knowledge"`
-	Privacy    PrivacyConfig   `json:"privacy"`
-	Git        GitConfig       `json:"git"`
-	GC         GcConfig        `json:"gc"`
+	Version     string            `json:"version"`
+	Project     string            `json:"project"`
+	AgentsFile  string            `json:"agents_file"`
+	Plans       PlansConfig       `json:"plans"`
+	Tasks       TasksConfig       `json:"tasks"`
+	Knowledge   KnowledgeConfig   `json:"knowledge"`
+	Privacy     PrivacyConfig     `json:"privacy"`
+	Attachments AttachmentsConfig `json:"attachments"`
+	Prompts     PromptsConfig     `json:"prompts"`
+	Display     DisplayConfig     `json:"display"`
+	Output      OutputConfig      `json:"output"`
+	Git         GitConfig         `json:"git"`
+	GC          GcConfig          `json:"gc"`
+	Limits      LimitsConfig      `json:"limits"`
+	Watch       WatchConfig       `json:"watch"`
+	// Storage, when set, points to another directory holding the
+	// project's .logosyncx/ (relative to the project root, or absolute).
+	// A config with Storage set is only a pointer: every command reads
+	// and writes the storage directory instead (see project.ResolveStorage).
+	Storage string `json:"storage,omitempty"`
+	// Overlays lists directories (relative to the project root, or
+	// absolute) holding a shared .logosyncx/, such as org-wide conventions.
+	// logos ls, refer, and search also read their plans, read-only; plans
+	// in the project root take precedence over an overlay plan of the same
+	// filename. Nothing is ever written to an overlay.
+	Overlays []string `json:"overlays,omitempty"`
+	// ContextFile, when set, is where logos sync regenerates the agent
+	// context file (see logos agents render-context), relative to the
+	// project root, e.g. ".claude/context.md".
+	ContextFile string `json:"context_file,omitempty"`
+	// Templates maps a name to the body sections (and tags) logos save and
+	// logos task create scaffold with --template <name>, adding to or
+	// overriding DefaultTemplates.
+	Templates map[string]TemplateConfig `json:"templates,omitempty"`
 }
 
 // Default returns a Config populated with sensible default values.
@@ -90,13 +589,13 @@ func Default(projectName string) Config {
 		AgentsFile: "AGENTS.md",
 		Plans: PlansConfig{
 			SummarySections: []string{"Background", "Spec"},
-			ExcerptSection:  "Background",
+			ExcerptConfig:   ExcerptConfig{ExcerptSection: "Background"},
 		},
 		Tasks: TasksConfig{
 			DefaultStatus:   "open",
 			DefaultPriority: "medium",
 			SummarySections: []string{"What", "Checklist"},
-			ExcerptSection:  "What",
+			ExcerptConfig:   ExcerptConfig{ExcerptSection: "What"},
 		},
 		Knowledge: KnowledgeConfig{
 			SummarySections: []string{"Summary", "Key Learnings"},
@@ -119,14 +618,20 @@ func ConfigPath(projectRoot string) string {
 
 // Load reads and parses config.json from the given project root.
 // If the file does not exist, it returns a default Config and no error.
-// Missing fields are filled with defaults after parsing.
+// Missing fields are filled with defaults after parsing, and the excerpt
+// commands are read from config.local.json.
 func Load(projectRoot string) (Config, error) {
 	path := ConfigPath(projectRoot)
 
 	data, err := os.ReadFile(path)
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
-			return Default(filepath.Base(projectRoot)), nil
+			cfg := Default(filepath.Base(projectRoot))
+			cfg.Git.Override = os.Getenv(GitEnv)
+			if err := applyLocal(&cfg, projectRoot); err != nil {
+				return Config{}, err
+			}
+			return cfg, nil
 		}
 		return Config{}, err
 	}
@@ -137,6 +642,10 @@ func Load(projectRoot string) (Config, error) {
 	}
 
 	applyDefaults(&cfg, projectRoot)
+	cfg.Git.Override = os.Getenv(GitEnv)
+	if err := applyLocal(&cfg, projectRoot); err != nil {
+		return Config{}, err
+	}
 	return cfg, nil
 }
 
@@ -157,6 +666,22 @@ func Save(projectRoot string, cfg Config) error {
 	return os.WriteFile(ConfigPath(projectRoot), data, 0o644)
 }
 
+// SetProject changes the "project" name in config.json under projectRoot.
+// The other settings are written back as they were read, without the
+// defaults Load fills in.
+func SetProject(projectRoot, name string) error {
+	data, err := os.ReadFile(ConfigPath(projectRoot))
+	if err != nil {
+		return err
+	}
+	var cfg Config
+	if err := json.Unmarshal(data, &cfg); err != nil {
+		return err
+	}
+	cfg.Project = name
+	return Save(projectRoot, cfg)
+}
+
 // applyDefaults fills in zero-value fields with sensible defaults.
 func applyDefaults(cfg *Config, projectRoot string) {
 	if cfg.Version == "" {
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 )
 
 func TestDefault(t *testing.T) {
@@ -142,6 +145,37 @@ func TestLoad_AppliesDefaults(t *testing.T) {
 	}
 }
 
+func TestSetProject_KeepsOtherSettingsWithoutDefaults(t *testing.T) {
+	dir := t.TempDir()
+	cfgDir := filepath.Join(dir, DirName)
+	if err := os.MkdirAll(cfgDir, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	raw := `{"project": "old-name", "tasks": {"default_priority": "high"}}`
+	if err := os.WriteFile(filepath.Join(cfgDir, ConfigFileName), []byte(raw), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := SetProject(dir, "new-name"); err != nil {
+		t.Fatalf("SetProject: %v", err)
+	}
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Project != "new-name" || cfg.Tasks.DefaultPriority != "high" {
+		t.Errorf("after SetProject: project %q default_priority %q", cfg.Project, cfg.Tasks.DefaultPriority)
+	}
+	data, _ := os.ReadFile(filepath.Join(cfgDir, ConfigFileName))
+	if strings.Contains(string(data), "AGENTS.md") {
+		t.Errorf("SetProject wrote Load's defaults into config.json:\n%s", data)
+	}
+
+	if err := SetProject(t.TempDir(), "x"); err == nil {
+		t.Error("SetProject without a config.json should fail")
+	}
+}
+
 func TestSave_CreatesFile(t *testing.T) {
 	dir := t.TempDir()
 	cfg := Default("save-test")
@@ -294,3 +328,269 @@ func TestSave_CreatesDirectoryIfMissing(t *testing.T) {
 		t.Fatal("expected config file to be created in nested directory")
 	}
 }
+
+func TestValidate(t *testing.T) {
+	dir := t.TempDir()
+	if problems, err := Validate(dir); err != nil || len(problems) != 1 {
+		t.Fatalf("missing config: got %v, %v", problems, err)
+	}
+
+	cfg := Default("p")
+	if err := Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if problems, err := Validate(dir); err != nil || len(problems) != 0 {
+		t.Fatalf("default config: got %v, %v", problems, err)
+	}
+
+	cfg.Tasks.IDMode = "uuid"
+	cfg.Tasks.AllowedTags = []string{"backend"}
+	cfg.Tasks.DefaultTags = []string{"frontend"}
+	cfg.Privacy.FilterPatterns = []string{"("}
+	if err := Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := Validate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 3 {
+		t.Errorf("expected 3 problems, got %v", problems)
+	}
+
+	if err := os.WriteFile(ConfigPath(dir), []byte(`{"version": "2", "tasks": {"defualt_status": "open"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	problems, _ = Validate(dir)
+	if len(problems) != 1 || !strings.Contains(problems[0], "defualt_status") {
+		t.Errorf("expected unknown-key problem, got %v", problems)
+	}
+
+	if err := os.WriteFile(ConfigPath(dir), []byte(`{"version": "2", "tasks": {"excerpt_strategy": "command", "excerpt_command": "summarize"}}`), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	problems, _ = Validate(dir)
+	if len(problems) != 1 || !strings.Contains(problems[0], "tasks.excerpt_command: move it to "+LocalFileName) {
+		t.Errorf("expected excerpt_command to be sent to %s, got %v", LocalFileName, problems)
+	}
+}
+
+func TestValidateValues_HeadingMarkers(t *testing.T) {
+	cfg := Default("p")
+	cfg.Plans.ExcerptSection = "## Background"
+	cfg.Tasks.RequiredSections = []string{"What", "### Scope"}
+	problems := ValidateValues(cfg)
+	if len(problems) != 2 {
+		t.Fatalf("got %v", problems)
+	}
+	if !strings.Contains(problems[0], "plans.excerpt_section") || !strings.Contains(problems[0], "level-2 heading") {
+		t.Errorf("problems[0] = %q", problems[0])
+	}
+	if !strings.Contains(problems[1], "tasks.required_sections[1]") || !strings.Contains(problems[1], "level-3 heading") {
+		t.Errorf("problems[1] = %q", problems[1])
+	}
+}
+
+func TestValidate_ExcerptSectionMustBeASection(t *testing.T) {
+	dir := t.TempDir()
+	templates := filepath.Join(dir, DirName, "templates")
+	if err := os.MkdirAll(templates, 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(templates, "plan.md"), []byte("## Background\n\n## Outcome\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	cfg := Default("p")
+	cfg.Plans.ExcerptSection = "outcome"
+	cfg.Plans.ExcerptFallback = []string{"Spec", "Summry"}
+	// No templates/task.md: tasks are not checked.
+	cfg.Tasks.ExcerptSection = "Anything"
+	if err := Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	problems, err := Validate(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(problems) != 1 || !strings.Contains(problems[0], `plans.excerpt_fallback[1]: "Summry"`) {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestDisplayLocation(t *testing.T) {
+	if loc, err := (DisplayConfig{}).Location(); err != nil || loc != time.Local {
+		t.Errorf("empty timezone = %v, %v; want Local", loc, err)
+	}
+	if loc, err := (DisplayConfig{Timezone: "UTC"}).Location(); err != nil || loc.String() != "UTC" {
+		t.Errorf("UTC timezone = %v, %v", loc, err)
+	}
+	cfg := Default("p")
+	cfg.Display.Timezone = "Mars/Olympus"
+	if problems := ValidateValues(cfg); len(problems) != 1 || !strings.Contains(problems[0], "display.timezone") {
+		t.Errorf("expected display.timezone problem, got %v", problems)
+	}
+}
+
+func TestValidateValues_Output(t *testing.T) {
+	cfg := Default("p")
+	cfg.Output = OutputConfig{LS: "wide", TaskLS: "json"}
+	if problems := ValidateValues(cfg); len(problems) != 0 {
+		t.Fatalf("valid output config: got %v", problems)
+	}
+	cfg.Output = OutputConfig{LS: "yaml", TaskLS: "wide"}
+	problems := ValidateValues(cfg)
+	if len(problems) != 2 || !strings.Contains(problems[0], "output.ls") || !strings.Contains(problems[1], "output.task_ls") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateValues_ExcerptStrategy(t *testing.T) {
+	cfg := Default("p")
+	cfg.Plans.ExcerptStrategy = "sentences"
+	cfg.Tasks.ExcerptStrategy = "command"
+	cfg.Tasks.ExcerptCommand = "summarize --short"
+	if problems := ValidateValues(cfg); len(problems) != 0 {
+		t.Fatalf("valid excerpt strategies: got %v", problems)
+	}
+	cfg.Plans.ExcerptStrategy = "llm"
+	cfg.Tasks.ExcerptCommand = ""
+	problems := ValidateValues(cfg)
+	if len(problems) != 2 || !strings.Contains(problems[0], "plans.excerpt_strategy") || !strings.Contains(problems[1], "tasks.excerpt_command") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateValues_ExcerptStripPrefixes(t *testing.T) {
+	cfg := Default("p")
+	cfg.Plans.ExcerptStripPrefixes = []string{`This session covers:?`}
+	cfg.Tasks.ExcerptStripPrefixes = []string{`ok`, `[`}
+	problems := ValidateValues(cfg)
+	if len(problems) != 1 || !strings.HasPrefix(problems[0], "tasks.excerpt_strip_prefixes[1]:") {
+		t.Errorf("got %v", problems)
+	}
+}
+
+func TestValidateValues_Privacy(t *testing.T) {
+	cfg := Default("p")
+	cfg.Privacy.Mode = "redact"
+	cfg.Privacy.Patterns = []NamedPattern{{Name: "ticket", Pattern: `SEC-\d+`}}
+	if problems := ValidateValues(cfg); len(problems) != 0 {
+		t.Fatalf("valid privacy config: got %v", problems)
+	}
+	cfg.Privacy.Mode = "delete"
+	cfg.Privacy.Patterns = append(cfg.Privacy.Patterns, NamedPattern{Name: "ticket", Pattern: "("}, NamedPattern{Pattern: "x"})
+	problems := ValidateValues(cfg)
+	for _, want := range []string{"privacy.mode", "privacy.patterns[1]: duplicate", "privacy.patterns[1]: error parsing", "privacy.patterns[2]: name"} {
+		if !slices.ContainsFunc(problems, func(p string) bool { return strings.HasPrefix(p, want) }) {
+			t.Errorf("missing %q in %v", want, problems)
+		}
+	}
+	if (PrivacyConfig{}).EffectiveMode() != PrivacyWarn {
+		t.Error("empty mode should mean warn")
+	}
+}
+
+func TestValidateValues_WatchJobs(t *testing.T) {
+	cfg := Default("p")
+	cfg.Watch.Jobs = []WatchJob{{Name: "check", Schedule: "0 3 * * *", Command: "sync --check"}}
+	if problems := ValidateValues(cfg); len(problems) != 0 {
+		t.Fatalf("valid job: got %v", problems)
+	}
+	cfg.Watch.Jobs = append(cfg.Watch.Jobs, WatchJob{Name: "check", Schedule: "nightly"})
+	problems := ValidateValues(cfg)
+	for _, want := range []string{"watch.jobs[1]: duplicate", "watch.jobs[1].schedule", "watch.jobs[1].command"} {
+		if !slices.ContainsFunc(problems, func(p string) bool { return strings.HasPrefix(p, want) }) {
+			t.Errorf("missing %q in %v", want, problems)
+		}
+	}
+}
+
+func TestGitConfig_Levels(t *testing.T) {
+	for _, tc := range []struct {
+		git                     GitConfig
+		kind                    string
+		stages, commits, pushes bool
+	}{
+		{GitConfig{}, CommitSave, false, false, false},
+		{GitConfig{Auto: GitOff, AutoPush: true}, CommitTaskDone, false, false, false},
+		{GitConfig{Auto: GitAdd}, CommitSave, true, false, false},
+		{GitConfig{Auto: GitCommit}, CommitSave, true, true, false},
+		{GitConfig{AutoPush: true}, CommitSave, true, false, false},
+		{GitConfig{AutoPush: true}, CommitTaskUpdate, true, false, false},
+		{GitConfig{AutoPush: true}, CommitTaskDone, true, true, true},
+		{GitConfig{Auto: GitAdd, Override: GitPush}, CommitSave, true, true, true},
+	} {
+		if tc.git.Stages() != tc.stages || tc.git.Commits(tc.kind) != tc.commits || tc.git.Pushes(tc.kind) != tc.pushes {
+			t.Errorf("%+v %s: stages/commits/pushes = %v/%v/%v, want %v/%v/%v", tc.git, tc.kind,
+				tc.git.Stages(), tc.git.Commits(tc.kind), tc.git.Pushes(tc.kind), tc.stages, tc.commits, tc.pushes)
+		}
+	}
+}
+
+func TestGitConfig_ScopeBranch(t *testing.T) {
+	g := GitConfig{RecordBranch: true}
+	for branch, want := range map[string]string{"main": "", "master": "", "feature/x": "feature/x"} {
+		if got := g.ScopeBranch(branch); got != want {
+			t.Errorf("ScopeBranch(%q) = %q, want %q", branch, got, want)
+		}
+	}
+	g.TrunkBranches = []string{"develop"}
+	if got := g.ScopeBranch("main"); got != "main" {
+		t.Errorf("with trunk_branches [develop], ScopeBranch(main) = %q", got)
+	}
+	if got := (GitConfig{}).ScopeBranch("feature/x"); got != "" {
+		t.Errorf("record_branch off: ScopeBranch = %q", got)
+	}
+}
+
+func TestGitConfig_CommitMessage(t *testing.T) {
+	g := GitConfig{CommitMessages: map[string]string{CommitTaskDone: "chore({{plan}}): {{title}} {{from}} -> {{to}}"}}
+	vars := map[string]string{"title": "Add login", "plan": "auth", "from": "in_progress", "to": "done"}
+	if got := g.CommitMessage(CommitTaskDone, vars); got != "chore(auth): Add login in_progress -> done" {
+		t.Errorf("custom template: got %q", got)
+	}
+	if got := g.CommitMessage(CommitTaskUpdate, vars); got != "logos: update task: Add login" {
+		t.Errorf("default template: got %q", got)
+	}
+}
+
+func TestLoad_GitEnvOverride(t *testing.T) {
+	t.Setenv(GitEnv, GitCommit)
+	cfg, err := Load(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Git.Commits(CommitSave) {
+		t.Errorf("$%s=commit should make Commits true, got level %q", GitEnv, cfg.Git.Level())
+	}
+}
+
+func TestValidateValues_Git(t *testing.T) {
+	cfg := Default("p")
+	cfg.Git.Auto = GitPush
+	cfg.Git.Worktrees = WorktreesMain
+	cfg.Git.CommitMessages = map[string]string{CommitSave: "docs: {{topic}}"}
+	if problems := ValidateValues(cfg); len(problems) != 0 {
+		t.Fatalf("valid git config: got %v", problems)
+	}
+	cfg.Git.Auto = "always"
+	cfg.Git.Worktrees = "shared"
+	cfg.Git.CommitMessages = map[string]string{CommitSave: "{{title}}", "archive": "x"}
+	problems := ValidateValues(cfg)
+	for _, want := range []string{"git.auto", "git.worktrees", "git.commit_messages.save: unknown placeholder {{title}}", "git.commit_messages: unknown key"} {
+		if !slices.ContainsFunc(problems, func(p string) bool { return strings.HasPrefix(p, want) }) {
+			t.Errorf("missing %q in %v", want, problems)
+		}
+	}
+}
+
+func TestLimitsConfig(t *testing.T) {
+	var c LimitsConfig
+	if c.PlanLimit() != DefaultMaxPlans || c.IndexLimitBytes() != DefaultMaxIndexKB*1024 || c.FileLimitBytes() != DefaultMaxFileKB*1024 {
+		t.Errorf("zero value should use defaults, got %d %d %d", c.PlanLimit(), c.IndexLimitBytes(), c.FileLimitBytes())
+	}
+	c = LimitsConfig{MaxPlans: 10, MaxIndexKB: -1, MaxFileKB: 4}
+	if c.PlanLimit() != 10 || c.IndexLimitBytes() != 0 || c.FileLimitBytes() != 4096 {
+		t.Errorf("got %d %d %d", c.PlanLimit(), c.IndexLimitBytes(), c.FileLimitBytes())
+	}
+}
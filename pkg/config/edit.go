@@ -0,0 +1,272 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// Get returns the effective value of the setting at key (see settingType)
+// in cfg, decoded from JSON: a string, float64, bool, []any, map[string]any,
+// or nil for a setting that is unset and has no default.
+func Get(cfg Config, key string) (any, error) {
+	if _, err := settingType(key); err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, err
+	}
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return nil, err
+	}
+	for _, seg := range strings.Split(key, ".") {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil, nil
+		}
+		v = m[seg]
+	}
+	return v, nil
+}
+
+// Set changes the setting at key (see settingType) in config.json under
+// projectRoot to value, editing the file in place: the rest of the file —
+// key order, indentation, settings left at their defaults — is kept as it
+// is. value is taken literally for string settings and as JSON for the
+// others (e.g. true, 300, ["go", "cli"]). A missing setting is added to the
+// end of its object, creating the objects above it.
+//
+// Set refuses a change that makes config.json fail Validate with a problem
+// it did not have before.
+func Set(projectRoot, key, value string) error {
+	t, err := settingType(key)
+	if err != nil {
+		return err
+	}
+	var v any
+	if t.Kind() == reflect.String {
+		v = value
+	} else {
+		if err := json.Unmarshal([]byte(value), reflect.New(t).Interface()); err != nil {
+			return fmt.Errorf("%s: %q is not a valid %s value", key, value, schemaFor(t, key)["type"])
+		}
+		dec := json.NewDecoder(strings.NewReader(value))
+		dec.UseNumber()
+		if err := dec.Decode(&v); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+
+	path := ConfigPath(projectRoot)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return fmt.Errorf("%s not found — run logos init", ConfigFileName)
+		}
+		return err
+	}
+	out, err := setJSON(data, strings.Split(key, "."), v)
+	if err != nil {
+		return err
+	}
+
+	before := validateData(projectRoot, data)
+	var introduced []string
+	for _, p := range validateData(projectRoot, out) {
+		if !slices.Contains(before, p) {
+			introduced = append(introduced, p)
+		}
+	}
+	if len(introduced) > 0 {
+		return fmt.Errorf("not setting %s: %s", key, strings.Join(introduced, "; "))
+	}
+	return writeFileAtomic(path, out)
+}
+
+// writeFileAtomic writes data to a temp file beside path and renames it into
+// place, so a command loading the config never reads a half-written file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), ".config-*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := os.Chmod(tmp.Name(), 0o644); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// setJSON returns the JSON object data with the value at path replaced by
+// v, or added to the end of the deepest object on path that exists. Only
+// the bytes of the value (or the added member) change.
+func setJSON(data []byte, path []string, v any) ([]byte, error) {
+	start, end, missing, err := locate(data, path)
+	if err != nil {
+		return nil, err
+	}
+	unit := indentUnit(data)
+
+	if missing == nil {
+		text, err := marshalIndented(v, lineIndent(data, start), unit)
+		if err != nil {
+			return nil, err
+		}
+		return slices.Concat(data[:start], text, data[end:]), nil
+	}
+
+	// start is the closing brace of the object the member is added to.
+	for _, k := range slices.Backward(missing[1:]) {
+		v = map[string]any{k: v}
+	}
+	key, _ := json.Marshal(missing[0])
+	last := bytes.LastIndexFunc(data[:start], func(r rune) bool { return !isSpace(byte(r)) })
+	empty := data[last] == '{'
+
+	closing := lineIndent(data, start)
+	// An empty {} is expanded onto its own lines unless the whole file is
+	// on one line.
+	multiline := strings.TrimSpace(string(data[bytes.LastIndexByte(data[:start], '\n')+1:start])) == "" ||
+		empty && bytes.IndexByte(bytes.TrimSpace(data), '\n') >= 0
+	if !multiline {
+		text, err := marshalIndented(v, "", "")
+		if err != nil {
+			return nil, err
+		}
+		member := slices.Concat(key, []byte(": "), text)
+		if empty {
+			return slices.Concat(data[:last+1], member, data[start:]), nil
+		}
+		return slices.Concat(data[:last+1], []byte(", "), member, data[last+1:]), nil
+	}
+
+	indent := closing + unit
+	text, err := marshalIndented(v, indent, unit)
+	if err != nil {
+		return nil, err
+	}
+	member := slices.Concat([]byte("\n"+indent), key, []byte(": "), text)
+	if empty {
+		return slices.Concat(data[:last+1], member, []byte("\n"+closing), data[start:]), nil
+	}
+	return slices.Concat(data[:last+1], []byte(","), member, data[last+1:]), nil
+}
+
+// locate finds the value at path in the JSON object data. When it exists,
+// it returns the offsets where the value starts and ends. Otherwise start
+// is the offset of the closing brace of the deepest object on path that
+// exists, end is -1, and missing holds the keys of path below that object.
+func locate(data []byte, path []string) (start, end int, missing []string, err error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return 0, 0, nil, fmt.Errorf("%s is not a JSON object", ConfigFileName)
+	}
+	for i := 0; ; {
+		tok, err := dec.Token()
+		if err != nil {
+			return 0, 0, nil, fmt.Errorf("parse %s: %w", ConfigFileName, err)
+		}
+		if tok == json.Delim('}') {
+			return int(dec.InputOffset()) - 1, -1, path[i:], nil
+		}
+		afterKey := int(dec.InputOffset())
+		if tok != path[i] {
+			if err := skipValue(dec); err != nil {
+				return 0, 0, nil, fmt.Errorf("parse %s: %w", ConfigFileName, err)
+			}
+			continue
+		}
+		if i == len(path)-1 {
+			start := afterKey
+			for start < len(data) && (isSpace(data[start]) || data[start] == ':') {
+				start++
+			}
+			if err := skipValue(dec); err != nil {
+				return 0, 0, nil, fmt.Errorf("parse %s: %w", ConfigFileName, err)
+			}
+			return start, int(dec.InputOffset()), nil, nil
+		}
+		if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+			return 0, 0, nil, fmt.Errorf("%s: %s is not an object", ConfigFileName, strings.Join(path[:i+1], "."))
+		}
+		i++
+	}
+}
+
+// skipValue consumes the next value from dec, nested objects and arrays
+// included.
+func skipValue(dec *json.Decoder) error {
+	depth := 0
+	for {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		switch tok {
+		case json.Delim('{'), json.Delim('['):
+			depth++
+		case json.Delim('}'), json.Delim(']'):
+			depth--
+		}
+		if depth == 0 {
+			return nil
+		}
+	}
+}
+
+// marshalIndented encodes v as JSON without escaping HTML characters, with
+// every line after the first starting with prefix and one indent per level.
+func marshalIndented(v any, prefix, indent string) ([]byte, error) {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	enc.SetIndent(prefix, indent)
+	if err := enc.Encode(v); err != nil {
+		return nil, err
+	}
+	return bytes.TrimRight(b.Bytes(), "\n"), nil
+}
+
+// lineIndent returns the white space at the start of the line holding
+// offset.
+func lineIndent(data []byte, offset int) string {
+	line := data[bytes.LastIndexByte(data[:offset], '\n')+1 : offset]
+	n := 0
+	for n < len(line) && (line[n] == ' ' || line[n] == '\t') {
+		n++
+	}
+	return string(line[:n])
+}
+
+// indentUnit returns the indentation of the first indented line of data,
+// which in a formatted JSON object is one level; a tab when no line is
+// indented.
+func indentUnit(data []byte) string {
+	for _, line := range bytes.Split(data, []byte("\n")) {
+		if indent := lineIndent(line, len(line)); indent != "" {
+			return indent
+		}
+	}
+	return "\t"
+}
+
+func isSpace(c byte) bool {
+	return c == ' ' || c == '\t' || c == '\n' || c == '\r'
+}
@@ -0,0 +1,153 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestGet(t *testing.T) {
+	cfg := Default("p")
+	cfg.Tasks.Retention = map[string]string{"done": "60d"}
+
+	for key, want := range map[string]any{
+		"plans.excerpt_section": "Background",
+		"gc.orphan_plan_days":   float64(90),
+		"tasks.retention.done":  "60d",
+		"tasks.id_prefix":       nil,
+	} {
+		got, err := Get(cfg, key)
+		if err != nil || got != want {
+			t.Errorf("Get(%q) = %v, %v; want %v", key, got, err, want)
+		}
+	}
+	if _, err := Get(cfg, "plans.excerpt_secton"); err == nil || !strings.Contains(err.Error(), `did you mean "plans.excerpt_section"`) {
+		t.Errorf("unknown key error = %v", err)
+	}
+}
+
+const editFixture = `{
+  "version": "2",
+  "plans": {
+    "excerpt_section": "Background"
+  },
+  "prompts": {},
+  "project": "p"
+}
+`
+
+func TestSetJSON(t *testing.T) {
+	tests := []struct {
+		name string
+		path []string
+		v    any
+		want string
+	}{
+		{
+			"replace", []string{"plans", "excerpt_section"}, "Summary",
+			strings.Replace(editFixture, `"Background"`, `"Summary"`, 1),
+		},
+		{
+			"add to object", []string{"plans", "summary_sections"}, []any{"Spec"},
+			strings.Replace(editFixture, `"Background"
+`, `"Background",
+    "summary_sections": [
+      "Spec"
+    ]
+`, 1),
+		},
+		{
+			"add to empty object", []string{"prompts", "default"}, "yes",
+			strings.Replace(editFixture, `"prompts": {}`, `"prompts": {
+    "default": "yes"
+  }`, 1),
+		},
+		{
+			"add nested", []string{"tasks", "retention", "done"}, "60d",
+			strings.Replace(editFixture, `"project": "p"
+`, `"project": "p",
+  "tasks": {
+    "retention": {
+      "done": "60d"
+    }
+  }
+`, 1),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := setJSON([]byte(editFixture), tt.path, tt.v)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Errorf("got\n%s\nwant\n%s", got, tt.want)
+			}
+		})
+	}
+
+	if _, err := setJSON([]byte(editFixture), []string{"version", "x"}, "y"); err == nil {
+		t.Error("setting a key below a string: want an error")
+	}
+}
+
+func TestSet(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, DirName), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(ConfigPath(dir), []byte(editFixture), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Set(dir, "gc.orphan_plan_days", "30"); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	if err := Set(dir, "tasks.default_tags", `["go", "cli"]`); err != nil {
+		t.Fatalf("Set: %v", err)
+	}
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.GC.OrphanPlanDays != 30 || !slices.Equal(cfg.Tasks.DefaultTags, []string{"go", "cli"}) {
+		t.Errorf("cfg = gc %+v, default_tags %v", cfg.GC, cfg.Tasks.DefaultTags)
+	}
+
+	for key, value := range map[string]string{
+		"gc.orphan_plan_days":    "soon",             // not an integer
+		"git.auto":               "comit",            // not a level
+		"plans.excerpt_secton":   "Spec",             // not a setting
+		"tasks.default_priority": "urgent",           // not a priority
+		"tasks.default_status":   `"open"`,           // quotes are kept for string settings
+		"tasks.retention":        `{"done": "soon"}`, // not an age
+	} {
+		if err := Set(dir, key, value); err == nil {
+			t.Errorf("Set(%q, %q) = nil, want an error", key, value)
+		}
+	}
+	if data, _ := os.ReadFile(ConfigPath(dir)); strings.Contains(string(data), "comit") {
+		t.Errorf("refused git.auto was written:\n%s", data)
+	}
+}
+
+func TestSchema(t *testing.T) {
+	s := Schema()
+	if s["$schema"] != SchemaURL || s["additionalProperties"] != false {
+		t.Fatalf("schema root = %v", s)
+	}
+	props := s["properties"].(map[string]any)
+	git := props["git"].(map[string]any)["properties"].(map[string]any)
+	if !slices.Equal(git["auto"].(map[string]any)["enum"].([]string), GitLevels) {
+		t.Errorf("git.auto = %v", git["auto"])
+	}
+	if _, ok := git["Override"]; ok {
+		t.Error("schema lists a field that is not in config.json")
+	}
+	retention := props["tasks"].(map[string]any)["properties"].(map[string]any)["retention"].(map[string]any)
+	if retention["type"] != "object" || retention["additionalProperties"].(map[string]any)["type"] != "string" {
+		t.Errorf("tasks.retention = %v", retention)
+	}
+}
@@ -0,0 +1,131 @@
+package config
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+)
+
+// LocalFileName is the per-user settings file next to config.json. It is
+// listed in .logosyncx/.gitignore so that it is never committed.
+const LocalFileName = "config.local.json"
+
+// LocalConfig holds settings that belong to one user's checkout rather than
+// to the project.
+type LocalConfig struct {
+	// InboxAckedAt is when logos inbox ack was last run; logos inbox lists
+	// plans and tasks created after it.
+	InboxAckedAt *time.Time `json:"inbox_acked_at,omitempty"`
+	// Plans and Tasks hold the excerpt commands of this checkout, which
+	// config.json cannot set (see ExcerptConfig.ExcerptCommand).
+	Plans LocalExcerptConfig `json:"plans,omitzero"`
+	Tasks LocalExcerptConfig `json:"tasks,omitzero"`
+}
+
+// LocalExcerptConfig holds the excerpt settings of one kind of file that
+// are kept in config.local.json.
+type LocalExcerptConfig struct {
+	ExcerptCommand string `json:"excerpt_command,omitempty"`
+}
+
+// LocalPath returns the path to config.local.json given the project root.
+func LocalPath(projectRoot string) string {
+	return filepath.Join(projectRoot, DirName, LocalFileName)
+}
+
+// LoadLocal reads config.local.json from the given project root.
+// If the file does not exist, it returns a zero LocalConfig and no error.
+func LoadLocal(projectRoot string) (LocalConfig, error) {
+	var local LocalConfig
+	data, err := os.ReadFile(LocalPath(projectRoot))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return local, nil
+		}
+		return local, err
+	}
+	if err := json.Unmarshal(data, &local); err != nil {
+		return LocalConfig{}, err
+	}
+	return local, nil
+}
+
+// applyLocal copies the settings kept in config.local.json under
+// projectRoot into cfg.
+func applyLocal(cfg *Config, projectRoot string) error {
+	local, err := LoadLocal(projectRoot)
+	if err != nil {
+		return fmt.Errorf("load %s: %w", LocalFileName, err)
+	}
+	cfg.Plans.ExcerptCommand = local.Plans.ExcerptCommand
+	cfg.Tasks.ExcerptCommand = local.Tasks.ExcerptCommand
+	return nil
+}
+
+// SaveLocal writes local to config.local.json under the given project root
+// and makes sure .logosyncx/.gitignore lists the file.
+func SaveLocal(projectRoot string, local LocalConfig) error {
+	dir := filepath.Join(projectRoot, DirName)
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(local, "", "\t")
+	if err != nil {
+		return err
+	}
+	data = append(data, '\n')
+	if err := os.WriteFile(LocalPath(projectRoot), data, 0o644); err != nil {
+		return err
+	}
+	return IgnoreLocal(projectRoot, LocalFileName)
+}
+
+// TransientPatterns are the .logosyncx/.gitignore patterns for the files
+// logos only keeps while it writes: the "<path>.lock" files of filelock and
+// the temporary files of atomic writes. A crashed run can leave them behind.
+var TransientPatterns = []string{"*.lock", "*.tmp"}
+
+// IgnoreLocal appends name, a per-user file directly under .logosyncx/, to
+// .logosyncx/.gitignore unless it is listed. The TransientPatterns are
+// added along with it.
+func IgnoreLocal(projectRoot, name string) error {
+	return ignore(projectRoot, append([]string{name}, TransientPatterns...))
+}
+
+// IgnoreTransient appends the TransientPatterns that are not yet listed to
+// .logosyncx/.gitignore, creating it if needed. logos init calls it.
+func IgnoreTransient(projectRoot string) error {
+	return ignore(projectRoot, TransientPatterns)
+}
+
+// ignore appends each of names that is not yet listed to
+// .logosyncx/.gitignore.
+func ignore(projectRoot string, names []string) error {
+	path := filepath.Join(projectRoot, DirName, ".gitignore")
+	data, err := os.ReadFile(path)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	lines := strings.Split(string(data), "\n")
+	var missing []string
+	for _, name := range names {
+		if !slices.Contains(lines, name) && !slices.Contains(lines, "/"+name) {
+			missing = append(missing, name)
+		}
+	}
+	if len(missing) == 0 {
+		return nil
+	}
+	if len(data) > 0 && data[len(data)-1] != '\n' {
+		data = append(data, '\n')
+	}
+	for _, name := range missing {
+		data = append(data, name+"\n"...)
+	}
+	return os.WriteFile(path, data, 0o644)
+}
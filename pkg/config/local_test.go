@@ -0,0 +1,79 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestLoadLocal_MissingFile(t *testing.T) {
+	local, err := LoadLocal(t.TempDir())
+	if err != nil {
+		t.Fatalf("LoadLocal: %v", err)
+	}
+	if local.InboxAckedAt != nil {
+		t.Errorf("expected no ack time, got %v", local.InboxAckedAt)
+	}
+}
+
+func TestSaveLocal_RoundTripAndIgnore(t *testing.T) {
+	dir := t.TempDir()
+	at := time.Date(2026, 3, 1, 9, 30, 0, 0, time.UTC)
+
+	for range 2 {
+		if err := SaveLocal(dir, LocalConfig{InboxAckedAt: &at}); err != nil {
+			t.Fatalf("SaveLocal: %v", err)
+		}
+	}
+	local, err := LoadLocal(dir)
+	if err != nil {
+		t.Fatalf("LoadLocal: %v", err)
+	}
+	if local.InboxAckedAt == nil || !local.InboxAckedAt.Equal(at) {
+		t.Errorf("InboxAckedAt = %v, want %v", local.InboxAckedAt, at)
+	}
+
+	data, err := os.ReadFile(filepath.Join(dir, DirName, ".gitignore"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range append([]string{LocalFileName}, TransientPatterns...) {
+		if got := strings.Count(string(data), name+"\n"); got != 1 {
+			t.Errorf(".gitignore lists %s %d times, want once:\n%s", name, got, data)
+		}
+	}
+}
+
+func TestLoad_ExcerptCommandOnlyFromLocal(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(dir, DirName), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	data := `{"version": "2", "plans": {"excerpt_command": "rm -rf ."}, "tasks": {"excerpt_strategy": "command"}}`
+	if err := os.WriteFile(ConfigPath(dir), []byte(data), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := SaveLocal(dir, LocalConfig{Tasks: LocalExcerptConfig{ExcerptCommand: "summarize --short"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := Load(dir)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Plans.ExcerptCommand != "" {
+		t.Errorf("plans.excerpt_command = %q, want config.json's to be ignored", cfg.Plans.ExcerptCommand)
+	}
+	if cfg.Tasks.ExcerptCommand != "summarize --short" {
+		t.Errorf("tasks.excerpt_command = %q, want it from %s", cfg.Tasks.ExcerptCommand, LocalFileName)
+	}
+
+	if err := Save(dir, cfg); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := os.ReadFile(ConfigPath(dir)); strings.Contains(string(data), "excerpt_command") {
+		t.Errorf("Save wrote the local excerpt command to %s:\n%s", ConfigFileName, data)
+	}
+}
@@ -0,0 +1,157 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/suggest"
+)
+
+// SchemaURL is the JSON Schema dialect Schema is written in.
+const SchemaURL = "https://json-schema.org/draft/2020-12/schema"
+
+// schemaEnums lists the values accepted by settings that take one of a fixed
+// set of strings, keyed by their path in config.json. Leaving a setting out
+// selects its default.
+var schemaEnums = map[string][]string{
+	"plans.excerpt_strategy": markdown.StrategyNames,
+	"tasks.excerpt_strategy": markdown.StrategyNames,
+	"tasks.id_mode":          {"random", "sequential"},
+	"tasks.retention_action": {"archive", "delete"},
+	"prompts.default":        {"yes", "no"},
+	"output.ls":              {"table", "wide", "json"},
+	"output.task_ls":         {"table", "json"},
+	"privacy.mode":           {PrivacyWarn, PrivacyRedact, PrivacyBlock},
+	"git.auto":               GitLevels,
+	"git.worktrees":          {WorktreesAuto, WorktreesMain, WorktreesLocal},
+}
+
+// Schema returns a JSON Schema describing config.json, generated from
+// Config: the type of every setting, the values of settings that take one
+// of a fixed set, and, like Validate, no keys beyond the known ones.
+func Schema() map[string]any {
+	s := schemaFor(reflect.TypeFor[Config](), "")
+	s["$schema"] = SchemaURL
+	s["title"] = "logosyncx " + ConfigFileName
+	return s
+}
+
+func schemaFor(t reflect.Type, path string) map[string]any {
+	switch t.Kind() {
+	case reflect.Pointer:
+		return schemaFor(t.Elem(), path)
+	case reflect.String:
+		s := map[string]any{"type": "string"}
+		if enum, ok := schemaEnums[path]; ok {
+			s["enum"] = enum
+		}
+		return s
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}
+	case reflect.Slice, reflect.Array:
+		return map[string]any{"type": "array", "items": schemaFor(t.Elem(), path+"[]")}
+	case reflect.Map:
+		return map[string]any{"type": "object", "additionalProperties": schemaFor(t.Elem(), path+".*")}
+	case reflect.Struct:
+		props := map[string]any{}
+		for _, f := range jsonFields(t) {
+			props[f.name] = schemaFor(f.typ, joinKey(path, f.name))
+		}
+		return map[string]any{"type": "object", "properties": props, "additionalProperties": false}
+	}
+	return map[string]any{}
+}
+
+type jsonField struct {
+	name string
+	typ  reflect.Type
+}
+
+// jsonFields returns the fields of the struct type t as they appear in
+// JSON, in declaration order. The fields of an untagged embedded struct
+// are listed in its place, as encoding/json flattens them.
+func jsonFields(t reflect.Type) []jsonField {
+	var fields []jsonField
+	for i := range t.NumField() {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if f.Anonymous && f.Type.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			fields = append(fields, jsonFields(f.Type)...)
+			continue
+		}
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		switch name {
+		case "-":
+			continue
+		case "":
+			name = f.Name
+		}
+		fields = append(fields, jsonField{name, f.Type})
+	}
+	return fields
+}
+
+func joinKey(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+// settingType returns the type of the setting at key, a dot-separated path
+// of config.json keys in which map entries are named by their key (e.g.
+// "tasks.retention.done"). An unknown key is an error with a "did you mean"
+// suggestion when a known one is close.
+func settingType(key string) (reflect.Type, error) {
+	t := reflect.TypeFor[Config]()
+	var walked []string
+	for _, seg := range strings.Split(key, ".") {
+		if t.Kind() == reflect.Pointer {
+			t = t.Elem()
+		}
+		switch t.Kind() {
+		case reflect.Struct:
+			fields := jsonFields(t)
+			var names []string
+			found := false
+			for _, f := range fields {
+				if f.name == seg {
+					t, found = f.typ, true
+					break
+				}
+				names = append(names, f.name)
+			}
+			if !found {
+				if s := suggest.Closest(seg, names); s != "" {
+					return nil, unknownSettingError(key, joinKey(strings.Join(walked, "."), s))
+				}
+				return nil, unknownSettingError(key, "")
+			}
+		case reflect.Map:
+			if seg == "" {
+				return nil, unknownSettingError(key, "")
+			}
+			t = t.Elem()
+		default:
+			return nil, unknownSettingError(key, "")
+		}
+		walked = append(walked, seg)
+	}
+	return t, nil
+}
+
+func unknownSettingError(key, suggestion string) error {
+	if suggestion != "" {
+		return fmt.Errorf("unknown setting %q (did you mean %q?)", key, suggestion)
+	}
+	return fmt.Errorf("unknown setting %q", key)
+}
@@ -0,0 +1,40 @@
+package config
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/suggest"
+)
+
+// CheckTags returns an error naming the first tag not in allowed, with a
+// "did you mean" suggestion when a vocabulary entry is a likely typo fix.
+// An empty allowed list accepts every tag. key names the config field in
+// the error message (e.g. "tasks.allowed_tags").
+func CheckTags(tags, allowed []string, key string) error {
+	if len(allowed) == 0 {
+		return nil
+	}
+	for _, tag := range tags {
+		if slices.Contains(allowed, tag) {
+			continue
+		}
+		if s := suggest.Closest(tag, allowed); s != "" {
+			return fmt.Errorf("tag %q is not in %s (did you mean %q?)", tag, key, s)
+		}
+		return fmt.Errorf("tag %q is not in %s: allowed tags are %s", tag, key, strings.Join(allowed, ", "))
+	}
+	return nil
+}
+
+// MergeTags returns tags followed by every default not already present.
+func MergeTags(tags, defaults []string) []string {
+	out := slices.Clone(tags)
+	for _, d := range defaults {
+		if !slices.Contains(out, d) {
+			out = append(out, d)
+		}
+	}
+	return out
+}
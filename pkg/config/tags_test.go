@@ -0,0 +1,46 @@
+package config
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckTags_EmptyVocabularyAcceptsAll(t *testing.T) {
+	if err := CheckTags([]string{"anything"}, nil, "tasks.allowed_tags"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckTags_AllowedTagsPass(t *testing.T) {
+	if err := CheckTags([]string{"go", "cli"}, []string{"go", "cli", "infra"}, "tasks.allowed_tags"); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestCheckTags_TypoSuggestsClosest(t *testing.T) {
+	err := CheckTags([]string{"infar"}, []string{"go", "infra", "frontend"}, "tasks.allowed_tags")
+	if err == nil {
+		t.Fatal("expected error for unknown tag")
+	}
+	if !strings.Contains(err.Error(), `did you mean "infra"`) {
+		t.Errorf("expected suggestion in error, got: %v", err)
+	}
+}
+
+func TestCheckTags_UnrelatedTagListsVocabulary(t *testing.T) {
+	err := CheckTags([]string{"database"}, []string{"go", "infra"}, "plans.allowed_tags")
+	if err == nil {
+		t.Fatal("expected error for unknown tag")
+	}
+	if strings.Contains(err.Error(), "did you mean") || !strings.Contains(err.Error(), "go, infra") {
+		t.Errorf("expected allowed list without suggestion, got: %v", err)
+	}
+}
+
+func TestMergeTags_AppendsMissingDefaults(t *testing.T) {
+	got := MergeTags([]string{"go", "api"}, []string{"api", "backend"})
+	want := []string{"go", "api", "backend"}
+	if strings.Join(got, ",") != strings.Join(want, ",") {
+		t.Errorf("MergeTags = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,87 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/senna-lang/logosyncx/internal/suggest"
+)
+
+// SectionConfig is one "## Name" section of a body template, with the text
+// placed under the heading.
+type SectionConfig struct {
+	Name    string `json:"name"`
+	Content string `json:"content,omitempty"`
+}
+
+// TemplateConfig is a named body template for new plans and tasks.
+type TemplateConfig struct {
+	// Sections are scaffolded in order.
+	Sections []SectionConfig `json:"sections"`
+	// Tags are added to the tags of the new plan or task.
+	Tags []string `json:"tags,omitempty"`
+}
+
+// DefaultTemplates are the templates available when templates does not
+// define one of the same name.
+var DefaultTemplates = map[string]TemplateConfig{
+	"bugfix": {Sections: []SectionConfig{
+		{Name: "Symptom", Content: "<!-- What is broken, and how to reproduce it. -->"},
+		{Name: "Root Cause"},
+		{Name: "Fix"},
+		{Name: "Verification", Content: "<!-- Tests added or run, and how the fix was checked. -->"},
+	}},
+	"retro": {Sections: []SectionConfig{
+		{Name: "What Went Well"},
+		{Name: "What Went Wrong"},
+		{Name: "Learnings"},
+		{Name: "Action Items", Content: "- [ ] "},
+	}},
+}
+
+// TemplateNames returns the names of every available template, sorted.
+func (c Config) TemplateNames() []string {
+	names := slices.Collect(maps.Keys(DefaultTemplates))
+	for name := range c.Templates {
+		if _, ok := DefaultTemplates[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Template returns the template called name: templates[name] when set,
+// otherwise the built-in one. An unknown name is an error with a "did you
+// mean" suggestion when an available name is a likely typo fix.
+func (c Config) Template(name string) (TemplateConfig, error) {
+	if t, ok := c.Templates[name]; ok {
+		return t, nil
+	}
+	if t, ok := DefaultTemplates[name]; ok {
+		return t, nil
+	}
+	names := c.TemplateNames()
+	if s := suggest.Closest(name, names); s != "" {
+		return TemplateConfig{}, fmt.Errorf("template %q not found (did you mean %q?)", name, s)
+	}
+	return TemplateConfig{}, fmt.Errorf("template %q not found: available templates are %s", name, strings.Join(names, ", "))
+}
+
+// Body returns the template's sections as Markdown: a "## Name" heading for
+// each, followed by its content when there is any.
+func (t TemplateConfig) Body() string {
+	var b strings.Builder
+	for i, s := range t.Sections {
+		if i > 0 {
+			b.WriteString("\n")
+		}
+		fmt.Fprintf(&b, "## %s\n", s.Name)
+		if content := strings.TrimSpace(s.Content); content != "" {
+			fmt.Fprintf(&b, "\n%s\n", content)
+		}
+	}
+	return b.String()
+}
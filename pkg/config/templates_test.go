@@ -0,0 +1,46 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestTemplate_ConfigOverridesDefault(t *testing.T) {
+	c := Config{Templates: map[string]TemplateConfig{
+		"retro": {Sections: []SectionConfig{{Name: "Notes"}}},
+		"adr":   {Sections: []SectionConfig{{Name: "Decision"}}},
+	}}
+	got, err := c.Template("retro")
+	if err != nil || len(got.Sections) != 1 || got.Sections[0].Name != "Notes" {
+		t.Errorf("retro = %+v, %v; want the configured template", got, err)
+	}
+	if got, err := c.Template("bugfix"); err != nil || len(got.Sections) == 0 {
+		t.Errorf("bugfix = %+v, %v; want the built-in template", got, err)
+	}
+	if names := c.TemplateNames(); !slices.Equal(names, []string{"adr", "bugfix", "retro"}) {
+		t.Errorf("TemplateNames = %v", names)
+	}
+}
+
+func TestTemplate_Unknown(t *testing.T) {
+	_, err := Config{}.Template("zzz")
+	if err == nil || !strings.Contains(err.Error(), "bugfix, retro") {
+		t.Errorf("expected available templates in error, got %v", err)
+	}
+}
+
+func TestTemplateConfig_Body(t *testing.T) {
+	tmpl := TemplateConfig{Sections: []SectionConfig{{Name: "A", Content: "text\n"}, {Name: "B"}}}
+	if got, want := tmpl.Body(), "## A\n\ntext\n\n## B\n"; got != want {
+		t.Errorf("Body() = %q, want %q", got, want)
+	}
+}
+
+func TestValidateValues_EmptyTemplate(t *testing.T) {
+	cfg := Default("p")
+	cfg.Templates = map[string]TemplateConfig{"empty": {}}
+	if problems := ValidateValues(cfg); len(problems) != 1 || !strings.Contains(problems[0], "templates.empty") {
+		t.Errorf("expected templates.empty problem, got %v", problems)
+	}
+}
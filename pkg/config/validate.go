@@ -0,0 +1,385 @@
+package config
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/senna-lang/logosyncx/internal/markdown"
+	"github.com/senna-lang/logosyncx/internal/schedule"
+)
+
+// TaskStatuses and TaskPriorities are the task statuses and priorities a
+// setting may name. They mirror task.ValidStatuses and task.ValidPriorities,
+// which this package cannot import.
+var (
+	TaskStatuses   = []string{"open", "in_progress", "done"}
+	TaskPriorities = []string{"high", "medium", "low"}
+)
+
+// ParseAge parses an age such as "30d" or "2w", as tasks.retention and
+// tasks.escalation.due_within are written.
+func ParseAge(s string) (time.Duration, error) {
+	unit := map[string]int{"d": 1, "w": 7}
+	for suffix, days := range unit {
+		if numStr, ok := strings.CutSuffix(s, suffix); ok {
+			n, err := strconv.Atoi(numStr)
+			if err == nil && n >= 0 {
+				return time.Duration(n*days) * 24 * time.Hour, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%q: expected Nd or Nw (e.g. 30d)", s)
+}
+
+// commitPlaceholder matches a {{name}} placeholder in a commit message
+// template.
+var commitPlaceholder = regexp.MustCompile(`\{\{(\w+)\}\}`)
+
+// Validate checks config.json under projectRoot against the schema and
+// returns one message per problem: unknown keys, malformed JSON, and values
+// outside what this package accepts. A missing config.json is reported as a
+// problem, since every initialised project has one.
+func Validate(projectRoot string) ([]string, error) {
+	data, err := os.ReadFile(ConfigPath(projectRoot))
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return []string{ConfigFileName + " not found — run logos init"}, nil
+		}
+		return nil, err
+	}
+	return validateData(projectRoot, data), nil
+}
+
+// validateData is Validate for the config.json contents data.
+func validateData(projectRoot string, data []byte) []string {
+	if moved := movedToLocal(data); len(moved) > 0 {
+		return moved
+	}
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+	var cfg Config
+	if err := dec.Decode(&cfg); err != nil {
+		// An unknown key stops decoding, so report it alone.
+		return []string{err.Error()}
+	}
+	applyDefaults(&cfg, projectRoot)
+	if err := applyLocal(&cfg, projectRoot); err != nil {
+		return []string{err.Error()}
+	}
+	problems := append(ValidateValues(cfg), excerptProblems(projectRoot, cfg)...)
+	slices.Sort(problems)
+	return problems
+}
+
+// movedToLocal reports the settings in the config.json contents data that
+// are only read from config.local.json.
+func movedToLocal(data []byte) []string {
+	var raw struct {
+		Plans, Tasks map[string]json.RawMessage
+	}
+	// Malformed JSON is left for the decoder in validateData to report.
+	_ = json.Unmarshal(data, &raw)
+	var problems []string
+	for key, section := range map[string]map[string]json.RawMessage{"plans": raw.Plans, "tasks": raw.Tasks} {
+		if _, ok := section["excerpt_command"]; ok {
+			problems = append(problems, fmt.Sprintf("%s.excerpt_command: move it to %s — %s cannot name a command to run", key, LocalFileName, ConfigFileName))
+		}
+	}
+	slices.Sort(problems)
+	return problems
+}
+
+// excerptProblems reports excerpt_section and excerpt_fallback entries that
+// name no section a plan or task can have: none of the headings of its
+// template in .logosyncx/templates/ and none of the sections named elsewhere
+// in cfg. Such an excerpt always falls back to the start of the body. A
+// kind whose template cannot be read is not checked.
+func excerptProblems(projectRoot string, cfg Config) []string {
+	var planKnown []string
+	for _, category := range cfg.Plans.AllowedCategories() {
+		planKnown = append(planKnown, cfg.Plans.CategorySectionsFor(category)...)
+	}
+	planKnown = append(planKnown, cfg.Plans.SummarySections...)
+	planKnown = append(planKnown, cfg.Plans.RequiredSections...)
+	planKnown = append(planKnown, cfg.Tasks.ActionItems())
+	taskKnown := append(append([]string{}, cfg.Tasks.SummarySections...), cfg.Tasks.RequiredSections...)
+	for _, name := range cfg.TemplateNames() {
+		tmpl, _ := cfg.Template(name)
+		for _, sec := range tmpl.Sections {
+			planKnown = append(planKnown, sec.Name)
+			taskKnown = append(taskKnown, sec.Name)
+		}
+	}
+
+	var problems []string
+	for _, kind := range []struct {
+		key, template string
+		known         []string
+		section       string
+		fallback      []string
+	}{
+		{"plans", "plan.md", planKnown, cfg.Plans.ExcerptSection, cfg.Plans.ExcerptFallback},
+		{"tasks", "task.md", taskKnown, cfg.Tasks.ExcerptSection, cfg.Tasks.ExcerptFallback},
+	} {
+		data, err := os.ReadFile(filepath.Join(projectRoot, DirName, "templates", kind.template))
+		if err != nil {
+			continue
+		}
+		known := kind.known
+		for _, s := range markdown.Outline(string(data)) {
+			known = append(known, s.Heading)
+		}
+		isKnown := func(name string) bool {
+			return slices.ContainsFunc(known, func(k string) bool { return strings.EqualFold(strings.TrimSpace(k), strings.TrimSpace(name)) })
+		}
+		if !isKnown(kind.section) {
+			problems = append(problems, fmt.Sprintf("%s.excerpt_section: %q is not a heading of templates/%s or a section named in config", kind.key, kind.section, kind.template))
+		}
+		for i, name := range kind.fallback {
+			if !isKnown(name) {
+				problems = append(problems, fmt.Sprintf("%s.excerpt_fallback[%d]: %q is not a heading of templates/%s or a section named in config", kind.key, i, name, kind.template))
+			}
+		}
+	}
+	return problems
+}
+
+// ValidateValues returns one message per field of cfg holding a value this
+// package would reject or silently ignore.
+func ValidateValues(cfg Config) []string {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if cfg.Storage != "" {
+		// Validate is given the resolved storage root; a pointer here
+		// would be a second hop, which is not followed.
+		add("storage: %q is ignored — only the code repository's config.json may point to a storage directory", cfg.Storage)
+	}
+	if cfg.Version != "2" {
+		add("version: %q is not supported (expected \"2\")", cfg.Version)
+	}
+	if m := cfg.Tasks.IDMode; m != "" && m != "random" && m != "sequential" {
+		add("tasks.id_mode: %q must be random or sequential", m)
+	}
+	if s := cfg.Tasks.DefaultStatus; s != "" && !slices.Contains(TaskStatuses, s) {
+		add("tasks.default_status: %q is not a valid status", s)
+	}
+	if p := cfg.Tasks.DefaultPriority; p != "" && !slices.Contains(TaskPriorities, p) {
+		add("tasks.default_priority: %q is not a valid priority", p)
+	}
+	for i, r := range cfg.Tasks.Rules {
+		if r.Priority != "" && !slices.Contains(TaskPriorities, r.Priority) {
+			add("tasks.rules[%d]: %q is not a valid priority", i, r.Priority)
+		}
+	}
+	for _, status := range slices.Sorted(maps.Keys(cfg.Tasks.Retention)) {
+		if !slices.Contains(TaskStatuses, status) {
+			add("tasks.retention: %q is not a valid status", status)
+		}
+		if _, err := ParseAge(cfg.Tasks.Retention[status]); err != nil {
+			add("tasks.retention.%s: %v", status, err)
+		}
+	}
+	if e := cfg.Tasks.Escalation; e != nil {
+		if e.DueWithin == "" {
+			add("tasks.escalation.due_within: required (e.g. \"3d\")")
+		}
+		if p := e.SetPriority; p != "" && !slices.Contains(TaskPriorities, p) {
+			add("tasks.escalation.set_priority: %q must be low, medium, or high", p)
+		}
+	}
+	if a := cfg.Tasks.RetentionAction; a != "" && a != "archive" && a != "delete" {
+		add("tasks.retention_action: %q must be archive or delete", a)
+	}
+	if d := cfg.Prompts.Default; d != "" && d != "yes" && d != "no" {
+		add("prompts.default: %q must be yes or no", d)
+	}
+	if f := cfg.Output.LS; f != "" && f != "table" && f != "wide" && f != "json" {
+		add("output.ls: %q must be table, wide, or json", f)
+	}
+	if f := cfg.Output.TaskLS; f != "" && f != "table" && f != "json" {
+		add("output.task_ls: %q must be table or json", f)
+	}
+	if _, err := cfg.Display.Location(); err != nil {
+		add("display.timezone: %v", err)
+	}
+	for key, e := range map[string]struct {
+		strategy, command string
+	}{
+		"plans": {cfg.Plans.ExcerptStrategy, cfg.Plans.ExcerptCommand},
+		"tasks": {cfg.Tasks.ExcerptStrategy, cfg.Tasks.ExcerptCommand},
+	} {
+		switch {
+		case e.strategy != "" && !slices.Contains(markdown.StrategyNames, e.strategy):
+			add("%s.excerpt_strategy: %q must be one of %s", key, e.strategy, strings.Join(markdown.StrategyNames, ", "))
+		case e.strategy == markdown.StrategyCommand && strings.TrimSpace(e.command) == "":
+			add("%s.excerpt_command: required in %s when excerpt_strategy is command", key, LocalFileName)
+		}
+	}
+	for key, n := range map[string]int{
+		"plans.excerpt_max_runes":     cfg.Plans.ExcerptMaxRunes,
+		"plans.excerpt_cjk_max_runes": cfg.Plans.ExcerptCJKMaxRunes,
+		"tasks.excerpt_max_runes":     cfg.Tasks.ExcerptMaxRunes,
+		"tasks.excerpt_cjk_max_runes": cfg.Tasks.ExcerptCJKMaxRunes,
+		"plans.excerpt_sentences":     cfg.Plans.ExcerptSentences,
+		"tasks.excerpt_sentences":     cfg.Tasks.ExcerptSentences,
+		"gc.linked_task_done_days":    cfg.GC.LinkedTaskDoneDays,
+		"gc.orphan_plan_days":         cfg.GC.OrphanPlanDays,
+		"attachments.max_size_kb":     cfg.Attachments.MaxSizeKB,
+	} {
+		if n < 0 {
+			add("%s: %d must not be negative", key, n)
+		}
+	}
+	for i, r := range cfg.Tasks.Rules {
+		if r.Tag == "" {
+			add("tasks.rules[%d]: tag is required", i)
+		}
+	}
+	for _, tag := range cfg.Plans.DefaultTags {
+		if len(cfg.Plans.AllowedTags) > 0 && !slices.Contains(cfg.Plans.AllowedTags, tag) {
+			add("plans.default_tags: %q is not in plans.allowed_tags", tag)
+		}
+	}
+	for _, tag := range cfg.Tasks.DefaultTags {
+		if len(cfg.Tasks.AllowedTags) > 0 && !slices.Contains(cfg.Tasks.AllowedTags, tag) {
+			add("tasks.default_tags: %q is not in tasks.allowed_tags", tag)
+		}
+	}
+	for category := range cfg.Plans.CategorySections {
+		if !slices.Contains(cfg.Plans.AllowedCategories(), category) {
+			add("plans.category_sections: %q is not in plans.categories", category)
+		}
+	}
+	for name, t := range cfg.Templates {
+		if len(t.Sections) == 0 {
+			add("templates.%s: sections must not be empty", name)
+		}
+		for i, sec := range t.Sections {
+			if strings.TrimSpace(sec.Name) == "" {
+				add("templates.%s.sections[%d]: name must not be empty", name, i)
+			}
+		}
+	}
+	for key, name := range map[string]string{
+		"plans.excerpt_section":      cfg.Plans.ExcerptSection,
+		"tasks.excerpt_section":      cfg.Tasks.ExcerptSection,
+		"tasks.action_items_section": cfg.Tasks.ActionItemsSection,
+		"knowledge.excerpt_section":  cfg.Knowledge.ExcerptSection,
+	} {
+		if _, level, ok := markdown.ParseHeading(strings.TrimSpace(name)); ok {
+			add("%s: %q is written as a level-%d heading — give the heading text without the # markers", key, name, level)
+		}
+	}
+	for key, names := range sectionLists(cfg) {
+		for i, name := range names {
+			if _, level, ok := markdown.ParseHeading(strings.TrimSpace(name)); ok {
+				add("%s[%d]: %q is written as a level-%d heading — give the heading text without the # markers", key, i, name, level)
+			}
+		}
+	}
+	for i, p := range cfg.Plans.ExcerptStripPrefixes {
+		if _, err := regexp.Compile(p); err != nil {
+			add("plans.excerpt_strip_prefixes[%d]: %v", i, err)
+		}
+	}
+	for i, p := range cfg.Tasks.ExcerptStripPrefixes {
+		if _, err := regexp.Compile(p); err != nil {
+			add("tasks.excerpt_strip_prefixes[%d]: %v", i, err)
+		}
+	}
+	for i, p := range cfg.Privacy.FilterPatterns {
+		if _, err := regexp.Compile(p); err != nil {
+			add("privacy.filter_patterns[%d]: %v", i, err)
+		}
+	}
+	seenPatterns := map[string]bool{}
+	for i, p := range cfg.Privacy.Patterns {
+		switch {
+		case strings.TrimSpace(p.Name) == "":
+			add("privacy.patterns[%d]: name must not be empty", i)
+		case seenPatterns[p.Name]:
+			add("privacy.patterns[%d]: duplicate name %q", i, p.Name)
+		}
+		seenPatterns[p.Name] = true
+		if _, err := regexp.Compile(p.Pattern); err != nil {
+			add("privacy.patterns[%d]: %v", i, err)
+		}
+	}
+	jobNames := map[string]bool{}
+	for i, j := range cfg.Watch.Jobs {
+		switch {
+		case strings.TrimSpace(j.Name) == "":
+			add("watch.jobs[%d]: name must not be empty", i)
+		case jobNames[j.Name]:
+			add("watch.jobs[%d]: duplicate name %q", i, j.Name)
+		}
+		jobNames[j.Name] = true
+		if _, err := schedule.Parse(j.Schedule); err != nil {
+			add("watch.jobs[%d].schedule: %v", i, err)
+		}
+		if strings.TrimSpace(j.Command) == "" {
+			add("watch.jobs[%d].command: must not be empty", i)
+		}
+	}
+	if m := cfg.Privacy.Mode; m != "" && m != PrivacyWarn && m != PrivacyRedact && m != PrivacyBlock {
+		add("privacy.mode: %q must be warn, redact, or block", m)
+	}
+	if l := cfg.Git.Auto; l != "" && !slices.Contains(GitLevels, l) {
+		add("git.auto: %q must be off, add, commit, or push", l)
+	}
+	if w := cfg.Git.Worktrees; w != "" && w != WorktreesAuto && w != WorktreesMain && w != WorktreesLocal {
+		add("git.worktrees: %q must be auto, main, or local", w)
+	}
+	for kind, tmpl := range cfg.Git.CommitMessages {
+		allowed, ok := commitPlaceholders[kind]
+		if !ok {
+			add("git.commit_messages: unknown key %q (want save, task_create, task_update, or task_done)", kind)
+			continue
+		}
+		for _, m := range commitPlaceholder.FindAllStringSubmatch(tmpl, -1) {
+			if !slices.Contains(allowed, m[1]) {
+				add("git.commit_messages.%s: unknown placeholder {{%s}} (use {{%s}})", kind, m[1], strings.Join(allowed, "}}, {{"))
+			}
+		}
+	}
+	slices.Sort(problems)
+	return problems
+}
+
+// sectionLists returns every list setting of cfg that names body sections,
+// keyed by its path in config.json.
+func sectionLists(cfg Config) map[string][]string {
+	lists := map[string][]string{
+		"plans.summary_sections":     cfg.Plans.SummarySections,
+		"plans.excerpt_fallback":     cfg.Plans.ExcerptFallback,
+		"plans.required_sections":    cfg.Plans.RequiredSections,
+		"tasks.summary_sections":     cfg.Tasks.SummarySections,
+		"tasks.excerpt_fallback":     cfg.Tasks.ExcerptFallback,
+		"tasks.required_sections":    cfg.Tasks.RequiredSections,
+		"knowledge.summary_sections": cfg.Knowledge.SummarySections,
+	}
+	for category, sections := range cfg.Plans.CategorySections {
+		lists["plans.category_sections."+category] = sections
+	}
+	for name, t := range cfg.Templates {
+		names := make([]string, len(t.Sections))
+		for i, sec := range t.Sections {
+			names[i] = sec.Name
+		}
+		lists["templates."+name+".sections"] = names
+	}
+	return lists
+}
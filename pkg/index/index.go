@@ -5,20 +5,27 @@
 package index
 
 import (
-	"bufio"
-	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
-	"strings"
 	"time"
 
+	"github.com/senna-lang/logosyncx/internal/filelock"
+	"github.com/senna-lang/logosyncx/internal/jsonl"
 	"github.com/senna-lang/logosyncx/pkg/plan"
 )
 
 const indexFileName = "index.jsonl"
 
+// SchemaVersion is the version of the index format written by this binary.
+// Bump it whenever Entry gains, drops, or reinterprets a field, so that
+// indexes written by older binaries are detected and rebuilt.
+const SchemaVersion = 1
+
+// Header is the first line of the index file.
+var Header = jsonl.Header{Schema: "logosyncx.index", Version: SchemaVersion}
+
 // Entry is a single row in the index file.
 // Fields mirror the plan frontmatter plus the excerpt and derived fields.
 type Entry struct {
@@ -28,12 +35,20 @@ type Entry struct {
 	Topic     string    `json:"topic"`
 	Tags      []string  `json:"tags"`
 	Agent     string    `json:"agent"`
+	Category  string    `json:"category,omitempty"`
 	Related   []string  `json:"related"`
 	DependsOn []string  `json:"depends_on"`
 	TasksDir  string    `json:"tasks_dir"`
 	Distilled bool      `json:"distilled"`
 	Blocked   bool      `json:"blocked"` // true if any DependsOn plan is not yet distilled
+	Pinned    bool      `json:"pinned,omitempty"`
+	Frozen    bool      `json:"frozen,omitempty"`
 	Excerpt   string    `json:"excerpt"`
+	Lang      string    `json:"lang,omitempty"` // detected language of the plan body, e.g. "en", "ja"
+	Branch    string    `json:"branch,omitempty"`
+	// Origin names the overlay root the entry was read from; empty for
+	// plans in the project itself. Never written to the index file.
+	Origin string `json:"origin,omitempty"`
 }
 
 // FilePath returns the absolute path to the index file under projectRoot.
@@ -83,73 +98,75 @@ func FromPlan(p plan.Plan, allPlans []plan.Plan) Entry {
 		Topic:     p.Topic,
 		Tags:      tags,
 		Agent:     p.Agent,
+		Category:  p.Category,
 		Related:   related,
 		DependsOn: dependsOn,
 		TasksDir:  p.TasksDir,
 		Distilled: p.Distilled,
+		Pinned:    p.Pinned,
+		Frozen:    p.Frozen,
 		Blocked:   blocked,
 		Excerpt:   p.Excerpt,
+		Lang:      p.Lang,
+		Branch:    p.Branch,
 	}
 }
 
 // ReadAll reads every entry from the index file under projectRoot.
 // If the file does not exist os.ErrNotExist is returned (unwrapped so callers
-// can use errors.Is).  Lines that are blank are silently skipped; a malformed
-// line causes ReadAll to return whatever it has collected so far plus an error.
+// can use errors.Is).  Lines that are blank are silently skipped.  Malformed
+// lines (e.g. a merge conflict marker) are skipped too: every well-formed
+// entry is returned along with an error wrapping a *jsonl.SkippedError that
+// lists them.  A file whose last line is incomplete (an interrupted write)
+// also returns an error wrapping jsonl.ErrPartial.
 func ReadAll(projectRoot string) ([]Entry, error) {
-	path := FilePath(projectRoot)
-	f, err := os.Open(path)
+	entries, err := jsonl.ReadTolerant[Entry](FilePath(projectRoot))
 	if err != nil {
 		if errors.Is(err, os.ErrNotExist) {
 			return nil, os.ErrNotExist
 		}
-		return nil, fmt.Errorf("open index: %w", err)
-	}
-	defer f.Close()
-
-	var entries []Entry
-	scanner := bufio.NewScanner(f)
-	lineNum := 0
-	for scanner.Scan() {
-		lineNum++
-		line := strings.TrimSpace(scanner.Text())
-		if line == "" {
-			continue
-		}
-		var e Entry
-		if err := json.Unmarshal([]byte(line), &e); err != nil {
-			return entries, fmt.Errorf("parse index line %d: %w", lineNum, err)
-		}
-		entries = append(entries, e)
-	}
-	if err := scanner.Err(); err != nil {
 		return entries, fmt.Errorf("read index: %w", err)
 	}
 	return entries, nil
 }
 
-// Append serialises e as a single JSON line and appends it to the index file
-// under projectRoot. The file and any missing parent directories are created
-// automatically.
-func Append(projectRoot string, e Entry) error {
-	path := FilePath(projectRoot)
-
-	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
-		return fmt.Errorf("create index directory: %w", err)
+// ReadPage is ReadAll for page p of the entries keep accepts, in the order
+// of cmp; total is the number of entries accepted (see jsonl.ReadPage).
+func ReadPage(projectRoot string, keep func(Entry) bool, cmp func(a, b Entry) int, p jsonl.Page) (entries []Entry, total int, err error) {
+	entries, total, err = jsonl.ReadPage(FilePath(projectRoot), keep, cmp, p)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, 0, os.ErrNotExist
+		}
+		return entries, total, fmt.Errorf("read index: %w", err)
 	}
+	return entries, total, nil
+}
 
-	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
-	if err != nil {
-		return fmt.Errorf("open index for append: %w", err)
+// CheckVersion reports whether the index file under projectRoot was written
+// with the current SchemaVersion. It returns an error wrapping
+// jsonl.ErrOldVersion for a file written by an older binary (including one
+// without a header), jsonl.ErrNewVersion for one written by a newer binary,
+// and nil when the versions match or there is no index yet.
+func CheckVersion(projectRoot string) error {
+	if err := jsonl.CheckHeader(FilePath(projectRoot), Header); err != nil {
+		return fmt.Errorf("index: %w", err)
 	}
-	defer f.Close()
+	return nil
+}
 
-	data, err := json.Marshal(e)
+// Append adds e as a single JSON line at the end of the index file under
+// projectRoot. The file and any missing parent directories are created
+// automatically. The index is locked for the duration of the write, and the
+// file is replaced atomically, so concurrent logos processes cannot
+// interleave or truncate lines.
+func Append(projectRoot string, e Entry) error {
+	path := FilePath(projectRoot)
+	err := filelock.With(path, filelock.DefaultTimeout, func() error {
+		return jsonl.AppendAtomicWithHeader(path, Header, e)
+	})
 	if err != nil {
-		return fmt.Errorf("marshal index entry: %w", err)
-	}
-	if _, err := fmt.Fprintf(f, "%s\n", data); err != nil {
-		return fmt.Errorf("write index entry: %w", err)
+		return fmt.Errorf("append index entry: %w", err)
 	}
 	return nil
 }
@@ -164,21 +181,38 @@ func Append(projectRoot string, e Entry) error {
 //
 // The first return value is the number of plans successfully indexed.
 func Rebuild(projectRoot string, excerptSection string) (int, error) {
-	path := FilePath(projectRoot)
+	return RebuildWithOptions(projectRoot, plan.ParseOptions{ExcerptSection: excerptSection})
+}
 
-	if err := os.WriteFile(path, []byte{}, 0o644); err != nil {
-		return 0, fmt.Errorf("create index: %w", err)
+// RebuildWithOptions is like Rebuild but parses plans with opts, so the
+// project's excerpt section and length limits apply. The new index is
+// written to a temp file and renamed into place, so an interrupted rebuild
+// leaves the previous index intact. The plans are scanned while the index
+// is locked, so of two concurrent rebuilds the later one sees every plan
+// the earlier one wrote.
+func RebuildWithOptions(projectRoot string, opts plan.ParseOptions) (int, error) {
+	path := FilePath(projectRoot)
+	var entries []Entry
+	var loadErr error
+	err := filelock.With(path, filelock.DefaultTimeout, func() error {
+		entries, loadErr = Build(projectRoot, opts)
+		return jsonl.WriteAtomicWithHeader(path, Header, entries)
+	})
+	if err != nil {
+		return 0, fmt.Errorf("write index: %w", err)
 	}
 
-	plans, loadErr := plan.LoadAllWithOptions(projectRoot, plan.ParseOptions{
-		ExcerptSection: excerptSection,
-	})
+	return len(entries), loadErr
+}
 
+// Build returns the entries Rebuild would write, without touching the index
+// file. Plans that fail to parse are skipped and reported in the error, as
+// with plan.LoadAll.
+func Build(projectRoot string, opts plan.ParseOptions) ([]Entry, error) {
+	plans, loadErr := plan.LoadAllWithOptions(projectRoot, opts)
+	entries := make([]Entry, 0, len(plans))
 	for _, p := range plans {
-		if err := Append(projectRoot, FromPlan(p, plans)); err != nil {
-			return 0, fmt.Errorf("append entry for %s: %w", p.Filename, err)
-		}
+		entries = append(entries, FromPlan(p, plans))
 	}
-
-	return len(plans), loadErr
+	return entries, loadErr
 }
@@ -3,11 +3,14 @@ package index
 
 import (
 	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"sync"
 	"testing"
 	"time"
 
+	"github.com/senna-lang/logosyncx/internal/jsonl"
 	"github.com/senna-lang/logosyncx/pkg/plan"
 )
 
@@ -355,6 +358,34 @@ func TestAppend_MultipleCallsAccumulate(t *testing.T) {
 	}
 }
 
+func TestAppend_ConcurrentWritersKeepEveryLine(t *testing.T) {
+	dir := setupProject(t)
+	const n = 20
+	var wg sync.WaitGroup
+	for i := range n {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			e := Entry{ID: fmt.Sprintf("c%02d", i), Topic: "concurrent", Tags: []string{}, Related: []string{}, DependsOn: []string{}, Date: time.Now()}
+			if err := Append(dir, e); err != nil {
+				t.Errorf("Append: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	entries, err := ReadAll(dir)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	if len(entries) != n {
+		t.Errorf("expected %d entries, got %d", n, len(entries))
+	}
+	if _, err := os.Stat(FilePath(dir) + ".lock"); !os.IsNotExist(err) {
+		t.Errorf("expected lock file to be removed, stat err = %v", err)
+	}
+}
+
 func TestAppend_PreservesExistingEntries(t *testing.T) {
 	dir := setupProject(t)
 	e1 := Entry{ID: "first", Topic: "first-topic", Tags: []string{}, Related: []string{}, DependsOn: []string{}, Date: time.Now()}
@@ -452,6 +483,35 @@ func TestRebuild_OverwritesExistingIndex(t *testing.T) {
 	}
 }
 
+func TestRebuildWithOptions_AppliesCJKLimitAndLang(t *testing.T) {
+	dir := setupProject(t)
+	date := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
+	en := makePlan("en1", "english-plan", []string{}, date)
+	ja := makePlan("ja1", "japanese-plan", []string{}, date)
+	ja.Body = "## Background\n認証フローを全面的に見直す。\n"
+	writePlanFile(t, dir, en)
+	writePlanFile(t, dir, ja)
+
+	opts := plan.ParseOptions{ExcerptSection: "Background", MaxRunes: 100, CJKMaxRunes: 4}
+	if _, err := RebuildWithOptions(dir, opts); err != nil {
+		t.Fatalf("RebuildWithOptions: %v", err)
+	}
+	entries, err := ReadAll(dir)
+	if err != nil {
+		t.Fatalf("ReadAll: %v", err)
+	}
+	got := map[string]Entry{}
+	for _, e := range entries {
+		got[e.ID] = e
+	}
+	if e := got["en1"]; e.Lang != "en" || e.Excerpt != "This is a test plan about english-plan." {
+		t.Errorf("en entry = lang %q excerpt %q", e.Lang, e.Excerpt)
+	}
+	if e := got["ja1"]; e.Lang != "ja" || e.Excerpt != "認証フロ…" {
+		t.Errorf("ja entry = lang %q excerpt %q", e.Lang, e.Excerpt)
+	}
+}
+
 func TestRebuild_PopulatesExcerpt(t *testing.T) {
 	dir := setupProject(t)
 	date := time.Date(2026, 3, 4, 10, 0, 0, 0, time.UTC)
@@ -496,3 +556,41 @@ func TestRebuild_NoPlansDir_ReturnsZero(t *testing.T) {
 		t.Errorf("expected 0 plans, got %d", n)
 	}
 }
+
+// --- schema version ----------------------------------------------------------
+
+func TestRebuild_WritesSchemaHeader(t *testing.T) {
+	dir := setupProject(t)
+	if _, err := Rebuild(dir, ""); err != nil {
+		t.Fatalf("Rebuild: %v", err)
+	}
+	if err := CheckVersion(dir); err != nil {
+		t.Errorf("CheckVersion after Rebuild: %v", err)
+	}
+}
+
+func TestCheckVersion_HeaderlessIndexIsOld(t *testing.T) {
+	dir := setupProject(t)
+	os.WriteFile(FilePath(dir), []byte(`{"id":"a1","topic":"legacy"}`+"\n"), 0o644)
+	if err := CheckVersion(dir); !errors.Is(err, jsonl.ErrOldVersion) {
+		t.Errorf("expected jsonl.ErrOldVersion, got %v", err)
+	}
+	entries, err := ReadAll(dir)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("ReadAll = %+v, %v; want the legacy entry", entries, err)
+	}
+}
+
+func TestAppend_NewFileStartsWithHeader(t *testing.T) {
+	dir := setupProject(t)
+	if err := Append(dir, Entry{ID: "a1", Topic: "first"}); err != nil {
+		t.Fatalf("Append: %v", err)
+	}
+	if err := CheckVersion(dir); err != nil {
+		t.Errorf("CheckVersion after Append: %v", err)
+	}
+	entries, err := ReadAll(dir)
+	if err != nil || len(entries) != 1 {
+		t.Errorf("ReadAll = %+v, %v; want one entry", entries, err)
+	}
+}
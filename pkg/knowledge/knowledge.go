@@ -10,12 +10,14 @@ import (
 	"bytes"
 	"crypto/rand"
 	"encoding/hex"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"time"
 
+	"github.com/senna-lang/logosyncx/internal/markdown"
 	"gopkg.in/yaml.v3"
 )
 
@@ -33,6 +35,8 @@ type Knowledge struct {
 	Tasks []string   `yaml:"tasks,omitempty"`
 	Tags  []string   `yaml:"tags"`
 	Body  string     `yaml:"-"`
+	// Filename is the file's name under knowledge/, set by LoadAll.
+	Filename string `yaml:"-"`
 }
 
 // KnowledgeDir returns the path to the knowledge directory under a project root.
@@ -72,7 +76,7 @@ func Write(projectRoot string, k Knowledge, sourceBlock string, templateSections
 	}
 
 	if k.Date == nil {
-		now := time.Now().UTC()
+		now := time.Now()
 		k.Date = &now
 	}
 
@@ -117,6 +121,76 @@ func Write(projectRoot string, k Knowledge, sourceBlock string, templateSections
 	return rel, nil
 }
 
+// Rewrite writes k back to its file (k.Filename, as set by LoadAll) with
+// its frontmatter re-encoded and its body unchanged.
+func Rewrite(projectRoot string, k Knowledge) (string, error) {
+	if k.Filename == "" {
+		return "", fmt.Errorf("rewrite knowledge %s: no filename", k.ID)
+	}
+	fm, err := yaml.Marshal(k)
+	if err != nil {
+		return "", fmt.Errorf("marshal frontmatter: %w", err)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(frontmatterSep + "\n")
+	buf.Write(fm)
+	buf.WriteString(frontmatterSep + "\n")
+	buf.WriteString(k.Body)
+
+	path := filepath.Join(KnowledgeDir(projectRoot), k.Filename)
+	if err := os.WriteFile(path, buf.Bytes(), 0o644); err != nil {
+		return "", fmt.Errorf("write knowledge file: %w", err)
+	}
+	return path, nil
+}
+
+// LoadAll reads every knowledge file under projectRoot/knowledge/. A missing
+// directory yields no knowledge. Files that cannot be parsed are skipped and
+// reported together in the returned error, alongside the ones that could.
+func LoadAll(projectRoot string) ([]Knowledge, error) {
+	dir := KnowledgeDir(projectRoot)
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	var out []Knowledge
+	var errs []string
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".md") {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", entry.Name(), err))
+			continue
+		}
+		fm, body, err := markdown.SplitFrontmatter(data)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", entry.Name(), err))
+			continue
+		}
+		var k Knowledge
+		if err := yaml.Unmarshal(fm, &k); err != nil {
+			errs = append(errs, fmt.Sprintf("%s: parse frontmatter: %v", entry.Name(), err))
+			continue
+		}
+		k.Body = string(body)
+		k.Filename = entry.Name()
+		out = append(out, k)
+	}
+
+	if len(errs) > 0 {
+		return out, fmt.Errorf("some knowledge files could not be parsed:\n  %s",
+			strings.Join(errs, "\n  "))
+	}
+	return out, nil
+}
+
 // generateID returns a new random 6-character lowercase hex string.
 func generateID() (string, error) {
 	b := make([]byte, 3)
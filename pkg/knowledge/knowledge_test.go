@@ -162,3 +162,40 @@ func TestWrite_RelativePathUnderKnowledgeDir(t *testing.T) {
 		t.Errorf("returned path %q should start with '.logosyncx/knowledge/'", rel)
 	}
 }
+
+// --- LoadAll -----------------------------------------------------------------
+
+func TestLoadAll_MissingDirIsEmpty(t *testing.T) {
+	got, err := LoadAll(t.TempDir())
+	if err != nil || len(got) != 0 {
+		t.Errorf("LoadAll = %v, %v; want none, nil", got, err)
+	}
+}
+
+func TestLoadAll_ReadsWrittenFiles(t *testing.T) {
+	root := t.TempDir()
+	date := time.Date(2026, 6, 10, 12, 0, 0, 0, time.UTC)
+	k := Knowledge{
+		Topic: "auth refactor",
+		Plan:  "20260601-auth-refactor.md",
+		Tasks: []string{"001-add-jwt"},
+		Date:  &date,
+	}
+	if _, err := Write(root, k, "source", "## Summary\n"); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(KnowledgeDir(root), "broken.md"), []byte("no frontmatter"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := LoadAll(root)
+	if err == nil || !strings.Contains(err.Error(), "broken.md") {
+		t.Errorf("LoadAll error = %v, want one naming broken.md", err)
+	}
+	if len(got) != 1 {
+		t.Fatalf("LoadAll returned %d files, want 1", len(got))
+	}
+	if got[0].Filename != "20260610-auth-refactor.md" || got[0].Plan != k.Plan || len(got[0].Tasks) != 1 || got[0].ID == "" {
+		t.Errorf("LoadAll = %+v", got[0])
+	}
+}
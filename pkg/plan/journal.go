@@ -0,0 +1,81 @@
+package plan
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+)
+
+// JournalTag is attached to every weekly journal plan so journals can be
+// filtered with logos ls --tag journal.
+const JournalTag = "journal"
+
+var isoWeekRe = regexp.MustCompile(`^(\d{4})-[Ww](\d{2})$`)
+
+// ISOWeek identifies a single ISO 8601 week.
+type ISOWeek struct {
+	Year int
+	Week int
+}
+
+// WeekOf returns the ISO week containing t.
+func WeekOf(t time.Time) ISOWeek {
+	y, w := t.ISOWeek()
+	return ISOWeek{Year: y, Week: w}
+}
+
+// ParseISOWeek parses a week in the form "2025-W12" (the "W" is
+// case-insensitive). The week must exist in the given ISO year.
+func ParseISOWeek(s string) (ISOWeek, error) {
+	m := isoWeekRe.FindStringSubmatch(s)
+	if m == nil {
+		return ISOWeek{}, fmt.Errorf("invalid week %q: expected YYYY-Www (e.g. 2025-W12)", s)
+	}
+	year, _ := strconv.Atoi(m[1])
+	week, _ := strconv.Atoi(m[2])
+	w := ISOWeek{Year: year, Week: week}
+	if week < 1 || WeekOf(w.Start()) != w {
+		return ISOWeek{}, fmt.Errorf("invalid week %q: %d has no week %d", s, year, week)
+	}
+	return w, nil
+}
+
+// String returns the week in "YYYY-Www" form.
+func (w ISOWeek) String() string {
+	return fmt.Sprintf("%04d-W%02d", w.Year, w.Week)
+}
+
+// Start returns the Monday (00:00 UTC) that begins the week.
+func (w ISOWeek) Start() time.Time {
+	// January 4th is always in ISO week 1.
+	jan4 := time.Date(w.Year, time.January, 4, 0, 0, 0, 0, time.UTC)
+	offset := (int(jan4.Weekday()) + 6) % 7 // days since Monday
+	return jan4.AddDate(0, 0, -offset+(w.Week-1)*7)
+}
+
+// JournalTopic returns the topic used for the week's journal plan,
+// e.g. "journal 2025-W12".
+func (w ISOWeek) JournalTopic() string {
+	return "journal " + w.String()
+}
+
+// JournalFileName returns the plan filename for the week's journal under
+// DefaultFilenamePattern. The date prefix is the Monday of the week, so every
+// day of the week maps to the same file: e.g. "20250317-journal-2025-w12.md".
+func (w ISOWeek) JournalFileName() string {
+	start := w.Start()
+	return FileName(Plan{Topic: w.JournalTopic(), Date: &start})
+}
+
+// FindJournal returns the journal plan for w among plans. Journals are
+// matched by topic rather than filename, so they are found under any
+// plans.filename_pattern.
+func FindJournal(plans []Plan, w ISOWeek) (Plan, bool) {
+	for _, p := range plans {
+		if p.Topic == w.JournalTopic() {
+			return p, true
+		}
+	}
+	return Plan{}, false
+}
@@ -0,0 +1,56 @@
+package plan
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseISOWeek_Valid(t *testing.T) {
+	for _, s := range []string{"2025-W12", "2025-w12", "2020-W53"} {
+		w, err := ParseISOWeek(s)
+		if err != nil {
+			t.Errorf("ParseISOWeek(%q): %v", s, err)
+			continue
+		}
+		if w.Year != 2025 && w.Year != 2020 {
+			t.Errorf("ParseISOWeek(%q).Year = %d", s, w.Year)
+		}
+	}
+}
+
+func TestParseISOWeek_Invalid(t *testing.T) {
+	for _, s := range []string{"", "2025-12", "2025-W1", "2025-W00", "2025-W53", "W12-2025"} {
+		if _, err := ParseISOWeek(s); err == nil {
+			t.Errorf("ParseISOWeek(%q): expected error, got nil", s)
+		}
+	}
+}
+
+func TestISOWeek_Start_IsMonday(t *testing.T) {
+	w := ISOWeek{Year: 2025, Week: 12}
+	want := time.Date(2025, 3, 17, 0, 0, 0, 0, time.UTC)
+	if got := w.Start(); !got.Equal(want) {
+		t.Errorf("Start() = %v, want %v", got, want)
+	}
+}
+
+func TestWeekOf_YearBoundary(t *testing.T) {
+	// 2024-12-30 belongs to ISO week 1 of 2025.
+	got := WeekOf(time.Date(2024, 12, 30, 12, 0, 0, 0, time.UTC))
+	if got != (ISOWeek{Year: 2025, Week: 1}) {
+		t.Errorf("WeekOf(2024-12-30) = %v, want 2025-W01", got)
+	}
+	if got.String() != "2025-W01" {
+		t.Errorf("String() = %q, want 2025-W01", got.String())
+	}
+}
+
+func TestISOWeek_JournalFileName_SameForWholeWeek(t *testing.T) {
+	want := "20250317-journal-2025-w12.md"
+	for d := 17; d <= 23; d++ {
+		w := WeekOf(time.Date(2025, 3, d, 9, 0, 0, 0, time.UTC))
+		if got := w.JournalFileName(); got != want {
+			t.Errorf("2025-03-%d: JournalFileName() = %q, want %q", d, got, want)
+		}
+	}
+}
@@ -10,11 +10,13 @@ package plan
 import (
 	"bytes"
 	"crypto/rand"
+	"crypto/sha256"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
+	"regexp"
 	"strings"
 	"time"
 
@@ -35,15 +37,39 @@ type Plan struct {
 	Topic     string     `yaml:"topic"`
 	Tags      []string   `yaml:"tags"`
 	Agent     string     `yaml:"agent"`
+	Category  string     `yaml:"category,omitempty"` // one of plans.categories, e.g. "incident"
 	Related   []string   `yaml:"related"`
 	DependsOn []string   `yaml:"depends_on,omitempty"` // plan filenames this plan depends on
 	TasksDir  string     `yaml:"tasks_dir"`
 	Distilled bool       `yaml:"distilled"`
+	// Pinned plans are always included in the agent context file written
+	// by logos agents render-context.
+	Pinned bool `yaml:"pinned,omitempty"`
+	// Attendees lists who took part, for meeting notes (logos meeting).
+	Attendees []string `yaml:"attendees,omitempty"`
+	// ClosedAt is when logos incident close resolved an incident plan.
+	ClosedAt *time.Time `yaml:"closed_at,omitempty"`
+	// RelatedTasks lists IDs of tasks in other plans linked to this plan,
+	// e.g. by logos sync --auto-link when either body mentions the other.
+	RelatedTasks []string `yaml:"related_tasks,omitempty"`
+	// Branch is the git branch the plan was saved on, when git.record_branch
+	// is set and it was not a trunk branch.
+	Branch string `yaml:"branch,omitempty"`
+	// Frozen marks a finalized record (set by logos freeze): commands that
+	// edit plans refuse to change it, and logos check reports a body that
+	// no longer matches FrozenSum.
+	Frozen    bool       `yaml:"frozen,omitempty"`
+	FrozenAt  *time.Time `yaml:"frozen_at,omitempty"`
+	FrozenSum string     `yaml:"frozen_sum,omitempty"` // BodySum of the body when frozen
 
 	// Derived fields (not written to frontmatter).
 	Filename string `yaml:"-"`
 	Excerpt  string `yaml:"-"`
+	Lang     string `yaml:"-"` // script-based language guess for the body (see markdown.DetectLanguage)
 	Body     string `yaml:"-"` // full markdown body (everything after frontmatter)
+	// Conflicts lists the file's line numbers holding git conflict markers
+	// (see markdown.ConflictMarkers). A conflicted plan gets no excerpt.
+	Conflicts []int `yaml:"-"`
 }
 
 // PlansDir returns the path to the plans directory under a project root.
@@ -56,14 +82,68 @@ func ArchiveDir(projectRoot string) string {
 	return filepath.Join(projectRoot, ".logosyncx", plansDirName, "archive")
 }
 
-// FileName returns the canonical filename for a plan: YYYYMMDD-<slug>.md.
-// If Date is nil, the current time is used as a fallback.
+// DefaultFilenamePattern is the plan filename pattern used when config
+// plans.filename_pattern is unset: YYYYMMDD-<slug>.md.
+const DefaultFilenamePattern = "{{date}}-{{slug}}.md"
+
+// patternPlaceholder matches a {{name}} placeholder in a filename pattern.
+var patternPlaceholder = regexp.MustCompile(`\{\{([^{}]*)\}\}`)
+
+// FileName returns the canonical filename for a plan under
+// DefaultFilenamePattern. If Date is nil, the current time is used as a
+// fallback.
 func FileName(p Plan) string {
+	return FileNameWithPattern(p, DefaultFilenamePattern)
+}
+
+// FileNameWithPattern returns the filename for p under pattern, replacing
+// {{date}} (YYYYMMDD; now when Date is nil), {{id}}, and {{slug}} (the
+// slugified topic). An empty pattern means DefaultFilenamePattern. The
+// pattern is assumed valid (see ValidateFilenamePattern).
+func FileNameWithPattern(p Plan, pattern string) string {
+	if pattern == "" {
+		pattern = DefaultFilenamePattern
+	}
 	t := time.Now()
 	if p.Date != nil {
 		t = *p.Date
 	}
-	return fmt.Sprintf("%s-%s.md", t.Format("20060102"), markdown.Slugify(p.Topic))
+	return strings.NewReplacer(
+		"{{date}}", t.Format("20060102"),
+		"{{id}}", p.ID,
+		"{{slug}}", markdown.Slugify(p.Topic),
+	).Replace(pattern)
+}
+
+// ValidateFilenamePattern reports why pattern cannot be used as a plan
+// filename pattern, or nil if it can. A pattern must end in ".md", must not
+// contain path separators, may only use the {{date}}, {{id}}, and {{slug}}
+// placeholders, and must include {{slug}} or {{id}} so that two plans saved
+// on the same day get different names. The empty pattern is valid (default).
+func ValidateFilenamePattern(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	if !strings.HasSuffix(pattern, ".md") {
+		return fmt.Errorf("filename pattern %q must end in .md", pattern)
+	}
+	if strings.ContainsAny(pattern, `/\`) {
+		return fmt.Errorf("filename pattern %q must not contain path separators", pattern)
+	}
+	unique := false
+	for _, m := range patternPlaceholder.FindAllStringSubmatch(pattern, -1) {
+		switch m[1] {
+		case "date":
+		case "id", "slug":
+			unique = true
+		default:
+			return fmt.Errorf("filename pattern %q: unknown placeholder {{%s}} (use {{date}}, {{id}}, {{slug}})", pattern, m[1])
+		}
+	}
+	if !unique {
+		return fmt.Errorf("filename pattern %q must include {{slug}} or {{id}}", pattern)
+	}
+	return nil
 }
 
 // DefaultTasksDir returns the default tasks_dir for a plan given its filename.
@@ -78,6 +158,26 @@ type ParseOptions struct {
 	// ExcerptSection is the heading name used to extract the excerpt.
 	// Defaults to "Background" when empty. Matched case-insensitively.
 	ExcerptSection string
+	// ExcerptFallback lists sections tried in order when ExcerptSection is
+	// missing or empty.
+	ExcerptFallback []string
+	// ExcerptSkipBody leaves the excerpt empty instead of falling back to
+	// the start of the body.
+	ExcerptSkipBody bool
+	// MaxRunes limits the excerpt length. 0 uses markdown.ExcerptMaxRunes.
+	MaxRunes int
+	// CJKMaxRunes, when > 0, replaces MaxRunes for excerpts detected as
+	// Chinese, Japanese, or Korean.
+	CJKMaxRunes int
+	// Strategy condenses the excerpt section before truncation (see
+	// markdown.NewExcerpter). nil keeps the whole section.
+	Strategy markdown.Excerpter
+	// CleanMarkdown removes links, images, and inline code markers from the
+	// excerpt (see markdown.CleanInline).
+	CleanMarkdown bool
+	// StripPrefixes are cut from the start of the excerpt (see
+	// markdown.CompilePrefixes).
+	StripPrefixes []*regexp.Regexp
 }
 
 // Parse reads a plan markdown file from data.
@@ -88,15 +188,16 @@ func Parse(filename string, data []byte) (Plan, error) {
 // ParseWithOptions is like Parse but accepts options to customise excerpt
 // extraction.
 func ParseWithOptions(filename string, data []byte, opts ParseOptions) (Plan, error) {
+	conflicts := markdown.ConflictMarkers(string(data))
 	fm, body, err := markdown.SplitFrontmatter(data)
 	if err != nil {
-		return Plan{}, fmt.Errorf("parse %s: %w", filename, err)
+		return Plan{}, fmt.Errorf("parse %s: %w%s", filename, err, markdown.ConflictHint(conflicts))
 	}
 
 	var p Plan
 	if err := yaml.Unmarshal(fm, &p); err != nil {
-		hint := ""
-		if bytes.Contains(fm, []byte("{{")) {
+		hint := markdown.ConflictHint(conflicts)
+		if hint == "" && bytes.Contains(fm, []byte("{{")) {
 			hint = " (hint: frontmatter contains '{{' — replace template placeholders before saving)"
 		}
 		return Plan{}, fmt.Errorf("parse frontmatter in %s: %w%s", filename, err, hint)
@@ -104,11 +205,25 @@ func ParseWithOptions(filename string, data []byte, opts ParseOptions) (Plan, er
 
 	p.Filename = filename
 	p.Body = string(body)
+	p.Lang = markdown.DetectLanguage(string(body))
+	if len(conflicts) > 0 {
+		p.Conflicts = conflicts
+		return p, nil
+	}
 	section := opts.ExcerptSection
 	if section == "" {
 		section = "Background"
 	}
-	p.Excerpt = markdown.ExtractExcerpt(body, section)
+	p.Excerpt = markdown.ExtractExcerptWithOptions(body, markdown.ExcerptOptions{
+		Section:       section,
+		Fallback:      opts.ExcerptFallback,
+		SkipBody:      opts.ExcerptSkipBody,
+		MaxRunes:      opts.MaxRunes,
+		CJKMaxRunes:   opts.CJKMaxRunes,
+		Strategy:      opts.Strategy,
+		Clean:         opts.CleanMarkdown,
+		StripPrefixes: opts.StripPrefixes,
+	})
 
 	return p, nil
 }
@@ -171,7 +286,8 @@ func LoadAllWithOptions(projectRoot string, opts ParseOptions) ([]Plan, error) {
 	return plans, nil
 }
 
-// Write creates a frontmatter scaffold for p under projectRoot/plans/.
+// Write creates a frontmatter scaffold for p under projectRoot/plans/, named
+// p.Filename or, when that is empty, FileName(p).
 // The plans directory is created if it does not exist.
 // Body is intentionally left empty — the agent fills it using the Write tool.
 // Returns the full path of the written file.
@@ -186,7 +302,11 @@ func Write(projectRoot string, p Plan) (string, error) {
 		return "", err
 	}
 
-	path := filepath.Join(dir, FileName(p))
+	filename := p.Filename
+	if filename == "" {
+		filename = FileName(p)
+	}
+	path := filepath.Join(dir, filename)
 	if err := os.WriteFile(path, data, 0o644); err != nil {
 		return "", err
 	}
@@ -216,6 +336,15 @@ func Marshal(p Plan) ([]byte, error) {
 	return buf.Bytes(), nil
 }
 
+// BodySum returns the SHA-256 of body as a hex string, ignoring leading and
+// trailing white space and line-ending style, so that rewriting a plan
+// without changing its text keeps the same sum.
+func BodySum(body string) string {
+	norm := strings.TrimSpace(strings.ReplaceAll(body, "\r\n", "\n"))
+	sum := sha256.Sum256([]byte(norm))
+	return hex.EncodeToString(sum[:])
+}
+
 // Archive moves the plan file identified by filename from plans/ to
 // plans/archive/. Returns the new absolute path of the archived file.
 func Archive(projectRoot, filename string) (string, error) {
@@ -232,6 +361,21 @@ func Archive(projectRoot, filename string) (string, error) {
 	return dst, nil
 }
 
+// Restore moves the plan file identified by filename from plans/archive/
+// back to plans/. It fails when an active plan has the same filename.
+// Returns the new absolute path of the restored file.
+func Restore(projectRoot, filename string) (string, error) {
+	src := filepath.Join(ArchiveDir(projectRoot), filename)
+	dst := filepath.Join(PlansDir(projectRoot), filename)
+	if _, err := os.Stat(dst); err == nil {
+		return "", fmt.Errorf("restore %s: an active plan with that filename exists", filename)
+	}
+	if err := os.Rename(src, dst); err != nil {
+		return "", fmt.Errorf("restore %s: %w", filename, err)
+	}
+	return dst, nil
+}
+
 // ExtractSections returns only the markdown sections whose headings match
 // the given list (case-insensitive). Used by `logos refer --summary`.
 func ExtractSections(body string, sectionNames []string) string {
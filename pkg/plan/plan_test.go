@@ -47,6 +47,36 @@ func TestFileName_NilDateUsesNow(t *testing.T) {
 	}
 }
 
+func TestFileNameWithPattern(t *testing.T) {
+	date := time.Date(2026, 1, 15, 0, 0, 0, 0, time.UTC)
+	p := Plan{ID: "a1b2c3", Topic: "Auth Refactor", Date: &date}
+	tests := []struct{ pattern, want string }{
+		{"", "20260115-auth-refactor.md"},
+		{"{{date}}_{{id}}_{{slug}}.md", "20260115_a1b2c3_auth-refactor.md"},
+		{"{{slug}}.md", "auth-refactor.md"},
+	}
+	for _, tt := range tests {
+		if got := FileNameWithPattern(p, tt.pattern); got != tt.want {
+			t.Errorf("FileNameWithPattern(%q) = %q, want %q", tt.pattern, got, tt.want)
+		}
+	}
+}
+
+func TestValidateFilenamePattern(t *testing.T) {
+	valid := []string{"", DefaultFilenamePattern, "{{date}}_{{id}}_{{slug}}.md", "{{id}}.md"}
+	for _, p := range valid {
+		if err := ValidateFilenamePattern(p); err != nil {
+			t.Errorf("ValidateFilenamePattern(%q) = %v, want nil", p, err)
+		}
+	}
+	invalid := []string{"{{date}}-{{slug}}", "{{date}}.md", "plans/{{slug}}.md", "{{date}}-{{title}}.md"}
+	for _, p := range invalid {
+		if err := ValidateFilenamePattern(p); err == nil {
+			t.Errorf("ValidateFilenamePattern(%q) = nil, want error", p)
+		}
+	}
+}
+
 // --- DefaultTasksDir ---------------------------------------------------------
 
 func TestDefaultTasksDir(t *testing.T) {
@@ -289,8 +319,62 @@ This should not appear in the excerpt.
 	}
 }
 
+func TestParse_ExcerptFallback(t *testing.T) {
+	raw := "---\nid: abc124\ntopic: fallback\n---\n\nStray intro.\n\n## Summary\n\nFrom the summary.\n"
+	p, err := ParseWithOptions("fallback.md", []byte(raw), ParseOptions{ExcerptFallback: []string{"Summary"}})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if p.Excerpt != "From the summary." {
+		t.Errorf("Excerpt = %q, want the Summary section", p.Excerpt)
+	}
+
+	raw = "---\nid: abc125\ntopic: no-sections\n---\n\nStray intro.\n"
+	p, err = ParseWithOptions("no-sections.md", []byte(raw), ParseOptions{ExcerptFallback: []string{"Summary"}, ExcerptSkipBody: true})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if p.Excerpt != "" {
+		t.Errorf("Excerpt = %q, want empty with ExcerptSkipBody", p.Excerpt)
+	}
+}
+
+func TestParse_ExcerptCleanup(t *testing.T) {
+	prefixes, _ := markdown.CompilePrefixes([]string{`This plan is about`})
+	raw := "---\nid: abc126\ntopic: noisy\n---\n\n## Background\n\nThis plan is about `logos sync` and [locking](docs/lock.md).\n"
+	p, err := ParseWithOptions("noisy.md", []byte(raw), ParseOptions{CleanMarkdown: true, StripPrefixes: prefixes})
+	if err != nil {
+		t.Fatalf("Parse failed: %v", err)
+	}
+	if p.Excerpt != "logos sync and locking." {
+		t.Errorf("Excerpt = %q, want the cleaned text without the prefix", p.Excerpt)
+	}
+}
+
 // --- LoadAll -----------------------------------------------------------------
 
+func TestParse_ConflictMarkersSuppressExcerpt(t *testing.T) {
+	data := []byte("---\nid: c1\ntopic: merged\n---\n\n## Background\n<<<<<<< HEAD\nours\n=======\ntheirs\n>>>>>>> main\n")
+	p, err := Parse("20260101-merged.md", data)
+	if err != nil {
+		t.Fatalf("Parse: %v", err)
+	}
+	if p.Excerpt != "" {
+		t.Errorf("expected no excerpt for a conflicted plan, got %q", p.Excerpt)
+	}
+	if len(p.Conflicts) != 3 || p.Conflicts[0] != 7 {
+		t.Errorf("expected conflict lines [7 9 11], got %v", p.Conflicts)
+	}
+}
+
+func TestParse_ConflictInFrontmatter_NamesLines(t *testing.T) {
+	data := []byte("---\nid: c2\n<<<<<<< HEAD\ntopic: a\n=======\ntopic: b\n>>>>>>> main\n---\n")
+	_, err := Parse("20260101-merged.md", data)
+	if err == nil || !strings.Contains(err.Error(), "conflict markers at lines 3, 5, 7") {
+		t.Errorf("expected conflict hint in error, got %v", err)
+	}
+}
+
 func TestLoadAll_ScansPlansDir(t *testing.T) {
 	dir := t.TempDir()
 	plansDir := filepath.Join(dir, ".logosyncx", "plans")
@@ -419,6 +503,34 @@ func TestArchive_MovesFile(t *testing.T) {
 	}
 }
 
+func TestRestore_MovesFileBack(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.MkdirAll(ArchiveDir(dir), 0o755); err != nil {
+		t.Fatal(err)
+	}
+	filename := "20260304-restore-me.md"
+	if err := os.WriteFile(filepath.Join(ArchiveDir(dir), filename), []byte("---\nid: x\ntopic: t\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	dst, err := Restore(dir, filename)
+	if err != nil {
+		t.Fatalf("Restore failed: %v", err)
+	}
+	if dst != filepath.Join(PlansDir(dir), filename) {
+		t.Errorf("restored to %s", dst)
+	}
+	if _, err := Archive(dir, filename); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(dst, []byte("---\nid: y\ntopic: t\n---\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Restore(dir, filename); err == nil {
+		t.Error("expected an error when an active plan has the same filename")
+	}
+}
+
 // --- GenerateID --------------------------------------------------------------
 
 func TestGenerateID_Length(t *testing.T) {
@@ -464,3 +576,13 @@ func TestSlugify_Basic(t *testing.T) {
 		}
 	}
 }
+
+func TestBodySum_IgnoresSurroundingSpaceAndLineEndings(t *testing.T) {
+	sum := BodySum("## Decision\nUse Postgres.\n")
+	if BodySum("\n## Decision\r\nUse Postgres.\r\n\n") != sum {
+		t.Error("expected the same sum for the same text")
+	}
+	if BodySum("## Decision\nUse MySQL.\n") == sum {
+		t.Error("expected a different sum for a changed body")
+	}
+}
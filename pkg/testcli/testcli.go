@@ -0,0 +1,200 @@
+// Package testcli runs logos commands in-process against a throwaway
+// project, for integration tests of the CLI contract: the table and JSON
+// output that agents and plugins parse.
+//
+// A test creates a Project with New, writes fixture files with WriteFile,
+// runs commands with Run or MustRun, and compares the output with a golden
+// file under testdata/ with Golden:
+//
+//	p := testcli.New(t)
+//	p.WriteFile(".logosyncx/plans/20260101-auth.md", planMD)
+//	p.MustRun("sync")
+//	testcli.Golden(t, "ls.json", p.MustRun("ls", "--json"))
+//
+// Golden rewrites the files instead when Update is set. testcli registers no
+// flags of its own; a test package that wants a switch declares one and
+// points it at Update:
+//
+//	func init() {
+//		flag.BoolVar(&testcli.Update, "update-golden", false, "rewrite golden files")
+//	}
+//
+// Then run `go test -update-golden` after a deliberate output change, and
+// review the diff like any other change.
+//
+// Commands share process-wide state (the working directory, os.Stdout,
+// os.Stderr, environment variables), so tests using testcli must not call
+// t.Parallel.
+package testcli
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/senna-lang/logosyncx/cmd"
+)
+
+// Update makes Golden rewrite golden files with the current output instead
+// of comparing against them.
+var Update bool
+
+// testdataDir is the testdata directory of the package under test. It is
+// resolved at startup because New changes the working directory.
+var testdataDir = func() string {
+	wd, _ := os.Getwd()
+	return filepath.Join(wd, "testdata")
+}()
+
+// RootPlaceholder replaces the project root in captured output, so that
+// golden files do not depend on the temporary directory.
+const RootPlaceholder = "$ROOT"
+
+// Project is an initialised logos project in a temporary directory that is
+// the working directory for the rest of the test.
+type Project struct {
+	// Root is the project directory.
+	Root string
+	// Stdin is fed to the next command run; empty means no input.
+	Stdin string
+
+	t testing.TB
+}
+
+// Result is the outcome of one command.
+type Result struct {
+	Stdout string
+	Stderr string
+	Err    error
+}
+
+// New creates a temporary project, changes into it, and runs logos init.
+// HOME points to another temporary directory so that user-level config and
+// caches are not touched, the update check is disabled, tables are fitted
+// to 120 columns, and times are shown in UTC.
+func New(t testing.TB) *Project {
+	t.Helper()
+	root, err := filepath.EvalSymlinks(t.TempDir())
+	if err != nil {
+		t.Fatalf("testcli: %v", err)
+	}
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(home, ".config"))
+	t.Setenv("LOGOS_NO_UPDATE_CHECK", "1")
+	t.Setenv("COLUMNS", "120")
+	t.Chdir(root)
+	local := time.Local
+	time.Local = time.UTC
+	t.Cleanup(func() { time.Local = local })
+
+	p := &Project{Root: root, t: t}
+	p.MustRun("init")
+	return p
+}
+
+// Run executes args (without the program name) as a logos command line and
+// returns what it wrote. Occurrences of the project root in the output are
+// replaced by RootPlaceholder.
+func (p *Project) Run(args ...string) Result {
+	p.t.Helper()
+	stdin, stdout, stderr := os.Stdin, os.Stdout, os.Stderr
+	defer func() { os.Stdin, os.Stdout, os.Stderr = stdin, stdout, stderr }()
+
+	inR, inW := p.pipe()
+	outR, outW := p.pipe()
+	errR, errW := p.pipe()
+	go func() {
+		_, _ = io.WriteString(inW, p.Stdin)
+		inW.Close()
+	}()
+	outC, errC := drain(outR), drain(errR)
+
+	os.Stdin, os.Stdout, os.Stderr = inR, outW, errW
+	err := cmd.Run(args)
+	os.Stdin, os.Stdout, os.Stderr = stdin, stdout, stderr
+	outW.Close()
+	errW.Close()
+	inR.Close()
+	p.Stdin = ""
+
+	return Result{
+		Stdout: strings.ReplaceAll(<-outC, p.Root, RootPlaceholder),
+		Stderr: strings.ReplaceAll(<-errC, p.Root, RootPlaceholder),
+		Err:    err,
+	}
+}
+
+// MustRun is like Run but fails the test when the command returns an
+// error. It returns the command's stdout.
+func (p *Project) MustRun(args ...string) string {
+	p.t.Helper()
+	r := p.Run(args...)
+	if r.Err != nil {
+		p.t.Fatalf("logos %s: %v\nstderr:\n%s", strings.Join(args, " "), r.Err, r.Stderr)
+	}
+	return r.Stdout
+}
+
+// WriteFile writes content to rel, a path relative to the project root,
+// creating missing directories.
+func (p *Project) WriteFile(rel, content string) {
+	p.t.Helper()
+	path := filepath.Join(p.Root, rel)
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		p.t.Fatalf("testcli: %v", err)
+	}
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		p.t.Fatalf("testcli: %v", err)
+	}
+}
+
+func (p *Project) pipe() (*os.File, *os.File) {
+	p.t.Helper()
+	r, w, err := os.Pipe()
+	if err != nil {
+		p.t.Fatalf("testcli: %v", err)
+	}
+	return r, w
+}
+
+// drain reads r to the end in the background, so a command writing more
+// than a pipe buffer does not block.
+func drain(r *os.File) <-chan string {
+	c := make(chan string, 1)
+	go func() {
+		var buf bytes.Buffer
+		_, _ = buf.ReadFrom(r)
+		r.Close()
+		c <- buf.String()
+	}()
+	return c
+}
+
+// Golden compares got with testdata/<name>.golden in the package under
+// test and fails the test on a difference. With Update set the file is
+// (re)written instead.
+func Golden(t testing.TB, name, got string) {
+	t.Helper()
+	path := filepath.Join(testdataDir, name+".golden")
+	if Update {
+		if err := os.MkdirAll(testdataDir, 0o755); err != nil {
+			t.Fatalf("testcli: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(got), 0o644); err != nil {
+			t.Fatalf("testcli: %v", err)
+		}
+		return
+	}
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("testcli: %v (run go test -update-golden to create it)", err)
+	}
+	if got != string(want) {
+		t.Errorf("output differs from %s (run go test -update-golden after a deliberate change)\n--- want\n%s\n--- got\n%s", path, want, got)
+	}
+}
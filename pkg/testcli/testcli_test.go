@@ -0,0 +1,144 @@
+package testcli
+
+import (
+	"flag"
+	"strings"
+	"testing"
+)
+
+func init() {
+	flag.BoolVar(&Update, "update-golden", false, "Rewrite testcli golden files with the current output")
+}
+
+const fixturePlan = `---
+id: a1b2c3
+date: 2026-01-15T10:00:00Z
+topic: Auth design
+tags:
+    - auth
+    - security
+agent: claude-code
+related: []
+tasks_dir: .logosyncx/tasks/20260115-auth-design
+distilled: false
+---
+
+## Background
+
+Sessions are stored in cookies; we want stateless tokens.
+
+## Spec
+
+Issue JWTs from the login endpoint.
+`
+
+const fixtureOtherPlan = `---
+id: d4e5f6
+date: 2026-01-10T09:00:00Z
+topic: Cache layer
+tags:
+    - perf
+agent: ""
+related: []
+tasks_dir: .logosyncx/tasks/20260110-cache-layer
+distilled: false
+---
+
+## Background
+
+Reads dominate; add a cache in front of the database.
+`
+
+const fixtureTask = `---
+id: t-aaa111
+date: 2026-01-15T11:00:00Z
+title: Add JWT middleware
+seq: 1
+status: in_progress
+priority: high
+plan: 20260115-auth-design
+tags:
+    - backend
+assignee: ""
+---
+
+## What
+
+Validate the bearer token on every request.
+`
+
+const fixtureDoneTask = `---
+id: t-bbb222
+date: 2026-01-15T12:00:00Z
+title: Write login endpoint
+seq: 2
+status: done
+priority: medium
+plan: 20260115-auth-design
+tags: []
+assignee: ""
+completed_at: 2026-01-16T08:00:00Z
+---
+
+## What
+
+POST /login returns a signed token.
+`
+
+// newFixtureProject returns a project holding two plans and two tasks with
+// fixed IDs and dates, with both indexes built.
+func newFixtureProject(t *testing.T) *Project {
+	t.Helper()
+	p := New(t)
+	p.WriteFile(".logosyncx/plans/20260115-auth-design.md", fixturePlan)
+	p.WriteFile(".logosyncx/plans/20260110-cache-layer.md", fixtureOtherPlan)
+	p.WriteFile(".logosyncx/tasks/20260115-auth-design/001-add-jwt-middleware/TASK.md", fixtureTask)
+	p.WriteFile(".logosyncx/tasks/20260115-auth-design/002-write-login-endpoint/TASK.md", fixtureDoneTask)
+	p.MustRun("sync")
+	return p
+}
+
+func TestGolden(t *testing.T) {
+	p := newFixtureProject(t)
+	for _, tc := range []struct {
+		name string
+		args []string
+	}{
+		{"ls", []string{"ls"}},
+		{"ls.json", []string{"ls", "--json"}},
+		{"task-ls", []string{"task", "ls", "--all"}},
+		{"task-ls.json", []string{"task", "ls", "--all", "--json"}},
+		{"refer-summary", []string{"refer", "--name", "auth", "--summary"}},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			Golden(t, tc.name, p.MustRun(tc.args...))
+		})
+	}
+}
+
+func TestRun_ResetsFlagsBetweenCommands(t *testing.T) {
+	p := newFixtureProject(t)
+	if out := p.MustRun("ls", "--tag", "perf", "--json"); strings.Contains(out, "Auth design") {
+		t.Fatalf("--tag perf should hide the auth plan, got %s", out)
+	}
+	if out := p.MustRun("ls", "--json"); !strings.Contains(out, "Auth design") {
+		t.Errorf("--tag from the previous run leaked into this one, got %s", out)
+	}
+}
+
+func TestRun_ReturnsCommandError(t *testing.T) {
+	p := New(t)
+	r := p.Run("refer", "--name", "missing")
+	if r.Err == nil {
+		t.Fatalf("expected an error, got stdout %q", r.Stdout)
+	}
+}
+
+func TestRun_ReplacesRoot(t *testing.T) {
+	p := New(t)
+	p.WriteFile("notes/a.md", "# Imported note\n\nBody.\n")
+	out := p.MustRun("import", p.Root+"/notes", "--dry-run")
+	if strings.Contains(out, p.Root) {
+		t.Errorf("project root not replaced in %q", out)
+	}
+}